@@ -12,12 +12,17 @@ import (
 	"github.com/alicebob/miniredis"
 	"github.com/spf13/cobra"
 
+	"storj.io/storj/pkg/accounting"
+	"storj.io/storj/pkg/accounting/rollup"
+	"storj.io/storj/pkg/accounting/tally"
 	"storj.io/storj/pkg/audit"
 	"storj.io/storj/pkg/auth/grpcauth"
 	"storj.io/storj/pkg/bwagreement"
 	"storj.io/storj/pkg/cfgstruct"
 	"storj.io/storj/pkg/datarepair/checker"
 	"storj.io/storj/pkg/datarepair/repairer"
+	"storj.io/storj/pkg/gc"
+	"storj.io/storj/pkg/gracefulexit"
 	"storj.io/storj/pkg/kademlia"
 	"storj.io/storj/pkg/miniogw"
 	"storj.io/storj/pkg/overlay"
@@ -28,6 +33,7 @@ import (
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/satellite/satelliteweb"
 	"storj.io/storj/pkg/statdb"
+	"storj.io/storj/pkg/uplinkdb"
 	"storj.io/storj/pkg/utils"
 )
 
@@ -39,14 +45,20 @@ const (
 type Satellite struct {
 	Identity    provider.IdentityConfig
 	Kademlia    kademlia.Config
+	UplinkDB    uplinkdb.Config
 	PointerDB   pointerdb.Config
 	Overlay     overlay.Config
+	Accounting  accounting.Config
+	Tally       tally.Config
 	Checker     checker.Config
 	Repairer    repairer.Config
 	Audit       audit.Config
 	StatDB      statdb.Config
 	BwAgreement bwagreement.Config
+	Rollup      rollup.Config
 	Web         satelliteweb.Config
+	GC          gc.Config
+	Exit        gracefulexit.Config
 	MockOverlay struct {
 		Enabled bool   `default:"true" help:"if false, use real overlay"`
 		Host    string `default:"" help:"if set, the mock overlay will return storage nodes with this host"`
@@ -136,6 +148,14 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 			runCfg.Satellite.Audit.SatelliteAddr = runCfg.Satellite.Identity.Address
 		}
 
+		if runCfg.Satellite.GC.SatelliteAddr == "" {
+			runCfg.Satellite.GC.SatelliteAddr = runCfg.Satellite.Identity.Address
+		}
+
+		if runCfg.Satellite.Exit.SatelliteAddr == "" {
+			runCfg.Satellite.Exit.SatelliteAddr = runCfg.Satellite.Identity.Address
+		}
+
 		if runCfg.Satellite.Web.SatelliteAddr == "" {
 			runCfg.Satellite.Web.SatelliteAddr = runCfg.Satellite.Identity.Address
 		}
@@ -143,16 +163,23 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 		// Run satellite
 		errch <- runCfg.Satellite.Identity.Run(ctx,
 			grpcauth.NewAPIKeyInterceptor(),
+			runCfg.Satellite.UplinkDB,
+			runCfg.Satellite.Accounting,
+			// pointerdb limits egress with the bandwidth agreements
+			runCfg.Satellite.BwAgreement,
 			runCfg.Satellite.PointerDB,
 			runCfg.Satellite.Kademlia,
 			runCfg.Satellite.Audit,
 			runCfg.Satellite.StatDB,
 			o,
+			runCfg.Satellite.Tally,
 			// TODO(coyle): re-enable the checker after we determine why it is panicing
 			// runCfg.Satellite.Checker,
 			runCfg.Satellite.Repairer,
-			runCfg.Satellite.BwAgreement,
+			runCfg.Satellite.Rollup,
 			runCfg.Satellite.Web,
+			runCfg.Satellite.GC,
+			runCfg.Satellite.Exit,
 		)
 	}()
 
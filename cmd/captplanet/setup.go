@@ -146,6 +146,10 @@ func cmdSetup(cmd *cobra.Command, args []string) (err error) {
 			setupCfg.BasePath, "satellite", "pointerdb.db"),
 		"satellite.overlay.database-url": "bolt://" + filepath.Join(
 			setupCfg.BasePath, "satellite", "overlay.db"),
+		"satellite.exit.database-url": "bolt://" + filepath.Join(
+			setupCfg.BasePath, "satellite", "gracefulexit.db"),
+		"satellite.uplink-db.database-url": "bolt://" + filepath.Join(
+			setupCfg.BasePath, "satellite", "uplinkdb.db"),
 		"satellite.repairer.queue-address": "redis://127.0.0.1:6378?db=1&password=abc123",
 		"satellite.repairer.overlay-addr":  overlayAddr,
 		"satellite.repairer.pointer-db-addr": joinHostPort(
@@ -11,16 +11,23 @@ import (
 	"path/filepath"
 	"sort"
 	"text/tabwriter"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/spf13/cobra"
 	"github.com/zeebo/errs"
 
+	"storj.io/storj/pkg/accounting"
+	"storj.io/storj/pkg/accounting/rollup"
+	"storj.io/storj/pkg/accounting/tally"
+	"storj.io/storj/pkg/audit"
 	"storj.io/storj/pkg/auth/grpcauth"
 	"storj.io/storj/pkg/bwagreement"
 	dbmanager "storj.io/storj/pkg/bwagreement/database-manager"
 	"storj.io/storj/pkg/cfgstruct"
 	"storj.io/storj/pkg/datarepair/queue"
+	"storj.io/storj/pkg/gc"
+	"storj.io/storj/pkg/gracefulexit"
 	"storj.io/storj/pkg/kademlia"
 	"storj.io/storj/pkg/overlay"
 	mockOverlay "storj.io/storj/pkg/overlay/mocks"
@@ -29,6 +36,8 @@ import (
 	"storj.io/storj/pkg/process"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/statdb"
+	"storj.io/storj/pkg/uplinkdb"
+	"storj.io/storj/pkg/utils"
 	"storj.io/storj/storage/redis"
 )
 
@@ -57,19 +66,35 @@ var (
 		Short: "Repair Queue Diagnostic Tool support",
 		RunE:  cmdQDiag,
 	}
+	payoutCmd = &cobra.Command{
+		Use:   "payout",
+		Short: "Export the storage node payout report for a date range",
+		RunE:  cmdPayout,
+	}
+	auditsCmd = &cobra.Command{
+		Use:   "audits",
+		Short: "List the recent audits of the storage nodes",
+		RunE:  cmdAudits,
+	}
 
 	runCfg struct {
 		Identity    provider.IdentityConfig
 		Kademlia    kademlia.Config
+		UplinkDB    uplinkdb.Config
 		PointerDB   pointerdb.Config
 		Overlay     overlay.Config
 		MockOverlay mockOverlay.Config
+		Accounting  accounting.Config
+		Tally       tally.Config
 		StatDB      statdb.Config
 		// RepairQueue   queue.Config
 		// RepairChecker checker.Config
 		// Repairer      repairer.Config
 		// Audit audit.Config
 		BwAgreement bwagreement.Config
+		Rollup      rollup.Config
+		GC          gc.Config
+		Exit        gracefulexit.Config
 	}
 	setupCfg struct {
 		BasePath  string `default:"$CONFDIR" help:"base path for setup"`
@@ -84,6 +109,18 @@ var (
 		DatabaseURL string `help:"the database connection string to use" default:"redis://127.0.0.1:6378?db=1&password=abc123"`
 		QListLimit  int    `help:"maximum segments that can be requested" default:"1000"`
 	}
+	payoutCfg struct {
+		DatabaseURL string `help:"the database connection string to use" default:"sqlite3://$CONFDIR/accounting.db"`
+		From        string `help:"first day of the report as YYYY-MM-DD, defaults to the first day of this month" default:""`
+		To          string `help:"last day of the report as YYYY-MM-DD, defaults to today" default:""`
+		Format      string `help:"output format of the report, csv or json" default:"csv"`
+	}
+	auditsCfg struct {
+		DatabaseURL string        `help:"the database connection string to use" default:"sqlite3://$CONFDIR/audit.db"`
+		Node        string        `help:"id of the node whose audits are listed, all nodes if empty" default:""`
+		Since       time.Duration `help:"how far back audits are listed" default:"24h"`
+		Limit       int           `help:"maximum number of audits listed" default:"100"`
+	}
 
 	defaultConfDir = "$HOME/.storj/satellite"
 )
@@ -93,10 +130,14 @@ func init() {
 	rootCmd.AddCommand(setupCmd)
 	rootCmd.AddCommand(diagCmd)
 	rootCmd.AddCommand(qdiagCmd)
+	rootCmd.AddCommand(payoutCmd)
+	rootCmd.AddCommand(auditsCmd)
 	cfgstruct.Bind(runCmd.Flags(), &runCfg, cfgstruct.ConfDir(defaultConfDir))
 	cfgstruct.Bind(setupCmd.Flags(), &setupCfg, cfgstruct.ConfDir(defaultConfDir))
 	cfgstruct.Bind(diagCmd.Flags(), &diagCfg, cfgstruct.ConfDir(defaultConfDir))
 	cfgstruct.Bind(qdiagCmd.Flags(), &qdiagCfg, cfgstruct.ConfDir(defaultConfDir))
+	cfgstruct.Bind(payoutCmd.Flags(), &payoutCfg, cfgstruct.ConfDir(defaultConfDir))
+	cfgstruct.Bind(auditsCmd.Flags(), &auditsCfg, cfgstruct.ConfDir(defaultConfDir))
 }
 
 func cmdRun(cmd *cobra.Command, args []string) (err error) {
@@ -104,15 +145,28 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 	if runCfg.MockOverlay.Nodes != "" {
 		o = runCfg.MockOverlay
 	}
+	if runCfg.GC.SatelliteAddr == "" {
+		runCfg.GC.SatelliteAddr = runCfg.Identity.Address
+	}
+	if runCfg.Exit.SatelliteAddr == "" {
+		runCfg.Exit.SatelliteAddr = runCfg.Identity.Address
+	}
 	return runCfg.Identity.Run(
 		process.Ctx(cmd),
 		grpcauth.NewAPIKeyInterceptor(),
 		runCfg.Kademlia,
+		runCfg.UplinkDB,
+		runCfg.Accounting,
+		// pointerdb limits egress with the bandwidth agreements
+		runCfg.BwAgreement,
 		runCfg.PointerDB,
 		o,
+		runCfg.Tally,
 		runCfg.StatDB,
 		// runCfg.Audit,
-		runCfg.BwAgreement,
+		runCfg.Rollup,
+		runCfg.GC,
+		runCfg.Exit,
 	)
 }
 
@@ -183,6 +237,8 @@ func cmdDiag(cmd *cobra.Command, args []string) (err error) {
 	// Agreement is a struct that contains a bandwidth agreement and the associated signature
 	type UplinkSummary struct {
 		TotalBytes        int64
+		PutBytes          int64
+		GetBytes          int64
 		PutActionCount    int64
 		GetActionCount    int64
 		TotalTransactions int64
@@ -217,9 +273,11 @@ func cmdDiag(cmd *cobra.Command, args []string) (err error) {
 		// fill the summary info
 		summary.TotalBytes += rbad.GetTotal()
 		summary.TotalTransactions++
-		if pbad.GetAction() == pb.PayerBandwidthAllocation_PUT {
+		if pbad.GetAction().IsPut() {
+			summary.PutBytes += rbad.GetTotal()
 			summary.PutActionCount++
 		} else {
+			summary.GetBytes += rbad.GetTotal()
 			summary.GetActionCount++
 		}
 	}
@@ -227,17 +285,28 @@ func cmdDiag(cmd *cobra.Command, args []string) (err error) {
 	// initialize the table header (fields)
 	const padding = 3
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, padding, ' ', tabwriter.AlignRight|tabwriter.Debug)
-	fmt.Fprintln(w, "UplinkID\tTotal\t# Of Transactions\tPUT Action\tGET Action\t")
+	fmt.Fprintln(w, "UplinkID\tTotal\tPUT Bytes\tGET Bytes\t# Of Transactions\tPUT Action\tGET Action\t")
 
 	// populate the row fields
 	sort.Strings(uplinkIDs)
 	for _, uplinkID := range uplinkIDs {
 		summary := summaries[uplinkID]
-		fmt.Fprint(w, uplinkID, "\t", summary.TotalBytes, "\t", summary.TotalTransactions, "\t", summary.PutActionCount, "\t", summary.GetActionCount, "\t\n")
+		fmt.Fprint(w, uplinkID, "\t", summary.TotalBytes, "\t", summary.PutBytes, "\t", summary.GetBytes, "\t", summary.TotalTransactions, "\t", summary.PutActionCount, "\t", summary.GetActionCount, "\t\n")
 	}
 
 	// display the data
-	return w.Flush()
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// uploads and downloads are paid differently
+	now := time.Now()
+	totals, err := dbm.GetTotalsByAction(context.Background(), time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()), now)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nThis month: %d PUT bytes, %d GET bytes\n", totals[pb.PayerBandwidthAllocation_PUT], totals[pb.PayerBandwidthAllocation_GET])
+	return nil
 }
 
 func cmdQDiag(cmd *cobra.Command, args []string) (err error) {
@@ -258,11 +327,79 @@ func cmdQDiag(cmd *cobra.Command, args []string) (err error) {
 	// initialize the table header (fields)
 	const padding = 3
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, padding, ' ', tabwriter.AlignRight|tabwriter.Debug)
-	fmt.Fprintln(w, "Path\tLost Pieces\t")
+	fmt.Fprintln(w, "Path\tLost Pieces\tHealthy\tRequired\t")
 
-	// populate the row fields
+	// populate the row fields, the most urgent repairs come first
 	for _, v := range list {
-		fmt.Fprint(w, v.GetPath(), "\t", v.GetLostPieces(), "\t")
+		fmt.Fprint(w, v.GetPath(), "\t", v.GetLostPieces(), "\t", v.GetHealthyCount(), "\t", v.GetMinReq(), "\t\n")
+	}
+
+	// display the data
+	return w.Flush()
+}
+
+func cmdPayout(cmd *cobra.Command, args []string) (err error) {
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := now
+	if payoutCfg.From != "" {
+		from, err = time.Parse("2006-01-02", payoutCfg.From)
+		if err != nil {
+			return errs.New("Invalid from date: %+v", err)
+		}
+	}
+	if payoutCfg.To != "" {
+		to, err = time.Parse("2006-01-02", payoutCfg.To)
+		if err != nil {
+			return errs.New("Invalid to date: %+v", err)
+		}
+	}
+	if to.Before(from) {
+		return errs.New("to date %s is before from date %s", to.Format("2006-01-02"), from.Format("2006-01-02"))
+	}
+
+	db, err := accounting.Config{DatabaseURL: payoutCfg.DatabaseURL}.Open()
+	if err != nil {
+		return err
+	}
+	defer func() { err = utils.CombineErrors(err, db.Close()) }()
+
+	payouts, err := rollup.PayoutReport(process.Ctx(cmd), db, from, to)
+	if err != nil {
+		return err
+	}
+
+	switch payoutCfg.Format {
+	case "csv":
+		return rollup.WriteCSV(os.Stdout, payouts)
+	case "json":
+		return rollup.WriteJSON(os.Stdout, payouts)
+	default:
+		return errs.New("Invalid format %q, expected csv or json", payoutCfg.Format)
+	}
+}
+
+func cmdAudits(cmd *cobra.Command, args []string) (err error) {
+	db, err := audit.OpenHistoryDB(auditsCfg.DatabaseURL)
+	if err != nil {
+		return err
+	}
+	defer func() { err = utils.CombineErrors(err, db.Close()) }()
+
+	records, err := audit.NewHistory(db).Query(process.Ctx(cmd), auditsCfg.Node, time.Now().Add(-auditsCfg.Since), auditsCfg.Limit)
+	if err != nil {
+		return err
+	}
+
+	// initialize the table header (fields)
+	const padding = 3
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, padding, ' ', tabwriter.AlignRight|tabwriter.Debug)
+	fmt.Fprintln(w, "Time\tNodeID\tPath\tStripe\tOutcome\tError\tLatency\t")
+
+	// populate the row fields
+	for _, record := range records {
+		fmt.Fprint(w, record.Created.Format(time.RFC3339), "\t", record.NodeID, "\t", record.Path, "\t", record.StripeIndex, "\t",
+			record.Outcome, "\t", record.ErrorClass, "\t", record.Latency, "\t\n")
 	}
 
 	// display the data
@@ -5,14 +5,18 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"text/tabwriter"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
 
 	"storj.io/storj/pkg/cfgstruct"
 	"storj.io/storj/pkg/kademlia"
@@ -21,6 +25,9 @@ import (
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
 	"storj.io/storj/pkg/process"
 	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/utils"
+	"storj.io/storj/storage/filestore"
 )
 
 var (
@@ -43,6 +50,22 @@ var (
 		Short: "Diagnostic Tool support",
 		RunE:  cmdDiag,
 	}
+	exitCmd = &cobra.Command{
+		Use:   "exit <satellite-address>",
+		Short: "Gracefully exit from a satellite",
+		Args:  cobra.ExactArgs(1),
+		RunE:  cmdExit,
+	}
+	exitStatusCmd = &cobra.Command{
+		Use:   "exit-status",
+		Short: "Show the progress of graceful exits",
+		RunE:  cmdExitStatus,
+	}
+	checkCmd = &cobra.Command{
+		Use:   "check",
+		Short: "Compare the piece database against the pieces on disk",
+		RunE:  cmdCheck,
+	}
 
 	runCfg struct {
 		Identity provider.IdentityConfig
@@ -57,6 +80,13 @@ var (
 	diagCfg struct {
 		BasePath string `default:"$CONFDIR" help:"base path for setup"`
 	}
+	exitCfg struct {
+		Identity provider.IdentityConfig
+		Storage  psserver.Config
+	}
+	checkCfg struct {
+		Storage psserver.Config
+	}
 
 	defaultConfDir = "$HOME/.storj/storagenode"
 	defaultDiagDir = "$HOME/.storj/capt/f37/data"
@@ -66,9 +96,15 @@ func init() {
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(setupCmd)
 	rootCmd.AddCommand(diagCmd)
+	rootCmd.AddCommand(exitCmd)
+	rootCmd.AddCommand(exitStatusCmd)
+	rootCmd.AddCommand(checkCmd)
 	cfgstruct.Bind(runCmd.Flags(), &runCfg, cfgstruct.ConfDir(defaultConfDir))
 	cfgstruct.Bind(setupCmd.Flags(), &setupCfg, cfgstruct.ConfDir(defaultConfDir))
 	cfgstruct.Bind(diagCmd.Flags(), &diagCfg, cfgstruct.ConfDir(defaultDiagDir))
+	cfgstruct.Bind(exitCmd.Flags(), &exitCfg, cfgstruct.ConfDir(defaultConfDir))
+	cfgstruct.Bind(exitStatusCmd.Flags(), &exitCfg, cfgstruct.ConfDir(defaultConfDir))
+	cfgstruct.Bind(checkCmd.Flags(), &checkCfg, cfgstruct.ConfDir(defaultConfDir))
 }
 
 func cmdRun(cmd *cobra.Command, args []string) (err error) {
@@ -121,7 +157,7 @@ func cmdDiag(cmd *cobra.Command, args []string) (err error) {
 
 	// open the sql db
 	dbpath := filepath.Join(diagCfg.BasePath, "piecestore.db")
-	db, err := psdb.Open(context.Background(), "", dbpath)
+	db, err := psdb.Open(context.Background(), nil, dbpath)
 	if err != nil {
 		fmt.Println("Storagenode database couldnt open:", dbpath)
 		return err
@@ -137,6 +173,8 @@ func cmdDiag(cmd *cobra.Command, args []string) (err error) {
 	// Agreement is a struct that contains a bandwidth agreement and the associated signature
 	type SatelliteSummary struct {
 		TotalBytes        int64
+		PutBytes          int64
+		GetBytes          int64
 		PutActionCount    int64
 		GetActionCount    int64
 		TotalTransactions int64
@@ -149,6 +187,11 @@ func cmdDiag(cmd *cobra.Command, args []string) (err error) {
 
 	for _, rbaVal := range bwAgreements {
 		for _, rbaDataVal := range rbaVal {
+			// only the agreements still to be settled with the satellites are summed up
+			if rbaDataVal.Status != psdb.AgreementUnsent && rbaDataVal.Status != psdb.AgreementSent {
+				continue
+			}
+
 			// deserializing rbad you get payerbwallocation, total & storage node id
 			rbad := &pb.RenterBandwidthAllocation_Data{}
 			if err := proto.Unmarshal(rbaDataVal.Agreement, rbad); err != nil {
@@ -172,9 +215,11 @@ func cmdDiag(cmd *cobra.Command, args []string) (err error) {
 			// fill the summary info
 			summary.TotalBytes += rbad.GetTotal()
 			summary.TotalTransactions++
-			if pbad.GetAction() == pb.PayerBandwidthAllocation_PUT {
+			if pbad.GetAction().IsPut() {
+				summary.PutBytes += rbad.GetTotal()
 				summary.PutActionCount++
 			} else {
+				summary.GetBytes += rbad.GetTotal()
 				summary.GetActionCount++
 			}
 
@@ -184,22 +229,201 @@ func cmdDiag(cmd *cobra.Command, args []string) (err error) {
 	// initialize the table header (fields)
 	const padding = 3
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, padding, ' ', tabwriter.AlignRight|tabwriter.Debug)
-	fmt.Fprintln(w, "SatelliteID\tTotal\t# Of Transactions\tPUT Action\tGET Action\t")
+	fmt.Fprintln(w, "SatelliteID\tTotal\tPUT Bytes\tGET Bytes\t# Of Transactions\tPUT Action\tGET Action\t")
 
 	// populate the row fields
 	sort.Strings(satelliteIDs)
 	for _, satelliteID := range satelliteIDs {
 		summary := summaries[satelliteID]
-		fmt.Fprint(w, satelliteID, "\t", summary.TotalBytes, "\t", summary.TotalTransactions, "\t", summary.PutActionCount, "\t", summary.GetActionCount, "\t\n")
+		fmt.Fprint(w, satelliteID, "\t", summary.TotalBytes, "\t", summary.PutBytes, "\t", summary.GetBytes, "\t", summary.TotalTransactions, "\t", summary.PutActionCount, "\t", summary.GetActionCount, "\t\n")
 	}
 
 	// display the data
-	err = w.Flush()
-	return err
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// list the agreements the satellites won't pay for
+	rejected, err := db.GetRejectedAgreements()
+	if err != nil {
+		return err
+	}
+	if len(rejected) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Fprintln(w, "SatelliteID\tSerial Number\tTotal\tReason\t")
+	for _, satelliteID := range sortedKeys(rejected) {
+		for _, agreement := range rejected[satelliteID] {
+			rbad := &pb.RenterBandwidthAllocation_Data{}
+			if err := proto.Unmarshal(agreement.Agreement, rbad); err != nil {
+				return err
+			}
+			pbad := &pb.PayerBandwidthAllocation_Data{}
+			if err := proto.Unmarshal(rbad.GetPayerAllocation().GetData(), pbad); err != nil {
+				return err
+			}
+			fmt.Fprint(w, satelliteID, "\t", pbad.GetSerialNumber(), "\t", rbad.GetTotal(), "\t", agreement.Reason, "\t\n")
+		}
+	}
+	return w.Flush()
+}
+
+func sortedKeys(agreements map[string][]*psdb.Agreement) (keys []string) {
+	for key := range agreements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func cmdExit(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+	satelliteAddr := args[0]
+
+	identity, err := exitCfg.Identity.Load()
+	if err != nil {
+		return err
+	}
+
+	db, err := psdb.Open(ctx, nil, filepath.Join(exitCfg.Storage.Path, "piecestore.db"))
+	if err != nil {
+		return err
+	}
+	defer func() { err = utils.CombineErrors(err, db.Close()) }()
+
+	conn, err := transport.NewClient(identity).DialAddress(ctx, satelliteAddr)
+	if err != nil {
+		return err
+	}
+	defer func() { err = utils.CombineErrors(err, conn.Close()) }()
+
+	satellitePeer := &peer.Peer{}
+	progress, err := pb.NewGracefulExitClient(conn).Initiate(ctx, &pb.InitiateExitRequest{}, grpc.Peer(satellitePeer))
+	if err != nil {
+		return err
+	}
+
+	satellite, err := provider.PeerIdentityFromPeer(satellitePeer)
+	if err != nil {
+		return err
+	}
+
+	// the running storage node picks up the exit and transfers the pieces
+	if err := db.AddExit(satellite.ID.String(), satelliteAddr); err != nil {
+		return err
+	}
+
+	fmt.Printf("Exiting from satellite %s, %d pieces to transfer\n", satellite.ID, progress.GetTransfersTotal())
+	return nil
+}
+
+func cmdExitStatus(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	identity, err := exitCfg.Identity.Load()
+	if err != nil {
+		return err
+	}
+
+	db, err := psdb.Open(ctx, nil, filepath.Join(exitCfg.Storage.Path, "piecestore.db"))
+	if err != nil {
+		return err
+	}
+	defer func() { err = utils.CombineErrors(err, db.Close()) }()
+
+	exits, err := db.GetExits()
+	if err != nil {
+		return err
+	}
+	if len(exits) == 0 {
+		fmt.Println("Not exiting from any satellite")
+		return nil
+	}
+
+	tc := transport.NewClient(identity)
+
+	const padding = 3
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, padding, ' ', tabwriter.AlignRight|tabwriter.Debug)
+	fmt.Fprintln(w, "SatelliteID\tStarted\tTransferred\tFailed\tTotal\tStatus\t")
+
+	for _, exit := range exits {
+		started := time.Unix(exit.Started, 0).Format(time.RFC3339)
+
+		progress, err := exitProgress(ctx, tc, exit.Address)
+		if err != nil {
+			fmt.Fprint(w, exit.Satellite, "\t", started, "\t-\t-\t-\t", err, "\t\n")
+			continue
+		}
+
+		status := "transferring"
+		switch {
+		case exit.Finished != 0:
+			status = "exited"
+		case progress.GetFinishedUnixSec() != 0:
+			status = "cleaning up"
+		}
+
+		fmt.Fprint(w, exit.Satellite, "\t", started, "\t", progress.GetTransfersCompleted(), "\t",
+			progress.GetTransfersFailed(), "\t", progress.GetTransfersTotal(), "\t", status, "\t\n")
+	}
+
+	return w.Flush()
+}
+
+// exitProgress asks the satellite for the progress of the exit
+func exitProgress(ctx context.Context, tc transport.Client, address string) (_ *pb.ExitProgress, err error) {
+	conn, err := tc.DialAddress(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, conn.Close()) }()
+
+	return pb.NewGracefulExitClient(conn).Progress(ctx, &pb.ExitProgressRequest{})
+}
+
+// cmdCheck prints the inconsistencies as JSON, one per line
+func cmdCheck(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	dataDir := filepath.Join(checkCfg.Storage.Path, "blobs")
+	blobs, err := filestore.NewAt(dataDir)
+	if err != nil {
+		return err
+	}
+
+	db, err := psdb.Open(ctx, nil, filepath.Join(checkCfg.Storage.Path, "piecestore.db"))
+	if err != nil {
+		return err
+	}
+	defer func() { err = utils.CombineErrors(err, db.Close()) }()
+
+	s, err := psserver.New(dataDir, blobs, db, checkCfg.Storage, nil)
+	if err != nil {
+		return err
+	}
+
+	found, err := psserver.NewChecker(s, time.Hour, checkCfg.Storage.CheckAction).Check(ctx)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	for _, inconsistency := range found {
+		if err := encoder.Encode(inconsistency); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func main() {
 	runCmd.Flags().String("config",
 		filepath.Join(defaultConfDir, "config.yaml"), "path to configuration")
+	exitCmd.Flags().String("config",
+		filepath.Join(defaultConfDir, "config.yaml"), "path to configuration")
+	exitStatusCmd.Flags().String("config",
+		filepath.Join(defaultConfDir, "config.yaml"), "path to configuration")
 	process.Exec(rootCmd)
 }
@@ -0,0 +1,32 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kvstore
+
+import (
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/utils"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/boltdb"
+	"storj.io/storj/storage/postgreskv"
+)
+
+// Error is the error class for opening key value stores
+var Error = errs.Class("kvstore error")
+
+// Open opens the key value store of the database url, the keys are kept in the bucket
+// apart from the keys of other stores sharing the database
+func Open(dbURL, bucket string) (storage.KeyValueStore, error) {
+	u, err := utils.ParseURL(dbURL)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "bolt":
+		return boltdb.New(u.Path, bucket)
+	case "postgresql", "postgres":
+		return postgreskv.NewBucket(dbURL, bucket)
+	}
+	return nil, Error.New("unsupported db scheme: %s", u.Scheme)
+}
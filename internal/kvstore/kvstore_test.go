@@ -0,0 +1,43 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kvstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/storage"
+)
+
+func TestOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storj-kvstore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	dbURL := "bolt://" + filepath.Join(dir, "test.db")
+	store, err := Open(dbURL, "bucket")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, store.Put(storage.Key("key"), storage.Value("value")))
+	assert.NoError(t, store.Close())
+
+	// the keys of another bucket aren't seen
+	store, err = Open(dbURL, "other")
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = store.Get(storage.Key("key"))
+	assert.True(t, storage.ErrKeyNotFound.Has(err))
+	assert.NoError(t, store.Close())
+
+	_, err = Open("redis://localhost:6379", "bucket")
+	assert.True(t, Error.Has(err))
+}
@@ -15,6 +15,7 @@ import (
 	"storj.io/storj/pkg/pointerdb/pdbclient"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/uplinkdb"
 	"storj.io/storj/pkg/utils"
 	"storj.io/storj/storage/teststore"
 )
@@ -29,6 +30,7 @@ type Node struct {
 	Provider  *provider.Provider
 	Kademlia  *kademlia.Kademlia
 	Overlay   *overlay.Cache
+	Keys      *uplinkdb.DB // registered uplink keys of a satellite
 
 	Dependencies []io.Closer
 }
@@ -63,8 +65,19 @@ func (node *Node) Shutdown() error {
 	return utils.CombineErrors(errs...)
 }
 
-// DialPointerDB dials destination with apikey and returns pointerdb Client
+// DialPointerDB registers the key of the node for apikey at destination, dials destination with apikey
+// and returns pointerdb Client
 func (node *Node) DialPointerDB(destination *Node, apikey string) (pdbclient.Client, error) {
+	if destination.Keys != nil {
+		publicKey, err := uplinkdb.PublicKeyBytes(node.Identity.Leaf.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := destination.Keys.Register([]byte(apikey), publicKey); err != nil {
+			return nil, err
+		}
+	}
+
 	// TODO: use node.Transport instead of pdbclient.NewClient
 	/*
 		conn, err := node.Transport.DialNode(context.Background(), &destination.Info)
@@ -24,7 +24,9 @@ import (
 	"storj.io/storj/pkg/pointerdb"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/uplinkdb"
 	"storj.io/storj/pkg/utils"
+	"storj.io/storj/storage/filestore"
 	"storj.io/storj/storage/teststore"
 )
 
@@ -88,13 +90,17 @@ func New(t zaptest.TestingT, satelliteCount, storageNodeCount, uplinkCount int)
 
 	// init Satellites
 	for _, node := range planet.Satellites {
+		node.Keys = uplinkdb.New(teststore.New())
+		pb.RegisterUplinkKeysServer(node.Provider.GRPC(), uplinkdb.NewServer(node.Keys, node.Log.Named("udb")))
+
 		server := pointerdb.NewServer(
-			teststore.New(), node.Overlay,
+			teststore.New(), node.Overlay, node.Keys, nil,
 			node.Log.Named("pdb"),
 			pointerdb.Config{
 				MinRemoteSegmentSize: 1240,
 				MaxInlineSegmentSize: 8000,
 				Overlay:              true,
+				MaxOrderLimit:        64 * memory.MB.Int64(),
 			},
 			node.Identity)
 		pb.RegisterPointerDBServer(node.Provider.GRPC(), server)
@@ -110,16 +116,24 @@ func New(t zaptest.TestingT, satelliteCount, storageNodeCount, uplinkCount int)
 	for _, node := range planet.StorageNodes {
 		storageDir := filepath.Join(planet.directory, node.ID())
 
-		serverdb, err := psdb.OpenInMemory(context.Background(), storageDir)
+		blobs, err := filestore.NewAt(storageDir)
 		if err != nil {
 			return nil, utils.CombineErrors(err, planet.Shutdown())
 		}
 
-		server := pieceserver.New(storageDir, serverdb, pieceserver.Config{
+		serverdb, err := psdb.OpenInMemory(context.Background(), blobs)
+		if err != nil {
+			return nil, utils.CombineErrors(err, planet.Shutdown())
+		}
+
+		server, err := pieceserver.New(storageDir, blobs, serverdb, pieceserver.Config{
 			Path:               storageDir,
 			AllocatedDiskSpace: memory.GB.Int64(),
 			AllocatedBandwidth: 100 * memory.GB.Int64(),
-		}, node.Identity.Key)
+		}, node.Identity)
+		if err != nil {
+			return nil, utils.CombineErrors(err, serverdb.Close(), planet.Shutdown())
+		}
 
 		pb.RegisterPieceStoreRoutesServer(node.Provider.GRPC(), server)
 
@@ -0,0 +1,54 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package accounting
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/zeebo/errs"
+
+	dbx "storj.io/storj/pkg/accounting/dbx"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/utils"
+)
+
+// CtxKey is used as the key of the accounting database in the context
+type CtxKey int
+
+const ctxKey CtxKey = iota
+
+// Config is a configuration struct for the accounting database shared by tally and rollup
+type Config struct {
+	DatabaseURL string `help:"the database connection string to use" default:"sqlite3://$CONFDIR/accounting.db"`
+}
+
+// Open opens the configured accounting database
+func (c Config) Open() (*dbx.DB, error) {
+	u, err := url.Parse(c.DatabaseURL)
+	if err != nil {
+		return nil, errs.New("invalid database url %q: %v", c.DatabaseURL, err)
+	}
+	return NewDB(u.Scheme, u.Path)
+}
+
+// Run implements the provider.Responsibility interface
+func (c Config) Run(ctx context.Context, server *provider.Provider) (err error) {
+	db, err := c.Open()
+	if err != nil {
+		return err
+	}
+	defer func() { err = utils.CombineErrors(err, db.Close()) }()
+
+	ctx = context.WithValue(ctx, ctxKey, db)
+	return server.Run(ctx)
+}
+
+// LoadFromContext gives access to the accounting database from the context, or returns nil
+func LoadFromContext(ctx context.Context) *dbx.DB {
+	if v, ok := ctx.Value(ctxKey).(*dbx.DB); ok {
+		return v
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package accounting
+
+import (
+	"storj.io/storj/internal/migrate"
+	dbx "storj.io/storj/pkg/accounting/dbx"
+	"storj.io/storj/pkg/utils"
+)
+
+// NewDB opens the accounting database shared by tally and rollup and creates its tables
+func NewDB(driver, source string) (*dbx.DB, error) {
+	db, err := dbx.Open(driver, source)
+	if err != nil {
+		return nil, err
+	}
+
+	err = migrate.Create("accounting", db)
+	if err != nil {
+		return nil, utils.CombineErrors(err, db.Close())
+	}
+	return db, nil
+}
@@ -272,22 +272,60 @@ func newpostgres(db *DB) *postgresDB {
 }
 
 func (obj *postgresDB) Schema() string {
-	return `CREATE TABLE aggregates (
+	return `CREATE TABLE rollups (
+	id bigserial NOT NULL,
 	node_id text NOT NULL,
 	start_time timestamp with time zone NOT NULL,
-	interval bigint NOT NULL,
+	at_rest_total double precision NOT NULL,
+	put_total bigint NOT NULL,
+	get_total bigint NOT NULL,
+	get_audit_total bigint NOT NULL,
+	get_repair_total bigint NOT NULL,
+	put_repair_total bigint NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	updated_at timestamp with time zone NOT NULL,
-	PRIMARY KEY ( node_id )
+	PRIMARY KEY ( id ),
+	UNIQUE ( node_id, start_time )
 );
 CREATE TABLE granulars (
+	id bigserial NOT NULL,
 	node_id text NOT NULL,
 	start_time timestamp with time zone NOT NULL,
 	end_time timestamp with time zone NOT NULL,
-	data_total bigint NOT NULL,
+	data_total double precision NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	updated_at timestamp with time zone NOT NULL,
-	PRIMARY KEY ( node_id )
+	PRIMARY KEY ( id ),
+	UNIQUE ( node_id, start_time )
+);
+CREATE TABLE tallies (
+	name text NOT NULL,
+	last_tally timestamp with time zone NOT NULL,
+	pass_start timestamp with time zone NOT NULL,
+	cursor_path text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( name )
+);
+CREATE TABLE bucket_usages (
+	id bigserial NOT NULL,
+	api_key_hash bytea NOT NULL,
+	bucket_name text NOT NULL,
+	stored_bytes bigint NOT NULL,
+	segments bigint NOT NULL,
+	objects bigint NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id ),
+	UNIQUE ( api_key_hash, bucket_name )
+);
+CREATE TABLE project_limits (
+	api_key_hash bytea NOT NULL,
+	storage_limit bigint NOT NULL,
+	egress_limit bigint NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( api_key_hash )
 );`
 }
 
@@ -352,22 +390,60 @@ func newsqlite3(db *DB) *sqlite3DB {
 }
 
 func (obj *sqlite3DB) Schema() string {
-	return `CREATE TABLE aggregates (
+	return `CREATE TABLE rollups (
+	id INTEGER NOT NULL,
 	node_id TEXT NOT NULL,
 	start_time TIMESTAMP NOT NULL,
-	interval INTEGER NOT NULL,
+	at_rest_total REAL NOT NULL,
+	put_total INTEGER NOT NULL,
+	get_total INTEGER NOT NULL,
+	get_audit_total INTEGER NOT NULL,
+	get_repair_total INTEGER NOT NULL,
+	put_repair_total INTEGER NOT NULL,
 	created_at TIMESTAMP NOT NULL,
 	updated_at TIMESTAMP NOT NULL,
-	PRIMARY KEY ( node_id )
+	PRIMARY KEY ( id ),
+	UNIQUE ( node_id, start_time )
 );
 CREATE TABLE granulars (
+	id INTEGER NOT NULL,
 	node_id TEXT NOT NULL,
 	start_time TIMESTAMP NOT NULL,
 	end_time TIMESTAMP NOT NULL,
-	data_total INTEGER NOT NULL,
+	data_total REAL NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( id ),
+	UNIQUE ( node_id, start_time )
+);
+CREATE TABLE tallies (
+	name TEXT NOT NULL,
+	last_tally TIMESTAMP NOT NULL,
+	pass_start TIMESTAMP NOT NULL,
+	cursor_path TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( name )
+);
+CREATE TABLE bucket_usages (
+	id INTEGER NOT NULL,
+	api_key_hash BLOB NOT NULL,
+	bucket_name TEXT NOT NULL,
+	stored_bytes INTEGER NOT NULL,
+	segments INTEGER NOT NULL,
+	objects INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( id ),
+	UNIQUE ( api_key_hash, bucket_name )
+);
+CREATE TABLE project_limits (
+	api_key_hash BLOB NOT NULL,
+	storage_limit INTEGER NOT NULL,
+	egress_limit INTEGER NOT NULL,
 	created_at TIMESTAMP NOT NULL,
 	updated_at TIMESTAMP NOT NULL,
-	PRIMARY KEY ( node_id )
+	PRIMARY KEY ( api_key_hash )
 );`
 }
 
@@ -431,116 +507,235 @@ nextval:
 	fmt.Fprint(f, "]")
 }
 
-type Aggregate struct {
-	NodeId    string
-	StartTime time.Time
-	Interval  int64
-	CreatedAt time.Time
-	UpdatedAt time.Time
+type Rollup struct {
+	Id             int64
+	NodeId         string
+	StartTime      time.Time
+	AtRestTotal    float64
+	PutTotal       int64
+	GetTotal       int64
+	GetAuditTotal  int64
+	GetRepairTotal int64
+	PutRepairTotal int64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (Rollup) _Table() string { return "rollups" }
+
+type Rollup_Update_Fields struct {
+	AtRestTotal    Rollup_AtRestTotal_Field
+	PutTotal       Rollup_PutTotal_Field
+	GetTotal       Rollup_GetTotal_Field
+	GetAuditTotal  Rollup_GetAuditTotal_Field
+	GetRepairTotal Rollup_GetRepairTotal_Field
+	PutRepairTotal Rollup_PutRepairTotal_Field
+}
+
+type Rollup_Id_Field struct {
+	_set   bool
+	_value int64
 }
 
-func (Aggregate) _Table() string { return "aggregates" }
+func Rollup_Id(v int64) Rollup_Id_Field {
+	return Rollup_Id_Field{_set: true, _value: v}
+}
 
-type Aggregate_Update_Fields struct {
-	StartTime Aggregate_StartTime_Field
-	Interval  Aggregate_Interval_Field
+func (f Rollup_Id_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
 }
 
-type Aggregate_NodeId_Field struct {
+func (Rollup_Id_Field) _Column() string { return "id" }
+
+type Rollup_NodeId_Field struct {
 	_set   bool
 	_value string
 }
 
-func Aggregate_NodeId(v string) Aggregate_NodeId_Field {
-	return Aggregate_NodeId_Field{_set: true, _value: v}
+func Rollup_NodeId(v string) Rollup_NodeId_Field {
+	return Rollup_NodeId_Field{_set: true, _value: v}
 }
 
-func (f Aggregate_NodeId_Field) value() interface{} {
+func (f Rollup_NodeId_Field) value() interface{} {
 	if !f._set {
 		return nil
 	}
 	return f._value
 }
 
-func (Aggregate_NodeId_Field) _Column() string { return "node_id" }
+func (Rollup_NodeId_Field) _Column() string { return "node_id" }
 
-type Aggregate_StartTime_Field struct {
+type Rollup_StartTime_Field struct {
 	_set   bool
 	_value time.Time
 }
 
-func Aggregate_StartTime(v time.Time) Aggregate_StartTime_Field {
-	return Aggregate_StartTime_Field{_set: true, _value: v}
+func Rollup_StartTime(v time.Time) Rollup_StartTime_Field {
+	return Rollup_StartTime_Field{_set: true, _value: v}
+}
+
+func (f Rollup_StartTime_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (Rollup_StartTime_Field) _Column() string { return "start_time" }
+
+type Rollup_AtRestTotal_Field struct {
+	_set   bool
+	_value float64
+}
+
+func Rollup_AtRestTotal(v float64) Rollup_AtRestTotal_Field {
+	return Rollup_AtRestTotal_Field{_set: true, _value: v}
+}
+
+func (f Rollup_AtRestTotal_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (Rollup_AtRestTotal_Field) _Column() string { return "at_rest_total" }
+
+type Rollup_PutTotal_Field struct {
+	_set   bool
+	_value int64
+}
+
+func Rollup_PutTotal(v int64) Rollup_PutTotal_Field {
+	return Rollup_PutTotal_Field{_set: true, _value: v}
+}
+
+func (f Rollup_PutTotal_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (Rollup_PutTotal_Field) _Column() string { return "put_total" }
+
+type Rollup_GetTotal_Field struct {
+	_set   bool
+	_value int64
+}
+
+func Rollup_GetTotal(v int64) Rollup_GetTotal_Field {
+	return Rollup_GetTotal_Field{_set: true, _value: v}
+}
+
+func (f Rollup_GetTotal_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (Rollup_GetTotal_Field) _Column() string { return "get_total" }
+
+type Rollup_GetAuditTotal_Field struct {
+	_set   bool
+	_value int64
+}
+
+func Rollup_GetAuditTotal(v int64) Rollup_GetAuditTotal_Field {
+	return Rollup_GetAuditTotal_Field{_set: true, _value: v}
+}
+
+func (f Rollup_GetAuditTotal_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (Rollup_GetAuditTotal_Field) _Column() string { return "get_audit_total" }
+
+type Rollup_GetRepairTotal_Field struct {
+	_set   bool
+	_value int64
+}
+
+func Rollup_GetRepairTotal(v int64) Rollup_GetRepairTotal_Field {
+	return Rollup_GetRepairTotal_Field{_set: true, _value: v}
 }
 
-func (f Aggregate_StartTime_Field) value() interface{} {
+func (f Rollup_GetRepairTotal_Field) value() interface{} {
 	if !f._set {
 		return nil
 	}
 	return f._value
 }
 
-func (Aggregate_StartTime_Field) _Column() string { return "start_time" }
+func (Rollup_GetRepairTotal_Field) _Column() string { return "get_repair_total" }
 
-type Aggregate_Interval_Field struct {
+type Rollup_PutRepairTotal_Field struct {
 	_set   bool
 	_value int64
 }
 
-func Aggregate_Interval(v int64) Aggregate_Interval_Field {
-	return Aggregate_Interval_Field{_set: true, _value: v}
+func Rollup_PutRepairTotal(v int64) Rollup_PutRepairTotal_Field {
+	return Rollup_PutRepairTotal_Field{_set: true, _value: v}
 }
 
-func (f Aggregate_Interval_Field) value() interface{} {
+func (f Rollup_PutRepairTotal_Field) value() interface{} {
 	if !f._set {
 		return nil
 	}
 	return f._value
 }
 
-func (Aggregate_Interval_Field) _Column() string { return "interval" }
+func (Rollup_PutRepairTotal_Field) _Column() string { return "put_repair_total" }
 
-type Aggregate_CreatedAt_Field struct {
+type Rollup_CreatedAt_Field struct {
 	_set   bool
 	_value time.Time
 }
 
-func Aggregate_CreatedAt(v time.Time) Aggregate_CreatedAt_Field {
-	return Aggregate_CreatedAt_Field{_set: true, _value: v}
+func Rollup_CreatedAt(v time.Time) Rollup_CreatedAt_Field {
+	return Rollup_CreatedAt_Field{_set: true, _value: v}
 }
 
-func (f Aggregate_CreatedAt_Field) value() interface{} {
+func (f Rollup_CreatedAt_Field) value() interface{} {
 	if !f._set {
 		return nil
 	}
 	return f._value
 }
 
-func (Aggregate_CreatedAt_Field) _Column() string { return "created_at" }
+func (Rollup_CreatedAt_Field) _Column() string { return "created_at" }
 
-type Aggregate_UpdatedAt_Field struct {
+type Rollup_UpdatedAt_Field struct {
 	_set   bool
 	_value time.Time
 }
 
-func Aggregate_UpdatedAt(v time.Time) Aggregate_UpdatedAt_Field {
-	return Aggregate_UpdatedAt_Field{_set: true, _value: v}
+func Rollup_UpdatedAt(v time.Time) Rollup_UpdatedAt_Field {
+	return Rollup_UpdatedAt_Field{_set: true, _value: v}
 }
 
-func (f Aggregate_UpdatedAt_Field) value() interface{} {
+func (f Rollup_UpdatedAt_Field) value() interface{} {
 	if !f._set {
 		return nil
 	}
 	return f._value
 }
 
-func (Aggregate_UpdatedAt_Field) _Column() string { return "updated_at" }
+func (Rollup_UpdatedAt_Field) _Column() string { return "updated_at" }
 
 type Granular struct {
+	Id        int64
 	NodeId    string
 	StartTime time.Time
 	EndTime   time.Time
-	DataTotal int64
+	DataTotal float64
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -548,11 +743,27 @@ type Granular struct {
 func (Granular) _Table() string { return "granulars" }
 
 type Granular_Update_Fields struct {
-	StartTime Granular_StartTime_Field
-	EndTime   Granular_EndTime_Field
 	DataTotal Granular_DataTotal_Field
 }
 
+type Granular_Id_Field struct {
+	_set   bool
+	_value int64
+}
+
+func Granular_Id(v int64) Granular_Id_Field {
+	return Granular_Id_Field{_set: true, _value: v}
+}
+
+func (f Granular_Id_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (Granular_Id_Field) _Column() string { return "id" }
+
 type Granular_NodeId_Field struct {
 	_set   bool
 	_value string
@@ -609,10 +820,10 @@ func (Granular_EndTime_Field) _Column() string { return "end_time" }
 
 type Granular_DataTotal_Field struct {
 	_set   bool
-	_value int64
+	_value float64
 }
 
-func Granular_DataTotal(v int64) Granular_DataTotal_Field {
+func Granular_DataTotal(v float64) Granular_DataTotal_Field {
 	return Granular_DataTotal_Field{_set: true, _value: v}
 }
 
@@ -661,147 +872,540 @@ func (f Granular_UpdatedAt_Field) value() interface{} {
 
 func (Granular_UpdatedAt_Field) _Column() string { return "updated_at" }
 
-func toUTC(t time.Time) time.Time {
-	return t.UTC()
+type Tally struct {
+	Name       string
+	LastTally  time.Time
+	PassStart  time.Time
+	CursorPath string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
-func toDate(t time.Time) time.Time {
-	// keep up the minute portion so that translations between timezones will
-	// continue to reflect properly.
-	return t.Truncate(time.Minute)
-}
+func (Tally) _Table() string { return "tallies" }
 
-//
-// runtime support for building sql statements
-//
+type Tally_Update_Fields struct {
+	LastTally  Tally_LastTally_Field
+	PassStart  Tally_PassStart_Field
+	CursorPath Tally_CursorPath_Field
+}
 
-type __sqlbundle_SQL interface {
-	Render() string
+type Tally_Name_Field struct {
+	_set   bool
+	_value string
+}
 
-	private()
+func Tally_Name(v string) Tally_Name_Field {
+	return Tally_Name_Field{_set: true, _value: v}
 }
 
-type __sqlbundle_Dialect interface {
-	Rebind(sql string) string
+func (f Tally_Name_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
 }
 
-type __sqlbundle_RenderOp int
+func (Tally_Name_Field) _Column() string { return "name" }
 
-const (
-	__sqlbundle_NoFlatten __sqlbundle_RenderOp = iota
-	__sqlbundle_NoTerminate
-)
+type Tally_LastTally_Field struct {
+	_set   bool
+	_value time.Time
+}
 
-func __sqlbundle_Render(dialect __sqlbundle_Dialect, sql __sqlbundle_SQL, ops ...__sqlbundle_RenderOp) string {
-	out := sql.Render()
+func Tally_LastTally(v time.Time) Tally_LastTally_Field {
+	return Tally_LastTally_Field{_set: true, _value: v}
+}
 
-	flatten := true
-	terminate := true
-	for _, op := range ops {
-		switch op {
-		case __sqlbundle_NoFlatten:
-			flatten = false
-		case __sqlbundle_NoTerminate:
-			terminate = false
-		}
+func (f Tally_LastTally_Field) value() interface{} {
+	if !f._set {
+		return nil
 	}
+	return f._value
+}
 
-	if flatten {
-		out = __sqlbundle_flattenSQL(out)
-	}
-	if terminate {
-		out += ";"
-	}
+func (Tally_LastTally_Field) _Column() string { return "last_tally" }
 
-	return dialect.Rebind(out)
+type Tally_PassStart_Field struct {
+	_set   bool
+	_value time.Time
 }
 
-var __sqlbundle_reSpace = regexp.MustCompile(`\s+`)
+func Tally_PassStart(v time.Time) Tally_PassStart_Field {
+	return Tally_PassStart_Field{_set: true, _value: v}
+}
 
-func __sqlbundle_flattenSQL(s string) string {
-	return strings.TrimSpace(__sqlbundle_reSpace.ReplaceAllString(s, " "))
+func (f Tally_PassStart_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
 }
 
-// this type is specially named to match up with the name returned by the
-// dialect impl in the sql package.
-type __sqlbundle_postgres struct{}
+func (Tally_PassStart_Field) _Column() string { return "pass_start" }
 
-func (p __sqlbundle_postgres) Rebind(sql string) string {
-	out := make([]byte, 0, len(sql)+10)
+type Tally_CursorPath_Field struct {
+	_set   bool
+	_value string
+}
 
-	j := 1
-	for i := 0; i < len(sql); i++ {
-		ch := sql[i]
-		if ch != '?' {
-			out = append(out, ch)
-			continue
-		}
+func Tally_CursorPath(v string) Tally_CursorPath_Field {
+	return Tally_CursorPath_Field{_set: true, _value: v}
+}
 
-		out = append(out, '$')
-		out = append(out, strconv.Itoa(j)...)
-		j++
+func (f Tally_CursorPath_Field) value() interface{} {
+	if !f._set {
+		return nil
 	}
-
-	return string(out)
+	return f._value
 }
 
-// this type is specially named to match up with the name returned by the
-// dialect impl in the sql package.
-type __sqlbundle_sqlite3 struct{}
+func (Tally_CursorPath_Field) _Column() string { return "cursor_path" }
 
-func (s __sqlbundle_sqlite3) Rebind(sql string) string {
-	return sql
+type Tally_CreatedAt_Field struct {
+	_set   bool
+	_value time.Time
 }
 
-type __sqlbundle_Literal string
+func Tally_CreatedAt(v time.Time) Tally_CreatedAt_Field {
+	return Tally_CreatedAt_Field{_set: true, _value: v}
+}
 
-func (__sqlbundle_Literal) private() {}
+func (f Tally_CreatedAt_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
 
-func (l __sqlbundle_Literal) Render() string { return string(l) }
+func (Tally_CreatedAt_Field) _Column() string { return "created_at" }
 
-type __sqlbundle_Literals struct {
-	Join string
-	SQLs []__sqlbundle_SQL
+type Tally_UpdatedAt_Field struct {
+	_set   bool
+	_value time.Time
 }
 
-func (__sqlbundle_Literals) private() {}
-
-func (l __sqlbundle_Literals) Render() string {
-	var out bytes.Buffer
+func Tally_UpdatedAt(v time.Time) Tally_UpdatedAt_Field {
+	return Tally_UpdatedAt_Field{_set: true, _value: v}
+}
 
-	first := true
-	for _, sql := range l.SQLs {
-		if sql == nil {
-			continue
-		}
-		if !first {
-			out.WriteString(l.Join)
-		}
-		first = false
-		out.WriteString(sql.Render())
+func (f Tally_UpdatedAt_Field) value() interface{} {
+	if !f._set {
+		return nil
 	}
-
-	return out.String()
+	return f._value
 }
 
-type __sqlbundle_Condition struct {
-	// set at compile/embed time
-	Name  string
-	Left  string
-	Equal bool
-	Right string
+func (Tally_UpdatedAt_Field) _Column() string { return "updated_at" }
 
-	// set at runtime
-	Null bool
+type BucketUsage struct {
+	Id          int64
+	ApiKeyHash  []byte
+	BucketName  string
+	StoredBytes int64
+	Segments    int64
+	Objects     int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
-func (*__sqlbundle_Condition) private() {}
+func (BucketUsage) _Table() string { return "bucket_usages" }
 
-func (c *__sqlbundle_Condition) Render() string {
+type BucketUsage_Update_Fields struct {
+	StoredBytes BucketUsage_StoredBytes_Field
+	Segments    BucketUsage_Segments_Field
+	Objects     BucketUsage_Objects_Field
+}
 
-	switch {
-	case c.Equal && c.Null:
-		return c.Left + " is null"
+type BucketUsage_Id_Field struct {
+	_set   bool
+	_value int64
+}
+
+func BucketUsage_Id(v int64) BucketUsage_Id_Field {
+	return BucketUsage_Id_Field{_set: true, _value: v}
+}
+
+func (f BucketUsage_Id_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketUsage_Id_Field) _Column() string { return "id" }
+
+type BucketUsage_ApiKeyHash_Field struct {
+	_set   bool
+	_value []byte
+}
+
+func BucketUsage_ApiKeyHash(v []byte) BucketUsage_ApiKeyHash_Field {
+	return BucketUsage_ApiKeyHash_Field{_set: true, _value: v}
+}
+
+func (f BucketUsage_ApiKeyHash_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketUsage_ApiKeyHash_Field) _Column() string { return "api_key_hash" }
+
+type BucketUsage_BucketName_Field struct {
+	_set   bool
+	_value string
+}
+
+func BucketUsage_BucketName(v string) BucketUsage_BucketName_Field {
+	return BucketUsage_BucketName_Field{_set: true, _value: v}
+}
+
+func (f BucketUsage_BucketName_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketUsage_BucketName_Field) _Column() string { return "bucket_name" }
+
+type BucketUsage_StoredBytes_Field struct {
+	_set   bool
+	_value int64
+}
+
+func BucketUsage_StoredBytes(v int64) BucketUsage_StoredBytes_Field {
+	return BucketUsage_StoredBytes_Field{_set: true, _value: v}
+}
+
+func (f BucketUsage_StoredBytes_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketUsage_StoredBytes_Field) _Column() string { return "stored_bytes" }
+
+type BucketUsage_Segments_Field struct {
+	_set   bool
+	_value int64
+}
+
+func BucketUsage_Segments(v int64) BucketUsage_Segments_Field {
+	return BucketUsage_Segments_Field{_set: true, _value: v}
+}
+
+func (f BucketUsage_Segments_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketUsage_Segments_Field) _Column() string { return "segments" }
+
+type BucketUsage_Objects_Field struct {
+	_set   bool
+	_value int64
+}
+
+func BucketUsage_Objects(v int64) BucketUsage_Objects_Field {
+	return BucketUsage_Objects_Field{_set: true, _value: v}
+}
+
+func (f BucketUsage_Objects_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketUsage_Objects_Field) _Column() string { return "objects" }
+
+type BucketUsage_CreatedAt_Field struct {
+	_set   bool
+	_value time.Time
+}
+
+func BucketUsage_CreatedAt(v time.Time) BucketUsage_CreatedAt_Field {
+	return BucketUsage_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f BucketUsage_CreatedAt_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketUsage_CreatedAt_Field) _Column() string { return "created_at" }
+
+type BucketUsage_UpdatedAt_Field struct {
+	_set   bool
+	_value time.Time
+}
+
+func BucketUsage_UpdatedAt(v time.Time) BucketUsage_UpdatedAt_Field {
+	return BucketUsage_UpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f BucketUsage_UpdatedAt_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketUsage_UpdatedAt_Field) _Column() string { return "updated_at" }
+
+type ProjectLimit struct {
+	ApiKeyHash   []byte
+	StorageLimit int64
+	EgressLimit  int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (ProjectLimit) _Table() string { return "project_limits" }
+
+type ProjectLimit_Update_Fields struct {
+	StorageLimit ProjectLimit_StorageLimit_Field
+	EgressLimit  ProjectLimit_EgressLimit_Field
+}
+
+type ProjectLimit_ApiKeyHash_Field struct {
+	_set   bool
+	_value []byte
+}
+
+func ProjectLimit_ApiKeyHash(v []byte) ProjectLimit_ApiKeyHash_Field {
+	return ProjectLimit_ApiKeyHash_Field{_set: true, _value: v}
+}
+
+func (f ProjectLimit_ApiKeyHash_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectLimit_ApiKeyHash_Field) _Column() string { return "api_key_hash" }
+
+type ProjectLimit_StorageLimit_Field struct {
+	_set   bool
+	_value int64
+}
+
+func ProjectLimit_StorageLimit(v int64) ProjectLimit_StorageLimit_Field {
+	return ProjectLimit_StorageLimit_Field{_set: true, _value: v}
+}
+
+func (f ProjectLimit_StorageLimit_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectLimit_StorageLimit_Field) _Column() string { return "storage_limit" }
+
+type ProjectLimit_EgressLimit_Field struct {
+	_set   bool
+	_value int64
+}
+
+func ProjectLimit_EgressLimit(v int64) ProjectLimit_EgressLimit_Field {
+	return ProjectLimit_EgressLimit_Field{_set: true, _value: v}
+}
+
+func (f ProjectLimit_EgressLimit_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectLimit_EgressLimit_Field) _Column() string { return "egress_limit" }
+
+type ProjectLimit_CreatedAt_Field struct {
+	_set   bool
+	_value time.Time
+}
+
+func ProjectLimit_CreatedAt(v time.Time) ProjectLimit_CreatedAt_Field {
+	return ProjectLimit_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f ProjectLimit_CreatedAt_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectLimit_CreatedAt_Field) _Column() string { return "created_at" }
+
+type ProjectLimit_UpdatedAt_Field struct {
+	_set   bool
+	_value time.Time
+}
+
+func ProjectLimit_UpdatedAt(v time.Time) ProjectLimit_UpdatedAt_Field {
+	return ProjectLimit_UpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f ProjectLimit_UpdatedAt_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectLimit_UpdatedAt_Field) _Column() string { return "updated_at" }
+
+func toUTC(t time.Time) time.Time {
+	return t.UTC()
+}
+
+func toDate(t time.Time) time.Time {
+	// keep up the minute portion so that translations between timezones will
+	// continue to reflect properly.
+	return t.Truncate(time.Minute)
+}
+
+//
+// runtime support for building sql statements
+//
+
+type __sqlbundle_SQL interface {
+	Render() string
+
+	private()
+}
+
+type __sqlbundle_Dialect interface {
+	Rebind(sql string) string
+}
+
+type __sqlbundle_RenderOp int
+
+const (
+	__sqlbundle_NoFlatten __sqlbundle_RenderOp = iota
+	__sqlbundle_NoTerminate
+)
+
+func __sqlbundle_Render(dialect __sqlbundle_Dialect, sql __sqlbundle_SQL, ops ...__sqlbundle_RenderOp) string {
+	out := sql.Render()
+
+	flatten := true
+	terminate := true
+	for _, op := range ops {
+		switch op {
+		case __sqlbundle_NoFlatten:
+			flatten = false
+		case __sqlbundle_NoTerminate:
+			terminate = false
+		}
+	}
+
+	if flatten {
+		out = __sqlbundle_flattenSQL(out)
+	}
+	if terminate {
+		out += ";"
+	}
+
+	return dialect.Rebind(out)
+}
+
+var __sqlbundle_reSpace = regexp.MustCompile(`\s+`)
+
+func __sqlbundle_flattenSQL(s string) string {
+	return strings.TrimSpace(__sqlbundle_reSpace.ReplaceAllString(s, " "))
+}
+
+// this type is specially named to match up with the name returned by the
+// dialect impl in the sql package.
+type __sqlbundle_postgres struct{}
+
+func (p __sqlbundle_postgres) Rebind(sql string) string {
+	out := make([]byte, 0, len(sql)+10)
+
+	j := 1
+	for i := 0; i < len(sql); i++ {
+		ch := sql[i]
+		if ch != '?' {
+			out = append(out, ch)
+			continue
+		}
+
+		out = append(out, '$')
+		out = append(out, strconv.Itoa(j)...)
+		j++
+	}
+
+	return string(out)
+}
+
+// this type is specially named to match up with the name returned by the
+// dialect impl in the sql package.
+type __sqlbundle_sqlite3 struct{}
+
+func (s __sqlbundle_sqlite3) Rebind(sql string) string {
+	return sql
+}
+
+type __sqlbundle_Literal string
+
+func (__sqlbundle_Literal) private() {}
+
+func (l __sqlbundle_Literal) Render() string { return string(l) }
+
+type __sqlbundle_Literals struct {
+	Join string
+	SQLs []__sqlbundle_SQL
+}
+
+func (__sqlbundle_Literals) private() {}
+
+func (l __sqlbundle_Literals) Render() string {
+	var out bytes.Buffer
+
+	first := true
+	for _, sql := range l.SQLs {
+		if sql == nil {
+			continue
+		}
+		if !first {
+			out.WriteString(l.Join)
+		}
+		first = false
+		out.WriteString(sql.Render())
+	}
+
+	return out.String()
+}
+
+type __sqlbundle_Condition struct {
+	// set at compile/embed time
+	Name  string
+	Left  string
+	Equal bool
+	Right string
+
+	// set at runtime
+	Null bool
+}
+
+func (*__sqlbundle_Condition) private() {}
+
+func (c *__sqlbundle_Condition) Render() string {
+
+	switch {
+	case c.Equal && c.Null:
+		return c.Left + " is null"
 	case c.Equal && !c.Null:
 		return c.Left + " = " + c.Right
 	case !c.Equal && c.Null:
@@ -813,50 +1417,904 @@ func (c *__sqlbundle_Condition) Render() string {
 	}
 }
 
-type __sqlbundle_Hole struct {
-	// set at compiile/embed time
-	Name string
+type __sqlbundle_Hole struct {
+	// set at compiile/embed time
+	Name string
+
+	// set at runtime
+	SQL __sqlbundle_SQL
+}
+
+func (*__sqlbundle_Hole) private() {}
+
+func (h *__sqlbundle_Hole) Render() string { return h.SQL.Render() }
+
+//
+// end runtime support for building sql statements
+//
+
+func (obj *postgresImpl) Create_Rollup(ctx context.Context,
+	rollup_node_id Rollup_NodeId_Field,
+	rollup_start_time Rollup_StartTime_Field,
+	rollup_at_rest_total Rollup_AtRestTotal_Field,
+	rollup_put_total Rollup_PutTotal_Field,
+	rollup_get_total Rollup_GetTotal_Field,
+	rollup_get_audit_total Rollup_GetAuditTotal_Field,
+	rollup_get_repair_total Rollup_GetRepairTotal_Field,
+	rollup_put_repair_total Rollup_PutRepairTotal_Field) (
+	rollup *Rollup, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__node_id_val := rollup_node_id.value()
+	__start_time_val := rollup_start_time.value()
+	__at_rest_total_val := rollup_at_rest_total.value()
+	__put_total_val := rollup_put_total.value()
+	__get_total_val := rollup_get_total.value()
+	__get_audit_total_val := rollup_get_audit_total.value()
+	__get_repair_total_val := rollup_get_repair_total.value()
+	__put_repair_total_val := rollup_put_repair_total.value()
+	__created_at_val := __now
+	__updated_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO rollups ( node_id, start_time, at_rest_total, put_total, get_total, get_audit_total, get_repair_total, put_repair_total, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING rollups.id, rollups.node_id, rollups.start_time, rollups.at_rest_total, rollups.put_total, rollups.get_total, rollups.get_audit_total, rollups.get_repair_total, rollups.put_repair_total, rollups.created_at, rollups.updated_at")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __node_id_val, __start_time_val, __at_rest_total_val, __put_total_val, __get_total_val, __get_audit_total_val, __get_repair_total_val, __put_repair_total_val, __created_at_val, __updated_at_val)
+
+	rollup = &Rollup{}
+	err = obj.driver.QueryRow(__stmt, __node_id_val, __start_time_val, __at_rest_total_val, __put_total_val, __get_total_val, __get_audit_total_val, __get_repair_total_val, __put_repair_total_val, __created_at_val, __updated_at_val).Scan(&rollup.Id, &rollup.NodeId, &rollup.StartTime, &rollup.AtRestTotal, &rollup.PutTotal, &rollup.GetTotal, &rollup.GetAuditTotal, &rollup.GetRepairTotal, &rollup.PutRepairTotal, &rollup.CreatedAt, &rollup.UpdatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rollup, nil
+
+}
+
+func (obj *postgresImpl) Create_Granular(ctx context.Context,
+	granular_node_id Granular_NodeId_Field,
+	granular_start_time Granular_StartTime_Field,
+	granular_end_time Granular_EndTime_Field,
+	granular_data_total Granular_DataTotal_Field) (
+	granular *Granular, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__node_id_val := granular_node_id.value()
+	__start_time_val := granular_start_time.value()
+	__end_time_val := granular_end_time.value()
+	__data_total_val := granular_data_total.value()
+	__created_at_val := __now
+	__updated_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO granulars ( node_id, start_time, end_time, data_total, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ? ) RETURNING granulars.id, granulars.node_id, granulars.start_time, granulars.end_time, granulars.data_total, granulars.created_at, granulars.updated_at")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __node_id_val, __start_time_val, __end_time_val, __data_total_val, __created_at_val, __updated_at_val)
+
+	granular = &Granular{}
+	err = obj.driver.QueryRow(__stmt, __node_id_val, __start_time_val, __end_time_val, __data_total_val, __created_at_val, __updated_at_val).Scan(&granular.Id, &granular.NodeId, &granular.StartTime, &granular.EndTime, &granular.DataTotal, &granular.CreatedAt, &granular.UpdatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return granular, nil
+
+}
+
+func (obj *postgresImpl) Create_Tally(ctx context.Context,
+	tally_name Tally_Name_Field,
+	tally_last_tally Tally_LastTally_Field,
+	tally_pass_start Tally_PassStart_Field,
+	tally_cursor_path Tally_CursorPath_Field) (
+	tally *Tally, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__name_val := tally_name.value()
+	__last_tally_val := tally_last_tally.value()
+	__pass_start_val := tally_pass_start.value()
+	__cursor_path_val := tally_cursor_path.value()
+	__created_at_val := __now
+	__updated_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO tallies ( name, last_tally, pass_start, cursor_path, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ? ) RETURNING tallies.name, tallies.last_tally, tallies.pass_start, tallies.cursor_path, tallies.created_at, tallies.updated_at")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __name_val, __last_tally_val, __pass_start_val, __cursor_path_val, __created_at_val, __updated_at_val)
+
+	tally = &Tally{}
+	err = obj.driver.QueryRow(__stmt, __name_val, __last_tally_val, __pass_start_val, __cursor_path_val, __created_at_val, __updated_at_val).Scan(&tally.Name, &tally.LastTally, &tally.PassStart, &tally.CursorPath, &tally.CreatedAt, &tally.UpdatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return tally, nil
+
+}
+
+func (obj *postgresImpl) Create_BucketUsage(ctx context.Context,
+	bucket_usage_api_key_hash BucketUsage_ApiKeyHash_Field,
+	bucket_usage_bucket_name BucketUsage_BucketName_Field,
+	bucket_usage_stored_bytes BucketUsage_StoredBytes_Field,
+	bucket_usage_segments BucketUsage_Segments_Field,
+	bucket_usage_objects BucketUsage_Objects_Field) (
+	bucket_usage *BucketUsage, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__api_key_hash_val := bucket_usage_api_key_hash.value()
+	__bucket_name_val := bucket_usage_bucket_name.value()
+	__stored_bytes_val := bucket_usage_stored_bytes.value()
+	__segments_val := bucket_usage_segments.value()
+	__objects_val := bucket_usage_objects.value()
+	__created_at_val := __now
+	__updated_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO bucket_usages ( api_key_hash, bucket_name, stored_bytes, segments, objects, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ?, ? ) RETURNING bucket_usages.id, bucket_usages.api_key_hash, bucket_usages.bucket_name, bucket_usages.stored_bytes, bucket_usages.segments, bucket_usages.objects, bucket_usages.created_at, bucket_usages.updated_at")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __api_key_hash_val, __bucket_name_val, __stored_bytes_val, __segments_val, __objects_val, __created_at_val, __updated_at_val)
+
+	bucket_usage = &BucketUsage{}
+	err = obj.driver.QueryRow(__stmt, __api_key_hash_val, __bucket_name_val, __stored_bytes_val, __segments_val, __objects_val, __created_at_val, __updated_at_val).Scan(&bucket_usage.Id, &bucket_usage.ApiKeyHash, &bucket_usage.BucketName, &bucket_usage.StoredBytes, &bucket_usage.Segments, &bucket_usage.Objects, &bucket_usage.CreatedAt, &bucket_usage.UpdatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return bucket_usage, nil
+
+}
+
+func (obj *postgresImpl) Create_ProjectLimit(ctx context.Context,
+	project_limit_api_key_hash ProjectLimit_ApiKeyHash_Field,
+	project_limit_storage_limit ProjectLimit_StorageLimit_Field,
+	project_limit_egress_limit ProjectLimit_EgressLimit_Field) (
+	project_limit *ProjectLimit, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__api_key_hash_val := project_limit_api_key_hash.value()
+	__storage_limit_val := project_limit_storage_limit.value()
+	__egress_limit_val := project_limit_egress_limit.value()
+	__created_at_val := __now
+	__updated_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO project_limits ( api_key_hash, storage_limit, egress_limit, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ? ) RETURNING project_limits.api_key_hash, project_limits.storage_limit, project_limits.egress_limit, project_limits.created_at, project_limits.updated_at")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __api_key_hash_val, __storage_limit_val, __egress_limit_val, __created_at_val, __updated_at_val)
+
+	project_limit = &ProjectLimit{}
+	err = obj.driver.QueryRow(__stmt, __api_key_hash_val, __storage_limit_val, __egress_limit_val, __created_at_val, __updated_at_val).Scan(&project_limit.ApiKeyHash, &project_limit.StorageLimit, &project_limit.EgressLimit, &project_limit.CreatedAt, &project_limit.UpdatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return project_limit, nil
+
+}
+
+func (obj *postgresImpl) First_Rollup_By_NodeId_And_StartTime(ctx context.Context,
+	rollup_node_id Rollup_NodeId_Field,
+	rollup_start_time Rollup_StartTime_Field) (
+	rollup *Rollup, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT rollups.id, rollups.node_id, rollups.start_time, rollups.at_rest_total, rollups.put_total, rollups.get_total, rollups.get_audit_total, rollups.get_repair_total, rollups.put_repair_total, rollups.created_at, rollups.updated_at FROM rollups WHERE rollups.node_id = ? AND rollups.start_time = ? LIMIT 1 OFFSET 0")
+
+	var __values []interface{}
+	__values = append(__values, rollup_node_id.value(), rollup_start_time.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	if !__rows.Next() {
+		if err := __rows.Err(); err != nil {
+			return nil, obj.makeErr(err)
+		}
+		return nil, nil
+	}
+
+	rollup = &Rollup{}
+	err = __rows.Scan(&rollup.Id, &rollup.NodeId, &rollup.StartTime, &rollup.AtRestTotal, &rollup.PutTotal, &rollup.GetTotal, &rollup.GetAuditTotal, &rollup.GetRepairTotal, &rollup.PutRepairTotal, &rollup.CreatedAt, &rollup.UpdatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+
+	return rollup, nil
+
+}
+
+func (obj *postgresImpl) First_Rollup_OrderBy_Desc_StartTime(ctx context.Context) (
+	rollup *Rollup, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT rollups.id, rollups.node_id, rollups.start_time, rollups.at_rest_total, rollups.put_total, rollups.get_total, rollups.get_audit_total, rollups.get_repair_total, rollups.put_repair_total, rollups.created_at, rollups.updated_at FROM rollups ORDER BY rollups.start_time DESC LIMIT 1 OFFSET 0")
+
+	var __values []interface{}
+	__values = append(__values)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	if !__rows.Next() {
+		if err := __rows.Err(); err != nil {
+			return nil, obj.makeErr(err)
+		}
+		return nil, nil
+	}
+
+	rollup = &Rollup{}
+	err = __rows.Scan(&rollup.Id, &rollup.NodeId, &rollup.StartTime, &rollup.AtRestTotal, &rollup.PutTotal, &rollup.GetTotal, &rollup.GetAuditTotal, &rollup.GetRepairTotal, &rollup.PutRepairTotal, &rollup.CreatedAt, &rollup.UpdatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+
+	return rollup, nil
+
+}
+
+func (obj *postgresImpl) All_Rollup_By_StartTime_GreaterOrEqual_And_StartTime_Less_OrderBy_Asc_NodeId(ctx context.Context,
+	rollup_start_time_greater_or_equal Rollup_StartTime_Field,
+	rollup_start_time_less Rollup_StartTime_Field) (
+	rows []*Rollup, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT rollups.id, rollups.node_id, rollups.start_time, rollups.at_rest_total, rollups.put_total, rollups.get_total, rollups.get_audit_total, rollups.get_repair_total, rollups.put_repair_total, rollups.created_at, rollups.updated_at FROM rollups WHERE rollups.start_time >= ? AND rollups.start_time < ? ORDER BY rollups.node_id")
+
+	var __values []interface{}
+	__values = append(__values, rollup_start_time_greater_or_equal.value(), rollup_start_time_less.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		rollup := &Rollup{}
+		err = __rows.Scan(&rollup.Id, &rollup.NodeId, &rollup.StartTime, &rollup.AtRestTotal, &rollup.PutTotal, &rollup.GetTotal, &rollup.GetAuditTotal, &rollup.GetRepairTotal, &rollup.PutRepairTotal, &rollup.CreatedAt, &rollup.UpdatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, rollup)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *postgresImpl) First_Granular_By_NodeId_And_StartTime(ctx context.Context,
+	granular_node_id Granular_NodeId_Field,
+	granular_start_time Granular_StartTime_Field) (
+	granular *Granular, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT granulars.id, granulars.node_id, granulars.start_time, granulars.end_time, granulars.data_total, granulars.created_at, granulars.updated_at FROM granulars WHERE granulars.node_id = ? AND granulars.start_time = ? LIMIT 1 OFFSET 0")
+
+	var __values []interface{}
+	__values = append(__values, granular_node_id.value(), granular_start_time.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	if !__rows.Next() {
+		if err := __rows.Err(); err != nil {
+			return nil, obj.makeErr(err)
+		}
+		return nil, nil
+	}
+
+	granular = &Granular{}
+	err = __rows.Scan(&granular.Id, &granular.NodeId, &granular.StartTime, &granular.EndTime, &granular.DataTotal, &granular.CreatedAt, &granular.UpdatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+
+	return granular, nil
+
+}
+
+func (obj *postgresImpl) All_Granular_By_EndTime_Greater_And_StartTime_Less(ctx context.Context,
+	granular_end_time_greater Granular_EndTime_Field,
+	granular_start_time_less Granular_StartTime_Field) (
+	rows []*Granular, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT granulars.id, granulars.node_id, granulars.start_time, granulars.end_time, granulars.data_total, granulars.created_at, granulars.updated_at FROM granulars WHERE granulars.end_time > ? AND granulars.start_time < ?")
+
+	var __values []interface{}
+	__values = append(__values, granular_end_time_greater.value(), granular_start_time_less.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		granular := &Granular{}
+		err = __rows.Scan(&granular.Id, &granular.NodeId, &granular.StartTime, &granular.EndTime, &granular.DataTotal, &granular.CreatedAt, &granular.UpdatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, granular)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *postgresImpl) First_Tally_By_Name(ctx context.Context,
+	tally_name Tally_Name_Field) (
+	tally *Tally, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT tallies.name, tallies.last_tally, tallies.pass_start, tallies.cursor_path, tallies.created_at, tallies.updated_at FROM tallies WHERE tallies.name = ? LIMIT 1 OFFSET 0")
+
+	var __values []interface{}
+	__values = append(__values, tally_name.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	if !__rows.Next() {
+		if err := __rows.Err(); err != nil {
+			return nil, obj.makeErr(err)
+		}
+		return nil, nil
+	}
+
+	tally = &Tally{}
+	err = __rows.Scan(&tally.Name, &tally.LastTally, &tally.PassStart, &tally.CursorPath, &tally.CreatedAt, &tally.UpdatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+
+	return tally, nil
+
+}
+
+func (obj *postgresImpl) First_BucketUsage_By_ApiKeyHash_And_BucketName(ctx context.Context,
+	bucket_usage_api_key_hash BucketUsage_ApiKeyHash_Field,
+	bucket_usage_bucket_name BucketUsage_BucketName_Field) (
+	bucket_usage *BucketUsage, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_usages.id, bucket_usages.api_key_hash, bucket_usages.bucket_name, bucket_usages.stored_bytes, bucket_usages.segments, bucket_usages.objects, bucket_usages.created_at, bucket_usages.updated_at FROM bucket_usages WHERE bucket_usages.api_key_hash = ? AND bucket_usages.bucket_name = ? LIMIT 1 OFFSET 0")
+
+	var __values []interface{}
+	__values = append(__values, bucket_usage_api_key_hash.value(), bucket_usage_bucket_name.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	if !__rows.Next() {
+		if err := __rows.Err(); err != nil {
+			return nil, obj.makeErr(err)
+		}
+		return nil, nil
+	}
+
+	bucket_usage = &BucketUsage{}
+	err = __rows.Scan(&bucket_usage.Id, &bucket_usage.ApiKeyHash, &bucket_usage.BucketName, &bucket_usage.StoredBytes, &bucket_usage.Segments, &bucket_usage.Objects, &bucket_usage.CreatedAt, &bucket_usage.UpdatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+
+	return bucket_usage, nil
+
+}
+
+func (obj *postgresImpl) All_BucketUsage_By_ApiKeyHash_OrderBy_Asc_BucketName(ctx context.Context,
+	bucket_usage_api_key_hash BucketUsage_ApiKeyHash_Field) (
+	rows []*BucketUsage, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_usages.id, bucket_usages.api_key_hash, bucket_usages.bucket_name, bucket_usages.stored_bytes, bucket_usages.segments, bucket_usages.objects, bucket_usages.created_at, bucket_usages.updated_at FROM bucket_usages WHERE bucket_usages.api_key_hash = ? ORDER BY bucket_usages.bucket_name")
+
+	var __values []interface{}
+	__values = append(__values, bucket_usage_api_key_hash.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		bucket_usage := &BucketUsage{}
+		err = __rows.Scan(&bucket_usage.Id, &bucket_usage.ApiKeyHash, &bucket_usage.BucketName, &bucket_usage.StoredBytes, &bucket_usage.Segments, &bucket_usage.Objects, &bucket_usage.CreatedAt, &bucket_usage.UpdatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, bucket_usage)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *postgresImpl) First_ProjectLimit_By_ApiKeyHash(ctx context.Context,
+	project_limit_api_key_hash ProjectLimit_ApiKeyHash_Field) (
+	project_limit *ProjectLimit, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_limits.api_key_hash, project_limits.storage_limit, project_limits.egress_limit, project_limits.created_at, project_limits.updated_at FROM project_limits WHERE project_limits.api_key_hash = ? LIMIT 1 OFFSET 0")
+
+	var __values []interface{}
+	__values = append(__values, project_limit_api_key_hash.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	if !__rows.Next() {
+		if err := __rows.Err(); err != nil {
+			return nil, obj.makeErr(err)
+		}
+		return nil, nil
+	}
+
+	project_limit = &ProjectLimit{}
+	err = __rows.Scan(&project_limit.ApiKeyHash, &project_limit.StorageLimit, &project_limit.EgressLimit, &project_limit.CreatedAt, &project_limit.UpdatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+
+	return project_limit, nil
+
+}
+
+func (obj *postgresImpl) Update_Rollup_By_Id(ctx context.Context,
+	rollup_id Rollup_Id_Field,
+	update Rollup_Update_Fields) (
+	rollup *Rollup, err error) {
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE rollups SET "), __sets, __sqlbundle_Literal(" WHERE rollups.id = ? RETURNING rollups.id, rollups.node_id, rollups.start_time, rollups.at_rest_total, rollups.put_total, rollups.get_total, rollups.get_audit_total, rollups.get_repair_total, rollups.put_repair_total, rollups.created_at, rollups.updated_at")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.AtRestTotal._set {
+		__values = append(__values, update.AtRestTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("at_rest_total = ?"))
+	}
+
+	if update.PutTotal._set {
+		__values = append(__values, update.PutTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("put_total = ?"))
+	}
+
+	if update.GetTotal._set {
+		__values = append(__values, update.GetTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("get_total = ?"))
+	}
+
+	if update.GetAuditTotal._set {
+		__values = append(__values, update.GetAuditTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("get_audit_total = ?"))
+	}
+
+	if update.GetRepairTotal._set {
+		__values = append(__values, update.GetRepairTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("get_repair_total = ?"))
+	}
+
+	if update.PutRepairTotal._set {
+		__values = append(__values, update.PutRepairTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("put_repair_total = ?"))
+	}
+
+	__now := obj.db.Hooks.Now().UTC()
+
+	__values = append(__values, __now)
+	__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("updated_at = ?"))
+
+	__args = append(__args, rollup_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	rollup = &Rollup{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&rollup.Id, &rollup.NodeId, &rollup.StartTime, &rollup.AtRestTotal, &rollup.PutTotal, &rollup.GetTotal, &rollup.GetAuditTotal, &rollup.GetRepairTotal, &rollup.PutRepairTotal, &rollup.CreatedAt, &rollup.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rollup, nil
+}
+
+func (obj *postgresImpl) Update_Granular_By_Id(ctx context.Context,
+	granular_id Granular_Id_Field,
+	update Granular_Update_Fields) (
+	granular *Granular, err error) {
+	var __sets = &__sqlbundle_Hole{}
 
-	// set at runtime
-	SQL __sqlbundle_SQL
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE granulars SET "), __sets, __sqlbundle_Literal(" WHERE granulars.id = ? RETURNING granulars.id, granulars.node_id, granulars.start_time, granulars.end_time, granulars.data_total, granulars.created_at, granulars.updated_at")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.DataTotal._set {
+		__values = append(__values, update.DataTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("data_total = ?"))
+	}
+
+	__now := obj.db.Hooks.Now().UTC()
+
+	__values = append(__values, __now)
+	__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("updated_at = ?"))
+
+	__args = append(__args, granular_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	granular = &Granular{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&granular.Id, &granular.NodeId, &granular.StartTime, &granular.EndTime, &granular.DataTotal, &granular.CreatedAt, &granular.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return granular, nil
+}
+
+func (obj *postgresImpl) Update_Tally_By_Name(ctx context.Context,
+	tally_name Tally_Name_Field,
+	update Tally_Update_Fields) (
+	tally *Tally, err error) {
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE tallies SET "), __sets, __sqlbundle_Literal(" WHERE tallies.name = ? RETURNING tallies.name, tallies.last_tally, tallies.pass_start, tallies.cursor_path, tallies.created_at, tallies.updated_at")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.LastTally._set {
+		__values = append(__values, update.LastTally.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_tally = ?"))
+	}
+
+	if update.PassStart._set {
+		__values = append(__values, update.PassStart.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("pass_start = ?"))
+	}
+
+	if update.CursorPath._set {
+		__values = append(__values, update.CursorPath.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("cursor_path = ?"))
+	}
+
+	__now := obj.db.Hooks.Now().UTC()
+
+	__values = append(__values, __now)
+	__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("updated_at = ?"))
+
+	__args = append(__args, tally_name.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	tally = &Tally{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&tally.Name, &tally.LastTally, &tally.PassStart, &tally.CursorPath, &tally.CreatedAt, &tally.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return tally, nil
+}
+
+func (obj *postgresImpl) Update_BucketUsage_By_Id(ctx context.Context,
+	bucket_usage_id BucketUsage_Id_Field,
+	update BucketUsage_Update_Fields) (
+	bucket_usage *BucketUsage, err error) {
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_usages SET "), __sets, __sqlbundle_Literal(" WHERE bucket_usages.id = ? RETURNING bucket_usages.id, bucket_usages.api_key_hash, bucket_usages.bucket_name, bucket_usages.stored_bytes, bucket_usages.segments, bucket_usages.objects, bucket_usages.created_at, bucket_usages.updated_at")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.StoredBytes._set {
+		__values = append(__values, update.StoredBytes.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("stored_bytes = ?"))
+	}
+
+	if update.Segments._set {
+		__values = append(__values, update.Segments.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("segments = ?"))
+	}
+
+	if update.Objects._set {
+		__values = append(__values, update.Objects.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("objects = ?"))
+	}
+
+	__now := obj.db.Hooks.Now().UTC()
+
+	__values = append(__values, __now)
+	__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("updated_at = ?"))
+
+	__args = append(__args, bucket_usage_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	bucket_usage = &BucketUsage{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&bucket_usage.Id, &bucket_usage.ApiKeyHash, &bucket_usage.BucketName, &bucket_usage.StoredBytes, &bucket_usage.Segments, &bucket_usage.Objects, &bucket_usage.CreatedAt, &bucket_usage.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return bucket_usage, nil
+}
+
+func (obj *postgresImpl) Update_ProjectLimit_By_ApiKeyHash(ctx context.Context,
+	project_limit_api_key_hash ProjectLimit_ApiKeyHash_Field,
+	update ProjectLimit_Update_Fields) (
+	project_limit *ProjectLimit, err error) {
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE project_limits SET "), __sets, __sqlbundle_Literal(" WHERE project_limits.api_key_hash = ? RETURNING project_limits.api_key_hash, project_limits.storage_limit, project_limits.egress_limit, project_limits.created_at, project_limits.updated_at")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.StorageLimit._set {
+		__values = append(__values, update.StorageLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("storage_limit = ?"))
+	}
+
+	if update.EgressLimit._set {
+		__values = append(__values, update.EgressLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("egress_limit = ?"))
+	}
+
+	__now := obj.db.Hooks.Now().UTC()
+
+	__values = append(__values, __now)
+	__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("updated_at = ?"))
+
+	__args = append(__args, project_limit_api_key_hash.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	project_limit = &ProjectLimit{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&project_limit.ApiKeyHash, &project_limit.StorageLimit, &project_limit.EgressLimit, &project_limit.CreatedAt, &project_limit.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return project_limit, nil
+}
+
+func (obj *postgresImpl) Delete_Rollup_By_Id(ctx context.Context,
+	rollup_id Rollup_Id_Field) (
+	deleted bool, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM rollups WHERE rollups.id = ?")
+
+	var __values []interface{}
+	__values = append(__values, rollup_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.Exec(__stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
+func (obj *postgresImpl) Delete_Granular_By_Id(ctx context.Context,
+	granular_id Granular_Id_Field) (
+	deleted bool, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM granulars WHERE granulars.id = ?")
+
+	var __values []interface{}
+	__values = append(__values, granular_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.Exec(__stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
+func (impl postgresImpl) isConstraintError(err error) (
+	constraint string, ok bool) {
+	if e, ok := err.(*pq.Error); ok {
+		if e.Code.Class() == "23" {
+			return e.Constraint, true
+		}
+	}
+	return "", false
 }
 
-func (*__sqlbundle_Hole) private() {}
+func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error) {
+	var __res sql.Result
+	var __count int64
+	__res, err = obj.driver.Exec("DELETE FROM project_limits;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM bucket_usages;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM tallies;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM granulars;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM rollups;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
 
-func (h *__sqlbundle_Hole) Render() string { return h.SQL.Render() }
+	return count, nil
 
-//
-// end runtime support for building sql statements
-//
+}
 
-func (obj *postgresImpl) Create_Aggregate(ctx context.Context,
-	aggregate_node_id Aggregate_NodeId_Field,
-	aggregate_start_time Aggregate_StartTime_Field,
-	aggregate_interval Aggregate_Interval_Field) (
-	aggregate *Aggregate, err error) {
+func (obj *sqlite3Impl) Create_Rollup(ctx context.Context,
+	rollup_node_id Rollup_NodeId_Field,
+	rollup_start_time Rollup_StartTime_Field,
+	rollup_at_rest_total Rollup_AtRestTotal_Field,
+	rollup_put_total Rollup_PutTotal_Field,
+	rollup_get_total Rollup_GetTotal_Field,
+	rollup_get_audit_total Rollup_GetAuditTotal_Field,
+	rollup_get_repair_total Rollup_GetRepairTotal_Field,
+	rollup_put_repair_total Rollup_PutRepairTotal_Field) (
+	rollup *Rollup, err error) {
 
 	__now := obj.db.Hooks.Now().UTC()
-	__node_id_val := aggregate_node_id.value()
-	__start_time_val := aggregate_start_time.value()
-	__interval_val := aggregate_interval.value()
+	__node_id_val := rollup_node_id.value()
+	__start_time_val := rollup_start_time.value()
+	__at_rest_total_val := rollup_at_rest_total.value()
+	__put_total_val := rollup_put_total.value()
+	__get_total_val := rollup_get_total.value()
+	__get_audit_total_val := rollup_get_audit_total.value()
+	__get_repair_total_val := rollup_get_repair_total.value()
+	__put_repair_total_val := rollup_put_repair_total.value()
 	__created_at_val := __now
 	__updated_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO aggregates ( node_id, start_time, interval, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ? ) RETURNING aggregates.node_id, aggregates.start_time, aggregates.interval, aggregates.created_at, aggregates.updated_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO rollups ( node_id, start_time, at_rest_total, put_total, get_total, get_audit_total, get_repair_total, put_repair_total, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __node_id_val, __start_time_val, __interval_val, __created_at_val, __updated_at_val)
+	obj.logStmt(__stmt, __node_id_val, __start_time_val, __at_rest_total_val, __put_total_val, __get_total_val, __get_audit_total_val, __get_repair_total_val, __put_repair_total_val, __created_at_val, __updated_at_val)
 
-	aggregate = &Aggregate{}
-	err = obj.driver.QueryRow(__stmt, __node_id_val, __start_time_val, __interval_val, __created_at_val, __updated_at_val).Scan(&aggregate.NodeId, &aggregate.StartTime, &aggregate.Interval, &aggregate.CreatedAt, &aggregate.UpdatedAt)
+	__res, err := obj.driver.Exec(__stmt, __node_id_val, __start_time_val, __at_rest_total_val, __put_total_val, __get_total_val, __get_audit_total_val, __get_repair_total_val, __put_repair_total_val, __created_at_val, __updated_at_val)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	__pk, err := __res.LastInsertId()
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return aggregate, nil
+	return obj.getLastRollup(ctx, __pk)
 
 }
 
-func (obj *postgresImpl) Create_Granular(ctx context.Context,
+func (obj *sqlite3Impl) Create_Granular(ctx context.Context,
 	granular_node_id Granular_NodeId_Field,
 	granular_start_time Granular_StartTime_Field,
 	granular_end_time Granular_EndTime_Field,
@@ -871,373 +2329,645 @@ func (obj *postgresImpl) Create_Granular(ctx context.Context,
 	__created_at_val := __now
 	__updated_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO granulars ( node_id, start_time, end_time, data_total, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ? ) RETURNING granulars.node_id, granulars.start_time, granulars.end_time, granulars.data_total, granulars.created_at, granulars.updated_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO granulars ( node_id, start_time, end_time, data_total, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ? )")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __node_id_val, __start_time_val, __end_time_val, __data_total_val, __created_at_val, __updated_at_val)
 
-	granular = &Granular{}
-	err = obj.driver.QueryRow(__stmt, __node_id_val, __start_time_val, __end_time_val, __data_total_val, __created_at_val, __updated_at_val).Scan(&granular.NodeId, &granular.StartTime, &granular.EndTime, &granular.DataTotal, &granular.CreatedAt, &granular.UpdatedAt)
+	__res, err := obj.driver.Exec(__stmt, __node_id_val, __start_time_val, __end_time_val, __data_total_val, __created_at_val, __updated_at_val)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return granular, nil
+	__pk, err := __res.LastInsertId()
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return obj.getLastGranular(ctx, __pk)
 
 }
 
-func (obj *postgresImpl) Get_Aggregate_By_NodeId(ctx context.Context,
-	aggregate_node_id Aggregate_NodeId_Field) (
-	aggregate *Aggregate, err error) {
+func (obj *sqlite3Impl) Create_Tally(ctx context.Context,
+	tally_name Tally_Name_Field,
+	tally_last_tally Tally_LastTally_Field,
+	tally_pass_start Tally_PassStart_Field,
+	tally_cursor_path Tally_CursorPath_Field) (
+	tally *Tally, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT aggregates.node_id, aggregates.start_time, aggregates.interval, aggregates.created_at, aggregates.updated_at FROM aggregates WHERE aggregates.node_id = ?")
+	__now := obj.db.Hooks.Now().UTC()
+	__name_val := tally_name.value()
+	__last_tally_val := tally_last_tally.value()
+	__pass_start_val := tally_pass_start.value()
+	__cursor_path_val := tally_cursor_path.value()
+	__created_at_val := __now
+	__updated_at_val := __now
 
-	var __values []interface{}
-	__values = append(__values, aggregate_node_id.value())
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO tallies ( name, last_tally, pass_start, cursor_path, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ? )")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __values...)
+	obj.logStmt(__stmt, __name_val, __last_tally_val, __pass_start_val, __cursor_path_val, __created_at_val, __updated_at_val)
 
-	aggregate = &Aggregate{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&aggregate.NodeId, &aggregate.StartTime, &aggregate.Interval, &aggregate.CreatedAt, &aggregate.UpdatedAt)
+	__res, err := obj.driver.Exec(__stmt, __name_val, __last_tally_val, __pass_start_val, __cursor_path_val, __created_at_val, __updated_at_val)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	__pk, err := __res.LastInsertId()
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return aggregate, nil
+	return obj.getLastTally(ctx, __pk)
 
 }
 
-func (obj *postgresImpl) Get_Granular_By_NodeId(ctx context.Context,
-	granular_node_id Granular_NodeId_Field) (
-	granular *Granular, err error) {
+func (obj *sqlite3Impl) Create_BucketUsage(ctx context.Context,
+	bucket_usage_api_key_hash BucketUsage_ApiKeyHash_Field,
+	bucket_usage_bucket_name BucketUsage_BucketName_Field,
+	bucket_usage_stored_bytes BucketUsage_StoredBytes_Field,
+	bucket_usage_segments BucketUsage_Segments_Field,
+	bucket_usage_objects BucketUsage_Objects_Field) (
+	bucket_usage *BucketUsage, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT granulars.node_id, granulars.start_time, granulars.end_time, granulars.data_total, granulars.created_at, granulars.updated_at FROM granulars WHERE granulars.node_id = ?")
+	__now := obj.db.Hooks.Now().UTC()
+	__api_key_hash_val := bucket_usage_api_key_hash.value()
+	__bucket_name_val := bucket_usage_bucket_name.value()
+	__stored_bytes_val := bucket_usage_stored_bytes.value()
+	__segments_val := bucket_usage_segments.value()
+	__objects_val := bucket_usage_objects.value()
+	__created_at_val := __now
+	__updated_at_val := __now
 
-	var __values []interface{}
-	__values = append(__values, granular_node_id.value())
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO bucket_usages ( api_key_hash, bucket_name, stored_bytes, segments, objects, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ?, ? )")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __values...)
+	obj.logStmt(__stmt, __api_key_hash_val, __bucket_name_val, __stored_bytes_val, __segments_val, __objects_val, __created_at_val, __updated_at_val)
 
-	granular = &Granular{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&granular.NodeId, &granular.StartTime, &granular.EndTime, &granular.DataTotal, &granular.CreatedAt, &granular.UpdatedAt)
+	__res, err := obj.driver.Exec(__stmt, __api_key_hash_val, __bucket_name_val, __stored_bytes_val, __segments_val, __objects_val, __created_at_val, __updated_at_val)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return granular, nil
+	__pk, err := __res.LastInsertId()
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return obj.getLastBucketUsage(ctx, __pk)
 
 }
 
-func (obj *postgresImpl) Update_Aggregate_By_NodeId(ctx context.Context,
-	aggregate_node_id Aggregate_NodeId_Field,
-	update Aggregate_Update_Fields) (
-	aggregate *Aggregate, err error) {
-	var __sets = &__sqlbundle_Hole{}
+func (obj *sqlite3Impl) Create_ProjectLimit(ctx context.Context,
+	project_limit_api_key_hash ProjectLimit_ApiKeyHash_Field,
+	project_limit_storage_limit ProjectLimit_StorageLimit_Field,
+	project_limit_egress_limit ProjectLimit_EgressLimit_Field) (
+	project_limit *ProjectLimit, err error) {
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE aggregates SET "), __sets, __sqlbundle_Literal(" WHERE aggregates.node_id = ? RETURNING aggregates.node_id, aggregates.start_time, aggregates.interval, aggregates.created_at, aggregates.updated_at")}}
+	__now := obj.db.Hooks.Now().UTC()
+	__api_key_hash_val := project_limit_api_key_hash.value()
+	__storage_limit_val := project_limit_storage_limit.value()
+	__egress_limit_val := project_limit_egress_limit.value()
+	__created_at_val := __now
+	__updated_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO project_limits ( api_key_hash, storage_limit, egress_limit, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ? )")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __api_key_hash_val, __storage_limit_val, __egress_limit_val, __created_at_val, __updated_at_val)
+
+	__res, err := obj.driver.Exec(__stmt, __api_key_hash_val, __storage_limit_val, __egress_limit_val, __created_at_val, __updated_at_val)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	__pk, err := __res.LastInsertId()
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return obj.getLastProjectLimit(ctx, __pk)
+
+}
+
+func (obj *sqlite3Impl) First_Rollup_By_NodeId_And_StartTime(ctx context.Context,
+	rollup_node_id Rollup_NodeId_Field,
+	rollup_start_time Rollup_StartTime_Field) (
+	rollup *Rollup, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT rollups.id, rollups.node_id, rollups.start_time, rollups.at_rest_total, rollups.put_total, rollups.get_total, rollups.get_audit_total, rollups.get_repair_total, rollups.put_repair_total, rollups.created_at, rollups.updated_at FROM rollups WHERE rollups.node_id = ? AND rollups.start_time = ? LIMIT 1 OFFSET 0")
 
-	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
-	var __args []interface{}
+	__values = append(__values, rollup_node_id.value(), rollup_start_time.value())
 
-	if update.StartTime._set {
-		__values = append(__values, update.StartTime.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("start_time = ?"))
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
 	}
+	defer __rows.Close()
 
-	if update.Interval._set {
-		__values = append(__values, update.Interval.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("interval = ?"))
+	if !__rows.Next() {
+		if err := __rows.Err(); err != nil {
+			return nil, obj.makeErr(err)
+		}
+		return nil, nil
 	}
 
-	__now := obj.db.Hooks.Now().UTC()
+	rollup = &Rollup{}
+	err = __rows.Scan(&rollup.Id, &rollup.NodeId, &rollup.StartTime, &rollup.AtRestTotal, &rollup.PutTotal, &rollup.GetTotal, &rollup.GetAuditTotal, &rollup.GetRepairTotal, &rollup.PutRepairTotal, &rollup.CreatedAt, &rollup.UpdatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
 
-	__values = append(__values, __now)
-	__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("updated_at = ?"))
+	return rollup, nil
 
-	__args = append(__args, aggregate_node_id.value())
+}
 
-	__values = append(__values, __args...)
-	__sets.SQL = __sets_sql
+func (obj *sqlite3Impl) First_Rollup_OrderBy_Desc_StartTime(ctx context.Context) (
+	rollup *Rollup, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT rollups.id, rollups.node_id, rollups.start_time, rollups.at_rest_total, rollups.put_total, rollups.get_total, rollups.get_audit_total, rollups.get_repair_total, rollups.put_repair_total, rollups.created_at, rollups.updated_at FROM rollups ORDER BY rollups.start_time DESC LIMIT 1 OFFSET 0")
+
+	var __values []interface{}
+	__values = append(__values)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	aggregate = &Aggregate{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&aggregate.NodeId, &aggregate.StartTime, &aggregate.Interval, &aggregate.CreatedAt, &aggregate.UpdatedAt)
-	if err == sql.ErrNoRows {
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	if !__rows.Next() {
+		if err := __rows.Err(); err != nil {
+			return nil, obj.makeErr(err)
+		}
 		return nil, nil
 	}
+
+	rollup = &Rollup{}
+	err = __rows.Scan(&rollup.Id, &rollup.NodeId, &rollup.StartTime, &rollup.AtRestTotal, &rollup.PutTotal, &rollup.GetTotal, &rollup.GetAuditTotal, &rollup.GetRepairTotal, &rollup.PutRepairTotal, &rollup.CreatedAt, &rollup.UpdatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return aggregate, nil
+
+	return rollup, nil
+
 }
 
-func (obj *postgresImpl) Update_Granular_By_NodeId(ctx context.Context,
-	granular_node_id Granular_NodeId_Field,
-	update Granular_Update_Fields) (
-	granular *Granular, err error) {
-	var __sets = &__sqlbundle_Hole{}
+func (obj *sqlite3Impl) All_Rollup_By_StartTime_GreaterOrEqual_And_StartTime_Less_OrderBy_Asc_NodeId(ctx context.Context,
+	rollup_start_time_greater_or_equal Rollup_StartTime_Field,
+	rollup_start_time_less Rollup_StartTime_Field) (
+	rows []*Rollup, err error) {
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE granulars SET "), __sets, __sqlbundle_Literal(" WHERE granulars.node_id = ? RETURNING granulars.node_id, granulars.start_time, granulars.end_time, granulars.data_total, granulars.created_at, granulars.updated_at")}}
+	var __embed_stmt = __sqlbundle_Literal("SELECT rollups.id, rollups.node_id, rollups.start_time, rollups.at_rest_total, rollups.put_total, rollups.get_total, rollups.get_audit_total, rollups.get_repair_total, rollups.put_repair_total, rollups.created_at, rollups.updated_at FROM rollups WHERE rollups.start_time >= ? AND rollups.start_time < ? ORDER BY rollups.node_id")
 
-	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
-	var __args []interface{}
+	__values = append(__values, rollup_start_time_greater_or_equal.value(), rollup_start_time_less.value())
 
-	if update.StartTime._set {
-		__values = append(__values, update.StartTime.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("start_time = ?"))
-	}
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
 
-	if update.EndTime._set {
-		__values = append(__values, update.EndTime.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("end_time = ?"))
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
 	}
+	defer __rows.Close()
 
-	if update.DataTotal._set {
-		__values = append(__values, update.DataTotal.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("data_total = ?"))
+	for __rows.Next() {
+		rollup := &Rollup{}
+		err = __rows.Scan(&rollup.Id, &rollup.NodeId, &rollup.StartTime, &rollup.AtRestTotal, &rollup.PutTotal, &rollup.GetTotal, &rollup.GetAuditTotal, &rollup.GetRepairTotal, &rollup.PutRepairTotal, &rollup.CreatedAt, &rollup.UpdatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, rollup)
 	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
 
-	__now := obj.db.Hooks.Now().UTC()
+}
 
-	__values = append(__values, __now)
-	__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("updated_at = ?"))
+func (obj *sqlite3Impl) First_Granular_By_NodeId_And_StartTime(ctx context.Context,
+	granular_node_id Granular_NodeId_Field,
+	granular_start_time Granular_StartTime_Field) (
+	granular *Granular, err error) {
 
-	__args = append(__args, granular_node_id.value())
+	var __embed_stmt = __sqlbundle_Literal("SELECT granulars.id, granulars.node_id, granulars.start_time, granulars.end_time, granulars.data_total, granulars.created_at, granulars.updated_at FROM granulars WHERE granulars.node_id = ? AND granulars.start_time = ? LIMIT 1 OFFSET 0")
 
-	__values = append(__values, __args...)
-	__sets.SQL = __sets_sql
+	var __values []interface{}
+	__values = append(__values, granular_node_id.value(), granular_start_time.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	granular = &Granular{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&granular.NodeId, &granular.StartTime, &granular.EndTime, &granular.DataTotal, &granular.CreatedAt, &granular.UpdatedAt)
-	if err == sql.ErrNoRows {
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	if !__rows.Next() {
+		if err := __rows.Err(); err != nil {
+			return nil, obj.makeErr(err)
+		}
 		return nil, nil
 	}
+
+	granular = &Granular{}
+	err = __rows.Scan(&granular.Id, &granular.NodeId, &granular.StartTime, &granular.EndTime, &granular.DataTotal, &granular.CreatedAt, &granular.UpdatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
+
 	return granular, nil
+
 }
 
-func (obj *postgresImpl) Delete_Aggregate_By_NodeId(ctx context.Context,
-	aggregate_node_id Aggregate_NodeId_Field) (
-	deleted bool, err error) {
+func (obj *sqlite3Impl) All_Granular_By_EndTime_Greater_And_StartTime_Less(ctx context.Context,
+	granular_end_time_greater Granular_EndTime_Field,
+	granular_start_time_less Granular_StartTime_Field) (
+	rows []*Granular, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("DELETE FROM aggregates WHERE aggregates.node_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT granulars.id, granulars.node_id, granulars.start_time, granulars.end_time, granulars.data_total, granulars.created_at, granulars.updated_at FROM granulars WHERE granulars.end_time > ? AND granulars.start_time < ?")
 
 	var __values []interface{}
-	__values = append(__values, aggregate_node_id.value())
+	__values = append(__values, granular_end_time_greater.value(), granular_start_time_less.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	__res, err := obj.driver.Exec(__stmt, __values...)
+	__rows, err := obj.driver.Query(__stmt, __values...)
 	if err != nil {
-		return false, obj.makeErr(err)
+		return nil, obj.makeErr(err)
 	}
+	defer __rows.Close()
 
-	__count, err := __res.RowsAffected()
-	if err != nil {
-		return false, obj.makeErr(err)
+	for __rows.Next() {
+		granular := &Granular{}
+		err = __rows.Scan(&granular.Id, &granular.NodeId, &granular.StartTime, &granular.EndTime, &granular.DataTotal, &granular.CreatedAt, &granular.UpdatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, granular)
 	}
-
-	return __count > 0, nil
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
 
 }
 
-func (obj *postgresImpl) Delete_Granular_By_NodeId(ctx context.Context,
-	granular_node_id Granular_NodeId_Field) (
-	deleted bool, err error) {
+func (obj *sqlite3Impl) First_Tally_By_Name(ctx context.Context,
+	tally_name Tally_Name_Field) (
+	tally *Tally, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("DELETE FROM granulars WHERE granulars.node_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT tallies.name, tallies.last_tally, tallies.pass_start, tallies.cursor_path, tallies.created_at, tallies.updated_at FROM tallies WHERE tallies.name = ? LIMIT 1 OFFSET 0")
 
 	var __values []interface{}
-	__values = append(__values, granular_node_id.value())
+	__values = append(__values, tally_name.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	__res, err := obj.driver.Exec(__stmt, __values...)
+	__rows, err := obj.driver.Query(__stmt, __values...)
 	if err != nil {
-		return false, obj.makeErr(err)
+		return nil, obj.makeErr(err)
 	}
+	defer __rows.Close()
 
-	__count, err := __res.RowsAffected()
+	if !__rows.Next() {
+		if err := __rows.Err(); err != nil {
+			return nil, obj.makeErr(err)
+		}
+		return nil, nil
+	}
+
+	tally = &Tally{}
+	err = __rows.Scan(&tally.Name, &tally.LastTally, &tally.PassStart, &tally.CursorPath, &tally.CreatedAt, &tally.UpdatedAt)
 	if err != nil {
-		return false, obj.makeErr(err)
+		return nil, obj.makeErr(err)
 	}
 
-	return __count > 0, nil
+	return tally, nil
 
 }
 
-func (impl postgresImpl) isConstraintError(err error) (
-	constraint string, ok bool) {
-	if e, ok := err.(*pq.Error); ok {
-		if e.Code.Class() == "23" {
-			return e.Constraint, true
+func (obj *sqlite3Impl) First_BucketUsage_By_ApiKeyHash_And_BucketName(ctx context.Context,
+	bucket_usage_api_key_hash BucketUsage_ApiKeyHash_Field,
+	bucket_usage_bucket_name BucketUsage_BucketName_Field) (
+	bucket_usage *BucketUsage, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_usages.id, bucket_usages.api_key_hash, bucket_usages.bucket_name, bucket_usages.stored_bytes, bucket_usages.segments, bucket_usages.objects, bucket_usages.created_at, bucket_usages.updated_at FROM bucket_usages WHERE bucket_usages.api_key_hash = ? AND bucket_usages.bucket_name = ? LIMIT 1 OFFSET 0")
+
+	var __values []interface{}
+	__values = append(__values, bucket_usage_api_key_hash.value(), bucket_usage_bucket_name.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	if !__rows.Next() {
+		if err := __rows.Err(); err != nil {
+			return nil, obj.makeErr(err)
 		}
+		return nil, nil
 	}
-	return "", false
-}
 
-func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error) {
-	var __res sql.Result
-	var __count int64
-	__res, err = obj.driver.Exec("DELETE FROM granulars;")
+	bucket_usage = &BucketUsage{}
+	err = __rows.Scan(&bucket_usage.Id, &bucket_usage.ApiKeyHash, &bucket_usage.BucketName, &bucket_usage.StoredBytes, &bucket_usage.Segments, &bucket_usage.Objects, &bucket_usage.CreatedAt, &bucket_usage.UpdatedAt)
 	if err != nil {
-		return 0, obj.makeErr(err)
+		return nil, obj.makeErr(err)
 	}
 
-	__count, err = __res.RowsAffected()
+	return bucket_usage, nil
+
+}
+
+func (obj *sqlite3Impl) All_BucketUsage_By_ApiKeyHash_OrderBy_Asc_BucketName(ctx context.Context,
+	bucket_usage_api_key_hash BucketUsage_ApiKeyHash_Field) (
+	rows []*BucketUsage, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_usages.id, bucket_usages.api_key_hash, bucket_usages.bucket_name, bucket_usages.stored_bytes, bucket_usages.segments, bucket_usages.objects, bucket_usages.created_at, bucket_usages.updated_at FROM bucket_usages WHERE bucket_usages.api_key_hash = ? ORDER BY bucket_usages.bucket_name")
+
+	var __values []interface{}
+	__values = append(__values, bucket_usage_api_key_hash.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
 	if err != nil {
-		return 0, obj.makeErr(err)
+		return nil, obj.makeErr(err)
 	}
-	count += __count
-	__res, err = obj.driver.Exec("DELETE FROM aggregates;")
+	defer __rows.Close()
+
+	for __rows.Next() {
+		bucket_usage := &BucketUsage{}
+		err = __rows.Scan(&bucket_usage.Id, &bucket_usage.ApiKeyHash, &bucket_usage.BucketName, &bucket_usage.StoredBytes, &bucket_usage.Segments, &bucket_usage.Objects, &bucket_usage.CreatedAt, &bucket_usage.UpdatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, bucket_usage)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *sqlite3Impl) First_ProjectLimit_By_ApiKeyHash(ctx context.Context,
+	project_limit_api_key_hash ProjectLimit_ApiKeyHash_Field) (
+	project_limit *ProjectLimit, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_limits.api_key_hash, project_limits.storage_limit, project_limits.egress_limit, project_limits.created_at, project_limits.updated_at FROM project_limits WHERE project_limits.api_key_hash = ? LIMIT 1 OFFSET 0")
+
+	var __values []interface{}
+	__values = append(__values, project_limit_api_key_hash.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
 	if err != nil {
-		return 0, obj.makeErr(err)
+		return nil, obj.makeErr(err)
 	}
+	defer __rows.Close()
 
-	__count, err = __res.RowsAffected()
+	if !__rows.Next() {
+		if err := __rows.Err(); err != nil {
+			return nil, obj.makeErr(err)
+		}
+		return nil, nil
+	}
+
+	project_limit = &ProjectLimit{}
+	err = __rows.Scan(&project_limit.ApiKeyHash, &project_limit.StorageLimit, &project_limit.EgressLimit, &project_limit.CreatedAt, &project_limit.UpdatedAt)
 	if err != nil {
-		return 0, obj.makeErr(err)
+		return nil, obj.makeErr(err)
 	}
-	count += __count
 
-	return count, nil
+	return project_limit, nil
 
 }
 
-func (obj *sqlite3Impl) Create_Aggregate(ctx context.Context,
-	aggregate_node_id Aggregate_NodeId_Field,
-	aggregate_start_time Aggregate_StartTime_Field,
-	aggregate_interval Aggregate_Interval_Field) (
-	aggregate *Aggregate, err error) {
+func (obj *sqlite3Impl) Update_Rollup_By_Id(ctx context.Context,
+	rollup_id Rollup_Id_Field,
+	update Rollup_Update_Fields) (
+	rollup *Rollup, err error) {
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE rollups SET "), __sets, __sqlbundle_Literal(" WHERE rollups.id = ?")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.AtRestTotal._set {
+		__values = append(__values, update.AtRestTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("at_rest_total = ?"))
+	}
+
+	if update.PutTotal._set {
+		__values = append(__values, update.PutTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("put_total = ?"))
+	}
+
+	if update.GetTotal._set {
+		__values = append(__values, update.GetTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("get_total = ?"))
+	}
+
+	if update.GetAuditTotal._set {
+		__values = append(__values, update.GetAuditTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("get_audit_total = ?"))
+	}
+
+	if update.GetRepairTotal._set {
+		__values = append(__values, update.GetRepairTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("get_repair_total = ?"))
+	}
+
+	if update.PutRepairTotal._set {
+		__values = append(__values, update.PutRepairTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("put_repair_total = ?"))
+	}
 
 	__now := obj.db.Hooks.Now().UTC()
-	__node_id_val := aggregate_node_id.value()
-	__start_time_val := aggregate_start_time.value()
-	__interval_val := aggregate_interval.value()
-	__created_at_val := __now
-	__updated_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO aggregates ( node_id, start_time, interval, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ? )")
+	__values = append(__values, __now)
+	__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("updated_at = ?"))
+
+	__args = append(__args, rollup_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __node_id_val, __start_time_val, __interval_val, __created_at_val, __updated_at_val)
+	obj.logStmt(__stmt, __values...)
 
-	__res, err := obj.driver.Exec(__stmt, __node_id_val, __start_time_val, __interval_val, __created_at_val, __updated_at_val)
+	rollup = &Rollup{}
+	_, err = obj.driver.Exec(__stmt, __values...)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	__pk, err := __res.LastInsertId()
+
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT rollups.id, rollups.node_id, rollups.start_time, rollups.at_rest_total, rollups.put_total, rollups.get_total, rollups.get_audit_total, rollups.get_repair_total, rollups.put_repair_total, rollups.created_at, rollups.updated_at FROM rollups WHERE rollups.id = ?")
+
+	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
+	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
+
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&rollup.Id, &rollup.NodeId, &rollup.StartTime, &rollup.AtRestTotal, &rollup.PutTotal, &rollup.GetTotal, &rollup.GetAuditTotal, &rollup.GetRepairTotal, &rollup.PutRepairTotal, &rollup.CreatedAt, &rollup.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return obj.getLastAggregate(ctx, __pk)
-
+	return rollup, nil
 }
 
-func (obj *sqlite3Impl) Create_Granular(ctx context.Context,
-	granular_node_id Granular_NodeId_Field,
-	granular_start_time Granular_StartTime_Field,
-	granular_end_time Granular_EndTime_Field,
-	granular_data_total Granular_DataTotal_Field) (
+func (obj *sqlite3Impl) Update_Granular_By_Id(ctx context.Context,
+	granular_id Granular_Id_Field,
+	update Granular_Update_Fields) (
 	granular *Granular, err error) {
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE granulars SET "), __sets, __sqlbundle_Literal(" WHERE granulars.id = ?")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.DataTotal._set {
+		__values = append(__values, update.DataTotal.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("data_total = ?"))
+	}
 
 	__now := obj.db.Hooks.Now().UTC()
-	__node_id_val := granular_node_id.value()
-	__start_time_val := granular_start_time.value()
-	__end_time_val := granular_end_time.value()
-	__data_total_val := granular_data_total.value()
-	__created_at_val := __now
-	__updated_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO granulars ( node_id, start_time, end_time, data_total, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ? )")
+	__values = append(__values, __now)
+	__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("updated_at = ?"))
+
+	__args = append(__args, granular_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __node_id_val, __start_time_val, __end_time_val, __data_total_val, __created_at_val, __updated_at_val)
+	obj.logStmt(__stmt, __values...)
 
-	__res, err := obj.driver.Exec(__stmt, __node_id_val, __start_time_val, __end_time_val, __data_total_val, __created_at_val, __updated_at_val)
+	granular = &Granular{}
+	_, err = obj.driver.Exec(__stmt, __values...)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	__pk, err := __res.LastInsertId()
+
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT granulars.id, granulars.node_id, granulars.start_time, granulars.end_time, granulars.data_total, granulars.created_at, granulars.updated_at FROM granulars WHERE granulars.id = ?")
+
+	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
+	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
+
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&granular.Id, &granular.NodeId, &granular.StartTime, &granular.EndTime, &granular.DataTotal, &granular.CreatedAt, &granular.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return obj.getLastGranular(ctx, __pk)
-
+	return granular, nil
 }
 
-func (obj *sqlite3Impl) Get_Aggregate_By_NodeId(ctx context.Context,
-	aggregate_node_id Aggregate_NodeId_Field) (
-	aggregate *Aggregate, err error) {
+func (obj *sqlite3Impl) Update_Tally_By_Name(ctx context.Context,
+	tally_name Tally_Name_Field,
+	update Tally_Update_Fields) (
+	tally *Tally, err error) {
+	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT aggregates.node_id, aggregates.start_time, aggregates.interval, aggregates.created_at, aggregates.updated_at FROM aggregates WHERE aggregates.node_id = ?")
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE tallies SET "), __sets, __sqlbundle_Literal(" WHERE tallies.name = ?")}}
 
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
-	__values = append(__values, aggregate_node_id.value())
+	var __args []interface{}
 
-	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __values...)
+	if update.LastTally._set {
+		__values = append(__values, update.LastTally.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_tally = ?"))
+	}
 
-	aggregate = &Aggregate{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&aggregate.NodeId, &aggregate.StartTime, &aggregate.Interval, &aggregate.CreatedAt, &aggregate.UpdatedAt)
-	if err != nil {
-		return nil, obj.makeErr(err)
+	if update.PassStart._set {
+		__values = append(__values, update.PassStart.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("pass_start = ?"))
 	}
-	return aggregate, nil
 
-}
+	if update.CursorPath._set {
+		__values = append(__values, update.CursorPath.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("cursor_path = ?"))
+	}
 
-func (obj *sqlite3Impl) Get_Granular_By_NodeId(ctx context.Context,
-	granular_node_id Granular_NodeId_Field) (
-	granular *Granular, err error) {
+	__now := obj.db.Hooks.Now().UTC()
+
+	__values = append(__values, __now)
+	__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("updated_at = ?"))
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT granulars.node_id, granulars.start_time, granulars.end_time, granulars.data_total, granulars.created_at, granulars.updated_at FROM granulars WHERE granulars.node_id = ?")
+	__args = append(__args, tally_name.value())
 
-	var __values []interface{}
-	__values = append(__values, granular_node_id.value())
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	granular = &Granular{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&granular.NodeId, &granular.StartTime, &granular.EndTime, &granular.DataTotal, &granular.CreatedAt, &granular.UpdatedAt)
+	tally = &Tally{}
+	_, err = obj.driver.Exec(__stmt, __values...)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return granular, nil
 
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT tallies.name, tallies.last_tally, tallies.pass_start, tallies.cursor_path, tallies.created_at, tallies.updated_at FROM tallies WHERE tallies.name = ?")
+
+	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
+	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
+
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&tally.Name, &tally.LastTally, &tally.PassStart, &tally.CursorPath, &tally.CreatedAt, &tally.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return tally, nil
 }
 
-func (obj *sqlite3Impl) Update_Aggregate_By_NodeId(ctx context.Context,
-	aggregate_node_id Aggregate_NodeId_Field,
-	update Aggregate_Update_Fields) (
-	aggregate *Aggregate, err error) {
+func (obj *sqlite3Impl) Update_BucketUsage_By_Id(ctx context.Context,
+	bucket_usage_id BucketUsage_Id_Field,
+	update BucketUsage_Update_Fields) (
+	bucket_usage *BucketUsage, err error) {
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE aggregates SET "), __sets, __sqlbundle_Literal(" WHERE aggregates.node_id = ?")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_usages SET "), __sets, __sqlbundle_Literal(" WHERE bucket_usages.id = ?")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
 	var __args []interface{}
 
-	if update.StartTime._set {
-		__values = append(__values, update.StartTime.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("start_time = ?"))
+	if update.StoredBytes._set {
+		__values = append(__values, update.StoredBytes.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("stored_bytes = ?"))
+	}
+
+	if update.Segments._set {
+		__values = append(__values, update.Segments.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("segments = ?"))
 	}
 
-	if update.Interval._set {
-		__values = append(__values, update.Interval.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("interval = ?"))
+	if update.Objects._set {
+		__values = append(__values, update.Objects.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("objects = ?"))
 	}
 
 	__now := obj.db.Hooks.Now().UTC()
@@ -1245,7 +2975,7 @@ func (obj *sqlite3Impl) Update_Aggregate_By_NodeId(ctx context.Context,
 	__values = append(__values, __now)
 	__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("updated_at = ?"))
 
-	__args = append(__args, aggregate_node_id.value())
+	__args = append(__args, bucket_usage_id.value())
 
 	__values = append(__values, __args...)
 	__sets.SQL = __sets_sql
@@ -1253,52 +2983,47 @@ func (obj *sqlite3Impl) Update_Aggregate_By_NodeId(ctx context.Context,
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	aggregate = &Aggregate{}
+	bucket_usage = &BucketUsage{}
 	_, err = obj.driver.Exec(__stmt, __values...)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
 
-	var __embed_stmt_get = __sqlbundle_Literal("SELECT aggregates.node_id, aggregates.start_time, aggregates.interval, aggregates.created_at, aggregates.updated_at FROM aggregates WHERE aggregates.node_id = ?")
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT bucket_usages.id, bucket_usages.api_key_hash, bucket_usages.bucket_name, bucket_usages.stored_bytes, bucket_usages.segments, bucket_usages.objects, bucket_usages.created_at, bucket_usages.updated_at FROM bucket_usages WHERE bucket_usages.id = ?")
 
 	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
 	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
 
-	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&aggregate.NodeId, &aggregate.StartTime, &aggregate.Interval, &aggregate.CreatedAt, &aggregate.UpdatedAt)
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&bucket_usage.Id, &bucket_usage.ApiKeyHash, &bucket_usage.BucketName, &bucket_usage.StoredBytes, &bucket_usage.Segments, &bucket_usage.Objects, &bucket_usage.CreatedAt, &bucket_usage.UpdatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return aggregate, nil
+	return bucket_usage, nil
 }
 
-func (obj *sqlite3Impl) Update_Granular_By_NodeId(ctx context.Context,
-	granular_node_id Granular_NodeId_Field,
-	update Granular_Update_Fields) (
-	granular *Granular, err error) {
+func (obj *sqlite3Impl) Update_ProjectLimit_By_ApiKeyHash(ctx context.Context,
+	project_limit_api_key_hash ProjectLimit_ApiKeyHash_Field,
+	update ProjectLimit_Update_Fields) (
+	project_limit *ProjectLimit, err error) {
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE granulars SET "), __sets, __sqlbundle_Literal(" WHERE granulars.node_id = ?")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE project_limits SET "), __sets, __sqlbundle_Literal(" WHERE project_limits.api_key_hash = ?")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
 	var __args []interface{}
 
-	if update.StartTime._set {
-		__values = append(__values, update.StartTime.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("start_time = ?"))
-	}
-
-	if update.EndTime._set {
-		__values = append(__values, update.EndTime.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("end_time = ?"))
+	if update.StorageLimit._set {
+		__values = append(__values, update.StorageLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("storage_limit = ?"))
 	}
 
-	if update.DataTotal._set {
-		__values = append(__values, update.DataTotal.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("data_total = ?"))
+	if update.EgressLimit._set {
+		__values = append(__values, update.EgressLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("egress_limit = ?"))
 	}
 
 	__now := obj.db.Hooks.Now().UTC()
@@ -1306,7 +3031,7 @@ func (obj *sqlite3Impl) Update_Granular_By_NodeId(ctx context.Context,
 	__values = append(__values, __now)
 	__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("updated_at = ?"))
 
-	__args = append(__args, granular_node_id.value())
+	__args = append(__args, project_limit_api_key_hash.value())
 
 	__values = append(__values, __args...)
 	__sets.SQL = __sets_sql
@@ -1314,35 +3039,35 @@ func (obj *sqlite3Impl) Update_Granular_By_NodeId(ctx context.Context,
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	granular = &Granular{}
+	project_limit = &ProjectLimit{}
 	_, err = obj.driver.Exec(__stmt, __values...)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
 
-	var __embed_stmt_get = __sqlbundle_Literal("SELECT granulars.node_id, granulars.start_time, granulars.end_time, granulars.data_total, granulars.created_at, granulars.updated_at FROM granulars WHERE granulars.node_id = ?")
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT project_limits.api_key_hash, project_limits.storage_limit, project_limits.egress_limit, project_limits.created_at, project_limits.updated_at FROM project_limits WHERE project_limits.api_key_hash = ?")
 
 	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
 	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
 
-	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&granular.NodeId, &granular.StartTime, &granular.EndTime, &granular.DataTotal, &granular.CreatedAt, &granular.UpdatedAt)
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&project_limit.ApiKeyHash, &project_limit.StorageLimit, &project_limit.EgressLimit, &project_limit.CreatedAt, &project_limit.UpdatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return granular, nil
+	return project_limit, nil
 }
 
-func (obj *sqlite3Impl) Delete_Aggregate_By_NodeId(ctx context.Context,
-	aggregate_node_id Aggregate_NodeId_Field) (
+func (obj *sqlite3Impl) Delete_Rollup_By_Id(ctx context.Context,
+	rollup_id Rollup_Id_Field) (
 	deleted bool, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("DELETE FROM aggregates WHERE aggregates.node_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM rollups WHERE rollups.id = ?")
 
 	var __values []interface{}
-	__values = append(__values, aggregate_node_id.value())
+	__values = append(__values, rollup_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
@@ -1361,14 +3086,14 @@ func (obj *sqlite3Impl) Delete_Aggregate_By_NodeId(ctx context.Context,
 
 }
 
-func (obj *sqlite3Impl) Delete_Granular_By_NodeId(ctx context.Context,
-	granular_node_id Granular_NodeId_Field) (
+func (obj *sqlite3Impl) Delete_Granular_By_Id(ctx context.Context,
+	granular_id Granular_Id_Field) (
 	deleted bool, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("DELETE FROM granulars WHERE granulars.node_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM granulars WHERE granulars.id = ?")
 
 	var __values []interface{}
-	__values = append(__values, granular_node_id.value())
+	__values = append(__values, granular_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
@@ -1387,21 +3112,21 @@ func (obj *sqlite3Impl) Delete_Granular_By_NodeId(ctx context.Context,
 
 }
 
-func (obj *sqlite3Impl) getLastAggregate(ctx context.Context,
+func (obj *sqlite3Impl) getLastRollup(ctx context.Context,
 	pk int64) (
-	aggregate *Aggregate, err error) {
+	rollup *Rollup, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT aggregates.node_id, aggregates.start_time, aggregates.interval, aggregates.created_at, aggregates.updated_at FROM aggregates WHERE _rowid_ = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT rollups.id, rollups.node_id, rollups.start_time, rollups.at_rest_total, rollups.put_total, rollups.get_total, rollups.get_audit_total, rollups.get_repair_total, rollups.put_repair_total, rollups.created_at, rollups.updated_at FROM rollups WHERE _rowid_ = ?")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, pk)
 
-	aggregate = &Aggregate{}
-	err = obj.driver.QueryRow(__stmt, pk).Scan(&aggregate.NodeId, &aggregate.StartTime, &aggregate.Interval, &aggregate.CreatedAt, &aggregate.UpdatedAt)
+	rollup = &Rollup{}
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&rollup.Id, &rollup.NodeId, &rollup.StartTime, &rollup.AtRestTotal, &rollup.PutTotal, &rollup.GetTotal, &rollup.GetAuditTotal, &rollup.GetRepairTotal, &rollup.PutRepairTotal, &rollup.CreatedAt, &rollup.UpdatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return aggregate, nil
+	return rollup, nil
 
 }
 
@@ -1409,13 +3134,13 @@ func (obj *sqlite3Impl) getLastGranular(ctx context.Context,
 	pk int64) (
 	granular *Granular, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT granulars.node_id, granulars.start_time, granulars.end_time, granulars.data_total, granulars.created_at, granulars.updated_at FROM granulars WHERE _rowid_ = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT granulars.id, granulars.node_id, granulars.start_time, granulars.end_time, granulars.data_total, granulars.created_at, granulars.updated_at FROM granulars WHERE _rowid_ = ?")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, pk)
 
 	granular = &Granular{}
-	err = obj.driver.QueryRow(__stmt, pk).Scan(&granular.NodeId, &granular.StartTime, &granular.EndTime, &granular.DataTotal, &granular.CreatedAt, &granular.UpdatedAt)
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&granular.Id, &granular.NodeId, &granular.StartTime, &granular.EndTime, &granular.DataTotal, &granular.CreatedAt, &granular.UpdatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -1423,6 +3148,60 @@ func (obj *sqlite3Impl) getLastGranular(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) getLastTally(ctx context.Context,
+	pk int64) (
+	tally *Tally, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT tallies.name, tallies.last_tally, tallies.pass_start, tallies.cursor_path, tallies.created_at, tallies.updated_at FROM tallies WHERE _rowid_ = ?")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, pk)
+
+	tally = &Tally{}
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&tally.Name, &tally.LastTally, &tally.PassStart, &tally.CursorPath, &tally.CreatedAt, &tally.UpdatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return tally, nil
+
+}
+
+func (obj *sqlite3Impl) getLastBucketUsage(ctx context.Context,
+	pk int64) (
+	bucket_usage *BucketUsage, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_usages.id, bucket_usages.api_key_hash, bucket_usages.bucket_name, bucket_usages.stored_bytes, bucket_usages.segments, bucket_usages.objects, bucket_usages.created_at, bucket_usages.updated_at FROM bucket_usages WHERE _rowid_ = ?")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, pk)
+
+	bucket_usage = &BucketUsage{}
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&bucket_usage.Id, &bucket_usage.ApiKeyHash, &bucket_usage.BucketName, &bucket_usage.StoredBytes, &bucket_usage.Segments, &bucket_usage.Objects, &bucket_usage.CreatedAt, &bucket_usage.UpdatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return bucket_usage, nil
+
+}
+
+func (obj *sqlite3Impl) getLastProjectLimit(ctx context.Context,
+	pk int64) (
+	project_limit *ProjectLimit, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_limits.api_key_hash, project_limits.storage_limit, project_limits.egress_limit, project_limits.created_at, project_limits.updated_at FROM project_limits WHERE _rowid_ = ?")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, pk)
+
+	project_limit = &ProjectLimit{}
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&project_limit.ApiKeyHash, &project_limit.StorageLimit, &project_limit.EgressLimit, &project_limit.CreatedAt, &project_limit.UpdatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return project_limit, nil
+
+}
+
 func (impl sqlite3Impl) isConstraintError(err error) (
 	constraint string, ok bool) {
 	if e, ok := err.(sqlite3.Error); ok {
@@ -1441,6 +3220,36 @@ func (impl sqlite3Impl) isConstraintError(err error) (
 func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error) {
 	var __res sql.Result
 	var __count int64
+	__res, err = obj.driver.Exec("DELETE FROM project_limits;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM bucket_usages;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM tallies;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
 	__res, err = obj.driver.Exec("DELETE FROM granulars;")
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -1451,7 +3260,7 @@ func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 	count += __count
-	__res, err = obj.driver.Exec("DELETE FROM aggregates;")
+	__res, err = obj.driver.Exec("DELETE FROM rollups;")
 	if err != nil {
 		return 0, obj.makeErr(err)
 	}
@@ -1508,16 +3317,50 @@ func (rx *Rx) Rollback() (err error) {
 	return err
 }
 
-func (rx *Rx) Create_Aggregate(ctx context.Context,
-	aggregate_node_id Aggregate_NodeId_Field,
-	aggregate_start_time Aggregate_StartTime_Field,
-	aggregate_interval Aggregate_Interval_Field) (
-	aggregate *Aggregate, err error) {
+func (rx *Rx) All_BucketUsage_By_ApiKeyHash_OrderBy_Asc_BucketName(ctx context.Context,
+	bucket_usage_api_key_hash BucketUsage_ApiKeyHash_Field) (
+	rows []*BucketUsage, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_BucketUsage_By_ApiKeyHash_OrderBy_Asc_BucketName(ctx, bucket_usage_api_key_hash)
+}
+
+func (rx *Rx) All_Granular_By_EndTime_Greater_And_StartTime_Less(ctx context.Context,
+	granular_end_time_greater Granular_EndTime_Field,
+	granular_start_time_less Granular_StartTime_Field) (
+	rows []*Granular, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_Granular_By_EndTime_Greater_And_StartTime_Less(ctx, granular_end_time_greater, granular_start_time_less)
+}
+
+func (rx *Rx) All_Rollup_By_StartTime_GreaterOrEqual_And_StartTime_Less_OrderBy_Asc_NodeId(ctx context.Context,
+	rollup_start_time_greater_or_equal Rollup_StartTime_Field,
+	rollup_start_time_less Rollup_StartTime_Field) (
+	rows []*Rollup, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Create_Aggregate(ctx, aggregate_node_id, aggregate_start_time, aggregate_interval)
+	return tx.All_Rollup_By_StartTime_GreaterOrEqual_And_StartTime_Less_OrderBy_Asc_NodeId(ctx, rollup_start_time_greater_or_equal, rollup_start_time_less)
+}
+
+func (rx *Rx) Create_BucketUsage(ctx context.Context,
+	bucket_usage_api_key_hash BucketUsage_ApiKeyHash_Field,
+	bucket_usage_bucket_name BucketUsage_BucketName_Field,
+	bucket_usage_stored_bytes BucketUsage_StoredBytes_Field,
+	bucket_usage_segments BucketUsage_Segments_Field,
+	bucket_usage_objects BucketUsage_Objects_Field) (
+	bucket_usage *BucketUsage, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Create_BucketUsage(ctx, bucket_usage_api_key_hash, bucket_usage_bucket_name, bucket_usage_stored_bytes, bucket_usage_segments, bucket_usage_objects)
 
 }
 
@@ -1535,74 +3378,210 @@ func (rx *Rx) Create_Granular(ctx context.Context,
 
 }
 
-func (rx *Rx) Delete_Aggregate_By_NodeId(ctx context.Context,
-	aggregate_node_id Aggregate_NodeId_Field) (
+func (rx *Rx) Create_ProjectLimit(ctx context.Context,
+	project_limit_api_key_hash ProjectLimit_ApiKeyHash_Field,
+	project_limit_storage_limit ProjectLimit_StorageLimit_Field,
+	project_limit_egress_limit ProjectLimit_EgressLimit_Field) (
+	project_limit *ProjectLimit, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Create_ProjectLimit(ctx, project_limit_api_key_hash, project_limit_storage_limit, project_limit_egress_limit)
+
+}
+
+func (rx *Rx) Create_Rollup(ctx context.Context,
+	rollup_node_id Rollup_NodeId_Field,
+	rollup_start_time Rollup_StartTime_Field,
+	rollup_at_rest_total Rollup_AtRestTotal_Field,
+	rollup_put_total Rollup_PutTotal_Field,
+	rollup_get_total Rollup_GetTotal_Field,
+	rollup_get_audit_total Rollup_GetAuditTotal_Field,
+	rollup_get_repair_total Rollup_GetRepairTotal_Field,
+	rollup_put_repair_total Rollup_PutRepairTotal_Field) (
+	rollup *Rollup, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Create_Rollup(ctx, rollup_node_id, rollup_start_time, rollup_at_rest_total, rollup_put_total, rollup_get_total, rollup_get_audit_total, rollup_get_repair_total, rollup_put_repair_total)
+
+}
+
+func (rx *Rx) Create_Tally(ctx context.Context,
+	tally_name Tally_Name_Field,
+	tally_last_tally Tally_LastTally_Field,
+	tally_pass_start Tally_PassStart_Field,
+	tally_cursor_path Tally_CursorPath_Field) (
+	tally *Tally, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Create_Tally(ctx, tally_name, tally_last_tally, tally_pass_start, tally_cursor_path)
+
+}
+
+func (rx *Rx) Delete_Granular_By_Id(ctx context.Context,
+	granular_id Granular_Id_Field) (
 	deleted bool, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Delete_Aggregate_By_NodeId(ctx, aggregate_node_id)
+	return tx.Delete_Granular_By_Id(ctx, granular_id)
 }
 
-func (rx *Rx) Delete_Granular_By_NodeId(ctx context.Context,
-	granular_node_id Granular_NodeId_Field) (
+func (rx *Rx) Delete_Rollup_By_Id(ctx context.Context,
+	rollup_id Rollup_Id_Field) (
 	deleted bool, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Delete_Granular_By_NodeId(ctx, granular_node_id)
+	return tx.Delete_Rollup_By_Id(ctx, rollup_id)
 }
 
-func (rx *Rx) Get_Aggregate_By_NodeId(ctx context.Context,
-	aggregate_node_id Aggregate_NodeId_Field) (
-	aggregate *Aggregate, err error) {
+func (rx *Rx) First_BucketUsage_By_ApiKeyHash_And_BucketName(ctx context.Context,
+	bucket_usage_api_key_hash BucketUsage_ApiKeyHash_Field,
+	bucket_usage_bucket_name BucketUsage_BucketName_Field) (
+	bucket_usage *BucketUsage, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Get_Aggregate_By_NodeId(ctx, aggregate_node_id)
+	return tx.First_BucketUsage_By_ApiKeyHash_And_BucketName(ctx, bucket_usage_api_key_hash, bucket_usage_bucket_name)
 }
 
-func (rx *Rx) Get_Granular_By_NodeId(ctx context.Context,
-	granular_node_id Granular_NodeId_Field) (
+func (rx *Rx) First_Granular_By_NodeId_And_StartTime(ctx context.Context,
+	granular_node_id Granular_NodeId_Field,
+	granular_start_time Granular_StartTime_Field) (
 	granular *Granular, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Get_Granular_By_NodeId(ctx, granular_node_id)
+	return tx.First_Granular_By_NodeId_And_StartTime(ctx, granular_node_id, granular_start_time)
 }
 
-func (rx *Rx) Update_Aggregate_By_NodeId(ctx context.Context,
-	aggregate_node_id Aggregate_NodeId_Field,
-	update Aggregate_Update_Fields) (
-	aggregate *Aggregate, err error) {
+func (rx *Rx) First_ProjectLimit_By_ApiKeyHash(ctx context.Context,
+	project_limit_api_key_hash ProjectLimit_ApiKeyHash_Field) (
+	project_limit *ProjectLimit, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Update_Aggregate_By_NodeId(ctx, aggregate_node_id, update)
+	return tx.First_ProjectLimit_By_ApiKeyHash(ctx, project_limit_api_key_hash)
 }
 
-func (rx *Rx) Update_Granular_By_NodeId(ctx context.Context,
-	granular_node_id Granular_NodeId_Field,
+func (rx *Rx) First_Rollup_By_NodeId_And_StartTime(ctx context.Context,
+	rollup_node_id Rollup_NodeId_Field,
+	rollup_start_time Rollup_StartTime_Field) (
+	rollup *Rollup, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.First_Rollup_By_NodeId_And_StartTime(ctx, rollup_node_id, rollup_start_time)
+}
+
+func (rx *Rx) First_Rollup_OrderBy_Desc_StartTime(ctx context.Context) (
+	rollup *Rollup, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.First_Rollup_OrderBy_Desc_StartTime(ctx)
+}
+
+func (rx *Rx) First_Tally_By_Name(ctx context.Context,
+	tally_name Tally_Name_Field) (
+	tally *Tally, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.First_Tally_By_Name(ctx, tally_name)
+}
+
+func (rx *Rx) Update_BucketUsage_By_Id(ctx context.Context,
+	bucket_usage_id BucketUsage_Id_Field,
+	update BucketUsage_Update_Fields) (
+	bucket_usage *BucketUsage, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Update_BucketUsage_By_Id(ctx, bucket_usage_id, update)
+}
+
+func (rx *Rx) Update_Granular_By_Id(ctx context.Context,
+	granular_id Granular_Id_Field,
 	update Granular_Update_Fields) (
 	granular *Granular, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Update_Granular_By_NodeId(ctx, granular_node_id, update)
+	return tx.Update_Granular_By_Id(ctx, granular_id, update)
+}
+
+func (rx *Rx) Update_ProjectLimit_By_ApiKeyHash(ctx context.Context,
+	project_limit_api_key_hash ProjectLimit_ApiKeyHash_Field,
+	update ProjectLimit_Update_Fields) (
+	project_limit *ProjectLimit, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Update_ProjectLimit_By_ApiKeyHash(ctx, project_limit_api_key_hash, update)
+}
+
+func (rx *Rx) Update_Rollup_By_Id(ctx context.Context,
+	rollup_id Rollup_Id_Field,
+	update Rollup_Update_Fields) (
+	rollup *Rollup, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Update_Rollup_By_Id(ctx, rollup_id, update)
+}
+
+func (rx *Rx) Update_Tally_By_Name(ctx context.Context,
+	tally_name Tally_Name_Field,
+	update Tally_Update_Fields) (
+	tally *Tally, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Update_Tally_By_Name(ctx, tally_name, update)
 }
 
 type Methods interface {
-	Create_Aggregate(ctx context.Context,
-		aggregate_node_id Aggregate_NodeId_Field,
-		aggregate_start_time Aggregate_StartTime_Field,
-		aggregate_interval Aggregate_Interval_Field) (
-		aggregate *Aggregate, err error)
+	All_BucketUsage_By_ApiKeyHash_OrderBy_Asc_BucketName(ctx context.Context,
+		bucket_usage_api_key_hash BucketUsage_ApiKeyHash_Field) (
+		rows []*BucketUsage, err error)
+
+	All_Granular_By_EndTime_Greater_And_StartTime_Less(ctx context.Context,
+		granular_end_time_greater Granular_EndTime_Field,
+		granular_start_time_less Granular_StartTime_Field) (
+		rows []*Granular, err error)
+
+	All_Rollup_By_StartTime_GreaterOrEqual_And_StartTime_Less_OrderBy_Asc_NodeId(ctx context.Context,
+		rollup_start_time_greater_or_equal Rollup_StartTime_Field,
+		rollup_start_time_less Rollup_StartTime_Field) (
+		rows []*Rollup, err error)
+
+	Create_BucketUsage(ctx context.Context,
+		bucket_usage_api_key_hash BucketUsage_ApiKeyHash_Field,
+		bucket_usage_bucket_name BucketUsage_BucketName_Field,
+		bucket_usage_stored_bytes BucketUsage_StoredBytes_Field,
+		bucket_usage_segments BucketUsage_Segments_Field,
+		bucket_usage_objects BucketUsage_Objects_Field) (
+		bucket_usage *BucketUsage, err error)
 
 	Create_Granular(ctx context.Context,
 		granular_node_id Granular_NodeId_Field,
@@ -1611,31 +3590,88 @@ type Methods interface {
 		granular_data_total Granular_DataTotal_Field) (
 		granular *Granular, err error)
 
-	Delete_Aggregate_By_NodeId(ctx context.Context,
-		aggregate_node_id Aggregate_NodeId_Field) (
+	Create_ProjectLimit(ctx context.Context,
+		project_limit_api_key_hash ProjectLimit_ApiKeyHash_Field,
+		project_limit_storage_limit ProjectLimit_StorageLimit_Field,
+		project_limit_egress_limit ProjectLimit_EgressLimit_Field) (
+		project_limit *ProjectLimit, err error)
+
+	Create_Rollup(ctx context.Context,
+		rollup_node_id Rollup_NodeId_Field,
+		rollup_start_time Rollup_StartTime_Field,
+		rollup_at_rest_total Rollup_AtRestTotal_Field,
+		rollup_put_total Rollup_PutTotal_Field,
+		rollup_get_total Rollup_GetTotal_Field,
+		rollup_get_audit_total Rollup_GetAuditTotal_Field,
+		rollup_get_repair_total Rollup_GetRepairTotal_Field,
+		rollup_put_repair_total Rollup_PutRepairTotal_Field) (
+		rollup *Rollup, err error)
+
+	Create_Tally(ctx context.Context,
+		tally_name Tally_Name_Field,
+		tally_last_tally Tally_LastTally_Field,
+		tally_pass_start Tally_PassStart_Field,
+		tally_cursor_path Tally_CursorPath_Field) (
+		tally *Tally, err error)
+
+	Delete_Granular_By_Id(ctx context.Context,
+		granular_id Granular_Id_Field) (
 		deleted bool, err error)
 
-	Delete_Granular_By_NodeId(ctx context.Context,
-		granular_node_id Granular_NodeId_Field) (
+	Delete_Rollup_By_Id(ctx context.Context,
+		rollup_id Rollup_Id_Field) (
 		deleted bool, err error)
 
-	Get_Aggregate_By_NodeId(ctx context.Context,
-		aggregate_node_id Aggregate_NodeId_Field) (
-		aggregate *Aggregate, err error)
+	First_BucketUsage_By_ApiKeyHash_And_BucketName(ctx context.Context,
+		bucket_usage_api_key_hash BucketUsage_ApiKeyHash_Field,
+		bucket_usage_bucket_name BucketUsage_BucketName_Field) (
+		bucket_usage *BucketUsage, err error)
 
-	Get_Granular_By_NodeId(ctx context.Context,
-		granular_node_id Granular_NodeId_Field) (
+	First_Granular_By_NodeId_And_StartTime(ctx context.Context,
+		granular_node_id Granular_NodeId_Field,
+		granular_start_time Granular_StartTime_Field) (
 		granular *Granular, err error)
 
-	Update_Aggregate_By_NodeId(ctx context.Context,
-		aggregate_node_id Aggregate_NodeId_Field,
-		update Aggregate_Update_Fields) (
-		aggregate *Aggregate, err error)
+	First_ProjectLimit_By_ApiKeyHash(ctx context.Context,
+		project_limit_api_key_hash ProjectLimit_ApiKeyHash_Field) (
+		project_limit *ProjectLimit, err error)
 
-	Update_Granular_By_NodeId(ctx context.Context,
-		granular_node_id Granular_NodeId_Field,
+	First_Rollup_By_NodeId_And_StartTime(ctx context.Context,
+		rollup_node_id Rollup_NodeId_Field,
+		rollup_start_time Rollup_StartTime_Field) (
+		rollup *Rollup, err error)
+
+	First_Rollup_OrderBy_Desc_StartTime(ctx context.Context) (
+		rollup *Rollup, err error)
+
+	First_Tally_By_Name(ctx context.Context,
+		tally_name Tally_Name_Field) (
+		tally *Tally, err error)
+
+	Update_BucketUsage_By_Id(ctx context.Context,
+		bucket_usage_id BucketUsage_Id_Field,
+		update BucketUsage_Update_Fields) (
+		bucket_usage *BucketUsage, err error)
+
+	Update_Granular_By_Id(ctx context.Context,
+		granular_id Granular_Id_Field,
 		update Granular_Update_Fields) (
 		granular *Granular, err error)
+
+	Update_ProjectLimit_By_ApiKeyHash(ctx context.Context,
+		project_limit_api_key_hash ProjectLimit_ApiKeyHash_Field,
+		update ProjectLimit_Update_Fields) (
+		project_limit *ProjectLimit, err error)
+
+	Update_Rollup_By_Id(ctx context.Context,
+		rollup_id Rollup_Id_Field,
+		update Rollup_Update_Fields) (
+		rollup *Rollup, err error)
+
+	Update_Tally_By_Name(ctx context.Context,
+		tally_name Tally_Name_Field,
+		update Tally_Update_Fields) (
+		tally *Tally, err error)
 }
 
 type TxMethods interface {
@@ -1,19 +1,57 @@
 -- AUTOGENERATED BY gopkg.in/spacemonkeygo/dbx.v1
 -- DO NOT EDIT
-CREATE TABLE aggregates (
+CREATE TABLE rollups (
+	id bigserial NOT NULL,
 	node_id text NOT NULL,
 	start_time timestamp with time zone NOT NULL,
-	interval bigint NOT NULL,
+	at_rest_total double precision NOT NULL,
+	put_total bigint NOT NULL,
+	get_total bigint NOT NULL,
+	get_audit_total bigint NOT NULL,
+	get_repair_total bigint NOT NULL,
+	put_repair_total bigint NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	updated_at timestamp with time zone NOT NULL,
-	PRIMARY KEY ( node_id )
+	PRIMARY KEY ( id ),
+	UNIQUE ( node_id, start_time )
 );
 CREATE TABLE granulars (
+	id bigserial NOT NULL,
 	node_id text NOT NULL,
 	start_time timestamp with time zone NOT NULL,
 	end_time timestamp with time zone NOT NULL,
-	data_total bigint NOT NULL,
+	data_total double precision NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	updated_at timestamp with time zone NOT NULL,
-	PRIMARY KEY ( node_id )
+	PRIMARY KEY ( id ),
+	UNIQUE ( node_id, start_time )
+);
+CREATE TABLE tallies (
+	name text NOT NULL,
+	last_tally timestamp with time zone NOT NULL,
+	pass_start timestamp with time zone NOT NULL,
+	cursor_path text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( name )
+);
+CREATE TABLE bucket_usages (
+	id bigserial NOT NULL,
+	api_key_hash bytea NOT NULL,
+	bucket_name text NOT NULL,
+	stored_bytes bigint NOT NULL,
+	segments bigint NOT NULL,
+	objects bigint NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id ),
+	UNIQUE ( api_key_hash, bucket_name )
+);
+CREATE TABLE project_limits (
+	api_key_hash bytea NOT NULL,
+	storage_limit bigint NOT NULL,
+	egress_limit bigint NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( api_key_hash )
 );
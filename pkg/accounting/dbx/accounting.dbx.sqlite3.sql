@@ -1,19 +1,57 @@
 -- AUTOGENERATED BY gopkg.in/spacemonkeygo/dbx.v1
 -- DO NOT EDIT
-CREATE TABLE aggregates (
+CREATE TABLE rollups (
+	id INTEGER NOT NULL,
 	node_id TEXT NOT NULL,
 	start_time TIMESTAMP NOT NULL,
-	interval INTEGER NOT NULL,
+	at_rest_total REAL NOT NULL,
+	put_total INTEGER NOT NULL,
+	get_total INTEGER NOT NULL,
+	get_audit_total INTEGER NOT NULL,
+	get_repair_total INTEGER NOT NULL,
+	put_repair_total INTEGER NOT NULL,
 	created_at TIMESTAMP NOT NULL,
 	updated_at TIMESTAMP NOT NULL,
-	PRIMARY KEY ( node_id )
+	PRIMARY KEY ( id ),
+	UNIQUE ( node_id, start_time )
 );
 CREATE TABLE granulars (
+	id INTEGER NOT NULL,
 	node_id TEXT NOT NULL,
 	start_time TIMESTAMP NOT NULL,
 	end_time TIMESTAMP NOT NULL,
-	data_total INTEGER NOT NULL,
+	data_total REAL NOT NULL,
 	created_at TIMESTAMP NOT NULL,
 	updated_at TIMESTAMP NOT NULL,
-	PRIMARY KEY ( node_id )
+	PRIMARY KEY ( id ),
+	UNIQUE ( node_id, start_time )
+);
+CREATE TABLE tallies (
+	name TEXT NOT NULL,
+	last_tally TIMESTAMP NOT NULL,
+	pass_start TIMESTAMP NOT NULL,
+	cursor_path TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( name )
+);
+CREATE TABLE bucket_usages (
+	id INTEGER NOT NULL,
+	api_key_hash BLOB NOT NULL,
+	bucket_name TEXT NOT NULL,
+	stored_bytes INTEGER NOT NULL,
+	segments INTEGER NOT NULL,
+	objects INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( id ),
+	UNIQUE ( api_key_hash, bucket_name )
+);
+CREATE TABLE project_limits (
+	api_key_hash BLOB NOT NULL,
+	storage_limit INTEGER NOT NULL,
+	egress_limit INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( api_key_hash )
 );
@@ -0,0 +1,133 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package accounting
+
+import (
+	"context"
+	"time"
+
+	dbx "storj.io/storj/pkg/accounting/dbx"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/utils"
+)
+
+// Egress reports how many bytes were downloaded from the buckets of projects
+type Egress interface {
+	// GetBucketEgress returns the bytes downloaded from every bucket of the project with the api key hash between from and to
+	GetBucketEgress(ctx context.Context, apiKeyHash []byte, from, to time.Time) (map[string]int64, error)
+}
+
+// Limits are the caps of a project, zero means unlimited
+type Limits struct {
+	// StorageBytes is the maximum number of bytes the buckets of the project store together.
+	StorageBytes int64
+	// EgressBytes is the maximum number of bytes downloaded from the project during a calendar month.
+	EgressBytes int64
+}
+
+// GetLimits returns the limits of the project with the api key hash
+func (u *Usage) GetLimits(ctx context.Context, apiKeyHash []byte) (limits Limits, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	row, err := u.db.First_ProjectLimit_By_ApiKeyHash(ctx, dbx.ProjectLimit_ApiKeyHash(apiKeyHash))
+	if err != nil || row == nil {
+		return Limits{}, UsageError.Wrap(err)
+	}
+	return Limits{StorageBytes: row.StorageLimit, EgressBytes: row.EgressLimit}, nil
+}
+
+// SetLimits replaces the limits of the project with the api key hash
+func (u *Usage) SetLimits(ctx context.Context, apiKeyHash []byte, limits Limits) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if limits.StorageBytes < 0 || limits.EgressBytes < 0 {
+		return UsageError.New("negative limits %+v", limits)
+	}
+
+	tx, err := u.db.Open(ctx)
+	if err != nil {
+		return UsageError.Wrap(err)
+	}
+	defer func() {
+		if err != nil {
+			err = UsageError.Wrap(utils.CombineErrors(err, tx.Rollback()))
+		} else {
+			err = UsageError.Wrap(tx.Commit())
+		}
+	}()
+
+	row, err := tx.First_ProjectLimit_By_ApiKeyHash(ctx, dbx.ProjectLimit_ApiKeyHash(apiKeyHash))
+	if err != nil {
+		return err
+	}
+	if row == nil {
+		_, err = tx.Create_ProjectLimit(ctx,
+			dbx.ProjectLimit_ApiKeyHash(apiKeyHash),
+			dbx.ProjectLimit_StorageLimit(limits.StorageBytes),
+			dbx.ProjectLimit_EgressLimit(limits.EgressBytes),
+		)
+		return err
+	}
+
+	_, err = tx.Update_ProjectLimit_By_ApiKeyHash(ctx, dbx.ProjectLimit_ApiKeyHash(apiKeyHash), dbx.ProjectLimit_Update_Fields{
+		StorageLimit: dbx.ProjectLimit_StorageLimit(limits.StorageBytes),
+		EgressLimit:  dbx.ProjectLimit_EgressLimit(limits.EgressBytes),
+	})
+	return err
+}
+
+// CheckStorage returns an ErrStorageLimit error when the buckets of the project with the api key hash
+// store as many bytes as the project may store
+func (u *Usage) CheckStorage(ctx context.Context, apiKeyHash []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	limits, err := u.GetLimits(ctx, apiKeyHash)
+	if err != nil || limits.StorageBytes == 0 {
+		return err
+	}
+
+	buckets, err := u.Buckets(ctx, apiKeyHash)
+	if err != nil {
+		return err
+	}
+	var stored int64
+	for _, bucket := range buckets {
+		stored += bucket.StoredBytes
+	}
+
+	if stored >= limits.StorageBytes {
+		return storj.ErrStorageLimit.New("the project stores %d bytes of its %d bytes limit", stored, limits.StorageBytes)
+	}
+	return nil
+}
+
+// CheckEgress returns an ErrEgressLimit error when as many bytes were downloaded from the project with
+// the api key hash during the current month as the project may download. Egress isn't limited without
+// the settled agreements.
+func (u *Usage) CheckEgress(ctx context.Context, apiKeyHash []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if u.egress == nil {
+		return nil
+	}
+
+	limits, err := u.GetLimits(ctx, apiKeyHash)
+	if err != nil || limits.EgressBytes == 0 {
+		return err
+	}
+
+	now := time.Now().UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	totals, err := u.egress.GetBucketEgress(ctx, apiKeyHash, month, now)
+	if err != nil {
+		return UsageError.Wrap(err)
+	}
+	var downloaded int64
+	for _, total := range totals {
+		downloaded += total
+	}
+
+	if downloaded >= limits.EgressBytes {
+		return storj.ErrEgressLimit.New("the project downloaded %d bytes of its %d bytes monthly limit", downloaded, limits.EgressBytes)
+	}
+	return nil
+}
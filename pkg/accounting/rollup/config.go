@@ -9,6 +9,8 @@ import (
 
 	"go.uber.org/zap"
 
+	"storj.io/storj/pkg/accounting"
+	"storj.io/storj/pkg/bwagreement"
 	"storj.io/storj/pkg/provider"
 )
 
@@ -19,7 +21,15 @@ type Config struct {
 
 // Initialize a rollup struct
 func (c Config) initialize(ctx context.Context) (Rollup, error) {
-	return newRollup(zap.L(), c.Interval), nil
+	db := accounting.LoadFromContext(ctx)
+	if db == nil {
+		return nil, Error.New("accounting database not found in context")
+	}
+	agreements := bwagreement.LoadFromContext(ctx)
+	if agreements == nil {
+		return nil, Error.New("bandwidth agreements not found in context")
+	}
+	return newRollup(zap.L(), db, agreements, c.Interval), nil
 }
 
 // Run runs the rollup with configured values
@@ -0,0 +1,89 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package rollup
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	dbx "storj.io/storj/pkg/accounting/dbx"
+)
+
+// NodePayout is the usage of a storage node the satellite pays for
+type NodePayout struct {
+	NodeID          string  `json:"nodeId"`
+	AtRestByteHours float64 `json:"atRestByteHours"`
+	PutBytes        int64   `json:"putBytes"`
+	GetBytes        int64   `json:"getBytes"`
+	AuditBytes      int64   `json:"auditBytes"`
+	GetRepairBytes  int64   `json:"getRepairBytes"`
+	PutRepairBytes  int64   `json:"putRepairBytes"`
+}
+
+// PayoutReport sums up the daily rollups of every storage node for the days from the day of from
+// up to the day of to, both included
+func PayoutReport(ctx context.Context, db *dbx.DB, from, to time.Time) (payouts []*NodePayout, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rollups, err := db.All_Rollup_By_StartTime_GreaterOrEqual_And_StartTime_Less_OrderBy_Asc_NodeId(ctx,
+		dbx.Rollup_StartTime(Day(from)), dbx.Rollup_StartTime(Day(to).AddDate(0, 0, 1)))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var payout *NodePayout
+	for _, rollup := range rollups {
+		// rollups are ordered by node
+		if payout == nil || payout.NodeID != rollup.NodeId {
+			payout = &NodePayout{NodeID: rollup.NodeId}
+			payouts = append(payouts, payout)
+		}
+		payout.AtRestByteHours += rollup.AtRestTotal
+		payout.PutBytes += rollup.PutTotal
+		payout.GetBytes += rollup.GetTotal
+		payout.AuditBytes += rollup.GetAuditTotal
+		payout.GetRepairBytes += rollup.GetRepairTotal
+		payout.PutRepairBytes += rollup.PutRepairTotal
+	}
+	return payouts, nil
+}
+
+// WriteCSV writes the payouts as csv with a header row
+func WriteCSV(w io.Writer, payouts []*NodePayout) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write([]string{"nodeId", "atRestByteHours", "putBytes", "getBytes", "auditBytes", "getRepairBytes", "putRepairBytes"})
+	if err != nil {
+		return err
+	}
+	for _, payout := range payouts {
+		err := cw.Write([]string{
+			payout.NodeID,
+			strconv.FormatFloat(payout.AtRestByteHours, 'f', -1, 64),
+			strconv.FormatInt(payout.PutBytes, 10),
+			strconv.FormatInt(payout.GetBytes, 10),
+			strconv.FormatInt(payout.AuditBytes, 10),
+			strconv.FormatInt(payout.GetRepairBytes, 10),
+			strconv.FormatInt(payout.PutRepairBytes, 10),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the payouts as a json array
+func WriteJSON(w io.Writer, payouts []*NodePayout) error {
+	if payouts == nil {
+		payouts = []*NodePayout{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(payouts)
+}
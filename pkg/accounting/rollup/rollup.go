@@ -8,6 +8,10 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	dbx "storj.io/storj/pkg/accounting/dbx"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/utils"
 )
 
 // Rollup is the service for totalling data on storage nodes for 1, 7, 30 day intervals
@@ -15,21 +19,24 @@ type Rollup interface {
 	Run(ctx context.Context) error
 }
 
+// Agreements gives the bandwidth of the settled agreements by storage node and action
+type Agreements interface {
+	GetNodeTotals(ctx context.Context, from, to time.Time) (map[string]map[pb.PayerBandwidthAllocation_Action]int64, error)
+}
+
 type rollup struct {
-	logger *zap.Logger
-	ticker *time.Ticker
-	//TODO:
-	//rollupDB
-	//rawDB
+	logger     *zap.Logger
+	ticker     *time.Ticker
+	db         *dbx.DB
+	agreements Agreements
 }
 
-func newRollup(logger *zap.Logger, interval time.Duration) *rollup {
+func newRollup(logger *zap.Logger, db *dbx.DB, agreements Agreements, interval time.Duration) *rollup {
 	return &rollup{
-		logger: logger,
-		ticker: time.NewTicker(interval),
-		//TODO:
-		//rollupDB
-		//rawDB
+		logger:     logger,
+		ticker:     time.NewTicker(interval),
+		db:         db,
+		agreements: agreements,
 	}
 }
 
@@ -51,6 +58,134 @@ func (r *rollup) Run(ctx context.Context) (err error) {
 	}
 }
 
-func (r *rollup) Query(ctx context.Context) error {
+// Query rolls up the days since the latest rollup up to today. Yesterday is always rolled up again,
+// because tally may still be accounting its last hours.
+func (r *rollup) Query(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	today := Day(time.Now())
+	start := today.AddDate(0, 0, -1)
+
+	latest, err := r.db.First_Rollup_OrderBy_Desc_StartTime(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if latest != nil && latest.StartTime.Before(start) {
+		start = Day(latest.StartTime)
+	}
+
+	for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+		if err := r.rollupDay(ctx, day); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// usage is the usage of a single node during a day
+type usage struct {
+	atRest    float64
+	bandwidth map[pb.PayerBandwidthAllocation_Action]int64
+}
+
+// rollupDay stores the at-rest byte-hours and the settled bandwidth of every node during the day
+func (r *rollup) rollupDay(ctx context.Context, start time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	end := start.AddDate(0, 0, 1)
+	nodes := make(map[string]*usage)
+	get := func(nodeID string) *usage {
+		u, ok := nodes[nodeID]
+		if !ok {
+			u = &usage{}
+			nodes[nodeID] = u
+		}
+		return u
+	}
+
+	granulars, err := r.db.All_Granular_By_EndTime_Greater_And_StartTime_Less(ctx,
+		dbx.Granular_EndTime(start), dbx.Granular_StartTime(end))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	for _, granular := range granulars {
+		get(granular.NodeId).atRest += overlap(granular, start, end)
+	}
+
+	totals, err := r.agreements.GetNodeTotals(ctx, start, end)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	for nodeID, bandwidth := range totals {
+		get(nodeID).bandwidth = bandwidth
+	}
+
+	tx, err := r.db.Open(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() {
+		if err != nil {
+			err = Error.Wrap(utils.CombineErrors(err, tx.Rollback()))
+		} else {
+			err = Error.Wrap(tx.Commit())
+		}
+	}()
+
+	for nodeID, u := range nodes {
+		row, err := tx.First_Rollup_By_NodeId_And_StartTime(ctx, dbx.Rollup_NodeId(nodeID), dbx.Rollup_StartTime(start))
+		if err != nil {
+			return err
+		}
+		if row == nil {
+			_, err = tx.Create_Rollup(ctx,
+				dbx.Rollup_NodeId(nodeID),
+				dbx.Rollup_StartTime(start),
+				dbx.Rollup_AtRestTotal(u.atRest),
+				dbx.Rollup_PutTotal(u.bandwidth[pb.PayerBandwidthAllocation_PUT]),
+				dbx.Rollup_GetTotal(u.bandwidth[pb.PayerBandwidthAllocation_GET]),
+				dbx.Rollup_GetAuditTotal(u.bandwidth[pb.PayerBandwidthAllocation_GET_AUDIT]),
+				dbx.Rollup_GetRepairTotal(u.bandwidth[pb.PayerBandwidthAllocation_GET_REPAIR]),
+				dbx.Rollup_PutRepairTotal(u.bandwidth[pb.PayerBandwidthAllocation_PUT_REPAIR]),
+			)
+		} else {
+			_, err = tx.Update_Rollup_By_Id(ctx, dbx.Rollup_Id(row.Id), dbx.Rollup_Update_Fields{
+				AtRestTotal:    dbx.Rollup_AtRestTotal(u.atRest),
+				PutTotal:       dbx.Rollup_PutTotal(u.bandwidth[pb.PayerBandwidthAllocation_PUT]),
+				GetTotal:       dbx.Rollup_GetTotal(u.bandwidth[pb.PayerBandwidthAllocation_GET]),
+				GetAuditTotal:  dbx.Rollup_GetAuditTotal(u.bandwidth[pb.PayerBandwidthAllocation_GET_AUDIT]),
+				GetRepairTotal: dbx.Rollup_GetRepairTotal(u.bandwidth[pb.PayerBandwidthAllocation_GET_REPAIR]),
+				PutRepairTotal: dbx.Rollup_PutRepairTotal(u.bandwidth[pb.PayerBandwidthAllocation_PUT_REPAIR]),
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// overlap returns the part of the byte-hours of the tally interval that falls between start and end
+func overlap(granular *dbx.Granular, start, end time.Time) float64 {
+	interval := granular.EndTime.Sub(granular.StartTime)
+	if interval <= 0 {
+		return 0
+	}
+	from, to := granular.StartTime, granular.EndTime
+	if from.Before(start) {
+		from = start
+	}
+	if to.After(end) {
+		to = end
+	}
+	if !to.After(from) {
+		return 0
+	}
+	return granular.DataTotal * float64(to.Sub(from)) / float64(interval)
+}
+
+// Day returns the start of the day of t in UTC, rollups are kept per UTC day
+func Day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
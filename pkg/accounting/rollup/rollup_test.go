@@ -2,3 +2,137 @@
 // See LICENSE for copying information.
 
 package rollup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/accounting"
+	dbx "storj.io/storj/pkg/accounting/dbx"
+	"storj.io/storj/pkg/pb"
+)
+
+var ctx = context.Background()
+
+type mockAgreements map[time.Time]map[string]map[pb.PayerBandwidthAllocation_Action]int64
+
+func (m mockAgreements) GetNodeTotals(ctx context.Context, from, to time.Time) (map[string]map[pb.PayerBandwidthAllocation_Action]int64, error) {
+	return m[from], nil
+}
+
+func TestOverlap(t *testing.T) {
+	day := time.Date(2018, 11, 20, 0, 0, 0, 0, time.UTC)
+	granular := &dbx.Granular{
+		StartTime: day.Add(-6 * time.Hour),
+		EndTime:   day.Add(6 * time.Hour),
+		DataTotal: 1200,
+	}
+
+	assert.Equal(t, 600.0, overlap(granular, day.AddDate(0, 0, -1), day))
+	assert.Equal(t, 600.0, overlap(granular, day, day.AddDate(0, 0, 1)))
+	assert.Equal(t, 0.0, overlap(granular, day.AddDate(0, 0, 1), day.AddDate(0, 0, 2)))
+	assert.Equal(t, 1200.0, overlap(granular, day.AddDate(0, 0, -1), day.AddDate(0, 0, 1)))
+}
+
+func TestRollupDay(t *testing.T) {
+	db, err := accounting.NewDB("sqlite3", fmt.Sprintf("file:memdb%d?mode=memory&cache=shared", rand.Int63()))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	day := time.Date(2018, 11, 20, 0, 0, 0, 0, time.UTC)
+	next := day.AddDate(0, 0, 1)
+
+	// node 1 has a tally interval crossing midnight
+	createGranular(t, db, "1", day.Add(-6*time.Hour), day.Add(6*time.Hour), 1200)
+	createGranular(t, db, "2", day.Add(time.Hour), day.Add(2*time.Hour), 100)
+
+	agreements := mockAgreements{
+		day: {
+			"2": {
+				pb.PayerBandwidthAllocation_PUT:        10,
+				pb.PayerBandwidthAllocation_GET:        20,
+				pb.PayerBandwidthAllocation_GET_AUDIT:  30,
+				pb.PayerBandwidthAllocation_GET_REPAIR: 40,
+				pb.PayerBandwidthAllocation_PUT_REPAIR: 50,
+			},
+		},
+		next: {
+			"3": {pb.PayerBandwidthAllocation_GET: 5},
+		},
+	}
+
+	rollup := newRollup(zap.NewNop(), db, agreements, time.Second)
+	require.NoError(t, rollup.rollupDay(ctx, day))
+	require.NoError(t, rollup.rollupDay(ctx, next))
+	// rolling up a day again replaces its rows
+	require.NoError(t, rollup.rollupDay(ctx, day))
+
+	payouts, err := PayoutReport(ctx, db, day, day)
+	require.NoError(t, err)
+	assert.Equal(t, []*NodePayout{
+		{NodeID: "1", AtRestByteHours: 600},
+		{NodeID: "2", AtRestByteHours: 100, PutBytes: 10, GetBytes: 20, AuditBytes: 30, GetRepairBytes: 40, PutRepairBytes: 50},
+	}, payouts)
+
+	payouts, err = PayoutReport(ctx, db, day, next.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []*NodePayout{
+		{NodeID: "1", AtRestByteHours: 600},
+		{NodeID: "2", AtRestByteHours: 100, PutBytes: 10, GetBytes: 20, AuditBytes: 30, GetRepairBytes: 40, PutRepairBytes: 50},
+		{NodeID: "3", GetBytes: 5},
+	}, payouts)
+
+	payouts, err = PayoutReport(ctx, db, next.AddDate(0, 0, 1), next.AddDate(0, 0, 2))
+	require.NoError(t, err)
+	assert.Empty(t, payouts)
+}
+
+func TestQuery(t *testing.T) {
+	db, err := accounting.NewDB("sqlite3", fmt.Sprintf("file:memdb%d?mode=memory&cache=shared", rand.Int63()))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	today := Day(time.Now())
+	createGranular(t, db, "1", today.AddDate(0, 0, -1), today, 24)
+
+	rollup := newRollup(zap.NewNop(), db, mockAgreements{}, time.Second)
+	require.NoError(t, rollup.Query(ctx))
+
+	latest, err := db.First_Rollup_OrderBy_Desc_StartTime(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.True(t, latest.StartTime.Equal(today.AddDate(0, 0, -1)))
+	assert.Equal(t, 24.0, latest.AtRestTotal)
+}
+
+func TestWrite(t *testing.T) {
+	payouts := []*NodePayout{
+		{NodeID: "1", AtRestByteHours: 1.5, PutBytes: 2, GetBytes: 3, AuditBytes: 4, GetRepairBytes: 5, PutRepairBytes: 6},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, payouts))
+	assert.Equal(t, "nodeId,atRestByteHours,putBytes,getBytes,auditBytes,getRepairBytes,putRepairBytes\n1,1.5,2,3,4,5,6\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteJSON(&buf, payouts))
+	assert.JSONEq(t, `[{"nodeId":"1","atRestByteHours":1.5,"putBytes":2,"getBytes":3,"auditBytes":4,"getRepairBytes":5,"putRepairBytes":6}]`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteJSON(&buf, nil))
+	assert.JSONEq(t, `[]`, buf.String())
+}
+
+func createGranular(t *testing.T, db *dbx.DB, nodeID string, start, end time.Time, total float64) {
+	_, err := db.Create_Granular(ctx, dbx.Granular_NodeId(nodeID), dbx.Granular_StartTime(start),
+		dbx.Granular_EndTime(end), dbx.Granular_DataTotal(total))
+	require.NoError(t, err)
+}
@@ -9,8 +9,9 @@ import (
 
 	"go.uber.org/zap"
 
-	"storj.io/storj/pkg/kademlia"
+	"storj.io/storj/pkg/accounting"
 	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/pointerdb"
 	"storj.io/storj/pkg/provider"
 )
@@ -23,9 +24,22 @@ type Config struct {
 // Initialize a tally struct
 func (c Config) initialize(ctx context.Context) (Tally, error) {
 	pointerdb := pointerdb.LoadFromContext(ctx)
-	overlay := overlay.LoadServerFromContext(ctx)
-	kademlia := kademlia.LoadFromContext(ctx)
-	return newTally(pointerdb, overlay, kademlia, 0, zap.L(), c.Interval), nil
+	if pointerdb == nil {
+		return nil, Error.New("pointerdb not found in context")
+	}
+
+	// without an overlay server, e.g. with the mock overlay, every node counts as online
+	var overlayServer pb.OverlayServer
+	if server := overlay.LoadServerFromContext(ctx); server != nil {
+		overlayServer = server
+	}
+
+	db := accounting.LoadFromContext(ctx)
+	if db == nil {
+		return nil, Error.New("accounting database not found in context")
+	}
+
+	return newTally(pointerdb, overlayServer, db, 0, zap.L(), c.Interval), nil
 }
 
 // Run runs the tally with configured values
@@ -4,22 +4,25 @@
 package tally
 
 import (
+	"bytes"
 	"context"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"go.uber.org/zap"
 
+	dbx "storj.io/storj/pkg/accounting/dbx"
 	"storj.io/storj/pkg/dht"
-	"storj.io/storj/pkg/kademlia"
 	"storj.io/storj/pkg/node"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/pointerdb"
-	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/utils"
 	"storj.io/storj/storage"
 )
 
+// atRestTally is the name of the row keeping the progress of the at-rest tally
+const atRestTally = "at-rest"
+
 // Tally is the service for accounting for data stored on each storage node
 type Tally interface {
 	Run(ctx context.Context) error
@@ -28,24 +31,20 @@ type Tally interface {
 type tally struct {
 	pointerdb *pointerdb.Server
 	overlay   pb.OverlayServer
-	kademlia  *kademlia.Kademlia
+	db        *dbx.DB
 	limit     int
 	logger    *zap.Logger
 	ticker    *time.Ticker
-	//TODO:
-	//rawDB
 }
 
-func newTally(pointerdb *pointerdb.Server, overlay pb.OverlayServer, kademlia *kademlia.Kademlia, limit int, logger *zap.Logger, interval time.Duration) *tally {
+func newTally(pointerdb *pointerdb.Server, overlay pb.OverlayServer, db *dbx.DB, limit int, logger *zap.Logger, interval time.Duration) *tally {
 	return &tally{
 		pointerdb: pointerdb,
 		overlay:   overlay,
-		kademlia:  kademlia,
+		db:        db,
 		limit:     limit,
 		logger:    logger,
 		ticker:    time.NewTicker(interval),
-		//TODO:
-		//rawDB
 	}
 }
 
@@ -54,7 +53,7 @@ func (t *tally) Run(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	for {
-		err = t.identifyActiveNodes(ctx)
+		err = t.calculateAtRestData(ctx)
 		if err != nil {
 			zap.L().Error("Tally failed", zap.Error(err))
 		}
@@ -67,53 +66,171 @@ func (t *tally) Run(ctx context.Context) (err error) {
 	}
 }
 
-// identifyActiveNodes iterates through pointerdb and identifies nodes that have storage on them
-func (t *tally) identifyActiveNodes(ctx context.Context) (err error) {
+// calculateAtRestData tallies the next batch of pointerdb and continues where the previous run stopped.
+// Every pass over pointerdb accounts the byte-hours between the start of the previous pass and the
+// start of this one, the first pass only establishes when tallying started.
+func (t *tally) calculateAtRestData(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	rt, err := t.kademlia.GetRoutingTable(ctx)
+	tx, err := t.db.Open(ctx)
 	if err != nil {
 		return Error.Wrap(err)
 	}
-	self := rt.Local()
-	identity := &provider.FullIdentity{} //do i need anything in here?
-	client, err := node.NewNodeClient(identity, self, t.kademlia)
+	defer func() {
+		if err != nil {
+			err = Error.Wrap(utils.CombineErrors(err, tx.Rollback()))
+		} else {
+			err = Error.Wrap(tx.Commit())
+		}
+	}()
+
+	state, err := tx.First_Tally_By_Name(ctx, dbx.Tally_Name(atRestTally))
 	if err != nil {
-		return Error.Wrap(err)
+		return err
+	}
+	if state == nil {
+		state, err = tx.Create_Tally(ctx,
+			dbx.Tally_Name(atRestTally),
+			dbx.Tally_LastTally(time.Time{}),
+			dbx.Tally_PassStart(time.Time{}),
+			dbx.Tally_CursorPath(""),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	passStart := state.PassStart.UTC()
+	if state.CursorPath == "" {
+		passStart = time.Now().UTC()
+	}
+
+	nodeData, cursor, err := t.tallyAtRestStorage(ctx, state.CursorPath)
+	if err != nil {
+		return err
+	}
+
+	lastTally := state.LastTally.UTC()
+	if !lastTally.IsZero() {
+		err = t.updateGranularTable(ctx, tx, nodeData, lastTally, passStart)
+		if err != nil {
+			return err
+		}
 	}
 
+	update := dbx.Tally_Update_Fields{CursorPath: dbx.Tally_CursorPath(cursor)}
+	if cursor == "" {
+		// the pass is complete, the next one accounts the time since it started
+		update.LastTally = dbx.Tally_LastTally(passStart)
+	} else {
+		update.PassStart = dbx.Tally_PassStart(passStart)
+	}
+	_, err = tx.Update_Tally_By_Name(ctx, dbx.Tally_Name(atRestTally), update)
+	return err
+}
+
+// tallyAtRestStorage walks pointerdb after cursor and sums up the bytes each node stores for the
+// remote segments. It returns the path to continue from or an empty path when the walk is complete.
+func (t *tally) tallyAtRestStorage(ctx context.Context, cursor string) (nodeData map[string]int64, next string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodeData = make(map[string]int64)
+
 	t.logger.Debug("entering pointerdb iterate")
-	err = t.pointerdb.Iterate(ctx, &pb.IterateRequest{Recurse: true},
+	err = t.pointerdb.Iterate(ctx, &pb.IterateRequest{First: cursor, Recurse: true},
 		func(it storage.Iterator) error {
 			var item storage.ListItem
 			lim := t.limit
 			if lim <= 0 || lim > storage.LookupLimit {
 				lim = storage.LookupLimit
 			}
-			for ; lim > 0 && it.Next(&item); lim-- {
+			for lim > 0 {
+				if !it.Next(&item) {
+					next = ""
+					return nil
+				}
+				if cursor != "" && bytes.Equal(item.Key, storage.Key(cursor)) {
+					continue
+				}
+				lim--
+				next = item.Key.String()
+
 				pointer := &pb.Pointer{}
-				err = proto.Unmarshal(item.Value, pointer)
+				err := proto.Unmarshal(item.Value, pointer)
 				if err != nil {
 					return Error.Wrap(err)
 				}
-				pieces := pointer.Remote.RemotePieces
-				var nodeIDs []dht.NodeID
-				for _, p := range pieces {
-					nodeIDs = append(nodeIDs, node.IDFromString(p.NodeId))
+				if pointer.GetType() != pb.Pointer_REMOTE {
+					continue
 				}
-				online, err := t.onlineNodes(ctx, nodeIDs)
-				if err != nil {
-					return Error.Wrap(err)
+				pieceSize := pointerdb.PieceSize(pointer)
+				for _, piece := range pointer.GetRemote().GetRemotePieces() {
+					nodeData[piece.NodeId] += pieceSize
 				}
-				go t.tallyAtRestStorage(ctx, pointer, online, client)
 			}
+			// the batch is full, continue from the last segment on the next run
 			return nil
 		},
 	)
-	return err
+	if err != nil {
+		return nil, "", err
+	}
+	return nodeData, next, nil
+}
+
+// updateGranularTable adds the byte-hours stored by the online nodes between start and end
+func (t *tally) updateGranularTable(ctx context.Context, tx *dbx.Tx, nodeData map[string]int64, start, end time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(nodeData) == 0 {
+		return nil
+	}
+
+	var nodeIDs []dht.NodeID
+	for id := range nodeData {
+		nodeIDs = append(nodeIDs, node.IDFromString(id))
+	}
+	online, err := t.onlineNodes(ctx, nodeIDs)
+	if err != nil {
+		return err
+	}
+
+	hours := end.Sub(start).Hours()
+	for _, n := range online {
+		byteHours := float64(nodeData[n.Id]) * hours
+
+		granular, err := tx.First_Granular_By_NodeId_And_StartTime(ctx,
+			dbx.Granular_NodeId(n.Id), dbx.Granular_StartTime(start))
+		if err != nil {
+			return err
+		}
+		if granular == nil {
+			_, err = tx.Create_Granular(ctx,
+				dbx.Granular_NodeId(n.Id),
+				dbx.Granular_StartTime(start),
+				dbx.Granular_EndTime(end),
+				dbx.Granular_DataTotal(byteHours),
+			)
+		} else {
+			_, err = tx.Update_Granular_By_Id(ctx, dbx.Granular_Id(granular.Id), dbx.Granular_Update_Fields{
+				DataTotal: dbx.Granular_DataTotal(granular.DataTotal + byteHours),
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
+// onlineNodes returns the nodes the overlay knows about, all nodes count as online without an overlay
 func (t *tally) onlineNodes(ctx context.Context, nodeIDs []dht.NodeID) (online []*pb.Node, err error) {
+	if t.overlay == nil {
+		for _, id := range nodeIDs {
+			online = append(online, &pb.Node{Id: id.String()})
+		}
+		return online, nil
+	}
 	responses, err := t.overlay.BulkLookup(ctx, utils.NodeIDsToLookupRequests(nodeIDs))
 	if err != nil {
 		return []*pb.Node{}, err
@@ -126,42 +243,3 @@ func (t *tally) onlineNodes(ctx context.Context, nodeIDs []dht.NodeID) (online [
 	}
 	return online, nil
 }
-
-func (t *tally) tallyAtRestStorage(ctx context.Context, pointer *pb.Pointer, nodes []*pb.Node, client node.Client) {
-	segmentSize := pointer.GetSize()
-	minReq := pointer.Remote.Redundancy.GetMinReq()
-	if minReq <= 0 {
-		zap.L().Error("minReq must be an int greater than 0")
-		return
-	}
-	pieceSize := segmentSize / int64(minReq)
-	for _, n := range nodes {
-		nodeAvail := true
-		var err error
-		ok := t.needToContact(n.Id)
-		if ok {
-			nodeAvail, err = client.Ping(ctx, *n)
-			if err != nil {
-				zap.L().Error("ping failed")
-				continue
-			}
-		}
-		if nodeAvail {
-			err := t.updateGranularTable(n.Id, pieceSize)
-			if err != nil {
-				zap.L().Error("update failed")
-			}
-		}
-	}
-}
-
-func (t *tally) needToContact(nodeID string) bool {
-	//TODO
-	//check db if node was updated within the last time period
-	return true
-}
-
-func (t *tally) updateGranularTable(nodeID string, pieceSize int64) error {
-	//TODO
-	return nil
-}
@@ -5,33 +5,34 @@ package tally
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"strconv"
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"storj.io/storj/pkg/accounting"
+	dbx "storj.io/storj/pkg/accounting/dbx"
 	"storj.io/storj/pkg/dht"
-	"storj.io/storj/pkg/kademlia"
 	"storj.io/storj/pkg/node"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/overlay/mocks"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/storage"
 	"storj.io/storj/storage/teststore"
 )
 
 var ctx = context.Background()
 
-func TestIdentifyActiveNodes(t *testing.T) {
-
-}
-
 func TestOnlineNodes(t *testing.T) {
 	logger := zap.NewNop()
-	pointerdb := pointerdb.NewServer(teststore.New(), &overlay.Cache{}, logger, pointerdb.Config{}, nil)
+	pointerdb := pointerdb.NewServer(teststore.New(), &overlay.Cache{}, nil, nil, logger, pointerdb.Config{}, nil)
 
 	const N = 50
 	nodes := []*pb.Node{}
@@ -51,24 +52,131 @@ func TestOnlineNodes(t *testing.T) {
 		}
 	}
 	overlayServer := mocks.NewOverlay(nodes)
-	kad := &kademlia.Kademlia{}
 	limit := 0
 	interval := time.Second
 
-	tally := newTally(pointerdb, overlayServer, kad, limit, logger, interval)
+	tally := newTally(pointerdb, overlayServer, nil, limit, logger, interval)
 	online, err := tally.onlineNodes(ctx, nodeIDs)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedOnline, online)
 }
 
 func TestTallyAtRestStorage(t *testing.T) {
+	logger := zap.NewNop()
+	db := teststore.New()
+	pointerdb := pointerdb.NewServer(db, &overlay.Cache{}, nil, nil, logger, pointerdb.Config{}, nil)
+
+	putRemotePointer(t, db, "a/1", 1000, "1", "2")
+	putRemotePointer(t, db, "a/2", 2000, "2", "3")
+	putInlinePointer(t, db, "a/3")
+
+	tally := newTally(pointerdb, nil, nil, 2, logger, time.Second)
+
+	nodeData, cursor, err := tally.tallyAtRestStorage(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, "a/2", cursor)
+	assert.Equal(t, map[string]int64{"1": 512, "2": 512 + 1024, "3": 1024}, nodeData)
 
+	nodeData, cursor, err = tally.tallyAtRestStorage(ctx, cursor)
+	require.NoError(t, err)
+	assert.Equal(t, "", cursor)
+	assert.Empty(t, nodeData)
 }
 
-func TestNeedToContact(t *testing.T) {
+func TestCalculateAtRestData(t *testing.T) {
+	logger := zap.NewNop()
+	store := teststore.New()
+	pointerdb := pointerdb.NewServer(store, &overlay.Cache{}, nil, nil, logger, pointerdb.Config{}, nil)
+
+	putRemotePointer(t, store, "a/1", 1000, "1", "2")
+	putRemotePointer(t, store, "a/2", 2000, "2", "3")
+	putRemotePointer(t, store, "a/3", 1000, "1", "3")
+
+	db, err := accounting.NewDB("sqlite3", fmt.Sprintf("file:memdb%d?mode=memory&cache=shared", rand.Int63()))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	// node 3 is not in the overlay
+	overlayServer := mocks.NewOverlay([]*pb.Node{{Id: "1"}, {Id: "2"}})
+	tally := newTally(pointerdb, overlayServer, db, 2, logger, time.Second)
+
+	// the first pass only records when tallying started
+	require.NoError(t, tally.calculateAtRestData(ctx))
+	state := getTallyState(t, db)
+	assert.Equal(t, "a/2", state.CursorPath)
+	assert.True(t, state.LastTally.IsZero())
+
+	require.NoError(t, tally.calculateAtRestData(ctx))
+	state = getTallyState(t, db)
+	assert.Equal(t, "", state.CursorPath)
+	assert.False(t, state.LastTally.IsZero())
+
+	granulars, err := db.All_Granular_By_EndTime_Greater_And_StartTime_Less(ctx,
+		dbx.Granular_EndTime(time.Time{}), dbx.Granular_StartTime(time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	assert.Empty(t, granulars)
+
+	// pretend the first pass started an hour ago
+	lastTally := time.Now().UTC().Add(-time.Hour)
+	_, err = db.Update_Tally_By_Name(ctx, dbx.Tally_Name(atRestTally), dbx.Tally_Update_Fields{
+		LastTally: dbx.Tally_LastTally(lastTally),
+	})
+	require.NoError(t, err)
+
+	// the second pass accounts the hour over two runs
+	require.NoError(t, tally.calculateAtRestData(ctx))
+	require.NoError(t, tally.calculateAtRestData(ctx))
+	state = getTallyState(t, db)
+	assert.Equal(t, "", state.CursorPath)
+	assert.True(t, state.LastTally.After(lastTally))
+
+	granulars, err = db.All_Granular_By_EndTime_Greater_And_StartTime_Less(ctx,
+		dbx.Granular_EndTime(time.Time{}), dbx.Granular_StartTime(time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+
+	byteHours := make(map[string]float64)
+	for _, granular := range granulars {
+		assert.True(t, granular.StartTime.Equal(lastTally))
+		assert.True(t, granular.EndTime.Equal(state.LastTally))
+		byteHours[granular.NodeId] = granular.DataTotal
+	}
+	require.Len(t, byteHours, 2)
+	assert.InEpsilon(t, 512+512, byteHours["1"], 0.01)
+	assert.InEpsilon(t, 512+1024, byteHours["2"], 0.01)
+}
 
+func getTallyState(t *testing.T, db *dbx.DB) *dbx.Tally {
+	state, err := db.First_Tally_By_Name(ctx, dbx.Tally_Name(atRestTally))
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	return state
 }
 
-func TestUpdateGranularTable(t *testing.T) {
+// putRemotePointer stores a segment of size bytes with a piece on each of the nodes,
+// the pieces of a 1000 byte segment are 512 bytes in size
+func putRemotePointer(t *testing.T, db storage.KeyValueStore, path string, size int64, nodeIDs ...string) {
+	pointer := &pb.Pointer{
+		Type: pb.Pointer_REMOTE,
+		Size: size,
+		Remote: &pb.RemoteSegment{
+			Redundancy: &pb.RedundancyScheme{
+				MinReq:           2,
+				Total:            4,
+				ErasureShareSize: 256,
+			},
+			PieceId: path,
+		},
+	}
+	for i, id := range nodeIDs {
+		pointer.Remote.RemotePieces = append(pointer.Remote.RemotePieces, &pb.RemotePiece{PieceNum: int32(i), NodeId: id})
+	}
+	value, err := proto.Marshal(pointer)
+	require.NoError(t, err)
+	require.NoError(t, db.Put(storage.Key(path), value))
+}
 
+func putInlinePointer(t *testing.T, db storage.KeyValueStore, path string) {
+	value, err := proto.Marshal(&pb.Pointer{Type: pb.Pointer_INLINE, InlineSegment: []byte("inline"), Size: 6})
+	require.NoError(t, err)
+	require.NoError(t, db.Put(storage.Key(path), value))
 }
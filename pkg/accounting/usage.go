@@ -0,0 +1,86 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package accounting
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zeebo/errs"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+
+	dbx "storj.io/storj/pkg/accounting/dbx"
+	"storj.io/storj/pkg/utils"
+)
+
+var (
+	mon = monkit.Package()
+
+	// UsageError is the error class of the bucket usage
+	UsageError = errs.Class("bucket usage error")
+)
+
+// Usage keeps the stored bytes, segments and objects of every bucket and the limits of the projects
+type Usage struct {
+	mu     sync.Mutex
+	db     *dbx.DB
+	egress Egress
+}
+
+// NewUsage creates the bucket usage stored in db, the egress of projects is only limited if egress isn't nil
+func NewUsage(db *dbx.DB, egress Egress) *Usage {
+	return &Usage{db: db, egress: egress}
+}
+
+// Update adds the deltas to the totals of the bucket of the project with the api key hash
+func (u *Usage) Update(ctx context.Context, apiKeyHash []byte, bucket string, storedBytes, segments, objects int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// the totals are read and written back, concurrent updates must not overwrite each other
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	tx, err := u.db.Open(ctx)
+	if err != nil {
+		return UsageError.Wrap(err)
+	}
+	defer func() {
+		if err != nil {
+			err = UsageError.Wrap(utils.CombineErrors(err, tx.Rollback()))
+		} else {
+			err = UsageError.Wrap(tx.Commit())
+		}
+	}()
+
+	usage, err := tx.First_BucketUsage_By_ApiKeyHash_And_BucketName(ctx,
+		dbx.BucketUsage_ApiKeyHash(apiKeyHash), dbx.BucketUsage_BucketName(bucket))
+	if err != nil {
+		return err
+	}
+	if usage == nil {
+		_, err = tx.Create_BucketUsage(ctx,
+			dbx.BucketUsage_ApiKeyHash(apiKeyHash),
+			dbx.BucketUsage_BucketName(bucket),
+			dbx.BucketUsage_StoredBytes(storedBytes),
+			dbx.BucketUsage_Segments(segments),
+			dbx.BucketUsage_Objects(objects),
+		)
+		return err
+	}
+
+	_, err = tx.Update_BucketUsage_By_Id(ctx, dbx.BucketUsage_Id(usage.Id), dbx.BucketUsage_Update_Fields{
+		StoredBytes: dbx.BucketUsage_StoredBytes(usage.StoredBytes + storedBytes),
+		Segments:    dbx.BucketUsage_Segments(usage.Segments + segments),
+		Objects:     dbx.BucketUsage_Objects(usage.Objects + objects),
+	})
+	return err
+}
+
+// Buckets returns the usage of every bucket of the project with the api key hash, ordered by name
+func (u *Usage) Buckets(ctx context.Context, apiKeyHash []byte) (buckets []*dbx.BucketUsage, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	buckets, err = u.db.All_BucketUsage_By_ApiKeyHash_OrderBy_Asc_BucketName(ctx, dbx.BucketUsage_ApiKeyHash(apiKeyHash))
+	return buckets, UsageError.Wrap(err)
+}
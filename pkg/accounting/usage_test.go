@@ -0,0 +1,88 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package accounting
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/storj"
+)
+
+func TestUsage(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewDB("sqlite3", fmt.Sprintf("file:memdb%d?mode=memory&cache=shared", rand.Int63()))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	usage := NewUsage(db, nil)
+	project, other := []byte("project"), []byte("other")
+
+	require.NoError(t, usage.Update(ctx, project, "b", 100, 1, 1))
+	require.NoError(t, usage.Update(ctx, project, "a", 10, 1, 1))
+	require.NoError(t, usage.Update(ctx, project, "b", 50, 1, 0))
+	require.NoError(t, usage.Update(ctx, project, "b", -100, -1, -1))
+	require.NoError(t, usage.Update(ctx, other, "b", 1, 1, 1))
+
+	buckets, err := usage.Buckets(ctx, project)
+	require.NoError(t, err)
+	require.Len(t, buckets, 2)
+
+	assert.Equal(t, "a", buckets[0].BucketName)
+	assert.Equal(t, []int64{10, 1, 1}, []int64{buckets[0].StoredBytes, buckets[0].Segments, buckets[0].Objects})
+	assert.Equal(t, "b", buckets[1].BucketName)
+	assert.Equal(t, []int64{50, 1, 0}, []int64{buckets[1].StoredBytes, buckets[1].Segments, buckets[1].Objects})
+
+	buckets, err = usage.Buckets(ctx, []byte("unknown"))
+	require.NoError(t, err)
+	assert.Empty(t, buckets)
+}
+
+type mockEgress map[string]int64
+
+func (egress mockEgress) GetBucketEgress(ctx context.Context, apiKeyHash []byte, from, to time.Time) (map[string]int64, error) {
+	return egress, nil
+}
+
+func TestLimits(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewDB("sqlite3", fmt.Sprintf("file:memdb%d?mode=memory&cache=shared", rand.Int63()))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	usage := NewUsage(db, mockEgress{"a": 60, "b": 40})
+	project := []byte("project")
+	require.NoError(t, usage.Update(ctx, project, "a", 60, 1, 1))
+	require.NoError(t, usage.Update(ctx, project, "b", 40, 1, 1))
+
+	// projects are unlimited by default
+	limits, err := usage.GetLimits(ctx, project)
+	require.NoError(t, err)
+	assert.Equal(t, Limits{}, limits)
+	assert.NoError(t, usage.CheckStorage(ctx, project))
+	assert.NoError(t, usage.CheckEgress(ctx, project))
+
+	require.NoError(t, usage.SetLimits(ctx, project, Limits{StorageBytes: 101, EgressBytes: 100}))
+	assert.NoError(t, usage.CheckStorage(ctx, project))
+	assert.True(t, storj.ErrEgressLimit.Has(usage.CheckEgress(ctx, project)))
+
+	require.NoError(t, usage.SetLimits(ctx, project, Limits{StorageBytes: 100, EgressBytes: 101}))
+	limits, err = usage.GetLimits(ctx, project)
+	require.NoError(t, err)
+	assert.Equal(t, Limits{StorageBytes: 100, EgressBytes: 101}, limits)
+	assert.True(t, storj.ErrStorageLimit.Has(usage.CheckStorage(ctx, project)))
+	assert.NoError(t, usage.CheckEgress(ctx, project))
+
+	assert.Error(t, usage.SetLimits(ctx, project, Limits{StorageBytes: -1}))
+
+	// egress isn't limited without the agreements
+	require.NoError(t, usage.SetLimits(ctx, project, Limits{EgressBytes: 1}))
+	assert.NoError(t, NewUsage(db, nil).CheckEgress(ctx, project))
+}
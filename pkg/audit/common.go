@@ -7,5 +7,17 @@ import (
 	"github.com/zeebo/errs"
 )
 
-// Error is the default audit errs class
-var Error = errs.Class("audit error")
+var (
+	// Error is the default audit errs class
+	Error = errs.Class("audit error")
+	// ErrAlteredShare is the error of a node whose share doesn't match the shares of the other nodes
+	ErrAlteredShare = errs.Class("altered share")
+	// ErrInvalidProof is the error of a node whose share doesn't match the Merkle root of its piece
+	ErrInvalidProof = errs.Class("invalid proof")
+	// ErrMissingPiece is the error of a node which answered that it doesn't have the audited piece
+	ErrMissingPiece = errs.Class("missing piece")
+	// ErrInvalidResponse is the error of a node whose answer to an audit can't be read
+	ErrInvalidResponse = errs.Class("invalid response")
+	// ErrUnansweredAudit is the error of a contained node which didn't answer its pending audit too many times
+	ErrUnansweredAudit = errs.Class("unanswered pending audit")
+)
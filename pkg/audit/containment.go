@@ -0,0 +1,246 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/pb"
+	sdbproto "storj.io/storj/pkg/statdb/proto"
+	"storj.io/storj/storage"
+)
+
+// containmentBucket is the bucket of the pending audits
+const containmentBucket = "containment"
+
+// Containment keeps the pending audits of the nodes which didn't answer an audit, a node has
+// at most one pending audit
+type Containment struct {
+	db storage.KeyValueStore
+}
+
+// NewContainment creates the containment with the pending audits stored in db
+func NewContainment(db storage.KeyValueStore) *Containment {
+	return &Containment{db: db}
+}
+
+// Get returns the pending audit of the node or nil if the node isn't contained
+func (containment *Containment) Get(ctx context.Context, nodeID string) (pending *pb.PendingAudit, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	value, err := containment.db.Get(storage.Key(nodeID))
+	if storage.ErrKeyNotFound.Has(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	pending = &pb.PendingAudit{}
+	if err := proto.Unmarshal(value, pending); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return pending, nil
+}
+
+// Put contains the node of the pending audit, replacing its previous pending audit
+func (containment *Containment) Put(ctx context.Context, pending *pb.PendingAudit) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	value, err := proto.Marshal(pending)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return Error.Wrap(containment.db.Put(storage.Key(pending.NodeId), value))
+}
+
+// Delete releases the node from containment
+func (containment *Containment) Delete(ctx context.Context, nodeID string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = containment.db.Delete(storage.Key(nodeID))
+	if storage.ErrKeyNotFound.Has(err) {
+		return nil
+	}
+	return Error.Wrap(err)
+}
+
+// All returns the pending audits of all contained nodes
+func (containment *Containment) All(ctx context.Context) (pending []*pb.PendingAudit, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = containment.db.Iterate(storage.IterateOptions{Recurse: true}, func(it storage.Iterator) error {
+		var item storage.ListItem
+		for it.Next(&item) {
+			audit := &pb.PendingAudit{}
+			if err := proto.Unmarshal(item.Value, audit); err != nil {
+				return err
+			}
+			pending = append(pending, audit)
+		}
+		return nil
+	})
+	return pending, Error.Wrap(err)
+}
+
+// contain records a pending audit of the stripe for every node which didn't answer it. Nodes which
+// are contained already are left out of the result, they only get a result for their pending audit.
+func (containment *Containment) contain(ctx context.Context, stripe *Stripe, result *stripeResult) (contained *stripeResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	uncontained := func(nodeIDs []string) (filtered []string, err error) {
+		for _, nodeID := range nodeIDs {
+			// nodes missing from the overlay can't be contained
+			if nodeID == "" {
+				continue
+			}
+			pending, err := containment.Get(ctx, nodeID)
+			if err != nil {
+				return nil, err
+			}
+			if pending == nil {
+				filtered = append(filtered, nodeID)
+			}
+		}
+		return filtered, nil
+	}
+
+	contained = &stripeResult{errors: result.errors, latencies: result.latencies}
+	if contained.offline, err = uncontained(result.offline); err != nil {
+		return nil, err
+	}
+	if contained.failed, err = uncontained(result.failed); err != nil {
+		return nil, err
+	}
+	if contained.success, err = uncontained(result.success); err != nil {
+		return nil, err
+	}
+
+	for _, nodeID := range contained.offline {
+		err = containment.Put(ctx, &pb.PendingAudit{
+			NodeId:      nodeID,
+			Path:        stripe.Path,
+			PieceId:     stripe.Segment.GetRemote().GetPieceId(),
+			StripeIndex: int64(stripe.Index),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return contained, nil
+}
+
+// reverify re-checks the stripes of the pending audits. A contained node is released once it
+// answers its pending audit and fails the audit after not answering it maxReverifyCount times.
+func (service *Service) reverify(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pending, err := service.Containment.All(ctx)
+	if err != nil {
+		return err
+	}
+
+	var statuses []*sdbproto.Node
+	var records []*Record
+	for _, audit := range pending {
+		status, record, err := service.reverifyNode(ctx, audit)
+		if err != nil {
+			zap.L().Error("reverifying pending audit failed", zap.String("node", audit.NodeId), zap.Error(err))
+			continue
+		}
+		if status != nil {
+			statuses = append(statuses, status)
+			records = append(records, record)
+		}
+	}
+	if len(statuses) == 0 {
+		return nil
+	}
+	if err := service.Reporter.RecordAudits(ctx, statuses); err != nil {
+		return err
+	}
+	return service.recordHistory(ctx, records)
+}
+
+// reverifyNode re-checks the stripe of the pending audit and returns the status of its node with
+// the record of the audit, the node is released without a status if the stripe doesn't exist anymore
+func (service *Service) reverifyNode(ctx context.Context, pending *pb.PendingAudit) (status *sdbproto.Node, record *Record, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pointer, _, _, err := service.Cursor.pointers.Get(ctx, pending.Path)
+	if storage.ErrKeyNotFound.Has(err) {
+		return nil, nil, service.Containment.Delete(ctx, pending.NodeId)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	piece := remotePiece(pointer, pending.NodeId)
+	if pointer.GetRemote().GetPieceId() != pending.PieceId || piece == nil {
+		return nil, nil, service.Containment.Delete(ctx, pending.NodeId)
+	}
+
+	authorization := service.Cursor.pointers.SignedMessage()
+	var result *stripeResult
+	if provable(pointer) {
+		// only the contained node is asked for its share
+		result = service.Verifier.prove(ctx, int(pending.StripeIndex), pointer, []*pb.RemotePiece{piece}, authorization)
+	} else {
+		result, err = service.Verifier.check(ctx, int(pending.StripeIndex), pointer, authorization)
+		if err != nil {
+			// a stripe which can't be checked, e.g. without enough shares of the other nodes,
+			// isn't answered by the contained node either
+			result = &stripeResult{}
+			result.detail(pending.NodeId, err, 0)
+		}
+	}
+
+	record = &Record{
+		NodeID:      pending.NodeId,
+		Path:        pending.Path,
+		StripeIndex: pending.StripeIndex,
+		ErrorClass:  errorClass(result.errors[pending.NodeId]),
+		Latency:     result.latencies[pending.NodeId],
+	}
+
+	switch {
+	case containsNode(result.success, pending.NodeId):
+		record.Outcome = OutcomeSuccess
+		return setSuccessStatus(ctx, []string{pending.NodeId})[0], record, service.Containment.Delete(ctx, pending.NodeId)
+	case containsNode(result.failed, pending.NodeId):
+		record.Outcome = OutcomeFailed
+		return setAuditFailStatus(ctx, []string{pending.NodeId})[0], record, service.Containment.Delete(ctx, pending.NodeId)
+	}
+
+	// not answering the pending audit again and again is failing it
+	pending.ReverifyCount++
+	if int(pending.ReverifyCount) >= service.maxReverifyCount {
+		record.Outcome = OutcomeFailed
+		record.ErrorClass = errorClass(ErrUnansweredAudit.New("%d times", pending.ReverifyCount))
+		return setAuditFailStatus(ctx, []string{pending.NodeId})[0], record, service.Containment.Delete(ctx, pending.NodeId)
+	}
+	record.Outcome = OutcomeOffline
+	return setOfflineStatus(ctx, []string{pending.NodeId})[0], record, service.Containment.Put(ctx, pending)
+}
+
+// remotePiece returns the piece of the remote segment stored by the node or nil
+func remotePiece(pointer *pb.Pointer, nodeID string) *pb.RemotePiece {
+	for _, piece := range pointer.GetRemote().GetRemotePieces() {
+		if piece.GetNodeId() == nodeID {
+			return piece
+		}
+	}
+	return nil
+}
+
+func containsNode(nodeIDs []string, nodeID string) bool {
+	for _, id := range nodeIDs {
+		if id == nodeID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vivint/infectious"
+
+	"storj.io/storj/pkg/pb"
+	mock_pointerdb "storj.io/storj/pkg/pointerdb/pdbclient/mocks"
+	sdbproto "storj.io/storj/pkg/statdb/proto"
+	"storj.io/storj/storage/teststore"
+)
+
+// stripeDownloader returns the share of every node of the pointer unless the node is offline
+type stripeDownloader struct {
+	shares  map[string][]byte
+	offline map[string]bool
+}
+
+func (d *stripeDownloader) DownloadShares(ctx context.Context, pointer *pb.Pointer,
+	stripeIndex int, authorization *pb.SignedMessage) (shares []share, nodes []*pb.Node, err error) {
+	for _, piece := range pointer.GetRemote().GetRemotePieces() {
+		s := share{PieceNumber: int(piece.PieceNum), Data: d.shares[piece.NodeId]}
+		if d.offline[piece.NodeId] {
+			s = share{PieceNumber: int(piece.PieceNum), Error: Error.New("timeout")}
+		}
+		shares = append(shares, s)
+		nodes = append(nodes, &pb.Node{Id: piece.NodeId})
+	}
+	return shares, nodes, nil
+}
+
+func (d *stripeDownloader) ProveShare(ctx context.Context, pointer *pb.Pointer, piece *pb.RemotePiece,
+	stripeIndex int, authorization *pb.SignedMessage) (s share, proof [][]byte, err error) {
+	return s, nil, Error.New("segments without Merkle roots are audited with a quorum")
+}
+
+type mockReporter struct {
+	statuses []*sdbproto.Node
+}
+
+func (r *mockReporter) RecordAudits(ctx context.Context, nodes []*sdbproto.Node) error {
+	r.statuses = append(r.statuses, nodes...)
+	return nil
+}
+
+// takeStatuses returns the recorded statuses by node id and forgets them
+func (r *mockReporter) takeStatuses() map[string]*sdbproto.Node {
+	statuses := make(map[string]*sdbproto.Node)
+	for _, status := range r.statuses {
+		statuses[string(status.NodeId)] = status
+	}
+	r.statuses = nil
+	return statuses
+}
+
+func TestContainment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nodeIDs := []string{"a", "b", "c", "d"}
+	fec, err := infectious.NewFEC(2, 4)
+	require.NoError(t, err)
+	shares := make(map[string][]byte)
+	require.NoError(t, fec.Encode(randData(64), func(s infectious.Share) {
+		shares[nodeIDs[s.Number]] = append([]byte{}, s.Data...)
+	}))
+
+	pointer := &pb.Pointer{
+		Type: pb.Pointer_REMOTE,
+		Size: 64,
+		Remote: &pb.RemoteSegment{
+			Redundancy: &pb.RedundancyScheme{MinReq: 2, Total: 4, ErasureShareSize: 32},
+			PieceId:    "piece",
+		},
+	}
+	for i, nodeID := range nodeIDs {
+		pointer.Remote.RemotePieces = append(pointer.Remote.RemotePieces, &pb.RemotePiece{PieceNum: int32(i), NodeId: nodeID})
+	}
+
+	pointers := mock_pointerdb.NewMockClient(ctrl)
+	pointers.EXPECT().Get(gomock.Any(), "l/bucket/object").Return(pointer, nil, nil, nil).AnyTimes()
+	pointers.EXPECT().SignedMessage().AnyTimes()
+
+	downloader := &stripeDownloader{shares: shares, offline: map[string]bool{"a": true}}
+	reporter := &mockReporter{}
+	containment := NewContainment(teststore.New())
+	history := newTestHistory(t)
+	defer func() { assert.NoError(t, history.db.Close()) }()
+	service := &Service{
+		Cursor:           NewCursor(pointers, nil, nil, ScheduleConfig{}),
+		Verifier:         &Verifier{downloader: downloader},
+		Reporter:         reporter,
+		Containment:      containment,
+		History:          history,
+		maxReverifyCount: 2,
+	}
+	stripe := &Stripe{Index: 0, Segment: pointer, Path: "l/bucket/object"}
+
+	audit := func() {
+		result, err := service.Verifier.check(ctx, stripe.Index, stripe.Segment, nil)
+		require.NoError(t, err)
+		result, err = containment.contain(ctx, stripe, result)
+		require.NoError(t, err)
+		require.NoError(t, reporter.RecordAudits(ctx, result.statuses(ctx)))
+	}
+
+	// the node which doesn't answer is contained with the stripe
+	audit()
+	statuses := reporter.takeStatuses()
+	assert.Len(t, statuses, 4)
+	assert.False(t, statuses["a"].IsUp)
+	assert.True(t, statuses["b"].AuditSuccess)
+	pending, err := containment.Get(ctx, "a")
+	require.NoError(t, err)
+	require.NotNil(t, pending)
+	assert.Equal(t, &pb.PendingAudit{NodeId: "a", Path: "l/bucket/object", PieceId: "piece"}, pending)
+
+	// a contained node doesn't get results for other audits
+	audit()
+	statuses = reporter.takeStatuses()
+	assert.Len(t, statuses, 3)
+	assert.Nil(t, statuses["a"])
+
+	// not answering the pending audit is reported offline until it fails the audit
+	require.NoError(t, service.reverify(ctx))
+	statuses = reporter.takeStatuses()
+	assert.Len(t, statuses, 1)
+	assert.False(t, statuses["a"].IsUp)
+	assert.False(t, statuses["a"].UpdateAuditSuccess)
+
+	require.NoError(t, service.reverify(ctx))
+	statuses = reporter.takeStatuses()
+	assert.Len(t, statuses, 1)
+	assert.True(t, statuses["a"].UpdateAuditSuccess)
+	assert.False(t, statuses["a"].AuditSuccess)
+
+	pending, err = containment.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Nil(t, pending)
+
+	// the history tells why the node failed
+	records, err := history.Query(ctx, "a", time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, OutcomeFailed, records[0].Outcome)
+	assert.Equal(t, "unanswered pending audit", records[0].ErrorClass)
+	assert.Equal(t, OutcomeOffline, records[1].Outcome)
+	assert.Equal(t, "audit error", records[1].ErrorClass)
+
+	// answering the pending audit releases the node
+	audit()
+	_ = reporter.takeStatuses()
+	downloader.offline = nil
+	require.NoError(t, service.reverify(ctx))
+	statuses = reporter.takeStatuses()
+	assert.Len(t, statuses, 1)
+	assert.True(t, statuses["a"].AuditSuccess)
+
+	all, err := containment.All(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, all)
+
+	// a pending audit which can't be checked without the shares of the other nodes isn't answered either
+	downloader.offline = map[string]bool{"a": true}
+	audit()
+	_ = reporter.takeStatuses()
+	downloader.offline = map[string]bool{"a": true, "b": true, "c": true}
+	require.NoError(t, service.reverify(ctx))
+	statuses = reporter.takeStatuses()
+	assert.Len(t, statuses, 1)
+	assert.False(t, statuses["a"].IsUp)
+
+	require.NoError(t, service.reverify(ctx))
+	statuses = reporter.takeStatuses()
+	assert.Len(t, statuses, 1)
+	assert.True(t, statuses["a"].UpdateAuditSuccess)
+	assert.False(t, statuses["a"].AuditSuccess)
+
+	all, err = containment.All(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
@@ -4,77 +4,119 @@
 package audit
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"math/big"
 	"sync"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/vivint/infectious"
+	"go.uber.org/zap"
 
 	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/pointerdb/pdbclient"
-	"storj.io/storj/pkg/storage/meta"
+	statpb "storj.io/storj/pkg/statdb/proto"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
 )
 
 // Stripe keeps track of a stripe's index and its parent segment
 type Stripe struct {
 	Index         int
 	Segment       *pb.Pointer
+	Path          storj.Path
 	Authorization *pb.SignedMessage
 }
 
-// Cursor keeps track of audit location in pointer db
+// ScheduleConfig configures how often nodes are audited
+type ScheduleConfig struct {
+	WalkLimit       int           `help:"number of segments sampled from pointerdb before every audit" default:"1000"`
+	ReservoirSize   int           `help:"number of segments sampled for every node during a pointerdb walk" default:"2"`
+	NodeInterval    time.Duration `help:"maximum time between two audits of a vetted node" default:"24h"`
+	VettingInterval time.Duration `help:"maximum time between two audits of a new or suspicious node" default:"1h"`
+	VettedAudits    int64         `help:"number of audits after which a node is vetted" default:"50"`
+	SuspiciousRatio float64       `help:"audit success ratio below which a node is audited as often as a new node" default:"0.9"`
+}
+
+// walker walks the segments of pointerdb
+type walker interface {
+	Iterate(ctx context.Context, req *pb.IterateRequest, f func(it storage.Iterator) error) error
+}
+
+// nodeStats gives access to the audit history of nodes
+type nodeStats interface {
+	Get(ctx context.Context, nodeID []byte) (stats *statpb.NodeStats, err error)
+}
+
+// Cursor selects the stripes to audit so that every node is audited at least as often as its
+// schedule requires. It walks pointerdb a page at a time and keeps a reservoir sample of the
+// segments of every node, the node which is due first gets a stripe of one of its segments.
 type Cursor struct {
 	pointers pdbclient.Client
-	lastPath storj.Path
+	walker   walker
+	stats    nodeStats
+	config   ScheduleConfig
 	mutex    sync.Mutex
+
+	// lastPath is where the walk continues
+	lastPath storj.Path
+	// walking holds the samples of the current walk, samples the ones of the last complete walk
+	walking map[string]*reservoir
+	samples map[string]*reservoir
+	// due is when the nodes have to be audited next, new nodes are due immediately
+	due map[string]time.Time
 }
 
-// NewCursor creates a Cursor which iterates over pointer db
-func NewCursor(pointers pdbclient.Client) *Cursor {
-	return &Cursor{pointers: pointers}
+// NewCursor creates a Cursor which walks pointer db, the nodes are vetted according to stats
+// unless stats is nil
+func NewCursor(pointers pdbclient.Client, walker walker, stats nodeStats, config ScheduleConfig) *Cursor {
+	return &Cursor{
+		pointers: pointers,
+		walker:   walker,
+		stats:    stats,
+		config:   config,
+		walking:  make(map[string]*reservoir),
+		due:      make(map[string]time.Time),
+	}
 }
 
-// NextStripe returns a random stripe to be audited
+// NextStripe returns a random stripe of a segment of the node which is due first
 func (cursor *Cursor) NextStripe(ctx context.Context) (stripe *Stripe, err error) {
+	defer mon.Task()(&ctx)(&err)
 	cursor.mutex.Lock()
 	defer cursor.mutex.Unlock()
 
-	var pointerItems []pdbclient.ListItem
-	var path storj.Path
-	var more bool
-
-	if cursor.lastPath == "" {
-		pointerItems, more, err = cursor.pointers.List(ctx, "", "", "", true, 0, meta.None)
-	} else {
-		pointerItems, more, err = cursor.pointers.List(ctx, "", cursor.lastPath, "", true, 0, meta.None)
-	}
-	if err != nil {
+	if err := cursor.walk(ctx); err != nil {
 		return nil, err
 	}
 
-	if len(pointerItems) == 0 {
-		return nil, nil
+	// until the first walk is complete the partial samples are used
+	samples := cursor.samples
+	if samples == nil {
+		samples = cursor.walking
 	}
 
-	pointerItem, err := getRandomPointer(pointerItems)
+	nodeID, ok := cursor.nextNode(samples)
+	if !ok {
+		return nil, nil
+	}
+	path, err := samples[nodeID].random()
 	if err != nil {
 		return nil, err
 	}
 
-	path = pointerItem.Path
-
-	// keep track of last path listed
-	if !more {
-		cursor.lastPath = ""
-	} else {
-		cursor.lastPath = pointerItems[len(pointerItems)-1].Path
-	}
+	// the node is rescheduled first, so that a broken segment doesn't keep it from being audited
+	cursor.schedule(ctx, nodeID)
 
 	// get pointer info
-	pointer, _, err := cursor.pointers.Get(ctx, path)
+	pointer, _, _, err := cursor.pointers.Get(ctx, path)
+	if storage.ErrKeyNotFound.Has(err) {
+		// the segment was deleted since it was sampled
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +142,98 @@ func (cursor *Cursor) NextStripe(ctx context.Context) (stripe *Stripe, err error
 
 	authorization := cursor.pointers.SignedMessage()
 
-	return &Stripe{Index: index, Segment: pointer, Authorization: authorization}, nil
+	return &Stripe{Index: index, Segment: pointer, Path: path, Authorization: authorization}, nil
+}
+
+// walk samples the segments of the next page of pointerdb, the samples are replaced when the walk is complete
+func (cursor *Cursor) walk(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var next storj.Path
+	err = cursor.walker.Iterate(ctx, &pb.IterateRequest{First: cursor.lastPath, Recurse: true},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			limit := cursor.config.WalkLimit
+			if limit <= 0 || limit > storage.LookupLimit {
+				limit = storage.LookupLimit
+			}
+			for limit > 0 {
+				if !it.Next(&item) {
+					next = ""
+					return nil
+				}
+				if cursor.lastPath != "" && bytes.Equal(item.Key, storage.Key(cursor.lastPath)) {
+					continue
+				}
+				limit--
+				next = item.Key.String()
+
+				pointer := &pb.Pointer{}
+				if err := proto.Unmarshal(item.Value, pointer); err != nil {
+					return Error.Wrap(err)
+				}
+				if pointer.GetType() != pb.Pointer_REMOTE || pointer.GetSize() == 0 {
+					continue
+				}
+				for _, piece := range pointer.GetRemote().GetRemotePieces() {
+					sample, ok := cursor.walking[piece.NodeId]
+					if !ok {
+						sample = &reservoir{}
+						cursor.walking[piece.NodeId] = sample
+					}
+					sample.add(item.Key.String(), cursor.config.ReservoirSize)
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	cursor.lastPath = next
+	if next != "" {
+		return nil
+	}
+
+	// the walk is complete, nodes which don't store any segments anymore aren't audited
+	cursor.samples, cursor.walking = cursor.walking, make(map[string]*reservoir)
+	for nodeID := range cursor.due {
+		if _, ok := cursor.samples[nodeID]; !ok {
+			delete(cursor.due, nodeID)
+		}
+	}
+	return nil
+}
+
+// nextNode returns the sampled node which is due first
+func (cursor *Cursor) nextNode(samples map[string]*reservoir) (nodeID string, ok bool) {
+	var first time.Time
+	for id := range samples {
+		due := cursor.due[id]
+		if !ok || due.Before(first) {
+			nodeID, first, ok = id, due, true
+		}
+	}
+
+	if ok && !first.IsZero() && time.Since(first) > cursor.config.VettingInterval {
+		zap.L().Warn("audits are falling behind the schedule", zap.String("node", nodeID), zap.Time("due", first))
+	}
+	return nodeID, ok
+}
+
+// schedule sets when the node is due next, new and suspicious nodes are audited more often than vetted nodes
+func (cursor *Cursor) schedule(ctx context.Context, nodeID string) {
+	interval := cursor.config.NodeInterval
+	if cursor.stats != nil {
+		stats, err := cursor.stats.Get(ctx, []byte(nodeID))
+		// nodes which aren't in statdb yet are new
+		if err != nil || stats.GetAuditCount() < cursor.config.VettedAudits ||
+			stats.GetAuditSuccessRatio() < cursor.config.SuspiciousRatio {
+			interval = cursor.config.VettingInterval
+		}
+	}
+	cursor.due[nodeID] = time.Now().Add(interval)
 }
 
 func makeErasureScheme(rs *pb.RedundancyScheme) (eestream.ErasureScheme, error) {
@@ -130,12 +263,34 @@ func getRandomStripe(es eestream.ErasureScheme, pointer *pb.Pointer) (index int,
 	return int(randomStripeIndex.Int64()), nil
 }
 
-func getRandomPointer(pointerItems []pdbclient.ListItem) (pointer pdbclient.ListItem, err error) {
-	randomNum, err := rand.Int(rand.Reader, big.NewInt(int64(len(pointerItems))))
+// reservoir keeps a uniform random sample of the segments of a node
+type reservoir struct {
+	paths []storj.Path
+	seen  int64
+}
+
+// add samples the path as one of size paths
+func (r *reservoir) add(path storj.Path, size int) {
+	if size <= 0 {
+		size = 1
+	}
+	r.seen++
+	if len(r.paths) < size {
+		r.paths = append(r.paths, path)
+		return
+	}
+	// every path seen so far stays in the sample with the same probability
+	i, err := rand.Int(rand.Reader, big.NewInt(r.seen))
+	if err == nil && i.Int64() < int64(size) {
+		r.paths[i.Int64()] = path
+	}
+}
+
+// random returns one of the sampled paths
+func (r *reservoir) random() (storj.Path, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(len(r.paths))))
 	if err != nil {
-		return pdbclient.ListItem{}, err
+		return "", err
 	}
-	randomNumInt64 := randomNum.Int64()
-	pointerItem := pointerItems[randomNumInt64]
-	return pointerItem, nil
+	return r.paths[i.Int64()], nil
 }
@@ -10,9 +10,14 @@ import (
 	"math"
 	"math/big"
 	"reflect"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
@@ -21,9 +26,12 @@ import (
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/pointerdb"
 	"storj.io/storj/pkg/pointerdb/pdbclient"
+	mock_pointerdb "storj.io/storj/pkg/pointerdb/pdbclient/mocks"
 	"storj.io/storj/pkg/provider"
+	statpb "storj.io/storj/pkg/statdb/proto"
 	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
 	"storj.io/storj/storage/teststore"
 )
 
@@ -58,6 +66,14 @@ func (pbd *pointerDBWrapper) Delete(ctx context.Context, in *pb.DeleteRequest, o
 	return pbd.s.Delete(ctx, in)
 }
 
+func (pbd *pointerDBWrapper) PayerBandwidthAllocation(ctx context.Context, in *pb.PayerBandwidthAllocationRequest, opts ...grpc.CallOption) (*pb.PayerBandwidthAllocationResponse, error) {
+	return pbd.s.PayerBandwidthAllocation(ctx, in)
+}
+
+func (pbd *pointerDBWrapper) OrderLimits(ctx context.Context, in *pb.OrderLimitsRequest, opts ...grpc.CallOption) (*pb.OrderLimitsResponse, error) {
+	return pbd.s.OrderLimits(ctx, in)
+}
+
 func TestAuditSegment(t *testing.T) {
 	type pathCount struct {
 		path  storj.Path
@@ -126,11 +142,12 @@ func TestAuditSegment(t *testing.T) {
 
 	cache := overlay.NewOverlayCache(teststore.New(), nil)
 
-	pdbw := newPointerDBWrapper(pointerdb.NewServer(db, cache, zap.NewNop(), c, identity))
+	server := pointerdb.NewServer(db, cache, nil, nil, zap.NewNop(), c, identity)
+	pdbw := newPointerDBWrapper(server)
 	pointers := pdbclient.New(pdbw)
 
 	// create a pdb client and instance of audit
-	cursor := NewCursor(pointers)
+	cursor := NewCursor(pointers, server, nil, ScheduleConfig{})
 
 	// put 10 paths in db
 	t.Run("putToDB", func(t *testing.T) {
@@ -259,3 +276,93 @@ func makePutRequest(path storj.Path) pb.PutRequest {
 	}
 	return pr
 }
+
+func TestReservoir(t *testing.T) {
+	r := &reservoir{}
+	for i := 0; i < 100; i++ {
+		r.add(storj.Path(strconv.Itoa(i)), 3)
+	}
+	assert.Len(t, r.paths, 3)
+	assert.Equal(t, int64(100), r.seen)
+
+	path, err := r.random()
+	assert.NoError(t, err)
+	assert.Contains(t, r.paths, path)
+}
+
+type mockStats map[string]*statpb.NodeStats
+
+func (stats mockStats) Get(ctx context.Context, nodeID []byte) (*statpb.NodeStats, error) {
+	if s, ok := stats[string(nodeID)]; ok {
+		return s, nil
+	}
+	return nil, errors.New("node not found")
+}
+
+func TestCursorSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := teststore.New()
+	pointers := mock_pointerdb.NewMockClient(ctrl)
+	stored := make(map[string]*pb.Pointer)
+	for path, nodeIDs := range map[string][]string{
+		"a/1": {"vetted", "suspicious"},
+		"a/2": {"suspicious", "new"},
+		"a/3": {"new"},
+	} {
+		pointer := makePutRequest(path).Pointer
+		pointer.Remote.RemotePieces = nil
+		for i, nodeID := range nodeIDs {
+			pointer.Remote.RemotePieces = append(pointer.Remote.RemotePieces, &pb.RemotePiece{PieceNum: int32(i), NodeId: nodeID})
+		}
+		value, err := proto.Marshal(pointer)
+		require.NoError(t, err)
+		require.NoError(t, db.Put(storage.Key(path), value))
+		stored[path] = pointer
+	}
+	pointers.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, path storj.Path) (*pb.Pointer, []*pb.Node, []*pb.PayerBandwidthAllocation, error) {
+			return stored[path], nil, nil, nil
+		}).AnyTimes()
+	pointers.EXPECT().SignedMessage().AnyTimes()
+
+	stats := mockStats{
+		"vetted":     {AuditCount: 100, AuditSuccessRatio: 1},
+		"suspicious": {AuditCount: 100, AuditSuccessRatio: 0.5},
+	}
+	config := ScheduleConfig{
+		WalkLimit:       2,
+		ReservoirSize:   1,
+		NodeInterval:    24 * time.Hour,
+		VettingInterval: time.Hour,
+		VettedAudits:    50,
+		SuspiciousRatio: 0.9,
+	}
+	walker := pointerdb.NewServer(db, nil, nil, nil, zap.NewNop(), pointerdb.Config{}, nil)
+	cursor := NewCursor(pointers, walker, stats, config)
+
+	// every node is audited once before any node is audited again
+	for i := 0; i < 3; i++ {
+		stripe, err := cursor.NextStripe(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, stripe)
+	}
+	require.Len(t, cursor.due, 3)
+	require.NotNil(t, cursor.samples, "the walk should be complete")
+
+	now := time.Now()
+	assert.WithinDuration(t, now.Add(config.NodeInterval), cursor.due["vetted"], time.Minute)
+	assert.WithinDuration(t, now.Add(config.VettingInterval), cursor.due["suspicious"], time.Minute)
+	assert.WithinDuration(t, now.Add(config.VettingInterval), cursor.due["new"], time.Minute)
+
+	// the vetted node isn't due before the others
+	for i := 0; i < 2; i++ {
+		stripe, err := cursor.NextStripe(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, stripe)
+	}
+	assert.WithinDuration(t, now.Add(config.NodeInterval), cursor.due["vetted"], time.Minute)
+	assert.True(t, cursor.due["suspicious"].After(now.Add(config.VettingInterval)))
+	assert.True(t, cursor.due["new"].After(now.Add(config.VettingInterval)))
+}
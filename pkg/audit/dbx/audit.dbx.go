@@ -0,0 +1,1276 @@
+// AUTOGENERATED BY gopkg.in/spacemonkeygo/dbx.v1
+// DO NOT EDIT.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/lib/pq"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// Prevent conditional imports from causing build failures
+var _ = strconv.Itoa
+var _ = strings.LastIndex
+var _ = fmt.Sprint
+var _ sync.Mutex
+
+var (
+	WrapErr = func(err *Error) error { return err }
+	Logger  func(format string, args ...interface{})
+
+	errTooManyRows       = errors.New("too many rows")
+	errUnsupportedDriver = errors.New("unsupported driver")
+	errEmptyUpdate       = errors.New("empty update")
+)
+
+func logError(format string, args ...interface{}) {
+	if Logger != nil {
+		Logger(format, args...)
+	}
+}
+
+type ErrorCode int
+
+const (
+	ErrorCode_Unknown ErrorCode = iota
+	ErrorCode_UnsupportedDriver
+	ErrorCode_NoRows
+	ErrorCode_TxDone
+	ErrorCode_TooManyRows
+	ErrorCode_ConstraintViolation
+	ErrorCode_EmptyUpdate
+)
+
+type Error struct {
+	Err         error
+	Code        ErrorCode
+	Driver      string
+	Constraint  string
+	QuerySuffix string
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func wrapErr(e *Error) error {
+	if WrapErr == nil {
+		return e
+	}
+	return WrapErr(e)
+}
+
+func makeErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	e := &Error{Err: err}
+	switch err {
+	case sql.ErrNoRows:
+		e.Code = ErrorCode_NoRows
+	case sql.ErrTxDone:
+		e.Code = ErrorCode_TxDone
+	}
+	return wrapErr(e)
+}
+
+func unsupportedDriver(driver string) error {
+	return wrapErr(&Error{
+		Err:    errUnsupportedDriver,
+		Code:   ErrorCode_UnsupportedDriver,
+		Driver: driver,
+	})
+}
+
+func emptyUpdate() error {
+	return wrapErr(&Error{
+		Err:  errEmptyUpdate,
+		Code: ErrorCode_EmptyUpdate,
+	})
+}
+
+func tooManyRows(query_suffix string) error {
+	return wrapErr(&Error{
+		Err:         errTooManyRows,
+		Code:        ErrorCode_TooManyRows,
+		QuerySuffix: query_suffix,
+	})
+}
+
+func constraintViolation(err error, constraint string) error {
+	return wrapErr(&Error{
+		Err:        err,
+		Code:       ErrorCode_ConstraintViolation,
+		Constraint: constraint,
+	})
+}
+
+type driver interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+var (
+	notAPointer     = errors.New("destination not a pointer")
+	lossyConversion = errors.New("lossy conversion")
+)
+
+type DB struct {
+	*sql.DB
+	dbMethods
+
+	Hooks struct {
+		Now func() time.Time
+	}
+}
+
+func Open(driver, source string) (db *DB, err error) {
+	var sql_db *sql.DB
+	switch driver {
+	case "postgres":
+		sql_db, err = openpostgres(source)
+	case "sqlite3":
+		sql_db, err = opensqlite3(source)
+	default:
+		return nil, unsupportedDriver(driver)
+	}
+	if err != nil {
+		return nil, makeErr(err)
+	}
+	defer func(sql_db *sql.DB) {
+		if err != nil {
+			sql_db.Close()
+		}
+	}(sql_db)
+
+	if err := sql_db.Ping(); err != nil {
+		return nil, makeErr(err)
+	}
+
+	db = &DB{
+		DB: sql_db,
+	}
+	db.Hooks.Now = time.Now
+
+	switch driver {
+	case "postgres":
+		db.dbMethods = newpostgres(db)
+	case "sqlite3":
+		db.dbMethods = newsqlite3(db)
+	default:
+		return nil, unsupportedDriver(driver)
+	}
+
+	return db, nil
+}
+
+func (obj *DB) Close() (err error) {
+	return obj.makeErr(obj.DB.Close())
+}
+
+func (obj *DB) Open(ctx context.Context) (*Tx, error) {
+	tx, err := obj.DB.Begin()
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+
+	return &Tx{
+		Tx:        tx,
+		txMethods: obj.wrapTx(tx),
+	}, nil
+}
+
+func (obj *DB) NewRx() *Rx {
+	return &Rx{db: obj}
+}
+
+func DeleteAll(ctx context.Context, db *DB) (int64, error) {
+	tx, err := db.Open(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err == nil {
+			err = db.makeErr(tx.Commit())
+			return
+		}
+
+		if err_rollback := tx.Rollback(); err_rollback != nil {
+			logError("delete-all: rollback failed: %v", db.makeErr(err_rollback))
+		}
+	}()
+	return tx.deleteAll(ctx)
+}
+
+type Tx struct {
+	Tx *sql.Tx
+	txMethods
+}
+
+type dialectTx struct {
+	tx *sql.Tx
+}
+
+func (tx *dialectTx) Commit() (err error) {
+	return makeErr(tx.tx.Commit())
+}
+
+func (tx *dialectTx) Rollback() (err error) {
+	return makeErr(tx.tx.Rollback())
+}
+
+type postgresImpl struct {
+	db      *DB
+	dialect __sqlbundle_postgres
+	driver  driver
+}
+
+func (obj *postgresImpl) Rebind(s string) string {
+	return obj.dialect.Rebind(s)
+}
+
+func (obj *postgresImpl) logStmt(stmt string, args ...interface{}) {
+	postgresLogStmt(stmt, args...)
+}
+
+func (obj *postgresImpl) makeErr(err error) error {
+	constraint, ok := obj.isConstraintError(err)
+	if ok {
+		return constraintViolation(err, constraint)
+	}
+	return makeErr(err)
+}
+
+type postgresDB struct {
+	db *DB
+	*postgresImpl
+}
+
+func newpostgres(db *DB) *postgresDB {
+	return &postgresDB{
+		db: db,
+		postgresImpl: &postgresImpl{
+			db:     db,
+			driver: db.DB,
+		},
+	}
+}
+
+func (obj *postgresDB) Schema() string {
+	return `CREATE TABLE audit_records (
+	id bigserial NOT NULL,
+	node_id text NOT NULL,
+	path text NOT NULL,
+	stripe_index bigint NOT NULL,
+	outcome text NOT NULL,
+	error_class text NOT NULL,
+	latency bigint NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
+CREATE INDEX audit_records_node_id_created_at_index ON audit_records ( node_id, created_at );
+CREATE INDEX audit_records_created_at_index ON audit_records ( created_at );`
+}
+
+func (obj *postgresDB) wrapTx(tx *sql.Tx) txMethods {
+	return &postgresTx{
+		dialectTx: dialectTx{tx: tx},
+		postgresImpl: &postgresImpl{
+			db:     obj.db,
+			driver: tx,
+		},
+	}
+}
+
+type postgresTx struct {
+	dialectTx
+	*postgresImpl
+}
+
+func postgresLogStmt(stmt string, args ...interface{}) {
+	// TODO: render placeholders
+	if Logger != nil {
+		out := fmt.Sprintf("stmt: %s\nargs: %v\n", stmt, pretty(args))
+		Logger(out)
+	}
+}
+
+type sqlite3Impl struct {
+	db      *DB
+	dialect __sqlbundle_sqlite3
+	driver  driver
+}
+
+func (obj *sqlite3Impl) Rebind(s string) string {
+	return obj.dialect.Rebind(s)
+}
+
+func (obj *sqlite3Impl) logStmt(stmt string, args ...interface{}) {
+	sqlite3LogStmt(stmt, args...)
+}
+
+func (obj *sqlite3Impl) makeErr(err error) error {
+	constraint, ok := obj.isConstraintError(err)
+	if ok {
+		return constraintViolation(err, constraint)
+	}
+	return makeErr(err)
+}
+
+type sqlite3DB struct {
+	db *DB
+	*sqlite3Impl
+}
+
+func newsqlite3(db *DB) *sqlite3DB {
+	return &sqlite3DB{
+		db: db,
+		sqlite3Impl: &sqlite3Impl{
+			db:     db,
+			driver: db.DB,
+		},
+	}
+}
+
+func (obj *sqlite3DB) Schema() string {
+	return `CREATE TABLE audit_records (
+	id INTEGER NOT NULL,
+	node_id TEXT NOT NULL,
+	path TEXT NOT NULL,
+	stripe_index INTEGER NOT NULL,
+	outcome TEXT NOT NULL,
+	error_class TEXT NOT NULL,
+	latency INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( id )
+);
+CREATE INDEX audit_records_node_id_created_at_index ON audit_records ( node_id, created_at );
+CREATE INDEX audit_records_created_at_index ON audit_records ( created_at );`
+}
+
+func (obj *sqlite3DB) wrapTx(tx *sql.Tx) txMethods {
+	return &sqlite3Tx{
+		dialectTx: dialectTx{tx: tx},
+		sqlite3Impl: &sqlite3Impl{
+			db:     obj.db,
+			driver: tx,
+		},
+	}
+}
+
+type sqlite3Tx struct {
+	dialectTx
+	*sqlite3Impl
+}
+
+func sqlite3LogStmt(stmt string, args ...interface{}) {
+	// TODO: render placeholders
+	if Logger != nil {
+		out := fmt.Sprintf("stmt: %s\nargs: %v\n", stmt, pretty(args))
+		Logger(out)
+	}
+}
+
+type pretty []interface{}
+
+func (p pretty) Format(f fmt.State, c rune) {
+	fmt.Fprint(f, "[")
+nextval:
+	for i, val := range p {
+		if i > 0 {
+			fmt.Fprint(f, ", ")
+		}
+		rv := reflect.ValueOf(val)
+		if rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				fmt.Fprint(f, "NULL")
+				continue
+			}
+			val = rv.Elem().Interface()
+		}
+		switch v := val.(type) {
+		case string:
+			fmt.Fprintf(f, "%q", v)
+		case time.Time:
+			fmt.Fprintf(f, "%s", v.Format(time.RFC3339Nano))
+		case []byte:
+			for _, b := range v {
+				if !unicode.IsPrint(rune(b)) {
+					fmt.Fprintf(f, "%#x", v)
+					continue nextval
+				}
+			}
+			fmt.Fprintf(f, "%q", v)
+		default:
+			fmt.Fprintf(f, "%v", v)
+		}
+	}
+	fmt.Fprint(f, "]")
+}
+
+type AuditRecord struct {
+	Id          int64
+	NodeId      string
+	Path        string
+	StripeIndex int64
+	Outcome     string
+	ErrorClass  string
+	Latency     int64
+	CreatedAt   time.Time
+}
+
+func (AuditRecord) _Table() string { return "audit_records" }
+
+type AuditRecord_Update_Fields struct {
+}
+
+type AuditRecord_Id_Field struct {
+	_set   bool
+	_value int64
+}
+
+func AuditRecord_Id(v int64) AuditRecord_Id_Field {
+	return AuditRecord_Id_Field{_set: true, _value: v}
+}
+
+func (f AuditRecord_Id_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditRecord_Id_Field) _Column() string { return "id" }
+
+type AuditRecord_NodeId_Field struct {
+	_set   bool
+	_value string
+}
+
+func AuditRecord_NodeId(v string) AuditRecord_NodeId_Field {
+	return AuditRecord_NodeId_Field{_set: true, _value: v}
+}
+
+func (f AuditRecord_NodeId_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditRecord_NodeId_Field) _Column() string { return "node_id" }
+
+type AuditRecord_Path_Field struct {
+	_set   bool
+	_value string
+}
+
+func AuditRecord_Path(v string) AuditRecord_Path_Field {
+	return AuditRecord_Path_Field{_set: true, _value: v}
+}
+
+func (f AuditRecord_Path_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditRecord_Path_Field) _Column() string { return "path" }
+
+type AuditRecord_StripeIndex_Field struct {
+	_set   bool
+	_value int64
+}
+
+func AuditRecord_StripeIndex(v int64) AuditRecord_StripeIndex_Field {
+	return AuditRecord_StripeIndex_Field{_set: true, _value: v}
+}
+
+func (f AuditRecord_StripeIndex_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditRecord_StripeIndex_Field) _Column() string { return "stripe_index" }
+
+type AuditRecord_Outcome_Field struct {
+	_set   bool
+	_value string
+}
+
+func AuditRecord_Outcome(v string) AuditRecord_Outcome_Field {
+	return AuditRecord_Outcome_Field{_set: true, _value: v}
+}
+
+func (f AuditRecord_Outcome_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditRecord_Outcome_Field) _Column() string { return "outcome" }
+
+type AuditRecord_ErrorClass_Field struct {
+	_set   bool
+	_value string
+}
+
+func AuditRecord_ErrorClass(v string) AuditRecord_ErrorClass_Field {
+	return AuditRecord_ErrorClass_Field{_set: true, _value: v}
+}
+
+func (f AuditRecord_ErrorClass_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditRecord_ErrorClass_Field) _Column() string { return "error_class" }
+
+type AuditRecord_Latency_Field struct {
+	_set   bool
+	_value int64
+}
+
+func AuditRecord_Latency(v int64) AuditRecord_Latency_Field {
+	return AuditRecord_Latency_Field{_set: true, _value: v}
+}
+
+func (f AuditRecord_Latency_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditRecord_Latency_Field) _Column() string { return "latency" }
+
+type AuditRecord_CreatedAt_Field struct {
+	_set   bool
+	_value time.Time
+}
+
+func AuditRecord_CreatedAt(v time.Time) AuditRecord_CreatedAt_Field {
+	return AuditRecord_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f AuditRecord_CreatedAt_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditRecord_CreatedAt_Field) _Column() string { return "created_at" }
+
+func toUTC(t time.Time) time.Time {
+	return t.UTC()
+}
+
+func toDate(t time.Time) time.Time {
+	// keep up the minute portion so that translations between timezones will
+	// continue to reflect properly.
+	return t.Truncate(time.Minute)
+}
+
+//
+// runtime support for building sql statements
+//
+
+type __sqlbundle_SQL interface {
+	Render() string
+
+	private()
+}
+
+type __sqlbundle_Dialect interface {
+	Rebind(sql string) string
+}
+
+type __sqlbundle_RenderOp int
+
+const (
+	__sqlbundle_NoFlatten __sqlbundle_RenderOp = iota
+	__sqlbundle_NoTerminate
+)
+
+func __sqlbundle_Render(dialect __sqlbundle_Dialect, sql __sqlbundle_SQL, ops ...__sqlbundle_RenderOp) string {
+	out := sql.Render()
+
+	flatten := true
+	terminate := true
+	for _, op := range ops {
+		switch op {
+		case __sqlbundle_NoFlatten:
+			flatten = false
+		case __sqlbundle_NoTerminate:
+			terminate = false
+		}
+	}
+
+	if flatten {
+		out = __sqlbundle_flattenSQL(out)
+	}
+	if terminate {
+		out += ";"
+	}
+
+	return dialect.Rebind(out)
+}
+
+var __sqlbundle_reSpace = regexp.MustCompile(`\s+`)
+
+func __sqlbundle_flattenSQL(s string) string {
+	return strings.TrimSpace(__sqlbundle_reSpace.ReplaceAllString(s, " "))
+}
+
+// this type is specially named to match up with the name returned by the
+// dialect impl in the sql package.
+type __sqlbundle_postgres struct{}
+
+func (p __sqlbundle_postgres) Rebind(sql string) string {
+	out := make([]byte, 0, len(sql)+10)
+
+	j := 1
+	for i := 0; i < len(sql); i++ {
+		ch := sql[i]
+		if ch != '?' {
+			out = append(out, ch)
+			continue
+		}
+
+		out = append(out, '$')
+		out = append(out, strconv.Itoa(j)...)
+		j++
+	}
+
+	return string(out)
+}
+
+// this type is specially named to match up with the name returned by the
+// dialect impl in the sql package.
+type __sqlbundle_sqlite3 struct{}
+
+func (s __sqlbundle_sqlite3) Rebind(sql string) string {
+	return sql
+}
+
+type __sqlbundle_Literal string
+
+func (__sqlbundle_Literal) private() {}
+
+func (l __sqlbundle_Literal) Render() string { return string(l) }
+
+type __sqlbundle_Literals struct {
+	Join string
+	SQLs []__sqlbundle_SQL
+}
+
+func (__sqlbundle_Literals) private() {}
+
+func (l __sqlbundle_Literals) Render() string {
+	var out bytes.Buffer
+
+	first := true
+	for _, sql := range l.SQLs {
+		if sql == nil {
+			continue
+		}
+		if !first {
+			out.WriteString(l.Join)
+		}
+		first = false
+		out.WriteString(sql.Render())
+	}
+
+	return out.String()
+}
+
+type __sqlbundle_Condition struct {
+	// set at compile/embed time
+	Name  string
+	Left  string
+	Equal bool
+	Right string
+
+	// set at runtime
+	Null bool
+}
+
+func (*__sqlbundle_Condition) private() {}
+
+func (c *__sqlbundle_Condition) Render() string {
+
+	switch {
+	case c.Equal && c.Null:
+		return c.Left + " is null"
+	case c.Equal && !c.Null:
+		return c.Left + " = " + c.Right
+	case !c.Equal && c.Null:
+		return c.Left + " is not null"
+	case !c.Equal && !c.Null:
+		return c.Left + " != " + c.Right
+	default:
+		panic("unhandled case")
+	}
+}
+
+type __sqlbundle_Hole struct {
+	// set at compiile/embed time
+	Name string
+
+	// set at runtime
+	SQL __sqlbundle_SQL
+}
+
+func (*__sqlbundle_Hole) private() {}
+
+func (h *__sqlbundle_Hole) Render() string { return h.SQL.Render() }
+
+//
+// end runtime support for building sql statements
+//
+
+func (obj *postgresImpl) Create_AuditRecord(ctx context.Context,
+	audit_record_node_id AuditRecord_NodeId_Field,
+	audit_record_path AuditRecord_Path_Field,
+	audit_record_stripe_index AuditRecord_StripeIndex_Field,
+	audit_record_outcome AuditRecord_Outcome_Field,
+	audit_record_error_class AuditRecord_ErrorClass_Field,
+	audit_record_latency AuditRecord_Latency_Field) (
+	audit_record *AuditRecord, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__node_id_val := audit_record_node_id.value()
+	__path_val := audit_record_path.value()
+	__stripe_index_val := audit_record_stripe_index.value()
+	__outcome_val := audit_record_outcome.value()
+	__error_class_val := audit_record_error_class.value()
+	__latency_val := audit_record_latency.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO audit_records ( node_id, path, stripe_index, outcome, error_class, latency, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ? ) RETURNING audit_records.id, audit_records.node_id, audit_records.path, audit_records.stripe_index, audit_records.outcome, audit_records.error_class, audit_records.latency, audit_records.created_at")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __node_id_val, __path_val, __stripe_index_val, __outcome_val, __error_class_val, __latency_val, __created_at_val)
+
+	audit_record = &AuditRecord{}
+	err = obj.driver.QueryRow(__stmt, __node_id_val, __path_val, __stripe_index_val, __outcome_val, __error_class_val, __latency_val, __created_at_val).Scan(&audit_record.Id, &audit_record.NodeId, &audit_record.Path, &audit_record.StripeIndex, &audit_record.Outcome, &audit_record.ErrorClass, &audit_record.Latency, &audit_record.CreatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return audit_record, nil
+
+}
+
+func (obj *postgresImpl) Limited_AuditRecord_By_NodeId_And_CreatedAt_GreaterOrEqual_OrderBy_Desc_CreatedAt(ctx context.Context,
+	audit_record_node_id AuditRecord_NodeId_Field,
+	audit_record_created_at_greater_or_equal AuditRecord_CreatedAt_Field,
+	limit int, offset int64) (
+	rows []*AuditRecord, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT audit_records.id, audit_records.node_id, audit_records.path, audit_records.stripe_index, audit_records.outcome, audit_records.error_class, audit_records.latency, audit_records.created_at FROM audit_records WHERE audit_records.node_id = ? AND audit_records.created_at >= ? ORDER BY audit_records.created_at DESC LIMIT ? OFFSET ?")
+
+	var __values []interface{}
+	__values = append(__values, audit_record_node_id.value(), audit_record_created_at_greater_or_equal.value())
+
+	__values = append(__values, limit, offset)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		audit_record := &AuditRecord{}
+		err = __rows.Scan(&audit_record.Id, &audit_record.NodeId, &audit_record.Path, &audit_record.StripeIndex, &audit_record.Outcome, &audit_record.ErrorClass, &audit_record.Latency, &audit_record.CreatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, audit_record)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *postgresImpl) Limited_AuditRecord_By_CreatedAt_GreaterOrEqual_OrderBy_Desc_CreatedAt(ctx context.Context,
+	audit_record_created_at_greater_or_equal AuditRecord_CreatedAt_Field,
+	limit int, offset int64) (
+	rows []*AuditRecord, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT audit_records.id, audit_records.node_id, audit_records.path, audit_records.stripe_index, audit_records.outcome, audit_records.error_class, audit_records.latency, audit_records.created_at FROM audit_records WHERE audit_records.created_at >= ? ORDER BY audit_records.created_at DESC LIMIT ? OFFSET ?")
+
+	var __values []interface{}
+	__values = append(__values, audit_record_created_at_greater_or_equal.value())
+
+	__values = append(__values, limit, offset)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		audit_record := &AuditRecord{}
+		err = __rows.Scan(&audit_record.Id, &audit_record.NodeId, &audit_record.Path, &audit_record.StripeIndex, &audit_record.Outcome, &audit_record.ErrorClass, &audit_record.Latency, &audit_record.CreatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, audit_record)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *postgresImpl) Delete_AuditRecord_By_CreatedAt_Less(ctx context.Context,
+	audit_record_created_at_less AuditRecord_CreatedAt_Field) (
+	count int64, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM audit_records WHERE audit_records.created_at < ?")
+
+	var __values []interface{}
+	__values = append(__values, audit_record_created_at_less.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.Exec(__stmt, __values...)
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	return count, nil
+
+}
+
+func (impl postgresImpl) isConstraintError(err error) (
+	constraint string, ok bool) {
+	if e, ok := err.(*pq.Error); ok {
+		if e.Code.Class() == "23" {
+			return e.Constraint, true
+		}
+	}
+	return "", false
+}
+
+func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error) {
+	var __res sql.Result
+	var __count int64
+	__res, err = obj.driver.Exec("DELETE FROM audit_records;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+
+	return count, nil
+
+}
+
+func (obj *sqlite3Impl) Create_AuditRecord(ctx context.Context,
+	audit_record_node_id AuditRecord_NodeId_Field,
+	audit_record_path AuditRecord_Path_Field,
+	audit_record_stripe_index AuditRecord_StripeIndex_Field,
+	audit_record_outcome AuditRecord_Outcome_Field,
+	audit_record_error_class AuditRecord_ErrorClass_Field,
+	audit_record_latency AuditRecord_Latency_Field) (
+	audit_record *AuditRecord, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__node_id_val := audit_record_node_id.value()
+	__path_val := audit_record_path.value()
+	__stripe_index_val := audit_record_stripe_index.value()
+	__outcome_val := audit_record_outcome.value()
+	__error_class_val := audit_record_error_class.value()
+	__latency_val := audit_record_latency.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO audit_records ( node_id, path, stripe_index, outcome, error_class, latency, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ? )")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __node_id_val, __path_val, __stripe_index_val, __outcome_val, __error_class_val, __latency_val, __created_at_val)
+
+	__res, err := obj.driver.Exec(__stmt, __node_id_val, __path_val, __stripe_index_val, __outcome_val, __error_class_val, __latency_val, __created_at_val)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	__pk, err := __res.LastInsertId()
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return obj.getLastAuditRecord(ctx, __pk)
+
+}
+
+func (obj *sqlite3Impl) Limited_AuditRecord_By_NodeId_And_CreatedAt_GreaterOrEqual_OrderBy_Desc_CreatedAt(ctx context.Context,
+	audit_record_node_id AuditRecord_NodeId_Field,
+	audit_record_created_at_greater_or_equal AuditRecord_CreatedAt_Field,
+	limit int, offset int64) (
+	rows []*AuditRecord, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT audit_records.id, audit_records.node_id, audit_records.path, audit_records.stripe_index, audit_records.outcome, audit_records.error_class, audit_records.latency, audit_records.created_at FROM audit_records WHERE audit_records.node_id = ? AND audit_records.created_at >= ? ORDER BY audit_records.created_at DESC LIMIT ? OFFSET ?")
+
+	var __values []interface{}
+	__values = append(__values, audit_record_node_id.value(), audit_record_created_at_greater_or_equal.value())
+
+	__values = append(__values, limit, offset)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		audit_record := &AuditRecord{}
+		err = __rows.Scan(&audit_record.Id, &audit_record.NodeId, &audit_record.Path, &audit_record.StripeIndex, &audit_record.Outcome, &audit_record.ErrorClass, &audit_record.Latency, &audit_record.CreatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, audit_record)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *sqlite3Impl) Limited_AuditRecord_By_CreatedAt_GreaterOrEqual_OrderBy_Desc_CreatedAt(ctx context.Context,
+	audit_record_created_at_greater_or_equal AuditRecord_CreatedAt_Field,
+	limit int, offset int64) (
+	rows []*AuditRecord, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT audit_records.id, audit_records.node_id, audit_records.path, audit_records.stripe_index, audit_records.outcome, audit_records.error_class, audit_records.latency, audit_records.created_at FROM audit_records WHERE audit_records.created_at >= ? ORDER BY audit_records.created_at DESC LIMIT ? OFFSET ?")
+
+	var __values []interface{}
+	__values = append(__values, audit_record_created_at_greater_or_equal.value())
+
+	__values = append(__values, limit, offset)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		audit_record := &AuditRecord{}
+		err = __rows.Scan(&audit_record.Id, &audit_record.NodeId, &audit_record.Path, &audit_record.StripeIndex, &audit_record.Outcome, &audit_record.ErrorClass, &audit_record.Latency, &audit_record.CreatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, audit_record)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *sqlite3Impl) Delete_AuditRecord_By_CreatedAt_Less(ctx context.Context,
+	audit_record_created_at_less AuditRecord_CreatedAt_Field) (
+	count int64, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM audit_records WHERE audit_records.created_at < ?")
+
+	var __values []interface{}
+	__values = append(__values, audit_record_created_at_less.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.Exec(__stmt, __values...)
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	return count, nil
+
+}
+
+func (obj *sqlite3Impl) getLastAuditRecord(ctx context.Context,
+	pk int64) (
+	audit_record *AuditRecord, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT audit_records.id, audit_records.node_id, audit_records.path, audit_records.stripe_index, audit_records.outcome, audit_records.error_class, audit_records.latency, audit_records.created_at FROM audit_records WHERE _rowid_ = ?")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, pk)
+
+	audit_record = &AuditRecord{}
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&audit_record.Id, &audit_record.NodeId, &audit_record.Path, &audit_record.StripeIndex, &audit_record.Outcome, &audit_record.ErrorClass, &audit_record.Latency, &audit_record.CreatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return audit_record, nil
+
+}
+
+func (impl sqlite3Impl) isConstraintError(err error) (
+	constraint string, ok bool) {
+	if e, ok := err.(sqlite3.Error); ok {
+		if e.Code == sqlite3.ErrConstraint {
+			msg := err.Error()
+			colon := strings.LastIndex(msg, ":")
+			if colon != -1 {
+				return strings.TrimSpace(msg[colon:]), true
+			}
+			return "", true
+		}
+	}
+	return "", false
+}
+
+func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error) {
+	var __res sql.Result
+	var __count int64
+	__res, err = obj.driver.Exec("DELETE FROM audit_records;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+
+	return count, nil
+
+}
+
+type Rx struct {
+	db *DB
+	tx *Tx
+}
+
+func (rx *Rx) UnsafeTx(ctx context.Context) (unsafe_tx *sql.Tx, err error) {
+	tx, err := rx.getTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tx.Tx, nil
+}
+
+func (rx *Rx) getTx(ctx context.Context) (tx *Tx, err error) {
+	if rx.tx == nil {
+		if rx.tx, err = rx.db.Open(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return rx.tx, nil
+}
+
+func (rx *Rx) Rebind(s string) string {
+	return rx.db.Rebind(s)
+}
+
+func (rx *Rx) Commit() (err error) {
+	if rx.tx != nil {
+		err = rx.tx.Commit()
+		rx.tx = nil
+	}
+	return err
+}
+
+func (rx *Rx) Rollback() (err error) {
+	if rx.tx != nil {
+		err = rx.tx.Rollback()
+		rx.tx = nil
+	}
+	return err
+}
+
+func (rx *Rx) Create_AuditRecord(ctx context.Context,
+	audit_record_node_id AuditRecord_NodeId_Field,
+	audit_record_path AuditRecord_Path_Field,
+	audit_record_stripe_index AuditRecord_StripeIndex_Field,
+	audit_record_outcome AuditRecord_Outcome_Field,
+	audit_record_error_class AuditRecord_ErrorClass_Field,
+	audit_record_latency AuditRecord_Latency_Field) (
+	audit_record *AuditRecord, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Create_AuditRecord(ctx, audit_record_node_id, audit_record_path, audit_record_stripe_index, audit_record_outcome, audit_record_error_class, audit_record_latency)
+
+}
+
+func (rx *Rx) Delete_AuditRecord_By_CreatedAt_Less(ctx context.Context,
+	audit_record_created_at_less AuditRecord_CreatedAt_Field) (
+	count int64, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Delete_AuditRecord_By_CreatedAt_Less(ctx, audit_record_created_at_less)
+
+}
+
+func (rx *Rx) Limited_AuditRecord_By_CreatedAt_GreaterOrEqual_OrderBy_Desc_CreatedAt(ctx context.Context,
+	audit_record_created_at_greater_or_equal AuditRecord_CreatedAt_Field,
+	limit int, offset int64) (
+	rows []*AuditRecord, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Limited_AuditRecord_By_CreatedAt_GreaterOrEqual_OrderBy_Desc_CreatedAt(ctx, audit_record_created_at_greater_or_equal, limit, offset)
+}
+
+func (rx *Rx) Limited_AuditRecord_By_NodeId_And_CreatedAt_GreaterOrEqual_OrderBy_Desc_CreatedAt(ctx context.Context,
+	audit_record_node_id AuditRecord_NodeId_Field,
+	audit_record_created_at_greater_or_equal AuditRecord_CreatedAt_Field,
+	limit int, offset int64) (
+	rows []*AuditRecord, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Limited_AuditRecord_By_NodeId_And_CreatedAt_GreaterOrEqual_OrderBy_Desc_CreatedAt(ctx, audit_record_node_id, audit_record_created_at_greater_or_equal, limit, offset)
+}
+
+type Methods interface {
+	Create_AuditRecord(ctx context.Context,
+		audit_record_node_id AuditRecord_NodeId_Field,
+		audit_record_path AuditRecord_Path_Field,
+		audit_record_stripe_index AuditRecord_StripeIndex_Field,
+		audit_record_outcome AuditRecord_Outcome_Field,
+		audit_record_error_class AuditRecord_ErrorClass_Field,
+		audit_record_latency AuditRecord_Latency_Field) (
+		audit_record *AuditRecord, err error)
+
+	Delete_AuditRecord_By_CreatedAt_Less(ctx context.Context,
+		audit_record_created_at_less AuditRecord_CreatedAt_Field) (
+		count int64, err error)
+
+	Limited_AuditRecord_By_CreatedAt_GreaterOrEqual_OrderBy_Desc_CreatedAt(ctx context.Context,
+		audit_record_created_at_greater_or_equal AuditRecord_CreatedAt_Field,
+		limit int, offset int64) (
+		rows []*AuditRecord, err error)
+
+	Limited_AuditRecord_By_NodeId_And_CreatedAt_GreaterOrEqual_OrderBy_Desc_CreatedAt(ctx context.Context,
+		audit_record_node_id AuditRecord_NodeId_Field,
+		audit_record_created_at_greater_or_equal AuditRecord_CreatedAt_Field,
+		limit int, offset int64) (
+		rows []*AuditRecord, err error)
+}
+
+type TxMethods interface {
+	Methods
+
+	Rebind(s string) string
+	Commit() error
+	Rollback() error
+}
+
+type txMethods interface {
+	TxMethods
+
+	deleteAll(ctx context.Context) (int64, error)
+	makeErr(err error) error
+}
+
+type DBMethods interface {
+	Methods
+
+	Schema() string
+	Rebind(sql string) string
+}
+
+type dbMethods interface {
+	DBMethods
+
+	wrapTx(tx *sql.Tx) txMethods
+	makeErr(err error) error
+}
+
+func openpostgres(source string) (*sql.DB, error) {
+	return sql.Open("postgres", source)
+}
+
+var sqlite3DriverName = "sqlite3_" + fmt.Sprint(time.Now().UnixNano())
+
+func init() {
+	sql.Register(sqlite3DriverName, &sqlite3.SQLiteDriver{
+		ConnectHook: sqlite3SetupConn,
+	})
+}
+
+// SQLite3JournalMode controls the journal_mode pragma for all new connections.
+// Since it is read without a mutex, it must be changed to the value you want
+// before any Open calls.
+var SQLite3JournalMode = "WAL"
+
+func sqlite3SetupConn(conn *sqlite3.SQLiteConn) (err error) {
+	_, err = conn.Exec("PRAGMA foreign_keys = ON", nil)
+	if err != nil {
+		return makeErr(err)
+	}
+	_, err = conn.Exec("PRAGMA journal_mode = "+SQLite3JournalMode, nil)
+	if err != nil {
+		return makeErr(err)
+	}
+	return nil
+}
+
+func opensqlite3(source string) (*sql.DB, error) {
+	return sql.Open(sqlite3DriverName, source)
+}
@@ -0,0 +1,15 @@
+-- AUTOGENERATED BY gopkg.in/spacemonkeygo/dbx.v1
+-- DO NOT EDIT
+CREATE TABLE audit_records (
+	id bigserial NOT NULL,
+	node_id text NOT NULL,
+	path text NOT NULL,
+	stripe_index bigint NOT NULL,
+	outcome text NOT NULL,
+	error_class text NOT NULL,
+	latency bigint NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
+CREATE INDEX audit_records_node_id_created_at_index ON audit_records ( node_id, created_at );
+CREATE INDEX audit_records_created_at_index ON audit_records ( created_at );
@@ -0,0 +1,15 @@
+-- AUTOGENERATED BY gopkg.in/spacemonkeygo/dbx.v1
+-- DO NOT EDIT
+CREATE TABLE audit_records (
+	id INTEGER NOT NULL,
+	node_id TEXT NOT NULL,
+	path TEXT NOT NULL,
+	stripe_index INTEGER NOT NULL,
+	outcome TEXT NOT NULL,
+	error_class TEXT NOT NULL,
+	latency INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( id )
+);
+CREATE INDEX audit_records_node_id_created_at_index ON audit_records ( node_id, created_at );
+CREATE INDEX audit_records_created_at_index ON audit_records ( created_at );
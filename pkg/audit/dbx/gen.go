@@ -0,0 +1,7 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+// go:generate dbx.v1 schema -d postgres -d sqlite3 audit.dbx .
+// go:generate dbx.v1 golang -d postgres -d sqlite3 audit.dbx .
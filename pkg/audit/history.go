@@ -0,0 +1,145 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/zeebo/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/migrate"
+	dbx "storj.io/storj/pkg/audit/dbx"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/utils"
+)
+
+// outcomes of the audit of a node
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailed  = "failed"
+	OutcomeOffline = "offline"
+)
+
+// Record is the outcome of the audit of a stripe on a node
+type Record struct {
+	NodeID      string
+	Path        storj.Path
+	StripeIndex int64
+	Outcome     string
+	// ErrorClass is the class of the error of a failed or offline node
+	ErrorClass string
+	Latency    time.Duration
+	Created    time.Time
+}
+
+// History keeps the records of the past audits
+type History struct {
+	db *dbx.DB
+}
+
+// NewHistory creates the history of the audits stored in db
+func NewHistory(db *dbx.DB) *History {
+	return &History{db: db}
+}
+
+// NewHistoryDB opens the database of the audit history and creates its tables
+func NewHistoryDB(driver, source string) (*dbx.DB, error) {
+	db, err := dbx.Open(driver, source)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	err = migrate.Create("audit", db)
+	if err != nil {
+		return nil, utils.CombineErrors(err, db.Close())
+	}
+	return db, nil
+}
+
+// OpenHistoryDB opens the database of the audit history from its connection string
+func OpenHistoryDB(dbURL string) (*dbx.DB, error) {
+	u, err := url.Parse(dbURL)
+	if err != nil {
+		return nil, Error.New("invalid database url %q: %v", dbURL, err)
+	}
+	return NewHistoryDB(u.Scheme, u.Path)
+}
+
+// Record saves the records of audits
+func (history *History) Record(ctx context.Context, records []*Record) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for _, record := range records {
+		_, err := history.db.Create_AuditRecord(ctx,
+			dbx.AuditRecord_NodeId(record.NodeID),
+			dbx.AuditRecord_Path(record.Path),
+			dbx.AuditRecord_StripeIndex(record.StripeIndex),
+			dbx.AuditRecord_Outcome(record.Outcome),
+			dbx.AuditRecord_ErrorClass(record.ErrorClass),
+			dbx.AuditRecord_Latency(int64(record.Latency)),
+		)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// Query returns at most limit records of the audits since the given time, newest first.
+// The records of all nodes are returned if nodeID is empty.
+func (history *History) Query(ctx context.Context, nodeID string, since time.Time, limit int) (records []*Record, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var rows []*dbx.AuditRecord
+	if nodeID == "" {
+		rows, err = history.db.Limited_AuditRecord_By_CreatedAt_GreaterOrEqual_OrderBy_Desc_CreatedAt(ctx,
+			dbx.AuditRecord_CreatedAt(since.UTC()), limit, 0)
+	} else {
+		rows, err = history.db.Limited_AuditRecord_By_NodeId_And_CreatedAt_GreaterOrEqual_OrderBy_Desc_CreatedAt(ctx,
+			dbx.AuditRecord_NodeId(nodeID), dbx.AuditRecord_CreatedAt(since.UTC()), limit, 0)
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	for _, row := range rows {
+		records = append(records, &Record{
+			NodeID:      row.NodeId,
+			Path:        row.Path,
+			StripeIndex: row.StripeIndex,
+			Outcome:     row.Outcome,
+			ErrorClass:  row.ErrorClass,
+			Latency:     time.Duration(row.Latency),
+			Created:     row.CreatedAt,
+		})
+	}
+	return records, nil
+}
+
+// Prune deletes the records of the audits before the given time and returns how many were deleted
+func (history *History) Prune(ctx context.Context, before time.Time) (count int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	count, err = history.db.Delete_AuditRecord_By_CreatedAt_Less(ctx, dbx.AuditRecord_CreatedAt(before.UTC()))
+	return count, Error.Wrap(err)
+}
+
+// errorClass returns the kind of the error for the audit history: the code of a grpc error,
+// otherwise the innermost class of the error
+func errorClass(err error) string {
+	if err == nil {
+		return ""
+	}
+	if s, ok := status.FromError(errs.Unwrap(err)); ok && s.Code() != codes.Unknown {
+		return s.Code().String()
+	}
+	if classes := errs.Classes(err); len(classes) > 0 {
+		return string(*classes[0])
+	}
+	return "unknown"
+}
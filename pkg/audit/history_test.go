@@ -0,0 +1,113 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/pb"
+)
+
+func newTestHistory(t *testing.T) *History {
+	db, err := NewHistoryDB("sqlite3", fmt.Sprintf("file:memdb%d?mode=memory&cache=shared", rand.Int63()))
+	require.NoError(t, err)
+	return NewHistory(db)
+}
+
+func TestHistory(t *testing.T) {
+	ctx := context.Background()
+	history := newTestHistory(t)
+	defer func() { assert.NoError(t, history.db.Close()) }()
+
+	err := history.Record(ctx, []*Record{
+		{NodeID: "a", Path: "l/bucket/object", StripeIndex: 1, Outcome: OutcomeSuccess, Latency: time.Millisecond},
+		{NodeID: "b", Path: "l/bucket/object", StripeIndex: 1, Outcome: OutcomeFailed, ErrorClass: "invalid proof", Latency: 2 * time.Millisecond},
+	})
+	require.NoError(t, err)
+	require.NoError(t, history.Record(ctx, []*Record{
+		{NodeID: "a", Path: "l/bucket/other", StripeIndex: 3, Outcome: OutcomeOffline, ErrorClass: "Unavailable"},
+	}))
+
+	since := time.Now().Add(-time.Hour)
+	records, err := history.Query(ctx, "a", since, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	// the newest record comes first
+	assert.Equal(t, OutcomeOffline, records[0].Outcome)
+	assert.Equal(t, "Unavailable", records[0].ErrorClass)
+	assert.Equal(t, "l/bucket/other", records[0].Path)
+	assert.Equal(t, int64(3), records[0].StripeIndex)
+	assert.Equal(t, OutcomeSuccess, records[1].Outcome)
+	assert.Equal(t, time.Millisecond, records[1].Latency)
+	assert.WithinDuration(t, time.Now(), records[1].Created, time.Minute)
+
+	records, err = history.Query(ctx, "", since, 10)
+	require.NoError(t, err)
+	assert.Len(t, records, 3)
+
+	records, err = history.Query(ctx, "", since, 1)
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+
+	records, err = history.Query(ctx, "", time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	// records before the retention are pruned
+	count, err := history.Prune(ctx, since)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	count, err = history.Prune(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	records, err = history.Query(ctx, "", since, 10)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestHistoryServer(t *testing.T) {
+	ctx := context.Background()
+	history := newTestHistory(t)
+	defer func() { assert.NoError(t, history.db.Close()) }()
+	require.NoError(t, history.Record(ctx, []*Record{
+		{NodeID: "a", Path: "l/bucket/object", StripeIndex: 1, Outcome: OutcomeFailed, ErrorClass: "altered share", Latency: time.Second},
+		{NodeID: "b", Path: "l/bucket/object", StripeIndex: 1, Outcome: OutcomeSuccess},
+	}))
+
+	server := NewHistoryServer(history, zap.NewNop())
+
+	_, err := server.Query(auth.WithAPIKey(ctx, []byte("wrong key")), &pb.AuditHistoryRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	resp, err := server.Query(auth.WithAPIKey(ctx, nil), &pb.AuditHistoryRequest{NodeId: "a"})
+	require.NoError(t, err)
+	require.Len(t, resp.Records, 1)
+	record := resp.Records[0]
+	assert.Equal(t, "a", record.NodeId)
+	assert.Equal(t, "l/bucket/object", record.Path)
+	assert.Equal(t, OutcomeFailed, record.Outcome)
+	assert.Equal(t, "altered share", record.ErrorClass)
+	assert.Equal(t, int64(time.Second), record.Latency)
+	assert.NotZero(t, record.CreatedUnixSec)
+}
+
+func TestErrorClass(t *testing.T) {
+	assert.Equal(t, "", errorClass(nil))
+	assert.Equal(t, "invalid proof", errorClass(ErrInvalidProof.New("piece %d", 1)))
+	assert.Equal(t, "altered share", errorClass(Error.Wrap(ErrAlteredShare.New("piece %d", 1))))
+	assert.Equal(t, "Unavailable", errorClass(Error.Wrap(status.Error(codes.Unavailable, "connection refused"))))
+	assert.Equal(t, "unknown", errorClass(status.Error(codes.Unknown, "remote error")))
+}
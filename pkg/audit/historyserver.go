@@ -0,0 +1,73 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/pb"
+	pointerdbAuth "storj.io/storj/pkg/pointerdb/auth"
+)
+
+// defaultQueryLimit is the number of records returned when a query doesn't set a limit
+const defaultQueryLimit = 100
+
+// HistoryServer implements the AuditHistory RPC service
+type HistoryServer struct {
+	history *History
+	logger  *zap.Logger
+}
+
+// NewHistoryServer creates the server of the audit history
+func NewHistoryServer(history *History, logger *zap.Logger) *HistoryServer {
+	return &HistoryServer{history: history, logger: logger}
+}
+
+func (s *HistoryServer) validateAuth(ctx context.Context) error {
+	APIKey, ok := auth.GetAPIKey(ctx)
+	if !ok || !pointerdbAuth.ValidateAPIKey(string(APIKey)) {
+		s.logger.Error("unauthorized request: ", zap.Error(status.Errorf(codes.Unauthenticated, "Invalid API credential")))
+		return status.Errorf(codes.Unauthenticated, "Invalid API credential")
+	}
+	return nil
+}
+
+// Query returns the records of the audits of a node or of all nodes since the requested time
+func (s *HistoryServer) Query(ctx context.Context, req *pb.AuditHistoryRequest) (resp *pb.AuditHistoryResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := s.validateAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultQueryLimit
+	}
+
+	records, err := s.history.Query(ctx, req.GetNodeId(), time.Unix(req.GetSinceUnixSec(), 0), limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	resp = &pb.AuditHistoryResponse{}
+	for _, record := range records {
+		resp.Records = append(resp.Records, &pb.AuditRecord{
+			NodeId:         record.NodeID,
+			Path:           record.Path,
+			StripeIndex:    record.StripeIndex,
+			Outcome:        record.Outcome,
+			ErrorClass:     record.ErrorClass,
+			Latency:        int64(record.Latency),
+			CreatedUnixSec: record.Created.Unix(),
+		})
+	}
+	return resp, nil
+}
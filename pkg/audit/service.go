@@ -9,7 +9,10 @@ import (
 
 	"go.uber.org/zap"
 
+	"storj.io/storj/internal/kvstore"
 	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb"
 	"storj.io/storj/pkg/pointerdb/pdbclient"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/transport"
@@ -17,10 +20,16 @@ import (
 
 // Service helps coordinate Cursor and Verifier to run the audit process continuously
 type Service struct {
-	Cursor   *Cursor
-	Verifier *Verifier
-	Reporter reporter
-	ticker   *time.Ticker
+	Cursor      *Cursor
+	Verifier    *Verifier
+	Reporter    reporter
+	Containment *Containment
+	History     *History
+	ticker      *time.Ticker
+
+	maxReverifyCount int
+	historyRetention time.Duration
+	lastPrune        time.Time
 }
 
 // Config contains configurable values for audit service
@@ -29,11 +38,24 @@ type Config struct {
 	SatelliteAddr    string        `help:"address to contact services on the satellite"`
 	MaxRetriesStatDB int           `help:"max number of times to attempt updating a statdb batch" default:"3"`
 	Interval         time.Duration `help:"how frequently segments are audited" default:"30s"`
+
+	ContainmentDatabaseURL string `help:"the database connection string of the pending audits of contained nodes" default:"bolt://$CONFDIR/containment.db"`
+	MaxReverifyCount       int    `help:"how many times a contained node may not answer its pending audit before it fails the audit" default:"3"`
+
+	HistoryDatabaseURL string        `help:"the database connection string of the audit history" default:"sqlite3://$CONFDIR/audit.db"`
+	HistoryRetention   time.Duration `help:"how long the records of the audit history are kept" default:"720h"`
+
+	Schedule ScheduleConfig
 }
 
 // Run runs the repairer with the configured values
 func (c Config) Run(ctx context.Context, server *provider.Provider) (err error) {
 	identity := server.Identity()
+	// the segments of the nodes are sampled by walking pointerdb
+	walker := pointerdb.LoadFromContext(ctx)
+	if walker == nil {
+		return Error.New("pointerdb not found in context")
+	}
 	pointers, err := pdbclient.NewClient(identity, c.SatelliteAddr, c.APIKey)
 	if err != nil {
 		return err
@@ -43,10 +65,27 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 		return err
 	}
 	transport := transport.NewClient(identity)
-	service, err := NewService(ctx, c.SatelliteAddr, c.Interval, c.MaxRetriesStatDB, pointers, transport, overlay, *identity, c.APIKey)
+	db, err := kvstore.Open(c.ContainmentDatabaseURL, containmentBucket)
 	if err != nil {
 		return err
 	}
+	defer func() { _ = db.Close() }()
+	historyDB, err := OpenHistoryDB(c.HistoryDatabaseURL)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = historyDB.Close() }()
+	history := NewHistory(historyDB)
+	pb.RegisterAuditHistoryServer(server.GRPC(), NewHistoryServer(history, zap.L()))
+
+	service, err := NewService(ctx, c.SatelliteAddr, c.Interval, c.MaxRetriesStatDB, pointers, walker, c.Schedule, transport, overlay, walker, c.APIKey)
+	if err != nil {
+		return err
+	}
+	service.Containment = NewContainment(db)
+	service.maxReverifyCount = c.MaxReverifyCount
+	service.History = history
+	service.historyRetention = c.HistoryRetention
 	go func() {
 		err := service.Run(ctx)
 		zap.S().Error("audit service failed to run:", zap.Error(err))
@@ -55,14 +94,16 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 }
 
 // NewService instantiates a Service with access to a Cursor and Verifier
-func NewService(ctx context.Context, statDBPort string, interval time.Duration, maxRetries int, pointers pdbclient.Client, transport transport.Client, overlay overlay.Client,
-	identity provider.FullIdentity, apiKey string) (service *Service, err error) {
-	cursor := NewCursor(pointers)
-	verifier := NewVerifier(transport, overlay, identity)
+func NewService(ctx context.Context, statDBPort string, interval time.Duration, maxRetries int, pointers pdbclient.Client, walker walker, schedule ScheduleConfig,
+	transport transport.Client, overlay overlay.Client, limits orderLimits, apiKey string) (service *Service, err error) {
 	reporter, err := NewReporter(ctx, statDBPort, maxRetries, apiKey)
 	if err != nil {
 		return nil, err
 	}
+	// nodes are vetted by their audit history in statdb
+	cursor := NewCursor(pointers, walker, reporter.statdb, schedule)
+	// the nodes are paid for audits by order limits of the satellite
+	verifier := NewVerifier(transport, overlay, limits)
 
 	return &Service{
 		Cursor:   cursor,
@@ -91,8 +132,15 @@ func (service *Service) Run(ctx context.Context) (err error) {
 	}
 }
 
-// process picks a random stripe and verifies correctness
+// process re-checks the pending audits, then picks a random stripe and verifies correctness
 func (service *Service) process(ctx context.Context) error {
+	// contained nodes have to answer their pending audits first
+	if service.Containment != nil {
+		if err := service.reverify(ctx); err != nil {
+			return err
+		}
+	}
+
 	stripe, err := service.Cursor.NextStripe(ctx)
 	if err != nil {
 		return err
@@ -102,15 +150,51 @@ func (service *Service) process(ctx context.Context) error {
 	}
 
 	authorization := service.Cursor.pointers.SignedMessage()
-	verifiedNodes, err := service.Verifier.verify(ctx, stripe.Index, stripe.Segment, authorization)
+	result, err := service.Verifier.check(ctx, stripe.Index, stripe.Segment, authorization)
 	if err != nil {
 		return err
 	}
 
-	err = service.Reporter.RecordAudits(ctx, verifiedNodes)
+	// without containment nodes which don't answer are only reported offline
+	if service.Containment != nil {
+		result, err = service.Containment.contain(ctx, stripe, result)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = service.Reporter.RecordAudits(ctx, result.statuses(ctx))
 	if err != nil {
 		return err
 	}
 
+	return service.recordHistory(ctx, result.records(stripe.Path, stripe.Index))
+}
+
+// pruneInterval is how often the records older than the retention are deleted from the history
+const pruneInterval = time.Hour
+
+// recordHistory saves the records of the audits unless the service keeps no history
+func (service *Service) recordHistory(ctx context.Context, records []*Record) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if service.History == nil {
+		return nil
+	}
+
+	if err := service.History.Record(ctx, records); err != nil {
+		return err
+	}
+
+	if service.historyRetention <= 0 || time.Since(service.lastPrune) < pruneInterval {
+		return nil
+	}
+	service.lastPrune = time.Now()
+	count, err := service.History.Prune(ctx, time.Now().Add(-service.historyRetention))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		zap.L().Info("pruned audit history", zap.Int64("records", count))
+	}
 	return nil
 }
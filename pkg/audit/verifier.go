@@ -9,17 +9,19 @@ import (
 	"io"
 	"time"
 
-	"github.com/gogo/protobuf/proto"
 	"github.com/vivint/infectious"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/pkg/dht"
+	"storj.io/storj/pkg/merkle"
 	"storj.io/storj/pkg/node"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/piecestore/psclient"
-	"storj.io/storj/pkg/provider"
 	sdbproto "storj.io/storj/pkg/statdb/proto"
+	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/transport"
 	"storj.io/storj/pkg/utils"
 )
@@ -30,6 +32,8 @@ type share struct {
 	Error       error
 	PieceNumber int
 	Data        []byte
+	// Latency is how long the node took to answer
+	Latency time.Duration
 }
 
 // Verifier helps verify the correctness of a given stripe
@@ -39,24 +43,31 @@ type Verifier struct {
 
 type downloader interface {
 	DownloadShares(ctx context.Context, pointer *pb.Pointer, stripeIndex int, authorization *pb.SignedMessage) (shares []share, nodes []*pb.Node, err error)
+	// ProveShare downloads the share of a single piece with the Merkle proof that it belongs to the piece
+	ProveShare(ctx context.Context, pointer *pb.Pointer, piece *pb.RemotePiece, stripeIndex int, authorization *pb.SignedMessage) (s share, proof [][]byte, err error)
+}
+
+// orderLimits issues the order limits of the satellite for transferring pieces itself
+type orderLimits interface {
+	NewSatelliteOrderLimit(action pb.PayerBandwidthAllocation_Action, nodeID string, derivedPieceID string, maxSize int64) (*pb.PayerBandwidthAllocation, error)
 }
 
 // defaultDownloader downloads shares from networked storage nodes
 type defaultDownloader struct {
 	transport transport.Client
 	overlay   overlay.Client
-	identity  provider.FullIdentity
+	limits    orderLimits
 	reporter
 }
 
-// newDefaultDownloader creates a defaultDownloader
-func newDefaultDownloader(transport transport.Client, overlay overlay.Client, id provider.FullIdentity) *defaultDownloader {
-	return &defaultDownloader{transport: transport, overlay: overlay, identity: id}
+// newDefaultDownloader creates a defaultDownloader paying the nodes with the order limits issued by limits
+func newDefaultDownloader(transport transport.Client, overlay overlay.Client, limits orderLimits) *defaultDownloader {
+	return &defaultDownloader{transport: transport, overlay: overlay, limits: limits}
 }
 
 // NewVerifier creates a Verifier
-func NewVerifier(transport transport.Client, overlay overlay.Client, id provider.FullIdentity) *Verifier {
-	return &Verifier{downloader: newDefaultDownloader(transport, overlay, id)}
+func NewVerifier(transport transport.Client, overlay overlay.Client, limits orderLimits) *Verifier {
+	return &Verifier{downloader: newDefaultDownloader(transport, overlay, limits)}
 }
 
 // getShare use piece store clients to download shares from a given node
@@ -75,20 +86,11 @@ func (d *defaultDownloader) getShare(ctx context.Context, stripeIndex, shareSize
 		return s, err
 	}
 
-	allocationData := &pb.PayerBandwidthAllocation_Data{
-		Action:         pb.PayerBandwidthAllocation_GET,
-		CreatedUnixSec: time.Now().Unix(),
-	}
-
-	serializedAllocation, err := proto.Marshal(allocationData)
+	pba, err := d.limits.NewSatelliteOrderLimit(pb.PayerBandwidthAllocation_GET_AUDIT, nodeID.String(), derivedPieceID.String(), pieceSize)
 	if err != nil {
 		return s, err
 	}
 
-	pba := &pb.PayerBandwidthAllocation{
-		Data: serializedAllocation,
-	}
-
 	rr, err := ps.Get(ctx, derivedPieceID, pieceSize, pba, authorization)
 	if err != nil {
 		return s, err
@@ -116,6 +118,53 @@ func (d *defaultDownloader) getShare(ctx context.Context, stripeIndex, shareSize
 	return s, nil
 }
 
+// ProveShare downloads the share at the stripe index with its Merkle proof from the node of the piece
+func (d *defaultDownloader) ProveShare(ctx context.Context, pointer *pb.Pointer, piece *pb.RemotePiece,
+	stripeIndex int, authorization *pb.SignedMessage) (s share, proof [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodeID := node.IDFromString(piece.GetNodeId())
+	fromNode, err := d.overlay.Lookup(ctx, nodeID)
+	if err != nil {
+		return s, nil, err
+	}
+
+	ps, err := psclient.NewPSClient(ctx, d.transport, fromNode, 0)
+	if err != nil {
+		return s, nil, err
+	}
+	defer utils.LogClose(ps)
+
+	derivedPieceID, err := psclient.PieceID(pointer.GetRemote().GetPieceId()).Derive(nodeID.Bytes())
+	if err != nil {
+		return s, nil, err
+	}
+
+	shareSize := int(pointer.GetRemote().GetRedundancy().GetErasureShareSize())
+	pba, err := d.limits.NewSatelliteOrderLimit(pb.PayerBandwidthAllocation_GET_AUDIT, nodeID.String(), derivedPieceID.String(), int64(shareSize))
+	if err != nil {
+		return s, nil, err
+	}
+	data, proof, err := ps.Prove(ctx, derivedPieceID, shareSize, int64(stripeIndex), pba, authorization)
+	if err != nil {
+		return s, nil, proveError(err)
+	}
+
+	return share{PieceNumber: int(piece.GetPieceNum()), Data: data}, proof, nil
+}
+
+// proveError tells the errors of nodes which answered without a share apart from the errors of unreachable nodes
+func proveError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return ErrMissingPiece.Wrap(err)
+	case codes.Internal:
+		// the reply of the node couldn't be decoded
+		return ErrInvalidResponse.Wrap(err)
+	}
+	return err
+}
+
 // Download Shares downloads shares from the nodes where remote pieces are located
 func (d *defaultDownloader) DownloadShares(ctx context.Context, pointer *pb.Pointer,
 	stripeIndex int, authorization *pb.SignedMessage) (shares []share, nodes []*pb.Node, err error) {
@@ -141,6 +190,7 @@ func (d *defaultDownloader) DownloadShares(ctx context.Context, pointer *pb.Poin
 		paddedSize := calcPadded(pointer.GetSize(), shareSize)
 		pieceSize := paddedSize / int64(pointer.Remote.Redundancy.GetMinReq())
 
+		start := time.Now()
 		s, err := d.getShare(ctx, stripeIndex, shareSize, int(pieces[i].PieceNum), pieceID, pieceSize, node, authorization)
 		if err != nil {
 			s = share{
@@ -149,6 +199,7 @@ func (d *defaultDownloader) DownloadShares(ctx context.Context, pointer *pb.Poin
 				Data:        nil,
 			}
 		}
+		s.Latency = time.Since(start)
 		shares = append(shares, s)
 	}
 
@@ -187,8 +238,13 @@ func auditShares(ctx context.Context, required, total int, originals []share) (p
 	if err != nil {
 		return nil, err
 	}
-	for i, share := range copies {
-		if !bytes.Equal(originals[i].Data, share.Data) {
+	// the copies leave out the shares which couldn't be downloaded
+	originalData := make(map[int][]byte, len(originals))
+	for _, original := range originals {
+		originalData[original.PieceNumber] = original.Data
+	}
+	for _, share := range copies {
+		if !bytes.Equal(originalData[share.Number], share.Data) {
 			pieceNums = append(pieceNums, share.Number)
 		}
 	}
@@ -203,20 +259,86 @@ func calcPadded(size int64, blockSize int) int64 {
 	return size + int64(blockSize) - mod
 }
 
+// stripeResult holds the ids of the nodes by the outcome of auditing a stripe
+type stripeResult struct {
+	offline []string
+	failed  []string
+	success []string
+
+	// errors holds why the nodes failed or were offline, latencies how long the nodes took to answer
+	errors    map[string]error
+	latencies map[string]time.Duration
+}
+
+// detail keeps the error and the latency of the node for the audit history
+func (result *stripeResult) detail(nodeID string, err error, latency time.Duration) {
+	if result.errors == nil {
+		result.errors = make(map[string]error)
+		result.latencies = make(map[string]time.Duration)
+	}
+	if err != nil {
+		result.errors[nodeID] = err
+	}
+	result.latencies[nodeID] = latency
+}
+
+// records returns the audit history records of the nodes of the result
+func (result *stripeResult) records(path storj.Path, stripeIndex int) (records []*Record) {
+	add := func(nodeIDs []string, outcome string) {
+		for _, nodeID := range nodeIDs {
+			records = append(records, &Record{
+				NodeID:      nodeID,
+				Path:        path,
+				StripeIndex: int64(stripeIndex),
+				Outcome:     outcome,
+				ErrorClass:  errorClass(result.errors[nodeID]),
+				Latency:     result.latencies[nodeID],
+			})
+		}
+	}
+	add(result.success, OutcomeSuccess)
+	add(result.failed, OutcomeFailed)
+	add(result.offline, OutcomeOffline)
+	return records
+}
+
+// statuses returns the statdb updates of the nodes of the result
+func (result *stripeResult) statuses(ctx context.Context) []*sdbproto.Node {
+	return setVerifiedNodes(ctx, nil, result.offline, result.failed, result.success)
+}
+
 // verify downloads shares then verifies the data correctness at the given stripe
 func (verifier *Verifier) verify(ctx context.Context, stripeIndex int, pointer *pb.Pointer, authorization *pb.SignedMessage) (verifiedNodes []*sdbproto.Node, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	result, err := verifier.check(ctx, stripeIndex, pointer, authorization)
+	if err != nil {
+		return nil, err
+	}
+	return result.statuses(ctx), nil
+}
+
+// check downloads shares then sorts the nodes by whether they answered and whether their share is correct
+func (verifier *Verifier) check(ctx context.Context, stripeIndex int, pointer *pb.Pointer, authorization *pb.SignedMessage) (result *stripeResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// segments with Merkle roots don't need a quorum of shares to find the altered ones
+	if provable(pointer) {
+		return verifier.prove(ctx, stripeIndex, pointer, pointer.GetRemote().GetRemotePieces(), authorization), nil
+	}
+
 	shares, nodes, err := verifier.downloader.DownloadShares(ctx, pointer, stripeIndex, authorization)
 	if err != nil {
 		return nil, err
 	}
 
+	result = &stripeResult{}
 	var offlineNodes []string
 	for i := range shares {
 		if shares[i].Error != nil {
 			offlineNodes = append(offlineNodes, nodes[i].GetId())
 		}
+		result.detail(nodes[i].GetId(), shares[i].Error, shares[i].Latency)
 	}
 
 	required := int(pointer.Remote.Redundancy.GetMinReq())
@@ -228,13 +350,63 @@ func (verifier *Verifier) verify(ctx context.Context, stripeIndex int, pointer *
 
 	var failedNodes []string
 	for _, pieceNum := range pieceNums {
-		failedNodes = append(failedNodes, nodes[pieceNum].GetId())
+		nodeID := nodes[pieceNum].GetId()
+		failedNodes = append(failedNodes, nodeID)
+		result.errors[nodeID] = ErrAlteredShare.New("piece %d", pieceNum)
 	}
 
-	successNodes := getSuccessNodes(ctx, nodes, failedNodes, offlineNodes)
-	verifiedNodes = setVerifiedNodes(ctx, nodes, offlineNodes, failedNodes, successNodes)
+	result.offline = offlineNodes
+	result.failed = failedNodes
+	result.success = getSuccessNodes(ctx, nodes, failedNodes, offlineNodes)
+	return result, nil
+}
 
-	return verifiedNodes, nil
+// provable returns whether every piece of the segment can be audited on its own with a Merkle proof
+func provable(pointer *pb.Pointer) bool {
+	remote := pointer.GetRemote()
+	roots := remote.GetPieceRoots()
+	if len(roots) != int(remote.GetRedundancy().GetTotal()) || !bytes.Equal(merkle.DataRoot(roots), remote.GetMerkleRoot()) {
+		return false
+	}
+	for _, piece := range remote.GetRemotePieces() {
+		if piece.GetPieceNum() < 0 || int(piece.GetPieceNum()) >= len(roots) || len(roots[piece.GetPieceNum()]) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// prove audits each of the pieces alone, a node fails the audit if its share doesn't match the Merkle root of its piece
+func (verifier *Verifier) prove(ctx context.Context, stripeIndex int, pointer *pb.Pointer, pieces []*pb.RemotePiece, authorization *pb.SignedMessage) (result *stripeResult) {
+	remote := pointer.GetRemote()
+	shareSize := int(remote.GetRedundancy().GetErasureShareSize())
+	stripeSize := shareSize * int(remote.GetRedundancy().GetMinReq())
+	// every piece has one share of every stripe of the padded segment
+	shareCount := int(calcPadded(pointer.GetSize(), stripeSize) / int64(stripeSize))
+
+	result = &stripeResult{}
+	for _, piece := range pieces {
+		start := time.Now()
+		s, proof, err := verifier.downloader.ProveShare(ctx, pointer, piece, stripeIndex, authorization)
+		latency := time.Since(start)
+		switch {
+		case ErrMissingPiece.Has(err) || ErrInvalidResponse.Has(err):
+			// the node answered, but without a share of its piece
+			result.failed = append(result.failed, piece.GetNodeId())
+			result.detail(piece.GetNodeId(), err, latency)
+		case err != nil:
+			result.offline = append(result.offline, piece.GetNodeId())
+			result.detail(piece.GetNodeId(), err, latency)
+		case len(s.Data) != shareSize ||
+			!merkle.Verify(remote.GetPieceRoots()[piece.GetPieceNum()], merkle.Leaf(s.Data), stripeIndex, shareCount, proof):
+			result.failed = append(result.failed, piece.GetNodeId())
+			result.detail(piece.GetNodeId(), ErrInvalidProof.New("piece %d", piece.GetPieceNum()), latency)
+		default:
+			result.detail(piece.GetNodeId(), nil, latency)
+			result.success = append(result.success, piece.GetNodeId())
+		}
+	}
+	return result
 }
 
 // getSuccessNodes uses the failed nodes and offline nodes arrays to determine which nodes passed the audit
@@ -6,13 +6,21 @@ package audit
 import (
 	"context"
 	"crypto/rand"
+	"errors"
 	"strconv"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vivint/infectious"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"storj.io/storj/pkg/merkle"
 	"storj.io/storj/pkg/pb"
+	mock_pointerdb "storj.io/storj/pkg/pointerdb/pdbclient/mocks"
+	"storj.io/storj/storage/teststore"
 )
 
 type mockDownloader struct {
@@ -218,6 +226,133 @@ func (m *mockDownloader) DownloadShares(ctx context.Context, pointer *pb.Pointer
 	return shares, nodes, nil
 }
 
+func (m *mockDownloader) ProveShare(ctx context.Context, pointer *pb.Pointer, piece *pb.RemotePiece,
+	stripeIndex int, authorization *pb.SignedMessage) (s share, proof [][]byte, err error) {
+	return s, nil, errors.New("proofs aren't mocked")
+}
+
+// pieceDownloader proves the shares of the pieces of the nodes unless the node is offline,
+// doesn't have the piece or sends a reply which can't be decoded
+type pieceDownloader struct {
+	pieces  map[string][]byte
+	offline map[string]bool
+	missing map[string]bool
+	invalid map[string]bool
+	proved  map[string]int
+}
+
+func (d *pieceDownloader) DownloadShares(ctx context.Context, pointer *pb.Pointer,
+	stripeIndex int, authorization *pb.SignedMessage) (shares []share, nodes []*pb.Node, err error) {
+	return nil, nil, errors.New("segments with Merkle roots are audited without a quorum")
+}
+
+func (d *pieceDownloader) ProveShare(ctx context.Context, pointer *pb.Pointer, piece *pb.RemotePiece,
+	stripeIndex int, authorization *pb.SignedMessage) (s share, proof [][]byte, err error) {
+	d.proved[piece.NodeId]++
+	switch {
+	case d.offline[piece.NodeId]:
+		return s, nil, proveError(status.Error(codes.Unavailable, "timeout"))
+	case d.missing[piece.NodeId]:
+		return s, nil, proveError(status.Error(codes.NotFound, "piece not found"))
+	case d.invalid[piece.NodeId]:
+		return s, nil, proveError(status.Error(codes.Internal, "grpc: failed to unmarshal the received message"))
+	}
+	shareSize := int(pointer.Remote.Redundancy.ErasureShareSize)
+	data := d.pieces[piece.NodeId]
+	hasher := merkle.NewShareHasher(shareSize)
+	_, _ = hasher.Write(data)
+	proof, err = merkle.Proof(hasher.Leaves(), stripeIndex)
+	if err != nil {
+		return s, nil, err
+	}
+	offset := stripeIndex * shareSize
+	return share{PieceNumber: int(piece.PieceNum), Data: data[offset : offset+shareSize]}, proof, nil
+}
+
+func TestProveAudit(t *testing.T) {
+	nodeIDs := []string{"a", "b", "c", "d"}
+	const shareSize, stripes = 16, 4
+	fec, err := infectious.NewFEC(2, 4)
+	require.NoError(t, err)
+
+	data := randData(2 * shareSize * stripes)
+	pieces := make(map[string][]byte)
+	for stripe := 0; stripe < stripes; stripe++ {
+		err := fec.Encode(data[stripe*2*shareSize:(stripe+1)*2*shareSize], func(s infectious.Share) {
+			pieces[nodeIDs[s.Number]] = append(pieces[nodeIDs[s.Number]], s.Data...)
+		})
+		require.NoError(t, err)
+	}
+
+	pointer := &pb.Pointer{
+		Type: pb.Pointer_REMOTE,
+		Size: int64(len(data)),
+		Remote: &pb.RemoteSegment{
+			Redundancy: &pb.RedundancyScheme{MinReq: 2, Total: 4, ErasureShareSize: shareSize},
+			PieceId:    "piece",
+		},
+	}
+	for i, nodeID := range nodeIDs {
+		pointer.Remote.RemotePieces = append(pointer.Remote.RemotePieces, &pb.RemotePiece{PieceNum: int32(i), NodeId: nodeID})
+		hasher := merkle.NewShareHasher(shareSize)
+		_, _ = hasher.Write(pieces[nodeID])
+		pointer.Remote.PieceRoots = append(pointer.Remote.PieceRoots, hasher.Root())
+	}
+	pointer.Remote.MerkleRoot = merkle.DataRoot(pointer.Remote.PieceRoots)
+	require.True(t, provable(pointer))
+
+	// the third share of c is altered after the upload
+	pieces["c"][2*shareSize] ^= 1
+	downloader := &pieceDownloader{pieces: pieces, offline: map[string]bool{"d": true}, proved: make(map[string]int)}
+	verifier := &Verifier{downloader: downloader}
+
+	result, err := verifier.check(ctx, 2, pointer, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, result.success)
+	assert.Equal(t, []string{"c"}, result.failed)
+	assert.Equal(t, []string{"d"}, result.offline)
+
+	// nodes which answer without a share of their piece fail the audit
+	downloader.missing = map[string]bool{"a": true}
+	downloader.invalid = map[string]bool{"b": true}
+	result, err = verifier.check(ctx, 2, pointer, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, result.failed)
+	assert.Equal(t, []string{"d"}, result.offline)
+	assert.True(t, ErrMissingPiece.Has(result.errors["a"]))
+	assert.True(t, ErrInvalidResponse.Has(result.errors["b"]))
+	downloader.missing, downloader.invalid = nil, nil
+
+	// the proofs of the other shares of c don't lead to the root either
+	result, err = verifier.check(ctx, 1, pointer, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, result.failed)
+
+	// a contained node is reverified without asking the other nodes
+	pointers := mock_pointerdb.NewMockClient(gomock.NewController(t))
+	pointers.EXPECT().Get(gomock.Any(), "l/bucket/object").Return(pointer, nil, nil, nil)
+	pointers.EXPECT().SignedMessage()
+	containment := NewContainment(teststore.New())
+	reporter := &mockReporter{}
+	service := &Service{
+		Cursor:           NewCursor(pointers, nil, nil, ScheduleConfig{}),
+		Verifier:         verifier,
+		Reporter:         reporter,
+		Containment:      containment,
+		maxReverifyCount: 2,
+	}
+	require.NoError(t, containment.Put(ctx, &pb.PendingAudit{NodeId: "d", Path: "l/bucket/object", PieceId: "piece", StripeIndex: 3}))
+	downloader.offline = nil
+	downloader.proved = make(map[string]int)
+	require.NoError(t, service.reverify(ctx))
+	assert.Equal(t, map[string]int{"d": 1}, downloader.proved)
+	assert.True(t, reporter.takeStatuses()["d"].AuditSuccess)
+
+	// segments with inconsistent roots are audited with a quorum
+	pointer.Remote.MerkleRoot = nil
+	assert.False(t, provable(pointer))
+}
+
 func makePointer(nodeAmt int) *pb.Pointer {
 	var rps []*pb.RemotePiece
 	for i := 0; i < nodeAmt; i++ {
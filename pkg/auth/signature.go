@@ -5,6 +5,7 @@ package auth
 
 import (
 	"crypto/ecdsa"
+	"crypto/x509"
 
 	"github.com/gtank/cryptopasta"
 
@@ -45,13 +46,44 @@ func NewSignedMessage(signature []byte, identity *provider.FullIdentity) (*pb.Si
 		Data:      identity.ID.Bytes(),
 		Signature: signature,
 		PublicKey: encodedKey,
+		Certs:     identity.ChainRaw(),
 	}, nil
 }
 
+// VerifySignature checks that data was signed with the leaf key of the certificate chain, leaf first,
+// and returns the id of the node the chain belongs to
+func VerifySignature(data, signature []byte, chain [][]byte) (nodeID string, err error) {
+	if len(chain) < 2 {
+		return "", Error.New("incomplete certificate chain")
+	}
+	certs, err := provider.ParseCertChain(chain)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	if err := peertls.VerifyPeerCertChains(nil, [][]*x509.Certificate{certs}); err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	k, ok := certs[0].PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return "", peertls.ErrUnsupportedKey.New("%T", certs[0].PublicKey)
+	}
+	if ok := cryptopasta.Verify(data, signature, k); !ok {
+		return "", Error.New("failed to verify message")
+	}
+
+	identity, err := provider.PeerIdentityFromCerts(certs[0], certs[1], certs[2:])
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	return identity.ID.String(), nil
+}
+
 // SignedMessageVerifier checks if provided signed message can be verified
 type SignedMessageVerifier func(signature *pb.SignedMessage) error
 
-// NewSignedMessageVerifier creates default implementation of SignedMessageVerifier
+// NewSignedMessageVerifier creates default implementation of SignedMessageVerifier,
+// which checks that the message is the id of the node signing it
 func NewSignedMessageVerifier() SignedMessageVerifier {
 	return func(signedMessage *pb.SignedMessage) error {
 		if signedMessage == nil {
@@ -63,16 +95,16 @@ func NewSignedMessageVerifier() SignedMessageVerifier {
 		if signedMessage.Data == nil {
 			return Error.New("missing data for verification")
 		}
-		if signedMessage.PublicKey == nil {
-			return Error.New("missing public key for verification")
+		if signedMessage.Certs == nil {
+			return Error.New("missing certificate chain for verification")
 		}
 
-		k, err := cryptopasta.DecodePublicKey(signedMessage.GetPublicKey())
+		nodeID, err := VerifySignature(signedMessage.GetData(), signedMessage.GetSignature(), signedMessage.GetCerts())
 		if err != nil {
-			return Error.Wrap(err)
+			return err
 		}
-		if ok := cryptopasta.Verify(signedMessage.GetData(), signedMessage.GetSignature(), k); !ok {
-			return Error.New("failed to verify message")
+		if nodeID != string(signedMessage.GetData()) {
+			return Error.New("message of %s signed by %s", signedMessage.GetData(), nodeID)
 		}
 		return nil
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/gtank/cryptopasta"
 	"github.com/stretchr/testify/assert"
 
+	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/provider"
 )
 
@@ -52,24 +53,33 @@ func TestSignedMessageVerifier(t *testing.T) {
 	signedMessage, err := NewSignedMessage(signature, identity)
 	assert.NoError(t, err)
 
+	// another node signing the id of the node
+	forgerCA, err := provider.NewTestCA(ctx)
+	assert.NoError(t, err)
+	forger, err := forgerCA.NewIdentity()
+	assert.NoError(t, err)
+	forged, err := GenerateSignature(identity.ID.Bytes(), forger)
+	assert.NoError(t, err)
+
 	for _, tt := range []struct {
 		signature []byte
 		data      []byte
-		publicKey []byte
+		certs     [][]byte
 		errString string
 	}{
-		{signedMessage.Signature, signedMessage.Data, signedMessage.PublicKey, ""},
-		{nil, signedMessage.Data, signedMessage.PublicKey, "auth error: missing signature for verification"},
-		{signedMessage.Signature, nil, signedMessage.PublicKey, "auth error: missing data for verification"},
-		{signedMessage.Signature, signedMessage.Data, nil, "auth error: missing public key for verification"},
+		{signedMessage.Signature, signedMessage.Data, signedMessage.Certs, ""},
+		{nil, signedMessage.Data, signedMessage.Certs, "auth error: missing signature for verification"},
+		{signedMessage.Signature, nil, signedMessage.Certs, "auth error: missing data for verification"},
+		{signedMessage.Signature, signedMessage.Data, nil, "auth error: missing certificate chain for verification"},
+		{signedMessage.Signature, signedMessage.Data, signedMessage.Certs[:1], "auth error: incomplete certificate chain"},
 
-		{signedMessage.Signature, []byte("malformed data"), signedMessage.PublicKey, "auth error: failed to verify message"},
+		{signedMessage.Signature, []byte("malformed data"), signedMessage.Certs, "auth error: failed to verify message"},
+		{forged, signedMessage.Data, signedMessage.Certs, "auth error: failed to verify message"},
+		{forged, signedMessage.Data, forger.ChainRaw(), "auth error: message of " + identity.ID.String() + " signed by " + forger.ID.String()},
 	} {
-		signedMessage.Signature = tt.signature
-		signedMessage.Data = tt.data
-		signedMessage.PublicKey = tt.publicKey
+		message := &pb.SignedMessage{Signature: tt.signature, Data: tt.data, Certs: tt.certs}
 
-		err := NewSignedMessageVerifier()(signedMessage)
+		err := NewSignedMessageVerifier()(message)
 		if tt.errString != "" {
 			assert.EqualError(t, err, tt.errString)
 		} else {
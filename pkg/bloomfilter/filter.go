@@ -0,0 +1,134 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bloomfilter
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+
+	"github.com/zeebo/errs"
+)
+
+// Error is the default error class for the bloomfilter package
+var Error = errs.Class("bloomfilter error")
+
+const (
+	// version is the first byte of the serialized filter
+	version = 1
+	// headerSize is the number of bytes before the filter table
+	headerSize = 1 + 1 + seedSize
+	seedSize   = 8
+
+	maxHashCount = 32
+)
+
+// Filter is a bloom filter of piece ids
+type Filter struct {
+	hashCount byte
+	seed      [seedSize]byte
+	table     []byte
+}
+
+// NewOptimal returns a filter sized for expectedElements with falsePositiveRate.
+// Every filter uses a random seed, so a piece that is a false positive
+// in one filter is unlikely to be a false positive in the next one.
+func NewOptimal(expectedElements int, falsePositiveRate float64) *Filter {
+	if expectedElements < 1 {
+		expectedElements = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.1
+	}
+
+	n := float64(expectedElements)
+	bits := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashCount := math.Ceil(bits / n * math.Ln2)
+	if hashCount < 1 {
+		hashCount = 1
+	}
+	if hashCount > maxHashCount {
+		hashCount = maxHashCount
+	}
+
+	filter := &Filter{
+		hashCount: byte(hashCount),
+		table:     make([]byte, int(math.Ceil(bits/8))),
+	}
+	_, _ = rand.Read(filter.seed[:])
+	return filter
+}
+
+// NewFromBytes decodes a filter serialized with Bytes
+func NewFromBytes(data []byte) (*Filter, error) {
+	if len(data) <= headerSize {
+		return nil, Error.New("not enough data")
+	}
+	if data[0] != version {
+		return nil, Error.New("unsupported version %d", data[0])
+	}
+
+	filter := &Filter{
+		hashCount: data[1],
+		table:     append([]byte{}, data[headerSize:]...),
+	}
+	if filter.hashCount == 0 || filter.hashCount > maxHashCount {
+		return nil, Error.New("invalid hash count %d", filter.hashCount)
+	}
+	copy(filter.seed[:], data[2:headerSize])
+
+	return filter, nil
+}
+
+// Add adds id to the filter
+func (filter *Filter) Add(id []byte) {
+	bits := uint64(len(filter.table)) * 8
+	h1, h2 := filter.hash(id)
+	for i := uint64(0); i < uint64(filter.hashCount); i++ {
+		bit := (h1 + i*h2) % bits
+		filter.table[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// Contains returns whether id may have been added to the filter.
+// It never returns false for an id that was added.
+func (filter *Filter) Contains(id []byte) bool {
+	bits := uint64(len(filter.table)) * 8
+	h1, h2 := filter.hash(id)
+	for i := uint64(0); i < uint64(filter.hashCount); i++ {
+		bit := (h1 + i*h2) % bits
+		if filter.table[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Bytes returns the serialized filter
+func (filter *Filter) Bytes() []byte {
+	data := make([]byte, 0, headerSize+len(filter.table))
+	data = append(data, version, filter.hashCount)
+	data = append(data, filter.seed[:]...)
+	data = append(data, filter.table...)
+	return data
+}
+
+// Size returns the size of the filter table in bytes
+func (filter *Filter) Size() int {
+	return len(filter.table)
+}
+
+// hash returns the two hashes used for double hashing id
+func (filter *Filter) hash(id []byte) (h1, h2 uint64) {
+	hasher := sha256.New()
+	_, _ = hasher.Write(filter.seed[:])
+	_, _ = hasher.Write(id)
+	sum := hasher.Sum(nil)
+
+	h1 = binary.BigEndian.Uint64(sum[0:8])
+	// h2 must be odd to visit different bits
+	h2 = binary.BigEndian.Uint64(sum[8:16]) | 1
+	return h1, h2
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bloomfilter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	const n = 10000
+
+	filter := NewOptimal(n, 0.01)
+	for i := 0; i < n; i++ {
+		filter.Add(id("added", i))
+	}
+
+	for i := 0; i < n; i++ {
+		assert.True(t, filter.Contains(id("added", i)))
+	}
+
+	falsePositives := 0
+	for i := 0; i < n; i++ {
+		if filter.Contains(id("missing", i)) {
+			falsePositives++
+		}
+	}
+	// expected around 1%, leave some room for randomness
+	assert.True(t, falsePositives < n*3/100, "too many false positives %d", falsePositives)
+}
+
+func TestFilterBytes(t *testing.T) {
+	filter := NewOptimal(100, 0.1)
+	for i := 0; i < 100; i++ {
+		filter.Add(id("added", i))
+	}
+
+	decoded, err := NewFromBytes(filter.Bytes())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, filter, decoded)
+
+	for i := 0; i < 100; i++ {
+		assert.True(t, decoded.Contains(id("added", i)))
+	}
+
+	for _, tt := range []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "header only", data: filter.Bytes()[:headerSize]},
+		{name: "wrong version", data: append([]byte{2}, filter.Bytes()[1:]...)},
+		{name: "zero hash count", data: append([]byte{version, 0}, filter.Bytes()[2:]...)},
+	} {
+		_, err := NewFromBytes(tt.data)
+		assert.Error(t, err, tt.name)
+	}
+}
+
+func id(prefix string, i int) []byte {
+	return []byte(fmt.Sprintf("%s-%d", prefix, i))
+}
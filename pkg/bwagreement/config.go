@@ -6,6 +6,7 @@ package bwagreement
 import (
 	"context"
 	"net/url"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -15,16 +16,23 @@ import (
 	"storj.io/storj/pkg/bwagreement/database-manager"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/uplinkdb"
 )
 
 var (
 	mon = monkit.Package()
 )
 
+// CtxKey is used as the key of the bandwidth agreement database in the context
+type CtxKey int
+
+const ctxKey CtxKey = iota
+
 // Config is a configuration struct that is everything you need to start an
 // agreement receiver responsibility
 type Config struct {
-	DatabaseURL string `help:"the database connection string to use" default:"sqlite3://$CONFDIR/bw.db"`
+	DatabaseURL         string        `help:"the database connection string to use" default:"sqlite3://$CONFDIR/bw.db"`
+	SerialPruneInterval time.Duration `help:"how frequently serial numbers of expired bandwidth allocations are deleted" default:"1h"`
 }
 
 // Run implements the provider.Responsibility interface
@@ -44,12 +52,34 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 		return errs.New("Error starting initializing database for Bandwidth Agreement server on satellite: %+v", err)
 	}
 
-	ns, err := NewServer(dbm, zap.L(), k)
+	keys := uplinkdb.LoadFromContext(ctx)
+	if keys == nil {
+		return errs.New("uplinkdb not found in context")
+	}
+
+	ns, err := NewServer(dbm, keys, zap.L(), k)
 	if err != nil {
 		return errs.New("Error starting Bandwidth Agreement server on satellite: %+v", err)
 	}
 
 	pb.RegisterBandwidthServer(server.GRPC(), ns)
 
+	pruner := NewSerialPruner(dbm, c.SerialPruneInterval)
+	go func() {
+		if err := pruner.Run(ctx); err != nil {
+			zap.S().Errorf("Serial number pruner stopped: %v", err)
+		}
+	}()
+
+	// add the agreements to the context for accounting
+	ctx = context.WithValue(ctx, ctxKey, dbm)
 	return server.Run(ctx)
 }
+
+// LoadFromContext gives access to the received bandwidth agreements from the context, or returns nil
+func LoadFromContext(ctx context.Context) *dbmanager.DBManager {
+	if v, ok := ctx.Value(ctxKey).(*dbmanager.DBManager); ok {
+		return v
+	}
+	return nil
+}
@@ -4,9 +4,12 @@
 package dbmanager
 
 import (
+	"bytes"
 	"context"
 	"sync"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -15,6 +18,7 @@ import (
 	"storj.io/storj/internal/migrate"
 	dbx "storj.io/storj/pkg/bwagreement/database-manager/dbx"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/utils"
 )
 
 var (
@@ -48,7 +52,8 @@ func (dbm *DBManager) locked() func() {
 	return dbm.mu.Unlock
 }
 
-// Create a db entry for the provided storagenode
+// Create a db entry for the provided storagenode, creating the same agreement again returns the
+// existing entry, while any other agreement with the same serial number is rejected
 func (dbm *DBManager) Create(ctx context.Context, createBwAgreement *pb.RenterBandwidthAllocation) (bwagreement *dbx.Bwagreement, err error) {
 	defer mon.Task()(&ctx)(&err)
 	defer dbm.locked()()
@@ -56,10 +61,70 @@ func (dbm *DBManager) Create(ctx context.Context, createBwAgreement *pb.RenterBa
 	signature := createBwAgreement.GetSignature()
 	data := createBwAgreement.GetData()
 
-	bwagreement, err = dbm.DB.Create_Bwagreement(
-		ctx,
+	rbad := &pb.RenterBandwidthAllocation_Data{}
+	if err := proto.Unmarshal(data, rbad); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pbad := &pb.PayerBandwidthAllocation_Data{}
+	if err := proto.Unmarshal(rbad.GetPayerAllocation().GetData(), pbad); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	tx, err := dbm.DB.Open(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	bwagreement, err = dbm.create(ctx, tx, signature, data, rbad, pbad)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return bwagreement, nil
+}
+
+func (dbm *DBManager) create(ctx context.Context, tx *dbx.Tx, signature, data []byte, rbad *pb.RenterBandwidthAllocation_Data, pbad *pb.PayerBandwidthAllocation_Data) (*dbx.Bwagreement, error) {
+	existing, err := tx.Get_Bwagreement_By_Signature(ctx, dbx.Bwagreement_Signature(signature))
+	if err == nil {
+		// the agreement was sent again, it is only counted once
+		return existing, nil
+	}
+	if !isNoRows(err) {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	serialNumber := pbad.GetSerialNumber()
+	_, err = tx.Get_Serialnumber_By_SerialNumber_And_StorageNodeId(ctx,
+		dbx.Serialnumber_SerialNumber(serialNumber),
+		dbx.Serialnumber_StorageNodeId(rbad.GetStorageNodeId()),
+	)
+	if err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "serial number %q already used", serialNumber)
+	}
+	if !isNoRows(err) {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	_, err = tx.Create_Serialnumber(ctx,
+		dbx.Serialnumber_SerialNumber(serialNumber),
+		dbx.Serialnumber_StorageNodeId(rbad.GetStorageNodeId()),
+		dbx.Serialnumber_ExpiresAt(time.Unix(pbad.GetExpirationUnixSec(), 0)),
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	bwagreement, err := tx.Create_Bwagreement(ctx,
 		dbx.Bwagreement_Signature(signature),
 		dbx.Bwagreement_Data(data),
+		dbx.Bwagreement_Action(int(pbad.GetAction())),
+		dbx.Bwagreement_Total(rbad.GetTotal()),
 	)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
@@ -68,6 +133,21 @@ func (dbm *DBManager) Create(ctx context.Context, createBwAgreement *pb.RenterBa
 	return bwagreement, nil
 }
 
+// DeleteExpiredSerialNumbers removes the serial numbers that expired before now,
+// their agreements are no longer accepted so they can't be replayed
+func (dbm *DBManager) DeleteExpiredSerialNumbers(ctx context.Context, now time.Time) (deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer dbm.locked()()
+
+	return dbm.DB.Delete_Serialnumber_By_ExpiresAt_Less(ctx, dbx.Serialnumber_ExpiresAt(now))
+}
+
+// isNoRows checks whether err is caused by reading a missing row
+func isNoRows(err error) bool {
+	dbxErr, ok := err.(*dbx.Error)
+	return ok && dbxErr.Code == dbx.ErrorCode_NoRows
+}
+
 // GetBandwidthAllocations all bandwidth agreements and sorts by satellite
 func (dbm *DBManager) GetBandwidthAllocations(ctx context.Context) (rows []*dbx.Bwagreement, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -75,3 +155,99 @@ func (dbm *DBManager) GetBandwidthAllocations(ctx context.Context) (rows []*dbx.
 	rows, err = dbm.DB.All_Bwagreement(ctx)
 	return rows, err
 }
+
+// GetTotalsByAction sums the bandwidth of the agreements received between from and to by action,
+// uploads and downloads are paid differently
+func (dbm *DBManager) GetTotalsByAction(ctx context.Context, from, to time.Time) (totals map[pb.PayerBandwidthAllocation_Action]int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer dbm.locked()()
+
+	rows, err := dbm.DB.QueryContext(ctx, dbm.DB.Rebind(`SELECT action, SUM(total) FROM bwagreements WHERE ? <= created_at AND created_at < ? GROUP BY action`), from.UTC(), to.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	totals = make(map[pb.PayerBandwidthAllocation_Action]int64)
+	for rows.Next() {
+		var action int
+		var total int64
+		if err := rows.Scan(&action, &total); err != nil {
+			return totals, err
+		}
+		totals[pb.PayerBandwidthAllocation_Action(action)] = total
+	}
+	return totals, rows.Err()
+}
+
+// GetNodeTotals sums the bandwidth of the agreements received between from and to by storage node and action
+func (dbm *DBManager) GetNodeTotals(ctx context.Context, from, to time.Time) (totals map[string]map[pb.PayerBandwidthAllocation_Action]int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer dbm.locked()()
+
+	rows, err := dbm.DB.QueryContext(ctx, dbm.DB.Rebind(`SELECT data, action, total FROM bwagreements WHERE ? <= created_at AND created_at < ?`), from.UTC(), to.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	totals = make(map[string]map[pb.PayerBandwidthAllocation_Action]int64)
+	for rows.Next() {
+		var data []byte
+		var action int
+		var total int64
+		if err := rows.Scan(&data, &action, &total); err != nil {
+			return totals, err
+		}
+
+		// the storage node is only known from the agreement itself
+		rbad := &pb.RenterBandwidthAllocation_Data{}
+		if err := proto.Unmarshal(data, rbad); err != nil {
+			return totals, err
+		}
+		nodeID := string(rbad.GetStorageNodeId())
+		if totals[nodeID] == nil {
+			totals[nodeID] = make(map[pb.PayerBandwidthAllocation_Action]int64)
+		}
+		totals[nodeID][pb.PayerBandwidthAllocation_Action(action)] += total
+	}
+	return totals, rows.Err()
+}
+
+// GetBucketEgress sums the bandwidth of the GET agreements received between from and to by bucket
+// of the project with the api key hash
+func (dbm *DBManager) GetBucketEgress(ctx context.Context, apiKeyHash []byte, from, to time.Time) (totals map[string]int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer dbm.locked()()
+
+	rows, err := dbm.DB.QueryContext(ctx, dbm.DB.Rebind(`SELECT data, total FROM bwagreements WHERE action = ? AND ? <= created_at AND created_at < ?`),
+		int(pb.PayerBandwidthAllocation_GET), from.UTC(), to.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	totals = make(map[string]int64)
+	for rows.Next() {
+		var data []byte
+		var total int64
+		if err := rows.Scan(&data, &total); err != nil {
+			return totals, err
+		}
+
+		// the bucket is only known from the payer allocation inside the agreement
+		rbad := &pb.RenterBandwidthAllocation_Data{}
+		if err := proto.Unmarshal(data, rbad); err != nil {
+			return totals, err
+		}
+		pbad := &pb.PayerBandwidthAllocation_Data{}
+		if err := proto.Unmarshal(rbad.GetPayerAllocation().GetData(), pbad); err != nil {
+			return totals, err
+		}
+		if pbad.GetBucket() == "" || !bytes.Equal(pbad.GetApiKeyHash(), apiKeyHash) {
+			continue
+		}
+		totals[pbad.GetBucket()] += total
+	}
+	return totals, rows.Err()
+}
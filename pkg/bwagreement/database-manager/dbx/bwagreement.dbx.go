@@ -275,8 +275,16 @@ func (obj *postgresDB) Schema() string {
 	return `CREATE TABLE bwagreements (
 	signature bytea NOT NULL,
 	data bytea NOT NULL,
+	action integer NOT NULL,
+	total bigint NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( signature )
+);
+CREATE TABLE serialnumbers (
+	serial_number text NOT NULL,
+	storage_node_id bytea NOT NULL,
+	expires_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( serial_number, storage_node_id )
 );`
 }
 
@@ -344,8 +352,16 @@ func (obj *sqlite3DB) Schema() string {
 	return `CREATE TABLE bwagreements (
 	signature BLOB NOT NULL,
 	data BLOB NOT NULL,
+	action INTEGER NOT NULL,
+	total INTEGER NOT NULL,
 	created_at TIMESTAMP NOT NULL,
 	PRIMARY KEY ( signature )
+);
+CREATE TABLE serialnumbers (
+	serial_number TEXT NOT NULL,
+	storage_node_id BLOB NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( serial_number, storage_node_id )
 );`
 }
 
@@ -412,6 +428,8 @@ nextval:
 type Bwagreement struct {
 	Signature []byte
 	Data      []byte
+	Action    int
+	Total     int64
 	CreatedAt time.Time
 }
 
@@ -456,6 +474,42 @@ func (f Bwagreement_Data_Field) value() interface{} {
 
 func (Bwagreement_Data_Field) _Column() string { return "data" }
 
+type Bwagreement_Action_Field struct {
+	_set   bool
+	_value int
+}
+
+func Bwagreement_Action(v int) Bwagreement_Action_Field {
+	return Bwagreement_Action_Field{_set: true, _value: v}
+}
+
+func (f Bwagreement_Action_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (Bwagreement_Action_Field) _Column() string { return "action" }
+
+type Bwagreement_Total_Field struct {
+	_set   bool
+	_value int64
+}
+
+func Bwagreement_Total(v int64) Bwagreement_Total_Field {
+	return Bwagreement_Total_Field{_set: true, _value: v}
+}
+
+func (f Bwagreement_Total_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (Bwagreement_Total_Field) _Column() string { return "total" }
+
 type Bwagreement_CreatedAt_Field struct {
 	_set   bool
 	_value time.Time
@@ -474,6 +528,71 @@ func (f Bwagreement_CreatedAt_Field) value() interface{} {
 
 func (Bwagreement_CreatedAt_Field) _Column() string { return "created_at" }
 
+type Serialnumber struct {
+	SerialNumber  string
+	StorageNodeId []byte
+	ExpiresAt     time.Time
+}
+
+func (Serialnumber) _Table() string { return "serialnumbers" }
+
+type Serialnumber_Update_Fields struct {
+}
+
+type Serialnumber_SerialNumber_Field struct {
+	_set   bool
+	_value string
+}
+
+func Serialnumber_SerialNumber(v string) Serialnumber_SerialNumber_Field {
+	return Serialnumber_SerialNumber_Field{_set: true, _value: v}
+}
+
+func (f Serialnumber_SerialNumber_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (Serialnumber_SerialNumber_Field) _Column() string { return "serial_number" }
+
+type Serialnumber_StorageNodeId_Field struct {
+	_set   bool
+	_value []byte
+}
+
+func Serialnumber_StorageNodeId(v []byte) Serialnumber_StorageNodeId_Field {
+	return Serialnumber_StorageNodeId_Field{_set: true, _value: v}
+}
+
+func (f Serialnumber_StorageNodeId_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (Serialnumber_StorageNodeId_Field) _Column() string { return "storage_node_id" }
+
+type Serialnumber_ExpiresAt_Field struct {
+	_set   bool
+	_value time.Time
+}
+
+func Serialnumber_ExpiresAt(v time.Time) Serialnumber_ExpiresAt_Field {
+	return Serialnumber_ExpiresAt_Field{_set: true, _value: v}
+}
+
+func (f Serialnumber_ExpiresAt_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (Serialnumber_ExpiresAt_Field) _Column() string { return "expires_at" }
+
 func toUTC(t time.Time) time.Time {
 	return t.UTC()
 }
@@ -644,21 +763,25 @@ func (h *__sqlbundle_Hole) Render() string { return h.SQL.Render() }
 
 func (obj *postgresImpl) Create_Bwagreement(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field,
-	bwagreement_data Bwagreement_Data_Field) (
+	bwagreement_data Bwagreement_Data_Field,
+	bwagreement_action Bwagreement_Action_Field,
+	bwagreement_total Bwagreement_Total_Field) (
 	bwagreement *Bwagreement, err error) {
 
 	__now := obj.db.Hooks.Now().UTC()
 	__signature_val := bwagreement_signature.value()
 	__data_val := bwagreement_data.value()
+	__action_val := bwagreement_action.value()
+	__total_val := bwagreement_total.value()
 	__created_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO bwagreements ( signature, data, created_at ) VALUES ( ?, ?, ? ) RETURNING bwagreements.signature, bwagreements.data, bwagreements.created_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO bwagreements ( signature, data, action, total, created_at ) VALUES ( ?, ?, ?, ?, ? ) RETURNING bwagreements.signature, bwagreements.data, bwagreements.action, bwagreements.total, bwagreements.created_at")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __signature_val, __data_val, __created_at_val)
+	obj.logStmt(__stmt, __signature_val, __data_val, __action_val, __total_val, __created_at_val)
 
 	bwagreement = &Bwagreement{}
-	err = obj.driver.QueryRow(__stmt, __signature_val, __data_val, __created_at_val).Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+	err = obj.driver.QueryRow(__stmt, __signature_val, __data_val, __action_val, __total_val, __created_at_val).Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.Action, &bwagreement.Total, &bwagreement.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -666,11 +789,35 @@ func (obj *postgresImpl) Create_Bwagreement(ctx context.Context,
 
 }
 
+func (obj *postgresImpl) Create_Serialnumber(ctx context.Context,
+	serialnumber_serial_number Serialnumber_SerialNumber_Field,
+	serialnumber_storage_node_id Serialnumber_StorageNodeId_Field,
+	serialnumber_expires_at Serialnumber_ExpiresAt_Field) (
+	serialnumber *Serialnumber, err error) {
+
+	__serial_number_val := serialnumber_serial_number.value()
+	__storage_node_id_val := serialnumber_storage_node_id.value()
+	__expires_at_val := serialnumber_expires_at.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO serialnumbers ( serial_number, storage_node_id, expires_at ) VALUES ( ?, ?, ? ) RETURNING serialnumbers.serial_number, serialnumbers.storage_node_id, serialnumbers.expires_at")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __serial_number_val, __storage_node_id_val, __expires_at_val)
+
+	serialnumber = &Serialnumber{}
+	err = obj.driver.QueryRow(__stmt, __serial_number_val, __storage_node_id_val, __expires_at_val).Scan(&serialnumber.SerialNumber, &serialnumber.StorageNodeId, &serialnumber.ExpiresAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return serialnumber, nil
+
+}
+
 func (obj *postgresImpl) Get_Bwagreement_By_Signature(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field) (
 	bwagreement *Bwagreement, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.created_at FROM bwagreements WHERE bwagreements.signature = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.action, bwagreements.total, bwagreements.created_at FROM bwagreements WHERE bwagreements.signature = ?")
 
 	var __values []interface{}
 	__values = append(__values, bwagreement_signature.value())
@@ -679,7 +826,7 @@ func (obj *postgresImpl) Get_Bwagreement_By_Signature(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	bwagreement = &Bwagreement{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.Action, &bwagreement.Total, &bwagreement.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -691,7 +838,7 @@ func (obj *postgresImpl) Limited_Bwagreement(ctx context.Context,
 	limit int, offset int64) (
 	rows []*Bwagreement, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.created_at FROM bwagreements LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.action, bwagreements.total, bwagreements.created_at FROM bwagreements LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values)
@@ -709,7 +856,7 @@ func (obj *postgresImpl) Limited_Bwagreement(ctx context.Context,
 
 	for __rows.Next() {
 		bwagreement := &Bwagreement{}
-		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.Action, &bwagreement.Total, &bwagreement.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -725,7 +872,7 @@ func (obj *postgresImpl) Limited_Bwagreement(ctx context.Context,
 func (obj *postgresImpl) All_Bwagreement(ctx context.Context) (
 	rows []*Bwagreement, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.created_at FROM bwagreements")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.action, bwagreements.total, bwagreements.created_at FROM bwagreements")
 
 	var __values []interface{}
 	__values = append(__values)
@@ -741,7 +888,7 @@ func (obj *postgresImpl) All_Bwagreement(ctx context.Context) (
 
 	for __rows.Next() {
 		bwagreement := &Bwagreement{}
-		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.Action, &bwagreement.Total, &bwagreement.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -754,6 +901,28 @@ func (obj *postgresImpl) All_Bwagreement(ctx context.Context) (
 
 }
 
+func (obj *postgresImpl) Get_Serialnumber_By_SerialNumber_And_StorageNodeId(ctx context.Context,
+	serialnumber_serial_number Serialnumber_SerialNumber_Field,
+	serialnumber_storage_node_id Serialnumber_StorageNodeId_Field) (
+	serialnumber *Serialnumber, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT serialnumbers.serial_number, serialnumbers.storage_node_id, serialnumbers.expires_at FROM serialnumbers WHERE serialnumbers.serial_number = ? AND serialnumbers.storage_node_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, serialnumber_serial_number.value(), serialnumber_storage_node_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	serialnumber = &Serialnumber{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&serialnumber.SerialNumber, &serialnumber.StorageNodeId, &serialnumber.ExpiresAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return serialnumber, nil
+
+}
+
 func (obj *postgresImpl) Delete_Bwagreement_By_Signature(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field) (
 	deleted bool, err error) {
@@ -780,6 +949,32 @@ func (obj *postgresImpl) Delete_Bwagreement_By_Signature(ctx context.Context,
 
 }
 
+func (obj *postgresImpl) Delete_Serialnumber_By_ExpiresAt_Less(ctx context.Context,
+	serialnumber_expires_at_less Serialnumber_ExpiresAt_Field) (
+	count int64, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM serialnumbers WHERE serialnumbers.expires_at < ?")
+
+	var __values []interface{}
+	__values = append(__values, serialnumber_expires_at_less.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.Exec(__stmt, __values...)
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	return count, nil
+
+}
+
 func (impl postgresImpl) isConstraintError(err error) (
 	constraint string, ok bool) {
 	if e, ok := err.(*pq.Error); ok {
@@ -793,6 +988,16 @@ func (impl postgresImpl) isConstraintError(err error) (
 func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error) {
 	var __res sql.Result
 	var __count int64
+	__res, err = obj.driver.Exec("DELETE FROM serialnumbers;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
 	__res, err = obj.driver.Exec("DELETE FROM bwagreements;")
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -810,20 +1015,24 @@ func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error)
 
 func (obj *sqlite3Impl) Create_Bwagreement(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field,
-	bwagreement_data Bwagreement_Data_Field) (
+	bwagreement_data Bwagreement_Data_Field,
+	bwagreement_action Bwagreement_Action_Field,
+	bwagreement_total Bwagreement_Total_Field) (
 	bwagreement *Bwagreement, err error) {
 
 	__now := obj.db.Hooks.Now().UTC()
 	__signature_val := bwagreement_signature.value()
 	__data_val := bwagreement_data.value()
+	__action_val := bwagreement_action.value()
+	__total_val := bwagreement_total.value()
 	__created_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO bwagreements ( signature, data, created_at ) VALUES ( ?, ?, ? )")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO bwagreements ( signature, data, action, total, created_at ) VALUES ( ?, ?, ?, ?, ? )")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __signature_val, __data_val, __created_at_val)
+	obj.logStmt(__stmt, __signature_val, __data_val, __action_val, __total_val, __created_at_val)
 
-	__res, err := obj.driver.Exec(__stmt, __signature_val, __data_val, __created_at_val)
+	__res, err := obj.driver.Exec(__stmt, __signature_val, __data_val, __action_val, __total_val, __created_at_val)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -835,11 +1044,38 @@ func (obj *sqlite3Impl) Create_Bwagreement(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) Create_Serialnumber(ctx context.Context,
+	serialnumber_serial_number Serialnumber_SerialNumber_Field,
+	serialnumber_storage_node_id Serialnumber_StorageNodeId_Field,
+	serialnumber_expires_at Serialnumber_ExpiresAt_Field) (
+	serialnumber *Serialnumber, err error) {
+
+	__serial_number_val := serialnumber_serial_number.value()
+	__storage_node_id_val := serialnumber_storage_node_id.value()
+	__expires_at_val := serialnumber_expires_at.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO serialnumbers ( serial_number, storage_node_id, expires_at ) VALUES ( ?, ?, ? )")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __serial_number_val, __storage_node_id_val, __expires_at_val)
+
+	__res, err := obj.driver.Exec(__stmt, __serial_number_val, __storage_node_id_val, __expires_at_val)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	__pk, err := __res.LastInsertId()
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return obj.getLastSerialnumber(ctx, __pk)
+
+}
+
 func (obj *sqlite3Impl) Get_Bwagreement_By_Signature(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field) (
 	bwagreement *Bwagreement, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.created_at FROM bwagreements WHERE bwagreements.signature = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.action, bwagreements.total, bwagreements.created_at FROM bwagreements WHERE bwagreements.signature = ?")
 
 	var __values []interface{}
 	__values = append(__values, bwagreement_signature.value())
@@ -848,7 +1084,7 @@ func (obj *sqlite3Impl) Get_Bwagreement_By_Signature(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	bwagreement = &Bwagreement{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.Action, &bwagreement.Total, &bwagreement.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -860,7 +1096,7 @@ func (obj *sqlite3Impl) Limited_Bwagreement(ctx context.Context,
 	limit int, offset int64) (
 	rows []*Bwagreement, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.created_at FROM bwagreements LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.action, bwagreements.total, bwagreements.created_at FROM bwagreements LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values)
@@ -878,7 +1114,7 @@ func (obj *sqlite3Impl) Limited_Bwagreement(ctx context.Context,
 
 	for __rows.Next() {
 		bwagreement := &Bwagreement{}
-		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.Action, &bwagreement.Total, &bwagreement.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -894,7 +1130,7 @@ func (obj *sqlite3Impl) Limited_Bwagreement(ctx context.Context,
 func (obj *sqlite3Impl) All_Bwagreement(ctx context.Context) (
 	rows []*Bwagreement, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.created_at FROM bwagreements")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.action, bwagreements.total, bwagreements.created_at FROM bwagreements")
 
 	var __values []interface{}
 	__values = append(__values)
@@ -910,7 +1146,7 @@ func (obj *sqlite3Impl) All_Bwagreement(ctx context.Context) (
 
 	for __rows.Next() {
 		bwagreement := &Bwagreement{}
-		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.Action, &bwagreement.Total, &bwagreement.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -923,6 +1159,28 @@ func (obj *sqlite3Impl) All_Bwagreement(ctx context.Context) (
 
 }
 
+func (obj *sqlite3Impl) Get_Serialnumber_By_SerialNumber_And_StorageNodeId(ctx context.Context,
+	serialnumber_serial_number Serialnumber_SerialNumber_Field,
+	serialnumber_storage_node_id Serialnumber_StorageNodeId_Field) (
+	serialnumber *Serialnumber, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT serialnumbers.serial_number, serialnumbers.storage_node_id, serialnumbers.expires_at FROM serialnumbers WHERE serialnumbers.serial_number = ? AND serialnumbers.storage_node_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, serialnumber_serial_number.value(), serialnumber_storage_node_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	serialnumber = &Serialnumber{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&serialnumber.SerialNumber, &serialnumber.StorageNodeId, &serialnumber.ExpiresAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return serialnumber, nil
+
+}
+
 func (obj *sqlite3Impl) Delete_Bwagreement_By_Signature(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field) (
 	deleted bool, err error) {
@@ -949,17 +1207,43 @@ func (obj *sqlite3Impl) Delete_Bwagreement_By_Signature(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) Delete_Serialnumber_By_ExpiresAt_Less(ctx context.Context,
+	serialnumber_expires_at_less Serialnumber_ExpiresAt_Field) (
+	count int64, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM serialnumbers WHERE serialnumbers.expires_at < ?")
+
+	var __values []interface{}
+	__values = append(__values, serialnumber_expires_at_less.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.Exec(__stmt, __values...)
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	return count, nil
+
+}
+
 func (obj *sqlite3Impl) getLastBwagreement(ctx context.Context,
 	pk int64) (
 	bwagreement *Bwagreement, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.created_at FROM bwagreements WHERE _rowid_ = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.action, bwagreements.total, bwagreements.created_at FROM bwagreements WHERE _rowid_ = ?")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, pk)
 
 	bwagreement = &Bwagreement{}
-	err = obj.driver.QueryRow(__stmt, pk).Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.Action, &bwagreement.Total, &bwagreement.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -967,6 +1251,24 @@ func (obj *sqlite3Impl) getLastBwagreement(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) getLastSerialnumber(ctx context.Context,
+	pk int64) (
+	serialnumber *Serialnumber, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT serialnumbers.serial_number, serialnumbers.storage_node_id, serialnumbers.expires_at FROM serialnumbers WHERE _rowid_ = ?")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, pk)
+
+	serialnumber = &Serialnumber{}
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&serialnumber.SerialNumber, &serialnumber.StorageNodeId, &serialnumber.ExpiresAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return serialnumber, nil
+
+}
+
 func (impl sqlite3Impl) isConstraintError(err error) (
 	constraint string, ok bool) {
 	if e, ok := err.(sqlite3.Error); ok {
@@ -985,6 +1287,16 @@ func (impl sqlite3Impl) isConstraintError(err error) (
 func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error) {
 	var __res sql.Result
 	var __count int64
+	__res, err = obj.driver.Exec("DELETE FROM serialnumbers;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
 	__res, err = obj.driver.Exec("DELETE FROM bwagreements;")
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -1053,13 +1365,28 @@ func (rx *Rx) All_Bwagreement(ctx context.Context) (
 
 func (rx *Rx) Create_Bwagreement(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field,
-	bwagreement_data Bwagreement_Data_Field) (
+	bwagreement_data Bwagreement_Data_Field,
+	bwagreement_action Bwagreement_Action_Field,
+	bwagreement_total Bwagreement_Total_Field) (
 	bwagreement *Bwagreement, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Create_Bwagreement(ctx, bwagreement_signature, bwagreement_data)
+	return tx.Create_Bwagreement(ctx, bwagreement_signature, bwagreement_data, bwagreement_action, bwagreement_total)
+
+}
+
+func (rx *Rx) Create_Serialnumber(ctx context.Context,
+	serialnumber_serial_number Serialnumber_SerialNumber_Field,
+	serialnumber_storage_node_id Serialnumber_StorageNodeId_Field,
+	serialnumber_expires_at Serialnumber_ExpiresAt_Field) (
+	serialnumber *Serialnumber, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Create_Serialnumber(ctx, serialnumber_serial_number, serialnumber_storage_node_id, serialnumber_expires_at)
 
 }
 
@@ -1073,6 +1400,17 @@ func (rx *Rx) Delete_Bwagreement_By_Signature(ctx context.Context,
 	return tx.Delete_Bwagreement_By_Signature(ctx, bwagreement_signature)
 }
 
+func (rx *Rx) Delete_Serialnumber_By_ExpiresAt_Less(ctx context.Context,
+	serialnumber_expires_at_less Serialnumber_ExpiresAt_Field) (
+	count int64, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Delete_Serialnumber_By_ExpiresAt_Less(ctx, serialnumber_expires_at_less)
+
+}
+
 func (rx *Rx) Get_Bwagreement_By_Signature(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field) (
 	bwagreement *Bwagreement, err error) {
@@ -1083,6 +1421,17 @@ func (rx *Rx) Get_Bwagreement_By_Signature(ctx context.Context,
 	return tx.Get_Bwagreement_By_Signature(ctx, bwagreement_signature)
 }
 
+func (rx *Rx) Get_Serialnumber_By_SerialNumber_And_StorageNodeId(ctx context.Context,
+	serialnumber_serial_number Serialnumber_SerialNumber_Field,
+	serialnumber_storage_node_id Serialnumber_StorageNodeId_Field) (
+	serialnumber *Serialnumber, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Get_Serialnumber_By_SerialNumber_And_StorageNodeId(ctx, serialnumber_serial_number, serialnumber_storage_node_id)
+}
+
 func (rx *Rx) Limited_Bwagreement(ctx context.Context,
 	limit int, offset int64) (
 	rows []*Bwagreement, err error) {
@@ -1099,17 +1448,34 @@ type Methods interface {
 
 	Create_Bwagreement(ctx context.Context,
 		bwagreement_signature Bwagreement_Signature_Field,
-		bwagreement_data Bwagreement_Data_Field) (
+		bwagreement_data Bwagreement_Data_Field,
+		bwagreement_action Bwagreement_Action_Field,
+		bwagreement_total Bwagreement_Total_Field) (
 		bwagreement *Bwagreement, err error)
 
+	Create_Serialnumber(ctx context.Context,
+		serialnumber_serial_number Serialnumber_SerialNumber_Field,
+		serialnumber_storage_node_id Serialnumber_StorageNodeId_Field,
+		serialnumber_expires_at Serialnumber_ExpiresAt_Field) (
+		serialnumber *Serialnumber, err error)
+
 	Delete_Bwagreement_By_Signature(ctx context.Context,
 		bwagreement_signature Bwagreement_Signature_Field) (
 		deleted bool, err error)
 
+	Delete_Serialnumber_By_ExpiresAt_Less(ctx context.Context,
+		serialnumber_expires_at_less Serialnumber_ExpiresAt_Field) (
+		count int64, err error)
+
 	Get_Bwagreement_By_Signature(ctx context.Context,
 		bwagreement_signature Bwagreement_Signature_Field) (
 		bwagreement *Bwagreement, err error)
 
+	Get_Serialnumber_By_SerialNumber_And_StorageNodeId(ctx context.Context,
+		serialnumber_serial_number Serialnumber_SerialNumber_Field,
+		serialnumber_storage_node_id Serialnumber_StorageNodeId_Field) (
+		serialnumber *Serialnumber, err error)
+
 	Limited_Bwagreement(ctx context.Context,
 		limit int, offset int64) (
 		rows []*Bwagreement, err error)
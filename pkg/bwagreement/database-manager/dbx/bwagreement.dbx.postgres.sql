@@ -3,6 +3,14 @@
 CREATE TABLE bwagreements (
 	signature bytea NOT NULL,
 	data bytea NOT NULL,
+	action integer NOT NULL,
+	total bigint NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( signature )
 );
+CREATE TABLE serialnumbers (
+	serial_number text NOT NULL,
+	storage_node_id bytea NOT NULL,
+	expires_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( serial_number, storage_node_id )
+);
@@ -3,6 +3,14 @@
 CREATE TABLE bwagreements (
 	signature BLOB NOT NULL,
 	data BLOB NOT NULL,
+	action INTEGER NOT NULL,
+	total INTEGER NOT NULL,
 	created_at TIMESTAMP NOT NULL,
 	PRIMARY KEY ( signature )
 );
+CREATE TABLE serialnumbers (
+	serial_number TEXT NOT NULL,
+	storage_node_id BLOB NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( serial_number, storage_node_id )
+);
@@ -0,0 +1,56 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bwagreement
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/bwagreement/database-manager"
+)
+
+// SerialPruner deletes the serial numbers of expired bandwidth allocations
+type SerialPruner struct {
+	dbm    *dbmanager.DBManager
+	ticker *time.Ticker
+}
+
+// NewSerialPruner creates a SerialPruner deleting expired serial numbers every interval
+func NewSerialPruner(dbm *dbmanager.DBManager, interval time.Duration) *SerialPruner {
+	return &SerialPruner{
+		dbm:    dbm,
+		ticker: time.NewTicker(interval),
+	}
+}
+
+// Run the serial number pruning loop
+func (pruner *SerialPruner) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		if err := pruner.Prune(ctx); err != nil {
+			zap.S().Errorf("Pruning serial numbers failed: %v", err)
+		}
+
+		select {
+		case <-pruner.ticker.C: // wait for the next interval to happen
+		case <-ctx.Done(): // or the pruner is canceled via context
+			return ctx.Err()
+		}
+	}
+}
+
+// Prune deletes the serial numbers that expired, agreements using them are rejected as expired
+func (pruner *SerialPruner) Prune(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	deleted, err := pruner.dbm.DeleteExpiredSerialNumbers(ctx, time.Now())
+	if err != nil {
+		return BwAgreementError.Wrap(err)
+	}
+	mon.IntVal("expired_serial_numbers").Observe(deleted)
+	return nil
+}
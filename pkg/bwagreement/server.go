@@ -4,23 +4,29 @@
 package bwagreement
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/x509"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/gtank/cryptopasta"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"storj.io/storj/pkg/bwagreement/database-manager"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/peertls"
+	"storj.io/storj/pkg/uplinkdb"
 )
 
 // Server is an implementation of the pb.BandwidthServer interface
 type Server struct {
 	dbm    *dbmanager.DBManager
+	keys   *uplinkdb.DB
 	pkey   crypto.PublicKey
 	logger *zap.Logger
 }
@@ -31,10 +37,11 @@ type Agreement struct {
 	Signature []byte
 }
 
-// NewServer creates instance of Server
-func NewServer(dbm *dbmanager.DBManager, logger *zap.Logger, pkey crypto.PublicKey) (*Server, error) {
+// NewServer creates instance of Server, agreements signed with uplink keys revoked in keys are rejected
+func NewServer(dbm *dbmanager.DBManager, keys *uplinkdb.DB, logger *zap.Logger, pkey crypto.PublicKey) (*Server, error) {
 	return &Server{
 		dbm:    dbm,
+		keys:   keys,
 		logger: logger,
 		pkey:   pkey,
 	}, nil
@@ -46,44 +53,92 @@ func (s *Server) BandwidthAgreements(ctx context.Context, agreement *pb.RenterBa
 
 	s.logger.Debug("Received Agreement...")
 
+	reply, err = s.store(ctx, agreement)
+	if err != nil {
+		return reply, err
+	}
+
+	s.logger.Debug("Stored Agreement...")
+
+	return reply, nil
+}
+
+// BandwidthAgreementsBatch receives and stores a batch of bandwidth agreements from storage nodes,
+// every agreement is accepted or rejected on its own
+func (s *Server) BandwidthAgreementsBatch(ctx context.Context, req *pb.AgreementsBatchRequest) (resp *pb.AgreementsBatchResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	s.logger.Debug("Received Agreements...", zap.Int("count", len(req.GetAgreements())))
+
+	resp = &pb.AgreementsBatchResponse{}
+	for _, agreement := range req.GetAgreements() {
+		reply, err := s.store(ctx, agreement)
+		if err != nil {
+			s.logger.Debug("Agreement not stored", zap.String("status", reply.GetStatus().String()), zap.Error(err))
+		}
+		resp.Summaries = append(resp.Summaries, reply)
+	}
+
+	return resp, nil
+}
+
+// store verifies and stores the agreement, the returned summary says whether a failed agreement
+// can be sent again or is rejected for good
+func (s *Server) store(ctx context.Context, agreement *pb.RenterBandwidthAllocation) (reply *pb.AgreementsSummary, err error) {
 	reply = &pb.AgreementsSummary{
 		Status: pb.AgreementsSummary_FAIL,
 	}
 
 	if err = s.verifySignature(ctx, agreement); err != nil {
+		reply.Status = pb.AgreementsSummary_REJECTED
+		reply.Reason = err.Error()
 		return reply, err
 	}
 
 	_, err = s.dbm.Create(ctx, agreement)
 	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument, codes.AlreadyExists:
+			reply.Status = pb.AgreementsSummary_REJECTED
+		}
+		reply.Reason = err.Error()
 		return reply, err
 	}
 
 	reply.Status = pb.AgreementsSummary_OK
-
-	s.logger.Debug("Stored Agreement...")
-
 	return reply, nil
 }
 
 func (s *Server) verifySignature(ctx context.Context, ba *pb.RenterBandwidthAllocation) error {
-	// TODO(security): detect replay attacks
-
-	//Deserealize RenterBandwidthAllocation.GetData() so we can get public key
 	rbad := &pb.RenterBandwidthAllocation_Data{}
 	if err := proto.Unmarshal(ba.GetData(), rbad); err != nil {
 		return BwAgreementError.New("Failed to unmarshal RenterBandwidthAllocation: %+v", err)
 	}
 
-	// Extract renter's public key from RenterBandwidthAllocation_Data
-	// TODO: Look this public key up in a database
-	pubkey, err := x509.ParsePKIXPublicKey(rbad.GetPubKey())
+	k, ok := s.pkey.(*ecdsa.PublicKey)
+	if !ok {
+		return peertls.ErrUnsupportedKey.New("%T", s.pkey)
+	}
+
+	// verify Payer's (satellite) signature
+	if ok := cryptopasta.Verify(rbad.GetPayerAllocation().GetData(), rbad.GetPayerAllocation().GetSignature(), k); !ok {
+		return BwAgreementError.New("Failed to verify Payer's Signature")
+	}
+
+	pbad := &pb.PayerBandwidthAllocation_Data{}
+	if err := proto.Unmarshal(rbad.GetPayerAllocation().GetData(), pbad); err != nil {
+		return BwAgreementError.New("Failed to unmarshal PayerBandwidthAllocation: %+v", err)
+	}
+
+	// the renter's public key is the one the satellite signed into the allocation,
+	// the public key of RenterBandwidthAllocation_Data can't be trusted
+	pubkey, err := x509.ParsePKIXPublicKey(pbad.GetUplinkPublicKey())
 	if err != nil {
-		return BwAgreementError.New("Failed to extract Public Key from RenterBandwidthAllocation: %+v", err)
+		return BwAgreementError.New("Failed to extract Uplink Public Key from PayerBandwidthAllocation: %+v", err)
 	}
 
 	// Typecast public key
-	k, ok := pubkey.(*ecdsa.PublicKey)
+	k, ok = pubkey.(*ecdsa.PublicKey)
 	if !ok {
 		return peertls.ErrUnsupportedKey.New("%T", pubkey)
 	}
@@ -93,14 +148,30 @@ func (s *Server) verifySignature(ctx context.Context, ba *pb.RenterBandwidthAllo
 		return BwAgreementError.New("Failed to verify Renter's Signature")
 	}
 
-	k, ok = s.pkey.(*ecdsa.PublicKey)
-	if !ok {
-		return peertls.ErrUnsupportedKey.New("%T", s.pkey)
+	// allocations issued before the uplink key was revoked are still paid
+	if s.keys != nil {
+		if err := s.keys.VerifyAt(pbad.GetUplinkPublicKey(), time.Unix(pbad.GetCreatedUnixSec(), 0)); err != nil {
+			return BwAgreementError.Wrap(err)
+		}
 	}
 
-	// verify Payer's (satellite) signature
-	if ok := cryptopasta.Verify(rbad.GetPayerAllocation().GetData(), rbad.GetPayerAllocation().GetSignature(), k); !ok {
-		return BwAgreementError.New("Failed to verify Payer's Signature")
+	// replays are detected by serial number, which is only stored until the allocation expires
+	if pbad.GetSerialNumber() == "" || pbad.GetExpirationUnixSec() == 0 {
+		return BwAgreementError.New("PayerBandwidthAllocation is missing a serial number or expiration")
+	}
+	if time.Unix(pbad.GetExpirationUnixSec(), 0).Before(time.Now()) {
+		return BwAgreementError.New("PayerBandwidthAllocation expired")
+	}
+
+	// every order limit is paid only to the storage node it was issued to and only up to its size
+	if len(pbad.GetStorageNodeId()) == 0 || pbad.GetMaxSize() <= 0 {
+		return BwAgreementError.New("PayerBandwidthAllocation is not an order limit for a storage node")
+	}
+	if !bytes.Equal(pbad.GetStorageNodeId(), rbad.GetStorageNodeId()) {
+		return BwAgreementError.New("PayerBandwidthAllocation was issued to another storage node")
+	}
+	if rbad.GetTotal() > pbad.GetMaxSize() {
+		return BwAgreementError.New("Total %d exceeds the order limit of %d bytes", rbad.GetTotal(), pbad.GetMaxSize())
 	}
 	return nil
 }
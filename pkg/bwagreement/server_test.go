@@ -9,6 +9,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/x509"
 	"flag"
+	"fmt"
 	"log"
 	"net"
 	"os"
@@ -22,10 +23,14 @@ import (
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"storj.io/storj/pkg/bwagreement/database-manager"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/uplinkdb"
+	"storj.io/storj/storage/teststore"
 )
 
 var (
@@ -36,7 +41,7 @@ func TestBandwidthAgreements(t *testing.T) {
 	TS := NewTestServer(t)
 	defer TS.Stop()
 
-	pba, err := generatePayerBandwidthAllocation(pb.PayerBandwidthAllocation_GET, TS.k)
+	pba, err := generatePayerBandwidthAllocation(pb.PayerBandwidthAllocation_GET, uniqueSerialNumber(), TS.k)
 	assert.NoError(t, err)
 
 	rba, err := generateRenterBandwidthAllocation(pba, TS.k)
@@ -47,12 +52,106 @@ func TestBandwidthAgreements(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestBandwidthAgreementsReplay(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	pba, err := generatePayerBandwidthAllocation(pb.PayerBandwidthAllocation_GET, uniqueSerialNumber(), TS.k)
+	assert.NoError(t, err)
+
+	rba, err := generateRenterBandwidthAllocation(pba, TS.k)
+	assert.NoError(t, err)
+
+	reply, err := TS.c.BandwidthAgreements(ctx, rba)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.AgreementsSummary_OK, reply.GetStatus())
+
+	// sending the same agreement again is accepted, it is stored only once
+	reply, err = TS.c.BandwidthAgreements(ctx, rba)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.AgreementsSummary_OK, reply.GetStatus())
+
+	// a different agreement reusing the serial number is rejected
+	replay, err := generateRenterBandwidthAllocation(pba, TS.k)
+	assert.NoError(t, err)
+	_, err = TS.c.BandwidthAgreements(ctx, replay)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestBandwidthAgreementsOrderLimit(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	for _, pbad := range []*pb.PayerBandwidthAllocation_Data{
+		{StorageNodeId: []byte("AnotherStorageNode"), MaxSize: 1000},
+		{StorageNodeId: []byte("StorageNodeID"), MaxSize: 100},
+		{MaxSize: 1000},
+	} {
+		pbad.SerialNumber = uniqueSerialNumber()
+		pbad.ExpirationUnixSec = time.Now().Add(time.Hour).Unix()
+
+		pba, err := signPayerBandwidthAllocation(pbad, TS.k)
+		assert.NoError(t, err)
+
+		rba, err := generateRenterBandwidthAllocation(pba, TS.k)
+		assert.NoError(t, err)
+
+		reply, err := TS.c.BandwidthAgreementsBatch(ctx, &pb.AgreementsBatchRequest{
+			Agreements: []*pb.RenterBandwidthAllocation{rba},
+		})
+		assert.NoError(t, err)
+		if assert.Len(t, reply.GetSummaries(), 1) {
+			assert.Equal(t, pb.AgreementsSummary_REJECTED, reply.GetSummaries()[0].GetStatus())
+		}
+	}
+}
+
+func TestBandwidthAgreementsUplinkKey(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	caU, err := provider.NewTestCA(context.Background())
+	assert.NoError(t, err)
+	fiU, err := caU.NewIdentity()
+	assert.NoError(t, err)
+
+	// the renter signature is verified with the key the satellite signed into the allocation
+	pba, err := generatePayerBandwidthAllocation(pb.PayerBandwidthAllocation_GET, uniqueSerialNumber(), TS.k)
+	assert.NoError(t, err)
+	forged, err := generateRenterBandwidthAllocation(pba, fiU.Key)
+	assert.NoError(t, err)
+	reply, err := TS.c.BandwidthAgreements(ctx, forged)
+	assert.Error(t, err)
+	assert.Equal(t, pb.AgreementsSummary_REJECTED, reply.GetStatus())
+
+	// allocations issued after the key was revoked are rejected
+	publicKey, err := uplinkdb.PublicKeyBytes(&TS.k.(*ecdsa.PrivateKey).PublicKey)
+	assert.NoError(t, err)
+	_, err = TS.keys.Register(nil, publicKey)
+	assert.NoError(t, err)
+	_, err = TS.keys.Revoke(nil, uplinkdb.KeyID(publicKey))
+	assert.NoError(t, err)
+
+	pba, err = generatePayerBandwidthAllocation(pb.PayerBandwidthAllocation_GET, uniqueSerialNumber(), TS.k)
+	assert.NoError(t, err)
+	rba, err := generateRenterBandwidthAllocation(pba, TS.k)
+	assert.NoError(t, err)
+	reply, err = TS.c.BandwidthAgreements(ctx, rba)
+	assert.Error(t, err)
+	assert.Equal(t, pb.AgreementsSummary_REJECTED, reply.GetStatus())
+}
+
+func uniqueSerialNumber() string {
+	return fmt.Sprintf("SerialNumber-%d", time.Now().UnixNano())
+}
+
 type TestServer struct {
 	s     *Server
 	grpcs *grpc.Server
 	conn  *grpc.ClientConn
 	c     pb.BandwidthClient
 	k     crypto.PrivateKey
+	keys  *uplinkdb.DB
 }
 
 func NewTestServer(t *testing.T) *TestServer {
@@ -76,12 +175,13 @@ func NewTestServer(t *testing.T) *TestServer {
 	co, err := fiC.DialOption("")
 	check(err)
 
-	s := newTestServerStruct(t, fiC.Key)
+	keys := uplinkdb.New(teststore.New())
+	s := newTestServerStruct(t, fiC.Key, keys)
 	grpcs := grpc.NewServer(so)
 
 	k, ok := fiC.Key.(*ecdsa.PrivateKey)
 	assert.True(t, ok)
-	ts := &TestServer{s: s, grpcs: grpcs, k: k}
+	ts := &TestServer{s: s, grpcs: grpcs, k: k, keys: keys}
 	addr := ts.start()
 	ts.c, ts.conn = connect(addr, co)
 
@@ -98,7 +198,7 @@ var (
 	testPostgres = flag.String("postgres-test-db", os.Getenv("STORJ_POSTGRES_TEST"), "PostgreSQL test database connection string")
 )
 
-func newTestServerStruct(t *testing.T, k crypto.PrivateKey) *Server {
+func newTestServerStruct(t *testing.T, k crypto.PrivateKey, keys *uplinkdb.DB) *Server {
 	if *testPostgres == "" {
 		t.Skipf("postgres flag missing, example:\n-postgres-test-db=%s", defaultPostgresConn)
 	}
@@ -109,7 +209,7 @@ func newTestServerStruct(t *testing.T, k crypto.PrivateKey) *Server {
 	}
 
 	p, _ := k.(*ecdsa.PrivateKey)
-	server, err := NewServer(dbm, zap.NewNop(), &p.PublicKey)
+	server, err := NewServer(dbm, keys, zap.NewNop(), &p.PublicKey)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -142,23 +242,36 @@ func connect(addr string, o ...grpc.DialOption) (pb.BandwidthClient, *grpc.Clien
 	return c, conn
 }
 
-func generatePayerBandwidthAllocation(action pb.PayerBandwidthAllocation_Action, satelliteKey crypto.PrivateKey) (*pb.PayerBandwidthAllocation, error) {
+func generatePayerBandwidthAllocation(action pb.PayerBandwidthAllocation_Action, serialNumber string, satelliteKey crypto.PrivateKey) (*pb.PayerBandwidthAllocation, error) {
+	return signPayerBandwidthAllocation(&pb.PayerBandwidthAllocation_Data{
+		SatelliteId:       []byte("SatelliteID"),
+		UplinkId:          []byte("UplinkID"),
+		ExpirationUnixSec: time.Now().Add(time.Hour * 24 * 10).Unix(),
+		SerialNumber:      serialNumber,
+		Action:            action,
+		CreatedUnixSec:    time.Now().Unix(),
+		StorageNodeId:     []byte("StorageNodeID"),
+		MaxSize:           int64(1000),
+	}, satelliteKey)
+}
+
+func signPayerBandwidthAllocation(pbad *pb.PayerBandwidthAllocation_Data, satelliteKey crypto.PrivateKey) (*pb.PayerBandwidthAllocation, error) {
 	satelliteKeyEcdsa, ok := satelliteKey.(*ecdsa.PrivateKey)
 	if !ok {
 		return nil, errs.New("Satellite Private Key is not a valid *ecdsa.PrivateKey")
 	}
 
+	// the test uplink uses the same key as the satellite
+	if pbad.UplinkPublicKey == nil {
+		pubbytes, err := x509.MarshalPKIXPublicKey(&satelliteKeyEcdsa.PublicKey)
+		if err != nil {
+			return nil, errs.New("Could not generate byte array from Uplink Public key: %+v", err)
+		}
+		pbad.UplinkPublicKey = pubbytes
+	}
+
 	// Generate PayerBandwidthAllocation_Data
-	data, _ := proto.Marshal(
-		&pb.PayerBandwidthAllocation_Data{
-			SatelliteId:       []byte("SatelliteID"),
-			UplinkId:          []byte("UplinkID"),
-			ExpirationUnixSec: time.Now().Add(time.Hour * 24 * 10).Unix(),
-			SerialNumber:      "SerialNumber",
-			Action:            action,
-			CreatedUnixSec:    time.Now().Unix(),
-		},
-	)
+	data, _ := proto.Marshal(pbad)
 
 	// Sign the PayerBandwidthAllocation_Data with the "Satellite" Private Key
 	s, err := cryptopasta.Sign(data, satelliteKeyEcdsa)
@@ -189,7 +302,7 @@ func generateRenterBandwidthAllocation(pba *pb.PayerBandwidthAllocation, uplinkK
 	data, _ := proto.Marshal(
 		&pb.RenterBandwidthAllocation_Data{
 			PayerAllocation: pba,
-			PubKey:          pubbytes, // TODO: Take this out. It will be kept in a database on the satellite
+			PubKey:          pubbytes,
 			StorageNodeId:   []byte("StorageNodeID"),
 			Total:           int64(666),
 		},
@@ -92,10 +92,20 @@ func (c *checker) identifyInjuredSegments(ctx context.Context) (err error) {
 					return Error.New("error getting offline nodes %s", err)
 				}
 				numHealthy := len(nodeIDs) - len(missingPieces)
+				// a segment with fewer healthy pieces than needed to reconstruct it can't be repaired
+				if int32(numHealthy) < pointer.Remote.Redundancy.MinReq {
+					mon.Meter("lost_segments").Mark(1)
+					c.logger.Warn("segment lost", zap.String("path", string(item.Key)),
+						zap.Int("healthy", numHealthy), zap.Int32("required", pointer.Remote.Redundancy.MinReq))
+					continue
+				}
 				if int32(numHealthy) < pointer.Remote.Redundancy.RepairThreshold {
+					// segments which are queued or being repaired already aren't added again
 					err = c.repairQueue.Enqueue(&pb.InjuredSegment{
-						Path:       string(item.Key),
-						LostPieces: missingPieces,
+						Path:         string(item.Key),
+						LostPieces:   missingPieces,
+						HealthyCount: int32(numHealthy),
+						MinReq:       pointer.Remote.Redundancy.MinReq,
 					})
 					if err != nil {
 						return Error.New("error adding injured segment to queue %s", err)
@@ -32,7 +32,7 @@ var ctx = context.Background()
 
 func TestIdentifyInjuredSegments(t *testing.T) {
 	logger := zap.NewNop()
-	pointerdb := pointerdb.NewServer(teststore.New(), &overlay.Cache{}, logger, pointerdb.Config{}, nil)
+	pointerdb := pointerdb.NewServer(teststore.New(), &overlay.Cache{}, nil, nil, logger, pointerdb.Config{}, nil)
 
 	repairQueue := queue.NewQueue(testqueue.New())
 
@@ -47,6 +47,7 @@ func TestIdentifyInjuredSegments(t *testing.T) {
 		p := &pb.Pointer{
 			Remote: &pb.RemoteSegment{
 				Redundancy: &pb.RedundancyScheme{
+					MinReq:          int32(1),
 					RepairThreshold: int32(2),
 				},
 				PieceId: strconv.Itoa(i),
@@ -74,11 +75,13 @@ func TestIdentifyInjuredSegments(t *testing.T) {
 			nodes = append(nodes, n)
 		}
 		pieces := []int32{0, 1, 2, 3}
-		//expected injured segments
-		if len(ids[:selection]) < int(p.Remote.Redundancy.RepairThreshold) {
+		//expected injured segments, lost segments aren't queued
+		if selection >= int(p.Remote.Redundancy.MinReq) && selection < int(p.Remote.Redundancy.RepairThreshold) {
 			seg := &pb.InjuredSegment{
-				Path:       p.Remote.PieceId,
-				LostPieces: pieces[selection:],
+				Path:         p.Remote.PieceId,
+				LostPieces:   pieces[selection:],
+				HealthyCount: int32(selection),
+				MinReq:       p.Remote.Redundancy.MinReq,
 			}
 			segs = append(segs, seg)
 		}
@@ -94,10 +97,16 @@ func TestIdentifyInjuredSegments(t *testing.T) {
 	//check if the expected segments were added to the queue
 	dequeued := []*pb.InjuredSegment{}
 	for i := 0; i < len(segs); i++ {
-		injSeg, err := repairQueue.Dequeue()
+		injSeg, _, err := repairQueue.Dequeue(time.Minute)
 		assert.NoError(t, err)
 		dequeued = append(dequeued, &injSeg)
 	}
+	_, _, err = repairQueue.Dequeue(time.Minute)
+	assert.Error(t, err)
+	// the segments with the fewest healthy pieces come first
+	assert.True(t, sort.SliceIsSorted(dequeued, func(i, k int) bool {
+		return dequeued[i].HealthyCount < dequeued[k].HealthyCount
+	}))
 	sort.Slice(segs, func(i, k int) bool { return segs[i].Path < segs[k].Path })
 	sort.Slice(dequeued, func(i, k int) bool { return dequeued[i].Path < dequeued[k].Path })
 
@@ -108,7 +117,7 @@ func TestIdentifyInjuredSegments(t *testing.T) {
 
 func TestOfflineNodes(t *testing.T) {
 	logger := zap.NewNop()
-	pointerdb := pointerdb.NewServer(teststore.New(), &overlay.Cache{}, logger, pointerdb.Config{}, nil)
+	pointerdb := pointerdb.NewServer(teststore.New(), &overlay.Cache{}, nil, nil, logger, pointerdb.Config{}, nil)
 
 	repairQueue := queue.NewQueue(testqueue.New())
 	const N = 50
@@ -139,7 +148,7 @@ func TestOfflineNodes(t *testing.T) {
 
 func BenchmarkIdentifyInjuredSegments(b *testing.B) {
 	logger := zap.NewNop()
-	pointerdb := pointerdb.NewServer(teststore.New(), &overlay.Cache{}, logger, pointerdb.Config{}, nil)
+	pointerdb := pointerdb.NewServer(teststore.New(), &overlay.Cache{}, nil, nil, logger, pointerdb.Config{}, nil)
 
 	addr, cleanup, err := redisserver.Start()
 	defer cleanup()
@@ -189,8 +198,9 @@ func BenchmarkIdentifyInjuredSegments(b *testing.B) {
 		//expected injured segments
 		if len(ids[:selection]) < int(p.Remote.Redundancy.RepairThreshold) {
 			seg := &pb.InjuredSegment{
-				Path:       p.Remote.PieceId,
-				LostPieces: pieces[selection:],
+				Path:         p.Remote.PieceId,
+				LostPieces:   pieces[selection:],
+				HealthyCount: int32(selection),
 			}
 			segs = append(segs, seg)
 		}
@@ -208,7 +218,7 @@ func BenchmarkIdentifyInjuredSegments(b *testing.B) {
 		//check if the expected segments were added to the queue
 		dequeued := []*pb.InjuredSegment{}
 		for i := 0; i < len(segs); i++ {
-			injSeg, err := repairQueue.Dequeue()
+			injSeg, _, err := repairQueue.Dequeue(time.Minute)
 			assert.NoError(b, err)
 			dequeued = append(dequeued, &injSeg)
 		}
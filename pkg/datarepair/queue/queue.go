@@ -4,15 +4,21 @@
 package queue
 
 import (
+	"time"
+
 	"github.com/golang/protobuf/proto"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/utils"
 	"storj.io/storj/storage"
 )
 
-// RepairQueue is the interface for the data repair queue
+// RepairQueue is the interface for the data repair queue. A dequeued segment is leased until it
+// is acknowledged after its repair or released to be repaired by another repairer.
 type RepairQueue interface {
 	Enqueue(qi *pb.InjuredSegment) error
-	Dequeue() (pb.InjuredSegment, error)
+	Dequeue(timeout time.Duration) (pb.InjuredSegment, storage.Lease, error)
+	Ack(lease storage.LeaseID) error
+	Nack(lease storage.LeaseID, delay time.Duration) error
 	Peekqueue(limit int) ([]pb.InjuredSegment, error)
 }
 
@@ -26,35 +32,59 @@ func NewQueue(client storage.Queue) *Queue {
 	return &Queue{db: client}
 }
 
-// Enqueue adds a repair segment to the queue
+// Enqueue adds a repair segment to the queue unless it is queued or being repaired already.
+// Segments with the fewest healthy pieces above the minimum are repaired first, segments with
+// fewer healthy pieces than the minimum are lost and can't be repaired.
 func (q *Queue) Enqueue(qi *pb.InjuredSegment) error {
+	if qi.GetHealthyCount() < qi.GetMinReq() {
+		return Error.New("segment %s is lost, %d of %d required pieces are healthy", qi.GetPath(), qi.GetHealthyCount(), qi.GetMinReq())
+	}
 	val, err := proto.Marshal(qi)
 	if err != nil {
 		return Error.New("error marshalling injured seg %s", err)
 	}
 
-	err = q.db.Enqueue(val)
+	urgency := int64(qi.GetHealthyCount()) - int64(qi.GetMinReq())
+	err = q.db.Enqueue(storage.Key(qi.GetPath()), val, urgency)
 	if err != nil {
 		return Error.New("error adding injured seg to queue %s", err)
 	}
 	return nil
 }
 
-// Dequeue returns the next repair segement and removes it from the queue
-func (q *Queue) Dequeue() (pb.InjuredSegment, error) {
-	val, err := q.db.Dequeue()
+// Dequeue returns the next repair segment, it is hidden from other repairers for timeout
+// unless it is acknowledged or released before. The lease counts the failed repairs of the segment.
+func (q *Queue) Dequeue(timeout time.Duration) (pb.InjuredSegment, storage.Lease, error) {
+	lease, err := q.db.Dequeue(timeout)
 	if err != nil {
-		return pb.InjuredSegment{}, Error.New("error obtaining item from repair queue %s", err)
+		return pb.InjuredSegment{}, storage.Lease{}, Error.New("error obtaining item from repair queue %s", err)
 	}
 	seg := &pb.InjuredSegment{}
-	err = proto.Unmarshal(val, seg)
+	err = proto.Unmarshal(lease.Value, seg)
 	if err != nil {
-		return pb.InjuredSegment{}, Error.New("error unmarshalling segment %s", err)
+		// a segment which can't be read would be dequeued again and again
+		return pb.InjuredSegment{}, storage.Lease{}, Error.New("error unmarshalling segment %s", utils.CombineErrors(err, q.db.Ack(lease.ID)))
+	}
+	return *seg, lease, nil
+}
+
+// Ack removes the repaired segment from the queue
+func (q *Queue) Ack(lease storage.LeaseID) error {
+	if err := q.db.Ack(lease); err != nil {
+		return Error.New("error acknowledging repair segment %s", err)
 	}
-	return *seg, nil
+	return nil
+}
+
+// Nack releases the segment after a failed repair so that it is dequeued again once delay passed
+func (q *Queue) Nack(lease storage.LeaseID, delay time.Duration) error {
+	if err := q.db.Nack(lease, delay); err != nil {
+		return Error.New("error releasing repair segment %s", err)
+	}
+	return nil
 }
 
-// Peekqueue returns upto 'limit' of the entries from the repair queue
+// Peekqueue returns upto 'limit' of the entries from the repair queue in the order they are repaired
 func (q *Queue) Peekqueue(limit int) ([]pb.InjuredSegment, error) {
 	if limit < 0 || limit > storage.LookupLimit {
 		limit = storage.LookupLimit
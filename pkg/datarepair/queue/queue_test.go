@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
@@ -27,15 +28,90 @@ func TestEnqueueDequeue(t *testing.T) {
 	err := q.Enqueue(seg)
 	assert.NoError(t, err)
 
-	s, err := q.Dequeue()
+	s, lease, err := q.Dequeue(time.Minute)
 	assert.NoError(t, err)
 	assert.True(t, proto.Equal(&s, seg))
+
+	// the segment is repaired by one repairer at a time
+	_, _, err = q.Dequeue(time.Minute)
+	assert.Error(t, err)
+
+	// a segment which isn't repaired is dequeued again
+	assert.NoError(t, q.Nack(lease.ID, 0))
+	s, lease, err = q.Dequeue(time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&s, seg))
+	assert.Equal(t, int64(1), lease.Failures)
+
+	assert.NoError(t, q.Ack(lease.ID))
+	assert.Error(t, q.Ack(lease.ID))
+	_, _, err = q.Dequeue(time.Minute)
+	assert.Error(t, err)
+}
+
+func TestPriority(t *testing.T) {
+	q := NewQueue(testqueue.New())
+	segs := []*pb.InjuredSegment{
+		{Path: "a", LostPieces: []int32{1}, HealthyCount: 6, MinReq: 4},
+		{Path: "b", LostPieces: []int32{1, 2}, HealthyCount: 5, MinReq: 4},
+		{Path: "c", LostPieces: []int32{1}, HealthyCount: 9, MinReq: 8},
+	}
+	for _, seg := range segs {
+		assert.NoError(t, q.Enqueue(seg))
+	}
+
+	// a segment found again isn't queued twice
+	again := &pb.InjuredSegment{Path: "a", LostPieces: []int32{1, 2, 3}, HealthyCount: 4, MinReq: 4}
+	assert.NoError(t, q.Enqueue(again))
+
+	// a lost segment can't be repaired
+	assert.Error(t, q.Enqueue(&pb.InjuredSegment{Path: "d", LostPieces: []int32{1, 2, 3, 4}, HealthyCount: 3, MinReq: 4}))
+
+	// the segments closest to losing data are repaired first
+	expected := []*pb.InjuredSegment{again, segs[1], segs[2]}
+	list, err := q.Peekqueue(100)
+	assert.NoError(t, err)
+	assert.Len(t, list, len(expected))
+	for i := range list {
+		assert.True(t, proto.Equal(expected[i], &list[i]))
+	}
+	for _, seg := range expected {
+		s, lease, err := q.Dequeue(time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(seg, &s))
+		assert.NoError(t, q.Ack(lease.ID))
+	}
+}
+
+func TestFailedRepairPriority(t *testing.T) {
+	q := NewQueue(testqueue.New())
+	failing := &pb.InjuredSegment{Path: "a", LostPieces: []int32{1}, HealthyCount: 4, MinReq: 4}
+	other := &pb.InjuredSegment{Path: "b", LostPieces: []int32{1}, HealthyCount: 6, MinReq: 4}
+	assert.NoError(t, q.Enqueue(failing))
+	assert.NoError(t, q.Enqueue(other))
+
+	// every failed repair lowers the priority of the segment as if it had one more healthy piece
+	for i := 0; i < 3; i++ {
+		s, lease, err := q.Dequeue(time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(failing, &s))
+		assert.NoError(t, q.Nack(lease.ID, 0))
+	}
+
+	// finding the segment again doesn't reset its priority
+	assert.NoError(t, q.Enqueue(failing))
+	list, err := q.Peekqueue(100)
+	assert.NoError(t, err)
+	if assert.Len(t, list, 2) {
+		assert.True(t, proto.Equal(other, &list[0]))
+		assert.True(t, proto.Equal(failing, &list[1]))
+	}
 }
 
 func TestDequeueEmptyQueue(t *testing.T) {
 	db := testqueue.New()
 	q := NewQueue(db)
-	s, err := q.Dequeue()
+	s, _, err := q.Dequeue(time.Minute)
 	assert.Error(t, err)
 	assert.Equal(t, pb.InjuredSegment{}, s)
 }
@@ -60,7 +136,7 @@ func TestSequential(t *testing.T) {
 		assert.True(t, proto.Equal(addSegs[i], &list[i]))
 	}
 	for i := 0; i < N; i++ {
-		dqSeg, err := q.Dequeue()
+		dqSeg, _, err := q.Dequeue(time.Minute)
 		assert.NoError(t, err)
 		assert.True(t, proto.Equal(addSegs[i], &dqSeg))
 	}
@@ -94,7 +170,7 @@ func TestParallel(t *testing.T) {
 	for i := 0; i < N; i++ {
 		go func(i int) {
 			defer wg.Done()
-			segment, err := queue.Dequeue()
+			segment, _, err := queue.Dequeue(time.Minute)
 			if err != nil {
 				errs <- err
 			}
@@ -151,7 +227,7 @@ func benchmarkSequential(b *testing.B, q RepairQueue) {
 			addSegs = append(addSegs, seg)
 		}
 		for i := 0; i < N; i++ {
-			dqSeg, err := q.Dequeue()
+			dqSeg, _, err := q.Dequeue(time.Minute)
 			assert.NoError(b, err)
 			assert.True(b, proto.Equal(addSegs[i], &dqSeg))
 		}
@@ -202,7 +278,7 @@ func benchmarkParallel(b *testing.B, q RepairQueue) {
 		for i := 0; i < N; i++ {
 			go func(i int) {
 				defer wg.Done()
-				segment, err := q.Dequeue()
+				segment, _, err := q.Dequeue(time.Minute)
 				if err != nil {
 					errs <- err
 				}
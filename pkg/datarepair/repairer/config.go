@@ -25,6 +25,9 @@ type Config struct {
 	QueueAddress string        `help:"data repair queue address" default:"redis://127.0.0.1:6378?db=1&password=abc123"`
 	MaxRepair    int           `help:"maximum segments that can be repaired concurrently" default:"100"`
 	Interval     time.Duration `help:"how frequently checker should audit segments" default:"3600s"`
+	LeaseTimeout time.Duration `help:"how long a segment is hidden from other repairers before its repair is given up" default:"1h"`
+	RetryDelay   time.Duration `help:"how long a segment waits after a failed repair, doubled with every further failure" default:"10m"`
+	MaxAttempts  int           `help:"how often the repair of a segment is attempted before it is dropped from the queue" default:"5"`
 	miniogw.ClientConfig
 	miniogw.RSConfig
 }
@@ -43,7 +46,7 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 		return Error.Wrap(err)
 	}
 
-	repairer := newRepairer(queue, ss, c.Interval, c.MaxRepair)
+	repairer := newRepairer(queue, ss, c.Interval, c.MaxRepair, c.LeaseTimeout, c.RetryDelay, c.MaxAttempts)
 
 	ctx, cancel := context.WithCancel(ctx)
 
@@ -27,14 +27,26 @@ type repairer struct {
 	store   segment.Store
 	limiter *sync2.Limiter
 	ticker  *time.Ticker
+	// leaseTimeout is how long a segment is hidden from other repairers while it is repaired
+	leaseTimeout time.Duration
+	// retryDelay is how long a segment waits after a failed repair, it doubles with every further failure
+	retryDelay time.Duration
+	// maxAttempts is how often the repair of a segment is attempted before it is dropped from the queue
+	maxAttempts int
 }
 
-func newRepairer(queue queue.RepairQueue, ss segment.Store, interval time.Duration, concurrency int) *repairer {
+// maxRetryDelay limits how long a segment waits for another repair attempt
+const maxRetryDelay = 24 * time.Hour
+
+func newRepairer(queue queue.RepairQueue, ss segment.Store, interval time.Duration, concurrency int, leaseTimeout time.Duration, retryDelay time.Duration, maxAttempts int) *repairer {
 	return &repairer{
-		queue:   queue,
-		store:   ss,
-		limiter: sync2.NewLimiter(concurrency),
-		ticker:  time.NewTicker(interval),
+		queue:        queue,
+		store:        ss,
+		limiter:      sync2.NewLimiter(concurrency),
+		ticker:       time.NewTicker(interval),
+		leaseTimeout: leaseTimeout,
+		retryDelay:   retryDelay,
+		maxAttempts:  maxAttempts,
 	}
 }
 
@@ -61,18 +73,50 @@ func (r *repairer) Run(ctx context.Context) (err error) {
 
 // process picks an item from repair queue and spawns a repairer
 func (r *repairer) process(ctx context.Context) error {
-	seg, err := r.queue.Dequeue()
+	seg, lease, err := r.queue.Dequeue(r.leaseTimeout)
 	if err != nil {
 		// TODO: only log when err != ErrQueueEmpty
 		return err
 	}
 
-	r.limiter.Go(ctx, func() {
+	started := r.limiter.Go(ctx, func() {
 		err := r.store.Repair(ctx, seg.GetPath(), seg.GetLostPieces())
-		if err != nil {
-			zap.L().Error("Repair failed", zap.Error(err))
+		if err == nil {
+			if err := r.queue.Ack(lease.ID); err != nil {
+				zap.L().Error("acknowledging segment failed", zap.Error(err))
+			}
+			return
+		}
+
+		attempts := lease.Failures + 1
+		zap.L().Error("Repair failed", zap.String("path", seg.GetPath()), zap.Int64("attempts", attempts), zap.Error(err))
+		if attempts >= int64(r.maxAttempts) {
+			// the segment is queued again once the checker finds it injured again
+			if err := r.queue.Ack(lease.ID); err != nil {
+				zap.L().Error("dropping segment failed", zap.Error(err))
+			}
+			return
+		}
+		// the segment waits for another attempt, so that it doesn't keep other segments from being repaired
+		if err := r.queue.Nack(lease.ID, r.backoff(attempts)); err != nil {
+			zap.L().Error("releasing segment failed", zap.Error(err))
 		}
 	})
+	if !started {
+		return r.queue.Nack(lease.ID, 0)
+	}
 
 	return nil
 }
+
+// backoff returns how long a segment waits after its repair failed attempts times
+func (r *repairer) backoff(attempts int64) time.Duration {
+	delay := r.retryDelay
+	for i := int64(1); i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
@@ -2,3 +2,102 @@
 // See LICENSE for copying information.
 
 package repairer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/datarepair/queue"
+	"storj.io/storj/pkg/pb"
+	segment "storj.io/storj/pkg/storage/segments"
+	"storj.io/storj/storage/testqueue"
+)
+
+func TestProcess(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := segment.NewMockStore(ctrl)
+	q := queue.NewQueue(testqueue.New())
+	r := newRepairer(q, store, time.Hour, 1, time.Hour, 0, 3)
+	defer r.ticker.Stop()
+
+	seg := &pb.InjuredSegment{Path: "l/bucket/object", LostPieces: []int32{1}}
+	require.NoError(t, q.Enqueue(seg))
+
+	// a failed repair releases the segment for another attempt
+	store.EXPECT().Repair(gomock.Any(), seg.Path, seg.LostPieces).Return(errors.New("repair failed"))
+	require.NoError(t, r.process(ctx))
+	r.limiter.Wait()
+
+	list, err := q.Peekqueue(10)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	// a repaired segment is removed from the queue
+	store.EXPECT().Repair(gomock.Any(), seg.Path, seg.LostPieces).Return(nil)
+	require.NoError(t, r.process(ctx))
+	r.limiter.Wait()
+
+	list, err = q.Peekqueue(10)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+	_, _, err = q.Dequeue(time.Hour)
+	assert.Error(t, err)
+}
+
+func TestProcessFailing(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := segment.NewMockStore(ctrl)
+	q := queue.NewQueue(testqueue.New())
+	r := newRepairer(q, store, time.Hour, 1, time.Hour, time.Hour, 3)
+	defer r.ticker.Stop()
+
+	failing := &pb.InjuredSegment{Path: "l/bucket/failing", LostPieces: []int32{1}}
+	for _, seg := range []*pb.InjuredSegment{
+		failing,
+		{Path: "l/bucket/first", LostPieces: []int32{1}},
+		{Path: "l/bucket/second", LostPieces: []int32{2}},
+	} {
+		require.NoError(t, q.Enqueue(seg))
+	}
+
+	// a segment whose repair always fails waits for its next attempt while the others are repaired
+	store.EXPECT().Repair(gomock.Any(), failing.Path, failing.LostPieces).Return(errors.New("repair failed"))
+	store.EXPECT().Repair(gomock.Any(), "l/bucket/first", []int32{1}).Return(nil)
+	store.EXPECT().Repair(gomock.Any(), "l/bucket/second", []int32{2}).Return(nil)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, r.process(ctx))
+		r.limiter.Wait()
+	}
+	assert.Error(t, r.process(ctx))
+
+	// the segment is dropped after the last attempt
+	r = newRepairer(q, store, time.Hour, 1, time.Hour, 0, 2)
+	defer r.ticker.Stop()
+	require.NoError(t, q.Enqueue(&pb.InjuredSegment{Path: "l/bucket/dropped", LostPieces: []int32{1}}))
+	store.EXPECT().Repair(gomock.Any(), "l/bucket/dropped", []int32{1}).Return(errors.New("repair failed")).Times(2)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, r.process(ctx))
+		r.limiter.Wait()
+	}
+	assert.Error(t, r.process(ctx))
+}
+
+func TestBackoff(t *testing.T) {
+	r := &repairer{retryDelay: time.Minute}
+	assert.Equal(t, time.Minute, r.backoff(1))
+	assert.Equal(t, 2*time.Minute, r.backoff(2))
+	assert.Equal(t, 4*time.Minute, r.backoff(3))
+	assert.Equal(t, maxRetryDelay, r.backoff(100))
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gc
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/transport"
+)
+
+// Config contains configurable values for garbage collection
+type Config struct {
+	SatelliteAddr     string        `help:"address to contact services on the satellite"`
+	Interval          time.Duration `help:"how frequently garbage collection filters are sent to storage nodes" default:"24h"`
+	InitialPieces     int           `help:"expected number of pieces per storage node, used for sizing the filters" default:"400000"`
+	FalsePositiveRate float64       `help:"false positive rate of the filters" default:"0.1"`
+}
+
+// Run runs garbage collection with the configured values
+func (c Config) Run(ctx context.Context, server *provider.Provider) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pointerdb := pointerdb.LoadFromContext(ctx)
+	if pointerdb == nil {
+		return Error.New("pointerdb not found in context")
+	}
+
+	identity := server.Identity()
+	overlay, err := overlay.NewOverlayClient(identity, c.SatelliteAddr)
+	if err != nil {
+		return err
+	}
+
+	service := NewService(pointerdb, overlay, transport.NewClient(identity), zap.L(), c)
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		if err := service.Run(ctx); err != nil {
+			defer cancel()
+			zap.L().Error("Error running garbage collection", zap.Error(err))
+		}
+	}()
+
+	return server.Run(ctx)
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gc
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/bloomfilter"
+	"storj.io/storj/pkg/node"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/utils"
+	"storj.io/storj/storage"
+)
+
+// Service periodically sends storage nodes a bloom filter of the pieces they should keep.
+// Storage nodes delete the pieces that aren't in the filter.
+type Service struct {
+	pointerdb *pointerdb.Server
+	overlay   overlay.Client
+	transport transport.Client
+	logger    *zap.Logger
+	config    Config
+	ticker    *time.Ticker
+}
+
+// NewService creates a new garbage collection service
+func NewService(pointerdb *pointerdb.Server, overlay overlay.Client, transport transport.Client, logger *zap.Logger, config Config) *Service {
+	return &Service{
+		pointerdb: pointerdb,
+		overlay:   overlay,
+		transport: transport,
+		logger:    logger,
+		config:    config,
+		ticker:    time.NewTicker(config.Interval),
+	}
+}
+
+// Run the garbage collection loop
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		err = service.collect(ctx)
+		if err != nil {
+			service.logger.Error("Garbage collection failed", zap.Error(err))
+		}
+
+		select {
+		case <-service.ticker.C: // wait for the next interval to happen
+		case <-ctx.Done(): // or the service is canceled via context
+			return ctx.Err()
+		}
+	}
+}
+
+// collect builds the filters and sends them to the storage nodes
+func (service *Service) collect(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// pieces uploaded after this are not guaranteed to be in the filters
+	createdBefore := time.Now()
+
+	filters, err := service.buildFilters(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for nodeID, filter := range filters {
+		if err := service.send(ctx, nodeID, filter, createdBefore); err != nil {
+			errs = append(errs, Error.New("failed sending filter to %s: %v", nodeID, err))
+		}
+	}
+
+	return utils.CombineErrors(errs...)
+}
+
+// buildFilters walks pointerdb and creates a filter of the derived piece ids for every storage node.
+// Storage nodes without any pieces in pointerdb don't get a filter.
+func (service *Service) buildFilters(ctx context.Context) (filters map[string]*bloomfilter.Filter, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	filters = make(map[string]*bloomfilter.Filter)
+
+	err = service.pointerdb.Iterate(ctx, &pb.IterateRequest{Recurse: true},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				pointer := &pb.Pointer{}
+				if err := proto.Unmarshal(item.Value, pointer); err != nil {
+					return Error.New("error unmarshalling pointer %s", err)
+				}
+
+				remote := pointer.GetRemote()
+				if remote == nil {
+					continue
+				}
+
+				pieceID := psclient.PieceID(remote.GetPieceId())
+				for _, piece := range remote.GetRemotePieces() {
+					derivedID, err := pieceID.Derive([]byte(piece.GetNodeId()))
+					if err != nil {
+						return Error.Wrap(err)
+					}
+
+					filter, ok := filters[piece.GetNodeId()]
+					if !ok {
+						filter = bloomfilter.NewOptimal(service.config.InitialPieces, service.config.FalsePositiveRate)
+						filters[piece.GetNodeId()] = filter
+					}
+					filter.Add([]byte(derivedID.String()))
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return filters, nil
+}
+
+// send sends the filter to the storage node
+func (service *Service) send(ctx context.Context, nodeID string, filter *bloomfilter.Filter, createdBefore time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	target, err := service.overlay.Lookup(ctx, node.IDFromString(nodeID))
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return Error.New("node not found")
+	}
+
+	ps, err := psclient.NewPSClient(ctx, service.transport, target, 0)
+	if err != nil {
+		return err
+	}
+	defer func() { err = utils.CombineErrors(err, ps.Close()) }()
+
+	deleted, err := ps.Retain(ctx, filter, createdBefore)
+	if err != nil {
+		return err
+	}
+
+	service.logger.Debug("garbage collected pieces", zap.String("node", nodeID), zap.Int64("deleted", deleted))
+	return nil
+}
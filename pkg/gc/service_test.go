@@ -0,0 +1,82 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gc
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/storage/teststore"
+)
+
+var ctx = context.Background()
+
+func TestBuildFilters(t *testing.T) {
+	logger := zap.NewNop()
+	pointers := pointerdb.NewServer(teststore.New(), &overlay.Cache{}, nil, nil, logger, pointerdb.Config{MaxInlineSegmentSize: 8000}, nil)
+	ctx := auth.WithAPIKey(ctx, nil)
+
+	const N = 20
+	nodeIDs := []string{"node-a", "node-b", "node-c"}
+	expected := map[string][]psclient.PieceID{}
+
+	for i := 0; i < N; i++ {
+		pieceID := psclient.NewPieceID()
+
+		// every segment is stored on two of the nodes
+		var pieces []*pb.RemotePiece
+		for j, nodeID := range []string{nodeIDs[i%3], nodeIDs[(i+1)%3]} {
+			pieces = append(pieces, &pb.RemotePiece{PieceNum: int32(j), NodeId: nodeID})
+
+			derivedID, err := pieceID.Derive([]byte(nodeID))
+			assert.NoError(t, err)
+			expected[nodeID] = append(expected[nodeID], derivedID)
+		}
+
+		_, err := pointers.Put(ctx, &pb.PutRequest{
+			Path: "remote/" + strconv.Itoa(i),
+			Pointer: &pb.Pointer{
+				Type: pb.Pointer_REMOTE,
+				Remote: &pb.RemoteSegment{
+					PieceId:      pieceID.String(),
+					RemotePieces: pieces,
+				},
+			},
+		})
+		assert.NoError(t, err)
+	}
+
+	_, err := pointers.Put(ctx, &pb.PutRequest{
+		Path:    "inline",
+		Pointer: &pb.Pointer{Type: pb.Pointer_INLINE, InlineSegment: []byte("butts")},
+	})
+	assert.NoError(t, err)
+
+	service := NewService(pointers, nil, nil, logger, Config{InitialPieces: N, FalsePositiveRate: 0.01, Interval: time.Hour})
+	filters, err := service.buildFilters(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Len(t, filters, len(nodeIDs))
+	for nodeID, pieceIDs := range expected {
+		filter := filters[nodeID]
+		if !assert.NotNil(t, filter, nodeID) {
+			continue
+		}
+		for _, id := range pieceIDs {
+			assert.True(t, filter.Contains([]byte(id.String())), nodeID)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gracefulexit
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/kvstore"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/utils"
+)
+
+// BoltExitBucket is the bucket used for graceful exit progress in BoltDB or PostgreSQL
+const BoltExitBucket = "gracefulexit"
+
+// Config contains configurable values for graceful exit
+type Config struct {
+	DatabaseURL   string `help:"the database connection string to use" default:"bolt://$CONFDIR/gracefulexit.db"`
+	SatelliteAddr string `help:"address to contact services on the satellite"`
+	TransferLimit int    `help:"maximum number of transfer orders sent to a storage node at once" default:"100"`
+	MaxFailures   int    `help:"number of failed transfers of a piece before leaving it to repair" default:"3"`
+}
+
+// Run implements the provider.Responsibility interface
+func (c Config) Run(ctx context.Context, server *provider.Provider) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pointerdb := pointerdb.LoadFromContext(ctx)
+	if pointerdb == nil {
+		return Error.New("pointerdb not found in context")
+	}
+
+	db, err := kvstore.Open(c.DatabaseURL, BoltExitBucket)
+	if err != nil {
+		return err
+	}
+	defer func() { err = utils.CombineErrors(err, db.Close()) }()
+
+	identity := server.Identity()
+	overlay, err := overlay.NewOverlayClient(identity, c.SatelliteAddr)
+	if err != nil {
+		return err
+	}
+
+	endpoint := NewEndpoint(db, pointerdb, overlay, transport.NewClient(identity), zap.L(), c)
+	pb.RegisterGracefulExitServer(server.GRPC(), endpoint)
+
+	return server.Run(ctx)
+}
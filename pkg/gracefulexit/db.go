@@ -0,0 +1,102 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gracefulexit
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/storage"
+)
+
+// exitDB keeps the exit progress and pending transfers of exiting storage nodes
+type exitDB struct {
+	db storage.KeyValueStore
+}
+
+func progressKey(nodeID string) storage.Key {
+	return storage.Key("exits/" + nodeID)
+}
+
+func transfersPrefix(nodeID string) storage.Key {
+	return storage.Key("transfers/" + nodeID + "/")
+}
+
+func transferKey(nodeID string, pieceNum int32, path string) storage.Key {
+	return storage.Key(fmt.Sprintf("transfers/%s/%d/%s", nodeID, pieceNum, path))
+}
+
+// getProgress returns the exit progress of the node or nil when the node isn't exiting
+func (db *exitDB) getProgress(nodeID string) (*pb.ExitProgress, error) {
+	value, err := db.db.Get(progressKey(nodeID))
+	if storage.ErrKeyNotFound.Has(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &pb.ExitProgress{}
+	if err := proto.Unmarshal(value, progress); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+// putProgress stores the exit progress of the node
+func (db *exitDB) putProgress(progress *pb.ExitProgress) error {
+	value, err := proto.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	return db.db.Put(progressKey(progress.GetNodeId()), value)
+}
+
+// putTransfer stores the pending transfer of the exiting node
+func (db *exitDB) putTransfer(nodeID string, transfer *pb.PendingTransfer) error {
+	value, err := proto.Marshal(transfer)
+	if err != nil {
+		return err
+	}
+	order := transfer.GetOrder()
+	return db.db.Put(transferKey(nodeID, order.GetPieceNum(), order.GetPath()), value)
+}
+
+// getTransfer returns the pending transfer of the exiting node
+func (db *exitDB) getTransfer(nodeID string, pieceNum int32, path string) (*pb.PendingTransfer, error) {
+	value, err := db.db.Get(transferKey(nodeID, pieceNum, path))
+	if err != nil {
+		return nil, err
+	}
+
+	transfer := &pb.PendingTransfer{}
+	if err := proto.Unmarshal(value, transfer); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// listTransfers returns at most limit pending transfers of the exiting node
+func (db *exitDB) listTransfers(nodeID string, limit int) (transfers []*pb.PendingTransfer, err error) {
+	err = db.db.Iterate(storage.IterateOptions{Prefix: transfersPrefix(nodeID), Recurse: true},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for len(transfers) < limit && it.Next(&item) {
+				transfer := &pb.PendingTransfer{}
+				if err := proto.Unmarshal(item.Value, transfer); err != nil {
+					return err
+				}
+				transfers = append(transfers, transfer)
+			}
+			return nil
+		})
+	return transfers, err
+}
+
+// deleteTransfer removes the pending transfer of the exiting node
+func (db *exitDB) deleteTransfer(nodeID string, pieceNum int32, path string) error {
+	return db.db.Delete(transferKey(nodeID, pieceNum, path))
+}
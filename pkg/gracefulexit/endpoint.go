@@ -0,0 +1,454 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gracefulexit
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"math/big"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/dht"
+	"storj.io/storj/pkg/merkle"
+	"storj.io/storj/pkg/node"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/utils"
+	"storj.io/storj/storage"
+)
+
+// maxSwapAttempts is how often swapping a piece is attempted when the pointer changes concurrently
+const maxSwapAttempts = 3
+
+// Endpoint implements the graceful exit RPC service
+type Endpoint struct {
+	db        *exitDB
+	pointerdb *pointerdb.Server
+	overlay   overlay.Client
+	transport transport.Client
+	logger    *zap.Logger
+	config    Config
+}
+
+// NewEndpoint creates a graceful exit endpoint, db is used for keeping the exit progress
+func NewEndpoint(db storage.KeyValueStore, pointerdb *pointerdb.Server, overlay overlay.Client, transport transport.Client, logger *zap.Logger, config Config) *Endpoint {
+	return &Endpoint{
+		db:        &exitDB{db: db},
+		pointerdb: pointerdb,
+		overlay:   overlay,
+		transport: transport,
+		logger:    logger,
+		config:    config,
+	}
+}
+
+// Initiate marks the calling storage node as exiting and queues a transfer for each of its pieces
+func (endpoint *Endpoint) Initiate(ctx context.Context, req *pb.InitiateExitRequest) (_ *pb.ExitProgress, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodeID, err := peerNodeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := endpoint.db.getProgress(nodeID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if progress != nil {
+		// the exit was already initiated
+		return progress, nil
+	}
+
+	endpoint.logger.Info("storage node is exiting", zap.String("node", nodeID))
+
+	var transfers []*pb.PendingTransfer
+	err = endpoint.pointerdb.Iterate(ctx, &pb.IterateRequest{Recurse: true},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				pointer := &pb.Pointer{}
+				if err := proto.Unmarshal(item.Value, pointer); err != nil {
+					return err
+				}
+				for _, piece := range pointer.GetRemote().GetRemotePieces() {
+					if piece.GetNodeId() != nodeID {
+						continue
+					}
+					transfers = append(transfers, &pb.PendingTransfer{
+						Order: &pb.TransferOrder{Path: string(item.Key), PieceNum: piece.GetPieceNum()},
+					})
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	for _, transfer := range transfers {
+		if err := endpoint.db.putTransfer(nodeID, transfer); err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+
+	progress = &pb.ExitProgress{
+		NodeId:         nodeID,
+		StartedUnixSec: time.Now().Unix(),
+		TransfersTotal: int64(len(transfers)),
+	}
+	if len(transfers) == 0 {
+		progress.FinishedUnixSec = progress.StartedUnixSec
+	}
+
+	if err := endpoint.db.putProgress(progress); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return progress, nil
+}
+
+// Progress returns the exit progress of the calling storage node
+func (endpoint *Endpoint) Progress(ctx context.Context, req *pb.ExitProgressRequest) (_ *pb.ExitProgress, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodeID, err := peerNodeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := endpoint.db.getProgress(nodeID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if progress == nil {
+		return nil, Error.New("node %s is not exiting", nodeID)
+	}
+	return progress, nil
+}
+
+// GetTransfers returns transfer orders for the pending transfers of the calling storage node
+func (endpoint *Endpoint) GetTransfers(ctx context.Context, req *pb.GetTransfersRequest) (_ *pb.GetTransfersResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodeID, err := peerNodeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > endpoint.config.TransferLimit {
+		limit = endpoint.config.TransferLimit
+	}
+
+	transfers, err := endpoint.db.listTransfers(nodeID, limit)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	authorization, err := endpoint.pointerdb.SignedMessage()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	orders := []*pb.TransferOrder{}
+	for _, transfer := range transfers {
+		order, err := endpoint.prepareOrder(ctx, nodeID, transfer)
+		if err != nil {
+			// the other transfers shouldn't wait for this one, it is ordered again with the next request
+			endpoint.logger.Warn("failed to prepare transfer", zap.String("node", nodeID), zap.String("path", transfer.GetOrder().GetPath()), zap.Error(err))
+			continue
+		}
+		if order == nil {
+			continue
+		}
+
+		// the exiting node uploads the piece on behalf of the satellite
+		allocation, err := endpoint.pointerdb.NewOrderLimit(ctx, pb.PayerBandwidthAllocation_PUT, order.GetTarget().GetId(), order.GetTargetPieceId(), 0)
+		if err != nil {
+			endpoint.logger.Warn("failed to create order limit", zap.String("node", nodeID), zap.String("path", order.GetPath()), zap.Error(err))
+			continue
+		}
+		order.BandwidthAllocation = allocation
+		order.Authorization = authorization
+
+		orders = append(orders, order)
+	}
+
+	return &pb.GetTransfersResponse{Orders: orders}, nil
+}
+
+// prepareOrder fills in the piece ids and selects the target node for the transfer.
+// It returns nil when the piece is no longer stored on the exiting node or can't be transferred.
+func (endpoint *Endpoint) prepareOrder(ctx context.Context, nodeID string, transfer *pb.PendingTransfer) (_ *pb.TransferOrder, err error) {
+	order := transfer.GetOrder()
+
+	_, pointer, piece, err := endpoint.getPiece(order.GetPath(), order.GetPieceNum(), nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if piece == nil {
+		// the segment was deleted or repaired in the meantime
+		return nil, endpoint.finishTransfer(nodeID, order, true)
+	}
+	if pieceRoot(pointer, order.GetPieceNum()) == nil {
+		// without the Merkle root of the piece the transfer can't be verified, leave the piece to repair
+		endpoint.logger.Info("piece can't be transferred", zap.String("node", nodeID), zap.String("path", order.GetPath()))
+		return nil, endpoint.finishTransfer(nodeID, order, false)
+	}
+
+	if order.GetTarget() != nil {
+		return order, nil
+	}
+
+	remote := pointer.GetRemote()
+	excluded := []dht.NodeID{}
+	for _, piece := range remote.GetRemotePieces() {
+		excluded = append(excluded, node.IDFromString(piece.GetNodeId()))
+	}
+
+	var pieceSize int64
+	if minReq := remote.GetRedundancy().GetMinReq(); minReq > 0 {
+		pieceSize = pointer.GetSize() / int64(minReq)
+	}
+
+	targets, err := endpoint.overlay.Choose(ctx, overlay.Options{Amount: 1, Space: pieceSize, Excluded: excluded})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if len(targets) == 0 {
+		return nil, Error.New("no storage node available for transfer")
+	}
+	target := targets[0]
+
+	pieceID := psclient.PieceID(remote.GetPieceId())
+	derivedID, err := pieceID.Derive([]byte(nodeID))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	targetID, err := pieceID.Derive([]byte(target.GetId()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	order.PieceId = derivedID.String()
+	order.Target = target
+	order.TargetPieceId = targetID.String()
+	if expiration := pointer.GetExpirationDate().GetSeconds(); expiration > 0 {
+		order.ExpirationUnixSec = expiration
+	}
+
+	// remember the target, so the transfer can be verified on completion
+	if err := endpoint.db.putTransfer(nodeID, transfer); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return order, nil
+}
+
+// CompleteTransfer verifies that the piece was transferred and swaps the piece in the pointer
+func (endpoint *Endpoint) CompleteTransfer(ctx context.Context, req *pb.CompleteTransferRequest) (_ *pb.CompleteTransferResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodeID, err := peerNodeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := endpoint.db.getTransfer(nodeID, req.GetPieceNum(), req.GetPath())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	order := transfer.GetOrder()
+	if order.GetTarget() == nil {
+		return nil, Error.New("transfer of %s was not ordered", req.GetPath())
+	}
+
+	if !req.GetFailed() {
+		err = endpoint.verifyTransfer(ctx, order, nodeID)
+		if err == nil {
+			err = endpoint.swapPiece(order, nodeID)
+		}
+		if err == nil {
+			return &pb.CompleteTransferResponse{}, Error.Wrap(endpoint.finishTransfer(nodeID, order, true))
+		}
+		endpoint.logger.Warn("failed to verify transfer", zap.String("node", nodeID), zap.String("path", order.GetPath()), zap.Error(err))
+	} else {
+		endpoint.logger.Warn("transfer failed", zap.String("node", nodeID), zap.String("path", order.GetPath()), zap.String("error", req.GetError()))
+	}
+
+	transfer.Failures++
+	if int(transfer.Failures) >= endpoint.config.MaxFailures {
+		// leave the piece to repair
+		return &pb.CompleteTransferResponse{}, Error.Wrap(endpoint.finishTransfer(nodeID, order, false))
+	}
+
+	// retry with a different target
+	transfer.Order = &pb.TransferOrder{Path: order.GetPath(), PieceNum: order.GetPieceNum()}
+	return &pb.CompleteTransferResponse{}, Error.Wrap(endpoint.db.putTransfer(nodeID, transfer))
+}
+
+// verifyTransfer checks that the target node stores the piece, by verifying the Merkle proof of a random
+// share of the piece against the piece root in the pointer. Nothing reported by the exiting node is trusted.
+func (endpoint *Endpoint) verifyTransfer(ctx context.Context, order *pb.TransferOrder, nodeID string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, pointer, piece, err := endpoint.getPiece(order.GetPath(), order.GetPieceNum(), nodeID)
+	if err != nil {
+		return err
+	}
+	if piece == nil {
+		return Error.New("piece %d of %s is no longer stored on %s", order.GetPieceNum(), order.GetPath(), nodeID)
+	}
+	root := pieceRoot(pointer, order.GetPieceNum())
+	if root == nil {
+		return Error.New("piece %d of %s has no Merkle root", order.GetPieceNum(), order.GetPath())
+	}
+
+	redundancy := pointer.GetRemote().GetRedundancy()
+	shareSize := int(redundancy.GetErasureShareSize())
+	stripeSize := int64(shareSize) * int64(redundancy.GetMinReq())
+	if stripeSize <= 0 {
+		return Error.New("invalid redundancy of %s", order.GetPath())
+	}
+	// every piece has one share of every stripe of the padded segment
+	shareCount := (pointer.GetSize() + stripeSize - 1) / stripeSize
+	if shareCount <= 0 {
+		return Error.New("segment %s is empty", order.GetPath())
+	}
+	index, err := rand.Int(rand.Reader, big.NewInt(shareCount))
+	if err != nil {
+		return err
+	}
+
+	allocation, err := endpoint.pointerdb.NewSatelliteOrderLimit(pb.PayerBandwidthAllocation_GET_AUDIT, order.GetTarget().GetId(), order.GetTargetPieceId(), int64(shareSize))
+	if err != nil {
+		return err
+	}
+	authorization, err := endpoint.pointerdb.SignedMessage()
+	if err != nil {
+		return err
+	}
+
+	ps, err := psclient.NewPSClient(ctx, endpoint.transport, order.GetTarget(), 0)
+	if err != nil {
+		return err
+	}
+	defer func() { err = utils.CombineErrors(err, ps.Close()) }()
+
+	share, proof, err := ps.Prove(ctx, psclient.PieceID(order.GetTargetPieceId()), shareSize, index.Int64(), allocation, authorization)
+	if err != nil {
+		return err
+	}
+	if len(share) != shareSize || !merkle.Verify(root, merkle.Leaf(share), int(index.Int64()), int(shareCount), proof) {
+		return Error.New("invalid proof for piece %d of %s", order.GetPieceNum(), order.GetPath())
+	}
+	return nil
+}
+
+// swapPiece replaces the piece of the exiting node with the transferred piece. The pointer is swapped
+// only if it didn't change since it was read, otherwise the swap is retried with the changed pointer.
+func (endpoint *Endpoint) swapPiece(order *pb.TransferOrder, nodeID string) error {
+	for attempt := 0; ; attempt++ {
+		value, pointer, piece, err := endpoint.getPiece(order.GetPath(), order.GetPieceNum(), nodeID)
+		if err != nil {
+			return err
+		}
+		if piece == nil {
+			return Error.New("piece %d of %s is no longer stored on %s", order.GetPieceNum(), order.GetPath(), nodeID)
+		}
+
+		piece.NodeId = order.GetTarget().GetId()
+
+		swapped, err := proto.Marshal(pointer)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		err = endpoint.pointerdb.DB.CompareAndSwap(storage.Key(order.GetPath()), value, swapped)
+		if !storage.ErrValueChanged.Has(err) || attempt+1 >= maxSwapAttempts {
+			return Error.Wrap(err)
+		}
+	}
+}
+
+// getPiece returns the pointer at path, as stored and decoded, and its piece stored on the node.
+// piece is nil when the node doesn't store the piece anymore.
+func (endpoint *Endpoint) getPiece(path string, pieceNum int32, nodeID string) (value storage.Value, pointer *pb.Pointer, piece *pb.RemotePiece, err error) {
+	value, err = endpoint.pointerdb.DB.Get(storage.Key(path))
+	if storage.ErrKeyNotFound.Has(err) {
+		return nil, nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, nil, Error.Wrap(err)
+	}
+
+	pointer = &pb.Pointer{}
+	if err := proto.Unmarshal(value, pointer); err != nil {
+		return nil, nil, nil, Error.Wrap(err)
+	}
+
+	for _, piece := range pointer.GetRemote().GetRemotePieces() {
+		if piece.GetPieceNum() == pieceNum && piece.GetNodeId() == nodeID {
+			return value, pointer, piece, nil
+		}
+	}
+	return value, pointer, nil, nil
+}
+
+// pieceRoot returns the Merkle root of the piece, or nil if the segment doesn't have valid piece roots
+func pieceRoot(pointer *pb.Pointer, pieceNum int32) []byte {
+	remote := pointer.GetRemote()
+	roots := remote.GetPieceRoots()
+	if pieceNum < 0 || int(pieceNum) >= len(roots) || len(roots[pieceNum]) == 0 {
+		return nil
+	}
+	if !bytes.Equal(merkle.DataRoot(roots), remote.GetMerkleRoot()) {
+		return nil
+	}
+	return roots[pieceNum]
+}
+
+// finishTransfer removes the pending transfer and updates the exit progress
+func (endpoint *Endpoint) finishTransfer(nodeID string, order *pb.TransferOrder, completed bool) error {
+	if err := endpoint.db.deleteTransfer(nodeID, order.GetPieceNum(), order.GetPath()); err != nil {
+		return err
+	}
+
+	progress, err := endpoint.db.getProgress(nodeID)
+	if err != nil {
+		return err
+	}
+	if progress == nil {
+		return Error.New("node %s is not exiting", nodeID)
+	}
+
+	if completed {
+		progress.TransfersCompleted++
+	} else {
+		progress.TransfersFailed++
+	}
+	if progress.TransfersCompleted+progress.TransfersFailed >= progress.TransfersTotal {
+		progress.FinishedUnixSec = time.Now().Unix()
+		endpoint.logger.Info("storage node finished exiting", zap.String("node", nodeID))
+	}
+
+	return endpoint.db.putProgress(progress)
+}
+
+// peerNodeID returns the node id of the caller
+func peerNodeID(ctx context.Context) (string, error) {
+	pi, err := provider.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	return pi.ID.String(), nil
+}
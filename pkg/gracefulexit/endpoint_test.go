@@ -0,0 +1,201 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gracefulexit
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"storj.io/storj/pkg/merkle"
+	"storj.io/storj/pkg/overlay/mocks"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/teststore"
+)
+
+func newTestIdentity(t *testing.T) *provider.FullIdentity {
+	ca, err := provider.NewTestCA(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity, err := ca.NewIdentity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return identity
+}
+
+func peerContext(identity *provider.FullIdentity) context.Context {
+	info := credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{identity.Leaf, identity.CA},
+	}}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: info})
+}
+
+func TestGracefulExit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	satellite := newTestIdentity(t)
+	exiting := newTestIdentity(t)
+	nodeID := exiting.ID.String()
+	ctx := peerContext(exiting)
+
+	pointers := pointerdb.NewServer(teststore.New(), nil, nil, nil, zap.NewNop(), pointerdb.Config{}, satellite)
+
+	putPointer := func(path string, provable bool, nodeIDs ...string) *pb.Pointer {
+		pointer := &pb.Pointer{
+			Type: pb.Pointer_REMOTE,
+			Size: 100,
+			Remote: &pb.RemoteSegment{
+				Redundancy: &pb.RedundancyScheme{MinReq: 1, Total: int32(len(nodeIDs)), ErasureShareSize: 10},
+				PieceId:    psclient.NewPieceID().String(),
+			},
+		}
+		for i, id := range nodeIDs {
+			pointer.Remote.RemotePieces = append(pointer.Remote.RemotePieces, &pb.RemotePiece{PieceNum: int32(i), NodeId: id})
+			if provable {
+				pointer.Remote.PieceRoots = append(pointer.Remote.PieceRoots, merkle.Leaf([]byte(path+id)))
+			}
+		}
+		if provable {
+			pointer.Remote.MerkleRoot = merkle.DataRoot(pointer.Remote.PieceRoots)
+		}
+		value, err := proto.Marshal(pointer)
+		assert.NoError(t, err)
+		assert.NoError(t, pointers.DB.Put(storage.Key(path), value))
+		return pointer
+	}
+
+	kept := putPointer("a/kept", true, "other", nodeID)
+	putPointer("a/deleted", true, nodeID, "other")
+	putPointer("a/corrupted", true, nodeID)
+	putPointer("a/unprovable", false, nodeID)
+	putPointer("b/unrelated", true, "other")
+
+	target := &pb.Node{Id: "target", Address: &pb.NodeAddress{Address: "127.0.0.1:1"}}
+	overlay := mocks.NewMockClient(ctrl)
+	overlay.EXPECT().Choose(gomock.Any(), gomock.Any()).Return([]*pb.Node{target}, nil).AnyTimes()
+
+	endpoint := NewEndpoint(teststore.New(), pointers, overlay, nil, zap.NewNop(), Config{TransferLimit: 10, MaxFailures: 2})
+
+	_, err := endpoint.Progress(ctx, &pb.ExitProgressRequest{})
+	assert.Error(t, err, "node shouldn't be exiting yet")
+
+	progress, err := endpoint.Initiate(ctx, &pb.InitiateExitRequest{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, nodeID, progress.GetNodeId())
+	assert.Equal(t, int64(4), progress.GetTransfersTotal())
+	assert.Zero(t, progress.GetFinishedUnixSec())
+
+	again, err := endpoint.Initiate(ctx, &pb.InitiateExitRequest{})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(progress, again))
+
+	// a pointer which can't be read doesn't hold up the other transfers
+	assert.NoError(t, pointers.DB.Put(storage.Key("a/corrupted"), storage.Value("corrupted")))
+
+	transfers, err := endpoint.GetTransfers(ctx, &pb.GetTransfersRequest{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, transfers.GetOrders(), 2)
+
+	for _, order := range transfers.GetOrders() {
+		assert.Equal(t, target, order.GetTarget())
+		assert.NotNil(t, order.GetAuthorization())
+		assert.NotEqual(t, order.GetPieceId(), order.GetTargetPieceId())
+
+		// the allocation is an order limit for uploading the piece to the target
+		pbad := &pb.PayerBandwidthAllocation_Data{}
+		assert.NoError(t, proto.Unmarshal(order.GetBandwidthAllocation().GetData(), pbad))
+		assert.Equal(t, pb.PayerBandwidthAllocation_PUT, pbad.GetAction())
+		assert.Equal(t, target.GetId(), string(pbad.GetStorageNodeId()))
+		assert.Equal(t, order.GetTargetPieceId(), pbad.GetPieceId())
+
+		if order.GetPath() == "a/kept" {
+			expected, err := psclient.PieceID(kept.GetRemote().GetPieceId()).Derive([]byte(nodeID))
+			assert.NoError(t, err)
+			assert.Equal(t, expected.String(), order.GetPieceId())
+		}
+	}
+
+	// the transfer of a piece without a Merkle root can't be verified, it is left to repair
+	progress, err = endpoint.Progress(ctx, &pb.ExitProgressRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), progress.GetTransfersFailed())
+
+	// the segment is deleted before the piece is transferred
+	assert.NoError(t, pointers.DB.Delete(storage.Key("a/deleted")))
+	assert.NoError(t, pointers.DB.Delete(storage.Key("a/corrupted")))
+
+	// the transfer fails until it is left to repair
+	for i := 0; i < 2; i++ {
+		transfers, err = endpoint.GetTransfers(ctx, &pb.GetTransfersRequest{})
+		if !assert.NoError(t, err) || !assert.Len(t, transfers.GetOrders(), 1) {
+			return
+		}
+		assert.Equal(t, "a/kept", transfers.GetOrders()[0].GetPath())
+
+		_, err = endpoint.CompleteTransfer(ctx, &pb.CompleteTransferRequest{Path: "a/kept", PieceNum: 1, Failed: true, Error: "failed"})
+		assert.NoError(t, err)
+	}
+
+	transfers, err = endpoint.GetTransfers(ctx, &pb.GetTransfersRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, transfers.GetOrders(), 0)
+
+	progress, err = endpoint.Progress(ctx, &pb.ExitProgressRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), progress.GetTransfersCompleted())
+	assert.Equal(t, int64(2), progress.GetTransfersFailed())
+	assert.NotZero(t, progress.GetFinishedUnixSec())
+
+	_, err = endpoint.CompleteTransfer(ctx, &pb.CompleteTransferRequest{Path: "a/kept", PieceNum: 1})
+	assert.Error(t, err, "transfer was already finished")
+}
+
+func TestSwapPiece(t *testing.T) {
+	satellite := newTestIdentity(t)
+	pointers := pointerdb.NewServer(teststore.New(), nil, nil, nil, zap.NewNop(), pointerdb.Config{}, satellite)
+	endpoint := NewEndpoint(teststore.New(), pointers, nil, nil, zap.NewNop(), Config{})
+
+	pointer := &pb.Pointer{
+		Type: pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{RemotePieces: []*pb.RemotePiece{
+			{PieceNum: 0, NodeId: "exiting"},
+			{PieceNum: 1, NodeId: "other"},
+		}},
+	}
+	value, err := proto.Marshal(pointer)
+	assert.NoError(t, err)
+	assert.NoError(t, pointers.DB.Put(storage.Key("a/path"), value))
+
+	order := &pb.TransferOrder{Path: "a/path", PieceNum: 0, Target: &pb.Node{Id: "target"}}
+	assert.NoError(t, endpoint.swapPiece(order, "exiting"))
+
+	value, err = pointers.DB.Get(storage.Key("a/path"))
+	assert.NoError(t, err)
+	swapped := &pb.Pointer{}
+	assert.NoError(t, proto.Unmarshal(value, swapped))
+	assert.Equal(t, "target", swapped.GetRemote().GetRemotePieces()[0].GetNodeId())
+	assert.Equal(t, "other", swapped.GetRemote().GetRemotePieces()[1].GetNodeId())
+
+	// the piece was replaced in the meantime, e.g. by repair
+	assert.Error(t, endpoint.swapPiece(order, "exiting"))
+}
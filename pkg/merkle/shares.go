@@ -0,0 +1,56 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package merkle
+
+// ShareHasher hashes the erasure shares of a piece as the leaves of its tree
+// while the piece is written to it
+type ShareHasher struct {
+	shareSize int
+	buf       []byte
+	leaves    [][]byte
+}
+
+// NewShareHasher creates a hasher of pieces split into shares of shareSize bytes
+func NewShareHasher(shareSize int) *ShareHasher {
+	return &ShareHasher{shareSize: shareSize}
+}
+
+// Write hashes every complete share of p, the rest is kept until the share is complete
+func (hasher *ShareHasher) Write(p []byte) (n int, err error) {
+	if hasher.shareSize <= 0 {
+		return 0, Error.New("invalid share size %d", hasher.shareSize)
+	}
+	n = len(p)
+	for len(p) > 0 {
+		if len(hasher.buf) == 0 && len(p) >= hasher.shareSize {
+			hasher.leaves = append(hasher.leaves, Leaf(p[:hasher.shareSize]))
+			p = p[hasher.shareSize:]
+			continue
+		}
+		missing := hasher.shareSize - len(hasher.buf)
+		if missing > len(p) {
+			missing = len(p)
+		}
+		hasher.buf = append(hasher.buf, p[:missing]...)
+		p = p[missing:]
+		if len(hasher.buf) == hasher.shareSize {
+			hasher.leaves = append(hasher.leaves, Leaf(hasher.buf))
+			hasher.buf = hasher.buf[:0]
+		}
+	}
+	return n, nil
+}
+
+// Leaves returns the hashes of the shares written so far, a last incomplete share is hashed as is
+func (hasher *ShareHasher) Leaves() [][]byte {
+	if len(hasher.buf) > 0 {
+		return append(hasher.leaves[:len(hasher.leaves):len(hasher.leaves)], Leaf(hasher.buf))
+	}
+	return hasher.leaves
+}
+
+// Root returns the root of the tree of the shares written so far
+func (hasher *ShareHasher) Root() []byte {
+	return Root(hasher.Leaves())
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/zeebo/errs"
+)
+
+// Error is the default error class for the merkle package
+var Error = errs.Class("merkle error")
+
+// leaves and inner nodes are hashed with different prefixes,
+// so that an inner node can't be passed off as a leaf
+const (
+	leafPrefix  = 0
+	innerPrefix = 1
+)
+
+// Leaf returns the hash of the data of a leaf of the tree
+func Leaf(data []byte) []byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte{leafPrefix})
+	_, _ = h.Write(data)
+	return h.Sum(nil)
+}
+
+// inner returns the hash of an inner node with the left and right subtrees
+func inner(left, right []byte) []byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte{innerPrefix})
+	_, _ = h.Write(left)
+	_, _ = h.Write(right)
+	return h.Sum(nil)
+}
+
+// split returns the number of leaves of the left subtree, the largest power of two less than count
+func split(count int) int {
+	k := 1
+	for k<<1 < count {
+		k <<= 1
+	}
+	return k
+}
+
+// Root returns the root hash of the tree of the leaf hashes, the tree is shaped as in RFC 6962.
+// The root of a tree without leaves is nil.
+func Root(leaves [][]byte) []byte {
+	switch len(leaves) {
+	case 0:
+		return nil
+	case 1:
+		return leaves[0]
+	}
+	k := split(len(leaves))
+	return inner(Root(leaves[:k]), Root(leaves[k:]))
+}
+
+// Proof returns the hashes needed to compute the root from the leaf at index,
+// ordered from the sibling of the leaf up to the sibling below the root
+func Proof(leaves [][]byte, index int) ([][]byte, error) {
+	if index < 0 || index >= len(leaves) {
+		return nil, Error.New("index %d out of range of %d leaves", index, len(leaves))
+	}
+	return proof(leaves, index), nil
+}
+
+func proof(leaves [][]byte, index int) [][]byte {
+	if len(leaves) <= 1 {
+		return nil
+	}
+	k := split(len(leaves))
+	if index < k {
+		return append(proof(leaves[:k], index), Root(leaves[k:]))
+	}
+	return append(proof(leaves[k:], index-k), Root(leaves[:k]))
+}
+
+// Verify checks that the leaf is at index of the tree of count leaves with root
+func Verify(root, leaf []byte, index, count int, proof [][]byte) bool {
+	if index < 0 || index >= count {
+		return false
+	}
+
+	// the algorithm of RFC 9162 section 2.1.3.2
+	fn, sn := index, count-1
+	hash := leaf
+	for _, sibling := range proof {
+		if sn == 0 {
+			return false
+		}
+		if fn&1 == 1 || fn == sn {
+			hash = inner(sibling, hash)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			hash = inner(hash, sibling)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	return sn == 0 && bytes.Equal(hash, root)
+}
+
+// DataRoot returns the root of the tree with the hashes of the data as leaves
+func DataRoot(data [][]byte) []byte {
+	leaves := make([][]byte, len(data))
+	for i := range data {
+		leaves[i] = Leaf(data[i])
+	}
+	return Root(leaves)
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package merkle
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeLeaves(count int) [][]byte {
+	leaves := make([][]byte, count)
+	for i := range leaves {
+		leaves[i] = Leaf([]byte{byte(i)})
+	}
+	return leaves
+}
+
+func TestRoot(t *testing.T) {
+	assert.Nil(t, Root(nil))
+
+	leaves := makeLeaves(3)
+	assert.Equal(t, leaves[0], Root(leaves[:1]))
+	assert.Equal(t, inner(leaves[0], leaves[1]), Root(leaves[:2]))
+	assert.Equal(t, inner(inner(leaves[0], leaves[1]), leaves[2]), Root(leaves))
+}
+
+func TestProof(t *testing.T) {
+	for count := 1; count <= 33; count++ {
+		leaves := makeLeaves(count)
+		root := Root(leaves)
+
+		for index := range leaves {
+			proof, err := Proof(leaves, index)
+			require.NoError(t, err)
+			assert.True(t, Verify(root, leaves[index], index, count, proof), "count %d index %d", count, index)
+
+			// the leaf doesn't verify anywhere else
+			if count > 1 {
+				assert.False(t, Verify(root, leaves[index], (index+1)%count, count, proof))
+				assert.False(t, Verify(root, leaves[(index+1)%count], index, count, proof))
+				assert.False(t, Verify(root, leaves[index], index, count, proof[1:]))
+			}
+		}
+	}
+
+	_, err := Proof(makeLeaves(2), 2)
+	assert.Error(t, err)
+}
+
+func TestShareHasher(t *testing.T) {
+	const shareSize = 16
+	data := make([]byte, 5*shareSize+3)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	hasher := NewShareHasher(shareSize)
+	for written := 0; written < len(data); {
+		n := 7
+		if written+n > len(data) {
+			n = len(data) - written
+		}
+		_, err := hasher.Write(data[written : written+n])
+		require.NoError(t, err)
+		written += n
+	}
+
+	var expected [][]byte
+	for offset := 0; offset < len(data); offset += shareSize {
+		end := offset + shareSize
+		if end > len(data) {
+			end = len(data)
+		}
+		expected = append(expected, Leaf(data[offset:end]))
+	}
+	assert.Equal(t, expected, hasher.Leaves())
+	assert.Equal(t, Root(expected), hasher.Root())
+}
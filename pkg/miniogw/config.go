@@ -21,6 +21,7 @@ import (
 	segment "storj.io/storj/pkg/storage/segments"
 	"storj.io/storj/pkg/storage/streams"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/uplinkdb/udbclient"
 )
 
 // RSConfig is a configuration struct that keeps details about default
@@ -136,6 +137,15 @@ func (c Config) GetBucketStore(ctx context.Context, identity *provider.FullIdent
 		return nil, err
 	}
 
+	// the satellite only allocates bandwidth to uplinks with a registered key
+	udb, err := udbclient.NewClient(identity, c.PointerDBAddr, c.APIKey)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = udb.Register(ctx); err != nil {
+		return nil, err
+	}
+
 	ec := ecclient.NewClient(identity, c.MaxBufferMem)
 	fc, err := infectious.NewFEC(c.MinThreshold, c.MaxThreshold)
 	if err != nil {
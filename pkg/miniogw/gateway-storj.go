@@ -132,7 +132,7 @@ func (s *storjObjects) GetObject(ctx context.Context, bucket, object string,
 
 	rr, err := s.getObject(ctx, bucket, object)
 	if err != nil {
-		return err
+		return convertLimitError(err, bucket)
 	}
 
 	if length == -1 {
@@ -141,13 +141,13 @@ func (s *storjObjects) GetObject(ctx context.Context, bucket, object string,
 
 	r, err := rr.Range(ctx, startOffset, length)
 	if err != nil {
-		return err
+		return convertLimitError(err, bucket)
 	}
 	defer utils.LogClose(r)
 
 	_, err = io.Copy(writer, r)
 
-	return err
+	return convertLimitError(err, bucket)
 }
 
 func (s *storjObjects) GetObjectInfo(ctx context.Context, bucket,
@@ -353,12 +353,12 @@ func (s *storjObjects) CopyObject(ctx context.Context, srcBucket, srcObject, des
 
 	rr, err := s.getObject(ctx, srcBucket, srcObject)
 	if err != nil {
-		return objInfo, err
+		return objInfo, convertLimitError(err, srcBucket)
 	}
 
 	r, err := rr.Range(ctx, 0, rr.Size())
 	if err != nil {
-		return objInfo, err
+		return objInfo, convertLimitError(err, srcBucket)
 	}
 
 	defer utils.LogClose(r)
@@ -390,7 +390,7 @@ func (s *storjObjects) putObject(ctx context.Context, bucket, object string, r i
 		ETag:        m.Checksum,
 		ContentType: m.ContentType,
 		UserDefined: m.UserDefined,
-	}, err
+	}, convertLimitError(err, bucket)
 }
 
 func (s *storjObjects) PutObject(ctx context.Context, bucket, object string,
@@ -409,6 +409,18 @@ func (s *storjObjects) PutObject(ctx context.Context, bucket, object string,
 	return s.putObject(ctx, bucket, object, data, serMetaInfo)
 }
 
+// convertLimitError converts reaching a limit of the project into the closest S3 error,
+// a full storage for uploads and a disabled bucket for downloads
+func convertLimitError(err error, bucket string) error {
+	switch {
+	case storj.ErrStorageLimit.Has(err):
+		return minio.StorageFull{}
+	case storj.ErrEgressLimit.Has(err):
+		return minio.AllAccessDisabled{Bucket: bucket}
+	}
+	return err
+}
+
 func (s *storjObjects) Shutdown(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
 	return nil
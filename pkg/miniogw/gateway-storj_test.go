@@ -22,6 +22,7 @@ import (
 	mock_buckets "storj.io/storj/pkg/storage/buckets/mocks"
 	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/pkg/storage/objects"
+	"storj.io/storj/pkg/storj"
 	"storj.io/storj/storage"
 )
 
@@ -150,6 +151,8 @@ func TestGetObject(t *testing.T) {
 		{"mybucket", "myobject1", "abcdef", 1, 7, "bcde", nil, "ranger error: buffer runoff"},
 		// error returned by the objects.Get()
 		{"mybucket", "myobject1", "abcdef", 0, 6, "abcdef", errors.New("some err"), "some err"},
+		// the project reached its egress limit
+		{"mybucket", "myobject1", "abcdef", 0, 6, "abcdef", storj.ErrEgressLimit.New("limit"), minio.AllAccessDisabled{Bucket: "mybucket"}.Error()},
 	} {
 		errTag := fmt.Sprintf("Test case #%d", i)
 
@@ -228,6 +231,8 @@ func TestPutObject(t *testing.T) {
 		{"mybucket", "myobject1", nil, ""},
 		// emulating objects.Put() returning err
 		{"mybucket", "myobject1", Error.New("some non nil error"), "Storj Gateway error: some non nil error"},
+		// the project reached its storage limit
+		{"mybucket", "myobject1", storj.ErrStorageLimit.New("limit"), minio.StorageFull{}.Error()},
 	} {
 		errTag := fmt.Sprintf("Test case #%d", i)
 
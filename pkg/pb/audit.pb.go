@@ -0,0 +1,386 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: audit.proto
+
+package pb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// PendingAudit is the stripe a contained storage node didn't answer,
+// the node has to answer it before it gets any other audit results
+type PendingAudit struct {
+	NodeId               string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	PieceId              string   `protobuf:"bytes,3,opt,name=piece_id,json=pieceId,proto3" json:"piece_id,omitempty"`
+	StripeIndex          int64    `protobuf:"varint,4,opt,name=stripe_index,json=stripeIndex,proto3" json:"stripe_index,omitempty"`
+	ReverifyCount        int32    `protobuf:"varint,5,opt,name=reverify_count,json=reverifyCount,proto3" json:"reverify_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PendingAudit) Reset()         { *m = PendingAudit{} }
+func (m *PendingAudit) String() string { return proto.CompactTextString(m) }
+func (*PendingAudit) ProtoMessage()    {}
+func (*PendingAudit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_audit_f95d66c1f97ca3c9, []int{0}
+}
+func (m *PendingAudit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PendingAudit.Unmarshal(m, b)
+}
+func (m *PendingAudit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PendingAudit.Marshal(b, m, deterministic)
+}
+func (dst *PendingAudit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingAudit.Merge(dst, src)
+}
+func (m *PendingAudit) XXX_Size() int {
+	return xxx_messageInfo_PendingAudit.Size(m)
+}
+func (m *PendingAudit) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingAudit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PendingAudit proto.InternalMessageInfo
+
+func (m *PendingAudit) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *PendingAudit) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *PendingAudit) GetPieceId() string {
+	if m != nil {
+		return m.PieceId
+	}
+	return ""
+}
+
+func (m *PendingAudit) GetStripeIndex() int64 {
+	if m != nil {
+		return m.StripeIndex
+	}
+	return 0
+}
+
+func (m *PendingAudit) GetReverifyCount() int32 {
+	if m != nil {
+		return m.ReverifyCount
+	}
+	return 0
+}
+
+type AuditHistoryRequest struct {
+	// node_id limits the records to the audits of a node, records of all nodes are returned if empty
+	NodeId               string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	SinceUnixSec         int64    `protobuf:"varint,2,opt,name=since_unix_sec,json=sinceUnixSec,proto3" json:"since_unix_sec,omitempty"`
+	Limit                int32    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditHistoryRequest) Reset()         { *m = AuditHistoryRequest{} }
+func (m *AuditHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*AuditHistoryRequest) ProtoMessage()    {}
+func (*AuditHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_audit_f95d66c1f97ca3c9, []int{1}
+}
+func (m *AuditHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditHistoryRequest.Unmarshal(m, b)
+}
+func (m *AuditHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditHistoryRequest.Marshal(b, m, deterministic)
+}
+func (dst *AuditHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditHistoryRequest.Merge(dst, src)
+}
+func (m *AuditHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_AuditHistoryRequest.Size(m)
+}
+func (m *AuditHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditHistoryRequest proto.InternalMessageInfo
+
+func (m *AuditHistoryRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *AuditHistoryRequest) GetSinceUnixSec() int64 {
+	if m != nil {
+		return m.SinceUnixSec
+	}
+	return 0
+}
+
+func (m *AuditHistoryRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type AuditHistoryResponse struct {
+	Records              []*AuditRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *AuditHistoryResponse) Reset()         { *m = AuditHistoryResponse{} }
+func (m *AuditHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*AuditHistoryResponse) ProtoMessage()    {}
+func (*AuditHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_audit_f95d66c1f97ca3c9, []int{2}
+}
+func (m *AuditHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditHistoryResponse.Unmarshal(m, b)
+}
+func (m *AuditHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditHistoryResponse.Marshal(b, m, deterministic)
+}
+func (dst *AuditHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditHistoryResponse.Merge(dst, src)
+}
+func (m *AuditHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_AuditHistoryResponse.Size(m)
+}
+func (m *AuditHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditHistoryResponse proto.InternalMessageInfo
+
+func (m *AuditHistoryResponse) GetRecords() []*AuditRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+// AuditRecord is the outcome of the audit of a stripe on a storage node
+type AuditRecord struct {
+	NodeId      string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Path        string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	StripeIndex int64  `protobuf:"varint,3,opt,name=stripe_index,json=stripeIndex,proto3" json:"stripe_index,omitempty"`
+	Outcome     string `protobuf:"bytes,4,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	ErrorClass  string `protobuf:"bytes,5,opt,name=error_class,json=errorClass,proto3" json:"error_class,omitempty"`
+	// latency is how long the node took to answer in nanoseconds
+	Latency              int64    `protobuf:"varint,6,opt,name=latency,proto3" json:"latency,omitempty"`
+	CreatedUnixSec       int64    `protobuf:"varint,7,opt,name=created_unix_sec,json=createdUnixSec,proto3" json:"created_unix_sec,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditRecord) Reset()         { *m = AuditRecord{} }
+func (m *AuditRecord) String() string { return proto.CompactTextString(m) }
+func (*AuditRecord) ProtoMessage()    {}
+func (*AuditRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_audit_f95d66c1f97ca3c9, []int{3}
+}
+func (m *AuditRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditRecord.Unmarshal(m, b)
+}
+func (m *AuditRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditRecord.Marshal(b, m, deterministic)
+}
+func (dst *AuditRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditRecord.Merge(dst, src)
+}
+func (m *AuditRecord) XXX_Size() int {
+	return xxx_messageInfo_AuditRecord.Size(m)
+}
+func (m *AuditRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditRecord proto.InternalMessageInfo
+
+func (m *AuditRecord) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetStripeIndex() int64 {
+	if m != nil {
+		return m.StripeIndex
+	}
+	return 0
+}
+
+func (m *AuditRecord) GetOutcome() string {
+	if m != nil {
+		return m.Outcome
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetErrorClass() string {
+	if m != nil {
+		return m.ErrorClass
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetLatency() int64 {
+	if m != nil {
+		return m.Latency
+	}
+	return 0
+}
+
+func (m *AuditRecord) GetCreatedUnixSec() int64 {
+	if m != nil {
+		return m.CreatedUnixSec
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*PendingAudit)(nil), "audit.PendingAudit")
+	proto.RegisterType((*AuditHistoryRequest)(nil), "audit.AuditHistoryRequest")
+	proto.RegisterType((*AuditHistoryResponse)(nil), "audit.AuditHistoryResponse")
+	proto.RegisterType((*AuditRecord)(nil), "audit.AuditRecord")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AuditHistoryClient is the client API for AuditHistory service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AuditHistoryClient interface {
+	Query(ctx context.Context, in *AuditHistoryRequest, opts ...grpc.CallOption) (*AuditHistoryResponse, error)
+}
+
+type auditHistoryClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAuditHistoryClient(cc *grpc.ClientConn) AuditHistoryClient {
+	return &auditHistoryClient{cc}
+}
+
+func (c *auditHistoryClient) Query(ctx context.Context, in *AuditHistoryRequest, opts ...grpc.CallOption) (*AuditHistoryResponse, error) {
+	out := new(AuditHistoryResponse)
+	err := c.cc.Invoke(ctx, "/audit.AuditHistory/Query", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuditHistoryServer is the server API for AuditHistory service.
+type AuditHistoryServer interface {
+	Query(context.Context, *AuditHistoryRequest) (*AuditHistoryResponse, error)
+}
+
+func RegisterAuditHistoryServer(s *grpc.Server, srv AuditHistoryServer) {
+	s.RegisterService(&_AuditHistory_serviceDesc, srv)
+}
+
+func _AuditHistory_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditHistoryServer).Query(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/audit.AuditHistory/Query",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditHistoryServer).Query(ctx, req.(*AuditHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AuditHistory_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "audit.AuditHistory",
+	HandlerType: (*AuditHistoryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Query",
+			Handler:    _AuditHistory_Query_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "audit.proto",
+}
+
+func init() { proto.RegisterFile("audit.proto", fileDescriptor_audit_f95d66c1f97ca3c9) }
+
+var fileDescriptor_audit_f95d66c1f97ca3c9 = []byte{
+	// 374 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xc1, 0xae, 0x93, 0x40,
+	0x14, 0x86, 0xc3, 0xa5, 0x14, 0x7b, 0xc0, 0xc6, 0x8c, 0x4d, 0xc4, 0xba, 0x10, 0x89, 0x26, 0x2c,
+	0x4c, 0x17, 0xf5, 0x05, 0xd4, 0xba, 0xb0, 0xbb, 0x3a, 0xc6, 0x8d, 0x1b, 0x42, 0x67, 0x8e, 0x3a,
+	0xa6, 0x9d, 0xc1, 0x99, 0xc1, 0x94, 0xb7, 0xf1, 0xc1, 0x7c, 0x18, 0xc3, 0xa1, 0xc4, 0x56, 0x8d,
+	0xc9, 0xdd, 0xf1, 0x7f, 0xe7, 0x00, 0x7f, 0xbe, 0x19, 0x48, 0xea, 0x56, 0x2a, 0xbf, 0x6a, 0xac,
+	0xf1, 0x86, 0x45, 0x14, 0x8a, 0x1f, 0x01, 0xa4, 0x3b, 0xd4, 0x52, 0xe9, 0xcf, 0xaf, 0x7a, 0xc0,
+	0x1e, 0x40, 0xac, 0x8d, 0xc4, 0x4a, 0xc9, 0x2c, 0xc8, 0x83, 0x72, 0xc6, 0xa7, 0x7d, 0xdc, 0x4a,
+	0xc6, 0x60, 0xd2, 0xd4, 0xfe, 0x4b, 0x76, 0x43, 0x94, 0x9e, 0xd9, 0x43, 0xb8, 0xd3, 0x28, 0x14,
+	0xb4, 0x1d, 0x12, 0x8f, 0x29, 0x6f, 0x25, 0x7b, 0x02, 0xa9, 0xf3, 0x56, 0x35, 0x58, 0x29, 0x2d,
+	0xf1, 0x94, 0x4d, 0xf2, 0xa0, 0x0c, 0x79, 0x32, 0xb0, 0x6d, 0x8f, 0xd8, 0x33, 0x98, 0x5b, 0xfc,
+	0x8e, 0x56, 0x7d, 0xea, 0x2a, 0x61, 0x5a, 0xed, 0xb3, 0x28, 0x0f, 0xca, 0x88, 0xdf, 0x1d, 0xe9,
+	0xa6, 0x87, 0xc5, 0x57, 0xb8, 0x4f, 0xd5, 0xde, 0x2a, 0xe7, 0x8d, 0xed, 0x38, 0x7e, 0x6b, 0xd1,
+	0xfd, 0xa7, 0xe8, 0x53, 0x98, 0x3b, 0xa5, 0x05, 0x56, 0xad, 0x56, 0xa7, 0xca, 0xa1, 0xa0, 0xca,
+	0x21, 0x4f, 0x89, 0x7e, 0xd0, 0xea, 0xf4, 0x1e, 0x05, 0x5b, 0x40, 0x74, 0x50, 0x47, 0xe5, 0xa9,
+	0x77, 0xc4, 0x87, 0x50, 0xbc, 0x81, 0xc5, 0xf5, 0xbf, 0x5c, 0x63, 0xb4, 0x43, 0xf6, 0x1c, 0x62,
+	0x8b, 0xc2, 0x58, 0xe9, 0xb2, 0x20, 0x0f, 0xcb, 0x64, 0xcd, 0x56, 0x83, 0x4c, 0xda, 0xe6, 0x34,
+	0xe2, 0xe3, 0x4a, 0xf1, 0x33, 0x80, 0xe4, 0x62, 0x70, 0x3b, 0xa7, 0x7f, 0x8a, 0x0b, 0xff, 0x16,
+	0x97, 0x41, 0x6c, 0x5a, 0x2f, 0xcc, 0x11, 0x49, 0xeb, 0x8c, 0x8f, 0x91, 0x3d, 0x86, 0x04, 0xad,
+	0x35, 0xb6, 0x12, 0x87, 0xda, 0x39, 0xf2, 0x39, 0xe3, 0x40, 0x68, 0xd3, 0x93, 0xfe, 0xd5, 0x43,
+	0xed, 0x51, 0x8b, 0x2e, 0x9b, 0xd2, 0x87, 0xc7, 0xc8, 0x4a, 0xb8, 0x27, 0x2c, 0xd6, 0x1e, 0xe5,
+	0x6f, 0x71, 0x31, 0xad, 0xcc, 0xcf, 0xfc, 0xac, 0x6e, 0xbd, 0x83, 0xf4, 0x52, 0x12, 0x7b, 0x09,
+	0xd1, 0xbb, 0x16, 0x6d, 0xc7, 0x96, 0x97, 0x52, 0xae, 0x8f, 0x6b, 0xf9, 0xe8, 0x9f, 0xb3, 0x41,
+	0xef, 0xeb, 0xc9, 0xc7, 0x9b, 0x66, 0xbf, 0x9f, 0xd2, 0xcd, 0x7c, 0xf1, 0x6b, 0x00, 0xef, 0xca,
+	0x3e, 0xa1, 0xa8, 0x02, 0x00, 0x00,
+}
@@ -26,28 +26,32 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type AgreementsSummary_Status int32
 
 const (
-	AgreementsSummary_FAIL AgreementsSummary_Status = 0
-	AgreementsSummary_OK   AgreementsSummary_Status = 1
+	AgreementsSummary_FAIL     AgreementsSummary_Status = 0
+	AgreementsSummary_OK       AgreementsSummary_Status = 1
+	AgreementsSummary_REJECTED AgreementsSummary_Status = 2
 )
 
 var AgreementsSummary_Status_name = map[int32]string{
 	0: "FAIL",
 	1: "OK",
+	2: "REJECTED",
 }
 var AgreementsSummary_Status_value = map[string]int32{
-	"FAIL": 0,
-	"OK":   1,
+	"FAIL":     0,
+	"OK":       1,
+	"REJECTED": 2,
 }
 
 func (x AgreementsSummary_Status) String() string {
 	return proto.EnumName(AgreementsSummary_Status_name, int32(x))
 }
 func (AgreementsSummary_Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_bandwidth_01db992f91c47bae, []int{0, 0}
+	return fileDescriptor_bandwidth_2519dd94b92b7be2, []int{0, 0}
 }
 
 type AgreementsSummary struct {
 	Status               AgreementsSummary_Status `protobuf:"varint,1,opt,name=status,proto3,enum=bandwidth.AgreementsSummary_Status" json:"status,omitempty"`
+	Reason               string                   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
 	XXX_unrecognized     []byte                   `json:"-"`
 	XXX_sizecache        int32                    `json:"-"`
@@ -57,7 +61,7 @@ func (m *AgreementsSummary) Reset()         { *m = AgreementsSummary{} }
 func (m *AgreementsSummary) String() string { return proto.CompactTextString(m) }
 func (*AgreementsSummary) ProtoMessage()    {}
 func (*AgreementsSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bandwidth_01db992f91c47bae, []int{0}
+	return fileDescriptor_bandwidth_2519dd94b92b7be2, []int{0}
 }
 func (m *AgreementsSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AgreementsSummary.Unmarshal(m, b)
@@ -84,8 +88,94 @@ func (m *AgreementsSummary) GetStatus() AgreementsSummary_Status {
 	return AgreementsSummary_FAIL
 }
 
+func (m *AgreementsSummary) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type AgreementsBatchRequest struct {
+	Agreements           []*RenterBandwidthAllocation `protobuf:"bytes,1,rep,name=agreements,proto3" json:"agreements,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *AgreementsBatchRequest) Reset()         { *m = AgreementsBatchRequest{} }
+func (m *AgreementsBatchRequest) String() string { return proto.CompactTextString(m) }
+func (*AgreementsBatchRequest) ProtoMessage()    {}
+func (*AgreementsBatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bandwidth_2519dd94b92b7be2, []int{1}
+}
+func (m *AgreementsBatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AgreementsBatchRequest.Unmarshal(m, b)
+}
+func (m *AgreementsBatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AgreementsBatchRequest.Marshal(b, m, deterministic)
+}
+func (dst *AgreementsBatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgreementsBatchRequest.Merge(dst, src)
+}
+func (m *AgreementsBatchRequest) XXX_Size() int {
+	return xxx_messageInfo_AgreementsBatchRequest.Size(m)
+}
+func (m *AgreementsBatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgreementsBatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgreementsBatchRequest proto.InternalMessageInfo
+
+func (m *AgreementsBatchRequest) GetAgreements() []*RenterBandwidthAllocation {
+	if m != nil {
+		return m.Agreements
+	}
+	return nil
+}
+
+// AgreementsBatchResponse has a summary for every agreement in the order of the request
+type AgreementsBatchResponse struct {
+	Summaries            []*AgreementsSummary `protobuf:"bytes,1,rep,name=summaries,proto3" json:"summaries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AgreementsBatchResponse) Reset()         { *m = AgreementsBatchResponse{} }
+func (m *AgreementsBatchResponse) String() string { return proto.CompactTextString(m) }
+func (*AgreementsBatchResponse) ProtoMessage()    {}
+func (*AgreementsBatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bandwidth_2519dd94b92b7be2, []int{2}
+}
+func (m *AgreementsBatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AgreementsBatchResponse.Unmarshal(m, b)
+}
+func (m *AgreementsBatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AgreementsBatchResponse.Marshal(b, m, deterministic)
+}
+func (dst *AgreementsBatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgreementsBatchResponse.Merge(dst, src)
+}
+func (m *AgreementsBatchResponse) XXX_Size() int {
+	return xxx_messageInfo_AgreementsBatchResponse.Size(m)
+}
+func (m *AgreementsBatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgreementsBatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgreementsBatchResponse proto.InternalMessageInfo
+
+func (m *AgreementsBatchResponse) GetSummaries() []*AgreementsSummary {
+	if m != nil {
+		return m.Summaries
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*AgreementsSummary)(nil), "bandwidth.AgreementsSummary")
+	proto.RegisterType((*AgreementsBatchRequest)(nil), "bandwidth.AgreementsBatchRequest")
+	proto.RegisterType((*AgreementsBatchResponse)(nil), "bandwidth.AgreementsBatchResponse")
 	proto.RegisterEnum("bandwidth.AgreementsSummary_Status", AgreementsSummary_Status_name, AgreementsSummary_Status_value)
 }
 
@@ -102,6 +192,7 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type BandwidthClient interface {
 	BandwidthAgreements(ctx context.Context, in *RenterBandwidthAllocation, opts ...grpc.CallOption) (*AgreementsSummary, error)
+	BandwidthAgreementsBatch(ctx context.Context, in *AgreementsBatchRequest, opts ...grpc.CallOption) (*AgreementsBatchResponse, error)
 }
 
 type bandwidthClient struct {
@@ -121,9 +212,19 @@ func (c *bandwidthClient) BandwidthAgreements(ctx context.Context, in *RenterBan
 	return out, nil
 }
 
+func (c *bandwidthClient) BandwidthAgreementsBatch(ctx context.Context, in *AgreementsBatchRequest, opts ...grpc.CallOption) (*AgreementsBatchResponse, error) {
+	out := new(AgreementsBatchResponse)
+	err := c.cc.Invoke(ctx, "/bandwidth.Bandwidth/BandwidthAgreementsBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BandwidthServer is the server API for Bandwidth service.
 type BandwidthServer interface {
 	BandwidthAgreements(context.Context, *RenterBandwidthAllocation) (*AgreementsSummary, error)
+	BandwidthAgreementsBatch(context.Context, *AgreementsBatchRequest) (*AgreementsBatchResponse, error)
 }
 
 func RegisterBandwidthServer(s *grpc.Server, srv BandwidthServer) {
@@ -148,6 +249,24 @@ func _Bandwidth_BandwidthAgreements_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Bandwidth_BandwidthAgreementsBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgreementsBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BandwidthServer).BandwidthAgreementsBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bandwidth.Bandwidth/BandwidthAgreementsBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BandwidthServer).BandwidthAgreementsBatch(ctx, req.(*AgreementsBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Bandwidth_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "bandwidth.Bandwidth",
 	HandlerType: (*BandwidthServer)(nil),
@@ -156,26 +275,37 @@ var _Bandwidth_serviceDesc = grpc.ServiceDesc{
 			MethodName: "BandwidthAgreements",
 			Handler:    _Bandwidth_BandwidthAgreements_Handler,
 		},
+		{
+			MethodName: "BandwidthAgreementsBatch",
+			Handler:    _Bandwidth_BandwidthAgreementsBatch_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "bandwidth.proto",
 }
 
-func init() { proto.RegisterFile("bandwidth.proto", fileDescriptor_bandwidth_01db992f91c47bae) }
-
-var fileDescriptor_bandwidth_01db992f91c47bae = []byte{
-	// 196 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x4f, 0x4a, 0xcc, 0x4b,
-	0x29, 0xcf, 0x4c, 0x29, 0xc9, 0xd0, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x84, 0x0b, 0x48,
-	0x09, 0x14, 0x64, 0xa6, 0x26, 0xa7, 0x16, 0x97, 0xe4, 0x17, 0xa5, 0x42, 0x24, 0x95, 0x72, 0xb8,
-	0x04, 0x1d, 0xd3, 0x8b, 0x52, 0x53, 0x73, 0x53, 0xf3, 0x4a, 0x8a, 0x83, 0x4b, 0x73, 0x73, 0x13,
-	0x8b, 0x2a, 0x85, 0xac, 0xb9, 0xd8, 0x8a, 0x4b, 0x12, 0x4b, 0x4a, 0x8b, 0x25, 0x18, 0x15, 0x18,
-	0x35, 0xf8, 0x8c, 0x94, 0xf5, 0x10, 0x66, 0x62, 0xa8, 0xd6, 0x0b, 0x06, 0x2b, 0x0d, 0x82, 0x6a,
-	0x51, 0x92, 0xe2, 0x62, 0x83, 0x88, 0x08, 0x71, 0x70, 0xb1, 0xb8, 0x39, 0x7a, 0xfa, 0x08, 0x30,
-	0x08, 0xb1, 0x71, 0x31, 0xf9, 0x7b, 0x0b, 0x30, 0x1a, 0xe5, 0x73, 0x71, 0x3a, 0xc1, 0x4c, 0x12,
-	0x4a, 0xe2, 0x12, 0x86, 0x73, 0x10, 0xa6, 0x0a, 0x69, 0xeb, 0x21, 0x1c, 0x59, 0x94, 0x5f, 0x5a,
-	0x92, 0x5a, 0xac, 0x17, 0x94, 0x9a, 0x57, 0x92, 0x5a, 0x84, 0x50, 0x9c, 0x93, 0x93, 0x9f, 0x9c,
-	0x58, 0x92, 0x99, 0x9f, 0x27, 0x25, 0x83, 0xcf, 0x65, 0x4a, 0x0c, 0x4e, 0x2c, 0x51, 0x4c, 0x05,
-	0x49, 0x49, 0x6c, 0x60, 0xbf, 0x1a, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0xdc, 0x38, 0x8e, 0x59,
-	0x1b, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("bandwidth.proto", fileDescriptor_bandwidth_2519dd94b92b7be2) }
+
+var fileDescriptor_bandwidth_2519dd94b92b7be2 = []byte{
+	// 311 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xc1, 0x4a, 0xf3, 0x40,
+	0x14, 0x85, 0x3b, 0xf9, 0x4b, 0x68, 0xee, 0x2f, 0x1a, 0xaf, 0x50, 0x43, 0x71, 0x51, 0xc7, 0x4d,
+	0x40, 0xc8, 0xa2, 0xee, 0x74, 0xd5, 0x6a, 0x05, 0xad, 0x20, 0x4c, 0x75, 0xe3, 0x6e, 0x92, 0x5e,
+	0x6c, 0xa0, 0xcd, 0xc4, 0x99, 0x09, 0xe2, 0x6b, 0xf8, 0x6c, 0x3e, 0x90, 0x90, 0xb6, 0x49, 0xa1,
+	0x25, 0xe0, 0x72, 0xe6, 0x9e, 0x33, 0xdf, 0x3d, 0x87, 0x81, 0xa3, 0x58, 0x66, 0xb3, 0xcf, 0x74,
+	0x66, 0xe7, 0x51, 0xae, 0x95, 0x55, 0xe8, 0x55, 0x17, 0x3d, 0x3f, 0x4f, 0x29, 0x21, 0x63, 0x95,
+	0xa6, 0xd5, 0x90, 0x7f, 0x33, 0x38, 0x1e, 0xbe, 0x6b, 0xa2, 0x25, 0x65, 0xd6, 0x4c, 0x8b, 0xe5,
+	0x52, 0xea, 0x2f, 0xbc, 0x01, 0xd7, 0x58, 0x69, 0x0b, 0x13, 0xb0, 0x3e, 0x0b, 0x0f, 0x07, 0x17,
+	0x51, 0xfd, 0xe8, 0x8e, 0x3a, 0x9a, 0x96, 0x52, 0xb1, 0xb6, 0x60, 0x17, 0x5c, 0x4d, 0xd2, 0xa8,
+	0x2c, 0x70, 0xfa, 0x2c, 0xf4, 0xc4, 0xfa, 0xc4, 0x43, 0x70, 0x57, 0x4a, 0xec, 0x40, 0xfb, 0x7e,
+	0xf8, 0xf0, 0xe4, 0xb7, 0xd0, 0x05, 0xe7, 0x79, 0xe2, 0x33, 0x3c, 0x80, 0x8e, 0x18, 0x3f, 0x8e,
+	0x6f, 0x5f, 0xc6, 0x77, 0xbe, 0xc3, 0x09, 0xba, 0x35, 0x65, 0x24, 0x6d, 0x32, 0x17, 0xf4, 0x51,
+	0x90, 0xb1, 0x38, 0x01, 0x90, 0xd5, 0x24, 0x60, 0xfd, 0x7f, 0xe1, 0xff, 0xc1, 0x65, 0x54, 0xa7,
+	0xd2, 0xaa, 0xb0, 0x64, 0x22, 0x41, 0x99, 0x25, 0x3d, 0xda, 0xec, 0x3c, 0x5c, 0x2c, 0x54, 0x22,
+	0x6d, 0xaa, 0x32, 0xb1, 0x65, 0xe7, 0xaf, 0x70, 0xba, 0x83, 0x31, 0xb9, 0xca, 0x0c, 0xe1, 0x35,
+	0x78, 0xa6, 0x4c, 0x97, 0xd2, 0x06, 0x73, 0xd6, 0xd4, 0x81, 0xa8, 0xe5, 0x83, 0x1f, 0x06, 0x5e,
+	0x85, 0xc6, 0x18, 0x4e, 0xea, 0x3d, 0x2a, 0x1b, 0xfe, 0x65, 0xe9, 0x5e, 0x23, 0x9a, 0xb7, 0x30,
+	0x81, 0x60, 0x0f, 0xa3, 0x4c, 0x84, 0xe7, 0x7b, 0xbd, 0xdb, 0xa5, 0xf6, 0x78, 0x93, 0x64, 0x55,
+	0x08, 0x6f, 0x8d, 0xda, 0x6f, 0x4e, 0x1e, 0xc7, 0x6e, 0xf9, 0x6d, 0xae, 0x7e, 0x07, 0x00, 0xf1,
+	0x73, 0xd2, 0x6f, 0x66, 0x02, 0x00, 0x00,
 }
@@ -20,8 +20,11 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 // InjuredSegment is the queue item used for the data repair queue
 type InjuredSegment struct {
-	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	LostPieces           []int32  `protobuf:"varint,2,rep,packed,name=lost_pieces,json=lostPieces,proto3" json:"lost_pieces,omitempty"`
+	Path       string  `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	LostPieces []int32 `protobuf:"varint,2,rep,packed,name=lost_pieces,json=lostPieces,proto3" json:"lost_pieces,omitempty"`
+	// the segment is repaired more urgently the fewer healthy pieces it has above min_req
+	HealthyCount         int32    `protobuf:"varint,3,opt,name=healthy_count,json=healthyCount,proto3" json:"healthy_count,omitempty"`
+	MinReq               int32    `protobuf:"varint,4,opt,name=min_req,json=minReq,proto3" json:"min_req,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -31,7 +34,7 @@ func (m *InjuredSegment) Reset()         { *m = InjuredSegment{} }
 func (m *InjuredSegment) String() string { return proto.CompactTextString(m) }
 func (*InjuredSegment) ProtoMessage()    {}
 func (*InjuredSegment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datarepair_13e4beab54f194bd, []int{0}
+	return fileDescriptor_datarepair_cd106f764b86ffb5, []int{0}
 }
 func (m *InjuredSegment) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InjuredSegment.Unmarshal(m, b)
@@ -65,20 +68,37 @@ func (m *InjuredSegment) GetLostPieces() []int32 {
 	return nil
 }
 
+func (m *InjuredSegment) GetHealthyCount() int32 {
+	if m != nil {
+		return m.HealthyCount
+	}
+	return 0
+}
+
+func (m *InjuredSegment) GetMinReq() int32 {
+	if m != nil {
+		return m.MinReq
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*InjuredSegment)(nil), "repair.InjuredSegment")
 }
 
-func init() { proto.RegisterFile("datarepair.proto", fileDescriptor_datarepair_13e4beab54f194bd) }
+func init() { proto.RegisterFile("datarepair.proto", fileDescriptor_datarepair_cd106f764b86ffb5) }
 
-var fileDescriptor_datarepair_13e4beab54f194bd = []byte{
-	// 119 bytes of a gzipped FileDescriptorProto
+var fileDescriptor_datarepair_cd106f764b86ffb5 = []byte{
+	// 169 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x48, 0x49, 0x2c, 0x49,
 	0x2c, 0x4a, 0x2d, 0x48, 0xcc, 0x2c, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x83, 0xf0,
-	0x94, 0x5c, 0xb9, 0xf8, 0x3c, 0xf3, 0xb2, 0x4a, 0x8b, 0x52, 0x53, 0x82, 0x53, 0xd3, 0x73, 0x53,
-	0xf3, 0x4a, 0x84, 0x84, 0xb8, 0x58, 0x0a, 0x12, 0x4b, 0x32, 0x24, 0x18, 0x15, 0x18, 0x35, 0x38,
-	0x83, 0xc0, 0x6c, 0x21, 0x79, 0x2e, 0xee, 0x9c, 0xfc, 0xe2, 0x92, 0xf8, 0x82, 0xcc, 0xd4, 0xe4,
-	0xd4, 0x62, 0x09, 0x26, 0x05, 0x66, 0x0d, 0xd6, 0x20, 0x2e, 0x90, 0x50, 0x00, 0x58, 0xc4, 0x89,
-	0x25, 0x8a, 0xa9, 0x20, 0x29, 0x89, 0x0d, 0x6c, 0xb6, 0x31, 0x20, 0x00, 0x00, 0xff, 0xff, 0x13,
-	0xff, 0xff, 0x1e, 0x6f, 0x00, 0x00, 0x00,
+	0x94, 0x9a, 0x19, 0xb9, 0xf8, 0x3c, 0xf3, 0xb2, 0x4a, 0x8b, 0x52, 0x53, 0x82, 0x53, 0xd3, 0x73,
+	0x53, 0xf3, 0x4a, 0x84, 0x84, 0xb8, 0x58, 0x0a, 0x12, 0x4b, 0x32, 0x24, 0x18, 0x15, 0x18, 0x35,
+	0x38, 0x83, 0xc0, 0x6c, 0x21, 0x79, 0x2e, 0xee, 0x9c, 0xfc, 0xe2, 0x92, 0xf8, 0x82, 0xcc, 0xd4,
+	0xe4, 0xd4, 0x62, 0x09, 0x26, 0x05, 0x66, 0x0d, 0xd6, 0x20, 0x2e, 0x90, 0x50, 0x00, 0x58, 0x44,
+	0x48, 0x99, 0x8b, 0x37, 0x23, 0x35, 0x31, 0xa7, 0x24, 0xa3, 0x32, 0x3e, 0x39, 0xbf, 0x34, 0xaf,
+	0x44, 0x82, 0x59, 0x81, 0x51, 0x83, 0x35, 0x88, 0x07, 0x2a, 0xe8, 0x0c, 0x12, 0x13, 0x12, 0xe7,
+	0x62, 0xcf, 0xcd, 0xcc, 0x8b, 0x2f, 0x4a, 0x2d, 0x94, 0x60, 0x01, 0x4b, 0xb3, 0xe5, 0x66, 0xe6,
+	0x05, 0xa5, 0x16, 0x3a, 0xb1, 0x44, 0x31, 0x15, 0x24, 0x25, 0xb1, 0x81, 0x9d, 0x66, 0x0c, 0x18,
+	0x00, 0xb3, 0x82, 0x9a, 0x83, 0xae, 0x00, 0x00, 0x00,
 }
@@ -9,3 +9,4 @@ package pb
 //go:generate protoc --go_out=plugins=grpc:. piecestore.proto
 //go:generate protoc --go_out=plugins=grpc:. bandwidth.proto
 //go:generate protoc --go_out=plugins=grpc:. kadcli.proto
+//go:generate protoc --go_out=plugins=grpc:. gracefulexit.proto
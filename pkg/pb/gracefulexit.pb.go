@@ -0,0 +1,726 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: gracefulexit.proto
+
+package pb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type InitiateExitRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InitiateExitRequest) Reset()         { *m = InitiateExitRequest{} }
+func (m *InitiateExitRequest) String() string { return proto.CompactTextString(m) }
+func (*InitiateExitRequest) ProtoMessage()    {}
+func (*InitiateExitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gracefulexit_7c83b4ec2728ced0, []int{0}
+}
+func (m *InitiateExitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InitiateExitRequest.Unmarshal(m, b)
+}
+func (m *InitiateExitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InitiateExitRequest.Marshal(b, m, deterministic)
+}
+func (dst *InitiateExitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InitiateExitRequest.Merge(dst, src)
+}
+func (m *InitiateExitRequest) XXX_Size() int {
+	return xxx_messageInfo_InitiateExitRequest.Size(m)
+}
+func (m *InitiateExitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InitiateExitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InitiateExitRequest proto.InternalMessageInfo
+
+type ExitProgressRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExitProgressRequest) Reset()         { *m = ExitProgressRequest{} }
+func (m *ExitProgressRequest) String() string { return proto.CompactTextString(m) }
+func (*ExitProgressRequest) ProtoMessage()    {}
+func (*ExitProgressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gracefulexit_7c83b4ec2728ced0, []int{1}
+}
+func (m *ExitProgressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExitProgressRequest.Unmarshal(m, b)
+}
+func (m *ExitProgressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExitProgressRequest.Marshal(b, m, deterministic)
+}
+func (dst *ExitProgressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExitProgressRequest.Merge(dst, src)
+}
+func (m *ExitProgressRequest) XXX_Size() int {
+	return xxx_messageInfo_ExitProgressRequest.Size(m)
+}
+func (m *ExitProgressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExitProgressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExitProgressRequest proto.InternalMessageInfo
+
+type ExitProgress struct {
+	NodeId               string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	StartedUnixSec       int64    `protobuf:"varint,2,opt,name=started_unix_sec,json=startedUnixSec,proto3" json:"started_unix_sec,omitempty"`
+	FinishedUnixSec      int64    `protobuf:"varint,3,opt,name=finished_unix_sec,json=finishedUnixSec,proto3" json:"finished_unix_sec,omitempty"`
+	TransfersTotal       int64    `protobuf:"varint,4,opt,name=transfers_total,json=transfersTotal,proto3" json:"transfers_total,omitempty"`
+	TransfersCompleted   int64    `protobuf:"varint,5,opt,name=transfers_completed,json=transfersCompleted,proto3" json:"transfers_completed,omitempty"`
+	TransfersFailed      int64    `protobuf:"varint,6,opt,name=transfers_failed,json=transfersFailed,proto3" json:"transfers_failed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExitProgress) Reset()         { *m = ExitProgress{} }
+func (m *ExitProgress) String() string { return proto.CompactTextString(m) }
+func (*ExitProgress) ProtoMessage()    {}
+func (*ExitProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gracefulexit_7c83b4ec2728ced0, []int{2}
+}
+func (m *ExitProgress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExitProgress.Unmarshal(m, b)
+}
+func (m *ExitProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExitProgress.Marshal(b, m, deterministic)
+}
+func (dst *ExitProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExitProgress.Merge(dst, src)
+}
+func (m *ExitProgress) XXX_Size() int {
+	return xxx_messageInfo_ExitProgress.Size(m)
+}
+func (m *ExitProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExitProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExitProgress proto.InternalMessageInfo
+
+func (m *ExitProgress) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *ExitProgress) GetStartedUnixSec() int64 {
+	if m != nil {
+		return m.StartedUnixSec
+	}
+	return 0
+}
+
+func (m *ExitProgress) GetFinishedUnixSec() int64 {
+	if m != nil {
+		return m.FinishedUnixSec
+	}
+	return 0
+}
+
+func (m *ExitProgress) GetTransfersTotal() int64 {
+	if m != nil {
+		return m.TransfersTotal
+	}
+	return 0
+}
+
+func (m *ExitProgress) GetTransfersCompleted() int64 {
+	if m != nil {
+		return m.TransfersCompleted
+	}
+	return 0
+}
+
+func (m *ExitProgress) GetTransfersFailed() int64 {
+	if m != nil {
+		return m.TransfersFailed
+	}
+	return 0
+}
+
+type TransferOrder struct {
+	Path                 string                    `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	PieceNum             int32                     `protobuf:"varint,2,opt,name=piece_num,json=pieceNum,proto3" json:"piece_num,omitempty"`
+	PieceId              string                    `protobuf:"bytes,3,opt,name=piece_id,json=pieceId,proto3" json:"piece_id,omitempty"`
+	Target               *Node                     `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	TargetPieceId        string                    `protobuf:"bytes,5,opt,name=target_piece_id,json=targetPieceId,proto3" json:"target_piece_id,omitempty"`
+	ExpirationUnixSec    int64                     `protobuf:"varint,6,opt,name=expiration_unix_sec,json=expirationUnixSec,proto3" json:"expiration_unix_sec,omitempty"`
+	BandwidthAllocation  *PayerBandwidthAllocation `protobuf:"bytes,7,opt,name=bandwidth_allocation,json=bandwidthAllocation,proto3" json:"bandwidth_allocation,omitempty"`
+	Authorization        *SignedMessage            `protobuf:"bytes,8,opt,name=authorization,proto3" json:"authorization,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *TransferOrder) Reset()         { *m = TransferOrder{} }
+func (m *TransferOrder) String() string { return proto.CompactTextString(m) }
+func (*TransferOrder) ProtoMessage()    {}
+func (*TransferOrder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gracefulexit_7c83b4ec2728ced0, []int{3}
+}
+func (m *TransferOrder) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferOrder.Unmarshal(m, b)
+}
+func (m *TransferOrder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferOrder.Marshal(b, m, deterministic)
+}
+func (dst *TransferOrder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferOrder.Merge(dst, src)
+}
+func (m *TransferOrder) XXX_Size() int {
+	return xxx_messageInfo_TransferOrder.Size(m)
+}
+func (m *TransferOrder) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferOrder.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferOrder proto.InternalMessageInfo
+
+func (m *TransferOrder) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *TransferOrder) GetPieceNum() int32 {
+	if m != nil {
+		return m.PieceNum
+	}
+	return 0
+}
+
+func (m *TransferOrder) GetPieceId() string {
+	if m != nil {
+		return m.PieceId
+	}
+	return ""
+}
+
+func (m *TransferOrder) GetTarget() *Node {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+func (m *TransferOrder) GetTargetPieceId() string {
+	if m != nil {
+		return m.TargetPieceId
+	}
+	return ""
+}
+
+func (m *TransferOrder) GetExpirationUnixSec() int64 {
+	if m != nil {
+		return m.ExpirationUnixSec
+	}
+	return 0
+}
+
+func (m *TransferOrder) GetBandwidthAllocation() *PayerBandwidthAllocation {
+	if m != nil {
+		return m.BandwidthAllocation
+	}
+	return nil
+}
+
+func (m *TransferOrder) GetAuthorization() *SignedMessage {
+	if m != nil {
+		return m.Authorization
+	}
+	return nil
+}
+
+type GetTransfersRequest struct {
+	Limit                int32    `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTransfersRequest) Reset()         { *m = GetTransfersRequest{} }
+func (m *GetTransfersRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransfersRequest) ProtoMessage()    {}
+func (*GetTransfersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gracefulexit_7c83b4ec2728ced0, []int{4}
+}
+func (m *GetTransfersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransfersRequest.Unmarshal(m, b)
+}
+func (m *GetTransfersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransfersRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetTransfersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransfersRequest.Merge(dst, src)
+}
+func (m *GetTransfersRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTransfersRequest.Size(m)
+}
+func (m *GetTransfersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransfersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransfersRequest proto.InternalMessageInfo
+
+func (m *GetTransfersRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type GetTransfersResponse struct {
+	Orders               []*TransferOrder `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetTransfersResponse) Reset()         { *m = GetTransfersResponse{} }
+func (m *GetTransfersResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTransfersResponse) ProtoMessage()    {}
+func (*GetTransfersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gracefulexit_7c83b4ec2728ced0, []int{5}
+}
+func (m *GetTransfersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransfersResponse.Unmarshal(m, b)
+}
+func (m *GetTransfersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransfersResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetTransfersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransfersResponse.Merge(dst, src)
+}
+func (m *GetTransfersResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTransfersResponse.Size(m)
+}
+func (m *GetTransfersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransfersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransfersResponse proto.InternalMessageInfo
+
+func (m *GetTransfersResponse) GetOrders() []*TransferOrder {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+type CompleteTransferRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	PieceNum             int32    `protobuf:"varint,2,opt,name=piece_num,json=pieceNum,proto3" json:"piece_num,omitempty"`
+	Failed               bool     `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Hash                 []byte   `protobuf:"bytes,5,opt,name=hash,proto3" json:"hash,omitempty"`
+	Size                 int64    `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompleteTransferRequest) Reset()         { *m = CompleteTransferRequest{} }
+func (m *CompleteTransferRequest) String() string { return proto.CompactTextString(m) }
+func (*CompleteTransferRequest) ProtoMessage()    {}
+func (*CompleteTransferRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gracefulexit_7c83b4ec2728ced0, []int{6}
+}
+func (m *CompleteTransferRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompleteTransferRequest.Unmarshal(m, b)
+}
+func (m *CompleteTransferRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompleteTransferRequest.Marshal(b, m, deterministic)
+}
+func (dst *CompleteTransferRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompleteTransferRequest.Merge(dst, src)
+}
+func (m *CompleteTransferRequest) XXX_Size() int {
+	return xxx_messageInfo_CompleteTransferRequest.Size(m)
+}
+func (m *CompleteTransferRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompleteTransferRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompleteTransferRequest proto.InternalMessageInfo
+
+func (m *CompleteTransferRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *CompleteTransferRequest) GetPieceNum() int32 {
+	if m != nil {
+		return m.PieceNum
+	}
+	return 0
+}
+
+func (m *CompleteTransferRequest) GetFailed() bool {
+	if m != nil {
+		return m.Failed
+	}
+	return false
+}
+
+func (m *CompleteTransferRequest) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *CompleteTransferRequest) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *CompleteTransferRequest) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type CompleteTransferResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompleteTransferResponse) Reset()         { *m = CompleteTransferResponse{} }
+func (m *CompleteTransferResponse) String() string { return proto.CompactTextString(m) }
+func (*CompleteTransferResponse) ProtoMessage()    {}
+func (*CompleteTransferResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gracefulexit_7c83b4ec2728ced0, []int{7}
+}
+func (m *CompleteTransferResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompleteTransferResponse.Unmarshal(m, b)
+}
+func (m *CompleteTransferResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompleteTransferResponse.Marshal(b, m, deterministic)
+}
+func (dst *CompleteTransferResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompleteTransferResponse.Merge(dst, src)
+}
+func (m *CompleteTransferResponse) XXX_Size() int {
+	return xxx_messageInfo_CompleteTransferResponse.Size(m)
+}
+func (m *CompleteTransferResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompleteTransferResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompleteTransferResponse proto.InternalMessageInfo
+
+type PendingTransfer struct {
+	Order                *TransferOrder `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Failures             int32          `protobuf:"varint,2,opt,name=failures,proto3" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PendingTransfer) Reset()         { *m = PendingTransfer{} }
+func (m *PendingTransfer) String() string { return proto.CompactTextString(m) }
+func (*PendingTransfer) ProtoMessage()    {}
+func (*PendingTransfer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gracefulexit_7c83b4ec2728ced0, []int{8}
+}
+func (m *PendingTransfer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PendingTransfer.Unmarshal(m, b)
+}
+func (m *PendingTransfer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PendingTransfer.Marshal(b, m, deterministic)
+}
+func (dst *PendingTransfer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingTransfer.Merge(dst, src)
+}
+func (m *PendingTransfer) XXX_Size() int {
+	return xxx_messageInfo_PendingTransfer.Size(m)
+}
+func (m *PendingTransfer) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingTransfer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PendingTransfer proto.InternalMessageInfo
+
+func (m *PendingTransfer) GetOrder() *TransferOrder {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+func (m *PendingTransfer) GetFailures() int32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*InitiateExitRequest)(nil), "gracefulexit.InitiateExitRequest")
+	proto.RegisterType((*ExitProgressRequest)(nil), "gracefulexit.ExitProgressRequest")
+	proto.RegisterType((*ExitProgress)(nil), "gracefulexit.ExitProgress")
+	proto.RegisterType((*TransferOrder)(nil), "gracefulexit.TransferOrder")
+	proto.RegisterType((*GetTransfersRequest)(nil), "gracefulexit.GetTransfersRequest")
+	proto.RegisterType((*GetTransfersResponse)(nil), "gracefulexit.GetTransfersResponse")
+	proto.RegisterType((*CompleteTransferRequest)(nil), "gracefulexit.CompleteTransferRequest")
+	proto.RegisterType((*CompleteTransferResponse)(nil), "gracefulexit.CompleteTransferResponse")
+	proto.RegisterType((*PendingTransfer)(nil), "gracefulexit.PendingTransfer")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// GracefulExitClient is the client API for GracefulExit service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type GracefulExitClient interface {
+	// Initiate marks the storage node as exiting and queues its pieces for transfer
+	Initiate(ctx context.Context, in *InitiateExitRequest, opts ...grpc.CallOption) (*ExitProgress, error)
+	// GetTransfers returns pieces the storage node should transfer to other nodes
+	GetTransfers(ctx context.Context, in *GetTransfersRequest, opts ...grpc.CallOption) (*GetTransfersResponse, error)
+	// CompleteTransfer reports the result of a transfer
+	CompleteTransfer(ctx context.Context, in *CompleteTransferRequest, opts ...grpc.CallOption) (*CompleteTransferResponse, error)
+	// Progress returns the exit progress of the storage node
+	Progress(ctx context.Context, in *ExitProgressRequest, opts ...grpc.CallOption) (*ExitProgress, error)
+}
+
+type gracefulExitClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewGracefulExitClient(cc *grpc.ClientConn) GracefulExitClient {
+	return &gracefulExitClient{cc}
+}
+
+func (c *gracefulExitClient) Initiate(ctx context.Context, in *InitiateExitRequest, opts ...grpc.CallOption) (*ExitProgress, error) {
+	out := new(ExitProgress)
+	err := c.cc.Invoke(ctx, "/gracefulexit.GracefulExit/Initiate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gracefulExitClient) GetTransfers(ctx context.Context, in *GetTransfersRequest, opts ...grpc.CallOption) (*GetTransfersResponse, error) {
+	out := new(GetTransfersResponse)
+	err := c.cc.Invoke(ctx, "/gracefulexit.GracefulExit/GetTransfers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gracefulExitClient) CompleteTransfer(ctx context.Context, in *CompleteTransferRequest, opts ...grpc.CallOption) (*CompleteTransferResponse, error) {
+	out := new(CompleteTransferResponse)
+	err := c.cc.Invoke(ctx, "/gracefulexit.GracefulExit/CompleteTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gracefulExitClient) Progress(ctx context.Context, in *ExitProgressRequest, opts ...grpc.CallOption) (*ExitProgress, error) {
+	out := new(ExitProgress)
+	err := c.cc.Invoke(ctx, "/gracefulexit.GracefulExit/Progress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GracefulExitServer is the server API for GracefulExit service.
+type GracefulExitServer interface {
+	// Initiate marks the storage node as exiting and queues its pieces for transfer
+	Initiate(context.Context, *InitiateExitRequest) (*ExitProgress, error)
+	// GetTransfers returns pieces the storage node should transfer to other nodes
+	GetTransfers(context.Context, *GetTransfersRequest) (*GetTransfersResponse, error)
+	// CompleteTransfer reports the result of a transfer
+	CompleteTransfer(context.Context, *CompleteTransferRequest) (*CompleteTransferResponse, error)
+	// Progress returns the exit progress of the storage node
+	Progress(context.Context, *ExitProgressRequest) (*ExitProgress, error)
+}
+
+func RegisterGracefulExitServer(s *grpc.Server, srv GracefulExitServer) {
+	s.RegisterService(&_GracefulExit_serviceDesc, srv)
+}
+
+func _GracefulExit_Initiate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitiateExitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GracefulExitServer).Initiate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gracefulexit.GracefulExit/Initiate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GracefulExitServer).Initiate(ctx, req.(*InitiateExitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GracefulExit_GetTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GracefulExitServer).GetTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gracefulexit.GracefulExit/GetTransfers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GracefulExitServer).GetTransfers(ctx, req.(*GetTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GracefulExit_CompleteTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GracefulExitServer).CompleteTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gracefulexit.GracefulExit/CompleteTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GracefulExitServer).CompleteTransfer(ctx, req.(*CompleteTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GracefulExit_Progress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExitProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GracefulExitServer).Progress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gracefulexit.GracefulExit/Progress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GracefulExitServer).Progress(ctx, req.(*ExitProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _GracefulExit_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "gracefulexit.GracefulExit",
+	HandlerType: (*GracefulExitServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Initiate",
+			Handler:    _GracefulExit_Initiate_Handler,
+		},
+		{
+			MethodName: "GetTransfers",
+			Handler:    _GracefulExit_GetTransfers_Handler,
+		},
+		{
+			MethodName: "CompleteTransfer",
+			Handler:    _GracefulExit_CompleteTransfer_Handler,
+		},
+		{
+			MethodName: "Progress",
+			Handler:    _GracefulExit_Progress_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gracefulexit.proto",
+}
+
+func init() { proto.RegisterFile("gracefulexit.proto", fileDescriptor_gracefulexit_7c83b4ec2728ced0) }
+
+var fileDescriptor_gracefulexit_7c83b4ec2728ced0 = []byte{
+	// 673 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xdd, 0x6e, 0xd3, 0x4c,
+	0x10, 0x6d, 0x92, 0x26, 0x4d, 0xa7, 0xc9, 0x97, 0x74, 0xd3, 0x8f, 0x1a, 0xf7, 0x82, 0xd6, 0x52,
+	0x4b, 0x28, 0x52, 0x10, 0xed, 0x13, 0x50, 0x54, 0xaa, 0xaa, 0xa2, 0x44, 0x6e, 0x11, 0x12, 0x12,
+	0x0a, 0x1b, 0x7b, 0x92, 0xac, 0xe4, 0x78, 0xcd, 0xee, 0x1a, 0xd2, 0x5e, 0x21, 0x9e, 0x84, 0xa7,
+	0xe3, 0x39, 0x90, 0xd7, 0x5e, 0x27, 0xee, 0x0f, 0x85, 0xbb, 0x9d, 0x33, 0x67, 0x8e, 0xe7, 0xcf,
+	0x03, 0x64, 0x2c, 0xa8, 0x87, 0xa3, 0x38, 0xc0, 0x19, 0x53, 0xbd, 0x48, 0x70, 0xc5, 0x49, 0x63,
+	0x11, 0xb3, 0x9b, 0xfc, 0x2b, 0x8a, 0x80, 0x5e, 0xa5, 0x4e, 0xbb, 0x1d, 0x31, 0xf4, 0x50, 0x2a,
+	0x2e, 0x30, 0x45, 0x9c, 0xff, 0xa1, 0x73, 0x1a, 0x32, 0xc5, 0xa8, 0xc2, 0xe3, 0x19, 0x53, 0x2e,
+	0x7e, 0x89, 0x51, 0xaa, 0x04, 0x4e, 0xcc, 0xbe, 0xe0, 0x63, 0x81, 0x52, 0x1a, 0xf8, 0x47, 0x19,
+	0x1a, 0x8b, 0x38, 0xd9, 0x84, 0x95, 0x90, 0xfb, 0x38, 0x60, 0xbe, 0x55, 0xda, 0x2e, 0x75, 0x57,
+	0xdd, 0x5a, 0x62, 0x9e, 0xfa, 0xa4, 0x0b, 0x6d, 0xa9, 0xa8, 0x50, 0xe8, 0x0f, 0xe2, 0x90, 0xcd,
+	0x06, 0x12, 0x3d, 0xab, 0xbc, 0x5d, 0xea, 0x56, 0xdc, 0xff, 0x32, 0xfc, 0x7d, 0xc8, 0x66, 0x17,
+	0xe8, 0x91, 0x7d, 0x58, 0x1f, 0xb1, 0x90, 0xc9, 0xc9, 0x22, 0xb5, 0xa2, 0xa9, 0x2d, 0xe3, 0x30,
+	0xdc, 0xa7, 0xd0, 0x52, 0x82, 0x86, 0x72, 0x84, 0x42, 0x0e, 0x14, 0x57, 0x34, 0xb0, 0x96, 0x53,
+	0xd1, 0x1c, 0xbe, 0x4c, 0x50, 0xf2, 0x02, 0x3a, 0x73, 0xa2, 0xc7, 0xa7, 0x51, 0x80, 0x0a, 0x7d,
+	0xab, 0xaa, 0xc9, 0x24, 0x77, 0xbd, 0x36, 0x1e, 0xf2, 0x0c, 0xda, 0xf3, 0x80, 0x11, 0x65, 0x01,
+	0xfa, 0x56, 0x2d, 0x4d, 0x22, 0xc7, 0xdf, 0x68, 0xd8, 0xf9, 0x5e, 0x81, 0xe6, 0x65, 0x86, 0xbd,
+	0x13, 0x3e, 0x0a, 0x42, 0x60, 0x39, 0xa2, 0x6a, 0x92, 0xb5, 0x40, 0xbf, 0xc9, 0x16, 0xac, 0xea,
+	0x66, 0x0f, 0xc2, 0x78, 0xaa, 0x2b, 0xaf, 0xba, 0x75, 0x0d, 0x9c, 0xc7, 0x53, 0xf2, 0x18, 0xd2,
+	0x77, 0xd2, 0xb7, 0x8a, 0x0e, 0x5a, 0xd1, 0xf6, 0xa9, 0x4f, 0x76, 0xa1, 0xa6, 0xa8, 0x18, 0xa3,
+	0xd2, 0x95, 0xad, 0x1d, 0x34, 0x7b, 0x66, 0x84, 0xe7, 0xdc, 0x47, 0x37, 0x73, 0x92, 0x3d, 0x68,
+	0xa5, 0xaf, 0x41, 0x2e, 0x54, 0xd5, 0x42, 0xcd, 0x14, 0xee, 0x67, 0x72, 0x3d, 0xe8, 0xe0, 0x2c,
+	0x62, 0x82, 0x2a, 0xc6, 0xc3, 0x79, 0x7f, 0xd3, 0xd2, 0xd6, 0xe7, 0x2e, 0xd3, 0xe1, 0x4f, 0xb0,
+	0x31, 0xa4, 0xa1, 0xff, 0x8d, 0xf9, 0x6a, 0x32, 0xa0, 0x41, 0xc0, 0x3d, 0xed, 0xb6, 0x56, 0x74,
+	0x32, 0xfb, 0xbd, 0xf9, 0x02, 0x09, 0x1e, 0x2b, 0x94, 0xbd, 0x3e, 0xbd, 0x42, 0x71, 0x64, 0x42,
+	0x5e, 0xe5, 0x11, 0x6e, 0x67, 0x78, 0x1b, 0x24, 0xc7, 0xd0, 0xa4, 0xb1, 0x9a, 0x70, 0xc1, 0xae,
+	0x53, 0xdd, 0xba, 0xd6, 0x7d, 0x72, 0x5b, 0xf7, 0x82, 0x8d, 0x43, 0xf4, 0xdf, 0xa2, 0x94, 0x74,
+	0x8c, 0x6e, 0x31, 0xca, 0x79, 0x0e, 0x9d, 0x13, 0x54, 0x66, 0x08, 0x66, 0x3d, 0xc9, 0x06, 0x54,
+	0x03, 0x36, 0x65, 0x4a, 0x0f, 0xa2, 0xea, 0xa6, 0x86, 0x73, 0x06, 0x1b, 0x45, 0xb2, 0x8c, 0x78,
+	0x28, 0x91, 0x1c, 0x42, 0x8d, 0x27, 0xe3, 0x93, 0x56, 0x69, 0xbb, 0xd2, 0x5d, 0x3b, 0xd8, 0xea,
+	0x15, 0x7e, 0xa7, 0xc2, 0x88, 0xdd, 0x8c, 0xea, 0xfc, 0x2c, 0xc1, 0xa6, 0xd9, 0x1a, 0xc3, 0x30,
+	0x9f, 0xff, 0xe7, 0x35, 0x78, 0x04, 0xb5, 0x6c, 0xd5, 0x92, 0x25, 0xa8, 0xbb, 0x99, 0x95, 0xd4,
+	0x81, 0x42, 0x70, 0xa1, 0x57, 0x60, 0xd5, 0x4d, 0x8d, 0x44, 0x7e, 0x42, 0xe5, 0x44, 0xcf, 0xb9,
+	0xe1, 0xea, 0x77, 0x82, 0x49, 0x76, 0x8d, 0xd9, 0x3c, 0xf5, 0xdb, 0xb1, 0xc1, 0xba, 0x9d, 0x61,
+	0x5a, 0xb3, 0xf3, 0x19, 0x5a, 0x7d, 0x0c, 0x7d, 0x16, 0x8e, 0x8d, 0x8b, 0xbc, 0x84, 0xaa, 0xae,
+	0x4d, 0xa7, 0xfd, 0x40, 0x17, 0x52, 0x26, 0xb1, 0xa1, 0x9e, 0x64, 0x1a, 0x0b, 0x94, 0xa6, 0x26,
+	0x63, 0x1f, 0xfc, 0x2a, 0x43, 0xe3, 0x24, 0x53, 0x48, 0x4e, 0x05, 0x39, 0x83, 0xba, 0xb9, 0x30,
+	0x64, 0xa7, 0x28, 0x7e, 0xc7, 0xe5, 0xb1, 0xed, 0x22, 0x65, 0xf1, 0xda, 0x38, 0x4b, 0xe4, 0x03,
+	0x34, 0x16, 0x67, 0x79, 0x53, 0xf0, 0x8e, 0xa5, 0xb0, 0x9d, 0x3f, 0x51, 0xb2, 0xb6, 0x2c, 0x11,
+	0x0f, 0xda, 0x37, 0x9b, 0x46, 0x76, 0x8b, 0x91, 0xf7, 0x8c, 0xdd, 0xde, 0x7b, 0x88, 0x96, 0x7f,
+	0xe4, 0x0c, 0xea, 0xf9, 0xe5, 0xdc, 0xb9, 0xbf, 0xce, 0xbf, 0x6a, 0xc5, 0xd1, 0xf2, 0xc7, 0x72,
+	0x34, 0x1c, 0xd6, 0xf4, 0x19, 0x3f, 0xfc, 0x3d, 0x00, 0xea, 0xc2, 0xeb, 0xbd, 0x0b, 0x06, 0x00,
+	0x00,
+}
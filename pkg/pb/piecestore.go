@@ -0,0 +1,22 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pb
+
+// IsGet returns whether the action downloads a piece from the storage node
+func (action PayerBandwidthAllocation_Action) IsGet() bool {
+	switch action {
+	case PayerBandwidthAllocation_GET, PayerBandwidthAllocation_GET_AUDIT, PayerBandwidthAllocation_GET_REPAIR:
+		return true
+	}
+	return false
+}
+
+// IsPut returns whether the action uploads a piece to the storage node
+func (action PayerBandwidthAllocation_Action) IsPut() bool {
+	switch action {
+	case PayerBandwidthAllocation_PUT, PayerBandwidthAllocation_PUT_REPAIR:
+		return true
+	}
+	return false
+}
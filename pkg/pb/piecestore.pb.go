@@ -26,29 +26,39 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type PayerBandwidthAllocation_Action int32
 
 const (
-	PayerBandwidthAllocation_PUT PayerBandwidthAllocation_Action = 0
-	PayerBandwidthAllocation_GET PayerBandwidthAllocation_Action = 1
+	PayerBandwidthAllocation_PUT        PayerBandwidthAllocation_Action = 0
+	PayerBandwidthAllocation_GET        PayerBandwidthAllocation_Action = 1
+	PayerBandwidthAllocation_GET_AUDIT  PayerBandwidthAllocation_Action = 2
+	PayerBandwidthAllocation_GET_REPAIR PayerBandwidthAllocation_Action = 3
+	PayerBandwidthAllocation_PUT_REPAIR PayerBandwidthAllocation_Action = 4
 )
 
 var PayerBandwidthAllocation_Action_name = map[int32]string{
 	0: "PUT",
 	1: "GET",
+	2: "GET_AUDIT",
+	3: "GET_REPAIR",
+	4: "PUT_REPAIR",
 }
 var PayerBandwidthAllocation_Action_value = map[string]int32{
-	"PUT": 0,
-	"GET": 1,
+	"PUT":        0,
+	"GET":        1,
+	"GET_AUDIT":  2,
+	"GET_REPAIR": 3,
+	"PUT_REPAIR": 4,
 }
 
 func (x PayerBandwidthAllocation_Action) String() string {
 	return proto.EnumName(PayerBandwidthAllocation_Action_name, int32(x))
 }
 func (PayerBandwidthAllocation_Action) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{0, 0}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{0, 0}
 }
 
 type PayerBandwidthAllocation struct {
 	Signature            []byte   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
 	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Certs                [][]byte `protobuf:"bytes,3,rep,name=certs,proto3" json:"certs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -58,7 +68,7 @@ func (m *PayerBandwidthAllocation) Reset()         { *m = PayerBandwidthAllocati
 func (m *PayerBandwidthAllocation) String() string { return proto.CompactTextString(m) }
 func (*PayerBandwidthAllocation) ProtoMessage()    {}
 func (*PayerBandwidthAllocation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{0}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{0}
 }
 func (m *PayerBandwidthAllocation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PayerBandwidthAllocation.Unmarshal(m, b)
@@ -92,6 +102,13 @@ func (m *PayerBandwidthAllocation) GetData() []byte {
 	return nil
 }
 
+func (m *PayerBandwidthAllocation) GetCerts() [][]byte {
+	if m != nil {
+		return m.Certs
+	}
+	return nil
+}
+
 type PayerBandwidthAllocation_Data struct {
 	SatelliteId          []byte                          `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3" json:"satellite_id,omitempty"`
 	UplinkId             []byte                          `protobuf:"bytes,2,opt,name=uplink_id,json=uplinkId,proto3" json:"uplink_id,omitempty"`
@@ -100,6 +117,11 @@ type PayerBandwidthAllocation_Data struct {
 	SerialNumber         string                          `protobuf:"bytes,5,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
 	Action               PayerBandwidthAllocation_Action `protobuf:"varint,6,opt,name=action,proto3,enum=piecestoreroutes.PayerBandwidthAllocation_Action" json:"action,omitempty"`
 	CreatedUnixSec       int64                           `protobuf:"varint,7,opt,name=created_unix_sec,json=createdUnixSec,proto3" json:"created_unix_sec,omitempty"`
+	StorageNodeId        []byte                          `protobuf:"bytes,8,opt,name=storage_node_id,json=storageNodeId,proto3" json:"storage_node_id,omitempty"`
+	PieceId              string                          `protobuf:"bytes,9,opt,name=piece_id,json=pieceId,proto3" json:"piece_id,omitempty"`
+	UplinkPublicKey      []byte                          `protobuf:"bytes,10,opt,name=uplink_public_key,json=uplinkPublicKey,proto3" json:"uplink_public_key,omitempty"`
+	ApiKeyHash           []byte                          `protobuf:"bytes,11,opt,name=api_key_hash,json=apiKeyHash,proto3" json:"api_key_hash,omitempty"`
+	Bucket               string                          `protobuf:"bytes,12,opt,name=bucket,proto3" json:"bucket,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
 	XXX_unrecognized     []byte                          `json:"-"`
 	XXX_sizecache        int32                           `json:"-"`
@@ -109,7 +131,7 @@ func (m *PayerBandwidthAllocation_Data) Reset()         { *m = PayerBandwidthAll
 func (m *PayerBandwidthAllocation_Data) String() string { return proto.CompactTextString(m) }
 func (*PayerBandwidthAllocation_Data) ProtoMessage()    {}
 func (*PayerBandwidthAllocation_Data) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{0, 0}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{0, 0}
 }
 func (m *PayerBandwidthAllocation_Data) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PayerBandwidthAllocation_Data.Unmarshal(m, b)
@@ -178,6 +200,41 @@ func (m *PayerBandwidthAllocation_Data) GetCreatedUnixSec() int64 {
 	return 0
 }
 
+func (m *PayerBandwidthAllocation_Data) GetStorageNodeId() []byte {
+	if m != nil {
+		return m.StorageNodeId
+	}
+	return nil
+}
+
+func (m *PayerBandwidthAllocation_Data) GetPieceId() string {
+	if m != nil {
+		return m.PieceId
+	}
+	return ""
+}
+
+func (m *PayerBandwidthAllocation_Data) GetUplinkPublicKey() []byte {
+	if m != nil {
+		return m.UplinkPublicKey
+	}
+	return nil
+}
+
+func (m *PayerBandwidthAllocation_Data) GetApiKeyHash() []byte {
+	if m != nil {
+		return m.ApiKeyHash
+	}
+	return nil
+}
+
+func (m *PayerBandwidthAllocation_Data) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
 type RenterBandwidthAllocation struct {
 	Signature            []byte   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
 	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -190,7 +247,7 @@ func (m *RenterBandwidthAllocation) Reset()         { *m = RenterBandwidthAlloca
 func (m *RenterBandwidthAllocation) String() string { return proto.CompactTextString(m) }
 func (*RenterBandwidthAllocation) ProtoMessage()    {}
 func (*RenterBandwidthAllocation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{1}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{1}
 }
 func (m *RenterBandwidthAllocation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RenterBandwidthAllocation.Unmarshal(m, b)
@@ -238,7 +295,7 @@ func (m *RenterBandwidthAllocation_Data) Reset()         { *m = RenterBandwidthA
 func (m *RenterBandwidthAllocation_Data) String() string { return proto.CompactTextString(m) }
 func (*RenterBandwidthAllocation_Data) ProtoMessage()    {}
 func (*RenterBandwidthAllocation_Data) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{1, 0}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{1, 0}
 }
 func (m *RenterBandwidthAllocation_Data) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RenterBandwidthAllocation_Data.Unmarshal(m, b)
@@ -299,7 +356,7 @@ func (m *PieceStore) Reset()         { *m = PieceStore{} }
 func (m *PieceStore) String() string { return proto.CompactTextString(m) }
 func (*PieceStore) ProtoMessage()    {}
 func (*PieceStore) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{2}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{2}
 }
 func (m *PieceStore) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceStore.Unmarshal(m, b)
@@ -353,7 +410,7 @@ func (m *PieceStore_PieceData) Reset()         { *m = PieceStore_PieceData{} }
 func (m *PieceStore_PieceData) String() string { return proto.CompactTextString(m) }
 func (*PieceStore_PieceData) ProtoMessage()    {}
 func (*PieceStore_PieceData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{2, 0}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{2, 0}
 }
 func (m *PieceStore_PieceData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceStore_PieceData.Unmarshal(m, b)
@@ -406,7 +463,7 @@ func (m *PieceId) Reset()         { *m = PieceId{} }
 func (m *PieceId) String() string { return proto.CompactTextString(m) }
 func (*PieceId) ProtoMessage()    {}
 func (*PieceId) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{3}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{3}
 }
 func (m *PieceId) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceId.Unmarshal(m, b)
@@ -441,19 +498,20 @@ func (m *PieceId) GetAuthorization() *SignedMessage {
 }
 
 type PieceSummary struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Size                 int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
-	ExpirationUnixSec    int64    `protobuf:"varint,3,opt,name=expiration_unix_sec,json=expirationUnixSec,proto3" json:"expiration_unix_sec,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Id                   string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Size                 int64      `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	ExpirationUnixSec    int64      `protobuf:"varint,3,opt,name=expiration_unix_sec,json=expirationUnixSec,proto3" json:"expiration_unix_sec,omitempty"`
+	Hash                 *PieceHash `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *PieceSummary) Reset()         { *m = PieceSummary{} }
 func (m *PieceSummary) String() string { return proto.CompactTextString(m) }
 func (*PieceSummary) ProtoMessage()    {}
 func (*PieceSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{4}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{4}
 }
 func (m *PieceSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceSummary.Unmarshal(m, b)
@@ -494,6 +552,13 @@ func (m *PieceSummary) GetExpirationUnixSec() int64 {
 	return 0
 }
 
+func (m *PieceSummary) GetHash() *PieceHash {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
 type PieceRetrieval struct {
 	Bandwidthallocation  *RenterBandwidthAllocation `protobuf:"bytes,1,opt,name=bandwidthallocation,proto3" json:"bandwidthallocation,omitempty"`
 	PieceData            *PieceRetrieval_PieceData  `protobuf:"bytes,2,opt,name=pieceData,proto3" json:"pieceData,omitempty"`
@@ -507,7 +572,7 @@ func (m *PieceRetrieval) Reset()         { *m = PieceRetrieval{} }
 func (m *PieceRetrieval) String() string { return proto.CompactTextString(m) }
 func (*PieceRetrieval) ProtoMessage()    {}
 func (*PieceRetrieval) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{5}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{5}
 }
 func (m *PieceRetrieval) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceRetrieval.Unmarshal(m, b)
@@ -561,7 +626,7 @@ func (m *PieceRetrieval_PieceData) Reset()         { *m = PieceRetrieval_PieceDa
 func (m *PieceRetrieval_PieceData) String() string { return proto.CompactTextString(m) }
 func (*PieceRetrieval_PieceData) ProtoMessage()    {}
 func (*PieceRetrieval_PieceData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{5, 0}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{5, 0}
 }
 func (m *PieceRetrieval_PieceData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceRetrieval_PieceData.Unmarshal(m, b)
@@ -603,18 +668,19 @@ func (m *PieceRetrieval_PieceData) GetOffset() int64 {
 }
 
 type PieceRetrievalStream struct {
-	Size                 int64    `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
-	Content              []byte   `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Size                 int64      `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Content              []byte     `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Hash                 *PieceHash `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *PieceRetrievalStream) Reset()         { *m = PieceRetrievalStream{} }
 func (m *PieceRetrievalStream) String() string { return proto.CompactTextString(m) }
 func (*PieceRetrievalStream) ProtoMessage()    {}
 func (*PieceRetrievalStream) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{6}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{6}
 }
 func (m *PieceRetrievalStream) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceRetrievalStream.Unmarshal(m, b)
@@ -648,6 +714,13 @@ func (m *PieceRetrievalStream) GetContent() []byte {
 	return nil
 }
 
+func (m *PieceRetrievalStream) GetHash() *PieceHash {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
 type PieceDelete struct {
 	Id                   string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Authorization        *SignedMessage `protobuf:"bytes,3,opt,name=authorization,proto3" json:"authorization,omitempty"`
@@ -660,7 +733,7 @@ func (m *PieceDelete) Reset()         { *m = PieceDelete{} }
 func (m *PieceDelete) String() string { return proto.CompactTextString(m) }
 func (*PieceDelete) ProtoMessage()    {}
 func (*PieceDelete) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{7}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{7}
 }
 func (m *PieceDelete) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceDelete.Unmarshal(m, b)
@@ -705,7 +778,7 @@ func (m *PieceDeleteSummary) Reset()         { *m = PieceDeleteSummary{} }
 func (m *PieceDeleteSummary) String() string { return proto.CompactTextString(m) }
 func (*PieceDeleteSummary) ProtoMessage()    {}
 func (*PieceDeleteSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{8}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{8}
 }
 func (m *PieceDeleteSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceDeleteSummary.Unmarshal(m, b)
@@ -732,19 +805,150 @@ func (m *PieceDeleteSummary) GetMessage() string {
 	return ""
 }
 
-type PieceStoreSummary struct {
-	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	TotalReceived        int64    `protobuf:"varint,2,opt,name=totalReceived,proto3" json:"totalReceived,omitempty"`
+type PieceDeleteBatch struct {
+	Ids                  []string       `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Authorization        *SignedMessage `protobuf:"bytes,2,opt,name=authorization,proto3" json:"authorization,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PieceDeleteBatch) Reset()         { *m = PieceDeleteBatch{} }
+func (m *PieceDeleteBatch) String() string { return proto.CompactTextString(m) }
+func (*PieceDeleteBatch) ProtoMessage()    {}
+func (*PieceDeleteBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{9}
+}
+func (m *PieceDeleteBatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PieceDeleteBatch.Unmarshal(m, b)
+}
+func (m *PieceDeleteBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PieceDeleteBatch.Marshal(b, m, deterministic)
+}
+func (dst *PieceDeleteBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PieceDeleteBatch.Merge(dst, src)
+}
+func (m *PieceDeleteBatch) XXX_Size() int {
+	return xxx_messageInfo_PieceDeleteBatch.Size(m)
+}
+func (m *PieceDeleteBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_PieceDeleteBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PieceDeleteBatch proto.InternalMessageInfo
+
+func (m *PieceDeleteBatch) GetIds() []string {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+func (m *PieceDeleteBatch) GetAuthorization() *SignedMessage {
+	if m != nil {
+		return m.Authorization
+	}
+	return nil
+}
+
+type PieceDeleteBatchSummary struct {
+	Results              []*PieceDeleteBatchSummary_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *PieceDeleteBatchSummary) Reset()         { *m = PieceDeleteBatchSummary{} }
+func (m *PieceDeleteBatchSummary) String() string { return proto.CompactTextString(m) }
+func (*PieceDeleteBatchSummary) ProtoMessage()    {}
+func (*PieceDeleteBatchSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{10}
+}
+func (m *PieceDeleteBatchSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PieceDeleteBatchSummary.Unmarshal(m, b)
+}
+func (m *PieceDeleteBatchSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PieceDeleteBatchSummary.Marshal(b, m, deterministic)
+}
+func (dst *PieceDeleteBatchSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PieceDeleteBatchSummary.Merge(dst, src)
+}
+func (m *PieceDeleteBatchSummary) XXX_Size() int {
+	return xxx_messageInfo_PieceDeleteBatchSummary.Size(m)
+}
+func (m *PieceDeleteBatchSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_PieceDeleteBatchSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PieceDeleteBatchSummary proto.InternalMessageInfo
+
+func (m *PieceDeleteBatchSummary) GetResults() []*PieceDeleteBatchSummary_Result {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type PieceDeleteBatchSummary_Result struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *PieceDeleteBatchSummary_Result) Reset()         { *m = PieceDeleteBatchSummary_Result{} }
+func (m *PieceDeleteBatchSummary_Result) String() string { return proto.CompactTextString(m) }
+func (*PieceDeleteBatchSummary_Result) ProtoMessage()    {}
+func (*PieceDeleteBatchSummary_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{10, 0}
+}
+func (m *PieceDeleteBatchSummary_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PieceDeleteBatchSummary_Result.Unmarshal(m, b)
+}
+func (m *PieceDeleteBatchSummary_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PieceDeleteBatchSummary_Result.Marshal(b, m, deterministic)
+}
+func (dst *PieceDeleteBatchSummary_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PieceDeleteBatchSummary_Result.Merge(dst, src)
+}
+func (m *PieceDeleteBatchSummary_Result) XXX_Size() int {
+	return xxx_messageInfo_PieceDeleteBatchSummary_Result.Size(m)
+}
+func (m *PieceDeleteBatchSummary_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_PieceDeleteBatchSummary_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PieceDeleteBatchSummary_Result proto.InternalMessageInfo
+
+func (m *PieceDeleteBatchSummary_Result) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PieceDeleteBatchSummary_Result) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type PieceStoreSummary struct {
+	Message              string     `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	TotalReceived        int64      `protobuf:"varint,2,opt,name=totalReceived,proto3" json:"totalReceived,omitempty"`
+	Hash                 *PieceHash `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
 func (m *PieceStoreSummary) Reset()         { *m = PieceStoreSummary{} }
 func (m *PieceStoreSummary) String() string { return proto.CompactTextString(m) }
 func (*PieceStoreSummary) ProtoMessage()    {}
 func (*PieceStoreSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{9}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{11}
 }
 func (m *PieceStoreSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceStoreSummary.Unmarshal(m, b)
@@ -778,6 +982,389 @@ func (m *PieceStoreSummary) GetTotalReceived() int64 {
 	return 0
 }
 
+func (m *PieceStoreSummary) GetHash() *PieceHash {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+type PieceHash struct {
+	Signature            []byte   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PieceHash) Reset()         { *m = PieceHash{} }
+func (m *PieceHash) String() string { return proto.CompactTextString(m) }
+func (*PieceHash) ProtoMessage()    {}
+func (*PieceHash) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{12}
+}
+func (m *PieceHash) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PieceHash.Unmarshal(m, b)
+}
+func (m *PieceHash) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PieceHash.Marshal(b, m, deterministic)
+}
+func (dst *PieceHash) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PieceHash.Merge(dst, src)
+}
+func (m *PieceHash) XXX_Size() int {
+	return xxx_messageInfo_PieceHash.Size(m)
+}
+func (m *PieceHash) XXX_DiscardUnknown() {
+	xxx_messageInfo_PieceHash.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PieceHash proto.InternalMessageInfo
+
+func (m *PieceHash) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *PieceHash) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type PieceHash_Data struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hash                 []byte   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Size                 int64    `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PieceHash_Data) Reset()         { *m = PieceHash_Data{} }
+func (m *PieceHash_Data) String() string { return proto.CompactTextString(m) }
+func (*PieceHash_Data) ProtoMessage()    {}
+func (*PieceHash_Data) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{12, 0}
+}
+func (m *PieceHash_Data) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PieceHash_Data.Unmarshal(m, b)
+}
+func (m *PieceHash_Data) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PieceHash_Data.Marshal(b, m, deterministic)
+}
+func (dst *PieceHash_Data) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PieceHash_Data.Merge(dst, src)
+}
+func (m *PieceHash_Data) XXX_Size() int {
+	return xxx_messageInfo_PieceHash_Data.Size(m)
+}
+func (m *PieceHash_Data) XXX_DiscardUnknown() {
+	xxx_messageInfo_PieceHash_Data.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PieceHash_Data proto.InternalMessageInfo
+
+func (m *PieceHash_Data) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PieceHash_Data) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *PieceHash_Data) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type RetainRequest struct {
+	Filter               []byte   `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	CreatedBeforeUnixSec int64    `protobuf:"varint,2,opt,name=created_before_unix_sec,json=createdBeforeUnixSec,proto3" json:"created_before_unix_sec,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RetainRequest) Reset()         { *m = RetainRequest{} }
+func (m *RetainRequest) String() string { return proto.CompactTextString(m) }
+func (*RetainRequest) ProtoMessage()    {}
+func (*RetainRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{13}
+}
+func (m *RetainRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetainRequest.Unmarshal(m, b)
+}
+func (m *RetainRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetainRequest.Marshal(b, m, deterministic)
+}
+func (dst *RetainRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetainRequest.Merge(dst, src)
+}
+func (m *RetainRequest) XXX_Size() int {
+	return xxx_messageInfo_RetainRequest.Size(m)
+}
+func (m *RetainRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetainRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetainRequest proto.InternalMessageInfo
+
+func (m *RetainRequest) GetFilter() []byte {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *RetainRequest) GetCreatedBeforeUnixSec() int64 {
+	if m != nil {
+		return m.CreatedBeforeUnixSec
+	}
+	return 0
+}
+
+type RetainSummary struct {
+	Deleted              int64    `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RetainSummary) Reset()         { *m = RetainSummary{} }
+func (m *RetainSummary) String() string { return proto.CompactTextString(m) }
+func (*RetainSummary) ProtoMessage()    {}
+func (*RetainSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{14}
+}
+func (m *RetainSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetainSummary.Unmarshal(m, b)
+}
+func (m *RetainSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetainSummary.Marshal(b, m, deterministic)
+}
+func (dst *RetainSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetainSummary.Merge(dst, src)
+}
+func (m *RetainSummary) XXX_Size() int {
+	return xxx_messageInfo_RetainSummary.Size(m)
+}
+func (m *RetainSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetainSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetainSummary proto.InternalMessageInfo
+
+func (m *RetainSummary) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+type RestoreTrashRequest struct {
+	Authorization        *SignedMessage `protobuf:"bytes,1,opt,name=authorization,proto3" json:"authorization,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RestoreTrashRequest) Reset()         { *m = RestoreTrashRequest{} }
+func (m *RestoreTrashRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreTrashRequest) ProtoMessage()    {}
+func (*RestoreTrashRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{15}
+}
+func (m *RestoreTrashRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTrashRequest.Unmarshal(m, b)
+}
+func (m *RestoreTrashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTrashRequest.Marshal(b, m, deterministic)
+}
+func (dst *RestoreTrashRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTrashRequest.Merge(dst, src)
+}
+func (m *RestoreTrashRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreTrashRequest.Size(m)
+}
+func (m *RestoreTrashRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTrashRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTrashRequest proto.InternalMessageInfo
+
+func (m *RestoreTrashRequest) GetAuthorization() *SignedMessage {
+	if m != nil {
+		return m.Authorization
+	}
+	return nil
+}
+
+type RestoreTrashSummary struct {
+	Restored             int64    `protobuf:"varint,1,opt,name=restored,proto3" json:"restored,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreTrashSummary) Reset()         { *m = RestoreTrashSummary{} }
+func (m *RestoreTrashSummary) String() string { return proto.CompactTextString(m) }
+func (*RestoreTrashSummary) ProtoMessage()    {}
+func (*RestoreTrashSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{16}
+}
+func (m *RestoreTrashSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTrashSummary.Unmarshal(m, b)
+}
+func (m *RestoreTrashSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTrashSummary.Marshal(b, m, deterministic)
+}
+func (dst *RestoreTrashSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTrashSummary.Merge(dst, src)
+}
+func (m *RestoreTrashSummary) XXX_Size() int {
+	return xxx_messageInfo_RestoreTrashSummary.Size(m)
+}
+func (m *RestoreTrashSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTrashSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTrashSummary proto.InternalMessageInfo
+
+func (m *RestoreTrashSummary) GetRestored() int64 {
+	if m != nil {
+		return m.Restored
+	}
+	return 0
+}
+
+type PieceProof struct {
+	Id                   string                     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ShareSize            int32                      `protobuf:"varint,2,opt,name=share_size,json=shareSize,proto3" json:"share_size,omitempty"`
+	ShareIndex           int64                      `protobuf:"varint,3,opt,name=share_index,json=shareIndex,proto3" json:"share_index,omitempty"`
+	Bandwidthallocation  *RenterBandwidthAllocation `protobuf:"bytes,4,opt,name=bandwidthallocation,proto3" json:"bandwidthallocation,omitempty"`
+	Authorization        *SignedMessage             `protobuf:"bytes,5,opt,name=authorization,proto3" json:"authorization,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *PieceProof) Reset()         { *m = PieceProof{} }
+func (m *PieceProof) String() string { return proto.CompactTextString(m) }
+func (*PieceProof) ProtoMessage()    {}
+func (*PieceProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{17}
+}
+func (m *PieceProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PieceProof.Unmarshal(m, b)
+}
+func (m *PieceProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PieceProof.Marshal(b, m, deterministic)
+}
+func (dst *PieceProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PieceProof.Merge(dst, src)
+}
+func (m *PieceProof) XXX_Size() int {
+	return xxx_messageInfo_PieceProof.Size(m)
+}
+func (m *PieceProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_PieceProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PieceProof proto.InternalMessageInfo
+
+func (m *PieceProof) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PieceProof) GetShareSize() int32 {
+	if m != nil {
+		return m.ShareSize
+	}
+	return 0
+}
+
+func (m *PieceProof) GetShareIndex() int64 {
+	if m != nil {
+		return m.ShareIndex
+	}
+	return 0
+}
+
+func (m *PieceProof) GetBandwidthallocation() *RenterBandwidthAllocation {
+	if m != nil {
+		return m.Bandwidthallocation
+	}
+	return nil
+}
+
+func (m *PieceProof) GetAuthorization() *SignedMessage {
+	if m != nil {
+		return m.Authorization
+	}
+	return nil
+}
+
+type PieceProofSummary struct {
+	Share                []byte   `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	Proof                [][]byte `protobuf:"bytes,2,rep,name=proof,proto3" json:"proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PieceProofSummary) Reset()         { *m = PieceProofSummary{} }
+func (m *PieceProofSummary) String() string { return proto.CompactTextString(m) }
+func (*PieceProofSummary) ProtoMessage()    {}
+func (*PieceProofSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{18}
+}
+func (m *PieceProofSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PieceProofSummary.Unmarshal(m, b)
+}
+func (m *PieceProofSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PieceProofSummary.Marshal(b, m, deterministic)
+}
+func (dst *PieceProofSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PieceProofSummary.Merge(dst, src)
+}
+func (m *PieceProofSummary) XXX_Size() int {
+	return xxx_messageInfo_PieceProofSummary.Size(m)
+}
+func (m *PieceProofSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_PieceProofSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PieceProofSummary proto.InternalMessageInfo
+
+func (m *PieceProofSummary) GetShare() []byte {
+	if m != nil {
+		return m.Share
+	}
+	return nil
+}
+
+func (m *PieceProofSummary) GetProof() [][]byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
 type StatsReq struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -788,7 +1375,7 @@ func (m *StatsReq) Reset()         { *m = StatsReq{} }
 func (m *StatsReq) String() string { return proto.CompactTextString(m) }
 func (*StatsReq) ProtoMessage()    {}
 func (*StatsReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{10}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{19}
 }
 func (m *StatsReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatsReq.Unmarshal(m, b)
@@ -809,20 +1396,24 @@ func (m *StatsReq) XXX_DiscardUnknown() {
 var xxx_messageInfo_StatsReq proto.InternalMessageInfo
 
 type StatSummary struct {
-	UsedSpace            int64    `protobuf:"varint,1,opt,name=usedSpace,proto3" json:"usedSpace,omitempty"`
-	AvailableSpace       int64    `protobuf:"varint,2,opt,name=availableSpace,proto3" json:"availableSpace,omitempty"`
-	UsedBandwidth        int64    `protobuf:"varint,3,opt,name=usedBandwidth,proto3" json:"usedBandwidth,omitempty"`
-	AvailableBandwidth   int64    `protobuf:"varint,4,opt,name=availableBandwidth,proto3" json:"availableBandwidth,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	UsedSpace            int64             `protobuf:"varint,1,opt,name=usedSpace,proto3" json:"usedSpace,omitempty"`
+	AvailableSpace       int64             `protobuf:"varint,2,opt,name=availableSpace,proto3" json:"availableSpace,omitempty"`
+	UsedBandwidth        int64             `protobuf:"varint,3,opt,name=usedBandwidth,proto3" json:"usedBandwidth,omitempty"`
+	AvailableBandwidth   int64             `protobuf:"varint,4,opt,name=availableBandwidth,proto3" json:"availableBandwidth,omitempty"`
+	Satellites           []*SatelliteStats `protobuf:"bytes,5,rep,name=satellites,proto3" json:"satellites,omitempty"`
+	TrashSpace           int64             `protobuf:"varint,6,opt,name=trash_space,json=trashSpace,proto3" json:"trash_space,omitempty"`
+	UsedPutBandwidth     int64             `protobuf:"varint,7,opt,name=used_put_bandwidth,json=usedPutBandwidth,proto3" json:"used_put_bandwidth,omitempty"`
+	UsedGetBandwidth     int64             `protobuf:"varint,8,opt,name=used_get_bandwidth,json=usedGetBandwidth,proto3" json:"used_get_bandwidth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *StatSummary) Reset()         { *m = StatSummary{} }
 func (m *StatSummary) String() string { return proto.CompactTextString(m) }
 func (*StatSummary) ProtoMessage()    {}
 func (*StatSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{11}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{20}
 }
 func (m *StatSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatSummary.Unmarshal(m, b)
@@ -870,10 +1461,125 @@ func (m *StatSummary) GetAvailableBandwidth() int64 {
 	return 0
 }
 
+func (m *StatSummary) GetSatellites() []*SatelliteStats {
+	if m != nil {
+		return m.Satellites
+	}
+	return nil
+}
+
+func (m *StatSummary) GetTrashSpace() int64 {
+	if m != nil {
+		return m.TrashSpace
+	}
+	return 0
+}
+
+func (m *StatSummary) GetUsedPutBandwidth() int64 {
+	if m != nil {
+		return m.UsedPutBandwidth
+	}
+	return 0
+}
+
+func (m *StatSummary) GetUsedGetBandwidth() int64 {
+	if m != nil {
+		return m.UsedGetBandwidth
+	}
+	return 0
+}
+
+type SatelliteStats struct {
+	SatelliteId          []byte   `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3" json:"satellite_id,omitempty"`
+	UsedSpace            int64    `protobuf:"varint,2,opt,name=used_space,json=usedSpace,proto3" json:"used_space,omitempty"`
+	AvailableSpace       int64    `protobuf:"varint,3,opt,name=available_space,json=availableSpace,proto3" json:"available_space,omitempty"`
+	UsedBandwidth        int64    `protobuf:"varint,4,opt,name=used_bandwidth,json=usedBandwidth,proto3" json:"used_bandwidth,omitempty"`
+	AvailableBandwidth   int64    `protobuf:"varint,5,opt,name=available_bandwidth,json=availableBandwidth,proto3" json:"available_bandwidth,omitempty"`
+	UsedPutBandwidth     int64    `protobuf:"varint,6,opt,name=used_put_bandwidth,json=usedPutBandwidth,proto3" json:"used_put_bandwidth,omitempty"`
+	UsedGetBandwidth     int64    `protobuf:"varint,7,opt,name=used_get_bandwidth,json=usedGetBandwidth,proto3" json:"used_get_bandwidth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SatelliteStats) Reset()         { *m = SatelliteStats{} }
+func (m *SatelliteStats) String() string { return proto.CompactTextString(m) }
+func (*SatelliteStats) ProtoMessage()    {}
+func (*SatelliteStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{21}
+}
+func (m *SatelliteStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SatelliteStats.Unmarshal(m, b)
+}
+func (m *SatelliteStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SatelliteStats.Marshal(b, m, deterministic)
+}
+func (dst *SatelliteStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SatelliteStats.Merge(dst, src)
+}
+func (m *SatelliteStats) XXX_Size() int {
+	return xxx_messageInfo_SatelliteStats.Size(m)
+}
+func (m *SatelliteStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_SatelliteStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SatelliteStats proto.InternalMessageInfo
+
+func (m *SatelliteStats) GetSatelliteId() []byte {
+	if m != nil {
+		return m.SatelliteId
+	}
+	return nil
+}
+
+func (m *SatelliteStats) GetUsedSpace() int64 {
+	if m != nil {
+		return m.UsedSpace
+	}
+	return 0
+}
+
+func (m *SatelliteStats) GetAvailableSpace() int64 {
+	if m != nil {
+		return m.AvailableSpace
+	}
+	return 0
+}
+
+func (m *SatelliteStats) GetUsedBandwidth() int64 {
+	if m != nil {
+		return m.UsedBandwidth
+	}
+	return 0
+}
+
+func (m *SatelliteStats) GetAvailableBandwidth() int64 {
+	if m != nil {
+		return m.AvailableBandwidth
+	}
+	return 0
+}
+
+func (m *SatelliteStats) GetUsedPutBandwidth() int64 {
+	if m != nil {
+		return m.UsedPutBandwidth
+	}
+	return 0
+}
+
+func (m *SatelliteStats) GetUsedGetBandwidth() int64 {
+	if m != nil {
+		return m.UsedGetBandwidth
+	}
+	return 0
+}
+
 type SignedMessage struct {
 	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
 	PublicKey            []byte   `protobuf:"bytes,3,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	Certs                [][]byte `protobuf:"bytes,4,rep,name=certs,proto3" json:"certs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -883,7 +1589,7 @@ func (m *SignedMessage) Reset()         { *m = SignedMessage{} }
 func (m *SignedMessage) String() string { return proto.CompactTextString(m) }
 func (*SignedMessage) ProtoMessage()    {}
 func (*SignedMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_51f85e261f621d08, []int{12}
+	return fileDescriptor_piecestore_5453a1ebcf4518c3, []int{22}
 }
 func (m *SignedMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedMessage.Unmarshal(m, b)
@@ -924,6 +1630,13 @@ func (m *SignedMessage) GetPublicKey() []byte {
 	return nil
 }
 
+func (m *SignedMessage) GetCerts() [][]byte {
+	if m != nil {
+		return m.Certs
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*PayerBandwidthAllocation)(nil), "piecestoreroutes.PayerBandwidthAllocation")
 	proto.RegisterType((*PayerBandwidthAllocation_Data)(nil), "piecestoreroutes.PayerBandwidthAllocation.Data")
@@ -938,9 +1651,21 @@ func init() {
 	proto.RegisterType((*PieceRetrievalStream)(nil), "piecestoreroutes.PieceRetrievalStream")
 	proto.RegisterType((*PieceDelete)(nil), "piecestoreroutes.PieceDelete")
 	proto.RegisterType((*PieceDeleteSummary)(nil), "piecestoreroutes.PieceDeleteSummary")
+	proto.RegisterType((*PieceDeleteBatch)(nil), "piecestoreroutes.PieceDeleteBatch")
+	proto.RegisterType((*PieceDeleteBatchSummary)(nil), "piecestoreroutes.PieceDeleteBatchSummary")
+	proto.RegisterType((*PieceDeleteBatchSummary_Result)(nil), "piecestoreroutes.PieceDeleteBatchSummary.Result")
 	proto.RegisterType((*PieceStoreSummary)(nil), "piecestoreroutes.PieceStoreSummary")
+	proto.RegisterType((*PieceHash)(nil), "piecestoreroutes.PieceHash")
+	proto.RegisterType((*PieceHash_Data)(nil), "piecestoreroutes.PieceHash.Data")
+	proto.RegisterType((*RetainRequest)(nil), "piecestoreroutes.RetainRequest")
+	proto.RegisterType((*RetainSummary)(nil), "piecestoreroutes.RetainSummary")
+	proto.RegisterType((*RestoreTrashRequest)(nil), "piecestoreroutes.RestoreTrashRequest")
+	proto.RegisterType((*RestoreTrashSummary)(nil), "piecestoreroutes.RestoreTrashSummary")
+	proto.RegisterType((*PieceProof)(nil), "piecestoreroutes.PieceProof")
+	proto.RegisterType((*PieceProofSummary)(nil), "piecestoreroutes.PieceProofSummary")
 	proto.RegisterType((*StatsReq)(nil), "piecestoreroutes.StatsReq")
 	proto.RegisterType((*StatSummary)(nil), "piecestoreroutes.StatSummary")
+	proto.RegisterType((*SatelliteStats)(nil), "piecestoreroutes.SatelliteStats")
 	proto.RegisterType((*SignedMessage)(nil), "piecestoreroutes.SignedMessage")
 	proto.RegisterEnum("piecestoreroutes.PayerBandwidthAllocation_Action", PayerBandwidthAllocation_Action_name, PayerBandwidthAllocation_Action_value)
 }
@@ -961,7 +1686,11 @@ type PieceStoreRoutesClient interface {
 	Retrieve(ctx context.Context, opts ...grpc.CallOption) (PieceStoreRoutes_RetrieveClient, error)
 	Store(ctx context.Context, opts ...grpc.CallOption) (PieceStoreRoutes_StoreClient, error)
 	Delete(ctx context.Context, in *PieceDelete, opts ...grpc.CallOption) (*PieceDeleteSummary, error)
+	DeleteBatch(ctx context.Context, in *PieceDeleteBatch, opts ...grpc.CallOption) (*PieceDeleteBatchSummary, error)
 	Stats(ctx context.Context, in *StatsReq, opts ...grpc.CallOption) (*StatSummary, error)
+	Retain(ctx context.Context, in *RetainRequest, opts ...grpc.CallOption) (*RetainSummary, error)
+	RestoreTrash(ctx context.Context, in *RestoreTrashRequest, opts ...grpc.CallOption) (*RestoreTrashSummary, error)
+	Prove(ctx context.Context, in *PieceProof, opts ...grpc.CallOption) (*PieceProofSummary, error)
 }
 
 type pieceStoreRoutesClient struct {
@@ -1055,6 +1784,15 @@ func (c *pieceStoreRoutesClient) Delete(ctx context.Context, in *PieceDelete, op
 	return out, nil
 }
 
+func (c *pieceStoreRoutesClient) DeleteBatch(ctx context.Context, in *PieceDeleteBatch, opts ...grpc.CallOption) (*PieceDeleteBatchSummary, error) {
+	out := new(PieceDeleteBatchSummary)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/DeleteBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *pieceStoreRoutesClient) Stats(ctx context.Context, in *StatsReq, opts ...grpc.CallOption) (*StatSummary, error) {
 	out := new(StatSummary)
 	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/Stats", in, out, opts...)
@@ -1064,13 +1802,44 @@ func (c *pieceStoreRoutesClient) Stats(ctx context.Context, in *StatsReq, opts .
 	return out, nil
 }
 
+func (c *pieceStoreRoutesClient) Retain(ctx context.Context, in *RetainRequest, opts ...grpc.CallOption) (*RetainSummary, error) {
+	out := new(RetainSummary)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/Retain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pieceStoreRoutesClient) RestoreTrash(ctx context.Context, in *RestoreTrashRequest, opts ...grpc.CallOption) (*RestoreTrashSummary, error) {
+	out := new(RestoreTrashSummary)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/RestoreTrash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pieceStoreRoutesClient) Prove(ctx context.Context, in *PieceProof, opts ...grpc.CallOption) (*PieceProofSummary, error) {
+	out := new(PieceProofSummary)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/Prove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PieceStoreRoutesServer is the server API for PieceStoreRoutes service.
 type PieceStoreRoutesServer interface {
 	Piece(context.Context, *PieceId) (*PieceSummary, error)
 	Retrieve(PieceStoreRoutes_RetrieveServer) error
 	Store(PieceStoreRoutes_StoreServer) error
 	Delete(context.Context, *PieceDelete) (*PieceDeleteSummary, error)
+	DeleteBatch(context.Context, *PieceDeleteBatch) (*PieceDeleteBatchSummary, error)
 	Stats(context.Context, *StatsReq) (*StatSummary, error)
+	Retain(context.Context, *RetainRequest) (*RetainSummary, error)
+	RestoreTrash(context.Context, *RestoreTrashRequest) (*RestoreTrashSummary, error)
+	Prove(context.Context, *PieceProof) (*PieceProofSummary, error)
 }
 
 func RegisterPieceStoreRoutesServer(s *grpc.Server, srv PieceStoreRoutesServer) {
@@ -1165,6 +1934,24 @@ func _PieceStoreRoutes_Delete_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PieceStoreRoutes_DeleteBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PieceDeleteBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceStoreRoutesServer).DeleteBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/piecestoreroutes.PieceStoreRoutes/DeleteBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceStoreRoutesServer).DeleteBatch(ctx, req.(*PieceDeleteBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PieceStoreRoutes_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StatsReq)
 	if err := dec(in); err != nil {
@@ -1183,6 +1970,60 @@ func _PieceStoreRoutes_Stats_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PieceStoreRoutes_Retain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceStoreRoutesServer).Retain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/piecestoreroutes.PieceStoreRoutes/Retain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceStoreRoutesServer).Retain(ctx, req.(*RetainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PieceStoreRoutes_RestoreTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceStoreRoutesServer).RestoreTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/piecestoreroutes.PieceStoreRoutes/RestoreTrash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceStoreRoutesServer).RestoreTrash(ctx, req.(*RestoreTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PieceStoreRoutes_Prove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PieceProof)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceStoreRoutesServer).Prove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/piecestoreroutes.PieceStoreRoutes/Prove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceStoreRoutesServer).Prove(ctx, req.(*PieceProof))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _PieceStoreRoutes_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "piecestoreroutes.PieceStoreRoutes",
 	HandlerType: (*PieceStoreRoutesServer)(nil),
@@ -1195,10 +2036,26 @@ var _PieceStoreRoutes_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _PieceStoreRoutes_Delete_Handler,
 		},
+		{
+			MethodName: "DeleteBatch",
+			Handler:    _PieceStoreRoutes_DeleteBatch_Handler,
+		},
 		{
 			MethodName: "Stats",
 			Handler:    _PieceStoreRoutes_Stats_Handler,
 		},
+		{
+			MethodName: "Retain",
+			Handler:    _PieceStoreRoutes_Retain_Handler,
+		},
+		{
+			MethodName: "RestoreTrash",
+			Handler:    _PieceStoreRoutes_RestoreTrash_Handler,
+		},
+		{
+			MethodName: "Prove",
+			Handler:    _PieceStoreRoutes_Prove_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1216,65 +2073,102 @@ var _PieceStoreRoutes_serviceDesc = grpc.ServiceDesc{
 	Metadata: "piecestore.proto",
 }
 
-func init() { proto.RegisterFile("piecestore.proto", fileDescriptor_piecestore_51f85e261f621d08) }
-
-var fileDescriptor_piecestore_51f85e261f621d08 = []byte{
-	// 898 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x5d, 0x6e, 0xdb, 0x46,
-	0x10, 0x36, 0x49, 0x59, 0xb2, 0x46, 0x3f, 0x51, 0x36, 0x41, 0x4b, 0xb3, 0x4e, 0xab, 0x32, 0x81,
-	0x21, 0xa4, 0x80, 0xd0, 0xba, 0x27, 0x48, 0xa0, 0x20, 0x15, 0x82, 0xba, 0x06, 0x15, 0xbf, 0x04,
-	0x28, 0xd8, 0x25, 0x39, 0x71, 0x16, 0xa1, 0x48, 0x96, 0x5c, 0xba, 0x92, 0x9f, 0x7b, 0x86, 0xbe,
-	0xf4, 0x04, 0x45, 0x5f, 0x7b, 0x89, 0x9e, 0xa2, 0x57, 0x29, 0xb8, 0xbb, 0x22, 0x25, 0x4b, 0xb4,
-	0x8a, 0x20, 0x7d, 0xe3, 0xce, 0xec, 0x7c, 0xf3, 0xcd, 0xec, 0x37, 0xbb, 0x84, 0x41, 0xc2, 0xd0,
-	0xc7, 0x8c, 0xc7, 0x29, 0x8e, 0x93, 0x34, 0xe6, 0x31, 0x59, 0xb3, 0xa4, 0x71, 0xce, 0x31, 0xb3,
-	0x7f, 0x33, 0xc0, 0xbc, 0xa0, 0x4b, 0x4c, 0x9f, 0xd3, 0x28, 0xf8, 0x85, 0x05, 0xfc, 0xdd, 0xb3,
-	0x30, 0x8c, 0x7d, 0xca, 0x59, 0x1c, 0x91, 0x13, 0x68, 0x67, 0xec, 0x2a, 0xa2, 0x3c, 0x4f, 0xd1,
-	0xd4, 0x86, 0xda, 0xa8, 0xeb, 0x54, 0x06, 0x42, 0xa0, 0x11, 0x50, 0x4e, 0x4d, 0x5d, 0x38, 0xc4,
-	0xb7, 0xf5, 0xa7, 0x0e, 0x8d, 0x09, 0xe5, 0x94, 0x7c, 0x09, 0xdd, 0x8c, 0x72, 0x0c, 0x43, 0xc6,
-	0xd1, 0x65, 0x81, 0x8a, 0xee, 0x94, 0xb6, 0x69, 0x40, 0x3e, 0x83, 0x76, 0x9e, 0x84, 0x2c, 0x7a,
-	0x5f, 0xf8, 0x25, 0xc8, 0x91, 0x34, 0x4c, 0x03, 0x72, 0x0c, 0x47, 0x73, 0xba, 0x70, 0x33, 0x76,
-	0x83, 0xa6, 0x31, 0xd4, 0x46, 0x86, 0xd3, 0x9a, 0xd3, 0xc5, 0x8c, 0xdd, 0x20, 0x19, 0xc3, 0x03,
-	0x5c, 0x24, 0x2c, 0x15, 0x1c, 0xdd, 0x3c, 0x62, 0x0b, 0x37, 0x43, 0xdf, 0x6c, 0x88, 0x5d, 0xf7,
-	0x2b, 0xd7, 0x65, 0xc4, 0x16, 0x33, 0xf4, 0xc9, 0x63, 0xe8, 0x65, 0x98, 0x32, 0x1a, 0xba, 0x51,
-	0x3e, 0xf7, 0x30, 0x35, 0x0f, 0x87, 0xda, 0xa8, 0xed, 0x74, 0xa5, 0xf1, 0x5c, 0xd8, 0xc8, 0x14,
-	0x9a, 0xd4, 0x2f, 0xa2, 0xcc, 0xe6, 0x50, 0x1b, 0xf5, 0xcf, 0xbe, 0x19, 0xdf, 0x6e, 0xd5, 0xb8,
-	0xae, 0x4d, 0xe3, 0x67, 0x22, 0xd0, 0x51, 0x00, 0x64, 0x04, 0x03, 0x3f, 0x45, 0xca, 0x31, 0xa8,
-	0xc8, 0xb5, 0x04, 0xb9, 0xbe, 0xb2, 0x2b, 0x66, 0xb6, 0x05, 0x4d, 0x19, 0x4b, 0x5a, 0x60, 0x5c,
-	0x5c, 0xbe, 0x1e, 0x1c, 0x14, 0x1f, 0x2f, 0x5f, 0xbc, 0x1e, 0x68, 0xf6, 0xaf, 0x3a, 0x1c, 0x3b,
-	0x18, 0xf1, 0x8f, 0x75, 0x32, 0x7f, 0x69, 0xea, 0x64, 0x2e, 0x61, 0x90, 0x14, 0x95, 0xb8, 0xb4,
-	0x84, 0x13, 0x08, 0x9d, 0xb3, 0xa7, 0xff, 0xbd, 0x66, 0xe7, 0x9e, 0xc0, 0x58, 0x63, 0xf4, 0x10,
-	0x0e, 0x79, 0xcc, 0x69, 0x28, 0x92, 0x1a, 0x8e, 0x5c, 0x90, 0x53, 0xb8, 0x57, 0xc0, 0xd1, 0x2b,
-	0x74, 0xa3, 0x38, 0x10, 0x4a, 0x30, 0x04, 0xa9, 0x9e, 0x32, 0x9f, 0xc7, 0x41, 0xa1, 0x85, 0x4f,
-	0xa1, 0x95, 0xe4, 0x9e, 0xfb, 0x1e, 0x97, 0xe2, 0x1c, 0xbb, 0x4e, 0x33, 0xc9, 0xbd, 0x57, 0xb8,
-	0xb4, 0xff, 0xd1, 0x01, 0x2e, 0x0a, 0x56, 0xb3, 0x82, 0x15, 0xf9, 0x11, 0x1e, 0x78, 0x2b, 0x36,
-	0x5b, 0xfc, 0xbf, 0xda, 0xe6, 0x5f, 0xdb, 0x41, 0x67, 0x17, 0x0e, 0x99, 0x40, 0x5b, 0x40, 0x94,
-	0xdd, 0xeb, 0x9c, 0x9d, 0xee, 0x68, 0x4a, 0xc9, 0x47, 0x7e, 0x16, 0x6d, 0x75, 0xaa, 0x40, 0xf2,
-	0x02, 0x7a, 0x34, 0xe7, 0xef, 0xe2, 0x94, 0xdd, 0x48, 0x7a, 0x86, 0x40, 0xfa, 0x62, 0x1b, 0x69,
-	0xc6, 0xae, 0x22, 0x0c, 0xbe, 0xc7, 0x2c, 0xa3, 0x57, 0xe8, 0x6c, 0x46, 0x59, 0x08, 0xed, 0x12,
-	0x9e, 0xf4, 0x41, 0x57, 0x53, 0xd4, 0x76, 0x74, 0x16, 0xd4, 0x0d, 0x81, 0x5e, 0x37, 0x04, 0x26,
-	0xb4, 0xfc, 0x38, 0xe2, 0x18, 0x71, 0x75, 0x00, 0xab, 0xa5, 0xfd, 0x13, 0xb4, 0x44, 0x9a, 0x69,
-	0xb0, 0x95, 0x64, 0xab, 0x10, 0xfd, 0x43, 0x0a, 0xb1, 0x3d, 0xe8, 0xca, 0x96, 0xe5, 0xf3, 0x39,
-	0x4d, 0x97, 0x5b, 0x69, 0x08, 0x34, 0xc4, 0x9c, 0x4b, 0xf2, 0xe2, 0xbb, 0xae, 0x3e, 0xa3, 0xa6,
-	0x3e, 0xfb, 0x6f, 0x1d, 0xfa, 0x22, 0x89, 0x83, 0x3c, 0x65, 0x78, 0x4d, 0xc3, 0xff, 0x5b, 0x2b,
-	0xdf, 0x29, 0xad, 0x4c, 0x2a, 0xad, 0x3c, 0xad, 0xd1, 0x4a, 0xc9, 0x69, 0x4b, 0x2f, 0x93, 0x8f,
-	0xa8, 0x97, 0x97, 0x77, 0xe9, 0x65, 0x57, 0x8f, 0x3f, 0x81, 0x66, 0xfc, 0xf6, 0x6d, 0x86, 0x5c,
-	0xb5, 0x55, 0xad, 0xec, 0x09, 0x3c, 0xdc, 0xa4, 0x3d, 0xe3, 0x29, 0xd2, 0x79, 0x89, 0xa1, 0xad,
-	0x61, 0xac, 0xe9, 0x4a, 0xdf, 0xd4, 0x55, 0x00, 0x1d, 0x49, 0x07, 0x43, 0xe4, 0xb8, 0x5f, 0x5b,
-	0x1f, 0x54, 0xb4, 0x3d, 0x06, 0xb2, 0x96, 0x65, 0xa5, 0x30, 0x13, 0x5a, 0x73, 0xb9, 0x5f, 0x65,
-	0x5c, 0x2d, 0xed, 0x19, 0xdc, 0xaf, 0xc6, 0x77, 0xef, 0x76, 0xf2, 0x04, 0x7a, 0xe2, 0x22, 0x73,
-	0xd0, 0x47, 0x76, 0x8d, 0x81, 0xea, 0xdf, 0xa6, 0xd1, 0x06, 0x38, 0x9a, 0x71, 0xca, 0x33, 0x07,
-	0x7f, 0xb6, 0xff, 0xd0, 0xa0, 0x53, 0x2c, 0x56, 0xd8, 0x27, 0xd0, 0xce, 0x33, 0x0c, 0x66, 0x09,
-	0xf5, 0x57, 0x9d, 0xab, 0x0c, 0xe4, 0x14, 0xfa, 0xf4, 0x9a, 0xb2, 0x90, 0x7a, 0x21, 0xca, 0x2d,
-	0x32, 0xc1, 0x2d, 0x6b, 0xc1, 0xa3, 0x08, 0x2a, 0xc5, 0xa9, 0x4e, 0x6c, 0xd3, 0x48, 0xc6, 0x40,
-	0xca, 0xb8, 0x6a, 0xab, 0x7c, 0x18, 0x77, 0x78, 0x6c, 0x17, 0x7a, 0x1b, 0xcd, 0x2d, 0x1f, 0x0e,
-	0xad, 0x7a, 0x38, 0x36, 0x9f, 0x1a, 0xfd, 0xf6, 0x53, 0x73, 0x02, 0xed, 0x24, 0xf7, 0x42, 0xe6,
-	0xbf, 0xc2, 0xa5, 0xba, 0x59, 0x2a, 0xc3, 0xd9, 0xef, 0x06, 0x0c, 0xaa, 0x76, 0x3b, 0xe2, 0x3c,
-	0xc9, 0x04, 0x0e, 0x85, 0x8d, 0x1c, 0xd7, 0x8c, 0xcb, 0x34, 0xb0, 0x3e, 0xaf, 0xbb, 0x75, 0x65,
-	0x57, 0xed, 0x03, 0xf2, 0x06, 0x8e, 0x94, 0x3e, 0x91, 0x0c, 0xf7, 0xcd, 0x9d, 0x75, 0xba, 0x6f,
-	0x87, 0x94, 0xb8, 0x7d, 0x30, 0xd2, 0xbe, 0xd6, 0xc8, 0x39, 0x1c, 0xca, 0xe7, 0xe6, 0xe4, 0xae,
-	0xcb, 0xdf, 0x7a, 0x7c, 0x97, 0xb7, 0x64, 0x3a, 0xd2, 0xc8, 0x0f, 0xd0, 0x54, 0x53, 0xf0, 0xa8,
-	0x26, 0x44, 0xba, 0xad, 0x27, 0x77, 0xba, 0xab, 0xe2, 0x27, 0x05, 0x41, 0xca, 0x33, 0x62, 0xed,
-	0x18, 0x17, 0xa5, 0x44, 0xeb, 0xd1, 0x6e, 0x5f, 0x89, 0xf2, 0xbc, 0xf1, 0x46, 0x4f, 0x3c, 0xaf,
-	0x29, 0x7e, 0x0d, 0xbf, 0xfd, 0x37, 0x00, 0x00, 0xff, 0xff, 0xd1, 0x5b, 0xd5, 0xb9, 0x2e, 0x0a,
-	0x00, 0x00,
+func init() { proto.RegisterFile("piecestore.proto", fileDescriptor_piecestore_5453a1ebcf4518c3) }
+
+var fileDescriptor_piecestore_5453a1ebcf4518c3 = []byte{
+	// 1504 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xcd, 0x52, 0xdc, 0xc6,
+	0x16, 0x46, 0xd2, 0xfc, 0x30, 0x67, 0x86, 0x61, 0x68, 0x28, 0x5b, 0x96, 0xe1, 0x7a, 0xae, 0x6c,
+	0x73, 0xc7, 0xbe, 0xb7, 0xc6, 0x36, 0xb7, 0xb2, 0x4d, 0x02, 0x05, 0x85, 0x27, 0x4e, 0x9c, 0x29,
+	0x01, 0x1b, 0x57, 0xe2, 0x49, 0xcf, 0xe8, 0x00, 0x2a, 0x86, 0x91, 0x2c, 0xb5, 0x08, 0xb8, 0x2a,
+	0x9b, 0x94, 0xdf, 0x20, 0x95, 0xe7, 0xc8, 0x26, 0x2f, 0x91, 0x7d, 0xf6, 0xd9, 0xe6, 0x0d, 0xb2,
+	0x4d, 0xa9, 0xbb, 0xf5, 0x33, 0x3f, 0x02, 0x4c, 0x91, 0x9d, 0xce, 0x4f, 0x9f, 0xfe, 0xfa, 0x3b,
+	0xa7, 0x4f, 0x77, 0x0b, 0x1a, 0x9e, 0x83, 0x03, 0x0c, 0x98, 0xeb, 0x63, 0xdb, 0xf3, 0x5d, 0xe6,
+	0x92, 0x8c, 0xc6, 0x77, 0x43, 0x86, 0x81, 0xf9, 0xa1, 0x08, 0x7a, 0x97, 0x5e, 0xa0, 0xbf, 0x45,
+	0x47, 0xf6, 0xf7, 0x8e, 0xcd, 0x8e, 0x37, 0x87, 0x43, 0x77, 0x40, 0x99, 0xe3, 0x8e, 0xc8, 0x2a,
+	0x54, 0x02, 0xe7, 0x68, 0x44, 0x59, 0xe8, 0xa3, 0xae, 0x34, 0x95, 0x56, 0xcd, 0x4a, 0x15, 0x84,
+	0x40, 0xc1, 0xa6, 0x8c, 0xea, 0x2a, 0x37, 0xf0, 0x6f, 0xb2, 0x02, 0xc5, 0x01, 0xfa, 0x2c, 0xd0,
+	0xb5, 0xa6, 0xd6, 0xaa, 0x59, 0x42, 0x30, 0x7e, 0xd7, 0xa0, 0xb0, 0x1d, 0x99, 0xff, 0x0d, 0xb5,
+	0x80, 0x32, 0x1c, 0x0e, 0x1d, 0x86, 0x3d, 0xc7, 0x96, 0x31, 0xab, 0x89, 0xae, 0x63, 0x93, 0xfb,
+	0x50, 0x09, 0xbd, 0xa1, 0x33, 0x3a, 0x89, 0xec, 0x22, 0xf4, 0xbc, 0x50, 0x74, 0x6c, 0x72, 0x0f,
+	0xe6, 0x4f, 0xe9, 0x79, 0x2f, 0x70, 0xde, 0xa3, 0xae, 0x35, 0x95, 0x96, 0x66, 0x95, 0x4f, 0xe9,
+	0xf9, 0x9e, 0xf3, 0x1e, 0x49, 0x1b, 0x96, 0xf1, 0xdc, 0x73, 0x7c, 0x8e, 0xbc, 0x17, 0x8e, 0x9c,
+	0xf3, 0x5e, 0x80, 0x03, 0xbd, 0xc0, 0xbd, 0x96, 0x52, 0xd3, 0xc1, 0xc8, 0x39, 0xdf, 0xc3, 0x01,
+	0x79, 0x08, 0x0b, 0x01, 0xfa, 0x0e, 0x1d, 0xf6, 0x46, 0xe1, 0x69, 0x1f, 0x7d, 0xbd, 0xd8, 0x54,
+	0x5a, 0x15, 0xab, 0x26, 0x94, 0xaf, 0xb9, 0x8e, 0x74, 0xa0, 0x44, 0x07, 0xd1, 0x28, 0xbd, 0xd4,
+	0x54, 0x5a, 0xf5, 0x8d, 0x17, 0xed, 0x49, 0x02, 0xdb, 0x79, 0xe4, 0xb5, 0x37, 0xf9, 0x40, 0x4b,
+	0x06, 0x20, 0x2d, 0x68, 0x0c, 0x7c, 0xa4, 0x0c, 0xed, 0x14, 0x5c, 0x99, 0x83, 0xab, 0x4b, 0x7d,
+	0x8c, 0x6c, 0x1d, 0x16, 0xa3, 0x09, 0xe8, 0x11, 0xf6, 0x46, 0xae, 0xcd, 0x79, 0x9a, 0xe7, 0x3c,
+	0x2c, 0x48, 0xf5, 0x6b, 0xd7, 0x46, 0x41, 0x06, 0x47, 0x13, 0x39, 0x54, 0x38, 0xf8, 0x32, 0x97,
+	0x3b, 0x36, 0x79, 0x0a, 0x4b, 0x92, 0x44, 0x2f, 0xec, 0x0f, 0x9d, 0x41, 0xef, 0x04, 0x2f, 0x74,
+	0xe0, 0x41, 0x16, 0x85, 0xa1, 0xcb, 0xf5, 0xaf, 0xf0, 0x82, 0x34, 0xa1, 0x46, 0x3d, 0x27, 0xf2,
+	0xe8, 0x1d, 0xd3, 0xe0, 0x58, 0xaf, 0x72, 0x37, 0xa0, 0x9e, 0xf3, 0x0a, 0x2f, 0x5e, 0xd2, 0xe0,
+	0x98, 0xdc, 0x81, 0x52, 0x3f, 0x1c, 0x9c, 0x20, 0xd3, 0x6b, 0x7c, 0x1a, 0x29, 0x99, 0x1d, 0x28,
+	0x89, 0x45, 0x92, 0x32, 0x68, 0xdd, 0x83, 0xfd, 0xc6, 0x5c, 0xf4, 0xb1, 0xbb, 0xb3, 0xdf, 0x50,
+	0xc8, 0x02, 0x54, 0x76, 0x77, 0xf6, 0x7b, 0x9b, 0x07, 0xdb, 0x9d, 0xfd, 0x86, 0x4a, 0xea, 0x00,
+	0x91, 0x68, 0xed, 0x74, 0x37, 0x3b, 0x56, 0x43, 0x8b, 0xe4, 0xee, 0x41, 0x22, 0x17, 0xcc, 0x0f,
+	0x2a, 0xdc, 0xb3, 0x70, 0xc4, 0x6e, 0xa9, 0x0e, 0x8d, 0x5f, 0x15, 0x59, 0x71, 0x07, 0xd0, 0xf0,
+	0xa2, 0x0c, 0xf5, 0x68, 0x12, 0x8e, 0x47, 0xa8, 0x6e, 0x3c, 0xbd, 0x7e, 0x2e, 0xad, 0x45, 0x1e,
+	0x23, 0x83, 0x68, 0x05, 0x8a, 0xcc, 0x65, 0x74, 0xc8, 0x27, 0xd5, 0x2c, 0x21, 0xcc, 0xca, 0x9c,
+	0x36, 0x2b, 0x73, 0x77, 0xa1, 0xec, 0x85, 0x7d, 0x9e, 0x94, 0x02, 0xb7, 0x97, 0xbc, 0xb0, 0xff,
+	0x0a, 0x2f, 0xcc, 0x3f, 0x54, 0x80, 0x6e, 0x84, 0x6a, 0x2f, 0x42, 0x45, 0xbe, 0x85, 0xe5, 0x7e,
+	0x8c, 0x66, 0x0a, 0xff, 0x7f, 0xa7, 0xf1, 0xe7, 0x32, 0x68, 0xcd, 0x8a, 0x43, 0xb6, 0xa1, 0xc2,
+	0x43, 0x24, 0xec, 0x55, 0x37, 0xd6, 0x67, 0x90, 0x92, 0xe0, 0x11, 0x9f, 0x11, 0xad, 0x56, 0x3a,
+	0x90, 0xec, 0xc0, 0x02, 0x0d, 0xd9, 0xb1, 0xeb, 0x3b, 0xef, 0x05, 0x3c, 0x8d, 0x47, 0x7a, 0x30,
+	0x1d, 0x69, 0xcf, 0x39, 0x1a, 0xa1, 0xfd, 0x15, 0x06, 0x01, 0x3d, 0x42, 0x6b, 0x7c, 0x94, 0x81,
+	0x50, 0x49, 0xc2, 0x93, 0x3a, 0xa8, 0xb2, 0x3b, 0x54, 0x2c, 0xd5, 0xb1, 0xf3, 0x36, 0xb7, 0x9a,
+	0xb7, 0xb9, 0x75, 0x28, 0x0f, 0xdc, 0x11, 0xc3, 0x11, 0x93, 0x09, 0x88, 0x45, 0xf3, 0x3b, 0x28,
+	0x77, 0xe5, 0x26, 0x99, 0x9c, 0x64, 0x6a, 0x21, 0xea, 0x4d, 0x16, 0x62, 0xfe, 0xa4, 0x40, 0x4d,
+	0x70, 0x16, 0x9e, 0x9e, 0x52, 0xff, 0x62, 0x6a, 0x1e, 0x02, 0x05, 0xde, 0xc0, 0x04, 0x7a, 0xfe,
+	0x9d, 0xb7, 0x40, 0x2d, 0x6f, 0x81, 0xcf, 0xa0, 0xc0, 0x37, 0x6b, 0x81, 0x43, 0xbc, 0x9f, 0x93,
+	0xb5, 0x68, 0xf7, 0x5a, 0xdc, 0xd1, 0xfc, 0x4d, 0x85, 0x3a, 0xd7, 0x59, 0xc8, 0x7c, 0x07, 0xcf,
+	0xe8, 0xf0, 0x9f, 0xae, 0xae, 0x97, 0xb2, 0xba, 0xb6, 0xd3, 0xea, 0x7a, 0x9a, 0x83, 0x33, 0xc1,
+	0x34, 0x55, 0x61, 0xdb, 0xb7, 0x58, 0x61, 0xbb, 0x97, 0x55, 0xd8, 0xac, 0xa4, 0xdc, 0x81, 0x92,
+	0x7b, 0x78, 0x18, 0x20, 0x93, 0x79, 0x90, 0x92, 0x19, 0xc2, 0xca, 0x38, 0xec, 0x3d, 0xe6, 0x23,
+	0x3d, 0x4d, 0x62, 0x28, 0x99, 0x18, 0x99, 0x4a, 0x54, 0xc7, 0x2a, 0x31, 0x49, 0xa1, 0x76, 0xdd,
+	0x14, 0xda, 0x50, 0x15, 0xf8, 0x71, 0x88, 0x0c, 0xaf, 0x2e, 0xdf, 0x1b, 0xb1, 0x64, 0xb6, 0x81,
+	0x64, 0x66, 0x89, 0x6b, 0x58, 0x87, 0xf2, 0xa9, 0xf0, 0x97, 0x33, 0xc6, 0xa2, 0x79, 0x02, 0x8d,
+	0x8c, 0xff, 0x16, 0x65, 0x83, 0x63, 0xd2, 0x00, 0xcd, 0xb1, 0x03, 0x5d, 0x69, 0x6a, 0xad, 0x8a,
+	0x15, 0x7d, 0xde, 0xd6, 0xde, 0xfa, 0x59, 0x81, 0xbb, 0x93, 0xb3, 0xc5, 0x10, 0xbf, 0x80, 0xb2,
+	0x8f, 0x41, 0x38, 0x64, 0x62, 0xe2, 0xea, 0xc6, 0xf3, 0x1c, 0x4a, 0xa7, 0xc7, 0xb6, 0x2d, 0x3e,
+	0xd0, 0x8a, 0x03, 0x18, 0x6d, 0x28, 0x09, 0xd5, 0x14, 0xcb, 0x2b, 0x50, 0x44, 0xdf, 0x77, 0x7d,
+	0xbe, 0x80, 0x8a, 0x25, 0x04, 0xf3, 0x83, 0x02, 0x4b, 0x69, 0x9f, 0xbc, 0x92, 0x34, 0xf2, 0x08,
+	0x16, 0xf8, 0x89, 0x61, 0xe1, 0x00, 0x9d, 0x33, 0xb4, 0x65, 0xd9, 0x8d, 0x2b, 0x3f, 0xbe, 0x42,
+	0x7e, 0x80, 0x4a, 0xa2, 0xba, 0xc1, 0xa1, 0xf9, 0xa9, 0x3c, 0x33, 0x67, 0xec, 0x0d, 0x8e, 0x43,
+	0xfa, 0x46, 0xdf, 0x49, 0xad, 0x6b, 0x69, 0xad, 0x9b, 0x6f, 0x61, 0xc1, 0x42, 0x46, 0x9d, 0x91,
+	0x85, 0xef, 0x42, 0x0c, 0x58, 0xb4, 0x81, 0x0e, 0x9d, 0x21, 0x43, 0x5f, 0xce, 0x2f, 0x25, 0xf2,
+	0x09, 0xdc, 0x8d, 0xef, 0x42, 0x7d, 0x3c, 0x74, 0x7d, 0x9c, 0x6c, 0xe9, 0x2b, 0xd2, 0xbc, 0xc5,
+	0xad, 0xb2, 0xe9, 0x99, 0x4f, 0xe2, 0xf8, 0x19, 0x82, 0x6d, 0x9e, 0x4c, 0x5b, 0xee, 0xb9, 0x58,
+	0x34, 0xbf, 0x81, 0x65, 0x4b, 0x50, 0xb5, 0xef, 0x47, 0xfc, 0x48, 0x40, 0x53, 0x65, 0xa8, 0xdc,
+	0xa8, 0x0c, 0x5f, 0x8c, 0x47, 0x8f, 0xe1, 0x18, 0x30, 0xef, 0x0b, 0x75, 0x8c, 0x27, 0x91, 0xcd,
+	0x1f, 0xe3, 0x93, 0xbd, 0xeb, 0xbb, 0xee, 0xe1, 0x14, 0xc5, 0x6b, 0x00, 0xc1, 0x31, 0xf5, 0xb1,
+	0x97, 0x34, 0xa1, 0xa2, 0x55, 0xe1, 0x1a, 0x7e, 0xb9, 0x7d, 0x00, 0x55, 0x61, 0x76, 0x46, 0x36,
+	0x9e, 0x4b, 0xd2, 0xc5, 0x88, 0x4e, 0xa4, 0xc9, 0xeb, 0xe5, 0x85, 0x5b, 0xea, 0xe5, 0x53, 0xbc,
+	0x15, 0x6f, 0xc4, 0xdb, 0x67, 0xb0, 0x94, 0x72, 0x10, 0xb3, 0xb6, 0x02, 0x45, 0xbe, 0x10, 0x59,
+	0x23, 0x42, 0x88, 0xb4, 0x5e, 0xe4, 0xa5, 0xab, 0xe2, 0x21, 0xc1, 0x05, 0x13, 0x60, 0x7e, 0x8f,
+	0x51, 0x16, 0x58, 0xf8, 0xce, 0xfc, 0x53, 0x85, 0x6a, 0x24, 0xc4, 0x71, 0x56, 0xa1, 0x12, 0x06,
+	0x68, 0xef, 0x79, 0x74, 0x10, 0xb7, 0xe0, 0x54, 0x41, 0xd6, 0xa1, 0x4e, 0xcf, 0xa8, 0x33, 0xa4,
+	0xfd, 0x21, 0x0a, 0x17, 0x51, 0x69, 0x13, 0xda, 0x68, 0x67, 0x46, 0x83, 0x12, 0x66, 0x24, 0xd7,
+	0xe3, 0x4a, 0xd2, 0x06, 0x92, 0x8c, 0x4b, 0x5d, 0xc5, 0x5b, 0x63, 0x86, 0x85, 0x7c, 0x0e, 0x90,
+	0xbc, 0x71, 0x02, 0xbd, 0xc8, 0xdb, 0x53, 0x73, 0x06, 0x79, 0xb1, 0x8f, 0x58, 0x64, 0x66, 0x4c,
+	0x54, 0x01, 0x2c, 0xaa, 0xb5, 0x5e, 0xc0, 0xc1, 0x97, 0x44, 0x05, 0x70, 0x95, 0x00, 0xfe, 0x3f,
+	0x20, 0x11, 0xc6, 0x9e, 0x17, 0xb2, 0x5e, 0x92, 0x42, 0xf9, 0xc2, 0x68, 0x44, 0x96, 0x6e, 0xc8,
+	0x52, 0x40, 0xb1, 0xf7, 0x11, 0x66, 0xbd, 0xe7, 0x53, 0xef, 0x5d, 0x4c, 0xbd, 0xcd, 0x5f, 0x54,
+	0xa8, 0x8f, 0x63, 0xbb, 0xce, 0x4b, 0x6e, 0x0d, 0x80, 0xcf, 0x11, 0x64, 0xe8, 0xce, 0x64, 0xe4,
+	0x3f, 0xb0, 0x98, 0x30, 0x25, 0x7d, 0xb4, 0x99, 0x29, 0x79, 0x0c, 0x75, 0x1e, 0xa7, 0x3f, 0x41,
+	0xf4, 0x44, 0x4e, 0x9e, 0xc1, 0x72, 0x1a, 0x2f, 0xf5, 0x2d, 0xe6, 0x26, 0x65, 0x36, 0x63, 0xa5,
+	0x8f, 0x62, 0xac, 0x9c, 0xc3, 0x58, 0x08, 0x0b, 0x63, 0x3b, 0x21, 0xe9, 0xb7, 0x4a, 0xe6, 0xb1,
+	0x3c, 0xd6, 0xa1, 0xd5, 0xc9, 0x0e, 0xbd, 0x0a, 0x15, 0x2f, 0x7e, 0xa4, 0xc9, 0x5b, 0x6c, 0xaa,
+	0x48, 0x1f, 0xda, 0x85, 0xcc, 0x43, 0x7b, 0xe3, 0xaf, 0x22, 0x34, 0xd2, 0x73, 0xc8, 0xe2, 0x55,
+	0x45, 0xb6, 0xa1, 0xc8, 0x75, 0xe4, 0x5e, 0xce, 0x09, 0xd2, 0xb1, 0x8d, 0x7f, 0xe5, 0x98, 0xe4,
+	0xe6, 0x32, 0xe7, 0xc8, 0x1b, 0x98, 0x97, 0xf7, 0x1d, 0x24, 0xcd, 0xab, 0xee, 0x71, 0xc6, 0xfa,
+	0x55, 0x1e, 0xe2, 0xca, 0x64, 0xce, 0xb5, 0x94, 0xe7, 0x0a, 0x79, 0x0d, 0x45, 0xf1, 0xe0, 0x59,
+	0xbd, 0xec, 0xf9, 0x61, 0x3c, 0xbc, 0xcc, 0x9a, 0x20, 0x6d, 0x29, 0xe4, 0x6b, 0x28, 0xc9, 0x4b,
+	0xd2, 0xda, 0xa5, 0x77, 0x00, 0xe3, 0xd1, 0xa5, 0xe6, 0x74, 0xf1, 0x6f, 0xa1, 0x9a, 0xbd, 0xdf,
+	0x98, 0x57, 0xdf, 0x2c, 0x8c, 0x27, 0xd7, 0xbe, 0x7d, 0x98, 0x73, 0x51, 0x8a, 0xc4, 0xb6, 0x32,
+	0xa6, 0x47, 0xc5, 0x0d, 0xcf, 0x58, 0x9b, 0x6d, 0x4b, 0xa3, 0x7c, 0x09, 0x25, 0x71, 0x3e, 0x92,
+	0x07, 0xb3, 0x3a, 0x7e, 0xe6, 0x64, 0x36, 0x72, 0x1d, 0xb2, 0x6b, 0xae, 0x65, 0x0f, 0x39, 0xf2,
+	0x78, 0xd6, 0x90, 0xa9, 0x23, 0xd6, 0xb8, 0xc2, 0x2d, 0x8b, 0xb6, 0xd8, 0xf5, 0xdd, 0xb3, 0xfc,
+	0xa4, 0xf3, 0x53, 0xc2, 0x78, 0x78, 0x99, 0x35, 0x89, 0xb6, 0x55, 0x78, 0xa3, 0x7a, 0xfd, 0x7e,
+	0x89, 0xff, 0xe6, 0xfa, 0xff, 0xdf, 0x03, 0x00, 0xf2, 0x20, 0x1a, 0xf9, 0xfa, 0x12, 0x00, 0x00,
 }
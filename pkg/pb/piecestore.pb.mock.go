@@ -57,6 +57,24 @@ func (mr *MockPieceStoreRoutesClientMockRecorder) Delete(arg0, arg1 interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).Delete), varargs...)
 }
 
+// DeleteBatch mocks base method
+func (m *MockPieceStoreRoutesClient) DeleteBatch(arg0 context.Context, arg1 *PieceDeleteBatch, arg2 ...grpc.CallOption) (*PieceDeleteBatchSummary, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteBatch", varargs...)
+	ret0, _ := ret[0].(*PieceDeleteBatchSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBatch indicates an expected call of DeleteBatch
+func (mr *MockPieceStoreRoutesClientMockRecorder) DeleteBatch(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatch", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).DeleteBatch), varargs...)
+}
+
 // Piece mocks base method
 func (m *MockPieceStoreRoutesClient) Piece(arg0 context.Context, arg1 *PieceId, arg2 ...grpc.CallOption) (*PieceSummary, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -75,6 +93,60 @@ func (mr *MockPieceStoreRoutesClientMockRecorder) Piece(arg0, arg1 interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Piece", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).Piece), varargs...)
 }
 
+// Prove mocks base method
+func (m *MockPieceStoreRoutesClient) Prove(arg0 context.Context, arg1 *PieceProof, arg2 ...grpc.CallOption) (*PieceProofSummary, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Prove", varargs...)
+	ret0, _ := ret[0].(*PieceProofSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prove indicates an expected call of Prove
+func (mr *MockPieceStoreRoutesClientMockRecorder) Prove(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prove", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).Prove), varargs...)
+}
+
+// RestoreTrash mocks base method
+func (m *MockPieceStoreRoutesClient) RestoreTrash(arg0 context.Context, arg1 *RestoreTrashRequest, arg2 ...grpc.CallOption) (*RestoreTrashSummary, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RestoreTrash", varargs...)
+	ret0, _ := ret[0].(*RestoreTrashSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTrash indicates an expected call of RestoreTrash
+func (mr *MockPieceStoreRoutesClientMockRecorder) RestoreTrash(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTrash", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).RestoreTrash), varargs...)
+}
+
+// Retain mocks base method
+func (m *MockPieceStoreRoutesClient) Retain(arg0 context.Context, arg1 *RetainRequest, arg2 ...grpc.CallOption) (*RetainSummary, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Retain", varargs...)
+	ret0, _ := ret[0].(*RetainSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Retain indicates an expected call of Retain
+func (mr *MockPieceStoreRoutesClientMockRecorder) Retain(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retain", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).Retain), varargs...)
+}
+
 // Retrieve mocks base method
 func (m *MockPieceStoreRoutesClient) Retrieve(arg0 context.Context, arg1 ...grpc.CallOption) (PieceStoreRoutes_RetrieveClient, error) {
 	varargs := []interface{}{arg0}
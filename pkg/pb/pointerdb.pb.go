@@ -41,7 +41,7 @@ func (x RedundancyScheme_SchemeType) String() string {
 	return proto.EnumName(RedundancyScheme_SchemeType_name, int32(x))
 }
 func (RedundancyScheme_SchemeType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_a8e1675f0e1f1a65, []int{0, 0}
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{0, 0}
 }
 
 type Pointer_DataType int32
@@ -64,7 +64,7 @@ func (x Pointer_DataType) String() string {
 	return proto.EnumName(Pointer_DataType_name, int32(x))
 }
 func (Pointer_DataType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_a8e1675f0e1f1a65, []int{3, 0}
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{3, 0}
 }
 
 type RedundancyScheme struct {
@@ -84,7 +84,7 @@ func (m *RedundancyScheme) Reset()         { *m = RedundancyScheme{} }
 func (m *RedundancyScheme) String() string { return proto.CompactTextString(m) }
 func (*RedundancyScheme) ProtoMessage()    {}
 func (*RedundancyScheme) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_a8e1675f0e1f1a65, []int{0}
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{0}
 }
 func (m *RedundancyScheme) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RedundancyScheme.Unmarshal(m, b)
@@ -158,7 +158,7 @@ func (m *RemotePiece) Reset()         { *m = RemotePiece{} }
 func (m *RemotePiece) String() string { return proto.CompactTextString(m) }
 func (*RemotePiece) ProtoMessage()    {}
 func (*RemotePiece) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_a8e1675f0e1f1a65, []int{1}
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{1}
 }
 func (m *RemotePiece) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemotePiece.Unmarshal(m, b)
@@ -197,6 +197,7 @@ type RemoteSegment struct {
 	PieceId              string            `protobuf:"bytes,2,opt,name=piece_id,json=pieceId,proto3" json:"piece_id,omitempty"`
 	RemotePieces         []*RemotePiece    `protobuf:"bytes,3,rep,name=remote_pieces,json=remotePieces,proto3" json:"remote_pieces,omitempty"`
 	MerkleRoot           []byte            `protobuf:"bytes,4,opt,name=merkle_root,json=merkleRoot,proto3" json:"merkle_root,omitempty"`
+	PieceRoots           [][]byte          `protobuf:"bytes,5,rep,name=piece_roots,json=pieceRoots,proto3" json:"piece_roots,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -206,7 +207,7 @@ func (m *RemoteSegment) Reset()         { *m = RemoteSegment{} }
 func (m *RemoteSegment) String() string { return proto.CompactTextString(m) }
 func (*RemoteSegment) ProtoMessage()    {}
 func (*RemoteSegment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_a8e1675f0e1f1a65, []int{2}
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{2}
 }
 func (m *RemoteSegment) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoteSegment.Unmarshal(m, b)
@@ -254,6 +255,13 @@ func (m *RemoteSegment) GetMerkleRoot() []byte {
 	return nil
 }
 
+func (m *RemoteSegment) GetPieceRoots() [][]byte {
+	if m != nil {
+		return m.PieceRoots
+	}
+	return nil
+}
+
 type Pointer struct {
 	Type                 Pointer_DataType     `protobuf:"varint,1,opt,name=type,proto3,enum=pointerdb.Pointer_DataType" json:"type,omitempty"`
 	InlineSegment        []byte               `protobuf:"bytes,3,opt,name=inline_segment,json=inlineSegment,proto3" json:"inline_segment,omitempty"`
@@ -271,7 +279,7 @@ func (m *Pointer) Reset()         { *m = Pointer{} }
 func (m *Pointer) String() string { return proto.CompactTextString(m) }
 func (*Pointer) ProtoMessage()    {}
 func (*Pointer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_a8e1675f0e1f1a65, []int{3}
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{3}
 }
 func (m *Pointer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Pointer.Unmarshal(m, b)
@@ -353,7 +361,7 @@ func (m *PutRequest) Reset()         { *m = PutRequest{} }
 func (m *PutRequest) String() string { return proto.CompactTextString(m) }
 func (*PutRequest) ProtoMessage()    {}
 func (*PutRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_a8e1675f0e1f1a65, []int{4}
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{4}
 }
 func (m *PutRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutRequest.Unmarshal(m, b)
@@ -399,7 +407,7 @@ func (m *GetRequest) Reset()         { *m = GetRequest{} }
 func (m *GetRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRequest) ProtoMessage()    {}
 func (*GetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_a8e1675f0e1f1a65, []int{5}
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{5}
 }
 func (m *GetRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetRequest.Unmarshal(m, b)
@@ -443,7 +451,7 @@ func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_a8e1675f0e1f1a65, []int{6}
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{6}
 }
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
@@ -516,7 +524,7 @@ func (m *PutResponse) Reset()         { *m = PutResponse{} }
 func (m *PutResponse) String() string { return proto.CompactTextString(m) }
 func (*PutResponse) ProtoMessage()    {}
 func (*PutResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_a8e1675f0e1f1a65, []int{7}
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{7}
 }
 func (m *PutResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutResponse.Unmarshal(m, b)
@@ -538,20 +546,21 @@ var xxx_messageInfo_PutResponse proto.InternalMessageInfo
 
 // GetResponse is a response message for the Get rpc call
 type GetResponse struct {
-	Pointer              *Pointer                  `protobuf:"bytes,1,opt,name=pointer,proto3" json:"pointer,omitempty"`
-	Nodes                []*Node                   `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	Pba                  *PayerBandwidthAllocation `protobuf:"bytes,3,opt,name=pba,proto3" json:"pba,omitempty"`
-	Authorization        *SignedMessage            `protobuf:"bytes,4,opt,name=authorization,proto3" json:"authorization,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	Pointer              *Pointer                    `protobuf:"bytes,1,opt,name=pointer,proto3" json:"pointer,omitempty"`
+	Nodes                []*Node                     `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Pba                  *PayerBandwidthAllocation   `protobuf:"bytes,3,opt,name=pba,proto3" json:"pba,omitempty"`
+	Authorization        *SignedMessage              `protobuf:"bytes,4,opt,name=authorization,proto3" json:"authorization,omitempty"`
+	OrderLimits          []*PayerBandwidthAllocation `protobuf:"bytes,5,rep,name=order_limits,json=orderLimits,proto3" json:"order_limits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
 }
 
 func (m *GetResponse) Reset()         { *m = GetResponse{} }
 func (m *GetResponse) String() string { return proto.CompactTextString(m) }
 func (*GetResponse) ProtoMessage()    {}
 func (*GetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_a8e1675f0e1f1a65, []int{8}
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{8}
 }
 func (m *GetResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetResponse.Unmarshal(m, b)
@@ -599,6 +608,13 @@ func (m *GetResponse) GetAuthorization() *SignedMessage {
 	return nil
 }
 
+func (m *GetResponse) GetOrderLimits() []*PayerBandwidthAllocation {
+	if m != nil {
+		return m.OrderLimits
+	}
+	return nil
+}
+
 // ListResponse is a response message for the List rpc call
 type ListResponse struct {
 	Items                []*ListResponse_Item `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
@@ -612,7 +628,7 @@ func (m *ListResponse) Reset()         { *m = ListResponse{} }
 func (m *ListResponse) String() string { return proto.CompactTextString(m) }
 func (*ListResponse) ProtoMessage()    {}
 func (*ListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_a8e1675f0e1f1a65, []int{9}
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{9}
 }
 func (m *ListResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListResponse.Unmarshal(m, b)
@@ -659,7 +675,7 @@ func (m *ListResponse_Item) Reset()         { *m = ListResponse_Item{} }
 func (m *ListResponse_Item) String() string { return proto.CompactTextString(m) }
 func (*ListResponse_Item) ProtoMessage()    {}
 func (*ListResponse_Item) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_a8e1675f0e1f1a65, []int{9, 0}
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{9, 0}
 }
 func (m *ListResponse_Item) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListResponse_Item.Unmarshal(m, b)
@@ -711,7 +727,7 @@ func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
 func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRequest) ProtoMessage()    {}
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_a8e1675f0e1f1a65, []int{10}
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{10}
 }
 func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
@@ -749,7 +765,7 @@ func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
 func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteResponse) ProtoMessage()    {}
 func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_a8e1675f0e1f1a65, []int{11}
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{11}
 }
 func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteResponse.Unmarshal(m, b)
@@ -784,7 +800,7 @@ func (m *IterateRequest) Reset()         { *m = IterateRequest{} }
 func (m *IterateRequest) String() string { return proto.CompactTextString(m) }
 func (*IterateRequest) ProtoMessage()    {}
 func (*IterateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_a8e1675f0e1f1a65, []int{12}
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{12}
 }
 func (m *IterateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IterateRequest.Unmarshal(m, b)
@@ -832,6 +848,202 @@ func (m *IterateRequest) GetReverse() bool {
 	return false
 }
 
+// PayerBandwidthAllocationRequest is a request message for the PayerBandwidthAllocation rpc call
+type PayerBandwidthAllocationRequest struct {
+	Action               PayerBandwidthAllocation_Action `protobuf:"varint,1,opt,name=action,proto3,enum=piecestoreroutes.PayerBandwidthAllocation_Action" json:"action,omitempty"`
+	Bucket               string                          `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *PayerBandwidthAllocationRequest) Reset()         { *m = PayerBandwidthAllocationRequest{} }
+func (m *PayerBandwidthAllocationRequest) String() string { return proto.CompactTextString(m) }
+func (*PayerBandwidthAllocationRequest) ProtoMessage()    {}
+func (*PayerBandwidthAllocationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{13}
+}
+func (m *PayerBandwidthAllocationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PayerBandwidthAllocationRequest.Unmarshal(m, b)
+}
+func (m *PayerBandwidthAllocationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PayerBandwidthAllocationRequest.Marshal(b, m, deterministic)
+}
+func (dst *PayerBandwidthAllocationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PayerBandwidthAllocationRequest.Merge(dst, src)
+}
+func (m *PayerBandwidthAllocationRequest) XXX_Size() int {
+	return xxx_messageInfo_PayerBandwidthAllocationRequest.Size(m)
+}
+func (m *PayerBandwidthAllocationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PayerBandwidthAllocationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PayerBandwidthAllocationRequest proto.InternalMessageInfo
+
+func (m *PayerBandwidthAllocationRequest) GetAction() PayerBandwidthAllocation_Action {
+	if m != nil {
+		return m.Action
+	}
+	return PayerBandwidthAllocation_PUT
+}
+
+func (m *PayerBandwidthAllocationRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+// PayerBandwidthAllocationResponse is a response message for the PayerBandwidthAllocation rpc call
+type PayerBandwidthAllocationResponse struct {
+	Pba                  *PayerBandwidthAllocation `protobuf:"bytes,1,opt,name=pba,proto3" json:"pba,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *PayerBandwidthAllocationResponse) Reset()         { *m = PayerBandwidthAllocationResponse{} }
+func (m *PayerBandwidthAllocationResponse) String() string { return proto.CompactTextString(m) }
+func (*PayerBandwidthAllocationResponse) ProtoMessage()    {}
+func (*PayerBandwidthAllocationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{14}
+}
+func (m *PayerBandwidthAllocationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PayerBandwidthAllocationResponse.Unmarshal(m, b)
+}
+func (m *PayerBandwidthAllocationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PayerBandwidthAllocationResponse.Marshal(b, m, deterministic)
+}
+func (dst *PayerBandwidthAllocationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PayerBandwidthAllocationResponse.Merge(dst, src)
+}
+func (m *PayerBandwidthAllocationResponse) XXX_Size() int {
+	return xxx_messageInfo_PayerBandwidthAllocationResponse.Size(m)
+}
+func (m *PayerBandwidthAllocationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PayerBandwidthAllocationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PayerBandwidthAllocationResponse proto.InternalMessageInfo
+
+func (m *PayerBandwidthAllocationResponse) GetPba() *PayerBandwidthAllocation {
+	if m != nil {
+		return m.Pba
+	}
+	return nil
+}
+
+// OrderLimitsRequest is a request message for the OrderLimits rpc call
+type OrderLimitsRequest struct {
+	Action               PayerBandwidthAllocation_Action `protobuf:"varint,1,opt,name=action,proto3,enum=piecestoreroutes.PayerBandwidthAllocation_Action" json:"action,omitempty"`
+	PieceId              string                          `protobuf:"bytes,2,opt,name=piece_id,json=pieceId,proto3" json:"piece_id,omitempty"`
+	NodeIds              []string                        `protobuf:"bytes,3,rep,name=node_ids,json=nodeIds,proto3" json:"node_ids,omitempty"`
+	MaxSize              int64                           `protobuf:"varint,4,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	Bucket               string                          `protobuf:"bytes,5,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *OrderLimitsRequest) Reset()         { *m = OrderLimitsRequest{} }
+func (m *OrderLimitsRequest) String() string { return proto.CompactTextString(m) }
+func (*OrderLimitsRequest) ProtoMessage()    {}
+func (*OrderLimitsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{15}
+}
+func (m *OrderLimitsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderLimitsRequest.Unmarshal(m, b)
+}
+func (m *OrderLimitsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderLimitsRequest.Marshal(b, m, deterministic)
+}
+func (dst *OrderLimitsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderLimitsRequest.Merge(dst, src)
+}
+func (m *OrderLimitsRequest) XXX_Size() int {
+	return xxx_messageInfo_OrderLimitsRequest.Size(m)
+}
+func (m *OrderLimitsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderLimitsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderLimitsRequest proto.InternalMessageInfo
+
+func (m *OrderLimitsRequest) GetAction() PayerBandwidthAllocation_Action {
+	if m != nil {
+		return m.Action
+	}
+	return PayerBandwidthAllocation_PUT
+}
+
+func (m *OrderLimitsRequest) GetPieceId() string {
+	if m != nil {
+		return m.PieceId
+	}
+	return ""
+}
+
+func (m *OrderLimitsRequest) GetNodeIds() []string {
+	if m != nil {
+		return m.NodeIds
+	}
+	return nil
+}
+
+func (m *OrderLimitsRequest) GetMaxSize() int64 {
+	if m != nil {
+		return m.MaxSize
+	}
+	return 0
+}
+
+func (m *OrderLimitsRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+// OrderLimitsResponse is a response message for the OrderLimits rpc call
+type OrderLimitsResponse struct {
+	OrderLimits          []*PayerBandwidthAllocation `protobuf:"bytes,1,rep,name=order_limits,json=orderLimits,proto3" json:"order_limits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *OrderLimitsResponse) Reset()         { *m = OrderLimitsResponse{} }
+func (m *OrderLimitsResponse) String() string { return proto.CompactTextString(m) }
+func (*OrderLimitsResponse) ProtoMessage()    {}
+func (*OrderLimitsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_f8882cc4aaf7fa7f, []int{16}
+}
+func (m *OrderLimitsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderLimitsResponse.Unmarshal(m, b)
+}
+func (m *OrderLimitsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderLimitsResponse.Marshal(b, m, deterministic)
+}
+func (dst *OrderLimitsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderLimitsResponse.Merge(dst, src)
+}
+func (m *OrderLimitsResponse) XXX_Size() int {
+	return xxx_messageInfo_OrderLimitsResponse.Size(m)
+}
+func (m *OrderLimitsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderLimitsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderLimitsResponse proto.InternalMessageInfo
+
+func (m *OrderLimitsResponse) GetOrderLimits() []*PayerBandwidthAllocation {
+	if m != nil {
+		return m.OrderLimits
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*RedundancyScheme)(nil), "pointerdb.RedundancyScheme")
 	proto.RegisterType((*RemotePiece)(nil), "pointerdb.RemotePiece")
@@ -847,6 +1059,10 @@ func init() {
 	proto.RegisterType((*DeleteRequest)(nil), "pointerdb.DeleteRequest")
 	proto.RegisterType((*DeleteResponse)(nil), "pointerdb.DeleteResponse")
 	proto.RegisterType((*IterateRequest)(nil), "pointerdb.IterateRequest")
+	proto.RegisterType((*PayerBandwidthAllocationRequest)(nil), "pointerdb.PayerBandwidthAllocationRequest")
+	proto.RegisterType((*PayerBandwidthAllocationResponse)(nil), "pointerdb.PayerBandwidthAllocationResponse")
+	proto.RegisterType((*OrderLimitsRequest)(nil), "pointerdb.OrderLimitsRequest")
+	proto.RegisterType((*OrderLimitsResponse)(nil), "pointerdb.OrderLimitsResponse")
 	proto.RegisterEnum("pointerdb.RedundancyScheme_SchemeType", RedundancyScheme_SchemeType_name, RedundancyScheme_SchemeType_value)
 	proto.RegisterEnum("pointerdb.Pointer_DataType", Pointer_DataType_name, Pointer_DataType_value)
 }
@@ -871,6 +1087,10 @@ type PointerDBClient interface {
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
 	// Delete formats and hands off a file path to delete from boltdb
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// PayerBandwidthAllocation returns a new bandwidth allocation paid by the satellite
+	PayerBandwidthAllocation(ctx context.Context, in *PayerBandwidthAllocationRequest, opts ...grpc.CallOption) (*PayerBandwidthAllocationResponse, error)
+	// OrderLimits returns a bandwidth allocation paid by the satellite for each of the storage nodes
+	OrderLimits(ctx context.Context, in *OrderLimitsRequest, opts ...grpc.CallOption) (*OrderLimitsResponse, error)
 }
 
 type pointerDBClient struct {
@@ -917,6 +1137,24 @@ func (c *pointerDBClient) Delete(ctx context.Context, in *DeleteRequest, opts ..
 	return out, nil
 }
 
+func (c *pointerDBClient) PayerBandwidthAllocation(ctx context.Context, in *PayerBandwidthAllocationRequest, opts ...grpc.CallOption) (*PayerBandwidthAllocationResponse, error) {
+	out := new(PayerBandwidthAllocationResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/PayerBandwidthAllocation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pointerDBClient) OrderLimits(ctx context.Context, in *OrderLimitsRequest, opts ...grpc.CallOption) (*OrderLimitsResponse, error) {
+	out := new(OrderLimitsResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/OrderLimits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PointerDBServer is the server API for PointerDB service.
 type PointerDBServer interface {
 	// Put formats and hands off a file path to be saved to boltdb
@@ -927,6 +1165,10 @@ type PointerDBServer interface {
 	List(context.Context, *ListRequest) (*ListResponse, error)
 	// Delete formats and hands off a file path to delete from boltdb
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// PayerBandwidthAllocation returns a new bandwidth allocation paid by the satellite
+	PayerBandwidthAllocation(context.Context, *PayerBandwidthAllocationRequest) (*PayerBandwidthAllocationResponse, error)
+	// OrderLimits returns a bandwidth allocation paid by the satellite for each of the storage nodes
+	OrderLimits(context.Context, *OrderLimitsRequest) (*OrderLimitsResponse, error)
 }
 
 func RegisterPointerDBServer(s *grpc.Server, srv PointerDBServer) {
@@ -1005,6 +1247,42 @@ func _PointerDB_Delete_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PointerDB_PayerBandwidthAllocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PayerBandwidthAllocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).PayerBandwidthAllocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/PayerBandwidthAllocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).PayerBandwidthAllocation(ctx, req.(*PayerBandwidthAllocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PointerDB_OrderLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).OrderLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/OrderLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).OrderLimits(ctx, req.(*OrderLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _PointerDB_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pointerdb.PointerDB",
 	HandlerType: (*PointerDBServer)(nil),
@@ -1025,76 +1303,96 @@ var _PointerDB_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _PointerDB_Delete_Handler,
 		},
+		{
+			MethodName: "PayerBandwidthAllocation",
+			Handler:    _PointerDB_PayerBandwidthAllocation_Handler,
+		},
+		{
+			MethodName: "OrderLimits",
+			Handler:    _PointerDB_OrderLimits_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pointerdb.proto",
 }
 
-func init() { proto.RegisterFile("pointerdb.proto", fileDescriptor_pointerdb_a8e1675f0e1f1a65) }
-
-var fileDescriptor_pointerdb_a8e1675f0e1f1a65 = []byte{
-	// 1003 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x5f, 0x6f, 0x1b, 0x45,
-	0x10, 0xaf, 0xff, 0xdb, 0xe3, 0x38, 0x35, 0xab, 0x90, 0xba, 0x6e, 0x51, 0xa2, 0xab, 0x40, 0x01,
-	0xaa, 0x0b, 0x32, 0x95, 0x90, 0x28, 0x08, 0x35, 0x4d, 0xa8, 0x2c, 0xb5, 0xc1, 0x5a, 0xe7, 0x89,
-	0x97, 0xd3, 0xda, 0x37, 0xb6, 0x57, 0xdc, 0xdd, 0x5e, 0x76, 0xf7, 0x42, 0x93, 0x6f, 0xc2, 0x37,
-	0xe1, 0x85, 0x47, 0x3e, 0x0a, 0x8f, 0x88, 0xaf, 0x80, 0x76, 0xf7, 0xce, 0x3e, 0x37, 0x34, 0x7d,
-	0xe0, 0xc5, 0xbe, 0x99, 0xf9, 0xcd, 0xec, 0xce, 0xfc, 0x7e, 0xb3, 0x70, 0x3f, 0x15, 0x3c, 0xd1,
-	0x28, 0xc3, 0x99, 0x9f, 0x4a, 0xa1, 0x05, 0xe9, 0xac, 0x1d, 0xc3, 0x83, 0xa5, 0x10, 0xcb, 0x08,
-	0x8f, 0x6d, 0x60, 0x96, 0x2d, 0x8e, 0x35, 0x8f, 0x51, 0x69, 0x16, 0xa7, 0x0e, 0x3b, 0xec, 0x89,
-	0x2b, 0x94, 0x11, 0xbb, 0xce, 0xcd, 0x7e, 0xca, 0x71, 0x8e, 0x4a, 0x0b, 0x89, 0xce, 0xe3, 0xfd,
-	0x56, 0x85, 0x3e, 0xc5, 0x30, 0x4b, 0x42, 0x96, 0xcc, 0xaf, 0xa7, 0xf3, 0x15, 0xc6, 0x48, 0xbe,
-	0x85, 0xba, 0xbe, 0x4e, 0x71, 0x50, 0x39, 0xac, 0x1c, 0xed, 0x8e, 0x3e, 0xf3, 0x37, 0x37, 0x78,
-	0x17, 0xea, 0xbb, 0xbf, 0x8b, 0xeb, 0x14, 0xa9, 0xcd, 0x21, 0x0f, 0xa0, 0x15, 0xf3, 0x24, 0x90,
-	0x78, 0x39, 0xa8, 0x1e, 0x56, 0x8e, 0x1a, 0xb4, 0x19, 0xf3, 0x84, 0xe2, 0x25, 0xd9, 0x83, 0x86,
-	0x16, 0x9a, 0x45, 0x83, 0x9a, 0x75, 0x3b, 0x83, 0x7c, 0x0e, 0x7d, 0x89, 0x29, 0xe3, 0x32, 0xd0,
-	0x2b, 0x89, 0x6a, 0x25, 0xa2, 0x70, 0x50, 0xb7, 0x80, 0xfb, 0xce, 0x7f, 0x51, 0xb8, 0xc9, 0x97,
-	0xf0, 0x91, 0xca, 0xe6, 0x73, 0x54, 0xaa, 0x84, 0x6d, 0x58, 0x6c, 0x3f, 0x0f, 0x6c, 0xc0, 0x4f,
-	0x81, 0xa0, 0x64, 0x2a, 0x93, 0x18, 0xa8, 0x15, 0x33, 0xbf, 0xfc, 0x06, 0x07, 0x4d, 0x87, 0xce,
-	0x23, 0x53, 0x13, 0x98, 0xf2, 0x1b, 0xf4, 0xf6, 0x00, 0x36, 0x8d, 0x90, 0x26, 0x54, 0xe9, 0xb4,
-	0x7f, 0xcf, 0x7b, 0x09, 0x5d, 0x8a, 0xb1, 0xd0, 0x38, 0x31, 0x53, 0x23, 0x8f, 0xa0, 0x63, 0xc7,
-	0x17, 0x24, 0x59, 0x6c, 0x47, 0xd3, 0xa0, 0x6d, 0xeb, 0x38, 0xcf, 0x62, 0xd3, 0x76, 0x22, 0x42,
-	0x0c, 0x78, 0x68, 0xdb, 0xee, 0xd0, 0xa6, 0x31, 0xc7, 0xa1, 0xf7, 0x67, 0x05, 0x7a, 0xae, 0xca,
-	0x14, 0x97, 0x31, 0x26, 0x9a, 0x3c, 0x07, 0x90, 0xeb, 0x31, 0xda, 0x42, 0xdd, 0xd1, 0xa3, 0x3b,
-	0x66, 0x4c, 0x4b, 0x70, 0xf2, 0x10, 0xdc, 0x99, 0x9b, 0x83, 0x5a, 0xd6, 0x1e, 0x87, 0xe4, 0x39,
-	0xf4, 0xa4, 0x3d, 0x28, 0x70, 0x2c, 0x0f, 0x6a, 0x87, 0xb5, 0xa3, 0xee, 0x68, 0x7f, 0xab, 0xf4,
-	0xba, 0x1d, 0xba, 0x23, 0x37, 0x86, 0x22, 0x07, 0xd0, 0x8d, 0x51, 0xfe, 0x12, 0x61, 0x20, 0x85,
-	0xd0, 0x96, 0x82, 0x1d, 0x0a, 0xce, 0x45, 0x85, 0xd0, 0xde, 0xdf, 0x55, 0x68, 0x4d, 0x5c, 0x21,
-	0x72, 0xbc, 0xa5, 0x8f, 0xf2, 0xdd, 0x73, 0x84, 0x7f, 0xca, 0x34, 0x2b, 0x89, 0xe2, 0x53, 0xd8,
-	0xe5, 0x49, 0xc4, 0x13, 0x0c, 0x94, 0x1b, 0x82, 0x15, 0xc1, 0x0e, 0xed, 0x39, 0x6f, 0x31, 0x99,
-	0xaf, 0xa0, 0xe9, 0x2e, 0x65, 0xcf, 0xef, 0x8e, 0x06, 0xb7, 0xae, 0x9e, 0x23, 0x69, 0x8e, 0x23,
-	0x04, 0xea, 0x96, 0x58, 0x23, 0x83, 0x1a, 0xb5, 0xdf, 0xe4, 0x07, 0xe8, 0xcd, 0x25, 0x32, 0xcd,
-	0x45, 0x12, 0x84, 0x4c, 0x3b, 0xd6, 0xbb, 0xa3, 0xa1, 0xef, 0x96, 0xc5, 0x2f, 0x96, 0xc5, 0xbf,
-	0x28, 0x96, 0x85, 0xee, 0x14, 0x09, 0xa7, 0x4c, 0x23, 0x79, 0x09, 0xf7, 0xf1, 0x6d, 0xca, 0x65,
-	0xa9, 0x44, 0xeb, 0x83, 0x25, 0x76, 0x37, 0x29, 0xb6, 0xc8, 0x10, 0xda, 0x31, 0x6a, 0x16, 0x32,
-	0xcd, 0x06, 0x6d, 0xdb, 0xec, 0xda, 0xf6, 0x3c, 0x68, 0x17, 0x03, 0x22, 0x00, 0xcd, 0xf1, 0xf9,
-	0xeb, 0xf1, 0xf9, 0x59, 0xff, 0x9e, 0xf9, 0xa6, 0x67, 0x6f, 0x7e, 0xba, 0x38, 0xeb, 0x57, 0xbc,
-	0x73, 0x80, 0x49, 0xa6, 0x29, 0x5e, 0x66, 0xa8, 0xb4, 0xe9, 0x33, 0x65, 0x7a, 0x65, 0x27, 0xde,
-	0xa1, 0xf6, 0x9b, 0x3c, 0x85, 0x56, 0x3e, 0x1e, 0xab, 0x84, 0xee, 0x88, 0xdc, 0x26, 0x82, 0x16,
-	0x10, 0xef, 0x10, 0xe0, 0x15, 0xde, 0x55, 0xcf, 0xfb, 0xbd, 0x02, 0xdd, 0xd7, 0x5c, 0xad, 0x31,
-	0xfb, 0xd0, 0x4c, 0x25, 0x2e, 0xf8, 0xdb, 0x1c, 0x95, 0x5b, 0x46, 0x2a, 0x4a, 0x33, 0xa9, 0x03,
-	0xb6, 0x28, 0xce, 0xee, 0x50, 0xb0, 0xae, 0x17, 0xc6, 0x43, 0x3e, 0x01, 0xc0, 0x24, 0x0c, 0x66,
-	0xb8, 0x10, 0x12, 0x2d, 0xd3, 0x1d, 0xda, 0xc1, 0x24, 0x3c, 0xb1, 0x0e, 0xf2, 0x18, 0x3a, 0x12,
-	0xe7, 0x99, 0x54, 0xfc, 0xca, 0x11, 0xdd, 0xa6, 0x1b, 0x87, 0x79, 0x26, 0x22, 0x1e, 0x73, 0x9d,
-	0x6f, 0xb6, 0x33, 0x4c, 0x49, 0x33, 0xbd, 0x60, 0x11, 0xb1, 0xa5, 0xb2, 0x84, 0xb6, 0x68, 0xc7,
-	0x78, 0x7e, 0x34, 0x0e, 0xaf, 0x07, 0x5d, 0x3b, 0x2c, 0x95, 0x8a, 0x44, 0xa1, 0xf7, 0x57, 0x05,
-	0xba, 0xb6, 0x59, 0x67, 0x97, 0x27, 0x55, 0xf9, 0xe0, 0xa4, 0xc8, 0x13, 0x68, 0x98, 0xdd, 0x55,
-	0x83, 0xaa, 0xdd, 0x9f, 0x9e, 0x5f, 0xbc, 0xa1, 0xe7, 0x22, 0x44, 0xea, 0x62, 0xe4, 0x3b, 0xa8,
-	0xa5, 0x33, 0x66, 0x9b, 0xeb, 0x8e, 0xbe, 0xf0, 0x37, 0xef, 0xaa, 0x14, 0x99, 0x46, 0xe5, 0x4f,
-	0xd8, 0x35, 0xca, 0x13, 0x96, 0x84, 0xbf, 0xf2, 0x50, 0xaf, 0x5e, 0x44, 0x91, 0x98, 0x5b, 0x6d,
-	0x50, 0x93, 0x46, 0xce, 0xa0, 0xc7, 0x32, 0xbd, 0x12, 0x92, 0xdf, 0x58, 0x6f, 0xae, 0xf7, 0x83,
-	0xdb, 0x75, 0xa6, 0x7c, 0x99, 0x60, 0xf8, 0x06, 0x95, 0x62, 0x4b, 0xa4, 0xdb, 0x59, 0xde, 0x1f,
-	0x15, 0xd8, 0x71, 0x8c, 0xe5, 0x8d, 0x8e, 0xa0, 0xc1, 0x35, 0xc6, 0x6a, 0x50, 0xb1, 0x57, 0x7f,
-	0x5c, 0x6a, 0xb3, 0x8c, 0xf3, 0xc7, 0x1a, 0x63, 0xea, 0xa0, 0x46, 0x0a, 0xb1, 0xe1, 0xa9, 0x6a,
-	0x99, 0xb0, 0xdf, 0x43, 0x84, 0xba, 0x81, 0xfc, 0x7f, 0xd9, 0x99, 0x47, 0x93, 0xab, 0x20, 0xd7,
-	0x51, 0xcd, 0x1e, 0xd1, 0xe6, 0x6a, 0x62, 0x6d, 0xef, 0x09, 0xf4, 0x4e, 0x31, 0x42, 0x8d, 0x77,
-	0xc9, 0xb2, 0x0f, 0xbb, 0x05, 0x28, 0xa7, 0x57, 0xc2, 0xee, 0x58, 0xa3, 0x64, 0x9b, 0xbc, 0xf7,
-	0x49, 0x75, 0x0f, 0x1a, 0x0b, 0x2e, 0x95, 0xce, 0x45, 0xea, 0x0c, 0x32, 0x80, 0x96, 0xd3, 0x1b,
-	0xe6, 0x37, 0x2a, 0x4c, 0x17, 0xb9, 0x42, 0x13, 0xa9, 0x17, 0x11, 0x6b, 0x8e, 0xfe, 0xa9, 0x40,
-	0x27, 0x6f, 0xee, 0xf4, 0x84, 0x3c, 0x83, 0xda, 0x24, 0xd3, 0xe4, 0xe3, 0x72, 0xe7, 0xeb, 0x65,
-	0x1d, 0xee, 0xbf, 0xeb, 0xce, 0xd9, 0x79, 0x06, 0xb5, 0x57, 0xb8, 0x9d, 0xb5, 0x59, 0xc9, 0xad,
-	0xac, 0xb2, 0x78, 0xbf, 0x81, 0xba, 0xe1, 0x8e, 0xec, 0xdf, 0x22, 0xd3, 0xe5, 0x3d, 0x78, 0x0f,
-	0xc9, 0xe4, 0x7b, 0x68, 0xba, 0xc1, 0x91, 0xf2, 0x3b, 0xba, 0x35, 0xf0, 0xe1, 0xc3, 0xff, 0x88,
-	0xb8, 0xf4, 0x93, 0xfa, 0xcf, 0xd5, 0x74, 0x36, 0x6b, 0xda, 0xa7, 0xee, 0xeb, 0x7f, 0x03, 0x00,
-	0x00, 0xff, 0xff, 0xa1, 0x60, 0x9c, 0x3f, 0x86, 0x08, 0x00, 0x00,
+func init() { proto.RegisterFile("pointerdb.proto", fileDescriptor_pointerdb_f8882cc4aaf7fa7f) }
+
+var fileDescriptor_pointerdb_f8882cc4aaf7fa7f = []byte{
+	// 1188 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xdd, 0x6e, 0x1b, 0x45,
+	0x14, 0xae, 0xff, 0xed, 0xb3, 0x71, 0x6a, 0x86, 0x92, 0x6e, 0xdd, 0x96, 0x44, 0x5b, 0x81, 0x4a,
+	0x5b, 0xb9, 0x60, 0x2a, 0x21, 0x51, 0x10, 0x6a, 0x9a, 0x10, 0x59, 0x4a, 0xd3, 0x68, 0x92, 0x2b,
+	0x6e, 0x96, 0xb1, 0xf7, 0x24, 0x1e, 0xd5, 0xfb, 0xd3, 0x99, 0xd9, 0x92, 0xf4, 0x9a, 0x97, 0xe0,
+	0x35, 0x10, 0x17, 0xdc, 0x70, 0xcf, 0x93, 0x70, 0xc7, 0x3b, 0xa0, 0xf9, 0x59, 0x7b, 0xdd, 0x34,
+	0x49, 0xa1, 0xe2, 0x26, 0xd9, 0x73, 0xce, 0x77, 0x66, 0xe6, 0x7c, 0xe7, 0x3b, 0x33, 0x86, 0xab,
+	0x59, 0xca, 0x13, 0x85, 0x22, 0x1a, 0x0f, 0x32, 0x91, 0xaa, 0x94, 0x74, 0xe6, 0x8e, 0xfe, 0xfa,
+	0x71, 0x9a, 0x1e, 0xcf, 0xf0, 0xa1, 0x09, 0x8c, 0xf3, 0xa3, 0x87, 0x8a, 0xc7, 0x28, 0x15, 0x8b,
+	0x33, 0x8b, 0xed, 0x77, 0xd3, 0x57, 0x28, 0x66, 0xec, 0xd4, 0x99, 0xbd, 0x8c, 0xe3, 0x04, 0xa5,
+	0x4a, 0x05, 0x5a, 0x4f, 0xf0, 0x4b, 0x15, 0x7a, 0x14, 0xa3, 0x3c, 0x89, 0x58, 0x32, 0x39, 0x3d,
+	0x98, 0x4c, 0x31, 0x46, 0xf2, 0x35, 0xd4, 0xd5, 0x69, 0x86, 0x7e, 0x65, 0xa3, 0x72, 0x77, 0x75,
+	0xf8, 0xe9, 0x60, 0x71, 0x82, 0x37, 0xa1, 0x03, 0xfb, 0xef, 0xf0, 0x34, 0x43, 0x6a, 0x72, 0xc8,
+	0x75, 0x68, 0xc5, 0x3c, 0x09, 0x05, 0xbe, 0xf4, 0xab, 0x1b, 0x95, 0xbb, 0x0d, 0xda, 0x8c, 0x79,
+	0x42, 0xf1, 0x25, 0xb9, 0x06, 0x0d, 0x95, 0x2a, 0x36, 0xf3, 0x6b, 0xc6, 0x6d, 0x0d, 0xf2, 0x19,
+	0xf4, 0x04, 0x66, 0x8c, 0x8b, 0x50, 0x4d, 0x05, 0xca, 0x69, 0x3a, 0x8b, 0xfc, 0xba, 0x01, 0x5c,
+	0xb5, 0xfe, 0xc3, 0xc2, 0x4d, 0xee, 0xc3, 0x07, 0x32, 0x9f, 0x4c, 0x50, 0xca, 0x12, 0xb6, 0x61,
+	0xb0, 0x3d, 0x17, 0x58, 0x80, 0x1f, 0x00, 0x41, 0xc1, 0x64, 0x2e, 0x30, 0x94, 0x53, 0xa6, 0xff,
+	0xf2, 0xd7, 0xe8, 0x37, 0x2d, 0xda, 0x45, 0x0e, 0x74, 0xe0, 0x80, 0xbf, 0xc6, 0xe0, 0x1a, 0xc0,
+	0xa2, 0x10, 0xd2, 0x84, 0x2a, 0x3d, 0xe8, 0x5d, 0x09, 0x9e, 0x82, 0x47, 0x31, 0x4e, 0x15, 0xee,
+	0x6b, 0xd6, 0xc8, 0x4d, 0xe8, 0x18, 0xfa, 0xc2, 0x24, 0x8f, 0x0d, 0x35, 0x0d, 0xda, 0x36, 0x8e,
+	0xbd, 0x3c, 0xd6, 0x65, 0x27, 0x69, 0x84, 0x21, 0x8f, 0x4c, 0xd9, 0x1d, 0xda, 0xd4, 0xe6, 0x28,
+	0x0a, 0xfe, 0xaa, 0x40, 0xd7, 0xae, 0x72, 0x80, 0xc7, 0x31, 0x26, 0x8a, 0x3c, 0x06, 0x10, 0x73,
+	0x1a, 0xcd, 0x42, 0xde, 0xf0, 0xe6, 0x05, 0x1c, 0xd3, 0x12, 0x9c, 0xdc, 0x00, 0xbb, 0xe7, 0x62,
+	0xa3, 0x96, 0xb1, 0x47, 0x11, 0x79, 0x0c, 0x5d, 0x61, 0x36, 0x0a, 0x6d, 0x97, 0xfd, 0xda, 0x46,
+	0xed, 0xae, 0x37, 0x5c, 0x5b, 0x5a, 0x7a, 0x5e, 0x0e, 0x5d, 0x11, 0x0b, 0x43, 0x92, 0x75, 0xf0,
+	0x62, 0x14, 0x2f, 0x66, 0x18, 0x8a, 0x34, 0x55, 0xa6, 0x05, 0x2b, 0x14, 0xac, 0x8b, 0xa6, 0xa9,
+	0xd2, 0x00, 0xbb, 0xb1, 0x8e, 0x4b, 0xbf, 0xb1, 0x51, 0xd3, 0x00, 0xe3, 0xd2, 0x71, 0x19, 0xfc,
+	0x5d, 0x85, 0xd6, 0xbe, 0xdd, 0x89, 0x3c, 0x5c, 0x12, 0x50, 0xb9, 0x38, 0x87, 0x18, 0x6c, 0x31,
+	0xc5, 0x4a, 0xaa, 0xf9, 0x04, 0x56, 0x79, 0x32, 0xe3, 0x09, 0x86, 0xd2, 0xb2, 0x64, 0x54, 0xb2,
+	0x42, 0xbb, 0xd6, 0x5b, 0x50, 0xf7, 0x39, 0x34, 0xed, 0xa9, 0xcd, 0x01, 0xbd, 0xa1, 0x7f, 0xa6,
+	0x36, 0x87, 0xa4, 0x0e, 0x47, 0x08, 0xd4, 0x4d, 0xe7, 0xb5, 0x4e, 0x6a, 0xd4, 0x7c, 0x93, 0xef,
+	0xa0, 0x3b, 0x11, 0xc8, 0x14, 0x4f, 0x93, 0x30, 0x62, 0xca, 0xca, 0xc2, 0x1b, 0xf6, 0x07, 0x76,
+	0x9a, 0x06, 0xc5, 0x34, 0x0d, 0x0e, 0x8b, 0x69, 0xa2, 0x2b, 0x45, 0xc2, 0x16, 0x53, 0x48, 0x9e,
+	0xc2, 0x55, 0x3c, 0xc9, 0xb8, 0x28, 0x2d, 0xd1, 0xba, 0x74, 0x89, 0xd5, 0x45, 0x8a, 0x59, 0xa4,
+	0x0f, 0xed, 0x18, 0x15, 0x8b, 0x98, 0x62, 0x7e, 0xdb, 0x14, 0x3b, 0xb7, 0x83, 0x00, 0xda, 0x05,
+	0x41, 0x04, 0xa0, 0x39, 0xda, 0xdb, 0x1d, 0xed, 0x6d, 0xf7, 0xae, 0xe8, 0x6f, 0xba, 0xfd, 0xec,
+	0xf9, 0xe1, 0x76, 0xaf, 0x12, 0xec, 0x01, 0xec, 0xe7, 0x8a, 0xe2, 0xcb, 0x1c, 0xa5, 0xd2, 0x75,
+	0x66, 0x4c, 0x4d, 0x0d, 0xe3, 0x1d, 0x6a, 0xbe, 0xc9, 0x03, 0x68, 0x39, 0x7a, 0x8c, 0x54, 0xbc,
+	0x21, 0x39, 0xdb, 0x08, 0x5a, 0x40, 0x82, 0x0d, 0x80, 0x1d, 0xbc, 0x68, 0xbd, 0xe0, 0xf7, 0x0a,
+	0x78, 0xbb, 0x5c, 0xce, 0x31, 0x6b, 0xd0, 0xcc, 0x04, 0x1e, 0xf1, 0x13, 0x87, 0x72, 0x96, 0x96,
+	0x8a, 0x54, 0x4c, 0xa8, 0x90, 0x1d, 0x15, 0x7b, 0x77, 0x28, 0x18, 0xd7, 0x13, 0xed, 0x21, 0xb7,
+	0x01, 0x30, 0x89, 0xc2, 0x31, 0x1e, 0xa5, 0x02, 0x4d, 0xa7, 0x3b, 0xb4, 0x83, 0x49, 0xb4, 0x69,
+	0x1c, 0xe4, 0x16, 0x74, 0x04, 0x4e, 0x72, 0x21, 0xf9, 0x2b, 0xdb, 0xe8, 0x36, 0x5d, 0x38, 0xf4,
+	0x3d, 0x32, 0xe3, 0x31, 0x57, 0x6e, 0xf4, 0xad, 0xa1, 0x97, 0xd4, 0xec, 0x85, 0x47, 0x33, 0x76,
+	0x2c, 0x4d, 0x43, 0x5b, 0xb4, 0xa3, 0x3d, 0xdf, 0x6b, 0x47, 0xd0, 0x05, 0xcf, 0x90, 0x25, 0xb3,
+	0x34, 0x91, 0x18, 0xfc, 0x56, 0x05, 0x6f, 0x07, 0xe7, 0x76, 0x99, 0xa9, 0xca, 0xa5, 0x4c, 0x91,
+	0x3b, 0xd0, 0xd0, 0xc3, 0x2d, 0xfd, 0xaa, 0x19, 0xb0, 0xee, 0xa0, 0xb8, 0x64, 0xf7, 0xd2, 0x08,
+	0xa9, 0x8d, 0x91, 0x6f, 0xa0, 0x96, 0x8d, 0x99, 0x29, 0xce, 0x1b, 0xde, 0x1b, 0x2c, 0x2e, 0x5e,
+	0x91, 0xe6, 0x0a, 0xe5, 0x60, 0x9f, 0x9d, 0xa2, 0xd8, 0x64, 0x49, 0xf4, 0x13, 0x8f, 0xd4, 0xf4,
+	0xc9, 0x6c, 0x96, 0x4e, 0x8c, 0x36, 0xa8, 0x4e, 0x23, 0xdb, 0xd0, 0x65, 0xb9, 0x9a, 0xa6, 0x82,
+	0xbf, 0x36, 0x5e, 0xa7, 0xf7, 0xf5, 0xb3, 0xeb, 0x1c, 0xf0, 0xe3, 0x04, 0xa3, 0x67, 0x28, 0x25,
+	0x3b, 0x46, 0xba, 0x9c, 0x45, 0x9e, 0xc1, 0x4a, 0x2a, 0x22, 0x14, 0xa1, 0x21, 0xc9, 0x4e, 0xed,
+	0xbf, 0x3b, 0x8d, 0x67, 0xf2, 0x77, 0x4d, 0x7a, 0xf0, 0x47, 0x05, 0x56, 0xac, 0x00, 0x1c, 0x6f,
+	0x43, 0x68, 0x70, 0x85, 0xb1, 0xf4, 0x2b, 0x66, 0xe1, 0x5b, 0x25, 0xd6, 0xca, 0xb8, 0xc1, 0x48,
+	0x61, 0x4c, 0x2d, 0x54, 0x2b, 0x2b, 0xd6, 0x6d, 0xaf, 0x9a, 0xc6, 0x9a, 0xef, 0x3e, 0x42, 0x5d,
+	0x43, 0xde, 0x5f, 0xc5, 0xfa, 0x92, 0xe6, 0x32, 0x74, 0xb2, 0xac, 0x99, 0x2d, 0xda, 0x5c, 0xee,
+	0x1b, 0x3b, 0xb8, 0x03, 0xdd, 0x2d, 0x9c, 0xa1, 0xc2, 0x8b, 0x54, 0xde, 0x83, 0xd5, 0x02, 0xe4,
+	0xd4, 0x22, 0x60, 0x75, 0xa4, 0x50, 0x30, 0x85, 0x97, 0x29, 0xff, 0x1a, 0x34, 0x8e, 0xb8, 0x90,
+	0xca, 0x69, 0xde, 0x1a, 0xc4, 0x87, 0x96, 0x95, 0x2f, 0xba, 0x13, 0x15, 0xa6, 0x8d, 0xbc, 0x42,
+	0x1d, 0xa9, 0x17, 0x11, 0x63, 0x06, 0x3f, 0x57, 0x60, 0xfd, 0xdc, 0xa6, 0xb8, 0x53, 0x8c, 0xa0,
+	0xc9, 0x26, 0x46, 0x1d, 0xf6, 0x9e, 0xfd, 0xe2, 0xdd, 0xfb, 0x3a, 0x78, 0x62, 0x12, 0xa9, 0x5b,
+	0x40, 0x17, 0x34, 0xce, 0x27, 0x2f, 0xb0, 0x38, 0xb9, 0xb3, 0x82, 0x1f, 0x61, 0xe3, 0xfc, 0x53,
+	0x38, 0x11, 0x38, 0xa5, 0x57, 0xfe, 0x93, 0xd2, 0x83, 0x3f, 0x2b, 0x40, 0x9e, 0x2f, 0x34, 0xf6,
+	0x3f, 0xd4, 0x76, 0xc1, 0x93, 0x79, 0x03, 0xda, 0xee, 0xd5, 0xb6, 0xaf, 0x65, 0x87, 0xb6, 0xec,
+	0xb3, 0x2d, 0x75, 0x28, 0x66, 0x27, 0xf6, 0x67, 0x43, 0xdd, 0x3c, 0x1e, 0xad, 0x98, 0x9d, 0xe8,
+	0x5f, 0x0b, 0x25, 0xb2, 0x1a, 0x4b, 0x64, 0x45, 0xf0, 0xe1, 0x52, 0x25, 0x8e, 0x9f, 0x37, 0x87,
+	0xb0, 0xf2, 0x5e, 0x43, 0x38, 0xfc, 0xb5, 0x06, 0x1d, 0x27, 0xfb, 0xad, 0x4d, 0xf2, 0x08, 0x6a,
+	0xfb, 0xb9, 0x22, 0x1f, 0x95, 0x67, 0x62, 0xfe, 0x2a, 0xf4, 0xd7, 0xde, 0x74, 0xbb, 0x23, 0x3d,
+	0x82, 0xda, 0x0e, 0x2e, 0x67, 0xed, 0xe0, 0x5b, 0xb3, 0xca, 0xb7, 0xe4, 0x57, 0x50, 0xd7, 0x53,
+	0x4d, 0xd6, 0xce, 0x8c, 0xb9, 0xcd, 0xbb, 0x7e, 0xce, 0xf8, 0x93, 0x6f, 0xa1, 0x69, 0x47, 0x8a,
+	0x94, 0x1f, 0xec, 0xa5, 0x51, 0xec, 0xdf, 0x78, 0x4b, 0xc4, 0xa5, 0x4b, 0xf0, 0xcf, 0xa3, 0x86,
+	0xdc, 0x2b, 0x57, 0x78, 0xf1, 0xbc, 0xf4, 0xef, 0xbf, 0x13, 0xd6, 0x6d, 0xba, 0x0b, 0x5e, 0xa9,
+	0x99, 0xe4, 0x76, 0x29, 0xf7, 0xac, 0x5c, 0xfb, 0x1f, 0x9f, 0x17, 0xb6, 0xab, 0x6d, 0xd6, 0x7f,
+	0xa8, 0x66, 0xe3, 0x71, 0xd3, 0xfc, 0x2c, 0xf8, 0xf2, 0x9f, 0x01, 0x00, 0xa7, 0x25, 0x03, 0x94,
+	0xd3, 0x0b, 0x00, 0x00,
 }
@@ -0,0 +1,488 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: uplinkdb.proto
+
+package pb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type UplinkKey struct {
+	KeyId                string   `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	PublicKey            []byte   `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	ApiKeyHash           []byte   `protobuf:"bytes,3,opt,name=api_key_hash,json=apiKeyHash,proto3" json:"api_key_hash,omitempty"`
+	CreatedUnixSec       int64    `protobuf:"varint,4,opt,name=created_unix_sec,json=createdUnixSec,proto3" json:"created_unix_sec,omitempty"`
+	RevokedUnixSec       int64    `protobuf:"varint,5,opt,name=revoked_unix_sec,json=revokedUnixSec,proto3" json:"revoked_unix_sec,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UplinkKey) Reset()         { *m = UplinkKey{} }
+func (m *UplinkKey) String() string { return proto.CompactTextString(m) }
+func (*UplinkKey) ProtoMessage()    {}
+func (*UplinkKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_uplinkdb_0dab414adae9a4ac, []int{0}
+}
+func (m *UplinkKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UplinkKey.Unmarshal(m, b)
+}
+func (m *UplinkKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UplinkKey.Marshal(b, m, deterministic)
+}
+func (dst *UplinkKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UplinkKey.Merge(dst, src)
+}
+func (m *UplinkKey) XXX_Size() int {
+	return xxx_messageInfo_UplinkKey.Size(m)
+}
+func (m *UplinkKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_UplinkKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UplinkKey proto.InternalMessageInfo
+
+func (m *UplinkKey) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *UplinkKey) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *UplinkKey) GetApiKeyHash() []byte {
+	if m != nil {
+		return m.ApiKeyHash
+	}
+	return nil
+}
+
+func (m *UplinkKey) GetCreatedUnixSec() int64 {
+	if m != nil {
+		return m.CreatedUnixSec
+	}
+	return 0
+}
+
+func (m *UplinkKey) GetRevokedUnixSec() int64 {
+	if m != nil {
+		return m.RevokedUnixSec
+	}
+	return 0
+}
+
+type RegisterUplinkKeyRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterUplinkKeyRequest) Reset()         { *m = RegisterUplinkKeyRequest{} }
+func (m *RegisterUplinkKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*RegisterUplinkKeyRequest) ProtoMessage()    {}
+func (*RegisterUplinkKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_uplinkdb_0dab414adae9a4ac, []int{1}
+}
+func (m *RegisterUplinkKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterUplinkKeyRequest.Unmarshal(m, b)
+}
+func (m *RegisterUplinkKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterUplinkKeyRequest.Marshal(b, m, deterministic)
+}
+func (dst *RegisterUplinkKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterUplinkKeyRequest.Merge(dst, src)
+}
+func (m *RegisterUplinkKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_RegisterUplinkKeyRequest.Size(m)
+}
+func (m *RegisterUplinkKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterUplinkKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterUplinkKeyRequest proto.InternalMessageInfo
+
+type RegisterUplinkKeyResponse struct {
+	Key                  *UplinkKey `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *RegisterUplinkKeyResponse) Reset()         { *m = RegisterUplinkKeyResponse{} }
+func (m *RegisterUplinkKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*RegisterUplinkKeyResponse) ProtoMessage()    {}
+func (*RegisterUplinkKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_uplinkdb_0dab414adae9a4ac, []int{2}
+}
+func (m *RegisterUplinkKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterUplinkKeyResponse.Unmarshal(m, b)
+}
+func (m *RegisterUplinkKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterUplinkKeyResponse.Marshal(b, m, deterministic)
+}
+func (dst *RegisterUplinkKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterUplinkKeyResponse.Merge(dst, src)
+}
+func (m *RegisterUplinkKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_RegisterUplinkKeyResponse.Size(m)
+}
+func (m *RegisterUplinkKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterUplinkKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterUplinkKeyResponse proto.InternalMessageInfo
+
+func (m *RegisterUplinkKeyResponse) GetKey() *UplinkKey {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+type RevokeUplinkKeyRequest struct {
+	KeyId                string   `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeUplinkKeyRequest) Reset()         { *m = RevokeUplinkKeyRequest{} }
+func (m *RevokeUplinkKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeUplinkKeyRequest) ProtoMessage()    {}
+func (*RevokeUplinkKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_uplinkdb_0dab414adae9a4ac, []int{3}
+}
+func (m *RevokeUplinkKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeUplinkKeyRequest.Unmarshal(m, b)
+}
+func (m *RevokeUplinkKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeUplinkKeyRequest.Marshal(b, m, deterministic)
+}
+func (dst *RevokeUplinkKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeUplinkKeyRequest.Merge(dst, src)
+}
+func (m *RevokeUplinkKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeUplinkKeyRequest.Size(m)
+}
+func (m *RevokeUplinkKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeUplinkKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeUplinkKeyRequest proto.InternalMessageInfo
+
+func (m *RevokeUplinkKeyRequest) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+type RevokeUplinkKeyResponse struct {
+	Key                  *UplinkKey `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *RevokeUplinkKeyResponse) Reset()         { *m = RevokeUplinkKeyResponse{} }
+func (m *RevokeUplinkKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeUplinkKeyResponse) ProtoMessage()    {}
+func (*RevokeUplinkKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_uplinkdb_0dab414adae9a4ac, []int{4}
+}
+func (m *RevokeUplinkKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeUplinkKeyResponse.Unmarshal(m, b)
+}
+func (m *RevokeUplinkKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeUplinkKeyResponse.Marshal(b, m, deterministic)
+}
+func (dst *RevokeUplinkKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeUplinkKeyResponse.Merge(dst, src)
+}
+func (m *RevokeUplinkKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_RevokeUplinkKeyResponse.Size(m)
+}
+func (m *RevokeUplinkKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeUplinkKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeUplinkKeyResponse proto.InternalMessageInfo
+
+func (m *RevokeUplinkKeyResponse) GetKey() *UplinkKey {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+type ListUplinkKeysRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListUplinkKeysRequest) Reset()         { *m = ListUplinkKeysRequest{} }
+func (m *ListUplinkKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*ListUplinkKeysRequest) ProtoMessage()    {}
+func (*ListUplinkKeysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_uplinkdb_0dab414adae9a4ac, []int{5}
+}
+func (m *ListUplinkKeysRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUplinkKeysRequest.Unmarshal(m, b)
+}
+func (m *ListUplinkKeysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUplinkKeysRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListUplinkKeysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUplinkKeysRequest.Merge(dst, src)
+}
+func (m *ListUplinkKeysRequest) XXX_Size() int {
+	return xxx_messageInfo_ListUplinkKeysRequest.Size(m)
+}
+func (m *ListUplinkKeysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUplinkKeysRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUplinkKeysRequest proto.InternalMessageInfo
+
+type ListUplinkKeysResponse struct {
+	Keys                 []*UplinkKey `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListUplinkKeysResponse) Reset()         { *m = ListUplinkKeysResponse{} }
+func (m *ListUplinkKeysResponse) String() string { return proto.CompactTextString(m) }
+func (*ListUplinkKeysResponse) ProtoMessage()    {}
+func (*ListUplinkKeysResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_uplinkdb_0dab414adae9a4ac, []int{6}
+}
+func (m *ListUplinkKeysResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUplinkKeysResponse.Unmarshal(m, b)
+}
+func (m *ListUplinkKeysResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUplinkKeysResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListUplinkKeysResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUplinkKeysResponse.Merge(dst, src)
+}
+func (m *ListUplinkKeysResponse) XXX_Size() int {
+	return xxx_messageInfo_ListUplinkKeysResponse.Size(m)
+}
+func (m *ListUplinkKeysResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUplinkKeysResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUplinkKeysResponse proto.InternalMessageInfo
+
+func (m *ListUplinkKeysResponse) GetKeys() []*UplinkKey {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*UplinkKey)(nil), "uplinkdb.UplinkKey")
+	proto.RegisterType((*RegisterUplinkKeyRequest)(nil), "uplinkdb.RegisterUplinkKeyRequest")
+	proto.RegisterType((*RegisterUplinkKeyResponse)(nil), "uplinkdb.RegisterUplinkKeyResponse")
+	proto.RegisterType((*RevokeUplinkKeyRequest)(nil), "uplinkdb.RevokeUplinkKeyRequest")
+	proto.RegisterType((*RevokeUplinkKeyResponse)(nil), "uplinkdb.RevokeUplinkKeyResponse")
+	proto.RegisterType((*ListUplinkKeysRequest)(nil), "uplinkdb.ListUplinkKeysRequest")
+	proto.RegisterType((*ListUplinkKeysResponse)(nil), "uplinkdb.ListUplinkKeysResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// UplinkKeysClient is the client API for UplinkKeys service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type UplinkKeysClient interface {
+	// Register registers the public key of the peer identity for the API key
+	Register(ctx context.Context, in *RegisterUplinkKeyRequest, opts ...grpc.CallOption) (*RegisterUplinkKeyResponse, error)
+	// Revoke revokes a public key registered for the API key
+	Revoke(ctx context.Context, in *RevokeUplinkKeyRequest, opts ...grpc.CallOption) (*RevokeUplinkKeyResponse, error)
+	// List returns the public keys registered for the API key
+	List(ctx context.Context, in *ListUplinkKeysRequest, opts ...grpc.CallOption) (*ListUplinkKeysResponse, error)
+}
+
+type uplinkKeysClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewUplinkKeysClient(cc *grpc.ClientConn) UplinkKeysClient {
+	return &uplinkKeysClient{cc}
+}
+
+func (c *uplinkKeysClient) Register(ctx context.Context, in *RegisterUplinkKeyRequest, opts ...grpc.CallOption) (*RegisterUplinkKeyResponse, error) {
+	out := new(RegisterUplinkKeyResponse)
+	err := c.cc.Invoke(ctx, "/uplinkdb.UplinkKeys/Register", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uplinkKeysClient) Revoke(ctx context.Context, in *RevokeUplinkKeyRequest, opts ...grpc.CallOption) (*RevokeUplinkKeyResponse, error) {
+	out := new(RevokeUplinkKeyResponse)
+	err := c.cc.Invoke(ctx, "/uplinkdb.UplinkKeys/Revoke", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uplinkKeysClient) List(ctx context.Context, in *ListUplinkKeysRequest, opts ...grpc.CallOption) (*ListUplinkKeysResponse, error) {
+	out := new(ListUplinkKeysResponse)
+	err := c.cc.Invoke(ctx, "/uplinkdb.UplinkKeys/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UplinkKeysServer is the server API for UplinkKeys service.
+type UplinkKeysServer interface {
+	// Register registers the public key of the peer identity for the API key
+	Register(context.Context, *RegisterUplinkKeyRequest) (*RegisterUplinkKeyResponse, error)
+	// Revoke revokes a public key registered for the API key
+	Revoke(context.Context, *RevokeUplinkKeyRequest) (*RevokeUplinkKeyResponse, error)
+	// List returns the public keys registered for the API key
+	List(context.Context, *ListUplinkKeysRequest) (*ListUplinkKeysResponse, error)
+}
+
+func RegisterUplinkKeysServer(s *grpc.Server, srv UplinkKeysServer) {
+	s.RegisterService(&_UplinkKeys_serviceDesc, srv)
+}
+
+func _UplinkKeys_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterUplinkKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UplinkKeysServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/uplinkdb.UplinkKeys/Register",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UplinkKeysServer).Register(ctx, req.(*RegisterUplinkKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UplinkKeys_Revoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeUplinkKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UplinkKeysServer).Revoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/uplinkdb.UplinkKeys/Revoke",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UplinkKeysServer).Revoke(ctx, req.(*RevokeUplinkKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UplinkKeys_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUplinkKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UplinkKeysServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/uplinkdb.UplinkKeys/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UplinkKeysServer).List(ctx, req.(*ListUplinkKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _UplinkKeys_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "uplinkdb.UplinkKeys",
+	HandlerType: (*UplinkKeysServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _UplinkKeys_Register_Handler,
+		},
+		{
+			MethodName: "Revoke",
+			Handler:    _UplinkKeys_Revoke_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _UplinkKeys_List_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "uplinkdb.proto",
+}
+
+func init() { proto.RegisterFile("uplinkdb.proto", fileDescriptor_uplinkdb_0dab414adae9a4ac) }
+
+var fileDescriptor_uplinkdb_0dab414adae9a4ac = []byte{
+	// 349 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0xcd, 0x4a, 0xfb, 0x40,
+	0x14, 0xc5, 0xff, 0x69, 0xd2, 0xd2, 0xde, 0x7f, 0x29, 0x32, 0xd2, 0x36, 0x06, 0xc4, 0x18, 0x11,
+	0xb3, 0xaa, 0x50, 0x5f, 0x40, 0xbb, 0x52, 0x2a, 0x08, 0x91, 0x6e, 0xdc, 0x84, 0x7c, 0x5c, 0xec,
+	0x90, 0x92, 0x8c, 0x99, 0x44, 0x9a, 0xa5, 0x4f, 0xe5, 0xeb, 0xc9, 0xe4, 0xb3, 0xd4, 0xa6, 0xe0,
+	0x72, 0xee, 0xfd, 0x9d, 0x73, 0x73, 0x0e, 0x04, 0x46, 0x29, 0xdb, 0xd0, 0x30, 0xf0, 0xdd, 0x19,
+	0x8b, 0xa3, 0x24, 0x22, 0xfd, 0xea, 0x6d, 0x7c, 0x4b, 0x30, 0x58, 0xe5, 0x8f, 0x25, 0x66, 0x64,
+	0x0c, 0xbd, 0x00, 0x33, 0x9b, 0xfa, 0xaa, 0xa4, 0x4b, 0xe6, 0xc0, 0xea, 0x06, 0x98, 0x3d, 0xf9,
+	0xe4, 0x1c, 0x80, 0xa5, 0xee, 0x86, 0x7a, 0x76, 0x80, 0x99, 0xda, 0xd1, 0x25, 0x73, 0x68, 0x0d,
+	0x8a, 0x89, 0x50, 0xe9, 0x30, 0x74, 0x18, 0x15, 0x3b, 0x7b, 0xed, 0xf0, 0xb5, 0x2a, 0xe7, 0x00,
+	0x38, 0x8c, 0x2e, 0x31, 0x7b, 0x74, 0xf8, 0x9a, 0x98, 0x70, 0xe2, 0xc5, 0xe8, 0x24, 0xe8, 0xdb,
+	0x69, 0x48, 0xb7, 0x36, 0x47, 0x4f, 0x55, 0x74, 0xc9, 0x94, 0xad, 0x51, 0x39, 0x5f, 0x85, 0x74,
+	0xfb, 0x8a, 0x9e, 0x20, 0x63, 0xfc, 0x8c, 0x82, 0x5d, 0xb2, 0x5b, 0x90, 0xe5, 0xbc, 0x24, 0x0d,
+	0x0d, 0x54, 0x0b, 0xdf, 0x29, 0x4f, 0x30, 0xae, 0x03, 0x58, 0xf8, 0x91, 0x22, 0x4f, 0x8c, 0x05,
+	0x9c, 0x1d, 0xd8, 0x71, 0x16, 0x85, 0x1c, 0xc9, 0x35, 0xc8, 0x22, 0x86, 0x48, 0xf8, 0x7f, 0x7e,
+	0x3a, 0xab, 0xab, 0x69, 0x48, 0xb1, 0x37, 0x6e, 0x61, 0x62, 0xe5, 0x17, 0xf7, 0xdd, 0x5b, 0x5a,
+	0x32, 0xee, 0x61, 0xfa, 0x4b, 0xf0, 0xb7, 0x93, 0x53, 0x18, 0x3f, 0x53, 0x9e, 0xd4, 0x53, 0x5e,
+	0xe5, 0x79, 0x80, 0xc9, 0xfe, 0xa2, 0x74, 0xbe, 0x01, 0x25, 0xc0, 0x8c, 0xab, 0x92, 0x2e, 0xb7,
+	0x59, 0xe7, 0xc0, 0xfc, 0xab, 0x03, 0xd0, 0xe8, 0xc9, 0x0a, 0xfa, 0x55, 0x43, 0xc4, 0x68, 0x54,
+	0x6d, 0x8d, 0x6a, 0x57, 0x47, 0x99, 0xe2, 0x63, 0x8c, 0x7f, 0xe4, 0x05, 0x7a, 0x45, 0x07, 0x44,
+	0xdf, 0x15, 0x1c, 0xaa, 0x51, 0xbb, 0x3c, 0x42, 0xd4, 0x86, 0x4b, 0x50, 0x44, 0x72, 0x72, 0xd1,
+	0xc0, 0x07, 0x2b, 0xd2, 0xf4, 0x76, 0xa0, 0x32, 0x5b, 0x28, 0x6f, 0x1d, 0xe6, 0xba, 0xbd, 0xfc,
+	0x1f, 0xb8, 0xfb, 0x19, 0x00, 0x97, 0x41, 0x65, 0x3d, 0x15, 0x03, 0x00, 0x00,
+}
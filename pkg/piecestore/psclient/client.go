@@ -7,17 +7,23 @@ import (
 	"bufio"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
 	"flag"
 	"fmt"
 	"io"
 	"log"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/gtank/cryptopasta"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
 
+	"storj.io/storj/pkg/bloomfilter"
 	"storj.io/storj/pkg/node"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/ranger"
@@ -42,7 +48,11 @@ type Client interface {
 	Put(ctx context.Context, id PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) error
 	Get(ctx context.Context, id PieceID, size int64, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (ranger.Ranger, error)
 	Delete(ctx context.Context, pieceID PieceID, authorization *pb.SignedMessage) error
+	DeleteBatch(ctx context.Context, ids []PieceID, authorization *pb.SignedMessage) (failed map[PieceID]error, err error)
 	Stats(ctx context.Context) (*pb.StatSummary, error)
+	Retain(ctx context.Context, filter *bloomfilter.Filter, createdBefore time.Time) (deleted int64, err error)
+	RestoreTrash(ctx context.Context, authorization *pb.SignedMessage) (restored int64, err error)
+	Prove(ctx context.Context, id PieceID, shareSize int, shareIndex int64, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (share []byte, proof [][]byte, err error)
 	io.Closer
 }
 
@@ -113,7 +123,9 @@ func (ps *PieceStore) Meta(ctx context.Context, id PieceID) (*pb.PieceSummary, e
 
 // Put uploads a Piece to a piece store Server
 func (ps *PieceStore) Put(ctx context.Context, id PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) error {
-	stream, err := ps.client.Store(ctx)
+	// peer is used for verifying the piece hash receipt
+	nodePeer := &peer.Peer{}
+	stream, err := ps.client.Store(ctx, grpc.Peer(nodePeer))
 	if err != nil {
 		return err
 	}
@@ -130,7 +142,7 @@ func (ps *PieceStore) Put(ctx context.Context, id PieceID, data io.Reader, ttl t
 		return fmt.Errorf("%v.Send() = %v", stream, err)
 	}
 
-	writer := &StreamWriter{signer: ps, stream: stream, pba: ba}
+	writer := &StreamWriter{signer: ps, stream: stream, pba: ba, id: id, peer: nodePeer, hash: sha256.New()}
 
 	defer func() {
 		if err := writer.Close(); err != nil && err != io.EOF {
@@ -153,17 +165,24 @@ func (ps *PieceStore) Put(ctx context.Context, id PieceID, data io.Reader, ttl t
 		return err
 	}
 
-	return bufw.Flush()
+	if err := bufw.Flush(); err != nil {
+		return err
+	}
+
+	// verifies the piece hash receipt
+	return writer.Close()
 }
 
 // Get begins downloading a Piece from a piece store Server
 func (ps *PieceStore) Get(ctx context.Context, id PieceID, size int64, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (ranger.Ranger, error) {
-	stream, err := ps.client.Retrieve(ctx)
+	// peer is used for verifying the piece hash receipt
+	nodePeer := &peer.Peer{}
+	stream, err := ps.client.Retrieve(ctx, grpc.Peer(nodePeer))
 	if err != nil {
 		return nil, err
 	}
 
-	return PieceRangerSize(ps, stream, id, size, ba, authorization), nil
+	return &pieceRanger{c: ps, id: id, size: size, stream: stream, pba: ba, authorization: authorization, peer: nodePeer}, nil
 }
 
 // Delete a Piece from a piece store Server
@@ -176,11 +195,98 @@ func (ps *PieceStore) Delete(ctx context.Context, id PieceID, authorization *pb.
 	return nil
 }
 
+// DeleteBatch deletes several Pieces from a piece store Server with one request,
+// the pieces that couldn't be deleted are returned with their error
+func (ps *PieceStore) DeleteBatch(ctx context.Context, ids []PieceID, authorization *pb.SignedMessage) (failed map[PieceID]error, err error) {
+	req := &pb.PieceDeleteBatch{Authorization: authorization}
+	for _, id := range ids {
+		req.Ids = append(req.Ids, id.String())
+	}
+
+	reply, err := ps.client.DeleteBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[PieceID]string, len(ids))
+	for _, result := range reply.GetResults() {
+		results[PieceID(result.GetId())] = result.GetError()
+	}
+
+	failed = make(map[PieceID]error)
+	for _, id := range ids {
+		message, ok := results[id]
+		switch {
+		case !ok:
+			failed[id] = ClientError.New("no delete result for piece %s", id)
+		case message != "":
+			failed[id] = ClientError.New("%s", message)
+		}
+	}
+	return failed, nil
+}
+
 // Stats will retrieve stats about a piece storage node
 func (ps *PieceStore) Stats(ctx context.Context) (*pb.StatSummary, error) {
 	return ps.client.Stats(ctx, &pb.StatsReq{})
 }
 
+// Retain asks the piece storage node to delete the pieces created before createdBefore that are not in filter
+func (ps *PieceStore) Retain(ctx context.Context, filter *bloomfilter.Filter, createdBefore time.Time) (deleted int64, err error) {
+	reply, err := ps.client.Retain(ctx, &pb.RetainRequest{Filter: filter.Bytes(), CreatedBeforeUnixSec: createdBefore.Unix()})
+	if err != nil {
+		return 0, err
+	}
+	return reply.GetDeleted(), nil
+}
+
+// RestoreTrash asks the piece storage node to bring back the deleted pieces of the satellite
+func (ps *PieceStore) RestoreTrash(ctx context.Context, authorization *pb.SignedMessage) (restored int64, err error) {
+	reply, err := ps.client.RestoreTrash(ctx, &pb.RestoreTrashRequest{Authorization: authorization})
+	if err != nil {
+		return 0, err
+	}
+	return reply.GetRestored(), nil
+}
+
+// Prove downloads a single erasure share of a piece with the proof that it belongs to the Merkle tree of the piece
+func (ps *PieceStore) Prove(ctx context.Context, id PieceID, shareSize int, shareIndex int64, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (share []byte, proof [][]byte, err error) {
+	prikey, ok := ps.prikey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, nil, ClientError.New("invalid private key, can't create RenterBandwidthAllocation")
+	}
+	pubbytes, err := x509.MarshalPKIXPublicKey(&prikey.PublicKey)
+	if err != nil {
+		return nil, nil, ClientError.Wrap(err)
+	}
+
+	serializedAllocation, err := proto.Marshal(&pb.RenterBandwidthAllocation_Data{
+		PayerAllocation: ba,
+		Total:           int64(shareSize),
+		StorageNodeId:   ps.nodeID.Bytes(),
+		PubKey:          pubbytes,
+	})
+	if err != nil {
+		return nil, nil, ClientError.Wrap(err)
+	}
+	signature, err := ps.sign(serializedAllocation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reply, err := ps.client.Prove(ctx, &pb.PieceProof{
+		Id:                  id.String(),
+		ShareSize:           int32(shareSize),
+		ShareIndex:          shareIndex,
+		Bandwidthallocation: &pb.RenterBandwidthAllocation{Data: serializedAllocation, Signature: signature},
+		Authorization:       authorization,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return reply.GetShare(), reply.GetProof(), nil
+}
+
 // sign a message using the clients private key
 func (ps *PieceStore) sign(msg []byte) (signature []byte, err error) {
 	if ps.prikey == nil {
@@ -0,0 +1,74 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psclient
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gtank/cryptopasta"
+	"github.com/zeebo/errs"
+	"google.golang.org/grpc/peer"
+
+	"storj.io/storj/pkg/node"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/peertls"
+	"storj.io/storj/pkg/provider"
+)
+
+// HashError is returned when a piece hash receipt doesn't match
+var HashError = errs.Class("piece hash error")
+
+// VerifyPieceHash checks that the piece hash receipt is signed with key and
+// matches the expected piece id, content hash and size
+func VerifyPieceHash(signed *pb.PieceHash, key *ecdsa.PublicKey, id PieceID, hash []byte, size int64) error {
+	if signed == nil {
+		return HashError.New("missing piece hash")
+	}
+
+	if !cryptopasta.Verify(signed.GetData(), signed.GetSignature(), key) {
+		return HashError.New("failed to verify signature")
+	}
+
+	data := &pb.PieceHash_Data{}
+	if err := proto.Unmarshal(signed.GetData(), data); err != nil {
+		return HashError.Wrap(err)
+	}
+
+	if data.GetId() != id.String() {
+		return HashError.New("piece id mismatch: expected %s got %s", id, data.GetId())
+	}
+	if data.GetSize() != size {
+		return HashError.New("size mismatch: expected %d got %d", size, data.GetSize())
+	}
+	if !bytes.Equal(data.GetHash(), hash) {
+		return HashError.New("hash mismatch for piece %s", id)
+	}
+
+	return nil
+}
+
+// NodeKeyFromPeer returns the public key of the peer, ensuring it belongs to the expected node
+func NodeKeyFromPeer(p *peer.Peer, nodeID *node.ID) (*ecdsa.PublicKey, error) {
+	if p == nil || p.AuthInfo == nil {
+		return nil, HashError.New("unknown storage node identity")
+	}
+
+	pi, err := provider.PeerIdentityFromPeer(p)
+	if err != nil {
+		return nil, HashError.Wrap(err)
+	}
+
+	if nodeID != nil && pi.ID.String() != nodeID.String() {
+		return nil, HashError.New("unexpected storage node %s, expected %s", pi.ID, nodeID)
+	}
+
+	key, ok := pi.Leaf.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, peertls.ErrUnsupportedKey.New("%T", pi.Leaf.PublicKey)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gtank/cryptopasta"
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/pkg/pb"
+)
+
+func TestVerifyPieceHash(t *testing.T) {
+	nodeKey, err := cryptopasta.NewSigningKey()
+	assert.NoError(t, err)
+	otherKey, err := cryptopasta.NewSigningKey()
+	assert.NoError(t, err)
+
+	id := NewPieceID()
+	content := []byte("butts")
+	hash := sha256.Sum256(content)
+
+	data, err := proto.Marshal(&pb.PieceHash_Data{Id: id.String(), Hash: hash[:], Size: int64(len(content))})
+	assert.NoError(t, err)
+	signature, err := cryptopasta.Sign(data, nodeKey)
+	assert.NoError(t, err)
+	signed := &pb.PieceHash{Data: data, Signature: signature}
+
+	otherHash := sha256.Sum256([]byte("truncated"))
+
+	for _, tt := range []struct {
+		name   string
+		signed *pb.PieceHash
+		key    *ecdsa.PublicKey
+		id     PieceID
+		hash   []byte
+		size   int64
+		err    bool
+	}{
+		{name: "valid", signed: signed, id: id, hash: hash[:], size: 5},
+		{name: "missing", signed: nil, id: id, hash: hash[:], size: 5, err: true},
+		{name: "wrong signer", signed: signed, key: &otherKey.PublicKey, id: id, hash: hash[:], size: 5, err: true},
+		{name: "wrong id", signed: signed, id: NewPieceID(), hash: hash[:], size: 5, err: true},
+		{name: "wrong hash", signed: signed, id: id, hash: otherHash[:], size: 5, err: true},
+		{name: "wrong size", signed: signed, id: id, hash: hash[:], size: 4, err: true},
+	} {
+		key := &nodeKey.PublicKey
+		if tt.key != nil {
+			key = tt.key
+		}
+
+		err := VerifyPieceHash(tt.signed, key, tt.id, tt.hash, tt.size)
+		if tt.err {
+			assert.Error(t, err, tt.name)
+			assert.True(t, HashError.Has(err), tt.name)
+		} else {
+			assert.NoError(t, err, tt.name)
+		}
+	}
+}
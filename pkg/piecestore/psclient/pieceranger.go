@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 
 	"github.com/zeebo/errs"
+	"google.golang.org/grpc/peer"
 
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/ranger"
@@ -25,6 +26,7 @@ type pieceRanger struct {
 	stream        pb.PieceStoreRoutes_RetrieveClient
 	pba           *pb.PayerBandwidthAllocation
 	authorization *pb.SignedMessage
+	peer          *peer.Peer // Storage node, filled in when the stream ended
 }
 
 // PieceRanger PieceRanger returns a Ranger from a PieceID.
@@ -68,5 +70,10 @@ func (r *pieceRanger) Range(ctx context.Context, offset, length int64) (io.ReadC
 		return nil, err
 	}
 
-	return NewStreamReader(r.c, r.stream, r.pba, r.size), nil
+	reader := NewStreamReader(r.c, r.stream, r.pba, r.size)
+	// the content of a whole piece is checked against the hash signed by the storage node
+	if offset == 0 && length == r.size && r.peer != nil {
+		reader.expectHash(r.id, r.peer)
+	}
+	return reader, nil
 }
@@ -8,15 +8,23 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"testing"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/golang/mock/gomock"
+	"github.com/gtank/cryptopasta"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/provider"
 )
 
 func TestPieceRanger(t *testing.T) {
@@ -176,3 +184,76 @@ func TestPieceRangerSize(t *testing.T) {
 		}
 	}
 }
+
+func TestPieceRangerHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	nodePeer := func(t *testing.T) (*provider.FullIdentity, *peer.Peer) {
+		ca, err := provider.NewTestCA(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		identity, err := ca.NewIdentity()
+		if err != nil {
+			t.Fatal(err)
+		}
+		certs := []*x509.Certificate{identity.Leaf, identity.CA}
+		return identity, &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: certs}}}
+	}
+	storageNode, storageNodePeer := nodePeer(t)
+	_, otherPeer := nodePeer(t)
+
+	pid := NewPieceID()
+	content := []byte("abcdef")
+	hash := sha256.Sum256(content)
+	data, err := proto.Marshal(&pb.PieceHash_Data{Id: pid.String(), Hash: hash[:], Size: int64(len(content))})
+	assert.NoError(t, err)
+	signature, err := cryptopasta.Sign(data, storageNode.Key.(*ecdsa.PrivateKey))
+	assert.NoError(t, err)
+	signed := &pb.PieceHash{Data: data, Signature: signature}
+
+	for i, tt := range []struct {
+		received  []byte
+		hash      *pb.PieceHash
+		peer      *peer.Peer
+		errString string
+	}{
+		{content, signed, storageNodePeer, ""},
+		{[]byte("abcdeg"), signed, storageNodePeer, "hash mismatch"},
+		{content, signed, otherPeer, "unexpected storage node"},
+		// pieces stored before the storage nodes hashed them
+		{content, nil, storageNodePeer, ""},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		stream := pb.NewMockPieceStoreRoutes_RetrieveClient(ctrl)
+		stream.EXPECT().Send(gomock.Any()).Return(nil).AnyTimes()
+		stream.EXPECT().Recv().Return(&pb.PieceRetrievalStream{Size: int64(len(tt.received)), Content: tt.received, Hash: tt.hash}, nil)
+		stream.EXPECT().Recv().Return(nil, io.EOF)
+
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		c, err := NewCustomRoute(pb.NewMockPieceStoreRoutesClient(ctrl), &pb.Node{Id: storageNode.ID.String()}, 32*1024, priv)
+		assert.NoError(t, err)
+
+		rr := &pieceRanger{c: c, id: pid, size: int64(len(content)), stream: stream, pba: &pb.PayerBandwidthAllocation{}, peer: tt.peer}
+		r, err := rr.Range(ctx, 0, rr.Size())
+		if !assert.NoError(t, err, errTag) {
+			continue
+		}
+		// readers stopping at the size of the piece get the error as well
+		buf := make([]byte, len(content))
+		_, err = io.ReadFull(r, buf)
+		if tt.errString != "" {
+			if assert.Error(t, err, errTag) {
+				assert.Contains(t, err.Error(), tt.errString, errTag)
+			}
+			continue
+		}
+		if assert.NoError(t, err, errTag) {
+			assert.Equal(t, content, buf, errTag)
+		}
+	}
+}
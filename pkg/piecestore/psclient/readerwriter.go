@@ -5,12 +5,16 @@ package psclient
 
 import (
 	"crypto/ecdsa"
+	"crypto/sha256"
 	"crypto/x509"
 	"fmt"
+	"hash"
+	"io"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/peer"
 
 	"storj.io/storj/internal/sync2"
 	"storj.io/storj/pkg/pb"
@@ -23,6 +27,10 @@ type StreamWriter struct {
 	signer       *PieceStore // We need this for signing
 	totalWritten int64
 	pba          *pb.PayerBandwidthAllocation
+	id           PieceID
+	peer         *peer.Peer // Storage node, filled in when the stream is closed
+	hash         hash.Hash  // Hash of the piece content sent so far
+	closed       bool
 }
 
 // Write Piece data to a piece store server upload stream
@@ -42,7 +50,7 @@ func (s *StreamWriter) Write(b []byte) (int, error) {
 		PayerAllocation: s.pba,
 		Total:           updatedAllocation,
 		StorageNodeId:   s.signer.nodeID.Bytes(),
-		PubKey:          pubbytes, // TODO: Take this out. It will be kept in a database on the satellite
+		PubKey:          pubbytes,
 	}
 
 	serializedAllocation, err := proto.Marshal(allocationData)
@@ -69,11 +77,20 @@ func (s *StreamWriter) Write(b []byte) (int, error) {
 		return 0, fmt.Errorf("%v.Send() = %v", s.stream, err)
 	}
 
+	if s.hash != nil {
+		_, _ = s.hash.Write(b)
+	}
+
 	return len(b), nil
 }
 
-// Close the piece store Write Stream
+// Close the piece store Write Stream and verify the piece hash receipt
 func (s *StreamWriter) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
 	reply, err := s.stream.CloseAndRecv()
 	if err != nil {
 		return err
@@ -81,7 +98,16 @@ func (s *StreamWriter) Close() error {
 
 	zap.S().Infof("Stream close and recv summary: %v", reply)
 
-	return nil
+	if s.hash == nil {
+		return nil
+	}
+
+	key, err := NodeKeyFromPeer(s.peer, s.signer.nodeID)
+	if err != nil {
+		return err
+	}
+
+	return VerifyPieceHash(reply.GetHash(), key, s.id, s.hash.Sum(nil), s.totalWritten)
 }
 
 // StreamReader is a struct for reading piece download stream from server
@@ -93,6 +119,10 @@ type StreamReader struct {
 	downloaded    int64
 	allocated     int64
 	size          int64
+	hash          *pb.PieceHash
+	id            PieceID
+	peer          *peer.Peer // Storage node, filled in when the stream ended
+	hasher        hash.Hash  // Hash of the content of a whole piece, nil unless it is verified
 }
 
 // NewStreamReader creates a StreamReader for reading data from the piece store server
@@ -136,7 +166,7 @@ func NewStreamReader(client *PieceStore, stream pb.PieceStoreRoutes_RetrieveClie
 				PayerAllocation: pba,
 				Total:           sr.allocated + allocate,
 				StorageNodeId:   sr.client.nodeID.Bytes(),
-				PubKey:          pubbytes, // TODO: Take this out. It will be kept in a database on the satellite
+				PubKey:          pubbytes,
 			}
 
 			serializedAllocation, err := proto.Marshal(allocationData)
@@ -184,6 +214,10 @@ func NewStreamReader(client *PieceStore, stream pb.PieceStoreRoutes_RetrieveClie
 			return nil, err
 		}
 
+		if resp.GetHash() != nil {
+			sr.hash = resp.GetHash()
+		}
+
 		sr.downloaded += int64(len(resp.GetContent()))
 
 		err = sr.pendingAllocs.Consume(int64(len(resp.GetContent())))
@@ -192,17 +226,66 @@ func NewStreamReader(client *PieceStore, stream pb.PieceStoreRoutes_RetrieveClie
 			return resp.GetContent(), err
 		}
 
+		if sr.hasher != nil {
+			_, _ = sr.hasher.Write(resp.GetContent())
+			if sr.downloaded >= sr.size {
+				// the last content is held back when it doesn't match the receipt, so that readers
+				// stopping at the size of the piece get the error
+				if err = sr.verifyHash(); err != nil {
+					sr.pendingAllocs.Fail(err)
+					return nil, err
+				}
+				sr.pendingAllocs.Fail(io.EOF)
+				return resp.GetContent(), io.EOF
+			}
+		}
+
 		return resp.GetContent(), nil
 	})
 
 	return sr
 }
 
+// expectHash makes the reader of the whole piece id verify its content against the piece hash receipt
+// of the storage node of the stream
+func (s *StreamReader) expectHash(id PieceID, nodePeer *peer.Peer) {
+	s.id = id
+	s.peer = nodePeer
+	s.hasher = sha256.New()
+}
+
+// verifyHash waits for the end of the stream, which identifies the storage node,
+// and checks the downloaded content against the piece hash receipt
+func (s *StreamReader) verifyHash() error {
+	if _, err := s.stream.Recv(); err != io.EOF {
+		if err == nil {
+			return HashError.New("piece %s is larger than %d bytes", s.id, s.size)
+		}
+		return err
+	}
+
+	// pieces stored before the storage nodes hashed them have no receipt
+	if s.hash == nil {
+		return nil
+	}
+
+	key, err := NodeKeyFromPeer(s.peer, s.client.nodeID)
+	if err != nil {
+		return err
+	}
+	return VerifyPieceHash(s.hash, key, s.id, s.hasher.Sum(nil), s.downloaded)
+}
+
 // Read Piece data from piece store server download stream
 func (s *StreamReader) Read(b []byte) (int, error) {
 	return s.src.Read(b)
 }
 
+// Hash returns the piece hash receipt sent by the storage node, when it has been received
+func (s *StreamReader) Hash() *pb.PieceHash {
+	return s.hash
+}
+
 // Close the piece store server Read Stream
 func (s *StreamReader) Close() error {
 	return utils.CombineErrors(
@@ -5,6 +5,7 @@ package agreementsender
 
 import (
 	"flag"
+	"sync"
 	"time"
 
 	"github.com/zeebo/errs"
@@ -16,6 +17,7 @@ import (
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/pkg/piecestore/psserver/trust"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/utils"
 )
@@ -23,6 +25,9 @@ import (
 var (
 	defaultCheckInterval = flag.Duration("piecestore.agreementsender.check_interval", time.Hour, "number of seconds to sleep between agreement checks")
 	defaultOverlayAddr   = flag.String("piecestore.agreementsender.overlay_addr", "127.0.0.1:7777", "Overlay Address")
+	defaultBatchSize     = flag.Int("piecestore.agreementsender.batch_size", 100, "maximum number of agreements sent to a satellite at once")
+	defaultRetryBackoff  = flag.Duration("piecestore.agreementsender.retry_backoff", time.Hour, "how long to wait before sending a failed agreement again, doubled on every failure")
+	defaultMaxBackoff    = flag.Duration("piecestore.agreementsender.max_backoff", 48*time.Hour, "maximum time to wait before sending a failed agreement again")
 
 	// ASError wraps errors returned from agreementsender package
 	ASError = errs.Class("agreement sender error")
@@ -33,98 +38,167 @@ type AgreementSender struct {
 	DB       *psdb.DB
 	overlay  overlay.Client
 	identity *provider.FullIdentity
-	errs     []error
+	trust    *trust.List
 }
 
-// Initialize the Agreement Sender
-func Initialize(DB *psdb.DB, identity *provider.FullIdentity) (*AgreementSender, error) {
+// Initialize the Agreement Sender, agreements are only sent to trusted satellites
+func Initialize(DB *psdb.DB, identity *provider.FullIdentity, trust *trust.List) (*AgreementSender, error) {
 	overlay, err := overlay.NewOverlayClient(identity, *defaultOverlayAddr)
 	if err != nil {
 		return nil, err
 	}
 
-	return &AgreementSender{DB: DB, identity: identity, overlay: overlay}, nil
+	return &AgreementSender{DB: DB, identity: identity, overlay: overlay, trust: trust}, nil
 }
 
-// Run the afreement sender with a context to cehck for cancel
+// Run the agreement sender with a context to check for cancel
 func (as *AgreementSender) Run(ctx context.Context) error {
 	zap.S().Info("AgreementSender is starting up")
 
-	type agreementGroup struct {
-		satellite  string
-		agreements []*psdb.Agreement
+	ticker := time.NewTicker(*defaultCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := as.SendAgreements(ctx); err != nil {
+			zap.S().Error(err)
+		}
+
+		select {
+		case <-ticker.C: // wait for the next interval to happen
+		case <-ctx.Done(): // or the sender is canceled via context
+			return ctx.Err()
+		}
 	}
+}
 
-	c := make(chan *agreementGroup, 1)
+// SendAgreements sends the agreements that are due to their satellites
+func (as *AgreementSender) SendAgreements(ctx context.Context) error {
+	agreementGroups, err := as.DB.GetUnsentAgreements(time.Now())
+	if err != nil {
+		return ASError.Wrap(err)
+	}
 
-	ticker := time.NewTicker(*defaultCheckInterval)
-	defer ticker.Stop()
-	go func() {
-		for range ticker.C {
-			agreementGroups, err := as.DB.GetBandwidthAllocations()
-			if err != nil {
-				zap.S().Error(err)
-				continue
-			}
+	// Send agreements in groups by satellite id to open less connections
+	var wg sync.WaitGroup
+	for satellite, agreements := range agreementGroups {
+		if !as.trust.IsTrusted(satellite) {
+			zap.S().Warnf("Not sending %v agreements to untrusted satellite %s", len(agreements), satellite)
+			continue
+		}
 
-			// Send agreements in groups by satellite id to open less connections
-			for satellite, agreements := range agreementGroups {
-				c <- &agreementGroup{satellite, agreements}
+		wg.Add(1)
+		go func(satellite string, agreements []*psdb.Agreement) {
+			defer wg.Done()
+			zap.S().Infof("Sending %v agreements to satellite %s", len(agreements), satellite)
+
+			if err := as.sendToSatellite(ctx, satellite, agreements); err != nil {
+				zap.S().Errorf("Failed to send agreements to satellite %s: %+v", satellite, err)
+				as.retry(agreements)
 			}
+		}(satellite, agreements)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// sendToSatellite connects to the satellite and sends the agreements in batches
+func (as *AgreementSender) sendToSatellite(ctx context.Context, satellite string, agreements []*psdb.Agreement) (err error) {
+	// Use the configured address or get satellite ip from overlay by Lookup satellite
+	address := as.trust.Address(satellite)
+	if address == "" {
+		node, err := as.overlay.Lookup(ctx, node.IDFromString(satellite))
+		if err != nil {
+			return err
 		}
-	}()
+		address = node.GetAddress().GetAddress()
+	}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return utils.CombineErrors(as.errs...)
-		case agreementGroup := <-c:
-			go func() {
-				zap.S().Infof("Sending %v agreements to satellite %s\n", len(agreementGroup.agreements), agreementGroup.satellite)
-
-				// Get satellite ip from overlay by Lookup agreementGroup.satellite
-				satellite, err := as.overlay.Lookup(ctx, node.IDFromString(agreementGroup.satellite))
-				if err != nil {
-					zap.S().Error(err)
-					return
-				}
-
-				// Create client from satellite ip
-				identOpt, err := as.identity.DialOption("")
-				if err != nil {
-					zap.S().Error(err)
-					return
-				}
-
-				conn, err := grpc.Dial(satellite.GetAddress().String(), identOpt)
-				if err != nil {
-					zap.S().Error(err)
-					return
-				}
-
-				client := pb.NewBandwidthClient(conn)
-
-				for _, agreement := range agreementGroup.agreements {
-
-					msg := &pb.RenterBandwidthAllocation{
-						Data:      agreement.Agreement,
-						Signature: agreement.Signature,
-					}
-
-					// Send agreement to satellite
-					r, err := client.BandwidthAgreements(ctx, msg)
-					if err != nil || r.GetStatus() != pb.AgreementsSummary_OK {
-						zap.S().Errorf("Failed to send agreement to satellite: %+v", err)
-						return
-					}
-
-					// Delete from PSDB by signature
-					if err = as.DB.DeleteBandwidthAllocationBySignature(agreement.Signature); err != nil {
-						zap.S().Error(err)
-						return
-					}
-				}
-			}()
+	// Create client from satellite ip
+	identOpt, err := as.identity.DialOption("")
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.Dial(address, identOpt)
+	if err != nil {
+		return err
+	}
+	defer func() { err = utils.CombineErrors(err, conn.Close()) }()
+
+	client := pb.NewBandwidthClient(conn)
+	for len(agreements) > 0 {
+		batch := agreements
+		if len(batch) > *defaultBatchSize {
+			batch = batch[:*defaultBatchSize]
 		}
+		agreements = agreements[len(batch):]
+
+		if err := as.sendBatch(ctx, client, batch); err != nil {
+			zap.S().Errorf("Failed to send agreements to satellite %s: %+v", satellite, err)
+			as.retry(batch)
+		}
+	}
+	return nil
+}
+
+// sendBatch sends the agreements at once and stores the reply of the satellite for every agreement
+func (as *AgreementSender) sendBatch(ctx context.Context, client pb.BandwidthClient, agreements []*psdb.Agreement) error {
+	req := &pb.AgreementsBatchRequest{}
+	signatures := make([][]byte, 0, len(agreements))
+	for _, agreement := range agreements {
+		req.Agreements = append(req.Agreements, &pb.RenterBandwidthAllocation{
+			Data:      agreement.Agreement,
+			Signature: agreement.Signature,
+		})
+		signatures = append(signatures, agreement.Signature)
+	}
+
+	if err := as.DB.MarkAgreementsSent(signatures); err != nil {
+		return ASError.Wrap(err)
+	}
+
+	resp, err := client.BandwidthAgreementsBatch(ctx, req)
+	if err != nil {
+		return ASError.Wrap(err)
+	}
+	if len(resp.GetSummaries()) != len(agreements) {
+		return ASError.New("expected %d agreement summaries got %d", len(agreements), len(resp.GetSummaries()))
+	}
+
+	var errors []error
+	for i, summary := range resp.GetSummaries() {
+		agreement := agreements[i]
+		switch summary.GetStatus() {
+		case pb.AgreementsSummary_OK:
+			errors = append(errors, as.DB.AcceptAgreement(agreement.Signature))
+		case pb.AgreementsSummary_REJECTED:
+			zap.S().Warnf("Satellite %s rejected agreement: %s", agreement.Satellite, summary.GetReason())
+			errors = append(errors, as.DB.RejectAgreement(agreement.Signature, summary.GetReason()))
+		default:
+			errors = append(errors, as.DB.RetryAgreement(agreement.Signature, time.Now().Add(backoff(agreement.Attempts))))
+		}
+	}
+	return ASError.Wrap(utils.CombineErrors(errors...))
+}
+
+// retry schedules the agreements to be sent again after backing off
+func (as *AgreementSender) retry(agreements []*psdb.Agreement) {
+	for _, agreement := range agreements {
+		if err := as.DB.RetryAgreement(agreement.Signature, time.Now().Add(backoff(agreement.Attempts))); err != nil {
+			zap.S().Error(err)
+		}
+	}
+}
+
+// backoff returns how long to wait before sending an agreement again after the failed attempts
+func backoff(attempts int) time.Duration {
+	delay := *defaultRetryBackoff
+	for i := 0; i < attempts && delay < *defaultMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > *defaultMaxBackoff {
+		delay = *defaultMaxBackoff
 	}
+	return delay
 }
@@ -0,0 +1,99 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package agreementsender
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
+)
+
+type bandwidthClient struct {
+	summaries []*pb.AgreementsSummary
+	err       error
+}
+
+func (client *bandwidthClient) BandwidthAgreements(ctx context.Context, in *pb.RenterBandwidthAllocation, opts ...grpc.CallOption) (*pb.AgreementsSummary, error) {
+	return nil, client.err
+}
+
+func (client *bandwidthClient) BandwidthAgreementsBatch(ctx context.Context, in *pb.AgreementsBatchRequest, opts ...grpc.CallOption) (*pb.AgreementsBatchResponse, error) {
+	return &pb.AgreementsBatchResponse{Summaries: client.summaries}, client.err
+}
+
+func TestSendBatch(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := psdb.OpenInMemory(ctx, nil)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	satelliteData, err := proto.Marshal(&pb.PayerBandwidthAllocation_Data{SatelliteId: []byte("satellite")})
+	require.NoError(t, err)
+
+	for _, signature := range []string{"accepted", "rejected", "failed"} {
+		data, err := proto.Marshal(&pb.RenterBandwidthAllocation_Data{
+			PayerAllocation: &pb.PayerBandwidthAllocation{Data: satelliteData},
+		})
+		require.NoError(t, err)
+		require.NoError(t, db.WriteBandwidthAllocToDB(&pb.RenterBandwidthAllocation{
+			Data:      data,
+			Signature: []byte(signature),
+		}))
+	}
+
+	unsent, err := db.GetUnsentAgreements(time.Now())
+	require.NoError(t, err)
+	agreements := unsent["satellite"]
+	require.Len(t, agreements, 3)
+
+	as := &AgreementSender{DB: db}
+
+	// summaries that don't match the batch leave the agreements to be sent again
+	err = as.sendBatch(ctx, &bandwidthClient{}, agreements)
+	assert.Error(t, err)
+	unsent, err = db.GetUnsentAgreements(time.Now())
+	require.NoError(t, err)
+	assert.Len(t, unsent["satellite"], 3)
+
+	err = as.sendBatch(ctx, &bandwidthClient{summaries: []*pb.AgreementsSummary{
+		{Status: pb.AgreementsSummary_OK},
+		{Status: pb.AgreementsSummary_REJECTED, Reason: "expired"},
+		{Status: pb.AgreementsSummary_FAIL},
+	}}, agreements)
+	require.NoError(t, err)
+
+	unsent, err = db.GetUnsentAgreements(time.Now())
+	require.NoError(t, err)
+	assert.Len(t, unsent["satellite"], 0)
+
+	unsent, err = db.GetUnsentAgreements(time.Now().Add(backoff(1)))
+	require.NoError(t, err)
+	if assert.Len(t, unsent["satellite"], 1) {
+		assert.Equal(t, "failed", string(unsent["satellite"][0].Signature))
+		assert.Equal(t, 1, unsent["satellite"][0].Attempts)
+	}
+
+	rejected, err := db.GetRejectedAgreements()
+	require.NoError(t, err)
+	if assert.Len(t, rejected["satellite"], 1) {
+		assert.Equal(t, "rejected", string(rejected["satellite"][0].Signature))
+		assert.Equal(t, "expired", rejected["satellite"][0].Reason)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, *defaultRetryBackoff, backoff(0))
+	assert.Equal(t, 2**defaultRetryBackoff, backoff(1))
+	assert.Equal(t, 4**defaultRetryBackoff, backoff(2))
+	assert.Equal(t, *defaultMaxBackoff, backoff(100))
+}
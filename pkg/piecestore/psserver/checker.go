@@ -0,0 +1,232 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+)
+
+// CheckError is a type of error for failures of the consistency checker
+var CheckError = errs.Class("consistency check error")
+
+// Kinds of inconsistencies between the piece database and the disk
+const (
+	UntrackedBlob = "untracked-blob" // blob on disk without a ttl
+	MissingBlob   = "missing-blob"   // ttl without a blob on disk
+	SizeMismatch  = "size-mismatch"  // blob size differs from the size in the ttl
+)
+
+// Actions the consistency checker takes on inconsistencies
+const (
+	CheckReport     = "report"
+	CheckRepair     = "repair"
+	CheckQuarantine = "quarantine"
+)
+
+// uploadGrace is how long blobs without a ttl are assumed to be uploads in progress
+const uploadGrace = time.Hour
+
+// Inconsistency is a piece that differs between the piece database and the disk
+type Inconsistency struct {
+	Kind         string `json:"kind"`
+	ID           string `json:"id,omitempty"` // empty when no piece references the blob
+	BlobRef      string `json:"blobref,omitempty"`
+	RecordedSize int64  `json:"recorded_size"`
+	DiskSize     int64  `json:"disk_size"`
+	Action       string `json:"action"` // reported, repaired or quarantined
+	Error        string `json:"error,omitempty"`
+}
+
+// walkableBlobs is a blob store that can list and quarantine its blobs
+type walkableBlobs interface {
+	Walk(ctx context.Context, fn func(filestore.BlobInfo) error) error
+	Quarantine(ctx context.Context, ref storage.BlobRef) error
+}
+
+// Checker compares the piece database against the blobs on disk
+type Checker struct {
+	server *Server
+	action string
+	ticker *time.Ticker
+}
+
+// NewChecker creates a Checker comparing every interval and taking action on the inconsistencies
+func NewChecker(server *Server, interval time.Duration, action string) *Checker {
+	return &Checker{
+		server: server,
+		action: action,
+		ticker: time.NewTicker(interval),
+	}
+}
+
+// Run the consistency check loop
+func (checker *Checker) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		found, err := checker.Check(ctx)
+		if err != nil {
+			zap.S().Errorf("Consistency check failed: %v", err)
+		}
+		for _, inconsistency := range found {
+			data, _ := json.Marshal(inconsistency)
+			zap.S().Warnf("Piece store inconsistency: %s", data)
+		}
+
+		select {
+		case <-checker.ticker.C: // wait for the next interval to happen
+		case <-ctx.Done(): // or the checker is canceled via context
+			return ctx.Err()
+		}
+	}
+}
+
+// Check finds the pieces that differ between the piece database and the disk
+// and reports, repairs or quarantines them
+func (checker *Checker) Check(ctx context.Context) (found []Inconsistency, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	switch checker.action {
+	case CheckReport, CheckRepair, CheckQuarantine:
+	default:
+		return nil, CheckError.New("unknown action %q", checker.action)
+	}
+
+	blobs, ok := checker.server.Blobs.(walkableBlobs)
+	if !ok {
+		return nil, CheckError.New("blob store %T cannot be checked", checker.server.Blobs)
+	}
+
+	// read the database before the disk, so new uploads only show up as untracked blobs
+	pieces, err := checker.server.DB.GetStoredPieces()
+	if err != nil {
+		return nil, CheckError.Wrap(err)
+	}
+	trashed, err := checker.server.DB.GetTrashBlobRefs()
+	if err != nil {
+		return nil, CheckError.Wrap(err)
+	}
+
+	onDisk := map[storage.BlobRef]filestore.BlobInfo{}
+	err = blobs.Walk(ctx, func(info filestore.BlobInfo) error {
+		onDisk[info.Ref] = info
+		return nil
+	})
+	if err != nil {
+		return nil, CheckError.Wrap(err)
+	}
+
+	tracked := map[storage.BlobRef]bool{}
+	for _, ref := range trashed {
+		tracked[ref] = true
+	}
+
+	for _, piece := range pieces {
+		inconsistency := Inconsistency{ID: piece.ID, RecordedSize: piece.Size}
+
+		var info filestore.BlobInfo
+		var exists bool
+		if piece.BlobRef != nil {
+			tracked[*piece.BlobRef] = true
+			inconsistency.BlobRef = hex.EncodeToString(piece.BlobRef[:])
+			info, exists = onDisk[*piece.BlobRef]
+		}
+
+		switch {
+		case !exists:
+			inconsistency.Kind = MissingBlob
+		case !piece.HasTTL:
+			inconsistency.Kind = UntrackedBlob
+			inconsistency.DiskSize = info.Size
+		case piece.Size != info.Size:
+			inconsistency.Kind = SizeMismatch
+			inconsistency.DiskSize = info.Size
+		default:
+			continue
+		}
+
+		checker.resolve(ctx, blobs, &inconsistency, piece.BlobRef)
+		found = append(found, inconsistency)
+	}
+
+	var untracked []filestore.BlobInfo
+	for ref, info := range onDisk {
+		if !tracked[ref] && time.Since(info.Modified) > uploadGrace {
+			untracked = append(untracked, info)
+		}
+	}
+	sort.Slice(untracked, func(i, k int) bool {
+		return hex.EncodeToString(untracked[i].Ref[:]) < hex.EncodeToString(untracked[k].Ref[:])
+	})
+
+	for _, info := range untracked {
+		ref := info.Ref
+		inconsistency := Inconsistency{
+			Kind:     UntrackedBlob,
+			BlobRef:  hex.EncodeToString(ref[:]),
+			DiskSize: info.Size,
+		}
+		checker.resolve(ctx, blobs, &inconsistency, &ref)
+		found = append(found, inconsistency)
+	}
+
+	counts := map[string]int64{}
+	for _, inconsistency := range found {
+		counts[inconsistency.Kind]++
+	}
+	mon.IntVal("untracked_blobs").Observe(counts[UntrackedBlob])
+	mon.IntVal("missing_blobs").Observe(counts[MissingBlob])
+	mon.IntVal("size_mismatches").Observe(counts[SizeMismatch])
+
+	return found, nil
+}
+
+// resolve takes the action of the checker on the inconsistency
+func (checker *Checker) resolve(ctx context.Context, blobs walkableBlobs, inconsistency *Inconsistency, ref *storage.BlobRef) {
+	if checker.action == CheckReport {
+		inconsistency.Action = "reported"
+		return
+	}
+
+	db := checker.server.DB
+	quarantine := checker.action == CheckQuarantine && ref != nil
+
+	var err error
+	switch {
+	case inconsistency.Kind == MissingBlob:
+		// there is nothing left to keep of the piece
+		err = checker.server.deleteByID(ctx, inconsistency.ID)
+	case quarantine:
+		err = blobs.Quarantine(ctx, *ref)
+		if err == nil && inconsistency.ID != "" {
+			err = checker.server.deleteByID(ctx, inconsistency.ID)
+		}
+	case inconsistency.Kind == UntrackedBlob && inconsistency.ID == "":
+		err = checker.server.Blobs.Delete(ctx, *ref)
+	case inconsistency.Kind == UntrackedBlob:
+		err = db.AddTTL(inconsistency.ID, 0, inconsistency.DiskSize)
+	case inconsistency.Kind == SizeMismatch:
+		err = db.UpdatePieceSize(inconsistency.ID, inconsistency.DiskSize)
+	}
+
+	switch {
+	case err != nil:
+		inconsistency.Action = "reported"
+		inconsistency.Error = err.Error()
+	case quarantine && inconsistency.Kind != MissingBlob:
+		inconsistency.Action = "quarantined"
+	default:
+		inconsistency.Action = "repaired"
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// Cleaner deletes the pieces of satellites that were removed from the trusted satellites
+type Cleaner struct {
+	server *Server
+	delay  time.Duration
+	ticker *time.Ticker
+}
+
+// NewCleaner creates a Cleaner checking every interval, pieces are deleted delay after the satellite was queued
+func NewCleaner(server *Server, interval, delay time.Duration) *Cleaner {
+	return &Cleaner{
+		server: server,
+		delay:  delay,
+		ticker: time.NewTicker(interval),
+	}
+}
+
+// Run the cleanup loop
+func (cleaner *Cleaner) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		if _, err := cleaner.Cleanup(ctx); err != nil {
+			zap.S().Errorf("Cleaning up untrusted satellites failed: %v", err)
+		}
+
+		select {
+		case <-cleaner.ticker.C: // wait for the next interval to happen
+		case <-ctx.Done(): // or the cleaner is canceled via context
+			return ctx.Err()
+		}
+	}
+}
+
+// Cleanup queues the satellites that are no longer trusted and deletes
+// the pieces of the satellites that have been queued longer than the delay
+func (cleaner *Cleaner) Cleanup(ctx context.Context) (deleted int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db, trust := cleaner.server.DB, cleaner.server.trust
+
+	satellites, err := db.GetPieceSatellites()
+	if err != nil {
+		return 0, err
+	}
+	for _, satellite := range satellites {
+		if trust.IsTrusted(satellite) {
+			continue
+		}
+		if err := db.QueueCleanup(satellite); err != nil {
+			return 0, err
+		}
+	}
+
+	cleanups, err := db.GetCleanups()
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	for _, cleanup := range cleanups {
+		// the satellite was added back before its pieces were deleted
+		if trust.IsTrusted(cleanup.Satellite) {
+			if err := db.DequeueCleanup(cleanup.Satellite); err != nil {
+				return deleted, err
+			}
+			continue
+		}
+
+		if now.Before(time.Unix(cleanup.Queued, 0).Add(cleaner.delay)) {
+			continue
+		}
+
+		infos, err := db.GetPieceInfosBySatellite(cleanup.Satellite)
+		if err != nil {
+			return deleted, err
+		}
+		for _, info := range infos {
+			if err := cleaner.server.deleteByID(ctx, info.ID); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+
+		zap.S().Infof("Deleted %d pieces of untrusted satellite %s", len(infos), cleanup.Satellite)
+		if err := db.DequeueCleanup(cleanup.Satellite); err != nil {
+			return deleted, err
+		}
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/utils"
+)
+
+// ExitWorker transfers the pieces of the satellites the storage node is exiting from
+type ExitWorker struct {
+	server    *Server
+	transport transport.Client
+	ticker    *time.Ticker
+}
+
+// NewExitWorker creates an ExitWorker checking for exits every interval
+func NewExitWorker(server *Server, transport transport.Client, interval time.Duration) *ExitWorker {
+	return &ExitWorker{
+		server:    server,
+		transport: transport,
+		ticker:    time.NewTicker(interval),
+	}
+}
+
+// Run the exit loop
+func (worker *ExitWorker) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		if err := worker.process(ctx); err != nil {
+			zap.S().Errorf("Graceful exit failed: %v", err)
+		}
+
+		select {
+		case <-worker.ticker.C: // wait for the next interval to happen
+		case <-ctx.Done(): // or the worker is canceled via context
+			return ctx.Err()
+		}
+	}
+}
+
+// process continues all unfinished exits
+func (worker *ExitWorker) process(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	exits, err := worker.server.DB.GetExits()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, exit := range exits {
+		if exit.Finished != 0 {
+			continue
+		}
+		if err := worker.exit(ctx, exit); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utils.CombineErrors(errs...)
+}
+
+// exit transfers pieces until the satellite has no more transfer orders
+func (worker *ExitWorker) exit(ctx context.Context, exit psdb.Exit) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	conn, err := worker.transport.DialAddress(ctx, exit.Address)
+	if err != nil {
+		return err
+	}
+	defer func() { err = utils.CombineErrors(err, conn.Close()) }()
+
+	client := pb.NewGracefulExitClient(conn)
+
+	for {
+		resp, err := client.GetTransfers(ctx, &pb.GetTransfersRequest{})
+		if err != nil {
+			return err
+		}
+		if len(resp.GetOrders()) == 0 {
+			break
+		}
+
+		for _, order := range resp.GetOrders() {
+			req := &pb.CompleteTransferRequest{Path: order.GetPath(), PieceNum: order.GetPieceNum()}
+
+			req.Hash, req.Size, err = worker.transfer(ctx, exit.Satellite, order)
+			if err != nil {
+				zap.S().Warnf("Failed to transfer %s to %s: %v", order.GetPieceId(), order.GetTarget().GetId(), err)
+				req.Failed = true
+				req.Error = err.Error()
+			}
+
+			if _, err := client.CompleteTransfer(ctx, req); err != nil {
+				return err
+			}
+		}
+	}
+
+	progress, err := client.Progress(ctx, &pb.ExitProgressRequest{})
+	if err != nil {
+		return err
+	}
+	if progress.GetFinishedUnixSec() == 0 {
+		return nil
+	}
+
+	// the satellite doesn't reference the pieces stored before the exit anymore
+	infos, err := worker.server.DB.GetPieceInfosCreatedBefore(exit.Satellite, time.Unix(exit.Started, 0))
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		if err := worker.server.deleteByID(ctx, info.ID); err != nil {
+			return err
+		}
+	}
+
+	zap.S().Infof("Exited from satellite %s, transferred %d of %d pieces",
+		exit.Satellite, progress.GetTransfersCompleted(), progress.GetTransfersTotal())
+
+	return worker.server.DB.FinishExit(exit.Satellite)
+}
+
+// transfer uploads the piece to the target node of the order
+func (worker *ExitWorker) transfer(ctx context.Context, satellite string, order *pb.TransferOrder) (hash []byte, size int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	info, err := worker.server.DB.GetPieceInfoByPieceID(satellite, order.GetPieceId())
+	if err == sql.ErrNoRows {
+		// pieces stored before piece info was recorded
+		info.ID, err = getNamespacedPieceID([]byte(order.GetPieceId()), getNamespace(order.GetAuthorization()))
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	blob, err := worker.server.loadPiece(ctx, info.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { err = utils.CombineErrors(err, blob.Close()) }()
+
+	ps, err := psclient.NewPSClient(ctx, worker.transport, order.GetTarget(), 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { err = utils.CombineErrors(err, ps.Close()) }()
+
+	hasher := sha256.New()
+	counter := &countingReader{reader: io.TeeReader(blob, hasher)}
+
+	expiration := time.Unix(order.GetExpirationUnixSec(), 0)
+	err = ps.Put(ctx, psclient.PieceID(order.GetTargetPieceId()), counter, expiration, order.GetBandwidthAllocation(), order.GetAuthorization())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return hasher.Sum(nil), counter.total, nil
+}
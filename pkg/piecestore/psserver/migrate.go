@@ -0,0 +1,112 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/piecestore"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/pkg/utils"
+	"storj.io/storj/storage"
+)
+
+// migrateLegacyPieces moves pieces stored with pstore.StoreWriter in legacyDir into blobs.
+// Pieces that already have a blob reference are only removed from legacyDir,
+// which makes it safe to rerun after a crash. legacyDir is removed afterwards.
+func migrateLegacyPieces(ctx context.Context, legacyDir string, blobs storage.Blobs, db *psdb.DB) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if _, err := os.Stat(legacyDir); os.IsNotExist(err) {
+		return nil
+	}
+
+	zap.S().Infof("Migrating pieces from %s", legacyDir)
+
+	migrated := 0
+	err = filepath.Walk(legacyDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(legacyDir, path)
+		if err != nil {
+			return err
+		}
+
+		// pstore.PathByID splits id as id[0:2]/id[2:4]/id[4:]
+		id := strings.Replace(filepath.ToSlash(rel), "/", "", -1)
+		if len(id) < pstore.IDLength {
+			zap.S().Warnf("Skipping unexpected file %s", path)
+			return nil
+		}
+
+		if err := migrateLegacyPiece(ctx, path, id, blobs, db); err != nil {
+			return err
+		}
+
+		migrated++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	zap.S().Infof("Migrated %d pieces", migrated)
+
+	return os.RemoveAll(legacyDir)
+}
+
+// migrateLegacyPiece moves a single piece file into blobs
+func migrateLegacyPiece(ctx context.Context, path, id string, blobs storage.Blobs, db *psdb.DB) error {
+	// the piece may have been migrated already, when we crashed before removing it
+	_, err := db.GetBlobRef(id)
+	if err == nil {
+		return os.Remove(path)
+	}
+	if err != sql.ErrNoRows {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return utils.CombineErrors(err, file.Close())
+	}
+
+	hasher := sha256.New()
+	ref, err := blobs.Store(ctx, io.TeeReader(file, hasher), info.Size())
+	if err != nil {
+		return utils.CombineErrors(err, file.Close())
+	}
+
+	if err := file.Close(); err != nil {
+		return utils.CombineErrors(err, blobs.Delete(ctx, ref))
+	}
+
+	if err := db.AddBlobRef(id, ref); err != nil {
+		return utils.CombineErrors(err, blobs.Delete(ctx, ref))
+	}
+
+	if err := db.AddPieceHash(id, hasher.Sum(nil)); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
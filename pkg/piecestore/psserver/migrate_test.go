@@ -0,0 +1,80 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/pkg/piecestore"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/storage/filestore"
+)
+
+func TestMigrateLegacyPieces(t *testing.T) {
+	assert := assert.New(t)
+
+	tmp, err := ioutil.TempDir("", "storj-piecestore-migrate")
+	if !assert.NoError(err) {
+		return
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	legacyDir := filepath.Join(tmp, "piece-store-data")
+
+	blobs, err := filestore.NewAt(filepath.Join(tmp, "blobs"))
+	if !assert.NoError(err) {
+		return
+	}
+
+	db, err := psdb.OpenInMemory(ctx, blobs)
+	if !assert.NoError(err) {
+		return
+	}
+	defer func() { assert.NoError(db.Close()) }()
+
+	pieces := map[string]string{
+		"11111111111111111111": "butts",
+		"22222222222222222222": "more butts",
+	}
+
+	for id, content := range pieces {
+		file, err := pstore.StoreWriter(id, legacyDir)
+		if !assert.NoError(err) {
+			return
+		}
+		_, err = file.Write([]byte(content))
+		assert.NoError(err)
+		assert.NoError(file.Close())
+	}
+
+	assert.NoError(migrateLegacyPieces(ctx, legacyDir, blobs, db))
+
+	for id, content := range pieces {
+		ref, err := db.GetBlobRef(id)
+		if !assert.NoError(err) {
+			continue
+		}
+
+		blob, err := blobs.Load(ctx, ref)
+		if !assert.NoError(err) {
+			continue
+		}
+
+		data, err := ioutil.ReadAll(blob)
+		assert.NoError(err)
+		assert.Equal(content, string(data))
+		assert.NoError(blob.Close())
+	}
+
+	_, err = os.Stat(legacyDir)
+	assert.True(os.IsNotExist(err), "legacy directory should be removed")
+
+	// running the migration again should be a no-op
+	assert.NoError(migrateLegacyPieces(ctx, legacyDir, blobs, db))
+}
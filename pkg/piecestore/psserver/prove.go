@@ -0,0 +1,143 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"database/sql"
+	"io"
+	"os"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/pkg/merkle"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore"
+	"storj.io/storj/pkg/utils"
+)
+
+// ProveError is a type of error for failures in Server.Prove()
+var ProveError = errs.Class("prove error")
+
+// Prove returns an erasure share of a piece with the proof that it belongs to the Merkle tree of
+// the piece, so that a satellite can audit the piece without downloading shares from other nodes
+func (s *Server) Prove(ctx context.Context, in *pb.PieceProof) (_ *pb.PieceProofSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	authorization := in.GetAuthorization()
+	if err := s.verifier(authorization); err != nil {
+		return nil, ProveError.Wrap(err)
+	}
+
+	satellite := string(getNamespace(authorization))
+	if err := s.verifyTrusted(satellite); err != nil {
+		return nil, err
+	}
+
+	shareSize, index := int64(in.GetShareSize()), in.GetShareIndex()
+	if shareSize <= 0 || index < 0 {
+		return nil, pstore.ArgError.New("invalid share %d of size %d", index, shareSize)
+	}
+
+	id, err := getNamespacedPieceID([]byte(in.GetId()), getNamespace(authorization))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validatePieceID(id); err != nil {
+		return nil, err
+	}
+
+	alloc := in.GetBandwidthallocation()
+	if err := s.verifyProofAllocation(ctx, alloc, satellite, in.GetId(), shareSize); err != nil {
+		return nil, ProveError.Wrap(err)
+	}
+
+	// reject the audit when the satellite has used up its bandwidth share
+	bandwidth, err := s.satelliteAvailableBandwidth(satellite)
+	if err != nil {
+		return nil, ProveError.Wrap(err)
+	}
+	if bandwidth < shareSize {
+		return nil, QuotaError.New("bandwidth share of satellite exceeded, %d bytes available", bandwidth)
+	}
+
+	zap.S().Infof("Proving share %d of %s...", index, in.GetId())
+
+	blob, err := s.loadPiece(ctx, id)
+	if err == sql.ErrNoRows || os.IsNotExist(err) {
+		// the satellite fails the audit of a missing piece instead of waiting for an answer
+		return nil, status.Errorf(codes.NotFound, "piece %s not found", in.GetId())
+	}
+	if err != nil {
+		return nil, ProveError.Wrap(err)
+	}
+	defer utils.LogClose(blob)
+
+	if index*shareSize >= blob.Size() {
+		return nil, pstore.ArgError.New("share %d is beyond the piece of %d bytes", index, blob.Size())
+	}
+
+	// the whole piece is hashed, the tree isn't kept since the share size is only known by the satellite
+	hasher := merkle.NewShareHasher(int(shareSize))
+	if _, err := io.Copy(hasher, blob); err != nil {
+		return nil, ProveError.Wrap(err)
+	}
+
+	share := make([]byte, shareSize)
+	n, err := blob.ReadAt(share, index*shareSize)
+	if err != nil && err != io.EOF {
+		return nil, ProveError.Wrap(err)
+	}
+
+	proof, err := merkle.Proof(hasher.Leaves(), int(index))
+	if err != nil {
+		return nil, ProveError.Wrap(err)
+	}
+
+	if err := s.DB.WriteBandwidthAllocToDB(alloc); err != nil {
+		return nil, ProveError.Wrap(err)
+	}
+	if err := s.DB.AddBandwidthUsed(pb.PayerBandwidthAllocation_GET, int64(n)); err != nil {
+		return nil, ProveError.Wrap(err)
+	}
+	if err := s.DB.AddSatelliteBandwidthUsed(satellite, pb.PayerBandwidthAllocation_GET, int64(n)); err != nil {
+		return nil, ProveError.Wrap(err)
+	}
+
+	zap.S().Infof("Successfully proved share %d of %s.", index, in.GetId())
+
+	return &pb.PieceProofSummary{Share: share[:n], Proof: proof}, nil
+}
+
+// verifyProofAllocation checks the allocation of the satellite paying for a share of shareSize bytes of the piece
+func (s *Server) verifyProofAllocation(ctx context.Context, alloc *pb.RenterBandwidthAllocation, satellite, pieceID string, shareSize int64) error {
+	allocData := &pb.RenterBandwidthAllocation_Data{}
+	if err := proto.Unmarshal(alloc.GetData(), allocData); err != nil {
+		return err
+	}
+
+	payer, err := s.verifyPayer(allocData, satellite)
+	if err != nil {
+		return err
+	}
+	if err := s.verifySignature(ctx, payer, alloc); err != nil {
+		return err
+	}
+	if err := verifyAction(payer, pb.PayerBandwidthAllocation_GET); err != nil {
+		return err
+	}
+	if err := s.verifyOrderLimit(payer, allocData, pieceID); err != nil {
+		return err
+	}
+	if allocData.GetTotal() < shareSize {
+		return ErrOrderLimit.New("allocated %d bytes for a share of %d bytes", allocData.GetTotal(), shareSize)
+	}
+	_, err = s.useSerialNumber(payer, "")
+	return err
+}
@@ -20,8 +20,8 @@ import (
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/pkg/pb"
-	"storj.io/storj/pkg/piecestore"
 	"storj.io/storj/pkg/utils"
+	"storj.io/storj/storage"
 )
 
 var (
@@ -31,20 +31,38 @@ var (
 
 // DB is a piece store database
 type DB struct {
-	dataPath string
-	mu       sync.Mutex
-	DB       *sql.DB // TODO: hide
-	check    *time.Ticker
+	blobs storage.Blobs
+	mu    sync.Mutex
+	DB    *sql.DB // TODO: hide
+	check *time.Ticker
 }
 
 // Agreement is a struct that contains a bandwidth agreement and the associated signature
 type Agreement struct {
+	Satellite string
 	Agreement []byte
 	Signature []byte
+	Status    AgreementStatus
+	Attempts  int
+	Reason    string // why the satellite rejected the agreement
 }
 
-// Open opens DB at DBPath
-func Open(ctx context.Context, dataPath, DBPath string) (db *DB, err error) {
+// AgreementStatus is the state of a bandwidth agreement in the outbox of the agreement sender
+type AgreementStatus int
+
+// Agreement states, accepted agreements are kept for a while and rejected ones until deleted by the operator
+const (
+	AgreementUnsent   AgreementStatus = 0 // waiting to be sent
+	AgreementSent     AgreementStatus = 1 // sent, waiting for the reply of the satellite
+	AgreementAccepted AgreementStatus = 2
+	AgreementRejected AgreementStatus = 3
+)
+
+// acceptedRetention is how long accepted agreements are kept
+const acceptedRetention = 7 * 24 * time.Hour
+
+// Open opens DB at DBPath, blobs is used for removing expired pieces
+func Open(ctx context.Context, blobs storage.Blobs, DBPath string) (db *DB, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	if err = os.MkdirAll(filepath.Dir(DBPath), 0700); err != nil {
@@ -56,9 +74,9 @@ func Open(ctx context.Context, dataPath, DBPath string) (db *DB, err error) {
 		return nil, err
 	}
 	db = &DB{
-		DB:       sqlite,
-		dataPath: dataPath,
-		check:    time.NewTicker(*defaultCheckInterval),
+		DB:    sqlite,
+		blobs: blobs,
+		check: time.NewTicker(*defaultCheckInterval),
 	}
 	if err := db.init(); err != nil {
 		return nil, utils.CombineErrors(err, db.DB.Close())
@@ -70,7 +88,7 @@ func Open(ctx context.Context, dataPath, DBPath string) (db *DB, err error) {
 }
 
 // OpenInMemory opens sqlite DB inmemory
-func OpenInMemory(ctx context.Context, dataPath string) (db *DB, err error) {
+func OpenInMemory(ctx context.Context, blobs storage.Blobs) (db *DB, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	sqlite, err := sql.Open("sqlite3", ":memory:")
@@ -79,9 +97,9 @@ func OpenInMemory(ctx context.Context, dataPath string) (db *DB, err error) {
 	}
 
 	db = &DB{
-		DB:       sqlite,
-		dataPath: dataPath,
-		check:    time.NewTicker(*defaultCheckInterval),
+		DB:    sqlite,
+		blobs: blobs,
+		check: time.NewTicker(*defaultCheckInterval),
 	}
 	if err := db.init(); err != nil {
 		return nil, utils.CombineErrors(err, db.DB.Close())
@@ -110,6 +128,24 @@ func (db *DB) init() (err error) {
 		return err
 	}
 
+	// track the outbox state of the agreements, existing agreements are unsent
+	for _, column := range []struct{ name, definition string }{
+		{"status", "INT NOT NULL DEFAULT 0"},
+		{"attempts", "INT NOT NULL DEFAULT 0"},
+		{"nextattempt", "INT NOT NULL DEFAULT 0"},
+		{"reason", "TEXT NOT NULL DEFAULT ''"},
+		{"updated", "INT NOT NULL DEFAULT 0"},
+	} {
+		if err := addColumn(tx, "bandwidth_agreements", column.name, column.definition); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec("CREATE INDEX IF NOT EXISTS idx_bandwidth_agreements_status ON bandwidth_agreements (status, nextattempt);")
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec("CREATE INDEX IF NOT EXISTS idx_ttl_expires ON ttl (expires);")
 	if err != nil {
 		return err
@@ -120,6 +156,86 @@ func (db *DB) init() (err error) {
 		return err
 	}
 
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `blobs` (`id` BLOB UNIQUE, `blobref` BLOB);")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `hashes` (`id` BLOB UNIQUE, `hash` BLOB);")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `pieceinfo` (`id` BLOB UNIQUE, `pieceid` TEXT, `satellite` TEXT);")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `exits` (`satellite` TEXT UNIQUE, `address` TEXT, `started` INT(10), `finished` INT(10));")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `spaceused` (`id` INT UNIQUE, `total` INT(10));")
+	if err != nil {
+		return err
+	}
+
+	// start the running total from the pieces stored by older versions
+	_, err = tx.Exec("INSERT OR IGNORE INTO spaceused (id, total) SELECT 0, COALESCE(SUM(size), 0) FROM ttl WHERE NOT EXISTS (SELECT 1 FROM spaceused);")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `satellitespace` (`satellite` TEXT UNIQUE, `total` INT(10));")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("INSERT OR IGNORE INTO satellitespace (satellite, total) SELECT COALESCE(pieceinfo.satellite, ''), SUM(ttl.size) FROM ttl LEFT JOIN pieceinfo ON ttl.id = pieceinfo.id WHERE NOT EXISTS (SELECT 1 FROM satellitespace) GROUP BY COALESCE(pieceinfo.satellite, '');")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `cleanups` (`satellite` TEXT UNIQUE, `queued` INT(10));")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `satellitebandwidth` (`satellite` TEXT, `size` INT(10), `daystartdate` INT(10), UNIQUE (`satellite`, `daystartdate`));")
+	if err != nil {
+		return err
+	}
+
+	// split the bandwidth by action, size stays the total including the usage of older versions
+	for _, table := range []string{"bwusagetbl", "satellitebandwidth"} {
+		for _, column := range []string{"putsize", "getsize"} {
+			if err := addColumn(tx, table, column, "INT NOT NULL DEFAULT 0"); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `trash` (`id` BLOB, `satellite` TEXT, `pieceid` TEXT, `blobref` BLOB, `hash` BLOB, `created` INT(10), `expires` INT(10), `size` INT(10), `trashed` INT(10));")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE INDEX IF NOT EXISTS idx_trash_satellite ON trash (satellite);")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `serialnumbers` (`satellite` TEXT, `serialnumber` TEXT, `expires` INT(10), UNIQUE (`satellite`, `serialnumber`));")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE INDEX IF NOT EXISTS idx_serialnumbers_expires ON serialnumbers (expires);")
+	if err != nil {
+		return err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return err
@@ -131,6 +247,18 @@ func (db *DB) init() (err error) {
 	return nil
 }
 
+// addColumn adds the column to the table when it was created by an older version
+func addColumn(tx *sql.Tx, table, column, definition string) error {
+	var exists int
+	err := tx.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column).Scan(&exists)
+	if err != nil || exists > 0 {
+		return err
+	}
+
+	_, err = tx.Exec("ALTER TABLE `" + table + "` ADD COLUMN `" + column + "` " + definition + ";")
+	return err
+}
+
 // Close the database
 func (db *DB) Close() error {
 	return db.DB.Close()
@@ -141,11 +269,219 @@ func (db *DB) locked() func() {
 	return db.mu.Unlock
 }
 
-// DeleteExpired checks for expired TTLs in the DB and removes data from both the DB and the FS
+// DeleteExpired checks for expired TTLs in the DB and moves the pieces to the trash
 func (db *DB) DeleteExpired(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var expired []string
+	// without blob storage the pieces cannot be removed, keep the entries
+	if db.blobs == nil {
+		return nil
+	}
+
+	defer db.locked()()
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().Unix()
+
+	_, err = tx.Exec(`INSERT INTO trash (id, satellite, pieceid, blobref, hash, created, expires, size, trashed)
+		SELECT ttl.id, COALESCE(pieceinfo.satellite, ''), pieceinfo.pieceid, blobs.blobref, hashes.hash, ttl.created, ttl.expires, COALESCE(ttl.size, 0), ?
+		FROM ttl INNER JOIN blobs ON ttl.id = blobs.id LEFT JOIN hashes ON ttl.id = hashes.id LEFT JOIN pieceinfo ON ttl.id = pieceinfo.id
+		WHERE 0 < ttl.expires AND ttl.expires < ?`, now, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM blobs WHERE id IN (SELECT id FROM ttl WHERE 0 < expires AND expires < ?)`, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM hashes WHERE id IN (SELECT id FROM ttl WHERE 0 < expires AND expires < ?)`, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE spaceused SET total = total - (SELECT COALESCE(SUM(size), 0) FROM ttl WHERE 0 < expires AND expires < ?)`, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE satellitespace SET total = total - (
+		SELECT COALESCE(SUM(ttl.size), 0) FROM ttl LEFT JOIN pieceinfo ON ttl.id = pieceinfo.id
+		WHERE COALESCE(pieceinfo.satellite, '') = satellitespace.satellite AND 0 < ttl.expires AND ttl.expires < ?)`, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM pieceinfo WHERE id IN (SELECT id FROM ttl WHERE 0 < expires AND expires < ?)`, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM ttl WHERE 0 < expires AND expires < ?`, now)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// TrashPiece moves the piece stored with id to the trash of its satellite,
+// the blob is kept until the trash is emptied
+func (db *DB) TrashPiece(id string) error {
+	return db.trashPiece(id, "")
+}
+
+// TrashUntrackedPiece moves the piece stored with id to the trash of satellite
+// when the satellite of the piece isn't known, otherwise to the trash of its satellite
+func (db *DB) TrashUntrackedPiece(id, satellite string) error {
+	return db.trashPiece(id, satellite)
+}
+
+func (db *DB) trashPiece(id, untrackedSatellite string) (err error) {
+	defer db.locked()()
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(`INSERT INTO trash (id, satellite, pieceid, blobref, hash, created, expires, size, trashed)
+		SELECT blobs.id, COALESCE(pieceinfo.satellite, ?), pieceinfo.pieceid, blobs.blobref, hashes.hash, ttl.created, ttl.expires, COALESCE(ttl.size, 0), ?
+		FROM blobs LEFT JOIN ttl ON blobs.id = ttl.id LEFT JOIN hashes ON blobs.id = hashes.id LEFT JOIN pieceinfo ON blobs.id = pieceinfo.id
+		WHERE blobs.id = ?`, untrackedSatellite, time.Now().Unix(), id)
+	if err != nil {
+		return err
+	}
+
+	var size int64
+	err = tx.QueryRow(`SELECT COALESCE(size, 0) FROM ttl WHERE id=?`, id).Scan(&size)
+	switch {
+	case err == sql.ErrNoRows:
+	case err != nil:
+		return err
+	default:
+		if err := addSpaceUsed(tx, id, -size); err != nil {
+			return err
+		}
+	}
+
+	for _, table := range []string{"blobs", "hashes", "pieceinfo", "ttl"} {
+		_, err = tx.Exec(`DELETE FROM `+table+` WHERE id=?`, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// RestoreTrash moves the trashed pieces of satellite back to the stored pieces.
+// Pieces that were stored again after being trashed stay in the trash.
+func (db *DB) RestoreTrash(satellite string) (restored int64, err error) {
+	defer db.locked()()
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	type trashed struct {
+		id      string
+		pieceID sql.NullString
+		blobref []byte
+		hash    []byte
+		created sql.NullInt64
+		expires sql.NullInt64
+		size    int64
+	}
+
+	var pieces []trashed
+	err = func() (err error) {
+		rows, err := tx.Query(`SELECT id, pieceid, blobref, hash, created, expires, size FROM trash
+			WHERE satellite = ? AND id NOT IN (SELECT id FROM blobs) ORDER BY trashed DESC`, satellite)
+		if err != nil {
+			return err
+		}
+		defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+		// only the latest deletion of a piece is restored
+		seen := map[string]bool{}
+		for rows.Next() {
+			var piece trashed
+			err := rows.Scan(&piece.id, &piece.pieceID, &piece.blobref, &piece.hash, &piece.created, &piece.expires, &piece.size)
+			if err != nil {
+				return err
+			}
+			if seen[piece.id] {
+				continue
+			}
+			seen[piece.id] = true
+			pieces = append(pieces, piece)
+		}
+		return rows.Err()
+	}()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, piece := range pieces {
+		_, err = tx.Exec(`INSERT INTO blobs (id, blobref) VALUES (?, ?)`, piece.id, piece.blobref)
+		if err != nil {
+			return 0, err
+		}
+
+		if piece.hash != nil {
+			_, err = tx.Exec(`INSERT OR REPLACE INTO hashes (id, hash) VALUES (?, ?)`, piece.id, piece.hash)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		if piece.pieceID.Valid {
+			_, err = tx.Exec(`INSERT OR REPLACE INTO pieceinfo (id, pieceid, satellite) VALUES (?, ?, ?)`, piece.id, piece.pieceID.String, satellite)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		if piece.created.Valid {
+			_, err = tx.Exec(`INSERT OR REPLACE INTO ttl (id, created, expires, size) VALUES (?, ?, ?, ?)`, piece.id, piece.created.Int64, piece.expires.Int64, piece.size)
+			if err != nil {
+				return 0, err
+			}
+			if err := addSpaceUsed(tx, piece.id, piece.size); err != nil {
+				return 0, err
+			}
+		}
+
+		_, err = tx.Exec(`DELETE FROM trash WHERE id = ? AND blobref = ?`, piece.id, piece.blobref)
+		if err != nil {
+			return 0, err
+		}
+		restored++
+	}
+
+	return restored, tx.Commit()
+}
+
+// EmptyTrash deletes the pieces trashed before trashedBefore from the DB and the FS
+func (db *DB) EmptyTrash(ctx context.Context, trashedBefore time.Time) (deleted int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// without blob storage the pieces cannot be removed, keep the entries
+	if db.blobs == nil {
+		return 0, nil
+	}
+
+	var refs []storage.BlobRef
 	err = func() error {
 		defer db.locked()()
 
@@ -155,45 +491,82 @@ func (db *DB) DeleteExpired(ctx context.Context) (err error) {
 		}
 		defer func() { _ = tx.Rollback() }()
 
-		now := time.Now().Unix()
-
-		rows, err := tx.Query("SELECT id FROM ttl WHERE 0 < expires AND ? < expires", now)
+		rows, err := tx.Query(`SELECT blobref FROM trash WHERE trashed < ?`, trashedBefore.Unix())
 		if err != nil {
 			return err
 		}
 
 		for rows.Next() {
-			var id string
-			if err := rows.Scan(&id); err != nil {
-				return err
+			var ref []byte
+			if err := rows.Scan(&ref); err != nil {
+				return utils.CombineErrors(err, rows.Close())
 			}
-			expired = append(expired, id)
+			var blobref storage.BlobRef
+			copy(blobref[:], ref)
+			refs = append(refs, blobref)
 		}
-		if err := rows.Close(); err != nil {
+		if err := utils.CombineErrors(rows.Err(), rows.Close()); err != nil {
 			return err
 		}
 
-		_, err = tx.Exec(`DELETE FROM ttl WHERE 0 < expires AND ? < expires`, now)
+		_, err = tx.Exec(`DELETE FROM trash WHERE trashed < ?`, trashedBefore.Unix())
 		if err != nil {
 			return err
 		}
 
 		return tx.Commit()
 	}()
+	if err != nil {
+		return 0, err
+	}
 
 	var errs []error
-	for _, id := range expired {
-		err := pstore.Delete(id, db.dataPath)
-		if err != nil {
+	for _, ref := range refs {
+		if err := db.blobs.Delete(ctx, ref); err != nil && !os.IsNotExist(err) {
 			errs = append(errs, err)
+			continue
 		}
+		deleted++
+	}
+
+	return deleted, utils.CombineErrors(errs...)
+}
+
+// TrashSpaceUsed returns the total size of the trashed pieces
+func (db *DB) TrashSpaceUsed() (total int64, err error) {
+	defer db.locked()()
+
+	err = db.DB.QueryRow(`SELECT COALESCE(SUM(size), 0) FROM trash`).Scan(&total)
+	return total, err
+}
+
+// UseSerialNumber records the serial number of a satellite until it expires,
+// zero expires keeps it forever, used is true when the serial number has already been recorded
+func (db *DB) UseSerialNumber(satellite, serialNumber string, expires int64) (used bool, err error) {
+	defer db.locked()()
+
+	result, err := db.DB.Exec(`INSERT OR IGNORE INTO serialnumbers (satellite, serialnumber, expires) VALUES (?, ?, ?)`, satellite, serialNumber, expires)
+	if err != nil {
+		return false, err
 	}
 
-	if len(errs) > 0 {
-		return utils.CombineErrors(errs...)
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, err
 	}
+	return inserted == 0, nil
+}
 
-	return nil
+// DeleteExpiredSerialNumbers removes the serial numbers that expired before now,
+// their allocations are no longer accepted so they can't be replayed
+func (db *DB) DeleteExpiredSerialNumbers(now time.Time) (deleted int64, err error) {
+	defer db.locked()()
+
+	result, err := db.DB.Exec(`DELETE FROM serialnumbers WHERE 0 < expires AND expires < ?`, now.Unix())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 // garbageCollect will periodically run DeleteExpired
@@ -203,11 +576,33 @@ func (db *DB) garbageCollect(ctx context.Context) {
 		if err != nil {
 			zap.S().Errorf("failed checking entries: %+v", err)
 		}
+
+		if _, err := db.DeleteExpiredSerialNumbers(time.Now()); err != nil {
+			zap.S().Errorf("failed deleting serial numbers: %+v", err)
+		}
+
+		if _, err := db.DeleteAcceptedAgreements(time.Now().Add(-acceptedRetention)); err != nil {
+			zap.S().Errorf("failed deleting accepted agreements: %+v", err)
+		}
+
+		// remove blobs that couldn't be deleted earlier
+		if collector, ok := db.blobs.(interface {
+			GarbageCollect(context.Context) error
+		}); ok {
+			if err := collector.GarbageCollect(ctx); err != nil {
+				zap.S().Errorf("failed collecting blobs: %+v", err)
+			}
+		}
 	}
 }
 
 // WriteBandwidthAllocToDB -- Insert bandwidth agreement into DB
 func (db *DB) WriteBandwidthAllocToDB(ba *pb.RenterBandwidthAllocation) error {
+	// an agreement without an allocation would only be rejected by the satellite
+	if ba == nil {
+		return errors.New("no bandwidth allocation")
+	}
+
 	defer db.locked()()
 
 	// We begin extracting the satellite_id
@@ -223,7 +618,7 @@ func (db *DB) WriteBandwidthAllocToDB(ba *pb.RenterBandwidthAllocation) error {
 		return err
 	}
 
-	_, err := db.DB.Exec(`INSERT INTO bandwidth_agreements (satellite, agreement, signature) VALUES (?, ?, ?)`, pbad.GetSatelliteId(), ba.GetData(), ba.GetSignature())
+	_, err := db.DB.Exec(`INSERT INTO bandwidth_agreements (satellite, agreement, signature, status, updated) VALUES (?, ?, ?, ?, ?)`, pbad.GetSatelliteId(), ba.GetData(), ba.GetSignature(), AgreementUnsent, time.Now().Unix())
 	return err
 }
 
@@ -269,7 +664,27 @@ func (db *DB) GetBandwidthAllocationBySignature(signature []byte) ([][]byte, err
 func (db *DB) GetBandwidthAllocations() (map[string][]*Agreement, error) {
 	defer db.locked()()
 
-	rows, err := db.DB.Query(`SELECT * FROM bandwidth_agreements ORDER BY satellite`)
+	return db.queryAgreements(`SELECT satellite, agreement, signature, status, attempts, reason FROM bandwidth_agreements ORDER BY satellite`)
+}
+
+// GetUnsentAgreements returns the agreements to send by satellite, which are the unsent agreements
+// and the agreements without a reply, once their next attempt is due
+func (db *DB) GetUnsentAgreements(now time.Time) (map[string][]*Agreement, error) {
+	defer db.locked()()
+
+	return db.queryAgreements(`SELECT satellite, agreement, signature, status, attempts, reason FROM bandwidth_agreements WHERE status IN (?, ?) AND nextattempt <= ? ORDER BY satellite, rowid`,
+		AgreementUnsent, AgreementSent, now.Unix())
+}
+
+// GetRejectedAgreements returns the agreements the satellites rejected by satellite
+func (db *DB) GetRejectedAgreements() (map[string][]*Agreement, error) {
+	defer db.locked()()
+
+	return db.queryAgreements(`SELECT satellite, agreement, signature, status, attempts, reason FROM bandwidth_agreements WHERE status = ? ORDER BY satellite, rowid`, AgreementRejected)
+}
+
+func (db *DB) queryAgreements(query string, args ...interface{}) (map[string][]*Agreement, error) {
+	rows, err := db.DB.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -283,7 +698,7 @@ func (db *DB) GetBandwidthAllocations() (map[string][]*Agreement, error) {
 	for rows.Next() {
 		agreement := &Agreement{}
 		var satellite sql.NullString
-		err := rows.Scan(&satellite, &agreement.Agreement, &agreement.Signature)
+		err := rows.Scan(&satellite, &agreement.Agreement, &agreement.Signature, &agreement.Status, &agreement.Attempts, &agreement.Reason)
 		if err != nil {
 			return agreements, err
 		}
@@ -291,21 +706,126 @@ func (db *DB) GetBandwidthAllocations() (map[string][]*Agreement, error) {
 		if !satellite.Valid {
 			return agreements, nil
 		}
+		agreement.Satellite = satellite.String
 		agreements[satellite.String] = append(agreements[satellite.String], agreement)
 	}
-	return agreements, nil
+	return agreements, rows.Err()
 }
 
-// AddTTL adds TTL into database by id
-func (db *DB) AddTTL(id string, expiration, size int64) error {
+// MarkAgreementsSent marks the agreements as sent to the satellite
+func (db *DB) MarkAgreementsSent(signatures [][]byte) (err error) {
 	defer db.locked()()
 
-	created := time.Now().Unix()
-	_, err := db.DB.Exec("INSERT OR REPLACE INTO ttl (id, created, expires, size) VALUES (?, ?, ?, ?)", id, created, expiration, size)
-	return err
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = utils.CombineErrors(err, tx.Rollback())
+		}
+	}()
+
+	now := time.Now().Unix()
+	for _, signature := range signatures {
+		_, err = tx.Exec(`UPDATE bandwidth_agreements SET status = ?, updated = ? WHERE signature = ?`, AgreementSent, now, signature)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
-// GetTTLByID finds the TTL in the database by id and return it
+// AcceptAgreement marks the agreement as accepted by the satellite
+func (db *DB) AcceptAgreement(signature []byte) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`UPDATE bandwidth_agreements SET status = ?, reason = '', updated = ? WHERE signature = ?`, AgreementAccepted, time.Now().Unix(), signature)
+	return err
+}
+
+// RejectAgreement archives the agreement with the reason the satellite rejected it for
+func (db *DB) RejectAgreement(signature []byte, reason string) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`UPDATE bandwidth_agreements SET status = ?, reason = ?, updated = ? WHERE signature = ?`, AgreementRejected, reason, time.Now().Unix(), signature)
+	return err
+}
+
+// RetryAgreement marks the agreement as unsent again to be sent after nextAttempt,
+// agreements the satellite already replied to are left alone
+func (db *DB) RetryAgreement(signature []byte, nextAttempt time.Time) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`UPDATE bandwidth_agreements SET status = ?, attempts = attempts + 1, nextattempt = ?, updated = ? WHERE signature = ? AND status IN (?, ?)`, AgreementUnsent, nextAttempt.Unix(), time.Now().Unix(), signature, AgreementUnsent, AgreementSent)
+	return err
+}
+
+// DeleteAcceptedAgreements deletes the agreements accepted before the given time
+func (db *DB) DeleteAcceptedAgreements(before time.Time) (deleted int64, err error) {
+	defer db.locked()()
+
+	result, err := db.DB.Exec(`DELETE FROM bandwidth_agreements WHERE status = ? AND updated < ?`, AgreementAccepted, before.Unix())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// AddTTL adds TTL into database by id and adds size to the used space
+func (db *DB) AddTTL(id string, expiration, size int64) (err error) {
+	defer db.locked()()
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// a replaced piece doesn't use its previous size anymore
+	var previous int64
+	err = tx.QueryRow(`SELECT COALESCE(size, 0) FROM ttl WHERE id=?`, id).Scan(&previous)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	created := time.Now().Unix()
+	_, err = tx.Exec("INSERT OR REPLACE INTO ttl (id, created, expires, size) VALUES (?, ?, ?, ?)", id, created, expiration, size)
+	if err != nil {
+		return err
+	}
+
+	if err := addSpaceUsed(tx, id, size-previous); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// addSpaceUsed adds delta to the used space of the node and of the satellite storing the piece
+func addSpaceUsed(tx *sql.Tx, id string, delta int64) error {
+	_, err := tx.Exec(`UPDATE spaceused SET total = total + ?`, delta)
+	if err != nil {
+		return err
+	}
+
+	// pieces without a known satellite are accounted to the empty satellite
+	var satellite string
+	err = tx.QueryRow(`SELECT satellite FROM pieceinfo WHERE id=?`, id).Scan(&satellite)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	_, err = tx.Exec(`INSERT OR IGNORE INTO satellitespace (satellite, total) VALUES (?, 0)`, satellite)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE satellitespace SET total = total + ? WHERE satellite = ?`, delta, satellite)
+	return err
+}
+
+// GetTTLByID finds the TTL in the database by id and return it
 func (db *DB) GetTTLByID(id string) (expiration int64, err error) {
 	defer db.locked()()
 
@@ -332,21 +852,445 @@ func (db *DB) SumTTLSizes() (sum int64, err error) {
 	return sum, err
 }
 
+// SpaceUsed returns the running total of the sizes of the stored pieces
+func (db *DB) SpaceUsed() (total int64, err error) {
+	defer db.locked()()
+
+	err = db.DB.QueryRow(`SELECT total FROM spaceused`).Scan(&total)
+	return total, err
+}
+
+// SpaceUsedBySatellite returns the running total of the sizes of the pieces stored for satellite
+func (db *DB) SpaceUsedBySatellite(satellite string) (total int64, err error) {
+	defer db.locked()()
+
+	err = db.DB.QueryRow(`SELECT total FROM satellitespace WHERE satellite = ?`, satellite).Scan(&total)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return total, err
+}
+
+// GetSpaceUsedBySatellites returns the used space of every satellite that stored pieces
+func (db *DB) GetSpaceUsedBySatellites() (used map[string]int64, err error) {
+	defer db.locked()()
+
+	rows, err := db.DB.Query(`SELECT satellite, total FROM satellitespace`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	used = make(map[string]int64)
+	for rows.Next() {
+		var satellite string
+		var total int64
+		if err := rows.Scan(&satellite, &total); err != nil {
+			return used, err
+		}
+		used[satellite] = total
+	}
+	return used, rows.Err()
+}
+
 // DeleteTTLByID finds the TTL in the database by id and delete it
-func (db *DB) DeleteTTLByID(id string) error {
+func (db *DB) DeleteTTLByID(id string) (err error) {
+	defer db.locked()()
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var size int64
+	err = tx.QueryRow(`SELECT COALESCE(size, 0) FROM ttl WHERE id=?`, id).Scan(&size)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := addSpaceUsed(tx, id, -size); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM ttl WHERE id=?`, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdatePieceSize changes the recorded size of the piece id and the used space
+func (db *DB) UpdatePieceSize(id string, size int64) (err error) {
+	defer db.locked()()
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var previous int64
+	err = tx.QueryRow(`SELECT COALESCE(size, 0) FROM ttl WHERE id=?`, id).Scan(&previous)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE ttl SET size = ? WHERE id=?`, size, id)
+	if err != nil {
+		return err
+	}
+
+	if err := addSpaceUsed(tx, id, size-previous); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// StoredPiece is what the database records about a piece
+type StoredPiece struct {
+	ID      string
+	Size    int64
+	HasTTL  bool
+	BlobRef *storage.BlobRef // nil when no blob is recorded
+}
+
+// GetStoredPieces returns every piece with a ttl or a blob reference
+func (db *DB) GetStoredPieces() (pieces []StoredPiece, err error) {
 	defer db.locked()()
 
-	_, err := db.DB.Exec(`DELETE FROM ttl WHERE id=?`, id)
+	rows, err := db.DB.Query(`SELECT ttl.id, ttl.size, blobs.blobref FROM ttl LEFT JOIN blobs ON ttl.id = blobs.id
+		UNION ALL SELECT blobs.id, NULL, blobs.blobref FROM blobs WHERE blobs.id NOT IN (SELECT id FROM ttl)`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	for rows.Next() {
+		var piece StoredPiece
+		var size sql.NullInt64
+		var ref []byte
+		if err := rows.Scan(&piece.ID, &size, &ref); err != nil {
+			return pieces, err
+		}
+		piece.Size, piece.HasTTL = size.Int64, size.Valid
+		if ref != nil {
+			piece.BlobRef = &storage.BlobRef{}
+			copy(piece.BlobRef[:], ref)
+		}
+		pieces = append(pieces, piece)
+	}
+	return pieces, rows.Err()
+}
+
+// GetTrashBlobRefs returns the blob references of the trashed pieces
+func (db *DB) GetTrashBlobRefs() (refs []storage.BlobRef, err error) {
+	defer db.locked()()
+
+	rows, err := db.DB.Query(`SELECT blobref FROM trash`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return refs, err
+		}
+		var ref storage.BlobRef
+		copy(ref[:], data)
+		refs = append(refs, ref)
+	}
+	return refs, rows.Err()
+}
+
+// AddBlobRef stores the blob reference for the piece id
+func (db *DB) AddBlobRef(id string, ref storage.BlobRef) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec("INSERT OR REPLACE INTO blobs (id, blobref) VALUES (?, ?)", id, ref[:])
+	return err
+}
+
+// GetBlobRef finds the blob reference for the piece id
+func (db *DB) GetBlobRef(id string) (ref storage.BlobRef, err error) {
+	defer db.locked()()
+
+	var data []byte
+	err = db.DB.QueryRow(`SELECT blobref FROM blobs WHERE id=?`, id).Scan(&data)
+	if err != nil {
+		return ref, err
+	}
+	copy(ref[:], data)
+	return ref, nil
+}
+
+// DeleteBlobRef deletes the blob reference for the piece id
+func (db *DB) DeleteBlobRef(id string) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`DELETE FROM blobs WHERE id=?`, id)
 	if err == sql.ErrNoRows {
 		err = nil
 	}
 	return err
 }
 
-// AddBandwidthUsed adds bandwidth usage into database by date
-func (db *DB) AddBandwidthUsed(size int64) (err error) {
+// AddPieceHash stores the content hash for the piece id
+func (db *DB) AddPieceHash(id string, hash []byte) error {
 	defer db.locked()()
 
+	_, err := db.DB.Exec("INSERT OR REPLACE INTO hashes (id, hash) VALUES (?, ?)", id, hash)
+	return err
+}
+
+// GetPieceHash finds the content hash for the piece id
+func (db *DB) GetPieceHash(id string) (hash []byte, err error) {
+	defer db.locked()()
+
+	err = db.DB.QueryRow(`SELECT hash FROM hashes WHERE id=?`, id).Scan(&hash)
+	return hash, err
+}
+
+// DeletePieceHash deletes the content hash for the piece id
+func (db *DB) DeletePieceHash(id string) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`DELETE FROM hashes WHERE id=?`, id)
+	if err == sql.ErrNoRows {
+		err = nil
+	}
+	return err
+}
+
+// PieceInfo describes which satellite a piece belongs to
+type PieceInfo struct {
+	ID        string // namespaced id used for storing the piece
+	PieceID   string // piece id as known by the satellite
+	Satellite string
+}
+
+// AddPieceInfo stores the piece id and satellite for the namespaced id
+func (db *DB) AddPieceInfo(info PieceInfo) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec("INSERT OR REPLACE INTO pieceinfo (id, pieceid, satellite) VALUES (?, ?, ?)", info.ID, info.PieceID, info.Satellite)
+	return err
+}
+
+// DeletePieceInfo deletes the piece info for the namespaced id
+func (db *DB) DeletePieceInfo(id string) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`DELETE FROM pieceinfo WHERE id=?`, id)
+	if err == sql.ErrNoRows {
+		err = nil
+	}
+	return err
+}
+
+// GetPieceInfosCreatedBefore finds the pieces of satellite that were created before the given time
+func (db *DB) GetPieceInfosCreatedBefore(satellite string, createdBefore time.Time) (infos []PieceInfo, err error) {
+	defer db.locked()()
+
+	rows, err := db.DB.Query(`SELECT pieceinfo.id, pieceinfo.pieceid FROM pieceinfo INNER JOIN ttl ON pieceinfo.id = ttl.id WHERE pieceinfo.satellite = ? AND ttl.created < ?`, satellite, createdBefore.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	for rows.Next() {
+		info := PieceInfo{Satellite: satellite}
+		if err := rows.Scan(&info.ID, &info.PieceID); err != nil {
+			return infos, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, rows.Err()
+}
+
+// GetUntrackedPiecesCreatedBefore finds the pieces without a recorded satellite that were created before the given time,
+// which were stored by versions that didn't record the satellite of a piece
+func (db *DB) GetUntrackedPiecesCreatedBefore(createdBefore time.Time) (ids []string, err error) {
+	defer db.locked()()
+
+	rows, err := db.DB.Query(`SELECT ttl.id FROM ttl INNER JOIN blobs ON ttl.id = blobs.id WHERE ttl.id NOT IN (SELECT id FROM pieceinfo) AND ttl.created < ?`, createdBefore.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// GetPieceInfosBySatellite finds all pieces of satellite
+func (db *DB) GetPieceInfosBySatellite(satellite string) (infos []PieceInfo, err error) {
+	defer db.locked()()
+
+	rows, err := db.DB.Query(`SELECT id, pieceid FROM pieceinfo WHERE satellite = ?`, satellite)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	for rows.Next() {
+		info := PieceInfo{Satellite: satellite}
+		if err := rows.Scan(&info.ID, &info.PieceID); err != nil {
+			return infos, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, rows.Err()
+}
+
+// GetPieceInfoByPieceID finds the piece of satellite by the piece id known by the satellite
+func (db *DB) GetPieceInfoByPieceID(satellite, pieceID string) (info PieceInfo, err error) {
+	defer db.locked()()
+
+	info = PieceInfo{PieceID: pieceID, Satellite: satellite}
+	err = db.DB.QueryRow(`SELECT id FROM pieceinfo WHERE satellite = ? AND pieceid = ?`, satellite, pieceID).Scan(&info.ID)
+	return info, err
+}
+
+// GetPieceSatellites returns the satellites that have stored pieces
+func (db *DB) GetPieceSatellites() (satellites []string, err error) {
+	defer db.locked()()
+
+	rows, err := db.DB.Query(`SELECT DISTINCT satellite FROM pieceinfo ORDER BY satellite`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	for rows.Next() {
+		var satellite string
+		if err := rows.Scan(&satellite); err != nil {
+			return satellites, err
+		}
+		satellites = append(satellites, satellite)
+	}
+	return satellites, rows.Err()
+}
+
+// Cleanup describes the pieces of a satellite queued for deletion
+type Cleanup struct {
+	Satellite string
+	Queued    int64
+}
+
+// QueueCleanup queues the pieces of the satellite for deletion, queuing again keeps the original time
+func (db *DB) QueueCleanup(satellite string) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec("INSERT OR IGNORE INTO cleanups (satellite, queued) VALUES (?, ?)", satellite, time.Now().Unix())
+	return err
+}
+
+// DequeueCleanup removes the satellite from the cleanup queue
+func (db *DB) DequeueCleanup(satellite string) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec("DELETE FROM cleanups WHERE satellite = ?", satellite)
+	return err
+}
+
+// GetCleanups returns the queued cleanups ordered by satellite
+func (db *DB) GetCleanups() (cleanups []Cleanup, err error) {
+	defer db.locked()()
+
+	rows, err := db.DB.Query(`SELECT satellite, queued FROM cleanups ORDER BY satellite`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	for rows.Next() {
+		var cleanup Cleanup
+		if err := rows.Scan(&cleanup.Satellite, &cleanup.Queued); err != nil {
+			return cleanups, err
+		}
+		cleanups = append(cleanups, cleanup)
+	}
+	return cleanups, rows.Err()
+}
+
+// Exit describes a graceful exit from a satellite
+type Exit struct {
+	Satellite string
+	Address   string
+	Started   int64
+	Finished  int64 // zero while the exit is in progress
+}
+
+// AddExit records that the node is exiting from the satellite
+func (db *DB) AddExit(satellite, address string) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec("INSERT OR IGNORE INTO exits (satellite, address, started, finished) VALUES (?, ?, ?, 0)", satellite, address, time.Now().Unix())
+	return err
+}
+
+// FinishExit records that the node has exited from the satellite
+func (db *DB) FinishExit(satellite string) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec("UPDATE exits SET finished = ? WHERE satellite = ?", time.Now().Unix(), satellite)
+	return err
+}
+
+// GetExits returns all exits ordered by satellite
+func (db *DB) GetExits() (exits []Exit, err error) {
+	defer db.locked()()
+
+	rows, err := db.DB.Query(`SELECT satellite, address, started, finished FROM exits ORDER BY satellite`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	for rows.Next() {
+		var exit Exit
+		if err := rows.Scan(&exit.Satellite, &exit.Address, &exit.Started, &exit.Finished); err != nil {
+			return exits, err
+		}
+		exits = append(exits, exit)
+	}
+	return exits, rows.Err()
+}
+
+// BandwidthUsage is the bandwidth used for uploads and downloads
+type BandwidthUsage struct {
+	Put int64
+	Get int64
+}
+
+// actionColumn returns the column with the bandwidth used for the action
+func actionColumn(action pb.PayerBandwidthAllocation_Action) string {
+	if action.IsGet() {
+		return "getsize"
+	}
+	return "putsize"
+}
+
+// AddBandwidthUsed adds bandwidth usage of the action into database by date
+func (db *DB) AddBandwidthUsed(action pb.PayerBandwidthAllocation_Action, size int64) (err error) {
+	defer db.locked()()
+
+	column := actionColumn(action)
+
 	t := time.Now()
 	daystartunixtime := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).Unix()
 	dayendunixtime := time.Date(t.Year(), t.Month(), t.Day(), 24, 0, 0, 0, t.Location()).Unix()
@@ -356,19 +1300,109 @@ func (db *DB) AddBandwidthUsed(size int64) (err error) {
 		err = db.DB.QueryRow(`SELECT size FROM bwusagetbl WHERE daystartdate <= ? AND ? <= dayenddate`, t.Unix(), t.Unix()).Scan(&getSize)
 		switch {
 		case err == sql.ErrNoRows:
-			_, err = db.DB.Exec("INSERT INTO bwusagetbl (size, daystartdate, dayenddate) VALUES (?, ?, ?)", size, daystartunixtime, dayendunixtime)
+			_, err = db.DB.Exec("INSERT INTO bwusagetbl (size, "+column+", daystartdate, dayenddate) VALUES (?, ?, ?, ?)", size, size, daystartunixtime, dayendunixtime)
 			return err
 		case err != nil:
 			return err
 		default:
 			getSize = size + getSize
-			_, err = db.DB.Exec("UPDATE bwusagetbl SET size = ? WHERE daystartdate = ?", getSize, daystartunixtime)
+			_, err = db.DB.Exec("UPDATE bwusagetbl SET size = ?, "+column+" = "+column+" + ? WHERE daystartdate = ?", getSize, size, daystartunixtime)
 			return err
 		}
 	}
 	return err
 }
 
+// AddSatelliteBandwidthUsed adds bandwidth usage of the satellite for the action into database by date
+func (db *DB) AddSatelliteBandwidthUsed(satellite string, action pb.PayerBandwidthAllocation_Action, size int64) (err error) {
+	defer db.locked()()
+
+	column := actionColumn(action)
+
+	t := time.Now()
+	daystartunixtime := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).Unix()
+
+	_, err = db.DB.Exec(`INSERT OR IGNORE INTO satellitebandwidth (satellite, size, daystartdate) VALUES (?, 0, ?)`, satellite, daystartunixtime)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.DB.Exec(`UPDATE satellitebandwidth SET size = size + ?, `+column+` = `+column+` + ? WHERE satellite = ? AND daystartdate = ?`, size, size, satellite, daystartunixtime)
+	return err
+}
+
+// GetSatelliteBandwidthBetween sums the bandwidth used by satellite between the days of startdate and enddate
+func (db *DB) GetSatelliteBandwidthBetween(satellite string, startdate time.Time, enddate time.Time) (total int64, err error) {
+	defer db.locked()()
+
+	startTimeUnix := time.Date(startdate.Year(), startdate.Month(), startdate.Day(), 0, 0, 0, 0, startdate.Location()).Unix()
+	endTimeUnix := time.Date(enddate.Year(), enddate.Month(), enddate.Day(), 0, 0, 0, 0, enddate.Location()).Unix()
+
+	err = db.DB.QueryRow(`SELECT COALESCE(SUM(size), 0) FROM satellitebandwidth WHERE satellite = ? AND daystartdate BETWEEN ? AND ?`, satellite, startTimeUnix, endTimeUnix).Scan(&total)
+	return total, err
+}
+
+// GetBandwidthBySatellitesBetween sums the bandwidth used by each satellite between the days of startdate and enddate
+func (db *DB) GetBandwidthBySatellitesBetween(startdate time.Time, enddate time.Time) (used map[string]int64, err error) {
+	defer db.locked()()
+
+	startTimeUnix := time.Date(startdate.Year(), startdate.Month(), startdate.Day(), 0, 0, 0, 0, startdate.Location()).Unix()
+	endTimeUnix := time.Date(enddate.Year(), enddate.Month(), enddate.Day(), 0, 0, 0, 0, enddate.Location()).Unix()
+
+	rows, err := db.DB.Query(`SELECT satellite, SUM(size) FROM satellitebandwidth WHERE daystartdate BETWEEN ? AND ? GROUP BY satellite`, startTimeUnix, endTimeUnix)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	used = make(map[string]int64)
+	for rows.Next() {
+		var satellite string
+		var size int64
+		if err := rows.Scan(&satellite, &size); err != nil {
+			return used, err
+		}
+		used[satellite] = size
+	}
+	return used, rows.Err()
+}
+
+// GetBandwidthByActionBetween sums the bandwidth used for each action between the days of startdate and enddate
+func (db *DB) GetBandwidthByActionBetween(startdate time.Time, enddate time.Time) (usage BandwidthUsage, err error) {
+	defer db.locked()()
+
+	startTimeUnix := time.Date(startdate.Year(), startdate.Month(), startdate.Day(), 0, 0, 0, 0, startdate.Location()).Unix()
+	endTimeUnix := time.Date(enddate.Year(), enddate.Month(), enddate.Day(), 0, 0, 0, 0, enddate.Location()).Unix()
+
+	err = db.DB.QueryRow(`SELECT COALESCE(SUM(putsize), 0), COALESCE(SUM(getsize), 0) FROM bwusagetbl WHERE daystartdate BETWEEN ? AND ?`, startTimeUnix, endTimeUnix).Scan(&usage.Put, &usage.Get)
+	return usage, err
+}
+
+// GetSatelliteBandwidthByActionBetween sums the bandwidth used by each satellite for each action between the days of startdate and enddate
+func (db *DB) GetSatelliteBandwidthByActionBetween(startdate time.Time, enddate time.Time) (used map[string]BandwidthUsage, err error) {
+	defer db.locked()()
+
+	startTimeUnix := time.Date(startdate.Year(), startdate.Month(), startdate.Day(), 0, 0, 0, 0, startdate.Location()).Unix()
+	endTimeUnix := time.Date(enddate.Year(), enddate.Month(), enddate.Day(), 0, 0, 0, 0, enddate.Location()).Unix()
+
+	rows, err := db.DB.Query(`SELECT satellite, SUM(putsize), SUM(getsize) FROM satellitebandwidth WHERE daystartdate BETWEEN ? AND ? GROUP BY satellite`, startTimeUnix, endTimeUnix)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	used = make(map[string]BandwidthUsage)
+	for rows.Next() {
+		var satellite string
+		var usage BandwidthUsage
+		if err := rows.Scan(&satellite, &usage.Put, &usage.Get); err != nil {
+			return used, err
+		}
+		used[satellite] = usage
+	}
+	return used, rows.Err()
+}
+
 // GetBandwidthUsedByDay finds the so far bw used by day and return it
 func (db *DB) GetBandwidthUsedByDay(t time.Time) (size int64, err error) {
 	defer db.locked()()
@@ -6,6 +6,8 @@ package psdb
 import (
 	"bytes"
 	"context"
+	"database/sql"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -17,6 +19,8 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
 )
 
 var ctx = context.Background()
@@ -30,7 +34,7 @@ func newDB(t testing.TB) (*DB, func()) {
 	}
 	dbpath := filepath.Join(tmpdir, "psdb.db")
 
-	db, err := Open(ctx, "", dbpath)
+	db, err := Open(ctx, nil, dbpath)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -49,7 +53,7 @@ func newDB(t testing.TB) (*DB, func()) {
 }
 
 func TestNewInmemory(t *testing.T) {
-	db, err := OpenInMemory(context.Background(), "")
+	db, err := OpenInMemory(context.Background(), nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -228,6 +232,652 @@ func TestHappyPath(t *testing.T) {
 	})
 }
 
+func TestDeleteExpired(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "storj-psdb-blobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(tmpdir) }()
+
+	blobs, err := filestore.NewAt(tmpdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := OpenInMemory(ctx, blobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+
+	store := func(id string, expiration int64) storage.BlobRef {
+		ref, err := blobs.Store(ctx, bytes.NewReader([]byte(id)), -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.AddBlobRef(id, ref); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.AddTTL(id, expiration, int64(len(id))); err != nil {
+			t.Fatal(err)
+		}
+		return ref
+	}
+
+	expiredRef := store("expired", time.Now().Add(-time.Hour).Unix())
+	aliveRef := store("alive", time.Now().Add(time.Hour).Unix())
+	foreverRef := store("forever", 0)
+
+	if err := db.DeleteExpired(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.GetBlobRef("expired"); err != sql.ErrNoRows {
+		t.Fatalf("expected expired blob reference to be deleted, got %v", err)
+	}
+
+	// the expired blob is kept in the trash until it is emptied
+	blob, err := blobs.Load(ctx, expiredRef)
+	if err != nil {
+		t.Fatalf("expected expired blob to be trashed, got %v", err)
+	}
+	_ = blob.Close()
+
+	deleted, err := db.EmptyTrash(ctx, time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 1 {
+		t.Fatalf("expected 1 purged piece, got %d", deleted)
+	}
+	if _, err := blobs.Load(ctx, expiredRef); !os.IsNotExist(err) {
+		t.Fatalf("expected expired blob to be deleted, got %v", err)
+	}
+
+	for id, ref := range map[string]storage.BlobRef{"alive": aliveRef, "forever": foreverRef} {
+		got, err := db.GetBlobRef(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != ref {
+			t.Fatalf("expected %x got %x", ref, got)
+		}
+
+		blob, err := blobs.Load(ctx, ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = blob.Close()
+	}
+}
+
+func TestTrash(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "storj-psdb-blobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(tmpdir) }()
+
+	blobs, err := filestore.NewAt(tmpdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := OpenInMemory(ctx, blobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+
+	store := func(info PieceInfo) storage.BlobRef {
+		ref, err := blobs.Store(ctx, bytes.NewReader([]byte(info.ID)), -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.AddBlobRef(info.ID, ref); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.AddPieceHash(info.ID, []byte("hash-"+info.ID)); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.AddPieceInfo(info); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.AddTTL(info.ID, 0, 10); err != nil {
+			t.Fatal(err)
+		}
+		return ref
+	}
+
+	expectSpace := func(used, trashed int64) {
+		t.Helper()
+		gotUsed, err := db.SpaceUsed()
+		if err != nil {
+			t.Fatal(err)
+		}
+		gotTrashed, err := db.TrashSpaceUsed()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotUsed != used || gotTrashed != trashed {
+			t.Fatalf("expected used %d and trashed %d got %d and %d", used, trashed, gotUsed, gotTrashed)
+		}
+	}
+
+	refA := store(PieceInfo{ID: "id-a", PieceID: "piece-a", Satellite: "satellite-a"})
+	refB := store(PieceInfo{ID: "id-b", PieceID: "piece-b", Satellite: "satellite-b"})
+	expectSpace(20, 0)
+
+	for _, id := range []string{"id-a", "id-b"} {
+		if err := db.TrashPiece(id); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.GetBlobRef(id); err != sql.ErrNoRows {
+			t.Fatalf("expected blob reference of %s to be trashed, got %v", id, err)
+		}
+	}
+	expectSpace(0, 20)
+
+	restored, err := db.RestoreTrash("satellite-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored != 1 {
+		t.Fatalf("expected 1 restored piece got %d", restored)
+	}
+	expectSpace(10, 10)
+
+	ref, err := db.GetBlobRef("id-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref != refA {
+		t.Fatalf("expected %x got %x", refA, ref)
+	}
+	hash, err := db.GetPieceHash("id-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(hash) != "hash-id-a" {
+		t.Fatalf("expected restored hash got %q", hash)
+	}
+	info, err := db.GetPieceInfoByPieceID("satellite-a", "piece-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ID != "id-a" {
+		t.Fatalf("expected id-a got %v", info.ID)
+	}
+	used, err := db.SpaceUsedBySatellite("satellite-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used != 10 {
+		t.Fatalf("expected 10 bytes used by satellite-a got %d", used)
+	}
+
+	// nothing is purged before the retention
+	deleted, err := db.EmptyTrash(ctx, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 0 {
+		t.Fatalf("expected no purged pieces got %d", deleted)
+	}
+
+	deleted, err = db.EmptyTrash(ctx, time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 1 {
+		t.Fatalf("expected 1 purged piece got %d", deleted)
+	}
+	expectSpace(10, 0)
+
+	if _, err := blobs.Load(ctx, refB); !os.IsNotExist(err) {
+		t.Fatalf("expected trashed blob to be deleted, got %v", err)
+	}
+	blob, err := blobs.Load(ctx, refA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = blob.Close()
+
+	restored, err = db.RestoreTrash("satellite-b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored != 0 {
+		t.Fatalf("expected no restored pieces got %d", restored)
+	}
+}
+
+func TestSpaceUsed(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "storj-psdb-blobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(tmpdir) }()
+
+	blobs, err := filestore.NewAt(tmpdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := OpenInMemory(ctx, blobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+
+	expectUsed := func(expected int64) {
+		t.Helper()
+		used, err := db.SpaceUsed()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if used != expected {
+			t.Fatalf("expected %d bytes used got %d", expected, used)
+		}
+	}
+
+	expectUsed(0)
+
+	for _, ttl := range []struct {
+		id         string
+		expiration int64
+		size       int64
+	}{
+		{"forever", 0, 100},
+		{"expired", time.Now().Add(-time.Hour).Unix(), 20},
+		{"deleted", 0, 3},
+		{"deleted", 0, 5}, // replaces the previous size
+	} {
+		if err := db.AddTTL(ttl.id, ttl.expiration, ttl.size); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectUsed(125)
+
+	if err := db.DeleteTTLByID("deleted"); err != nil {
+		t.Fatal(err)
+	}
+	expectUsed(120)
+
+	if err := db.DeleteTTLByID("missing"); err != nil {
+		t.Fatal(err)
+	}
+	expectUsed(120)
+
+	if err := db.DeleteExpired(ctx); err != nil {
+		t.Fatal(err)
+	}
+	expectUsed(100)
+}
+
+func TestSatelliteUsage(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+
+	for _, info := range []PieceInfo{
+		{ID: "id-1", PieceID: "piece-1", Satellite: "satellite-a"},
+		{ID: "id-2", PieceID: "piece-2", Satellite: "satellite-a"},
+		{ID: "id-3", PieceID: "piece-3", Satellite: "satellite-b"},
+	} {
+		if err := db.AddPieceInfo(info); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for id, size := range map[string]int64{"id-1": 10, "id-2": 20, "id-3": 30, "id-4": 40} {
+		if err := db.AddTTL(id, 0, size); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := db.DeleteTTLByID("id-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	used, err := db.SpaceUsedBySatellite("satellite-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used != 20 {
+		t.Fatalf("expected 20 bytes used got %d", used)
+	}
+
+	used, err = db.SpaceUsedBySatellite("satellite-c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used != 0 {
+		t.Fatalf("expected no space used got %d", used)
+	}
+
+	usedBySatellite, err := db.GetSpaceUsedBySatellites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]int64{"satellite-a": 20, "satellite-b": 30, "": 40}
+	if len(usedBySatellite) != len(expected) {
+		t.Fatalf("expected %v got %v", expected, usedBySatellite)
+	}
+	for satellite, size := range expected {
+		if usedBySatellite[satellite] != size {
+			t.Fatalf("expected %v got %v", expected, usedBySatellite)
+		}
+	}
+
+	for _, bw := range []struct {
+		satellite string
+		action    pb.PayerBandwidthAllocation_Action
+		size      int64
+	}{
+		{"satellite-a", pb.PayerBandwidthAllocation_PUT, 100},
+		{"satellite-a", pb.PayerBandwidthAllocation_GET, 200},
+		{"satellite-b", pb.PayerBandwidthAllocation_GET, 50},
+	} {
+		if err := db.AddSatelliteBandwidthUsed(bw.satellite, bw.action, bw.size); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	total, err := db.GetSatelliteBandwidthBetween("satellite-a", time.Now(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 300 {
+		t.Fatalf("expected 300 bytes of bandwidth got %d", total)
+	}
+
+	bandwidth, err := db.GetBandwidthBySatellitesBetween(time.Now().AddDate(0, 0, -1), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bandwidth) != 2 || bandwidth["satellite-a"] != 300 || bandwidth["satellite-b"] != 50 {
+		t.Fatalf("unexpected bandwidth usage %v", bandwidth)
+	}
+
+	byAction, err := db.GetSatelliteBandwidthByActionBetween(time.Now(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byAction) != 2 || byAction["satellite-a"] != (BandwidthUsage{Put: 100, Get: 200}) || byAction["satellite-b"] != (BandwidthUsage{Get: 50}) {
+		t.Fatalf("unexpected bandwidth usage by action %v", byAction)
+	}
+
+	bandwidth, err = db.GetBandwidthBySatellitesBetween(time.Now().AddDate(0, 0, -2), time.Now().AddDate(0, 0, -1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bandwidth) != 0 {
+		t.Fatalf("expected no bandwidth usage got %v", bandwidth)
+	}
+}
+
+func TestPieceInfo(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+
+	for _, info := range []PieceInfo{
+		{ID: "id-1", PieceID: "piece-1", Satellite: "satellite-a"},
+		{ID: "id-2", PieceID: "piece-2", Satellite: "satellite-a"},
+		{ID: "id-3", PieceID: "piece-3", Satellite: "satellite-b"},
+	} {
+		if err := db.AddTTL(info.ID, 0, 1); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.AddPieceInfo(info); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	infos, err := db.GetPieceInfosCreatedBefore("satellite-a", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 pieces got %v", infos)
+	}
+
+	infos, err = db.GetPieceInfosCreatedBefore("satellite-a", time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 0 {
+		t.Fatalf("expected no pieces got %v", infos)
+	}
+
+	if err := db.DeletePieceInfo("id-3"); err != nil {
+		t.Fatal(err)
+	}
+
+	infos, err = db.GetPieceInfosCreatedBefore("satellite-b", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 0 {
+		t.Fatalf("expected no pieces got %v", infos)
+	}
+
+	info, err := db.GetPieceInfoByPieceID("satellite-a", "piece-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ID != "id-2" {
+		t.Fatalf("expected id-2 got %v", info.ID)
+	}
+
+	if _, err := db.GetPieceInfoByPieceID("satellite-b", "piece-2"); err != sql.ErrNoRows {
+		t.Fatalf("expected no rows got %v", err)
+	}
+}
+
+func TestExits(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+
+	for _, satellite := range []string{"satellite-b", "satellite-a", "satellite-b"} {
+		if err := db.AddExit(satellite, satellite+":7777"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	exits, err := db.GetExits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exits) != 2 {
+		t.Fatalf("expected 2 exits got %v", exits)
+	}
+	for i, satellite := range []string{"satellite-a", "satellite-b"} {
+		if exits[i].Satellite != satellite || exits[i].Address != satellite+":7777" {
+			t.Fatalf("unexpected exit %v", exits[i])
+		}
+		if exits[i].Started == 0 || exits[i].Finished != 0 {
+			t.Fatalf("unexpected exit %v", exits[i])
+		}
+	}
+
+	if err := db.FinishExit("satellite-a"); err != nil {
+		t.Fatal(err)
+	}
+
+	exits, err = db.GetExits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exits[0].Finished == 0 || exits[1].Finished != 0 {
+		t.Fatalf("expected only satellite-a to be finished got %v", exits)
+	}
+}
+
+func TestSerialNumbers(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+
+	now := time.Now()
+	use := func(satellite, serialNumber string, expires time.Time) bool {
+		used, err := db.UseSerialNumber(satellite, serialNumber, expires.Unix())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return used
+	}
+
+	if use("satellite-a", "serial-1", now.Add(-time.Hour)) {
+		t.Fatal("expected serial-1 of satellite-a to be unused")
+	}
+	if use("satellite-a", "serial-2", now.Add(time.Hour)) {
+		t.Fatal("expected serial-2 of satellite-a to be unused")
+	}
+	if use("satellite-b", "serial-1", now.Add(time.Hour)) {
+		t.Fatal("expected serial-1 of satellite-b to be unused")
+	}
+	if used, err := db.UseSerialNumber("satellite-b", "serial-2", 0); err != nil || used {
+		t.Fatalf("expected serial-2 of satellite-b to be unused got %v %v", used, err)
+	}
+	if !use("satellite-a", "serial-1", now.Add(time.Hour)) {
+		t.Fatal("expected serial-1 of satellite-a to be used")
+	}
+
+	deleted, err := db.DeleteExpiredSerialNumbers(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 1 {
+		t.Fatalf("expected 1 expired serial number got %d", deleted)
+	}
+
+	if use("satellite-a", "serial-1", now.Add(time.Hour)) {
+		t.Fatal("expected expired serial-1 of satellite-a to be unused")
+	}
+	if !use("satellite-a", "serial-2", now.Add(time.Hour)) {
+		t.Fatal("expected serial-2 of satellite-a to be used")
+	}
+	if !use("satellite-b", "serial-2", now.Add(time.Hour)) {
+		t.Fatal("expected serial-2 of satellite-b without expiration to be kept")
+	}
+}
+
+func TestAgreementOutbox(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "storj-psdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(tmpdir) }()
+	dbpath := filepath.Join(tmpdir, "psdb.db")
+
+	// agreements stored by older versions are unsent
+	old, err := sql.Open("sqlite3", dbpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = old.Exec("CREATE TABLE `bandwidth_agreements` (`satellite` TEXT, `agreement` BLOB, `signature` BLOB);")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = old.Exec("INSERT INTO bandwidth_agreements (satellite, agreement, signature) VALUES ('satellite-a', x'00', 'old')")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := old.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := Open(ctx, nil, dbpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+
+	for _, agreement := range []struct{ satellite, signature string }{
+		{"satellite-a", "accepted"},
+		{"satellite-a", "rejected"},
+		{"satellite-b", "retried"},
+	} {
+		err := db.WriteBandwidthAllocToDB(&pb.RenterBandwidthAllocation{
+			Signature: []byte(agreement.signature),
+			Data: serialize(t, &pb.RenterBandwidthAllocation_Data{
+				PayerAllocation: &pb.PayerBandwidthAllocation{
+					Data: serialize(t, &pb.PayerBandwidthAllocation_Data{SatelliteId: []byte(agreement.satellite)}),
+				},
+			}),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	signatures := func(groups map[string][]*Agreement) (all []string) {
+		for _, satellite := range []string{"satellite-a", "satellite-b"} {
+			for _, agreement := range groups[satellite] {
+				all = append(all, satellite+"/"+string(agreement.Signature))
+			}
+		}
+		return all
+	}
+	expect := func(now time.Time, expected ...string) {
+		unsent, err := db.GetUnsentAgreements(now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := signatures(unsent); fmt.Sprint(got) != fmt.Sprint(expected) {
+			t.Fatalf("expected unsent agreements %v got %v", expected, got)
+		}
+	}
+
+	// uploads without an allocation don't queue an empty agreement
+	if err := db.WriteBandwidthAllocToDB(nil); err == nil {
+		t.Fatal("expected an error writing no allocation")
+	}
+
+	now := time.Now()
+	expect(now, "satellite-a/old", "satellite-a/accepted", "satellite-a/rejected", "satellite-b/retried")
+
+	err = db.MarkAgreementsSent([][]byte{[]byte("old"), []byte("accepted"), []byte("rejected"), []byte("retried")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AcceptAgreement([]byte("accepted")); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.RejectAgreement([]byte("rejected"), "serial number already used"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.RetryAgreement([]byte("retried"), now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	// agreements without a reply are sent again
+	expect(now, "satellite-a/old")
+	expect(now.Add(2*time.Hour), "satellite-a/old", "satellite-b/retried")
+
+	unsent, err := db.GetUnsentAgreements(now.Add(2 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retried := unsent["satellite-b"][0]; retried.Attempts != 1 || retried.Status != AgreementUnsent {
+		t.Fatalf("expected a retried unsent agreement got %+v", retried)
+	}
+
+	rejected, err := db.GetRejectedAgreements()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rejected["satellite-a"]) != 1 || rejected["satellite-a"][0].Reason != "serial number already used" {
+		t.Fatalf("expected the rejected agreement with its reason got %v", signatures(rejected))
+	}
+
+	deleted, err := db.DeleteAcceptedAgreements(now.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 1 {
+		t.Fatalf("expected 1 accepted agreement to be deleted got %d", deleted)
+	}
+}
+
 func TestBandwidthUsage(t *testing.T) {
 	db, cleanup := newDB(t)
 	defer cleanup()
@@ -248,7 +898,7 @@ func TestBandwidthUsage(t *testing.T) {
 			t.Run("#"+strconv.Itoa(P), func(t *testing.T) {
 				t.Parallel()
 				for _, bw := range bwtests {
-					err := db.AddBandwidthUsed(bw.size)
+					err := db.AddBandwidthUsed(pb.PayerBandwidthAllocation_PUT, bw.size)
 					if err != nil {
 						t.Fatal(err)
 					}
@@ -274,6 +924,18 @@ func TestBandwidthUsage(t *testing.T) {
 		}
 	})
 
+	t.Run("GetBandwidthByActionBetween", func(t *testing.T) {
+		for _, bw := range bwtests {
+			usage, err := db.GetBandwidthByActionBetween(bw.timenow, bw.timenow)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if usage != (BandwidthUsage{Put: bwTotal}) {
+				t.Fatalf("expected %d bytes of uploads got %v", bwTotal, usage)
+			}
+		}
+	})
+
 	t.Run("GetBandwidthUsedByDay", func(t *testing.T) {
 		for P := 0; P < concurrency; P++ {
 			t.Run("#"+strconv.Itoa(P), func(t *testing.T) {
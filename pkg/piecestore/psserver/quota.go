@@ -0,0 +1,197 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// QuotaError is a type of error for satellites exceeding their share
+var QuotaError = errs.Class("quota error")
+
+// Quota is the share of disk space and bandwidth of a satellite,
+// zero means the satellite is only limited by the allocation of the node
+type Quota struct {
+	DiskSpace int64
+	Bandwidth int64 // per month
+}
+
+// Quotas contains the shares of the satellites
+type Quotas struct {
+	Default    Quota
+	Satellites map[string]Quota
+}
+
+// ParseQuotas parses a comma-separated list of <satellite-id>:<disk-space>:<bandwidth>
+func ParseQuotas(list string, defaultQuota Quota) (*Quotas, error) {
+	quotas := &Quotas{Default: defaultQuota, Satellites: map[string]Quota{}}
+	if strings.TrimSpace(list) == "" {
+		return quotas, nil
+	}
+
+	for _, entry := range strings.Split(list, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 3 || parts[0] == "" {
+			return nil, QuotaError.New("invalid quota %q", entry)
+		}
+
+		diskSpace, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return nil, QuotaError.New("invalid disk space in quota %q: %v", entry, err)
+		}
+		bandwidth, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil {
+			return nil, QuotaError.New("invalid bandwidth in quota %q: %v", entry, err)
+		}
+
+		quotas.Satellites[parts[0]] = Quota{DiskSpace: diskSpace, Bandwidth: bandwidth}
+	}
+	return quotas, nil
+}
+
+// Get returns the quota of the satellite
+func (quotas *Quotas) Get(satellite string) Quota {
+	if quotas == nil {
+		return Quota{}
+	}
+	if quota, ok := quotas.Satellites[satellite]; ok {
+		return quota
+	}
+	return quotas.Default
+}
+
+// reservationSize is how much space an upload reserves at once
+const reservationSize = 4 << 20
+
+// spaceReservations is the disk space reserved by the uploads in progress
+type spaceReservations struct {
+	mu         sync.Mutex
+	total      int64
+	satellites map[string]int64
+}
+
+// satelliteAvailableSpace returns how many bytes satellite can still store
+func (s *Server) satelliteAvailableSpace(satellite string) (int64, error) {
+	s.reservations.mu.Lock()
+	defer s.reservations.mu.Unlock()
+	return s.unreservedSpace(satellite)
+}
+
+// unreservedSpace returns how many bytes satellite can still store besides the uploads in progress,
+// the caller has to hold the reservations lock
+func (s *Server) unreservedSpace(satellite string) (int64, error) {
+	available, err := s.availableSpace()
+	if err != nil {
+		return 0, err
+	}
+	available -= s.reservations.total
+
+	quota := s.quotas.Get(satellite)
+	if quota.DiskSpace <= 0 {
+		return available, nil
+	}
+
+	used, err := s.DB.SpaceUsedBySatellite(satellite)
+	if err != nil {
+		return 0, err
+	}
+	if remaining := quota.DiskSpace - used - s.reservations.satellites[satellite]; remaining < available {
+		return remaining, nil
+	}
+	return available, nil
+}
+
+// reserveSpace reserves at most size bytes for an upload of satellite and returns how many bytes were reserved
+func (s *Server) reserveSpace(satellite string, size int64) (int64, error) {
+	s.reservations.mu.Lock()
+	defer s.reservations.mu.Unlock()
+
+	available, err := s.unreservedSpace(satellite)
+	if err != nil {
+		return 0, err
+	}
+	if size > available {
+		size = available
+	}
+	if size <= 0 {
+		return 0, nil
+	}
+
+	if s.reservations.satellites == nil {
+		s.reservations.satellites = map[string]int64{}
+	}
+	s.reservations.total += size
+	s.reservations.satellites[satellite] += size
+	return size, nil
+}
+
+// releaseSpace releases the space reserved for an upload of satellite
+func (s *Server) releaseSpace(satellite string, size int64) {
+	s.reservations.mu.Lock()
+	defer s.reservations.mu.Unlock()
+
+	s.reservations.total -= size
+	s.reservations.satellites[satellite] -= size
+	if s.reservations.satellites[satellite] <= 0 {
+		delete(s.reservations.satellites, satellite)
+	}
+}
+
+// spaceReservation is the space reserved by a single upload
+type spaceReservation struct {
+	server    *Server
+	satellite string
+	reserved  int64
+	used      int64
+}
+
+// use accounts size more bytes of the upload, reserving more space when needed
+func (r *spaceReservation) use(size int64) error {
+	r.used += size
+	if r.used <= r.reserved {
+		return nil
+	}
+
+	needed := r.used - r.reserved
+	if needed < reservationSize {
+		needed = reservationSize
+	}
+	reserved, err := r.server.reserveSpace(r.satellite, needed)
+	if err != nil {
+		return err
+	}
+	r.reserved += reserved
+	if r.used > r.reserved {
+		return ErrNotEnoughSpace.New("piece exceeds the available space")
+	}
+	return nil
+}
+
+// release releases the reserved space, once the piece is stored its size is accounted in the database
+func (r *spaceReservation) release() {
+	if r.reserved > 0 {
+		r.server.releaseSpace(r.satellite, r.reserved)
+		r.reserved = 0
+	}
+}
+
+// satelliteAvailableBandwidth returns how many bytes satellite can still transfer this month
+func (s *Server) satelliteAvailableBandwidth(satellite string) (int64, error) {
+	quota := s.quotas.Get(satellite)
+	if quota.Bandwidth <= 0 {
+		return math.MaxInt64, nil
+	}
+
+	used, err := s.DB.GetSatelliteBandwidthBetween(satellite, getBeginningOfMonth(), time.Now())
+	if err != nil {
+		return 0, err
+	}
+	return quota.Bandwidth - used, nil
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuotas(t *testing.T) {
+	defaultQuota := Quota{DiskSpace: 10, Bandwidth: 20}
+
+	for _, tt := range []struct {
+		list       string
+		satellites map[string]Quota
+		err        string
+	}{
+		{ // no quotas
+			list:       "",
+			satellites: map[string]Quota{},
+		},
+		{ // several satellites
+			list: "satellite-a:100:200, satellite-b:0:300",
+			satellites: map[string]Quota{
+				"satellite-a": {DiskSpace: 100, Bandwidth: 200},
+				"satellite-b": {DiskSpace: 0, Bandwidth: 300},
+			},
+		},
+		{ // missing bandwidth
+			list: "satellite-a:100",
+			err:  `quota error: invalid quota "satellite-a:100"`,
+		},
+		{ // missing satellite
+			list: ":100:200",
+			err:  `quota error: invalid quota ":100:200"`,
+		},
+		{ // invalid disk space
+			list: "satellite-a:lots:200",
+			err:  `quota error: invalid disk space in quota "satellite-a:lots:200": strconv.ParseInt: parsing "lots": invalid syntax`,
+		},
+	} {
+		quotas, err := ParseQuotas(tt.list, defaultQuota)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err)
+			continue
+		}
+		if !assert.NoError(t, err) {
+			continue
+		}
+
+		assert.Equal(t, tt.satellites, quotas.Satellites)
+		for satellite, quota := range tt.satellites {
+			assert.Equal(t, quota, quotas.Get(satellite))
+		}
+		assert.Equal(t, defaultQuota, quotas.Get("unlisted"))
+	}
+
+	var none *Quotas
+	assert.Equal(t, Quota{}, none.Get("satellite-a"))
+}
@@ -14,6 +14,7 @@ import (
 type StreamWriter struct {
 	server *Server
 	stream pb.PieceStoreRoutes_RetrieveServer
+	hash   *pb.PieceHash // sent with the first message
 }
 
 // NewStreamWriter returns a new StreamWriter
@@ -24,9 +25,10 @@ func NewStreamWriter(s *Server, stream pb.PieceStoreRoutes_RetrieveServer) *Stre
 // Write -- Write method for piece upload to stream for Server.Retrieve
 func (s *StreamWriter) Write(b []byte) (int, error) {
 	// Write the buffer to the stream we opened earlier
-	if err := s.stream.Send(&pb.PieceRetrievalStream{Size: int64(len(b)), Content: b}); err != nil {
+	if err := s.stream.Send(&pb.PieceRetrievalStream{Size: int64(len(b)), Content: b, Hash: s.hash}); err != nil {
 		return 0, err
 	}
+	s.hash = nil
 
 	return len(b), nil
 }
@@ -36,10 +38,12 @@ type StreamReader struct {
 	src                 *utils.ReaderSource
 	bandwidthAllocation *pb.RenterBandwidthAllocation
 	currentTotal        int64
+	received            int64
+	serialNumber        string
 }
 
-// NewStreamReader returns a new StreamReader for Server.Store
-func NewStreamReader(s *Server, stream pb.PieceStoreRoutes_StoreServer) *StreamReader {
+// NewStreamReader returns a new StreamReader for Server.Store of the piece id authorized by the satellite
+func NewStreamReader(s *Server, stream pb.PieceStoreRoutes_StoreServer, pieceID, satellite string) *StreamReader {
 	sr := &StreamReader{}
 	sr.src = utils.NewReaderSource(func() ([]byte, error) {
 
@@ -52,24 +56,51 @@ func NewStreamReader(s *Server, stream pb.PieceStoreRoutes_StoreServer) *StreamR
 		ba := recv.GetBandwidthallocation()
 
 		if ba != nil {
-			if err = s.verifySignature(stream.Context(), ba); err != nil {
-				return nil, err
-			}
-
 			deserializedData := &pb.RenterBandwidthAllocation_Data{}
 			err = proto.Unmarshal(ba.GetData(), deserializedData)
 			if err != nil {
 				return nil, err
 			}
 
+			var payer *pb.PayerBandwidthAllocation_Data
+			if payer, err = s.verifyPayer(deserializedData, satellite); err != nil {
+				return nil, err
+			}
+
+			if err = s.verifySignature(stream.Context(), payer, ba); err != nil {
+				return nil, err
+			}
+
+			if err = verifyAction(payer, pb.PayerBandwidthAllocation_PUT); err != nil {
+				return nil, err
+			}
+
+			if err = s.verifyOrderLimit(payer, deserializedData, pieceID); err != nil {
+				return nil, err
+			}
+
+			if sr.serialNumber, err = s.useSerialNumber(payer, sr.serialNumber); err != nil {
+				return nil, err
+			}
+
 			// Update bandwidthallocation to be stored
-			if deserializedData.GetTotal() > sr.currentTotal {
+			if sr.bandwidthAllocation == nil || deserializedData.GetTotal() > sr.currentTotal {
 				sr.bandwidthAllocation = ba
 				sr.currentTotal = deserializedData.GetTotal()
 			}
 		}
 
-		return pd.GetContent(), nil
+		// content is only accepted within the bandwidth the uplink allocated with a verified allocation
+		content := pd.GetContent()
+		if len(content) > 0 && sr.bandwidthAllocation == nil {
+			return nil, ErrOrderLimit.New("piece data sent without an allocation")
+		}
+		sr.received += int64(len(content))
+		if sr.received > sr.currentTotal {
+			return nil, ErrOrderLimit.New("received %d bytes, allocated %d bytes", sr.received, sr.currentTotal)
+		}
+
+		return content, nil
 	})
 
 	return sr
@@ -0,0 +1,86 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// Reconciler compares the used space accounting against the disk usage
+type Reconciler struct {
+	server *Server
+	ticker *time.Ticker
+}
+
+// NewReconciler creates a Reconciler checking the used space every interval
+func NewReconciler(server *Server, interval time.Duration) *Reconciler {
+	return &Reconciler{
+		server: server,
+		ticker: time.NewTicker(interval),
+	}
+}
+
+// Run the reconciliation loop
+func (reconciler *Reconciler) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		if _, err := reconciler.Reconcile(ctx); err != nil {
+			zap.S().Errorf("Space reconciliation failed: %v", err)
+		}
+
+		select {
+		case <-reconciler.ticker.C: // wait for the next interval to happen
+		case <-ctx.Done(): // or the reconciler is canceled via context
+			return ctx.Err()
+		}
+	}
+}
+
+// Reconcile walks the piece directory and returns how many bytes
+// the disk usage differs from the used space accounting
+func (reconciler *Reconciler) Reconcile(ctx context.Context) (drift int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	accounted, err := reconciler.server.DB.SpaceUsed()
+	if err != nil {
+		return 0, err
+	}
+
+	// trashed pieces stay on disk until they are purged
+	trashed, err := reconciler.server.DB.TrashSpaceUsed()
+	if err != nil {
+		return 0, err
+	}
+	accounted += trashed
+
+	onDisk, err := reconciler.diskUsage(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	drift = onDisk - accounted
+	mon.IntVal("space_used_drift").Observe(drift)
+
+	if drift != 0 {
+		zap.S().Warnf("Used space differs from the disk usage by %d bytes, accounted = %d Bytes, on disk = %d Bytes",
+			drift, accounted, onDisk)
+	}
+
+	return drift, nil
+}
+
+// diskUsage walks the disk to find how many bytes the stored pieces use
+func (reconciler *Reconciler) diskUsage(ctx context.Context) (int64, error) {
+	// blob stores know which files are blob contents
+	if blobs, ok := reconciler.server.Blobs.(interface {
+		SpaceUsed(context.Context) (int64, error)
+	}); ok {
+		return blobs.SpaceUsed(ctx)
+	}
+	return DirSize(reconciler.server.DataDir)
+}
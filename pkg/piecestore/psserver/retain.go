@@ -0,0 +1,78 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+
+	"storj.io/storj/pkg/bloomfilter"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/provider"
+)
+
+// RetainError is a type of error for failures in Server.Retain()
+var RetainError = errs.Class("retain error")
+
+// Retain moves the pieces of the calling satellite that are not in the filter to the trash.
+// Only pieces created before the requested time and older than the grace period are deleted.
+// Pieces without a recorded satellite are deleted as well, since they can't be checked against the filter.
+func (s *Server) Retain(ctx context.Context, in *pb.RetainRequest) (_ *pb.RetainSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pi, err := provider.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, RetainError.Wrap(err)
+	}
+	satellite := string(pi.ID)
+
+	filter, err := bloomfilter.NewFromBytes(in.GetFilter())
+	if err != nil {
+		return nil, RetainError.Wrap(err)
+	}
+
+	createdBefore := time.Unix(in.GetCreatedBeforeUnixSec(), 0)
+	if graceCutoff := time.Now().Add(-s.retainGrace); graceCutoff.Before(createdBefore) {
+		createdBefore = graceCutoff
+	}
+
+	zap.S().Infof("Retaining pieces of %s created before %s...", satellite, createdBefore)
+
+	infos, err := s.DB.GetPieceInfosCreatedBefore(satellite, createdBefore)
+	if err != nil {
+		return nil, RetainError.Wrap(err)
+	}
+
+	var deleted int64
+	for _, info := range infos {
+		if filter.Contains([]byte(info.PieceID)) {
+			continue
+		}
+
+		if err := s.DB.TrashPiece(info.ID); err != nil {
+			return &pb.RetainSummary{Deleted: deleted}, RetainError.Wrap(err)
+		}
+		deleted++
+	}
+
+	// pieces stored before the satellite of a piece was recorded can't be checked against the filter,
+	// they are collected as well and the satellite can restore them from its trash
+	untracked, err := s.DB.GetUntrackedPiecesCreatedBefore(createdBefore)
+	if err != nil {
+		return &pb.RetainSummary{Deleted: deleted}, RetainError.Wrap(err)
+	}
+	for _, id := range untracked {
+		if err := s.DB.TrashUntrackedPiece(id, satellite); err != nil {
+			return &pb.RetainSummary{Deleted: deleted}, RetainError.Wrap(err)
+		}
+		deleted++
+	}
+
+	zap.S().Infof("Garbage collected %d pieces of %s.", deleted, satellite)
+
+	return &pb.RetainSummary{Deleted: deleted}, nil
+}
@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"os"
 	"sync/atomic"
 
 	"github.com/gogo/protobuf/proto"
@@ -19,6 +18,7 @@ import (
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/piecestore"
 	"storj.io/storj/pkg/utils"
+	"storj.io/storj/storage"
 )
 
 // RetrieveError is a type of error for failures in Server.Retrieve()
@@ -43,6 +43,11 @@ func (s *Server) Retrieve(stream pb.PieceStoreRoutes_RetrieveServer) (err error)
 		return ServerError.Wrap(err)
 	}
 
+	satellite := string(getNamespace(authorization))
+	if err := s.verifyTrusted(satellite); err != nil {
+		return err
+	}
+
 	pd := recv.GetPieceData()
 	if pd == nil {
 		return RetrieveError.New("PieceStore message is nil")
@@ -55,28 +60,41 @@ func (s *Server) Retrieve(stream pb.PieceStoreRoutes_RetrieveServer) (err error)
 		return err
 	}
 
-	// Get path to data being retrieved
-	path, err := pstore.PathByID(id, s.DataDir)
-	if err != nil {
+	if err := validatePieceID(id); err != nil {
 		return err
 	}
 
-	// Verify that the path exists
-	fileInfo, err := os.Stat(path)
+	// Open the blob being retrieved
+	blob, err := s.loadPiece(ctx, id)
 	if err != nil {
 		return RetrieveError.Wrap(err)
 	}
+	defer utils.LogClose(blob)
 
 	// Read the size specified
 	totalToRead := pd.GetSize()
-	fileSize := fileInfo.Size()
+	fileSize := blob.Size()
 
 	// Read the entire file if specified -1 but make sure we do it from the correct offset
 	if pd.GetSize() <= -1 || totalToRead+pd.GetOffset() > fileSize {
 		totalToRead = fileSize - pd.GetOffset()
 	}
 
-	retrieved, allocated, err := s.retrieveData(ctx, stream, id, pd.GetOffset(), totalToRead)
+	// reject the download when the satellite has used up its bandwidth share
+	bandwidth, err := s.satelliteAvailableBandwidth(satellite)
+	if err != nil {
+		return RetrieveError.Wrap(err)
+	}
+	if bandwidth < totalToRead {
+		return QuotaError.New("bandwidth share of satellite exceeded, %d bytes available", bandwidth)
+	}
+
+	hash, err := s.storedPieceHash(pd.GetId(), id, fileSize)
+	if err != nil {
+		return RetrieveError.Wrap(err)
+	}
+
+	retrieved, allocated, err := s.retrieveData(ctx, stream, satellite, pd.GetId(), blob, hash, pd.GetOffset(), totalToRead)
 	if err != nil {
 		return err
 	}
@@ -85,17 +103,18 @@ func (s *Server) Retrieve(stream pb.PieceStoreRoutes_RetrieveServer) (err error)
 	return nil
 }
 
-func (s *Server) retrieveData(ctx context.Context, stream pb.PieceStoreRoutes_RetrieveServer, id string, offset, length int64) (retrieved, allocated int64, err error) {
+func (s *Server) retrieveData(ctx context.Context, stream pb.PieceStoreRoutes_RetrieveServer, satellite, pieceID string, blob storage.ReadSeekCloser, hash *pb.PieceHash, offset, length int64) (retrieved, allocated int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	storeFile, err := pstore.RetrieveReader(ctx, id, offset, length, s.DataDir)
-	if err != nil {
-		return 0, 0, err
+	// If offset is greater than blob size return
+	if offset >= blob.Size() || offset < 0 {
+		return 0, 0, pstore.ArgError.New("invalid offset: %v", offset)
 	}
 
-	defer utils.LogClose(storeFile)
+	storeFile := io.NewSectionReader(blob, offset, length)
 
 	writer := NewStreamWriter(s, stream)
+	writer.hash = hash
 	allocationTracking := sync2.NewThrottle()
 	totalAllocated := int64(0)
 
@@ -103,6 +122,7 @@ func (s *Server) retrieveData(ctx context.Context, stream pb.PieceStoreRoutes_Re
 	go func() {
 		var lastTotal int64
 		var lastAllocation *pb.RenterBandwidthAllocation
+		var serialNumber string
 		defer func() {
 			if lastAllocation == nil {
 				return
@@ -128,12 +148,32 @@ func (s *Server) retrieveData(ctx context.Context, stream pb.PieceStoreRoutes_Re
 				return
 			}
 
-			if err = s.verifySignature(ctx, alloc); err != nil {
+			var payer *pb.PayerBandwidthAllocation_Data
+			if payer, err = s.verifyPayer(allocData, satellite); err != nil {
+				allocationTracking.Fail(err)
+				return
+			}
+
+			if err = s.verifySignature(ctx, payer, alloc); err != nil {
+				allocationTracking.Fail(err)
+				return
+			}
+
+			if err = verifyAction(payer, pb.PayerBandwidthAllocation_GET); err != nil {
+				allocationTracking.Fail(err)
+				return
+			}
+
+			// nothing is sent beyond the order limit
+			if err = s.verifyOrderLimit(payer, allocData, pieceID); err != nil {
 				allocationTracking.Fail(err)
 				return
 			}
 
-			// TODO: break when lastTotal >= allocData.GetPayer_allocation().GetData().GetMax_size()
+			if serialNumber, err = s.useSerialNumber(payer, serialNumber); err != nil {
+				allocationTracking.Fail(err)
+				return
+			}
 
 			if lastTotal > allocData.GetTotal() {
 				allocationTracking.Fail(fmt.Errorf("got lower allocation was %v got %v", lastTotal, allocData.GetTotal()))
@@ -179,7 +219,11 @@ func (s *Server) retrieveData(ctx context.Context, stream pb.PieceStoreRoutes_Re
 	}
 
 	// write to bandwidth usage table
-	if err = s.DB.AddBandwidthUsed(used); err != nil {
+	if err = s.DB.AddBandwidthUsed(pb.PayerBandwidthAllocation_GET, used); err != nil {
+		return retrieved, allocated, StoreError.New("failed to write bandwidth info to database: %v", err)
+	}
+
+	if err = s.DB.AddSatelliteBandwidthUsed(satellite, pb.PayerBandwidthAllocation_GET, used); err != nil {
 		return retrieved, allocated, StoreError.New("failed to write bandwidth info to database: %v", err)
 	}
 
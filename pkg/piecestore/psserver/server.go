@@ -4,17 +4,22 @@
 package psserver
 
 import (
+	"bytes"
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/hmac"
 	"crypto/sha512"
+	"crypto/x509"
+	"database/sql"
 	"errors"
 	"log"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/gtank/cryptopasta"
 	"github.com/mr-tron/base58/base58"
 	"github.com/shirou/gopsutil/disk"
@@ -24,12 +29,18 @@ import (
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/dht"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/peertls"
 	"storj.io/storj/pkg/piecestore"
 	as "storj.io/storj/pkg/piecestore/psserver/agreementsender"
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/pkg/piecestore/psserver/trust"
 	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/utils"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
 )
 
 var (
@@ -37,6 +48,18 @@ var (
 
 	// ServerError wraps errors returned from Server struct methods
 	ServerError = errs.Class("PSServer error")
+
+	// ErrSerialNumberUsed is returned when an allocation reuses the serial number of an earlier transfer
+	ErrSerialNumberUsed = errs.Class("serial number already used")
+
+	// ErrAllocationExpired is returned when an allocation is used after its expiration
+	ErrAllocationExpired = errs.Class("allocation expired")
+
+	// ErrWrongAction is returned when an allocation is used for another action than it was issued for
+	ErrWrongAction = errs.Class("wrong allocation action")
+
+	// ErrOrderLimit is returned when an allocation is used by another uplink, for another node or piece or beyond its limit
+	ErrOrderLimit = errs.Class("order limit")
 )
 
 // Config contains everything necessary for a server
@@ -44,6 +67,23 @@ type Config struct {
 	Path               string `help:"path to store data in" default:"$CONFDIR"`
 	AllocatedDiskSpace int64  `help:"total allocated disk space, default(1GB)" default:"1073741824"`
 	AllocatedBandwidth int64  `help:"total allocated bandwidth, default(100GB)" default:"107374182400"`
+
+	SatelliteQuotas           string `help:"a comma-separated list of <satellite-id>:<disk-space>:<bandwidth> shares of the allocation, zero for no limit" default:""`
+	DefaultSatelliteDiskSpace int64  `help:"disk space share of satellites not listed in the quotas, zero for no limit" default:"0"`
+	DefaultSatelliteBandwidth int64  `help:"monthly bandwidth share of satellites not listed in the quotas, zero for no limit" default:"0"`
+
+	TrustedSatellites     string        `help:"a comma-separated list of trusted satellites as <satellite-id> or <satellite-id>@<address>, empty trusts all satellites" default:""`
+	UntrustedCleanupDelay time.Duration `help:"how long the pieces of satellites removed from the trusted satellites are kept" default:"168h"`
+	CleanupInterval       time.Duration `help:"how frequently the pieces of untrusted satellites are checked for cleanup" default:"1h"`
+
+	TrashRetention time.Duration `help:"how long deleted pieces are kept in the trash before being purged" default:"168h"`
+	TrashInterval  time.Duration `help:"how frequently the trash is checked for pieces to purge" default:"1h"`
+
+	RetainGracePeriod time.Duration `help:"pieces younger than this are never garbage collected" default:"24h"`
+	ExitInterval      time.Duration `help:"how frequently pieces of exiting satellites are transferred" default:"1m"`
+	ReconcileInterval time.Duration `help:"how frequently the used space is compared against the disk" default:"24h"`
+	CheckInterval     time.Duration `help:"how frequently the piece database is compared against the blobs on disk" default:"24h"`
+	CheckAction       string        `help:"what to do with pieces that differ between the database and the disk: report, repair or quarantine" default:"report"`
 }
 
 // Run implements provider.Responsibility
@@ -52,7 +92,7 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 
 	ctx, cancel := context.WithCancel(ctx)
 
-	s, err := Initialize(ctx, c, server.Identity().Key)
+	s, err := Initialize(ctx, c, server.Identity())
 	if err != nil {
 		return err
 	}
@@ -60,7 +100,7 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 	pb.RegisterPieceStoreRoutesServer(server.GRPC(), s)
 
 	// Run the agreement sender process
-	asProcess, err := as.Initialize(s.DB, server.Identity())
+	asProcess, err := as.Initialize(s.DB, server.Identity(), s.trust)
 	if err != nil {
 		return err
 	}
@@ -70,6 +110,46 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 		}
 	}()
 
+	// Transfer pieces of satellites the node is exiting from
+	exitWorker := NewExitWorker(s, transport.NewClient(server.Identity()), c.ExitInterval)
+	go func() {
+		if err := exitWorker.Run(ctx); err != nil {
+			zap.S().Errorf("Graceful exit stopped: %v", err)
+		}
+	}()
+
+	// Delete the pieces of satellites that are no longer trusted
+	cleaner := NewCleaner(s, c.CleanupInterval, c.UntrustedCleanupDelay)
+	go func() {
+		if err := cleaner.Run(ctx); err != nil {
+			zap.S().Errorf("Cleaning up untrusted satellites stopped: %v", err)
+		}
+	}()
+
+	// Purge deleted pieces once they can no longer be restored
+	trashCollector := NewTrashCollector(s, c.TrashInterval, c.TrashRetention)
+	go func() {
+		if err := trashCollector.Run(ctx); err != nil {
+			zap.S().Errorf("Emptying the trash stopped: %v", err)
+		}
+	}()
+
+	// Report drift between the used space accounting and the disk
+	reconciler := NewReconciler(s, c.ReconcileInterval)
+	go func() {
+		if err := reconciler.Run(ctx); err != nil {
+			zap.S().Errorf("Space reconciliation stopped: %v", err)
+		}
+	}()
+
+	// Compare the piece database against the blobs on disk
+	checker := NewChecker(s, c.CheckInterval, c.CheckAction)
+	go func() {
+		if err := checker.Run(ctx); err != nil {
+			zap.S().Errorf("Consistency check stopped: %v", err)
+		}
+	}()
+
 	defer func() {
 		log.Fatal(s.Stop(ctx))
 	}()
@@ -77,6 +157,14 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 	return server.Run(ctx)
 }
 
+// quotas parses the satellite shares of the config
+func (c Config) quotas() (*Quotas, error) {
+	return ParseQuotas(c.SatelliteQuotas, Quota{
+		DiskSpace: c.DefaultSatelliteDiskSpace,
+		Bandwidth: c.DefaultSatelliteBandwidth,
+	})
+}
+
 //DirSize returns the total size of the files in that directory
 func DirSize(path string) (int64, error) {
 	var size int64
@@ -85,10 +173,13 @@ func DirSize(path string) (int64, error) {
 		return 0, errors.New("path doesn't exists")
 	}
 	adjSize := func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
 		if !info.IsDir() {
 			size += info.Size()
 		}
-		return err
+		return nil
 	}
 	err = filepath.Walk(path, adjSize)
 
@@ -98,22 +189,39 @@ func DirSize(path string) (int64, error) {
 // Server -- GRPC server meta data used in route calls
 type Server struct {
 	DataDir          string
+	Blobs            storage.Blobs
 	DB               *psdb.DB
 	pkey             crypto.PrivateKey
+	id               dht.NodeID
 	totalAllocated   int64
 	totalBwAllocated int64
+	quotas           *Quotas
+	reservations     spaceReservations
+	trust            *trust.List
+	retainGrace      time.Duration
 	verifier         auth.SignedMessageVerifier
 }
 
 // Initialize -- initializes a server struct
-func Initialize(ctx context.Context, config Config, pkey crypto.PrivateKey) (*Server, error) {
+func Initialize(ctx context.Context, config Config, identity *provider.FullIdentity) (*Server, error) {
 	dbPath := filepath.Join(config.Path, "piecestore.db")
-	dataDir := filepath.Join(config.Path, "piece-store-data")
+	dataDir := filepath.Join(config.Path, "blobs")
+	legacyDataDir := filepath.Join(config.Path, "piece-store-data")
 
 	// read the allocated disk space from the config file
 	allocatedDiskSpace := config.AllocatedDiskSpace
 	allocatedBandwidth := config.AllocatedBandwidth
 
+	quotas, err := config.quotas()
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+
+	trusted, err := trust.Parse(config.TrustedSatellites)
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+
 	// get the disk space details
 	// The returned path ends in a slash only if it represents a root directory, such as "/" on Unix or `C:\` on Windows.
 	rootPath := filepath.Dir(filepath.Clean(config.Path))
@@ -123,16 +231,25 @@ func Initialize(ctx context.Context, config Config, pkey crypto.PrivateKey) (*Se
 	}
 	freeDiskSpace := int64(diskSpace.Free)
 
-	db, err := psdb.Open(ctx, dataDir, dbPath)
+	blobs, err := filestore.NewAt(dataDir)
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+
+	db, err := psdb.Open(ctx, blobs, dbPath)
 	if err != nil {
 		return nil, ServerError.Wrap(err)
 	}
 
+	// move pieces stored by older versions into the blob store
+	if err := migrateLegacyPieces(ctx, legacyDataDir, blobs, db); err != nil {
+		return nil, ServerError.Wrap(utils.CombineErrors(err, db.Close()))
+	}
+
 	// get how much is currently used, if for the first time totalUsed = 0
-	totalUsed, err := db.SumTTLSizes()
+	totalUsed, err := db.SpaceUsed()
 	if err != nil {
-		//first time setup
-		totalUsed = 0x00
+		return nil, ServerError.Wrap(utils.CombineErrors(err, db.Close()))
 	}
 
 	// get used bandwidth from the beginning of the month to till date
@@ -170,24 +287,51 @@ func Initialize(ctx context.Context, config Config, pkey crypto.PrivateKey) (*Se
 
 	return &Server{
 		DataDir:          dataDir,
+		Blobs:            blobs,
 		DB:               db,
-		pkey:             pkey,
+		pkey:             identity.Key,
+		id:               identity.ID,
 		totalAllocated:   allocatedDiskSpace,
 		totalBwAllocated: allocatedBandwidth,
+		quotas:           quotas,
+		trust:            trusted,
+		retainGrace:      config.RetainGracePeriod,
 		verifier:         auth.NewSignedMessageVerifier(),
 	}, nil
 }
 
-// New creates a Server with custom db
-func New(dataDir string, db *psdb.DB, config Config, pkey crypto.PrivateKey) *Server {
+// New creates a Server with custom db and blob storage, the identity
+// can be nil when the server isn't used to store or retrieve pieces
+func New(dataDir string, blobs storage.Blobs, db *psdb.DB, config Config, identity *provider.FullIdentity) (*Server, error) {
+	quotas, err := config.quotas()
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+
+	trusted, err := trust.Parse(config.TrustedSatellites)
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+
+	var pkey crypto.PrivateKey
+	var id dht.NodeID
+	if identity != nil {
+		pkey, id = identity.Key, identity.ID
+	}
+
 	return &Server{
 		DataDir:          dataDir,
+		Blobs:            blobs,
 		DB:               db,
 		pkey:             pkey,
+		id:               id,
 		totalAllocated:   config.AllocatedDiskSpace,
 		totalBwAllocated: config.AllocatedBandwidth,
+		quotas:           quotas,
+		trust:            trusted,
+		retainGrace:      config.RetainGracePeriod,
 		verifier:         auth.NewSignedMessageVerifier(),
-	}
+	}, nil
 }
 
 // Stop the piececstore node
@@ -204,26 +348,31 @@ func (s *Server) Piece(ctx context.Context, in *pb.PieceId) (*pb.PieceSummary, e
 		return nil, ServerError.Wrap(err)
 	}
 
-	id, err := getNamespacedPieceID([]byte(in.GetId()), getNamespace(authorization))
+	// namespaced ids are longer, so the id is checked as sent
+	match, err := regexp.MatchString("^[A-Za-z0-9]{20,64}$", in.GetId())
 	if err != nil {
 		return nil, err
 	}
 
-	path, err := pstore.PathByID(id, s.DataDir)
+	if !match {
+		return nil, ServerError.New("invalid ID")
+	}
+
+	id, err := getNamespacedPieceID([]byte(in.GetId()), getNamespace(authorization))
 	if err != nil {
 		return nil, err
 	}
 
-	match, err := regexp.MatchString("^[A-Za-z0-9]{20,64}$", id)
-	if err != nil {
+	if err := validatePieceID(id); err != nil {
 		return nil, err
 	}
 
-	if !match {
-		return nil, ServerError.New("invalid ID")
+	size, err := s.pieceSize(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
-	fileInfo, err := os.Stat(path)
+	hash, err := s.storedPieceHash(in.GetId(), id, size)
 	if err != nil {
 		return nil, err
 	}
@@ -235,14 +384,35 @@ func (s *Server) Piece(ctx context.Context, in *pb.PieceId) (*pb.PieceSummary, e
 	}
 
 	zap.S().Infof("Successfully retrieved meta for %s.", in.GetId())
-	return &pb.PieceSummary{Id: in.GetId(), Size: fileInfo.Size(), ExpirationUnixSec: ttl}, nil
+	return &pb.PieceSummary{Id: in.GetId(), Size: size, ExpirationUnixSec: ttl, Hash: hash}, nil
+}
+
+// pieceSize returns the size of the stored piece
+func (s *Server) pieceSize(ctx context.Context, id string) (size int64, err error) {
+	blob, err := s.loadPiece(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	defer utils.LogClose(blob)
+
+	return blob.Size(), nil
+}
+
+// loadPiece opens the blob that contains the piece with the specified id
+func (s *Server) loadPiece(ctx context.Context, id string) (storage.ReadSeekCloser, error) {
+	ref, err := s.DB.GetBlobRef(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Blobs.Load(ctx, ref)
 }
 
 // Stats will return statistics about the Server
 func (s *Server) Stats(ctx context.Context, in *pb.StatsReq) (*pb.StatSummary, error) {
 	zap.S().Infof("Getting Stats...\n")
 
-	totalUsed, err := s.DB.SumTTLSizes()
+	totalUsed, err := s.DB.SpaceUsed()
 	if err != nil {
 		return nil, err
 	}
@@ -252,7 +422,101 @@ func (s *Server) Stats(ctx context.Context, in *pb.StatsReq) (*pb.StatSummary, e
 		return nil, err
 	}
 
-	return &pb.StatSummary{UsedSpace: totalUsed, AvailableSpace: (s.totalAllocated - totalUsed), UsedBandwidth: totalUsedBandwidth, AvailableBandwidth: (s.totalBwAllocated - totalUsedBandwidth)}, nil
+	usedByAction, err := s.DB.GetBandwidthByActionBetween(getBeginningOfMonth(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	trashUsed, err := s.DB.TrashSpaceUsed()
+	if err != nil {
+		return nil, err
+	}
+
+	satellites, err := s.satelliteStats(totalUsed+trashUsed, totalUsedBandwidth)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.StatSummary{UsedSpace: totalUsed, AvailableSpace: (s.totalAllocated - totalUsed - trashUsed), UsedBandwidth: totalUsedBandwidth, AvailableBandwidth: (s.totalBwAllocated - totalUsedBandwidth), Satellites: satellites, TrashSpace: trashUsed, UsedPutBandwidth: usedByAction.Put, UsedGetBandwidth: usedByAction.Get}, nil
+}
+
+// satelliteStats returns the usage of the satellites with a quota or stored pieces
+func (s *Server) satelliteStats(totalUsed, totalUsedBandwidth int64) ([]*pb.SatelliteStats, error) {
+	usedSpace, err := s.DB.GetSpaceUsedBySatellites()
+	if err != nil {
+		return nil, err
+	}
+
+	usedBandwidth, err := s.DB.GetBandwidthBySatellitesBetween(getBeginningOfMonth(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	usedByAction, err := s.DB.GetSatelliteBandwidthByActionBetween(getBeginningOfMonth(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	satellites := map[string]bool{}
+	for satellite := range usedSpace {
+		satellites[satellite] = true
+	}
+	for satellite := range usedBandwidth {
+		satellites[satellite] = true
+	}
+	if s.quotas != nil {
+		for satellite := range s.quotas.Satellites {
+			satellites[satellite] = true
+		}
+	}
+	// pieces stored without a satellite aren't reported
+	delete(satellites, "")
+
+	ids := make([]string, 0, len(satellites))
+	for satellite := range satellites {
+		ids = append(ids, satellite)
+	}
+	sort.Strings(ids)
+
+	stats := make([]*pb.SatelliteStats, 0, len(ids))
+	for _, satellite := range ids {
+		quota := s.quotas.Get(satellite)
+
+		// satellites without a share may use what is left of the node allocation
+		availableSpace := s.totalAllocated - totalUsed
+		if quota.DiskSpace > 0 && quota.DiskSpace-usedSpace[satellite] < availableSpace {
+			availableSpace = quota.DiskSpace - usedSpace[satellite]
+		}
+		availableBandwidth := s.totalBwAllocated - totalUsedBandwidth
+		if quota.Bandwidth > 0 && quota.Bandwidth-usedBandwidth[satellite] < availableBandwidth {
+			availableBandwidth = quota.Bandwidth - usedBandwidth[satellite]
+		}
+
+		stats = append(stats, &pb.SatelliteStats{
+			SatelliteId:        []byte(satellite),
+			UsedSpace:          usedSpace[satellite],
+			AvailableSpace:     availableSpace,
+			UsedBandwidth:      usedBandwidth[satellite],
+			AvailableBandwidth: availableBandwidth,
+			UsedPutBandwidth:   usedByAction[satellite].Put,
+			UsedGetBandwidth:   usedByAction[satellite].Get,
+		})
+	}
+	return stats, nil
+}
+
+// availableSpace returns how many bytes can still be stored within the allocated disk space,
+// trashed pieces take up space until they are purged
+func (s *Server) availableSpace() (int64, error) {
+	totalUsed, err := s.DB.SpaceUsed()
+	if err != nil {
+		return 0, err
+	}
+	trashUsed, err := s.DB.TrashSpaceUsed()
+	if err != nil {
+		return 0, err
+	}
+	return s.totalAllocated - totalUsed - trashUsed, nil
 }
 
 // Delete -- Delete data by Id from piecestore
@@ -264,11 +528,12 @@ func (s *Server) Delete(ctx context.Context, in *pb.PieceDelete) (*pb.PieceDelet
 		return nil, ServerError.Wrap(err)
 	}
 
-	id, err := getNamespacedPieceID([]byte(in.GetId()), getNamespace(authorization))
-	if err != nil {
+	namespace := getNamespace(authorization)
+	if err := s.verifyTrusted(string(namespace)); err != nil {
 		return nil, err
 	}
-	if err := s.deleteByID(id); err != nil {
+
+	if err := s.deletePiece(in.GetId(), namespace); err != nil {
 		return nil, err
 	}
 
@@ -276,22 +541,184 @@ func (s *Server) Delete(ctx context.Context, in *pb.PieceDelete) (*pb.PieceDelet
 	return &pb.PieceDeleteSummary{Message: OK}, nil
 }
 
-func (s *Server) deleteByID(id string) error {
-	if err := pstore.Delete(id, s.DataDir); err != nil {
+// DeleteBatch deletes several pieces under one authorization and reports the result of each
+func (s *Server) DeleteBatch(ctx context.Context, in *pb.PieceDeleteBatch) (*pb.PieceDeleteBatchSummary, error) {
+	zap.S().Infof("Deleting %d pieces...", len(in.GetIds()))
+
+	authorization := in.GetAuthorization()
+	if err := s.verifier(authorization); err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+
+	namespace := getNamespace(authorization)
+	if err := s.verifyTrusted(string(namespace)); err != nil {
+		return nil, err
+	}
+
+	var failed int
+	results := make([]*pb.PieceDeleteBatchSummary_Result, 0, len(in.GetIds()))
+	for _, pieceID := range in.GetIds() {
+		result := &pb.PieceDeleteBatchSummary_Result{Id: pieceID}
+		if err := s.deletePiece(pieceID, namespace); err != nil {
+			zap.S().Errorf("Failed deleting %s: %v", pieceID, err)
+			result.Error = err.Error()
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	zap.S().Infof("Successfully deleted %d of %d pieces.", len(results)-failed, len(results))
+	return &pb.PieceDeleteBatchSummary{Results: results}, nil
+}
+
+// deletePiece moves the piece stored for the namespace to the trash
+func (s *Server) deletePiece(pieceID string, namespace []byte) error {
+	id, err := getNamespacedPieceID([]byte(pieceID), namespace)
+	if err != nil {
 		return err
 	}
+	if err := validatePieceID(id); err != nil {
+		return err
+	}
+
+	// keep the piece in the trash in case the satellite restores it
+	return s.DB.TrashPiece(id)
+}
+
+func (s *Server) deleteByID(ctx context.Context, id string) error {
+	ref, err := s.DB.GetBlobRef(id)
+	switch {
+	case err == sql.ErrNoRows:
+		// nothing stored with this id
+	case err != nil:
+		return err
+	default:
+		// the reference is kept until the blob is gone, so that a failed delete can be retried
+		if err := s.Blobs.Delete(ctx, ref); err != nil && !os.IsNotExist(errs.Unwrap(err)) {
+			return err
+		}
+		if err := s.DB.DeleteBlobRef(id); err != nil {
+			return err
+		}
+	}
 
 	if err := s.DB.DeleteTTLByID(id); err != nil {
 		return err
 	}
 
+	if err := s.DB.DeletePieceHash(id); err != nil {
+		return err
+	}
+
+	if err := s.DB.DeletePieceInfo(id); err != nil {
+		return err
+	}
+
 	zap.S().Infof("Deleted data of id (%s)\n", id)
 
 	return nil
 }
 
-func (s *Server) verifySignature(ctx context.Context, ba *pb.RenterBandwidthAllocation) error {
-	// TODO(security): detect replay attacks
+// verifyTrusted checks that the storage node trusts the satellite
+func (s *Server) verifyTrusted(satellite string) error {
+	if !s.trust.IsTrusted(satellite) {
+		return trust.Error.New("satellite %q is not trusted", satellite)
+	}
+	return nil
+}
+
+// verifyPayer checks that the payer allocation is signed by the satellite authorizing the transfer,
+// which has to be trusted, and returns the verified allocation
+func (s *Server) verifyPayer(rbad *pb.RenterBandwidthAllocation_Data, satellite string) (*pb.PayerBandwidthAllocation_Data, error) {
+	payer := rbad.GetPayerAllocation()
+	signer, err := auth.VerifySignature(payer.GetData(), payer.GetSignature(), payer.GetCerts())
+	if err != nil {
+		return nil, err
+	}
+
+	pbad := &pb.PayerBandwidthAllocation_Data{}
+	if err := proto.Unmarshal(payer.GetData(), pbad); err != nil {
+		return nil, err
+	}
+	if string(pbad.GetSatelliteId()) != signer {
+		return nil, auth.Error.New("allocation of satellite %q signed by %q", pbad.GetSatelliteId(), signer)
+	}
+	if signer != satellite {
+		return nil, auth.Error.New("allocation of satellite %q used for satellite %q", signer, satellite)
+	}
+	if err := s.verifyTrusted(signer); err != nil {
+		return nil, err
+	}
+	return pbad, nil
+}
+
+// verifyAction checks that the verified payer allocation was issued for uploading or downloading as expected
+func verifyAction(pbad *pb.PayerBandwidthAllocation_Data, expected pb.PayerBandwidthAllocation_Action) error {
+	// audits and repairs transfer pieces the same way as downloads and uploads
+	if actual := pbad.GetAction(); actual.IsGet() != expected.IsGet() || actual.IsPut() != expected.IsPut() {
+		return ErrWrongAction.New("expected %v got %v", expected, pbad.GetAction())
+	}
+	return nil
+}
+
+// verifyOrderLimit checks that the verified payer allocation was issued for this storage node and the piece,
+// and that the renter doesn't allocate more bandwidth than the satellite pays for
+func (s *Server) verifyOrderLimit(pbad *pb.PayerBandwidthAllocation_Data, rbad *pb.RenterBandwidthAllocation_Data, pieceID string) error {
+	nodeID := pbad.GetStorageNodeId()
+	if len(nodeID) == 0 {
+		return ErrOrderLimit.New("not issued to a storage node")
+	}
+	if s.id == nil || !bytes.Equal(nodeID, s.id.Bytes()) {
+		return ErrOrderLimit.New("issued to storage node %s", nodeID)
+	}
+
+	limitPieceID := pbad.GetPieceId()
+	if limitPieceID == "" {
+		return ErrOrderLimit.New("not issued for a piece")
+	}
+	if limitPieceID != pieceID {
+		return ErrOrderLimit.New("issued for piece %s", limitPieceID)
+	}
+
+	maxSize := pbad.GetMaxSize()
+	if maxSize <= 0 {
+		return ErrOrderLimit.New("no size limit")
+	}
+	if rbad.GetTotal() > maxSize {
+		return ErrOrderLimit.New("allocated %d bytes, limit is %d bytes", rbad.GetTotal(), maxSize)
+	}
+	return nil
+}
+
+// useSerialNumber records the serial number of the verified payer allocation, so that it can't be replayed
+// by another transfer; current is the serial number already recorded for this transfer
+func (s *Server) useSerialNumber(pbad *pb.PayerBandwidthAllocation_Data, current string) (serialNumber string, err error) {
+	// replays are detected by serial number, which is only stored until the allocation expires
+	serialNumber, expires := pbad.GetSerialNumber(), pbad.GetExpirationUnixSec()
+	if serialNumber == "" || expires == 0 {
+		return current, ErrOrderLimit.New("missing serial number or expiration")
+	}
+	if expires < time.Now().Unix() {
+		return current, ErrAllocationExpired.New("serial number %q", serialNumber)
+	}
+
+	if serialNumber == current {
+		return serialNumber, nil
+	}
+
+	used, err := s.DB.UseSerialNumber(string(pbad.GetSatelliteId()), serialNumber, expires)
+	if err != nil {
+		return current, err
+	}
+	if used {
+		return current, ErrSerialNumberUsed.New("%q", serialNumber)
+	}
+	return serialNumber, nil
+}
+
+// verifySignature checks that the renter allocation is signed by the peer, which has to be the uplink
+// the satellite issued the verified payer allocation to
+func (s *Server) verifySignature(ctx context.Context, pbad *pb.PayerBandwidthAllocation_Data, ba *pb.RenterBandwidthAllocation) error {
 	pi, err := provider.PeerIdentityFromContext(ctx)
 	if err != nil {
 		return err
@@ -302,12 +729,66 @@ func (s *Server) verifySignature(ctx context.Context, ba *pb.RenterBandwidthAllo
 		return peertls.ErrUnsupportedKey.New("%T", pi.Leaf.PublicKey)
 	}
 
+	uplinkKey := pbad.GetUplinkPublicKey()
+	if len(uplinkKey) == 0 {
+		return ErrOrderLimit.New("not issued to an uplink")
+	}
+	peerKey, err := x509.MarshalPKIXPublicKey(k)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(uplinkKey, peerKey) {
+		return ErrOrderLimit.New("issued to another uplink")
+	}
+
 	if ok := cryptopasta.Verify(ba.GetData(), ba.GetSignature(), k); !ok {
 		return ServerError.New("failed to verify Signature")
 	}
 	return nil
 }
 
+// signPieceHash creates a receipt for the piece content signed by the storage node
+func (s *Server) signPieceHash(pieceID string, hash []byte, size int64) (*pb.PieceHash, error) {
+	k, ok := s.pkey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, peertls.ErrUnsupportedKey.New("%T", s.pkey)
+	}
+
+	data, err := proto.Marshal(&pb.PieceHash_Data{Id: pieceID, Hash: hash, Size: size})
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := cryptopasta.Sign(data, k)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.PieceHash{Data: data, Signature: signature}, nil
+}
+
+// storedPieceHash returns a signed receipt for the stored hash of id
+// or nil when the piece was stored without a hash
+func (s *Server) storedPieceHash(pieceID, id string, size int64) (*pb.PieceHash, error) {
+	hash, err := s.DB.GetPieceHash(id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signPieceHash(pieceID, hash, size)
+}
+
+// validatePieceID checks whether id can be used for storing a piece
+func validatePieceID(id string) error {
+	if len(id) < pstore.IDLength {
+		return pstore.ArgError.New("invalid id length")
+	}
+	return nil
+}
+
 func getBeginningOfMonth() time.Time {
 	t := time.Now()
 	y, m, _ := t.Date()
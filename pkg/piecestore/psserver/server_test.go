@@ -7,17 +7,22 @@ import (
 	"bytes"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"database/sql"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"net"
 	"os"
-	"path"
 	"path/filepath"
-	"runtime"
-	"strings"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/gtank/cryptopasta"
@@ -25,71 +30,89 @@ import (
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/bloomfilter"
+	"storj.io/storj/pkg/merkle"
+	"storj.io/storj/pkg/node"
 	"storj.io/storj/pkg/pb"
-	"storj.io/storj/pkg/piecestore"
+	"storj.io/storj/pkg/piecestore/psclient"
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/pkg/piecestore/psserver/trust"
 	"storj.io/storj/pkg/provider"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
 )
 
 var ctx = context.Background()
 
-func writeFileToDir(name, dir string) error {
-	file, err := pstore.StoreWriter(name, dir)
+func writePiece(s *Server, id string) error {
+	ref, err := s.Blobs.Store(ctx, bytes.NewReader([]byte("butts")), -1)
 	if err != nil {
 		return err
 	}
-
-	// Close when finished
-	_, err = io.Copy(file, bytes.NewReader([]byte("butts")))
-	if err != nil {
-		_ = file.Close()
-		return err
-	}
-	return file.Close()
+	return s.DB.AddBlobRef(id, ref)
 }
 
 func TestPiece(t *testing.T) {
 	TS := NewTestServer(t)
 	defer TS.Stop()
 
-	if err := writeFileToDir("11111111111111111111", TS.s.DataDir); err != nil {
+	id := TS.pieceID(t, "11111111111111111111")
+	if err := writePiece(TS.s, id); err != nil {
 		t.Errorf("Error: %v\nCould not create test piece", err)
 		return
 	}
 
-	defer func() { _ = pstore.Delete("11111111111111111111", TS.s.DataDir) }()
+	defer func() { _ = TS.s.deleteByID(ctx, id) }()
+
+	// another node signing the authorization of the client
+	forged := authorize(t, newSatellite(t))
+	forged.Data = TS.authorization.Data
 
 	// set up test cases
 	tests := []struct {
-		id         string
-		size       int64
-		expiration int64
-		err        string
+		id            string
+		authorization *pb.SignedMessage
+		size          int64
+		expiration    int64
+		err           string
 	}{
 		{ // should successfully retrieve piece meta-data
-			id:         "11111111111111111111",
-			size:       5,
-			expiration: 9999999999,
-			err:        "",
-		},
-		{ // server should err with invalid id
-			id:         "123",
-			size:       5,
-			expiration: 9999999999,
-			err:        "rpc error: code = Unknown desc = argError: invalid id length",
+			id:            "11111111111111111111",
+			authorization: TS.authorization,
+			size:          5,
+			expiration:    9999999999,
+			err:           "",
 		},
 		{ // server should err with nonexistent file
-			id:         "22222222222222222222",
-			size:       5,
-			expiration: 9999999999,
-			err:        fmt.Sprintf("rpc error: code = Unknown desc = stat %s: no such file or directory", path.Join(TS.s.DataDir, "/22/22/2222222222222222")),
+			id:            "22222222222222222222",
+			authorization: TS.authorization,
+			size:          5,
+			expiration:    9999999999,
+			err:           "rpc error: code = Unknown desc = sql: no rows in result set",
 		},
-		{ // server should err with invalid TTL
-			id:         "22222222222222222222;DELETE*FROM TTL;;;;",
+		{ // server should err with invalid ID
+			id:            "22222222222222222222;DELETE*FROM TTL;;;;",
+			authorization: TS.authorization,
+			size:          5,
+			expiration:    9999999999,
+			err:           "rpc error: code = Unknown desc = PSServer error: invalid ID",
+		},
+		{ // server should err without authorization
+			id:         "11111111111111111111",
 			size:       5,
 			expiration: 9999999999,
-			err:        "rpc error: code = Unknown desc = PSServer error: invalid ID",
+			err:        "rpc error: code = Unknown desc = PSServer error: auth error: no message to verify",
+		},
+		{ // server should err with forged authorization
+			id:            "11111111111111111111",
+			authorization: forged,
+			size:          5,
+			expiration:    9999999999,
+			err:           "rpc error: code = Unknown desc = PSServer error: auth error: failed to verify message",
 		},
 	}
 
@@ -98,23 +121,20 @@ func TestPiece(t *testing.T) {
 			assert := assert.New(t)
 
 			// simulate piece TTL entry
-			_, err := TS.s.DB.DB.Exec(fmt.Sprintf(`INSERT INTO ttl (id, created, expires) VALUES ("%s", "%d", "%d")`, tt.id, 1234567890, tt.expiration))
+			ttlID := TS.pieceID(t, tt.id)
+			_, err := TS.s.DB.DB.Exec(fmt.Sprintf(`INSERT INTO ttl (id, created, expires) VALUES ("%s", "%d", "%d")`, ttlID, 1234567890, tt.expiration))
 			assert.NoError(err)
 
 			defer func() {
-				_, err := TS.s.DB.DB.Exec(fmt.Sprintf(`DELETE FROM ttl WHERE id="%s"`, tt.id))
+				_, err := TS.s.DB.DB.Exec(fmt.Sprintf(`DELETE FROM ttl WHERE id="%s"`, ttlID))
 				assert.NoError(err)
 			}()
 
-			req := &pb.PieceId{Id: tt.id}
+			req := &pb.PieceId{Id: tt.id, Authorization: tt.authorization}
 			resp, err := TS.c.Piece(ctx, req)
 
 			if tt.err != "" {
 				assert.NotNil(err)
-				if runtime.GOOS == "windows" && strings.Contains(tt.err, "no such file or directory") {
-					//TODO (windows): ignoring for windows due to different underlying error
-					return
-				}
 				assert.Equal(tt.err, err.Error())
 				return
 			}
@@ -135,12 +155,12 @@ func TestRetrieve(t *testing.T) {
 	defer TS.Stop()
 
 	// simulate piece stored with storagenode
-	if err := writeFileToDir("11111111111111111111", TS.s.DataDir); err != nil {
+	if err := writePiece(TS.s, "11111111111111111111"); err != nil {
 		t.Errorf("Error: %v\nCould not create test piece", err)
 		return
 	}
 
-	defer func() { _ = pstore.Delete("11111111111111111111", TS.s.DataDir) }()
+	defer func() { _ = TS.s.deleteByID(ctx, "11111111111111111111") }()
 
 	// set up test cases
 	tests := []struct {
@@ -204,7 +224,7 @@ func TestRetrieve(t *testing.T) {
 			allocSize: 5,
 			offset:    0,
 			content:   []byte("butts"),
-			err:       fmt.Sprintf("rpc error: code = Unknown desc = retrieve error: stat %s: no such file or directory", path.Join(TS.s.DataDir, "/22/22/2222222222222222")),
+			err:       "rpc error: code = Unknown desc = retrieve error: sql: no rows in result set",
 		},
 		{ // server should return expected content and respSize with offset and excess reqSize
 			id:        "11111111111111111111",
@@ -233,7 +253,7 @@ func TestRetrieve(t *testing.T) {
 			assert.NoError(err)
 
 			// send piece database
-			err = stream.Send(&pb.PieceRetrieval{PieceData: &pb.PieceRetrieval_PieceData{Id: tt.id, Size: tt.reqSize, Offset: tt.offset}})
+			err = stream.Send(&pb.PieceRetrieval{PieceData: &pb.PieceRetrieval_PieceData{Id: tt.id, Size: tt.reqSize, Offset: tt.offset}, Authorization: TS.authorization})
 			assert.NoError(err)
 
 			totalAllocated := int64(0)
@@ -246,7 +266,7 @@ func TestRetrieve(t *testing.T) {
 
 				ba := pb.RenterBandwidthAllocation{
 					Data: serializeData(&pb.RenterBandwidthAllocation_Data{
-						PayerAllocation: &pb.PayerBandwidthAllocation{},
+						PayerAllocation: TS.payer(t, tt.id, &pb.PayerBandwidthAllocation_Data{Action: pb.PayerBandwidthAllocation_GET}),
 						Total:           totalAllocated,
 					}),
 				}
@@ -265,10 +285,6 @@ func TestRetrieve(t *testing.T) {
 				resp, err = stream.Recv()
 				if tt.err != "" {
 					assert.NotNil(err)
-					if runtime.GOOS == "windows" && strings.Contains(tt.err, "no such file or directory") {
-						//TODO (windows): ignoring for windows due to different underlying error
-						return
-					}
 					assert.Equal(tt.err, err.Error())
 					return
 				}
@@ -287,6 +303,46 @@ func TestRetrieve(t *testing.T) {
 	}
 }
 
+func TestRetrieveHash(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	payer := func(action pb.PayerBandwidthAllocation_Action) *pb.PayerBandwidthAllocation {
+		return TS.payer(t, "11111111111111111111", &pb.PayerBandwidthAllocation_Data{Action: action})
+	}
+	assert.NoError(t, storePiece(TS, nil, payer(pb.PayerBandwidthAllocation_PUT), "11111111111111111111", []byte("butts")))
+
+	download := func() ([]byte, error) {
+		client, err := psclient.NewCustomRoute(TS.c, &pb.Node{Id: TS.s.id.String()}, 0, TS.k)
+		if err != nil {
+			return nil, err
+		}
+		rr, err := client.Get(ctx, "11111111111111111111", 5, payer(pb.PayerBandwidthAllocation_GET), TS.authorization)
+		if err != nil {
+			return nil, err
+		}
+		r, err := rr.Range(ctx, 0, rr.Size())
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = r.Close() }()
+		return ioutil.ReadAll(r)
+	}
+
+	data, err := download()
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("butts"), data)
+	}
+
+	// the content of a whole piece has to match the hash of the upload
+	hash := sha256.Sum256([]byte("other"))
+	assert.NoError(t, TS.s.DB.AddPieceHash(TS.pieceID(t, "11111111111111111111"), hash[:]))
+	_, err = download()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "hash mismatch")
+	}
+}
+
 func TestStore(t *testing.T) {
 	TS := NewTestServer(t)
 	defer TS.Stop()
@@ -299,6 +355,7 @@ func TestStore(t *testing.T) {
 		content       []byte
 		message       string
 		totalReceived int64
+		unauthorized  bool
 		err           string
 	}{
 		{ // should successfully store data
@@ -309,13 +366,14 @@ func TestStore(t *testing.T) {
 			totalReceived: 5,
 			err:           "",
 		},
-		{ // should err with invalid id length
-			id:            "butts",
+		{ // should err without authorization
+			id:            "88888888888888888888",
 			ttl:           9999999999,
 			content:       []byte("butts"),
 			message:       "",
 			totalReceived: 0,
-			err:           "rpc error: code = Unknown desc = argError: invalid id length",
+			unauthorized:  true,
+			err:           "rpc error: code = Unknown desc = PSServer error: auth error: no message to verify",
 		},
 		{ // should err with piece ID not specified
 			id:            "",
@@ -333,16 +391,22 @@ func TestStore(t *testing.T) {
 			stream, err := TS.c.Store(ctx)
 			assert.NoError(err)
 
+			authorization := TS.authorization
+			if tt.unauthorized {
+				authorization = nil
+			}
+
 			// Write the buffer to the stream we opened earlier
-			err = stream.Send(&pb.PieceStore{Piecedata: &pb.PieceStore_PieceData{Id: tt.id, ExpirationUnixSec: tt.ttl}})
+			err = stream.Send(&pb.PieceStore{Piecedata: &pb.PieceStore_PieceData{Id: tt.id, ExpirationUnixSec: tt.ttl}, Authorization: authorization})
 			assert.NoError(err)
 
 			// Send Bandwidth Allocation Data
+			payer := TS.payer(t, tt.id, &pb.PayerBandwidthAllocation_Data{})
 			msg := &pb.PieceStore{
 				Piecedata: &pb.PieceStore_PieceData{Content: tt.content},
 				Bandwidthallocation: &pb.RenterBandwidthAllocation{
 					Data: serializeData(&pb.RenterBandwidthAllocation_Data{
-						PayerAllocation: &pb.PayerBandwidthAllocation{},
+						PayerAllocation: payer,
 						Total:           int64(len(tt.content)),
 					}),
 				},
@@ -368,7 +432,7 @@ func TestStore(t *testing.T) {
 			assert.NoError(err)
 
 			defer func() {
-				_, err := db.Exec(fmt.Sprintf(`DELETE FROM ttl WHERE id="%s"`, tt.id))
+				_, err := db.Exec(fmt.Sprintf(`DELETE FROM ttl WHERE id="%s"`, TS.pieceID(t, tt.id)))
 				assert.NoError(err)
 			}()
 
@@ -391,7 +455,7 @@ func TestStore(t *testing.T) {
 				err = proto.Unmarshal(agreement, decoded)
 				assert.NoError(err)
 				assert.Equal(msg.Bandwidthallocation.GetSignature(), signature)
-				assert.Equal(&pb.PayerBandwidthAllocation{}, decoded.GetPayerAllocation())
+				assert.True(proto.Equal(payer, decoded.GetPayerAllocation()))
 				assert.Equal(int64(len(tt.content)), decoded.GetTotal())
 
 			}
@@ -400,36 +464,654 @@ func TestStore(t *testing.T) {
 
 			assert.Equal(tt.message, resp.Message)
 			assert.Equal(tt.totalReceived, resp.TotalReceived)
+
+			// check that the piece hash receipt matches the content
+			hash := sha256.Sum256(tt.content)
+			serverKey := &TS.s.pkey.(*ecdsa.PrivateKey).PublicKey
+			pieceID := psclient.PieceID(tt.id)
+			assert.NoError(psclient.VerifyPieceHash(resp.Hash, serverKey, pieceID, hash[:], tt.totalReceived))
+
+			storedHash, err := TS.s.DB.GetPieceHash(TS.pieceID(t, tt.id))
+			assert.NoError(err)
+			assert.Equal(hash[:], storedHash)
 		})
 	}
 }
 
+func TestStoreNotEnoughSpace(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	tests := []struct {
+		allocated int64
+		content   []byte
+	}{
+		{ // nothing allocated, rejected before receiving data
+			allocated: 0,
+			content:   []byte("butts"),
+		},
+		{ // piece larger than the available space
+			allocated: 3,
+			content:   []byte("butts"),
+		},
+	}
+
+	for _, tt := range tests {
+		TS.s.totalAllocated = tt.allocated
+
+		err := storePiece(TS, nil, TS.payer(t, "99999999999999999999", &pb.PayerBandwidthAllocation_Data{}), "99999999999999999999", tt.content)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "not enough disk space")
+		}
+
+		used, err := TS.s.DB.SpaceUsed()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), used)
+
+		_, err = TS.s.DB.GetBlobRef(TS.pieceID(t, "99999999999999999999"))
+		assert.Equal(t, sql.ErrNoRows, err)
+	}
+}
+
+func TestSatelliteQuotas(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	satelliteA, satelliteB, satelliteC, satelliteD := newSatellite(t), newSatellite(t), newSatellite(t), newSatellite(t)
+
+	TS.s.totalBwAllocated = 1 << 30
+	TS.s.quotas = &Quotas{Satellites: map[string]Quota{
+		satelliteA.ID.String(): {DiskSpace: 3},
+		satelliteB.ID.String(): {Bandwidth: 5},
+		satelliteD.ID.String(): {Bandwidth: 3},
+	}}
+
+	store := func(satellite *provider.FullIdentity, id string, content []byte) error {
+		return storePiece(TS, authorize(t, satellite), signPayer(t, satellite, TS.orderLimit(id, &pb.PayerBandwidthAllocation_Data{})), id, content)
+	}
+
+	// disk space share exceeded
+	err := store(satelliteA, "11111111111111111111", []byte("butts"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "not enough disk space")
+	}
+
+	// bandwidth share used up by the first upload
+	assert.NoError(t, store(satelliteB, "22222222222222222222", []byte("butts")))
+	err = store(satelliteB, "33333333333333333333", []byte("butts"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "bandwidth share of satellite exceeded")
+	}
+
+	// bandwidth share used up while receiving the piece
+	err = store(satelliteD, "55555555555555555555", []byte("butts"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "quota error: bandwidth share of satellite exceeded")
+	}
+	_, err = TS.s.DB.GetBlobRef(TS.pieceID(t, "55555555555555555555"))
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	// satellites without a share are limited only by the node allocation
+	assert.NoError(t, store(satelliteC, "44444444444444444444", []byte("butts")))
+
+	stats, err := TS.c.Stats(ctx, &pb.StatsReq{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int64(10), stats.UsedSpace)
+
+	expected := map[string]*pb.SatelliteStats{
+		satelliteA.ID.String(): {SatelliteId: satelliteA.ID.Bytes(), UsedSpace: 0, AvailableSpace: 3, UsedBandwidth: 0, AvailableBandwidth: 1<<30 - 10},
+		satelliteB.ID.String(): {SatelliteId: satelliteB.ID.Bytes(), UsedSpace: 5, AvailableSpace: 1<<30 - 10, UsedBandwidth: 5, AvailableBandwidth: 0, UsedPutBandwidth: 5},
+		satelliteC.ID.String(): {SatelliteId: satelliteC.ID.Bytes(), UsedSpace: 5, AvailableSpace: 1<<30 - 10, UsedBandwidth: 5, AvailableBandwidth: 1<<30 - 10, UsedPutBandwidth: 5},
+		satelliteD.ID.String(): {SatelliteId: satelliteD.ID.Bytes(), UsedSpace: 0, AvailableSpace: 1<<30 - 10, UsedBandwidth: 0, AvailableBandwidth: 3},
+	}
+	if assert.Len(t, stats.Satellites, len(expected)) {
+		for _, satellite := range stats.Satellites {
+			assert.True(t, proto.Equal(expected[string(satellite.SatelliteId)], satellite), "%v != %v", expected[string(satellite.SatelliteId)], satellite)
+		}
+	}
+}
+
+func TestSpaceReservations(t *testing.T) {
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	s.totalAllocated = reservationSize + 10
+	s.quotas = &Quotas{Satellites: map[string]Quota{"satellite-b": {DiskSpace: 5}}}
+
+	// the first upload reserves space for more than its first bytes
+	first := &spaceReservation{server: s, satellite: "satellite-a"}
+	assert.NoError(t, first.use(6))
+	available, err := s.satelliteAvailableSpace("satellite-a")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), available)
+
+	// concurrent uploads can't exceed the space left together
+	second := &spaceReservation{server: s, satellite: "satellite-a"}
+	assert.NoError(t, second.use(6))
+	assert.True(t, ErrNotEnoughSpace.Has(second.use(6)))
+
+	// the quota of a satellite is reserved as well
+	quota := &spaceReservation{server: s, satellite: "satellite-b"}
+	assert.True(t, ErrNotEnoughSpace.Has(quota.use(1)))
+	second.release()
+	quota = &spaceReservation{server: s, satellite: "satellite-b"}
+	assert.NoError(t, quota.use(5))
+	assert.True(t, ErrNotEnoughSpace.Has(quota.use(1)))
+
+	quota.release()
+	first.release()
+	available, err = s.satelliteAvailableSpace("satellite-a")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(reservationSize+10), available)
+}
+
+func TestTrustedSatellites(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	satelliteA, satelliteB := newSatellite(t), newSatellite(t)
+	trusted, err := trust.Parse(satelliteA.ID.String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	TS.s.trust = trusted
+
+	payer := func(satellite *provider.FullIdentity) *pb.PayerBandwidthAllocation {
+		return signPayer(t, satellite, TS.orderLimit("11111111111111111111", &pb.PayerBandwidthAllocation_Data{}))
+	}
+
+	err = storePiece(TS, authorize(t, satelliteB), payer(satelliteB), "11111111111111111111", []byte("butts"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), fmt.Sprintf("satellite %q is not trusted", satelliteB.ID))
+	}
+
+	err = storePiece(TS, authorize(t, satelliteA), payer(satelliteB), "11111111111111111111", []byte("butts"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), fmt.Sprintf("allocation of satellite %q used for satellite %q", satelliteB.ID, satelliteA.ID))
+	}
+
+	// an allocation claiming to be paid by the trusted satellite, signed by another one
+	forged := signPayer(t, satelliteB, TS.orderLimit("11111111111111111111", &pb.PayerBandwidthAllocation_Data{SatelliteId: satelliteA.ID.Bytes()}))
+	err = storePiece(TS, authorize(t, satelliteA), forged, "11111111111111111111", []byte("butts"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), fmt.Sprintf("allocation of satellite %q signed by %q", satelliteA.ID, satelliteB.ID))
+	}
+
+	// the certificate chain of the trusted satellite with the signature of another one
+	forged = payer(satelliteB)
+	forged.Certs = satelliteA.ChainRaw()
+	err = storePiece(TS, authorize(t, satelliteA), forged, "11111111111111111111", []byte("butts"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "failed to verify message")
+	}
+
+	// the authorization of the trusted satellite alone doesn't pay for storing data
+	content := &pb.PieceStore{Piecedata: &pb.PieceStore_PieceData{Content: []byte("butts")}}
+	err = storeMessages(TS, authorize(t, satelliteA), "11111111111111111111", content)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "piece data sent without an allocation")
+	}
+
+	err = storePiece(TS, authorize(t, satelliteA), payer(satelliteA), "11111111111111111111", []byte("butts"))
+	assert.NoError(t, err)
+
+	// the trusted satellite audits the piece
+	alloc := &pb.RenterBandwidthAllocation{
+		Data: serializeData(&pb.RenterBandwidthAllocation_Data{
+			PayerAllocation: signPayer(t, satelliteA, TS.orderLimit("11111111111111111111", &pb.PayerBandwidthAllocation_Data{Action: pb.PayerBandwidthAllocation_GET_AUDIT})),
+			Total:           5,
+		}),
+	}
+	alloc.Signature, err = cryptopasta.Sign(alloc.Data, TS.k.(*ecdsa.PrivateKey))
+	assert.NoError(t, err)
+	resp, err := TS.c.Prove(ctx, &pb.PieceProof{
+		Id:                  "11111111111111111111",
+		ShareSize:           5,
+		Authorization:       authorize(t, satelliteA),
+		Bandwidthallocation: alloc,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("butts"), resp.GetShare())
+	}
+
+	_, err = TS.c.Delete(ctx, &pb.PieceDelete{Id: "11111111111111111111", Authorization: authorize(t, satelliteB)})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), fmt.Sprintf("satellite %q is not trusted", satelliteB.ID))
+	}
+
+	_, err = TS.c.Delete(ctx, &pb.PieceDelete{Id: "11111111111111111111", Authorization: authorize(t, satelliteA)})
+	assert.NoError(t, err)
+}
+
+func TestSerialNumbers(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	another := newSatellite(t)
+
+	payer := func(satellite *provider.FullIdentity, pieceID, serialNumber string, expiration time.Time) *pb.PayerBandwidthAllocation {
+		return signPayer(t, satellite, TS.orderLimit(pieceID, &pb.PayerBandwidthAllocation_Data{
+			SerialNumber:      serialNumber,
+			ExpirationUnixSec: expiration.Unix(),
+		}))
+	}
+	expiration := time.Now().Add(time.Hour)
+
+	err := storePiece(TS, nil, payer(TS.identity, "11111111111111111111", "serial-1", expiration), "11111111111111111111", []byte("butts"))
+	assert.NoError(t, err)
+
+	// the serial number can't be used by another transfer
+	err = storePiece(TS, nil, payer(TS.identity, "22222222222222222222", "serial-1", expiration), "22222222222222222222", []byte("butts"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "serial number already used")
+	}
+
+	// serial numbers are unique per satellite
+	err = storePiece(TS, authorize(t, another), payer(another, "22222222222222222222", "serial-1", expiration), "22222222222222222222", []byte("butts"))
+	assert.NoError(t, err)
+
+	err = storePiece(TS, nil, payer(TS.identity, "33333333333333333333", "serial-2", time.Now().Add(-time.Hour)), "33333333333333333333", []byte("butts"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "allocation expired")
+	}
+
+	// allocations without a serial number or an expiration can't be tracked
+	for _, untracked := range []*pb.PayerBandwidthAllocation_Data{
+		{SerialNumber: "", ExpirationUnixSec: expiration.Unix()},
+		{SerialNumber: "serial-3", ExpirationUnixSec: 0},
+	} {
+		pbad := TS.orderLimit("33333333333333333333", &pb.PayerBandwidthAllocation_Data{})
+		pbad.SerialNumber, pbad.ExpirationUnixSec = untracked.SerialNumber, untracked.ExpirationUnixSec
+		err = storePiece(TS, nil, signPayer(t, TS.identity, pbad), "33333333333333333333", []byte("butts"))
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "missing serial number or expiration")
+		}
+	}
+
+	_, err = TS.s.DB.GetBlobRef(TS.pieceID(t, "33333333333333333333"))
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	// every upload uses up a serial number, even the upload of an empty piece
+	err = storePiece(TS, nil, payer(TS.identity, "44444444444444444444", "serial-4", expiration), "44444444444444444444", nil)
+	assert.NoError(t, err)
+	err = storePiece(TS, nil, payer(TS.identity, "55555555555555555555", "serial-4", expiration), "55555555555555555555", []byte("butts"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "serial number already used")
+	}
+
+	// an upload without an allocation can't skip the serial number
+	err = storeMessages(TS, nil, "55555555555555555555", &pb.PieceStore{Piecedata: &pb.PieceStore_PieceData{Content: []byte("butts")}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "piece data sent without an allocation")
+	}
+	_, err = TS.s.DB.GetBlobRef(TS.pieceID(t, "55555555555555555555"))
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestAllocationAction(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	payer := func(action pb.PayerBandwidthAllocation_Action) *pb.PayerBandwidthAllocation {
+		return TS.payer(t, "11111111111111111111", &pb.PayerBandwidthAllocation_Data{Action: action})
+	}
+
+	// download allocations can't be used for uploading
+	err := storePiece(TS, nil, payer(pb.PayerBandwidthAllocation_GET), "11111111111111111111", []byte("butts"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong allocation action")
+	}
+
+	_, err = TS.s.DB.GetBlobRef(TS.pieceID(t, "11111111111111111111"))
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	// the action of an upload is always checked, as it can't be sent without an allocation
+	err = storeMessages(TS, nil, "11111111111111111111")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "no allocation received")
+	}
+	alloc, err := renterAllocation(TS, payer(pb.PayerBandwidthAllocation_GET), 5)
+	assert.NoError(t, err)
+	err = storeMessages(TS, nil, "11111111111111111111",
+		&pb.PieceStore{Piecedata: &pb.PieceStore_PieceData{Content: []byte("butts")}},
+		&pb.PieceStore{Bandwidthallocation: alloc},
+	)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "piece data sent without an allocation")
+	}
+
+	// the action can't be changed without the satellite signing it
+	tampered := payer(pb.PayerBandwidthAllocation_GET)
+	tampered.Data, err = proto.Marshal(&pb.PayerBandwidthAllocation_Data{SatelliteId: TS.identity.ID.Bytes(), Action: pb.PayerBandwidthAllocation_PUT})
+	assert.NoError(t, err)
+	err = storePiece(TS, nil, tampered, "11111111111111111111", []byte("butts"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "failed to verify message")
+	}
+
+	err = storePiece(TS, nil, payer(pb.PayerBandwidthAllocation_PUT), "11111111111111111111", []byte("butts"))
+	assert.NoError(t, err)
+
+	stats, err := TS.c.Stats(ctx, &pb.StatsReq{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(5), stats.UsedPutBandwidth)
+		assert.Equal(t, int64(0), stats.UsedGetBandwidth)
+	}
+}
+
+func TestOrderLimit(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	TS.s.id = node.IDFromString("storage-node")
+
+	uplinkKey, err := x509.MarshalPKIXPublicKey(&TS.k.(*ecdsa.PrivateKey).PublicKey)
+	assert.NoError(t, err)
+
+	payer := func(nodeID, pieceID string, maxSize int64) *pb.PayerBandwidthAllocation {
+		return signPayer(t, TS.identity, &pb.PayerBandwidthAllocation_Data{
+			Action:            pb.PayerBandwidthAllocation_PUT,
+			StorageNodeId:     []byte(nodeID),
+			PieceId:           pieceID,
+			MaxSize:           maxSize,
+			UplinkPublicKey:   uplinkKey,
+			SerialNumber:      "serial-1",
+			ExpirationUnixSec: time.Now().Add(time.Hour).Unix(),
+		})
+	}
+
+	for _, tt := range []struct {
+		payer     *pb.PayerBandwidthAllocation
+		errString string
+	}{
+		{payer("", "11111111111111111111", 5), "not issued to a storage node"},
+		{payer("another-node", "11111111111111111111", 5), "issued to storage node another-node"},
+		{payer("storage-node", "", 5), "not issued for a piece"},
+		{payer("storage-node", "22222222222222222222", 5), "issued for piece 22222222222222222222"},
+		{payer("storage-node", "11111111111111111111", 0), "no size limit"},
+		{payer("storage-node", "11111111111111111111", 4), "allocated 5 bytes, limit is 4 bytes"},
+	} {
+		err := storePiece(TS, nil, tt.payer, "11111111111111111111", []byte("butts"))
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), tt.errString)
+		}
+
+		_, err = TS.s.DB.GetBlobRef(TS.pieceID(t, "11111111111111111111"))
+		assert.Equal(t, sql.ErrNoRows, err)
+	}
+
+	// piece data is only accepted within a verified allocation
+	limit := func() *pb.PayerBandwidthAllocation {
+		return TS.payer(t, "11111111111111111111", &pb.PayerBandwidthAllocation_Data{MaxSize: 5})
+	}
+	content := &pb.PieceStore{Piecedata: &pb.PieceStore_PieceData{Content: []byte("butts")}}
+	for _, tt := range []struct {
+		total     int64
+		messages  []*pb.PieceStore
+		errString string
+	}{
+		{ // content without allocation
+			messages:  []*pb.PieceStore{content},
+			errString: "piece data sent without an allocation",
+		},
+		{ // content before the allocation
+			total:     5,
+			messages:  []*pb.PieceStore{content, nil},
+			errString: "piece data sent without an allocation",
+		},
+		{ // no allocation for an empty piece
+			errString: "no allocation received",
+		},
+		{ // content beyond the allocated total
+			total:     4,
+			messages:  []*pb.PieceStore{nil, content},
+			errString: "received 5 bytes, allocated 4 bytes",
+		},
+	} {
+		for i, msg := range tt.messages {
+			if msg == nil {
+				alloc, err := renterAllocation(TS, limit(), tt.total)
+				if !assert.NoError(t, err) {
+					return
+				}
+				tt.messages[i] = &pb.PieceStore{Bandwidthallocation: alloc}
+			}
+		}
+
+		err := storeMessages(TS, nil, "11111111111111111111", tt.messages...)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), tt.errString)
+		}
+
+		_, err = TS.s.DB.GetBlobRef(TS.pieceID(t, "11111111111111111111"))
+		assert.Equal(t, sql.ErrNoRows, err)
+	}
+
+	err = storePiece(TS, nil, payer("storage-node", "11111111111111111111", 5), "11111111111111111111", []byte("butts"))
+	assert.NoError(t, err)
+}
+
+func TestUplinkKey(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	payer := func(uplinkKey crypto.PublicKey) *pb.PayerBandwidthAllocation {
+		publicKey, err := x509.MarshalPKIXPublicKey(uplinkKey)
+		assert.NoError(t, err)
+		return TS.payer(t, "11111111111111111111", &pb.PayerBandwidthAllocation_Data{
+			Action:          pb.PayerBandwidthAllocation_PUT,
+			UplinkPublicKey: publicKey,
+		})
+	}
+
+	another, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	err = storePiece(TS, nil, payer(&another.PublicKey), "11111111111111111111", []byte("butts"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "issued to another uplink")
+	}
+
+	// every allocation has to be issued to an uplink
+	unissued := TS.orderLimit("11111111111111111111", &pb.PayerBandwidthAllocation_Data{Action: pb.PayerBandwidthAllocation_PUT})
+	unissued.UplinkPublicKey = nil
+	err = storePiece(TS, nil, signPayer(t, TS.identity, unissued), "11111111111111111111", []byte("butts"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "not issued to an uplink")
+	}
+
+	err = storePiece(TS, nil, payer(&TS.k.(*ecdsa.PrivateKey).PublicKey), "11111111111111111111", []byte("butts"))
+	assert.NoError(t, err)
+}
+
+func TestCleanup(t *testing.T) {
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	for _, info := range []psdb.PieceInfo{
+		{ID: "11111111111111111111", PieceID: "piece-1", Satellite: "satellite-a"},
+		{ID: "22222222222222222222", PieceID: "piece-2", Satellite: "satellite-b"},
+		{ID: "33333333333333333333", PieceID: "piece-3", Satellite: "satellite-b"},
+	} {
+		assert.NoError(t, writePiece(s, info.ID))
+		assert.NoError(t, s.DB.AddTTL(info.ID, 0, 5))
+		assert.NoError(t, s.DB.AddPieceInfo(info))
+	}
+
+	// every satellite is trusted
+	deleted, err := NewCleaner(s, time.Hour, 0).Cleanup(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	s.trust, err = trust.Parse("satellite-a")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// satellite-b is queued, but kept until the delay passes
+	deleted, err = NewCleaner(s, time.Hour, time.Hour).Cleanup(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	cleanups, err := s.DB.GetCleanups()
+	assert.NoError(t, err)
+	if assert.Len(t, cleanups, 1) {
+		assert.Equal(t, "satellite-b", cleanups[0].Satellite)
+	}
+
+	deleted, err = NewCleaner(s, time.Hour, 0).Cleanup(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	cleanups, err = s.DB.GetCleanups()
+	assert.NoError(t, err)
+	assert.Len(t, cleanups, 0)
+
+	for id, exists := range map[string]bool{
+		"11111111111111111111": true,
+		"22222222222222222222": false,
+		"33333333333333333333": false,
+	} {
+		_, err := s.DB.GetBlobRef(id)
+		assert.Equal(t, exists, err == nil, id)
+	}
+
+	used, err := s.DB.SpaceUsed()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), used)
+}
+
+func TestReconcile(t *testing.T) {
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	reconciler := NewReconciler(s, time.Hour)
+
+	id := "11111111111111111111"
+	assert.NoError(t, writePiece(s, id))
+
+	// the piece isn't accounted for yet
+	drift, err := reconciler.Reconcile(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len("butts")), drift)
+
+	assert.NoError(t, s.DB.AddTTL(id, 0, int64(len("butts"))))
+
+	drift, err = reconciler.Reconcile(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), drift)
+}
+
+func TestCheck(t *testing.T) {
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	// storeUntracked stores a blob that isn't referenced by any piece and was written long ago
+	storeUntracked := func() string {
+		ref, err := s.Blobs.Store(ctx, bytes.NewReader([]byte("orphan")), -1)
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		blobref := hex.EncodeToString(ref[:])
+		old := time.Now().Add(-2 * uploadGrace)
+		assert.NoError(t, os.Chtimes(filepath.Join(s.DataDir, blobref[:2], blobref[2:]), old, old))
+		return blobref
+	}
+
+	assert.NoError(t, writePiece(s, "11111111111111111111"))
+	assert.NoError(t, s.DB.AddTTL("11111111111111111111", 0, 5))
+	assert.NoError(t, writePiece(s, "22222222222222222222"))
+	assert.NoError(t, s.DB.AddTTL("22222222222222222222", 0, 7))
+	assert.NoError(t, s.DB.AddTTL("33333333333333333333", 0, 5))
+	assert.NoError(t, writePiece(s, "44444444444444444444"))
+	orphan := storeUntracked()
+
+	// blobs of recent uploads aren't reported yet
+	_, err := s.Blobs.Store(ctx, bytes.NewReader([]byte("uploading")), -1)
+	assert.NoError(t, err)
+
+	found, err := NewChecker(s, time.Hour, CheckReport).Check(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	byID := map[string]Inconsistency{}
+	for _, inconsistency := range found {
+		assert.Equal(t, "reported", inconsistency.Action)
+		byID[inconsistency.ID] = inconsistency
+	}
+	assert.Len(t, found, 4)
+	assert.Equal(t, SizeMismatch, byID["22222222222222222222"].Kind)
+	assert.Equal(t, int64(7), byID["22222222222222222222"].RecordedSize)
+	assert.Equal(t, int64(5), byID["22222222222222222222"].DiskSize)
+	assert.Equal(t, MissingBlob, byID["33333333333333333333"].Kind)
+	assert.Equal(t, UntrackedBlob, byID["44444444444444444444"].Kind)
+	assert.Equal(t, UntrackedBlob, byID[""].Kind)
+	assert.Equal(t, orphan, byID[""].BlobRef)
+
+	found, err = NewChecker(s, time.Hour, CheckRepair).Check(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, found, 4)
+	for _, inconsistency := range found {
+		assert.Equal(t, "repaired", inconsistency.Action, inconsistency.Kind)
+	}
+
+	found, err = NewChecker(s, time.Hour, CheckReport).Check(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, found, 0)
+
+	// the used space follows the disk after the repair
+	used, err := s.DB.SpaceUsed()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(15), used)
+
+	storeUntracked()
+	found, err = NewChecker(s, time.Hour, CheckQuarantine).Check(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, found, 1) {
+		assert.Equal(t, "quarantined", found[0].Action)
+	}
+
+	found, err = NewChecker(s, time.Hour, CheckReport).Check(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, found, 0)
+
+	_, err = NewChecker(s, time.Hour, "ignore").Check(ctx)
+	assert.Error(t, err)
+}
+
 func TestDelete(t *testing.T) {
 	TS := NewTestServer(t)
 	defer TS.Stop()
 
 	db := TS.s.DB.DB
+	id := TS.pieceID(t, "11111111111111111111")
 
 	// set up test cases
 	tests := []struct {
-		id      string
-		message string
-		err     string
+		id            string
+		authorization *pb.SignedMessage
+		message       string
+		err           string
 	}{
 		{ // should successfully delete data
-			id:      "11111111111111111111",
-			message: "OK",
-			err:     "",
+			id:            "11111111111111111111",
+			authorization: TS.authorization,
+			message:       "OK",
+			err:           "",
 		},
-		{ // should err with invalid id length
-			id:      "123",
-			message: "rpc error: code = Unknown desc = argError: invalid id length",
-			err:     "rpc error: code = Unknown desc = argError: invalid id length",
+		{ // should err without authorization
+			id:      "11111111111111111111",
+			message: "rpc error: code = Unknown desc = PSServer error: auth error: no message to verify",
+			err:     "rpc error: code = Unknown desc = PSServer error: auth error: no message to verify",
 		},
 		{ // should return OK with nonexistent file
-			id:      "22222222222222222223",
-			message: "OK",
-			err:     "",
+			id:            "22222222222222222223",
+			authorization: TS.authorization,
+			message:       "OK",
+			err:           "",
 		},
 	}
 
@@ -438,25 +1120,26 @@ func TestDelete(t *testing.T) {
 			assert := assert.New(t)
 
 			// simulate piece stored with storagenode
-			if err := writeFileToDir("11111111111111111111", TS.s.DataDir); err != nil {
+			if err := writePiece(TS.s, id); err != nil {
 				t.Errorf("Error: %v\nCould not create test piece", err)
 				return
 			}
 
 			// simulate piece TTL entry
-			_, err := db.Exec(fmt.Sprintf(`INSERT INTO ttl (id, created, expires) VALUES ("%s", "%d", "%d")`, tt.id, 1234567890, 1234567890))
+			ttlID := TS.pieceID(t, tt.id)
+			_, err := db.Exec(fmt.Sprintf(`INSERT INTO ttl (id, created, expires) VALUES ("%s", "%d", "%d")`, ttlID, 1234567890, 1234567890))
 			assert.NoError(err)
 
 			defer func() {
-				_, err := db.Exec(fmt.Sprintf(`DELETE FROM ttl WHERE id="%s"`, tt.id))
+				_, err := db.Exec(fmt.Sprintf(`DELETE FROM ttl WHERE id="%s"`, ttlID))
 				assert.NoError(err)
 			}()
 
 			defer func() {
-				assert.NoError(pstore.Delete("11111111111111111111", TS.s.DataDir))
+				assert.NoError(TS.s.deleteByID(ctx, id))
 			}()
 
-			req := &pb.PieceDelete{Id: tt.id}
+			req := &pb.PieceDelete{Id: tt.id, Authorization: tt.authorization}
 			resp, err := TS.c.Delete(ctx, req)
 
 			if tt.err != "" {
@@ -467,17 +1150,410 @@ func TestDelete(t *testing.T) {
 			assert.NoError(err)
 			assert.Equal(tt.message, resp.GetMessage())
 
-			// if test passes, check if file was indeed deleted
-			filePath, err := pstore.PathByID(tt.id, TS.s.DataDir)
-			assert.NoError(err)
-			if _, err = os.Stat(filePath); os.IsExist(err) {
-				t.Errorf("File not deleted")
+			// if test passes, check if piece was indeed deleted
+			if _, err = TS.s.DB.GetBlobRef(ttlID); err != sql.ErrNoRows {
+				t.Errorf("Piece not deleted")
 				return
 			}
 		})
 	}
 }
 
+func TestDeleteBatch(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	ids := []string{"11111111111111111111", "22222222222222222222"}
+	for _, id := range ids {
+		if !assert.NoError(t, writePiece(TS.s, TS.pieceID(t, id))) {
+			return
+		}
+		assert.NoError(t, TS.s.DB.AddTTL(TS.pieceID(t, id), 0, 5))
+	}
+
+	_, err := TS.c.DeleteBatch(ctx, &pb.PieceDeleteBatch{Ids: ids})
+	assert.Error(t, err)
+
+	resp, err := TS.c.DeleteBatch(ctx, &pb.PieceDeleteBatch{
+		Ids:           []string{ids[0], ids[1], "33333333333333333333"},
+		Authorization: TS.authorization,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	results := resp.GetResults()
+	if assert.Len(t, results, 3) {
+		for i, expected := range []struct {
+			id  string
+			err string
+		}{
+			{id: ids[0]},
+			{id: ids[1]},
+			{id: "33333333333333333333"}, // nonexistent pieces are already deleted
+		} {
+			assert.Equal(t, expected.id, results[i].GetId())
+			assert.Equal(t, expected.err, results[i].GetError())
+		}
+	}
+
+	for _, id := range ids {
+		_, err := TS.s.DB.GetBlobRef(TS.pieceID(t, id))
+		assert.Equal(t, sql.ErrNoRows, err)
+	}
+
+	used, err := TS.s.DB.SpaceUsed()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), used)
+}
+
+// failingBlobs fails deleting blobs
+type failingBlobs struct {
+	storage.Blobs
+}
+
+func (failingBlobs) Delete(context.Context, storage.BlobRef) error {
+	return errors.New("delete failed")
+}
+
+func TestDeleteByID(t *testing.T) {
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	id := "11111111111111111111"
+	if !assert.NoError(t, writePiece(s, id)) {
+		return
+	}
+	ref, err := s.DB.GetBlobRef(id)
+	assert.NoError(t, err)
+
+	// the reference is kept when the blob can't be deleted
+	blobs := s.Blobs
+	s.Blobs = failingBlobs{blobs}
+	assert.Error(t, s.deleteByID(ctx, id))
+	_, err = s.DB.GetBlobRef(id)
+	assert.NoError(t, err)
+
+	// a blob that is already gone is deleted
+	s.Blobs = blobs
+	blobref := hex.EncodeToString(ref[:])
+	assert.NoError(t, os.Remove(filepath.Join(s.DataDir, blobref[:2], blobref[2:])))
+	assert.NoError(t, s.deleteByID(ctx, id))
+	_, err = s.DB.GetBlobRef(id)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestRestoreTrash(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	if !assert.NoError(t, storePiece(TS, nil, TS.payer(t, "11111111111111111111", &pb.PayerBandwidthAllocation_Data{}), "11111111111111111111", []byte("butts"))) {
+		return
+	}
+	id := TS.pieceID(t, "11111111111111111111")
+
+	expectStats := func(used, trashed int64) {
+		t.Helper()
+		stats, err := TS.c.Stats(ctx, &pb.StatsReq{})
+		if assert.NoError(t, err) {
+			assert.Equal(t, used, stats.GetUsedSpace())
+			assert.Equal(t, trashed, stats.GetTrashSpace())
+			assert.Equal(t, TS.s.totalAllocated-used-trashed, stats.GetAvailableSpace())
+		}
+	}
+
+	_, err := TS.c.Delete(ctx, &pb.PieceDelete{Id: "11111111111111111111", Authorization: TS.authorization})
+	assert.NoError(t, err)
+	_, err = TS.s.DB.GetBlobRef(id)
+	assert.Equal(t, sql.ErrNoRows, err)
+	expectStats(0, 5)
+
+	resp, err := TS.c.RestoreTrash(ctx, &pb.RestoreTrashRequest{Authorization: TS.authorization})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(1), resp.GetRestored())
+	}
+	expectStats(5, 0)
+
+	blob, err := TS.s.loadPiece(ctx, id)
+	if assert.NoError(t, err) {
+		_ = blob.Close()
+	}
+
+	_, err = TS.c.Delete(ctx, &pb.PieceDelete{Id: "11111111111111111111", Authorization: TS.authorization})
+	assert.NoError(t, err)
+
+	// the piece is kept for the retention
+	deleted, err := NewTrashCollector(TS.s, time.Hour, time.Hour).Collect(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	deleted, err = NewTrashCollector(TS.s, time.Hour, -time.Second).Collect(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	expectStats(0, 0)
+
+	resp, err = TS.c.RestoreTrash(ctx, &pb.RestoreTrashRequest{Authorization: TS.authorization})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(0), resp.GetRestored())
+	}
+
+	// an uplink holding the authorization of another satellite can't restore its trash
+	_, err = TS.c.RestoreTrash(ctx, &pb.RestoreTrashRequest{Authorization: authorize(t, newSatellite(t))})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "restored by")
+	}
+
+	// the satellite has to be trusted
+	TS.s.trust, err = trust.Parse(newSatellite(t).ID.String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = TS.c.RestoreTrash(ctx, &pb.RestoreTrashRequest{Authorization: TS.authorization})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is not trusted")
+	}
+}
+
+func TestRetain(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	satellite := string(TS.identity.ID)
+	old := time.Now().Add(-time.Hour).Unix()
+
+	pieces := []struct {
+		id        string
+		pieceID   string
+		satellite string
+		created   int64
+		retained  bool
+	}{
+		{id: "11111111111111111111", pieceID: "live", satellite: satellite, created: old, retained: true},
+		{id: "22222222222222222222", pieceID: "garbage", satellite: satellite, created: old, retained: false},
+		{id: "33333333333333333333", pieceID: "other", satellite: "other-satellite", created: old, retained: true},
+		{id: "44444444444444444444", pieceID: "new", satellite: satellite, created: time.Now().Unix(), retained: true},
+	}
+
+	for _, piece := range pieces {
+		if !assert.NoError(t, writePiece(TS.s, piece.id)) {
+			return
+		}
+		_, err := TS.s.DB.DB.Exec(`INSERT INTO ttl (id, created, expires, size) VALUES (?, ?, 0, 5)`, piece.id, piece.created)
+		assert.NoError(t, err)
+		assert.NoError(t, TS.s.DB.AddPieceInfo(psdb.PieceInfo{ID: piece.id, PieceID: piece.pieceID, Satellite: piece.satellite}))
+	}
+
+	// a piece stored before the satellite of a piece was recorded
+	untracked := "55555555555555555555"
+	if !assert.NoError(t, writePiece(TS.s, untracked)) {
+		return
+	}
+	_, err := TS.s.DB.DB.Exec(`INSERT INTO ttl (id, created, expires, size) VALUES (?, ?, 0, 5)`, untracked, old)
+	assert.NoError(t, err)
+
+	filter := bloomfilter.NewOptimal(1, 0.0001)
+	filter.Add([]byte("live"))
+
+	resp, err := TS.c.Retain(ctx, &pb.RetainRequest{
+		Filter:               filter.Bytes(),
+		CreatedBeforeUnixSec: time.Now().Add(-time.Minute).Unix(),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int64(2), resp.GetDeleted())
+
+	for _, piece := range pieces {
+		_, err := TS.s.DB.GetBlobRef(piece.id)
+		if piece.retained {
+			assert.NoError(t, err, piece.pieceID)
+		} else {
+			assert.Equal(t, sql.ErrNoRows, err, piece.pieceID)
+		}
+	}
+	_, err = TS.s.DB.GetBlobRef(untracked)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	// the untracked piece is in the trash of the satellite
+	restored, err := TS.s.DB.RestoreTrash(satellite)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), restored)
+	_, err = TS.s.DB.GetBlobRef(untracked)
+	assert.NoError(t, err)
+
+	_, err = TS.c.Retain(ctx, &pb.RetainRequest{Filter: []byte("invalid")})
+	assert.Error(t, err)
+}
+
+func TestProve(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	payer := func(action pb.PayerBandwidthAllocation_Action) *pb.PayerBandwidthAllocation {
+		return TS.payer(t, "11111111111111111111", &pb.PayerBandwidthAllocation_Data{Action: action})
+	}
+
+	const shareSize = 16
+	content := make([]byte, 4*shareSize)
+	_, err := rand.Read(content)
+	assert.NoError(t, err)
+	assert.NoError(t, storePiece(TS, nil, payer(pb.PayerBandwidthAllocation_PUT), "11111111111111111111", content))
+
+	hasher := merkle.NewShareHasher(shareSize)
+	_, _ = hasher.Write(content)
+	root := hasher.Root()
+
+	prove := func(action pb.PayerBandwidthAllocation_Action, index int64, total int64) (*pb.PieceProofSummary, error) {
+		alloc := &pb.RenterBandwidthAllocation{
+			Data: serializeData(&pb.RenterBandwidthAllocation_Data{
+				PayerAllocation: payer(action),
+				Total:           total,
+			}),
+		}
+		alloc.Signature, err = cryptopasta.Sign(alloc.Data, TS.k.(*ecdsa.PrivateKey))
+		assert.NoError(t, err)
+		return TS.c.Prove(ctx, &pb.PieceProof{
+			Id:                  "11111111111111111111",
+			ShareSize:           shareSize,
+			ShareIndex:          index,
+			Bandwidthallocation: alloc,
+			Authorization:       TS.authorization,
+		})
+	}
+
+	resp, err := prove(pb.PayerBandwidthAllocation_GET_AUDIT, 2, shareSize)
+	if assert.NoError(t, err) {
+		assert.Equal(t, content[2*shareSize:3*shareSize], resp.GetShare())
+		assert.True(t, merkle.Verify(root, merkle.Leaf(resp.GetShare()), 2, 4, resp.GetProof()))
+	}
+
+	for _, tt := range []struct {
+		action    pb.PayerBandwidthAllocation_Action
+		index     int64
+		total     int64
+		errString string
+	}{
+		{pb.PayerBandwidthAllocation_PUT, 2, shareSize, "wrong allocation action"},
+		{pb.PayerBandwidthAllocation_GET_AUDIT, 2, shareSize - 1, "allocated 15 bytes for a share of 16 bytes"},
+		{pb.PayerBandwidthAllocation_GET_AUDIT, 4, shareSize, "share 4 is beyond the piece of 64 bytes"},
+	} {
+		_, err := prove(tt.action, tt.index, tt.total)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), tt.errString)
+		}
+	}
+
+	stats, err := TS.c.Stats(ctx, &pb.StatsReq{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(shareSize), stats.UsedGetBandwidth)
+	}
+
+	// the satellite is told when the piece is missing
+	_, err = TS.c.Delete(ctx, &pb.PieceDelete{Id: "11111111111111111111", Authorization: TS.authorization})
+	assert.NoError(t, err)
+	_, err = prove(pb.PayerBandwidthAllocation_GET_AUDIT, 2, shareSize)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// newSatellite creates the identity of a satellite signing authorizations and allocations
+func newSatellite(t *testing.T) *provider.FullIdentity {
+	ca, err := provider.NewTestCA(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity, err := ca.NewIdentity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return identity
+}
+
+// authorize returns the authorization of the satellite
+func authorize(t *testing.T, satellite *provider.FullIdentity) *pb.SignedMessage {
+	signature, err := auth.GenerateSignature(satellite.ID.Bytes(), satellite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authorization, err := auth.NewSignedMessage(signature, satellite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return authorization
+}
+
+// signPayer returns the allocation signed by the satellite, which pays for it unless specified otherwise
+func signPayer(t *testing.T, satellite *provider.FullIdentity, pbad *pb.PayerBandwidthAllocation_Data) *pb.PayerBandwidthAllocation {
+	if pbad.SatelliteId == nil {
+		pbad.SatelliteId = satellite.ID.Bytes()
+	}
+	data, err := proto.Marshal(pbad)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := auth.GenerateSignature(data, satellite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &pb.PayerBandwidthAllocation{Signature: signature, Data: data, Certs: satellite.ChainRaw()}
+}
+
+// storePiece uploads content authorized and paid for by the specified satellites,
+// the client is the satellite authorizing the upload if authorization is nil
+func storePiece(TS *TestServer, authorization *pb.SignedMessage, payer *pb.PayerBandwidthAllocation, id string, content []byte) error {
+	alloc, err := renterAllocation(TS, payer, int64(len(content)))
+	if err != nil {
+		return err
+	}
+	return storeMessages(TS, authorization, id, &pb.PieceStore{
+		Piecedata:           &pb.PieceStore_PieceData{Content: content},
+		Bandwidthallocation: alloc,
+	})
+}
+
+// storeMessages sends the messages of an upload of the piece after the authorization,
+// the client is the satellite authorizing the upload if authorization is nil
+func storeMessages(TS *TestServer, authorization *pb.SignedMessage, id string, messages ...*pb.PieceStore) error {
+	stream, err := TS.c.Store(ctx)
+	if err != nil {
+		return err
+	}
+
+	if authorization == nil {
+		authorization = TS.authorization
+	}
+
+	err = stream.Send(&pb.PieceStore{Piecedata: &pb.PieceStore_PieceData{Id: id}, Authorization: authorization})
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range messages {
+		// the server may have already closed the stream
+		if err := stream.Send(msg); err != nil {
+			break
+		}
+	}
+
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+// renterAllocation returns the allocation of total bytes of the payer allocation signed by the client
+func renterAllocation(TS *TestServer, payer *pb.PayerBandwidthAllocation, total int64) (*pb.RenterBandwidthAllocation, error) {
+	alloc := &pb.RenterBandwidthAllocation{
+		Data: serializeData(&pb.RenterBandwidthAllocation_Data{
+			PayerAllocation: payer,
+			Total:           total,
+		}),
+	}
+	signature, err := cryptopasta.Sign(alloc.Data, TS.k.(*ecdsa.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+	alloc.Signature = signature
+	return alloc, nil
+}
+
 func newTestServerStruct(t *testing.T) (*Server, func()) {
 	tmp, err := ioutil.TempDir("", "storj-piecestore")
 	if err != nil {
@@ -487,15 +1563,22 @@ func newTestServerStruct(t *testing.T) (*Server, func()) {
 	tempDBPath := filepath.Join(tmp, "test.db")
 	tempDir := filepath.Join(tmp, "test-data", "3000")
 
-	psDB, err := psdb.Open(ctx, tempDir, tempDBPath)
+	blobs, err := filestore.NewAt(tempDir)
+	if err != nil {
+		t.Fatalf("failed open filestore: %v", err)
+	}
+
+	psDB, err := psdb.Open(ctx, blobs, tempDBPath)
 	if err != nil {
 		t.Fatalf("failed open psdb: %v", err)
 	}
 
-	verifier := func(authorization *pb.SignedMessage) error {
-		return nil
+	pkey, err := cryptopasta.NewSigningKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
 	}
-	server := &Server{DataDir: tempDir, DB: psDB, verifier: verifier}
+
+	server := &Server{DataDir: tempDir, Blobs: blobs, DB: psDB, pkey: pkey, totalAllocated: 1 << 30, verifier: auth.NewSignedMessageVerifier()}
 	return server, func() {
 		if serr := server.Stop(ctx); serr != nil {
 			t.Fatal(serr)
@@ -526,6 +1609,9 @@ type TestServer struct {
 	conn     *grpc.ClientConn
 	c        pb.PieceStoreRoutesClient
 	k        crypto.PrivateKey
+	identity *provider.FullIdentity
+	// authorization of the client acting as the satellite
+	authorization *pb.SignedMessage
 }
 
 func NewTestServer(t *testing.T) *TestServer {
@@ -550,17 +1636,59 @@ func NewTestServer(t *testing.T) *TestServer {
 	check(err)
 
 	s, cleanup := newTestServerStruct(t)
+	// the storage node signs with the key of its identity
+	s.pkey, s.id = fiS.Key, fiS.ID
 	grpcs := grpc.NewServer(so)
 
 	k, ok := fiC.Key.(*ecdsa.PrivateKey)
 	assert.True(t, ok)
-	ts := &TestServer{s: s, scleanup: cleanup, grpcs: grpcs, k: k}
+	ts := &TestServer{s: s, scleanup: cleanup, grpcs: grpcs, k: k, identity: fiC, authorization: authorize(t, fiC)}
 	addr := ts.start()
 	ts.c, ts.conn = connect(addr, co)
 
 	return ts
 }
 
+// orderLimit fills in the storage node, the piece, the size limit, the uplink, a unique serial number
+// and the expiration of the allocation for the piece unless set
+func (TS *TestServer) orderLimit(pieceID string, pbad *pb.PayerBandwidthAllocation_Data) *pb.PayerBandwidthAllocation_Data {
+	if pbad.SerialNumber == "" {
+		var serialNumber [16]byte
+		_, _ = rand.Read(serialNumber[:])
+		pbad.SerialNumber = hex.EncodeToString(serialNumber[:])
+	}
+	if pbad.ExpirationUnixSec == 0 {
+		pbad.ExpirationUnixSec = time.Now().Add(time.Hour).Unix()
+	}
+	if pbad.UplinkPublicKey == nil {
+		pbad.UplinkPublicKey, _ = x509.MarshalPKIXPublicKey(&TS.k.(*ecdsa.PrivateKey).PublicKey)
+	}
+	if pbad.StorageNodeId == nil {
+		pbad.StorageNodeId = TS.s.id.Bytes()
+	}
+	if pbad.PieceId == "" {
+		pbad.PieceId = pieceID
+	}
+	if pbad.MaxSize == 0 {
+		pbad.MaxSize = 1 << 20
+	}
+	return pbad
+}
+
+// payer returns the order limit for the piece signed by the client acting as the satellite
+func (TS *TestServer) payer(t *testing.T, pieceID string, pbad *pb.PayerBandwidthAllocation_Data) *pb.PayerBandwidthAllocation {
+	return signPayer(t, TS.identity, TS.orderLimit(pieceID, pbad))
+}
+
+// pieceID returns the id the piece uploaded by the client is stored with
+func (TS *TestServer) pieceID(t *testing.T, id string) string {
+	namespaced, err := getNamespacedPieceID([]byte(id), getNamespace(TS.authorization))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return namespaced
+}
+
 func (TS *TestServer) start() (addr string) {
 	lis, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
@@ -5,21 +5,27 @@ package psserver
 
 import (
 	"context"
+	"crypto/sha256"
 	"io"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
 	"storj.io/storj/pkg/pb"
-	"storj.io/storj/pkg/piecestore"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
 	"storj.io/storj/pkg/utils"
 )
 
 // OK - Success!
 const OK = "OK"
 
-// StoreError is a type of error for failures in Server.Store()
-var StoreError = errs.Class("store error")
+var (
+	// StoreError is a type of error for failures in Server.Store()
+	StoreError = errs.Class("store error")
+
+	// ErrNotEnoughSpace is returned when a piece doesn't fit into the allocated disk space
+	ErrNotEnoughSpace = errs.Class("not enough disk space")
+)
 
 // Store incoming data using piecestore
 func (s *Server) Store(reqStream pb.PieceStoreRoutes_StoreServer) (err error) {
@@ -39,6 +45,11 @@ func (s *Server) Store(reqStream pb.PieceStoreRoutes_StoreServer) (err error) {
 		return ServerError.Wrap(err)
 	}
 
+	satellite := string(getNamespace(authorization))
+	if err := s.verifyTrusted(satellite); err != nil {
+		return err
+	}
+
 	pd := recv.GetPiecedata()
 	if pd == nil {
 		return StoreError.New("PieceStore message is nil")
@@ -54,58 +65,156 @@ func (s *Server) Store(reqStream pb.PieceStoreRoutes_StoreServer) (err error) {
 	if err != nil {
 		return err
 	}
-	total, err := s.storeData(ctx, reqStream, id)
+	if err := validatePieceID(id); err != nil {
+		return err
+	}
+
+	// reject the upload before receiving any data when the allocation is used up
+	available, err := s.satelliteAvailableSpace(satellite)
+	if err != nil {
+		return StoreError.Wrap(err)
+	}
+	if available <= 0 {
+		return ErrNotEnoughSpace.New("%d bytes available", available)
+	}
+
+	bandwidth, err := s.satelliteAvailableBandwidth(satellite)
+	if err != nil {
+		return StoreError.Wrap(err)
+	}
+	if bandwidth <= 0 {
+		return QuotaError.New("bandwidth share of satellite exceeded")
+	}
+
+	// the space is reserved while receiving the piece, so concurrent uploads can't exceed the available space together
+	reservation := &spaceReservation{server: s, satellite: satellite}
+	defer reservation.release()
+
+	total, hash, err := s.storeData(ctx, reqStream, pd.GetId(), id, satellite, reservation, bandwidth)
 	if err != nil {
 		return err
 	}
 
 	if err = s.DB.AddTTL(id, pd.GetExpirationUnixSec(), total); err != nil {
-		deleteErr := s.deleteByID(id)
+		deleteErr := s.deleteByID(ctx, id)
 		return StoreError.New("failed to write piece meta data to database: %v", utils.CombineErrors(err, deleteErr))
 	}
 
-	if err = s.DB.AddBandwidthUsed(total); err != nil {
+	if err = s.DB.AddPieceHash(id, hash); err != nil {
+		deleteErr := s.deleteByID(ctx, id)
+		return StoreError.New("failed to write piece hash to database: %v", utils.CombineErrors(err, deleteErr))
+	}
+
+	if err = s.DB.AddBandwidthUsed(pb.PayerBandwidthAllocation_PUT, total); err != nil {
 		return StoreError.New("failed to write bandwidth info to database: %v", err)
 	}
+
+	if err = s.DB.AddSatelliteBandwidthUsed(satellite, pb.PayerBandwidthAllocation_PUT, total); err != nil {
+		return StoreError.New("failed to write bandwidth info to database: %v", err)
+	}
+
+	signedHash, err := s.signPieceHash(pd.GetId(), hash, total)
+	if err != nil {
+		return StoreError.Wrap(err)
+	}
 	zap.S().Infof("Successfully stored %s.", pd.GetId())
 
-	return reqStream.SendAndClose(&pb.PieceStoreSummary{Message: OK, TotalReceived: total})
+	return reqStream.SendAndClose(&pb.PieceStoreSummary{Message: OK, TotalReceived: total, Hash: signedHash})
 }
 
-func (s *Server) storeData(ctx context.Context, stream pb.PieceStoreRoutes_StoreServer, id string) (total int64, err error) {
+func (s *Server) storeData(ctx context.Context, stream pb.PieceStoreRoutes_StoreServer, pieceID, id, namespace string, reservation *spaceReservation, bandwidth int64) (total int64, hash []byte, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	// Delete data if we error
 	defer func() {
 		if err != nil && err != io.EOF {
-			if deleteErr := s.deleteByID(id); deleteErr != nil {
+			if deleteErr := s.deleteByID(ctx, id); deleteErr != nil {
 				zap.S().Errorf("Failed on deleteByID in Store: %s", deleteErr.Error())
 			}
 		}
 	}()
 
-	// Initialize file for storing data
-	storeFile, err := pstore.StoreWriter(id, s.DataDir)
-	if err != nil {
-		return 0, err
-	}
-
-	defer utils.LogClose(storeFile)
-
-	reader := NewStreamReader(s, stream)
+	reader := NewStreamReader(s, stream, pieceID, namespace)
 
 	defer func() {
+		// nothing is paid for when no allocation was received
+		if reader.bandwidthAllocation == nil {
+			return
+		}
 		baWriteErr := s.DB.WriteBandwidthAllocToDB(reader.bandwidthAllocation)
 		if baWriteErr != nil {
 			zap.S().Errorf("Error while writing Bandwidth Alloc to DB: %s\n", baWriteErr.Error())
 		}
 	}()
 
-	total, err = io.Copy(storeFile, reader)
+	hasher := sha256.New()
+	reserving := &reservingReader{reader: reader, reservation: reservation}
+	limited := &bandwidthLimitedReader{reader: reserving, remaining: bandwidth}
+	counter := &countingReader{reader: io.TeeReader(limited, hasher)}
+
+	ref, err := s.Blobs.Store(ctx, counter, -1)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// every upload has to be paid for with a verified allocation, even an empty one
+	if reader.bandwidthAllocation == nil {
+		return 0, nil, utils.CombineErrors(ErrOrderLimit.New("no allocation received"), s.Blobs.Delete(ctx, ref))
+	}
 
-	if err != nil && err != io.EOF {
-		return 0, err
+	if err = s.DB.AddBlobRef(id, ref); err != nil {
+		return 0, nil, utils.CombineErrors(err, s.Blobs.Delete(ctx, ref))
 	}
 
-	return total, nil
+	// remember the satellite, so it can garbage collect the piece later
+	err = s.DB.AddPieceInfo(psdb.PieceInfo{ID: id, PieceID: pieceID, Satellite: namespace})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return counter.total, hasher.Sum(nil), nil
+}
+
+// countingReader counts the number of bytes read through it
+type countingReader struct {
+	reader io.Reader
+	total  int64
+}
+
+// Read implements io.Reader
+func (r *countingReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	r.total += int64(n)
+	return n, err
+}
+
+// reservingReader reserves the space for the bytes read through it
+type reservingReader struct {
+	reader      io.Reader
+	reservation *spaceReservation
+}
+
+// Read implements io.Reader
+func (r *reservingReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	if reserveErr := r.reservation.use(int64(n)); reserveErr != nil {
+		return n, reserveErr
+	}
+	return n, err
+}
+
+// bandwidthLimitedReader fails when more than the remaining bandwidth is read through it
+type bandwidthLimitedReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+// Read implements io.Reader
+func (r *bandwidthLimitedReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n, QuotaError.New("bandwidth share of satellite exceeded, piece exceeds the available bandwidth")
+	}
+	return n, err
 }
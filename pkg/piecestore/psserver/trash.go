@@ -0,0 +1,97 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/provider"
+)
+
+// TrashError is a type of error for failures in Server.RestoreTrash()
+var TrashError = errs.Class("trash error")
+
+// RestoreTrash brings back the deleted pieces of the calling satellite that haven't been purged yet.
+// Uplinks are handed the authorization of the satellite, so only the satellite connecting itself may restore.
+func (s *Server) RestoreTrash(ctx context.Context, in *pb.RestoreTrashRequest) (_ *pb.RestoreTrashSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pi, err := provider.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, TrashError.Wrap(err)
+	}
+	satellite := string(pi.ID)
+
+	authorization := in.GetAuthorization()
+	if err := s.verifier(authorization); err != nil {
+		return nil, TrashError.Wrap(err)
+	}
+	if namespace := string(getNamespace(authorization)); namespace != satellite {
+		return nil, TrashError.New("trash of satellite %q restored by %q", namespace, satellite)
+	}
+
+	if err := s.verifyTrusted(satellite); err != nil {
+		return nil, err
+	}
+
+	zap.S().Infof("Restoring trash of %s...", satellite)
+
+	restored, err := s.DB.RestoreTrash(satellite)
+	if err != nil {
+		return nil, TrashError.Wrap(err)
+	}
+
+	zap.S().Infof("Restored %d pieces of %s.", restored, satellite)
+
+	return &pb.RestoreTrashSummary{Restored: restored}, nil
+}
+
+// TrashCollector purges the pieces that have been in the trash longer than the retention
+type TrashCollector struct {
+	server    *Server
+	retention time.Duration
+	ticker    *time.Ticker
+}
+
+// NewTrashCollector creates a TrashCollector checking the trash every interval
+func NewTrashCollector(server *Server, interval, retention time.Duration) *TrashCollector {
+	return &TrashCollector{
+		server:    server,
+		retention: retention,
+		ticker:    time.NewTicker(interval),
+	}
+}
+
+// Run the trash collection loop
+func (collector *TrashCollector) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		if _, err := collector.Collect(ctx); err != nil {
+			zap.S().Errorf("Emptying the trash failed: %v", err)
+		}
+
+		select {
+		case <-collector.ticker.C: // wait for the next interval to happen
+		case <-ctx.Done(): // or the collector is canceled via context
+			return ctx.Err()
+		}
+	}
+}
+
+// Collect deletes the pieces trashed more than the retention ago
+func (collector *TrashCollector) Collect(ctx context.Context) (deleted int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	deleted, err = collector.server.DB.EmptyTrash(ctx, time.Now().Add(-collector.retention))
+	if deleted > 0 {
+		zap.S().Infof("Purged %d pieces from the trash.", deleted)
+	}
+	return deleted, err
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package trust
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+// Error is the default trust error class
+var Error = errs.Class("trust error")
+
+// List contains the satellites trusted by the storage node
+type List struct {
+	addresses map[string]string // satellite id to address, empty when unknown
+}
+
+// Parse parses a comma-separated list of <satellite-id> or <satellite-id>@<address> entries.
+// An empty list trusts every satellite.
+func Parse(list string) (*List, error) {
+	trust := &List{addresses: map[string]string{}}
+	if strings.TrimSpace(list) == "" {
+		return trust, nil
+	}
+
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		parts := strings.SplitN(entry, "@", 2)
+		if parts[0] == "" {
+			return nil, Error.New("invalid satellite %q", entry)
+		}
+
+		var address string
+		if len(parts) == 2 {
+			address = parts[1]
+			if address == "" {
+				return nil, Error.New("invalid satellite address %q", entry)
+			}
+		}
+		trust.addresses[parts[0]] = address
+	}
+	return trust, nil
+}
+
+// All returns whether every satellite is trusted
+func (trust *List) All() bool {
+	return trust == nil || len(trust.addresses) == 0
+}
+
+// IsTrusted returns whether the satellite is trusted
+func (trust *List) IsTrusted(satellite string) bool {
+	if trust.All() {
+		return true
+	}
+	_, ok := trust.addresses[satellite]
+	return ok
+}
+
+// Address returns the configured address of the satellite or an empty string when unknown
+func (trust *List) Address(satellite string) string {
+	if trust == nil {
+		return ""
+	}
+	return trust.addresses[satellite]
+}
+
+// Satellites returns the ids of the trusted satellites
+func (trust *List) Satellites() []string {
+	if trust == nil {
+		return nil
+	}
+
+	satellites := make([]string, 0, len(trust.addresses))
+	for satellite := range trust.addresses {
+		satellites = append(satellites, satellite)
+	}
+	sort.Strings(satellites)
+	return satellites
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package trust
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	for _, tt := range []struct {
+		list       string
+		satellites []string
+		addresses  map[string]string
+		err        string
+	}{
+		{ // trust every satellite
+			list:       "",
+			satellites: []string{},
+		},
+		{ // ids with and without addresses
+			list:       "satellite-b@127.0.0.1:7777, satellite-a",
+			satellites: []string{"satellite-a", "satellite-b"},
+			addresses:  map[string]string{"satellite-a": "", "satellite-b": "127.0.0.1:7777"},
+		},
+		{ // missing id
+			list: "@127.0.0.1:7777",
+			err:  `trust error: invalid satellite "@127.0.0.1:7777"`,
+		},
+		{ // missing address
+			list: "satellite-a@",
+			err:  `trust error: invalid satellite address "satellite-a@"`,
+		},
+	} {
+		trust, err := Parse(tt.list)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err)
+			continue
+		}
+		if !assert.NoError(t, err) {
+			continue
+		}
+
+		assert.Equal(t, tt.satellites, trust.Satellites())
+		assert.Equal(t, len(tt.satellites) == 0, trust.All())
+		for satellite, address := range tt.addresses {
+			assert.True(t, trust.IsTrusted(satellite))
+			assert.Equal(t, address, trust.Address(satellite))
+		}
+		assert.Equal(t, trust.All(), trust.IsTrusted("satellite-c"))
+	}
+
+	var none *List
+	assert.True(t, none.IsTrusted("satellite-a"))
+	assert.Equal(t, "", none.Address("satellite-a"))
+}
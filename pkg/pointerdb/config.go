@@ -5,12 +5,16 @@ package pointerdb
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
 
+	"storj.io/storj/pkg/accounting"
+	"storj.io/storj/pkg/bwagreement"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/uplinkdb"
 	"storj.io/storj/pkg/utils"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/boltdb"
@@ -34,6 +38,9 @@ type Config struct {
 	MinRemoteSegmentSize int    `default:"1240" help:"minimum remote segment size"`
 	MaxInlineSegmentSize int    `default:"8000" help:"maximum inline segment size"`
 	Overlay              bool   `default:"false" help:"toggle flag if overlay is enabled"`
+
+	AllocationExpiration time.Duration `default:"168h" help:"how long bandwidth allocations can be used and claimed"`
+	MaxOrderLimit        int64         `default:"64000000" help:"maximum number of bytes a single order limit allows to transfer to or from a storage node"`
 }
 
 func newKeyValueStore(dbURLString string) (db storage.KeyValueStore, err error) {
@@ -60,8 +67,23 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) error {
 	defer func() { _ = db.Close() }()
 
 	cache := overlay.LoadFromContext(ctx)
+	keys := uplinkdb.LoadFromContext(ctx)
+	if keys == nil {
+		return Error.New("uplinkdb not found in context")
+	}
+	// without the accounting database the usage of the buckets isn't kept and projects aren't limited,
+	// without the bandwidth agreements only their storage is
+	var usage Usage
+	if accountingDB := accounting.LoadFromContext(ctx); accountingDB != nil {
+		var egress accounting.Egress
+		if agreements := bwagreement.LoadFromContext(ctx); agreements != nil {
+			egress = agreements
+		}
+		usage = accounting.NewUsage(accountingDB, egress)
+	}
+
 	dblogged := storelogger.New(zap.L(), db)
-	s := NewServer(dblogged, cache, zap.L(), c, server.Identity())
+	s := NewServer(dblogged, cache, keys, usage, zap.L(), c, server.Identity())
 	pb.RegisterPointerDBServer(server.GRPC(), s)
 	// add the server to the context
 	ctx = context.WithValue(ctx, ctxKey, s)
@@ -14,6 +14,7 @@ import (
 
 	"storj.io/storj/pkg/auth/grpcauth"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/storage"
@@ -27,7 +28,6 @@ var (
 type PointerDB struct {
 	client        pb.PointerDBClient
 	authorization *pb.SignedMessage
-	pba           *pb.PayerBandwidthAllocation
 }
 
 // New Used as a public function
@@ -48,12 +48,13 @@ type ListItem struct {
 // Client services offerred for the interface
 type Client interface {
 	Put(ctx context.Context, path storj.Path, pointer *pb.Pointer) error
-	Get(ctx context.Context, path storj.Path) (*pb.Pointer, []*pb.Node, error)
+	Get(ctx context.Context, path storj.Path) (*pb.Pointer, []*pb.Node, []*pb.PayerBandwidthAllocation, error)
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
 	Delete(ctx context.Context, path storj.Path) error
 
 	SignedMessage() *pb.SignedMessage
-	PayerBandwidthAllocation() *pb.PayerBandwidthAllocation
+	PayerBandwidthAllocation(ctx context.Context, action pb.PayerBandwidthAllocation_Action, bucket string) (*pb.PayerBandwidthAllocation, error)
+	OrderLimits(ctx context.Context, action pb.PayerBandwidthAllocation_Action, bucket string, pieceID psclient.PieceID, nodes []*pb.Node, maxSize int64) ([]*pb.PayerBandwidthAllocation, error)
 
 	// Disconnect() error // TODO: implement
 }
@@ -82,26 +83,29 @@ func (pdb *PointerDB) Put(ctx context.Context, path storj.Path, pointer *pb.Poin
 	defer mon.Task()(&ctx)(&err)
 
 	_, err = pdb.client.Put(ctx, &pb.PutRequest{Path: path, Pointer: pointer})
+	if status.Code(err) == codes.ResourceExhausted {
+		return storj.ErrStorageLimit.Wrap(err)
+	}
 
 	return err
 }
 
-// Get is the interface to make a GET request, needs PATH and APIKey
-func (pdb *PointerDB) Get(ctx context.Context, path storj.Path) (pointer *pb.Pointer, nodes []*pb.Node, err error) {
+// Get is the interface to make a GET request, needs PATH and APIKey. The order limits
+// for downloading the pieces are in the order of the remote pieces of the pointer.
+func (pdb *PointerDB) Get(ctx context.Context, path storj.Path) (pointer *pb.Pointer, nodes []*pb.Node, limits []*pb.PayerBandwidthAllocation, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	res, err := pdb.client.Get(ctx, &pb.GetRequest{Path: path})
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			return nil, nil, storage.ErrKeyNotFound.Wrap(err)
+			return nil, nil, nil, storage.ErrKeyNotFound.Wrap(err)
 		}
-		return nil, nil, Error.Wrap(err)
+		return nil, nil, nil, Error.Wrap(err)
 	}
 
-	pdb.pba = res.GetPba()
 	pdb.authorization = res.GetAuthorization()
 
-	return res.GetPointer(), res.GetNodes(), nil
+	return res.GetPointer(), res.GetNodes(), res.GetOrderLimits(), nil
 }
 
 // List is the interface to make a LIST request, needs StartingPathKey, Limit, and APIKey
@@ -147,7 +151,62 @@ func (pdb *PointerDB) SignedMessage() *pb.SignedMessage {
 	return pdb.authorization
 }
 
-// PayerBandwidthAllocation gets payer bandwidth allocation message from last get request
-func (pdb *PointerDB) PayerBandwidthAllocation() *pb.PayerBandwidthAllocation {
-	return pdb.pba
+// PayerBandwidthAllocation requests a new payer bandwidth allocation for uploading or downloading,
+// every transfer needs its own because its serial number can only be used once. Uploads and downloads
+// are accounted to the bucket
+func (pdb *PointerDB) PayerBandwidthAllocation(ctx context.Context, action pb.PayerBandwidthAllocation_Action, bucket string) (pba *pb.PayerBandwidthAllocation, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	res, err := pdb.client.PayerBandwidthAllocation(ctx, &pb.PayerBandwidthAllocationRequest{Action: action, Bucket: bucket})
+	if err != nil {
+		return nil, allocationError(action, err)
+	}
+
+	return res.GetPba(), nil
+}
+
+// OrderLimits requests an order limit for transferring at most maxSize bytes of the piece to or from
+// each of the nodes, the returned limits are in the order of the nodes and nil for missing nodes.
+// Uploads and downloads are accounted to the bucket
+func (pdb *PointerDB) OrderLimits(ctx context.Context, action pb.PayerBandwidthAllocation_Action, bucket string, pieceID psclient.PieceID, nodes []*pb.Node, maxSize int64) (limits []*pb.PayerBandwidthAllocation, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	req := &pb.OrderLimitsRequest{Action: action, PieceId: pieceID.String(), MaxSize: maxSize, Bucket: bucket}
+	for _, n := range nodes {
+		if n != nil {
+			req.NodeIds = append(req.NodeIds, n.GetId())
+		}
+	}
+
+	res, err := pdb.client.OrderLimits(ctx, req)
+	if err != nil {
+		return nil, allocationError(action, err)
+	}
+	if len(res.GetOrderLimits()) != len(req.NodeIds) {
+		return nil, Error.New("expected %d order limits got %d", len(req.NodeIds), len(res.GetOrderLimits()))
+	}
+
+	limits = make([]*pb.PayerBandwidthAllocation, len(nodes))
+	next := 0
+	for i, n := range nodes {
+		if n != nil {
+			limits[i] = res.GetOrderLimits()[next]
+			next++
+		}
+	}
+	return limits, nil
+}
+
+// allocationError wraps the error of requesting bandwidth for the action, the satellite refuses
+// uploads once the project reached its storage limit and downloads once it reached its egress limit
+func allocationError(action pb.PayerBandwidthAllocation_Action, err error) error {
+	if status.Code(err) == codes.ResourceExhausted {
+		switch action {
+		case pb.PayerBandwidthAllocation_PUT:
+			return storj.ErrStorageLimit.Wrap(err)
+		case pb.PayerBandwidthAllocation_GET:
+			return storj.ErrEgressLimit.Wrap(err)
+		}
+	}
+	return Error.Wrap(err)
 }
@@ -144,7 +144,7 @@ func TestGet(t *testing.T) {
 		err = proto.Unmarshal(byteData, ptr)
 		assert.NoError(t, err)
 
-		getResponse := pb.GetResponse{Pointer: ptr, Nodes: []*pb.Node{}}
+		getResponse := pb.GetResponse{Pointer: ptr, Nodes: []*pb.Node{}, OrderLimits: []*pb.PayerBandwidthAllocation{{}}}
 
 		errTag := fmt.Sprintf("Test case #%d", i)
 
@@ -153,15 +153,17 @@ func TestGet(t *testing.T) {
 
 		gc.EXPECT().Get(gomock.Any(), &getRequest).Return(&getResponse, tt.err)
 
-		pointer, nodes, err := pdb.Get(ctx, tt.path)
+		pointer, nodes, limits, err := pdb.Get(ctx, tt.path)
 
 		if err != nil {
 			assert.True(t, strings.Contains(err.Error(), tt.errString), errTag)
 			assert.Nil(t, pointer)
 			assert.Nil(t, nodes)
+			assert.Nil(t, limits)
 		} else {
 			assert.NotNil(t, pointer)
 			assert.NotNil(t, nodes)
+			assert.Len(t, limits, 1)
 			assert.NoError(t, err, errTag)
 		}
 	}
@@ -290,3 +292,39 @@ func TestDelete(t *testing.T) {
 		}
 	}
 }
+
+func TestOrderLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	gc := NewMockPointerDBClient(ctrl)
+	pdb := PointerDB{client: gc}
+
+	nodes := []*pb.Node{{Id: "node1"}, nil, {Id: "node3"}}
+	first := &pb.PayerBandwidthAllocation{Signature: []byte("first")}
+	second := &pb.PayerBandwidthAllocation{Signature: []byte("second")}
+
+	request := &pb.OrderLimitsRequest{
+		Action:  pb.PayerBandwidthAllocation_GET,
+		PieceId: "piece",
+		NodeIds: []string{"node1", "node3"},
+		MaxSize: 100,
+		Bucket:  "bucket",
+	}
+	gc.EXPECT().OrderLimits(gomock.Any(), request).Return(&pb.OrderLimitsResponse{
+		OrderLimits: []*pb.PayerBandwidthAllocation{first, second},
+	}, nil)
+
+	limits, err := pdb.OrderLimits(ctx, pb.PayerBandwidthAllocation_GET, "bucket", "piece", nodes, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.PayerBandwidthAllocation{first, nil, second}, limits)
+
+	// every node needs its own order limit
+	gc.EXPECT().OrderLimits(gomock.Any(), request).Return(&pb.OrderLimitsResponse{
+		OrderLimits: []*pb.PayerBandwidthAllocation{first},
+	}, nil)
+
+	_, err = pdb.OrderLimits(ctx, pb.PayerBandwidthAllocation_GET, "bucket", "piece", nodes, 100)
+	assert.Error(t, err)
+}
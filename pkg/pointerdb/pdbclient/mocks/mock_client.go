@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	pb "storj.io/storj/pkg/pb"
+	psclient "storj.io/storj/pkg/piecestore/psclient"
 	pdbclient "storj.io/storj/pkg/pointerdb/pdbclient"
 )
 
@@ -49,12 +50,13 @@ func (mr *MockClientMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
 }
 
 // Get mocks base method
-func (m *MockClient) Get(arg0 context.Context, arg1 string) (*pb.Pointer, []*pb.Node, error) {
+func (m *MockClient) Get(arg0 context.Context, arg1 string) (*pb.Pointer, []*pb.Node, []*pb.PayerBandwidthAllocation, error) {
 	ret := m.ctrl.Call(m, "Get", arg0, arg1)
 	ret0, _ := ret[0].(*pb.Pointer)
 	ret1, _ := ret[1].([]*pb.Node)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret2, _ := ret[2].([]*pb.PayerBandwidthAllocation)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
 }
 
 // Get indicates an expected call of Get
@@ -76,16 +78,30 @@ func (mr *MockClientMockRecorder) List(arg0, arg1, arg2, arg3, arg4, arg5, arg6
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockClient)(nil).List), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
+// OrderLimits mocks base method
+func (m *MockClient) OrderLimits(arg0 context.Context, arg1 pb.PayerBandwidthAllocation_Action, arg2 string, arg3 psclient.PieceID, arg4 []*pb.Node, arg5 int64) ([]*pb.PayerBandwidthAllocation, error) {
+	ret := m.ctrl.Call(m, "OrderLimits", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]*pb.PayerBandwidthAllocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrderLimits indicates an expected call of OrderLimits
+func (mr *MockClientMockRecorder) OrderLimits(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderLimits", reflect.TypeOf((*MockClient)(nil).OrderLimits), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // PayerBandwidthAllocation mocks base method
-func (m *MockClient) PayerBandwidthAllocation() *pb.PayerBandwidthAllocation {
-	ret := m.ctrl.Call(m, "PayerBandwidthAllocation")
+func (m *MockClient) PayerBandwidthAllocation(arg0 context.Context, arg1 pb.PayerBandwidthAllocation_Action, arg2 string) (*pb.PayerBandwidthAllocation, error) {
+	ret := m.ctrl.Call(m, "PayerBandwidthAllocation", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*pb.PayerBandwidthAllocation)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // PayerBandwidthAllocation indicates an expected call of PayerBandwidthAllocation
-func (mr *MockClientMockRecorder) PayerBandwidthAllocation() *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayerBandwidthAllocation", reflect.TypeOf((*MockClient)(nil).PayerBandwidthAllocation))
+func (mr *MockClientMockRecorder) PayerBandwidthAllocation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayerBandwidthAllocation", reflect.TypeOf((*MockClient)(nil).PayerBandwidthAllocation), arg0, arg1, arg2)
 }
 
 // Put mocks base method
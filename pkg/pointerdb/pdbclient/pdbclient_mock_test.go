@@ -104,6 +104,42 @@ func (mr *MockPointerDBClientMockRecorder) ReverseList(arg0, arg1 interface{}, a
 	return mr.List(arg0, arg1, arg2...)
 }
 
+// OrderLimits mocks base method
+func (m *MockPointerDBClient) OrderLimits(arg0 context.Context, arg1 *pb.OrderLimitsRequest, arg2 ...grpc.CallOption) (*pb.OrderLimitsResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "OrderLimits", varargs...)
+	ret0, _ := ret[0].(*pb.OrderLimitsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrderLimits indicates an expected call of OrderLimits
+func (mr *MockPointerDBClientMockRecorder) OrderLimits(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderLimits", reflect.TypeOf((*MockPointerDBClient)(nil).OrderLimits), varargs...)
+}
+
+// PayerBandwidthAllocation mocks base method
+func (m *MockPointerDBClient) PayerBandwidthAllocation(arg0 context.Context, arg1 *pb.PayerBandwidthAllocationRequest, arg2 ...grpc.CallOption) (*pb.PayerBandwidthAllocationResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PayerBandwidthAllocation", varargs...)
+	ret0, _ := ret[0].(*pb.PayerBandwidthAllocationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayerBandwidthAllocation indicates an expected call of PayerBandwidthAllocation
+func (mr *MockPointerDBClientMockRecorder) PayerBandwidthAllocation(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayerBandwidthAllocation", reflect.TypeOf((*MockPointerDBClient)(nil).PayerBandwidthAllocation), varargs...)
+}
+
 // Put mocks base method
 func (m *MockPointerDBClient) Put(arg0 context.Context, arg1 *pb.PutRequest, arg2 ...grpc.CallOption) (*pb.PutResponse, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -5,10 +5,13 @@ package pointerdb
 
 import (
 	"context"
+	"crypto"
+	"crypto/rand"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/mr-tron/base58/base58"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -16,11 +19,15 @@ import (
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/node"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
 	pointerdbAuth "storj.io/storj/pkg/pointerdb/auth"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/storage/meta"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/uplinkdb"
 	"storj.io/storj/storage"
 )
 
@@ -29,22 +36,37 @@ var (
 	segmentError = errs.Class("segment error")
 )
 
+// Usage keeps the totals of the buckets up to date as segments are put and deleted
+// and checks the totals of projects against their limits
+type Usage interface {
+	Update(ctx context.Context, apiKeyHash []byte, bucket string, storedBytes, segments, objects int64) error
+	// CheckStorage returns a storj.ErrStorageLimit error once the project stores as much as it may
+	CheckStorage(ctx context.Context, apiKeyHash []byte) error
+	// CheckEgress returns a storj.ErrEgressLimit error once the project downloaded as much as it may this month
+	CheckEgress(ctx context.Context, apiKeyHash []byte) error
+}
+
 // Server implements the network state RPC service
 type Server struct {
 	DB       storage.KeyValueStore
 	logger   *zap.Logger
 	config   Config
 	cache    *overlay.Cache
+	keys     *uplinkdb.DB
+	usage    Usage
 	identity *provider.FullIdentity
 }
 
-// NewServer creates instance of Server
-func NewServer(db storage.KeyValueStore, cache *overlay.Cache, logger *zap.Logger, c Config, identity *provider.FullIdentity) *Server {
+// NewServer creates instance of Server, bandwidth is only allocated to uplinks with a key
+// registered in keys unless keys is nil, the usage of the buckets is kept in usage unless it is nil
+func NewServer(db storage.KeyValueStore, cache *overlay.Cache, keys *uplinkdb.DB, usage Usage, logger *zap.Logger, c Config, identity *provider.FullIdentity) *Server {
 	return &Server{
 		DB:       db,
 		logger:   logger,
 		config:   c,
 		cache:    cache,
+		keys:     keys,
+		usage:    usage,
 		identity: identity,
 	}
 }
@@ -58,6 +80,66 @@ func (s *Server) validateAuth(ctx context.Context) error {
 	return nil
 }
 
+// validateUplinkKey checks that the public key of the peer is registered for the API key,
+// the satellite itself doesn't need to register its key
+func (s *Server) validateUplinkKey(ctx context.Context) error {
+	if s.keys == nil {
+		return nil
+	}
+
+	pi, err := provider.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, err.Error())
+	}
+	if s.identity != nil && pi.ID.String() == s.identity.ID.String() {
+		return nil
+	}
+
+	publicKey, err := uplinkdb.PublicKeyBytes(pi.Leaf.PublicKey)
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, err.Error())
+	}
+	APIKey, _ := auth.GetAPIKey(ctx)
+	if err := s.keys.Verify(APIKey, publicKey); err != nil {
+		if uplinkdb.ErrNotRegistered.Has(err) || uplinkdb.ErrRevoked.Has(err) {
+			return status.Errorf(codes.PermissionDenied, err.Error())
+		}
+		s.logger.Error("err verifying uplink key", zap.Error(err))
+		return status.Errorf(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+// validateAction checks that the action is known, only the satellite itself audits and repairs
+func (s *Server) validateAction(ctx context.Context, action pb.PayerBandwidthAllocation_Action) error {
+	switch action {
+	case pb.PayerBandwidthAllocation_PUT, pb.PayerBandwidthAllocation_GET:
+		return nil
+	case pb.PayerBandwidthAllocation_GET_AUDIT, pb.PayerBandwidthAllocation_GET_REPAIR, pb.PayerBandwidthAllocation_PUT_REPAIR:
+		pi, err := provider.PeerIdentityFromContext(ctx)
+		if err != nil {
+			return status.Errorf(codes.Unauthenticated, err.Error())
+		}
+		if s.identity == nil || pi.ID.String() != s.identity.ID.String() {
+			return status.Errorf(codes.PermissionDenied, "action %v is reserved for the satellite", action)
+		}
+		return nil
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown action %v", action)
+	}
+}
+
+// validateBucket checks that the uploads and downloads of the uplinks are accounted to a bucket
+func validateBucket(action pb.PayerBandwidthAllocation_Action, bucket string) error {
+	switch action {
+	case pb.PayerBandwidthAllocation_PUT, pb.PayerBandwidthAllocation_GET:
+		if bucket == "" {
+			return status.Errorf(codes.InvalidArgument, "bucket not specified for action %v", action)
+		}
+	}
+	return nil
+}
+
 func (s *Server) validateSegment(req *pb.PutRequest) error {
 	min := s.config.MinRemoteSegmentSize
 	remote := req.GetPointer().Remote
@@ -99,14 +181,28 @@ func (s *Server) Put(ctx context.Context, req *pb.PutRequest) (resp *pb.PutRespo
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 
+	// segments which don't grow what the project stores are accepted over the limit
+	var limitErr error
+	checkLimits := func(old *pb.Pointer) bool {
+		if _, _, ok := segmentBucket(req.GetPath()); ok && req.GetPointer().GetSize() > old.GetSize() {
+			limitErr = s.checkLimits(ctx, pb.PayerBandwidthAllocation_PUT)
+		}
+		return limitErr == nil
+	}
+
 	// TODO(kaloyan): make sure that we know we are overwriting the pointer!
 	// In such case we should delete the pieces of the old segment if it was
 	// a remote one.
-	if err = s.DB.Put([]byte(req.GetPath()), pointerBytes); err != nil {
+	old, err := s.swapPointer(req.GetPath(), pointerBytes, checkLimits)
+	if limitErr != nil {
+		return nil, limitErr
+	}
+	if err != nil {
 		s.logger.Error("err putting pointer", zap.Error(err))
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 
+	s.updateUsage(ctx, req.GetPath(), old, req.GetPointer())
 	return &pb.PutResponse{}, nil
 }
 
@@ -119,6 +215,9 @@ func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (resp *pb.GetRespo
 	if err = s.validateAuth(ctx); err != nil {
 		return nil, err
 	}
+	if err = s.validateUplinkKey(ctx); err != nil {
+		return nil, err
+	}
 
 	pointerBytes, err := s.DB.Get([]byte(req.GetPath()))
 	if err != nil {
@@ -136,10 +235,29 @@ func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (resp *pb.GetRespo
 		return nil, err
 	}
 
-	pba, err := s.getPayerBandwidthAllocation(ctx)
-	if err != nil {
-		s.logger.Error("err getting payer bandwidth allocation", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, err.Error())
+	// once the project reached its egress limit the pointer is still returned so that the segment can be
+	// inspected and deleted, but without any allocations for downloading it
+	withhold := false
+	if err = s.checkLimits(ctx, pb.PayerBandwidthAllocation_GET); err != nil {
+		if status.Code(err) != codes.ResourceExhausted {
+			return nil, err
+		}
+		withhold = true
+	}
+
+	// downloads are accounted to the bucket of the segment, pointers outside of buckets get no allocations
+	bucket, _, ok := segmentBucket(req.GetPath())
+	if !ok {
+		withhold = true
+	}
+
+	var pba *pb.PayerBandwidthAllocation
+	if !withhold {
+		pba, err = s.getPayerBandwidthAllocation(ctx, pb.PayerBandwidthAllocation_GET, bucket)
+		if err != nil {
+			s.logger.Error("err getting payer bandwidth allocation", zap.Error(err))
+			return nil, status.Errorf(codes.Internal, err.Error())
+		}
 	}
 
 	authorization, err := s.getSignedMessage()
@@ -148,8 +266,6 @@ func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (resp *pb.GetRespo
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 
-	nodes := []*pb.Node{}
-
 	var r = &pb.GetResponse{
 		Pointer:       pointer,
 		Nodes:         nil,
@@ -157,10 +273,28 @@ func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (resp *pb.GetRespo
 		Authorization: authorization,
 	}
 
-	if !s.config.Overlay || pointer.Remote == nil {
+	if pointer.Remote == nil {
+		return r, nil
+	}
+
+	// every node gets its own order limit for downloading its piece
+	if !withhold {
+		var nodeIDs []string
+		for _, piece := range pointer.Remote.RemotePieces {
+			nodeIDs = append(nodeIDs, piece.NodeId)
+		}
+		r.OrderLimits, err = s.getOrderLimits(ctx, pb.PayerBandwidthAllocation_GET, bucket, psclient.PieceID(pointer.Remote.PieceId), nodeIDs, PieceSize(pointer))
+		if err != nil {
+			s.logger.Error("err getting order limits", zap.Error(err))
+			return nil, status.Errorf(codes.Internal, err.Error())
+		}
+	}
+
+	if !s.config.Overlay {
 		return r, nil
 	}
 
+	nodes := []*pb.Node{}
 	for _, piece := range pointer.Remote.RemotePieces {
 		node, err := s.cache.Get(ctx, piece.NodeId)
 		if err != nil {
@@ -168,17 +302,24 @@ func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (resp *pb.GetRespo
 		}
 		nodes = append(nodes, node)
 	}
-
-	r = &pb.GetResponse{
-		Pointer:       pointer,
-		Nodes:         nodes,
-		Pba:           pba,
-		Authorization: authorization,
-	}
+	r.Nodes = nodes
 
 	return r, nil
 }
 
+// PieceSize returns the size of the pieces the remote segment was erasure encoded into
+func PieceSize(pointer *pb.Pointer) int64 {
+	redundancy := pointer.GetRemote().GetRedundancy()
+	shareSize := int64(redundancy.GetErasureShareSize())
+	stripeSize := shareSize * int64(redundancy.GetMinReq())
+	if stripeSize <= 0 {
+		return 0
+	}
+	// the segment is padded with at least 4 bytes to a multiple of the stripe size
+	stripes := (pointer.GetSize() + 4 + stripeSize - 1) / stripeSize
+	return stripes * shareSize
+}
+
 // List returns all Path keys in the Pointers bucket
 func (s *Server) List(ctx context.Context, req *pb.ListRequest) (resp *pb.ListResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -274,15 +415,139 @@ func (s *Server) Delete(ctx context.Context, req *pb.DeleteRequest) (resp *pb.De
 		return nil, err
 	}
 
-	err = s.DB.Delete([]byte(req.GetPath()))
+	old, err := s.swapPointer(req.GetPath(), nil, nil)
 	if err != nil {
 		s.logger.Error("err deleting path and pointer", zap.Error(err))
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 	s.logger.Debug("deleted pointer at path: " + req.GetPath())
+
+	s.updateUsage(ctx, req.GetPath(), old, nil)
 	return &pb.DeleteResponse{}, nil
 }
 
+// swapPointer stores pointerBytes at the path, or deletes the pointer if pointerBytes is nil, and returns
+// the pointer it replaced, so that the change can be accounted to its bucket. The pointer is only replaced
+// while accept returns true for the current one, unless accept is nil. The replaced pointer is nil if
+// there was none or the usage of the buckets isn't kept.
+func (s *Server) swapPointer(path string, pointerBytes storage.Value, accept func(old *pb.Pointer) bool) (*pb.Pointer, error) {
+	key := storage.Key(path)
+	if s.usage == nil {
+		if accept != nil && !accept(nil) {
+			return nil, nil
+		}
+		if pointerBytes == nil {
+			return nil, s.DB.Delete(key)
+		}
+		return nil, s.DB.Put(key, pointerBytes)
+	}
+
+	// the pointer may be changed concurrently, then the swap is retried with the new one
+	for {
+		oldBytes, err := s.DB.Get(key)
+		if storage.ErrKeyNotFound.Has(err) {
+			if pointerBytes == nil {
+				return nil, err
+			}
+			oldBytes, err = nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var old *pb.Pointer
+		if oldBytes != nil {
+			old = &pb.Pointer{}
+			if err := proto.Unmarshal(oldBytes, old); err != nil {
+				return nil, err
+			}
+		}
+		if accept != nil && !accept(old) {
+			return nil, nil
+		}
+
+		err = s.DB.CompareAndSwap(key, oldBytes, pointerBytes)
+		if !storage.ErrValueChanged.Has(err) {
+			return old, err
+		}
+	}
+}
+
+// updateUsage accounts the difference between the pointer before and after the change at the path to its bucket,
+// a failure is only logged as the segment has been stored or deleted already
+func (s *Server) updateUsage(ctx context.Context, path string, before, after *pb.Pointer) {
+	if s.usage == nil {
+		return
+	}
+	bucket, lastSegment, ok := segmentBucket(path)
+	if !ok {
+		return
+	}
+
+	var storedBytes, segments, objects int64
+	if before != nil {
+		storedBytes -= before.GetSize()
+		segments--
+	}
+	if after != nil {
+		storedBytes += after.GetSize()
+		segments++
+	}
+	// every object has exactly one last segment
+	if lastSegment {
+		objects = segments
+	}
+	if storedBytes == 0 && segments == 0 {
+		return
+	}
+
+	if err := s.usage.Update(ctx, apiKeyHash(ctx), bucket, storedBytes, segments, objects); err != nil {
+		s.logger.Error("err updating bucket usage", zap.Error(err))
+	}
+}
+
+// checkLimits refuses uploads once the project of the request stores as much as it may and downloads
+// once it downloaded as much as it may this month with a ResourceExhausted status
+func (s *Server) checkLimits(ctx context.Context, action pb.PayerBandwidthAllocation_Action) error {
+	if s.usage == nil {
+		return nil
+	}
+
+	var err error
+	switch action {
+	case pb.PayerBandwidthAllocation_PUT:
+		err = s.usage.CheckStorage(ctx, apiKeyHash(ctx))
+	case pb.PayerBandwidthAllocation_GET:
+		err = s.usage.CheckEgress(ctx, apiKeyHash(ctx))
+	}
+
+	switch {
+	case err == nil:
+		return nil
+	case storj.ErrStorageLimit.Has(err), storj.ErrEgressLimit.Has(err):
+		return status.Errorf(codes.ResourceExhausted, err.Error())
+	default:
+		s.logger.Error("err checking project limits", zap.Error(err))
+		return status.Errorf(codes.Internal, err.Error())
+	}
+}
+
+// segmentBucket returns the bucket of the segment path and whether it is the last segment of an object,
+// segment paths consist of the segment, the bucket and the encrypted path of the object
+func segmentBucket(path string) (bucket string, lastSegment bool, ok bool) {
+	components := storj.SplitPath(path)
+	if len(components) < 3 {
+		return "", false, false
+	}
+	return components[1], components[0] == "l", true
+}
+
+// apiKeyHash returns the hash of the API key of the request, which identifies the project
+func apiKeyHash(ctx context.Context) []byte {
+	APIKey, _ := auth.GetAPIKey(ctx)
+	return uplinkdb.HashAPIKey(APIKey)
+}
+
 // Iterate iterates over items based on IterateRequest
 func (s *Server) Iterate(ctx context.Context, req *pb.IterateRequest, f func(it storage.Iterator) error) error {
 	opts := storage.IterateOptions{
@@ -294,21 +559,205 @@ func (s *Server) Iterate(ctx context.Context, req *pb.IterateRequest, f func(it
 	return s.DB.Iterate(opts, f)
 }
 
-func (s *Server) getPayerBandwidthAllocation(ctx context.Context) (*pb.PayerBandwidthAllocation, error) {
-	payer := s.identity.ID.Bytes()
+// PayerBandwidthAllocation returns a new bandwidth allocation paid by this satellite for the requested action
+func (s *Server) PayerBandwidthAllocation(ctx context.Context, req *pb.PayerBandwidthAllocationRequest) (resp *pb.PayerBandwidthAllocationResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+	s.logger.Debug("entering pointerdb payer bandwidth allocation")
+
+	if err = s.validateAuth(ctx); err != nil {
+		return nil, err
+	}
+	if err = s.validateUplinkKey(ctx); err != nil {
+		return nil, err
+	}
+
+	if err = s.validateAction(ctx, req.GetAction()); err != nil {
+		return nil, err
+	}
+	if err = validateBucket(req.GetAction(), req.GetBucket()); err != nil {
+		return nil, err
+	}
+	if err = s.checkLimits(ctx, req.GetAction()); err != nil {
+		return nil, err
+	}
+
+	pba, err := s.getPayerBandwidthAllocation(ctx, req.GetAction(), req.GetBucket())
+	if err != nil {
+		s.logger.Error("err getting payer bandwidth allocation", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &pb.PayerBandwidthAllocationResponse{Pba: pba}, nil
+}
+
+// OrderLimits returns a bandwidth allocation paid by this satellite for each of the requested storage nodes
+func (s *Server) OrderLimits(ctx context.Context, req *pb.OrderLimitsRequest) (resp *pb.OrderLimitsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+	s.logger.Debug("entering pointerdb order limits")
+
+	if err = s.validateAuth(ctx); err != nil {
+		return nil, err
+	}
+	if err = s.validateUplinkKey(ctx); err != nil {
+		return nil, err
+	}
+
+	if err = s.validateAction(ctx, req.GetAction()); err != nil {
+		return nil, err
+	}
+	if err = validateBucket(req.GetAction(), req.GetBucket()); err != nil {
+		return nil, err
+	}
+	if req.GetPieceId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "piece id not specified")
+	}
+	if req.GetMaxSize() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "negative max size %d", req.GetMaxSize())
+	}
+	for _, nodeID := range req.GetNodeIds() {
+		if nodeID == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "node id not specified")
+		}
+	}
+	if err = s.checkLimits(ctx, req.GetAction()); err != nil {
+		return nil, err
+	}
+
+	limits, err := s.getOrderLimits(ctx, req.GetAction(), req.GetBucket(), psclient.PieceID(req.GetPieceId()), req.GetNodeIds(), req.GetMaxSize())
+	if err != nil {
+		s.logger.Error("err getting order limits", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &pb.OrderLimitsResponse{OrderLimits: limits}, nil
+}
+
+// NewPayerBandwidthAllocation creates a bandwidth allocation for the action paid by this satellite for the peer in ctx,
+// which is accounted to the bucket
+func (s *Server) NewPayerBandwidthAllocation(ctx context.Context, action pb.PayerBandwidthAllocation_Action, bucket string) (*pb.PayerBandwidthAllocation, error) {
+	return s.getPayerBandwidthAllocation(ctx, action, bucket)
+}
+
+// NewOrderLimit creates a bandwidth allocation paid by this satellite for the peer in ctx,
+// which allows to transfer at most maxSize bytes of the derived piece id to or from the storage node
+func (s *Server) NewOrderLimit(ctx context.Context, action pb.PayerBandwidthAllocation_Action, nodeID string, derivedPieceID string, maxSize int64) (*pb.PayerBandwidthAllocation, error) {
+	return s.newOrderLimit(ctx, action, "", nodeID, derivedPieceID, maxSize)
+}
+
+func (s *Server) newOrderLimit(ctx context.Context, action pb.PayerBandwidthAllocation_Action, bucket string, nodeID string, derivedPieceID string, maxSize int64) (*pb.PayerBandwidthAllocation, error) {
+	pbad, err := s.newAllocationData(ctx, action, bucket)
+	if err != nil {
+		return nil, err
+	}
+	return s.signOrderLimit(pbad, nodeID, derivedPieceID, maxSize)
+}
+
+// NewSatelliteOrderLimit creates an order limit for this satellite itself, which allows the satellite
+// to transfer at most maxSize bytes of the derived piece id to or from the storage node
+func (s *Server) NewSatelliteOrderLimit(action pb.PayerBandwidthAllocation_Action, nodeID string, derivedPieceID string, maxSize int64) (*pb.PayerBandwidthAllocation, error) {
+	pbad, err := s.allocationData(action, s.identity.ID.Bytes(), s.identity.Leaf.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return s.signOrderLimit(pbad, nodeID, derivedPieceID, maxSize)
+}
+
+// signOrderLimit restricts the allocation to the derived piece id on the storage node and signs it
+func (s *Server) signOrderLimit(pbad *pb.PayerBandwidthAllocation_Data, nodeID string, derivedPieceID string, maxSize int64) (*pb.PayerBandwidthAllocation, error) {
+	pbad.StorageNodeId = node.IDFromString(nodeID).Bytes()
+	pbad.PieceId = derivedPieceID
+	pbad.MaxSize = s.orderLimitSize(maxSize)
+	return s.signAllocation(pbad)
+}
 
+// SignedMessage creates the authorization for accessing pieces of this satellite
+func (s *Server) SignedMessage() (*pb.SignedMessage, error) {
+	return s.getSignedMessage()
+}
+
+func (s *Server) getPayerBandwidthAllocation(ctx context.Context, action pb.PayerBandwidthAllocation_Action, bucket string) (*pb.PayerBandwidthAllocation, error) {
+	pbad, err := s.newAllocationData(ctx, action, bucket)
+	if err != nil {
+		return nil, err
+	}
+	return s.signAllocation(pbad)
+}
+
+// getOrderLimits creates an order limit for the piece of each of the nodes
+func (s *Server) getOrderLimits(ctx context.Context, action pb.PayerBandwidthAllocation_Action, bucket string, pieceID psclient.PieceID, nodeIDs []string, maxSize int64) ([]*pb.PayerBandwidthAllocation, error) {
+	limits := make([]*pb.PayerBandwidthAllocation, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		derivedPieceID, err := pieceID.Derive([]byte(nodeID))
+		if err != nil {
+			return nil, err
+		}
+		limit, err := s.newOrderLimit(ctx, action, bucket, nodeID, derivedPieceID.String(), maxSize)
+		if err != nil {
+			return nil, err
+		}
+		limits = append(limits, limit)
+	}
+	return limits, nil
+}
+
+// orderLimitSize returns how many bytes an order limit allows, zero or more than the configured
+// maximum is replaced by the maximum
+func (s *Server) orderLimitSize(requested int64) int64 {
+	max := s.config.MaxOrderLimit
+	if requested <= 0 || (max > 0 && requested > max) {
+		return max
+	}
+	return requested
+}
+
+// newAllocationData creates the unsigned allocation for the peer in ctx with a unique serial number,
+// renter allocations have to be signed with the public key of the peer. The bandwidth is accounted
+// to the bucket of the project of the peer unless bucket is empty.
+func (s *Server) newAllocationData(ctx context.Context, action pb.PayerBandwidthAllocation_Action, bucket string) (*pb.PayerBandwidthAllocation_Data, error) {
 	// TODO(michal) should be replaced with renter id when available
 	peerIdentity, err := provider.PeerIdentityFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
+	pbad, err := s.allocationData(action, peerIdentity.ID.Bytes(), peerIdentity.Leaf.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if bucket != "" {
+		pbad.ApiKeyHash = apiKeyHash(ctx)
+		pbad.Bucket = bucket
+	}
+	return pbad, nil
+}
+
+// allocationData creates the unsigned allocation for the uplink with a unique serial number
+func (s *Server) allocationData(action pb.PayerBandwidthAllocation_Action, uplinkID []byte, uplinkKey crypto.PublicKey) (*pb.PayerBandwidthAllocation_Data, error) {
+	uplinkPublicKey, err := uplinkdb.PublicKeyBytes(uplinkKey)
+	if err != nil {
+		return nil, err
+	}
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	created := time.Now()
 	pbad := &pb.PayerBandwidthAllocation_Data{
-		SatelliteId:    payer,
-		UplinkId:       peerIdentity.ID.Bytes(),
-		CreatedUnixSec: time.Now().Unix(),
-		// TODO: Action: pb.PayerBandwidthAllocation_GET, // Action should be a GET or a PUT
+		SatelliteId:     s.identity.ID.Bytes(),
+		UplinkId:        uplinkID,
+		SerialNumber:    serialNumber,
+		CreatedUnixSec:  created.Unix(),
+		Action:          action,
+		UplinkPublicKey: uplinkPublicKey,
+	}
+	if s.config.AllocationExpiration > 0 {
+		pbad.ExpirationUnixSec = created.Add(s.config.AllocationExpiration).Unix()
 	}
+	return pbad, nil
+}
 
+// signAllocation signs the allocation as the satellite paying for it
+func (s *Server) signAllocation(pbad *pb.PayerBandwidthAllocation_Data) (*pb.PayerBandwidthAllocation, error) {
 	data, err := proto.Marshal(pbad)
 	if err != nil {
 		return nil, err
@@ -317,7 +766,17 @@ func (s *Server) getPayerBandwidthAllocation(ctx context.Context) (*pb.PayerBand
 	if err != nil {
 		return nil, err
 	}
-	return &pb.PayerBandwidthAllocation{Signature: signature, Data: data}, nil
+	return &pb.PayerBandwidthAllocation{Signature: signature, Data: data, Certs: s.identity.ChainRaw()}, nil
+}
+
+// newSerialNumber creates a random serial number for a bandwidth allocation,
+// storage nodes and satellites accept every serial number only once
+func newSerialNumber() (string, error) {
+	var serialNumber [16]byte
+	if _, err := rand.Read(serialNumber[:]); err != nil {
+		return "", err
+	}
+	return base58.Encode(serialNumber[:]), nil
 }
 
 func (s *Server) getSignedMessage() (*pb.SignedMessage, error) {
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
@@ -23,8 +24,11 @@ import (
 
 	"storj.io/storj/pkg/auth"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/storage/meta"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/uplinkdb"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/teststore"
 )
@@ -119,7 +123,197 @@ func TestServiceGet(t *testing.T) {
 
 			assert.NotNil(t, resp.GetAuthorization())
 			assert.NotNil(t, resp.GetPba())
+
+			// the download is accounted to the bucket
+			pbad := &pb.PayerBandwidthAllocation_Data{}
+			assert.NoError(t, proto.Unmarshal(resp.GetPba().GetData(), pbad), errTag)
+			assert.Equal(t, "b", pbad.GetBucket(), errTag)
+			assert.Equal(t, uplinkdb.HashAPIKey(tt.apiKey), pbad.GetApiKeyHash(), errTag)
+		}
+	}
+}
+
+func TestServicePayerBandwidthAllocation(t *testing.T) {
+	ctx := context.Background()
+	ca, err := provider.NewTestCA(ctx)
+	assert.NoError(t, err)
+	identity, err := ca.NewIdentity()
+	assert.NoError(t, err)
+
+	info := credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{identity.Leaf, identity.CA}}}
+	ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: info})
+
+	s := Server{logger: zap.NewNop(), identity: identity, config: Config{AllocationExpiration: time.Hour}}
+
+	_, err = s.PayerBandwidthAllocation(auth.WithAPIKey(ctx, []byte("wrong key")), &pb.PayerBandwidthAllocationRequest{})
+	assert.EqualError(t, err, status.Errorf(codes.Unauthenticated, "Invalid API credential").Error())
+
+	_, err = s.PayerBandwidthAllocation(auth.WithAPIKey(ctx, nil), &pb.PayerBandwidthAllocationRequest{Action: 5, Bucket: "bucket"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// uploads and downloads are refused unless they can be accounted to a bucket
+	_, err = s.PayerBandwidthAllocation(auth.WithAPIKey(ctx, nil), &pb.PayerBandwidthAllocationRequest{Action: pb.PayerBandwidthAllocation_GET})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	serialNumbers := map[string]bool{}
+	for _, action := range []pb.PayerBandwidthAllocation_Action{
+		pb.PayerBandwidthAllocation_PUT, pb.PayerBandwidthAllocation_GET, pb.PayerBandwidthAllocation_GET,
+	} {
+		resp, err := s.PayerBandwidthAllocation(auth.WithAPIKey(ctx, nil), &pb.PayerBandwidthAllocationRequest{Action: action, Bucket: "bucket"})
+		if !assert.NoError(t, err) {
+			return
 		}
+
+		pbad := &pb.PayerBandwidthAllocation_Data{}
+		assert.NoError(t, proto.Unmarshal(resp.GetPba().GetData(), pbad))
+
+		assert.Equal(t, action, pbad.GetAction())
+		assert.Equal(t, "bucket", pbad.GetBucket())
+		assert.NotEmpty(t, pbad.GetApiKeyHash())
+		assert.NotEmpty(t, pbad.GetSerialNumber())
+		assert.False(t, serialNumbers[pbad.GetSerialNumber()], "serial number reused")
+		serialNumbers[pbad.GetSerialNumber()] = true
+
+		assert.Equal(t, pbad.GetCreatedUnixSec()+int64(time.Hour/time.Second), pbad.GetExpirationUnixSec())
+	}
+}
+
+func TestServiceUplinkKeys(t *testing.T) {
+	ctx := context.Background()
+	satelliteCA, err := provider.NewTestCA(ctx)
+	assert.NoError(t, err)
+	satellite, err := satelliteCA.NewIdentity()
+	assert.NoError(t, err)
+	uplinkCA, err := provider.NewTestCA(ctx)
+	assert.NoError(t, err)
+	uplink, err := uplinkCA.NewIdentity()
+	assert.NoError(t, err)
+
+	info := credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{uplink.Leaf, uplink.CA}}}
+	ctx = auth.WithAPIKey(peer.NewContext(ctx, &peer.Peer{AuthInfo: info}), nil)
+
+	keys := uplinkdb.New(teststore.New())
+	s := Server{logger: zap.NewNop(), identity: satellite, keys: keys}
+	req := &pb.PayerBandwidthAllocationRequest{Action: pb.PayerBandwidthAllocation_GET, Bucket: "bucket"}
+
+	_, err = s.PayerBandwidthAllocation(ctx, req)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	publicKey, err := uplinkdb.PublicKeyBytes(uplink.Leaf.PublicKey)
+	assert.NoError(t, err)
+	_, err = keys.Register(nil, publicKey)
+	assert.NoError(t, err)
+
+	resp, err := s.PayerBandwidthAllocation(ctx, req)
+	if assert.NoError(t, err) {
+		pbad := &pb.PayerBandwidthAllocation_Data{}
+		assert.NoError(t, proto.Unmarshal(resp.GetPba().GetData(), pbad))
+		assert.Equal(t, publicKey, pbad.GetUplinkPublicKey())
+	}
+
+	_, err = keys.Revoke(nil, uplinkdb.KeyID(publicKey))
+	assert.NoError(t, err)
+
+	_, err = s.PayerBandwidthAllocation(ctx, req)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestServiceOrderLimits(t *testing.T) {
+	ctx := context.Background()
+	ca, err := provider.NewTestCA(ctx)
+	assert.NoError(t, err)
+	identity, err := ca.NewIdentity()
+	assert.NoError(t, err)
+
+	info := credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{identity.Leaf, identity.CA}}}
+	ctx = auth.WithAPIKey(peer.NewContext(ctx, &peer.Peer{AuthInfo: info}), nil)
+
+	db := teststore.New()
+	s := Server{DB: db, logger: zap.NewNop(), identity: identity, config: Config{MaxOrderLimit: 1000}}
+
+	for _, req := range []*pb.OrderLimitsRequest{
+		{Action: 5, PieceId: "piece", NodeIds: []string{"node1"}, Bucket: "bucket"},
+		{Action: pb.PayerBandwidthAllocation_PUT, PieceId: "piece", NodeIds: []string{"node1"}},
+		{Action: pb.PayerBandwidthAllocation_GET, PieceId: "piece", NodeIds: []string{"node1"}},
+		{Action: pb.PayerBandwidthAllocation_PUT, NodeIds: []string{"node1"}, Bucket: "bucket"},
+		{Action: pb.PayerBandwidthAllocation_PUT, PieceId: "piece", NodeIds: []string{""}, Bucket: "bucket"},
+		{Action: pb.PayerBandwidthAllocation_PUT, PieceId: "piece", NodeIds: []string{"node1"}, MaxSize: -1, Bucket: "bucket"},
+	} {
+		_, err := s.OrderLimits(ctx, req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	checkLimits := func(limits []*pb.PayerBandwidthAllocation, action pb.PayerBandwidthAllocation_Action, nodeIDs []string, maxSize int64) {
+		if !assert.Len(t, limits, len(nodeIDs)) {
+			return
+		}
+		serialNumbers := map[string]bool{}
+		for i, limit := range limits {
+			pbad := &pb.PayerBandwidthAllocation_Data{}
+			assert.NoError(t, proto.Unmarshal(limit.GetData(), pbad))
+
+			derivedPieceID, err := psclient.PieceID("piece").Derive([]byte(nodeIDs[i]))
+			assert.NoError(t, err)
+
+			assert.Equal(t, action, pbad.GetAction())
+			assert.Equal(t, "bucket", pbad.GetBucket())
+			assert.Equal(t, nodeIDs[i], string(pbad.GetStorageNodeId()))
+			assert.Equal(t, derivedPieceID.String(), pbad.GetPieceId())
+			assert.Equal(t, maxSize, pbad.GetMaxSize())
+			assert.False(t, serialNumbers[pbad.GetSerialNumber()], "serial number reused")
+			serialNumbers[pbad.GetSerialNumber()] = true
+		}
+	}
+
+	nodeIDs := []string{"node1", "node2"}
+	for _, tt := range []struct {
+		requested int64
+		expected  int64
+	}{
+		{0, 1000},
+		{500, 500},
+		{2000, 1000},
+	} {
+		resp, err := s.OrderLimits(ctx, &pb.OrderLimitsRequest{
+			Action:  pb.PayerBandwidthAllocation_PUT,
+			PieceId: "piece",
+			NodeIds: nodeIDs,
+			MaxSize: tt.requested,
+			Bucket:  "bucket",
+		})
+		if assert.NoError(t, err) {
+			checkLimits(resp.GetOrderLimits(), pb.PayerBandwidthAllocation_PUT, nodeIDs, tt.expected)
+		}
+	}
+
+	// downloading a remote segment is limited to the size of the pieces
+	pointer := &pb.Pointer{
+		Type: pb.Pointer_REMOTE,
+		Size: 300,
+		Remote: &pb.RemoteSegment{
+			Redundancy: &pb.RedundancyScheme{MinReq: 2, Total: 3, ErasureShareSize: 64},
+			PieceId:    "piece",
+			RemotePieces: []*pb.RemotePiece{
+				{PieceNum: 0, NodeId: "node1"},
+				{PieceNum: 2, NodeId: "node2"},
+			},
+		},
+	}
+	pointerBytes, err := proto.Marshal(pointer)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Put(storage.Key("l/bucket/object"), pointerBytes))
+
+	resp, err := s.Get(ctx, &pb.GetRequest{Path: "l/bucket/object"})
+	if assert.NoError(t, err) {
+		checkLimits(resp.GetOrderLimits(), pb.PayerBandwidthAllocation_GET, nodeIDs, 192)
+	}
+
+	// downloads which can't be accounted to a bucket aren't allowed
+	assert.NoError(t, db.Put(storage.Key("bucket"), pointerBytes))
+	resp, err = s.Get(ctx, &pb.GetRequest{Path: "bucket"})
+	if assert.NoError(t, err) {
+		assert.Nil(t, resp.GetPba())
+		assert.Empty(t, resp.GetOrderLimits())
 	}
 }
 
@@ -159,6 +353,160 @@ func TestServiceDelete(t *testing.T) {
 	}
 }
 
+type usageUpdate struct {
+	bucket                         string
+	storedBytes, segments, objects int64
+}
+
+type mockUsage []usageUpdate
+
+func (usage *mockUsage) Update(ctx context.Context, apiKeyHash []byte, bucket string, storedBytes, segments, objects int64) error {
+	*usage = append(*usage, usageUpdate{bucket, storedBytes, segments, objects})
+	return nil
+}
+
+func (usage *mockUsage) CheckStorage(ctx context.Context, apiKeyHash []byte) error { return nil }
+func (usage *mockUsage) CheckEgress(ctx context.Context, apiKeyHash []byte) error  { return nil }
+
+// exhaustedUsage is the usage of a project which reached all of its limits
+type exhaustedUsage struct{ mockUsage }
+
+func (usage *exhaustedUsage) CheckStorage(ctx context.Context, apiKeyHash []byte) error {
+	return storj.ErrStorageLimit.New("stored 2 of 1 bytes")
+}
+
+func (usage *exhaustedUsage) CheckEgress(ctx context.Context, apiKeyHash []byte) error {
+	return storj.ErrEgressLimit.New("downloaded 2 of 1 bytes")
+}
+
+func TestServiceUsage(t *testing.T) {
+	ctx := auth.WithAPIKey(context.Background(), nil)
+	usage := &mockUsage{}
+	s := Server{DB: teststore.New(), logger: zap.NewNop(), usage: usage}
+
+	put := func(path string, size int64) {
+		_, err := s.Put(ctx, &pb.PutRequest{Path: path, Pointer: &pb.Pointer{Size: size}})
+		assert.NoError(t, err)
+	}
+	put("l/bucket", 0)
+	put("l/bucket/object", 100)
+	put("s0/bucket/object", 50)
+	put("l/bucket/object", 150)
+	put("l/other/object", 10)
+
+	_, err := s.Delete(ctx, &pb.DeleteRequest{Path: "s0/bucket/object"})
+	assert.NoError(t, err)
+	_, err = s.Delete(ctx, &pb.DeleteRequest{Path: "l/other/object"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, &mockUsage{
+		{"bucket", 100, 1, 1},
+		{"bucket", 50, 1, 0},
+		{"bucket", 50, 0, 0},
+		{"other", 10, 1, 1},
+		{"bucket", -50, -1, 0},
+		{"other", -10, -1, -1},
+	}, usage)
+}
+
+// racingStore writes the pointer race at the key before the first swap, as a concurrent Put would
+type racingStore struct {
+	*teststore.Client
+	race *pb.Pointer
+}
+
+func (store *racingStore) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	if store.race != nil {
+		raceBytes, err := proto.Marshal(store.race)
+		if err != nil {
+			return err
+		}
+		store.race = nil
+		if err := store.Client.Put(key, raceBytes); err != nil {
+			return err
+		}
+	}
+	return store.Client.CompareAndSwap(key, oldValue, newValue)
+}
+
+func TestServiceUsageRace(t *testing.T) {
+	ctx := auth.WithAPIKey(context.Background(), nil)
+	usage := &mockUsage{}
+	db := &racingStore{Client: teststore.New()}
+	s := Server{DB: db, logger: zap.NewNop(), usage: usage}
+
+	_, err := s.Put(ctx, &pb.PutRequest{Path: "l/bucket/object", Pointer: &pb.Pointer{Size: 100}})
+	assert.NoError(t, err)
+
+	// the usage is changed from the pointer which was actually replaced
+	db.race = &pb.Pointer{Size: 120}
+	_, err = s.Put(ctx, &pb.PutRequest{Path: "l/bucket/object", Pointer: &pb.Pointer{Size: 150}})
+	assert.NoError(t, err)
+
+	db.race = &pb.Pointer{Size: 130}
+	_, err = s.Delete(ctx, &pb.DeleteRequest{Path: "l/bucket/object"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, &mockUsage{
+		{"bucket", 100, 1, 1},
+		{"bucket", 30, 0, 0},
+		{"bucket", -130, -1, -1},
+	}, usage)
+}
+
+func TestServiceLimits(t *testing.T) {
+	ctx := context.Background()
+	ca, err := provider.NewTestCA(ctx)
+	assert.NoError(t, err)
+	identity, err := ca.NewIdentity()
+	assert.NoError(t, err)
+
+	info := credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{identity.Leaf, identity.CA}}}
+	ctx = peer.NewContext(auth.WithAPIKey(ctx, nil), &peer.Peer{AuthInfo: info})
+
+	db := teststore.New()
+	s := Server{DB: db, logger: zap.NewNop(), identity: identity, usage: &exhaustedUsage{}}
+
+	pointer := &pb.Pointer{
+		Type: pb.Pointer_REMOTE,
+		Size: 100,
+		Remote: &pb.RemoteSegment{
+			PieceId:      "piece",
+			RemotePieces: []*pb.RemotePiece{{PieceNum: 0, NodeId: "node"}},
+		},
+	}
+	pointerBytes, err := proto.Marshal(pointer)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Put(storage.Key("l/bucket/object"), pointerBytes))
+
+	// new segments are refused, shrinking and deleting segments is not
+	_, err = s.Put(ctx, &pb.PutRequest{Path: "l/bucket/other", Pointer: &pb.Pointer{Size: 1}})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, err = s.Put(ctx, &pb.PutRequest{Path: "l/bucket/object", Pointer: &pb.Pointer{Size: 200}})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, err = s.Put(ctx, &pb.PutRequest{Path: "l/bucket", Pointer: &pb.Pointer{}})
+	assert.NoError(t, err)
+
+	// the pointer is returned without any allocations for downloading it
+	resp, err := s.Get(ctx, &pb.GetRequest{Path: "l/bucket/object"})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(pointer, resp.GetPointer()))
+	assert.Nil(t, resp.GetPba())
+	assert.Empty(t, resp.GetOrderLimits())
+
+	for _, action := range []pb.PayerBandwidthAllocation_Action{pb.PayerBandwidthAllocation_PUT, pb.PayerBandwidthAllocation_GET} {
+		_, err = s.PayerBandwidthAllocation(ctx, &pb.PayerBandwidthAllocationRequest{Action: action, Bucket: "bucket"})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err), action)
+		_, err = s.OrderLimits(ctx, &pb.OrderLimitsRequest{Action: action, PieceId: "piece", NodeIds: []string{"node"}, Bucket: "bucket"})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err), action)
+	}
+
+	_, err = s.Put(ctx, &pb.PutRequest{Path: "l/bucket/object", Pointer: &pb.Pointer{Size: 50}})
+	assert.NoError(t, err)
+	_, err = s.Delete(ctx, &pb.DeleteRequest{Path: "l/bucket/object"})
+	assert.NoError(t, err)
+}
+
 func TestServiceList(t *testing.T) {
 	db := teststore.New()
 	server := Server{DB: db, logger: zap.NewNop()}
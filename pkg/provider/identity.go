@@ -281,6 +281,11 @@ func (ic IdentityConfig) Run(ctx context.Context, interceptor grpc.UnaryServerIn
 	return s.Run(ctx)
 }
 
+// ChainRaw returns the certificate chain, leaf first, as a 2d byte slice
+func (fi *FullIdentity) ChainRaw() [][]byte {
+	return append([][]byte{fi.Leaf.Raw, fi.CA.Raw}, fi.RestChainRaw()...)
+}
+
 // RestChainRaw returns the rest (excluding leaf and CA) of the certficate chain as a 2d byte slice
 func (fi *FullIdentity) RestChainRaw() [][]byte {
 	var chain [][]byte
@@ -36,6 +36,8 @@ type Project struct {
 	Description string
 	// Indicates if user accepted terms and conditions during project creation.
 	IsAgreedWithTerms bool
+	// Hash of the API key uplinks of the project use, their usage is accounted to the project.
+	APIKeyHash []byte
 
 	CreatedAt time.Time
 }
@@ -294,8 +294,10 @@ CREATE TABLE projects (
 	name TEXT NOT NULL,
 	description TEXT NOT NULL,
 	is_agreed_with_terms INTEGER NOT NULL,
+	api_key_hash BLOB,
 	created_at TIMESTAMP NOT NULL,
-	PRIMARY KEY ( id )
+	PRIMARY KEY ( id ),
+	UNIQUE ( api_key_hash )
 );`
 }
 
@@ -676,13 +678,15 @@ type Project struct {
 	Name              string
 	Description       string
 	IsAgreedWithTerms bool
+	ApiKeyHash        []byte
 	CreatedAt         time.Time
 }
 
 func (Project) _Table() string { return "projects" }
 
 type Project_Create_Fields struct {
-	OwnerId Project_OwnerId_Field
+	OwnerId    Project_OwnerId_Field
+	ApiKeyHash Project_ApiKeyHash_Field
 }
 
 type Project_Update_Fields struct {
@@ -690,6 +694,7 @@ type Project_Update_Fields struct {
 	Name              Project_Name_Field
 	Description       Project_Description_Field
 	IsAgreedWithTerms Project_IsAgreedWithTerms_Field
+	ApiKeyHash        Project_ApiKeyHash_Field
 }
 
 type Project_Id_Field struct {
@@ -795,6 +800,37 @@ func (f Project_IsAgreedWithTerms_Field) value() interface{} {
 
 func (Project_IsAgreedWithTerms_Field) _Column() string { return "is_agreed_with_terms" }
 
+type Project_ApiKeyHash_Field struct {
+	_set   bool
+	_value []byte
+}
+
+func Project_ApiKeyHash(v []byte) Project_ApiKeyHash_Field {
+	return Project_ApiKeyHash_Field{_set: true, _value: v}
+}
+
+func Project_ApiKeyHash_Raw(v []byte) Project_ApiKeyHash_Field {
+	if v == nil {
+		return Project_ApiKeyHash_Null()
+	}
+	return Project_ApiKeyHash(v)
+}
+
+func Project_ApiKeyHash_Null() Project_ApiKeyHash_Field {
+	return Project_ApiKeyHash_Field{_set: true}
+}
+
+func (f Project_ApiKeyHash_Field) isnull() bool { return !f._set || f._value == nil }
+
+func (f Project_ApiKeyHash_Field) value() interface{} {
+	if !f._set {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_ApiKeyHash_Field) _Column() string { return "api_key_hash" }
+
 type Project_CreatedAt_Field struct {
 	_set   bool
 	_value time.Time
@@ -1067,14 +1103,15 @@ func (obj *sqlite3Impl) Create_Project(ctx context.Context,
 	__name_val := project_name.value()
 	__description_val := project_description.value()
 	__is_agreed_with_terms_val := project_is_agreed_with_terms.value()
+	__api_key_hash_val := optional.ApiKeyHash.value()
 	__created_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO projects ( id, owner_id, name, description, is_agreed_with_terms, created_at ) VALUES ( ?, ?, ?, ?, ?, ? )")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO projects ( id, owner_id, name, description, is_agreed_with_terms, api_key_hash, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ? )")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __id_val, __owner_id_val, __name_val, __description_val, __is_agreed_with_terms_val, __created_at_val)
+	obj.logStmt(__stmt, __id_val, __owner_id_val, __name_val, __description_val, __is_agreed_with_terms_val, __api_key_hash_val, __created_at_val)
 
-	__res, err := obj.driver.Exec(__stmt, __id_val, __owner_id_val, __name_val, __description_val, __is_agreed_with_terms_val, __created_at_val)
+	__res, err := obj.driver.Exec(__stmt, __id_val, __owner_id_val, __name_val, __description_val, __is_agreed_with_terms_val, __api_key_hash_val, __created_at_val)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -1196,7 +1233,7 @@ func (obj *sqlite3Impl) Get_Company_By_Id(ctx context.Context,
 func (obj *sqlite3Impl) All_Project(ctx context.Context) (
 	rows []*Project, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.owner_id, projects.name, projects.description, projects.is_agreed_with_terms, projects.created_at FROM projects")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.owner_id, projects.name, projects.description, projects.is_agreed_with_terms, projects.api_key_hash, projects.created_at FROM projects")
 
 	var __values []interface{}
 	__values = append(__values)
@@ -1212,7 +1249,7 @@ func (obj *sqlite3Impl) All_Project(ctx context.Context) (
 
 	for __rows.Next() {
 		project := &Project{}
-		err = __rows.Scan(&project.Id, &project.OwnerId, &project.Name, &project.Description, &project.IsAgreedWithTerms, &project.CreatedAt)
+		err = __rows.Scan(&project.Id, &project.OwnerId, &project.Name, &project.Description, &project.IsAgreedWithTerms, &project.ApiKeyHash, &project.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -1229,7 +1266,7 @@ func (obj *sqlite3Impl) Get_Project_By_Id(ctx context.Context,
 	project_id Project_Id_Field) (
 	project *Project, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.owner_id, projects.name, projects.description, projects.is_agreed_with_terms, projects.created_at FROM projects WHERE projects.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.owner_id, projects.name, projects.description, projects.is_agreed_with_terms, projects.api_key_hash, projects.created_at FROM projects WHERE projects.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, project_id.value())
@@ -1238,7 +1275,7 @@ func (obj *sqlite3Impl) Get_Project_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	project = &Project{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&project.Id, &project.OwnerId, &project.Name, &project.Description, &project.IsAgreedWithTerms, &project.CreatedAt)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&project.Id, &project.OwnerId, &project.Name, &project.Description, &project.IsAgreedWithTerms, &project.ApiKeyHash, &project.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -1252,7 +1289,7 @@ func (obj *sqlite3Impl) All_Project_By_OwnerId(ctx context.Context,
 
 	var __cond_0 = &__sqlbundle_Condition{Left: "projects.owner_id", Equal: true, Right: "?", Null: true}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("SELECT projects.id, projects.owner_id, projects.name, projects.description, projects.is_agreed_with_terms, projects.created_at FROM projects WHERE "), __cond_0}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("SELECT projects.id, projects.owner_id, projects.name, projects.description, projects.is_agreed_with_terms, projects.api_key_hash, projects.created_at FROM projects WHERE "), __cond_0}}
 
 	var __values []interface{}
 	__values = append(__values)
@@ -1273,7 +1310,7 @@ func (obj *sqlite3Impl) All_Project_By_OwnerId(ctx context.Context,
 
 	for __rows.Next() {
 		project := &Project{}
-		err = __rows.Scan(&project.Id, &project.OwnerId, &project.Name, &project.Description, &project.IsAgreedWithTerms, &project.CreatedAt)
+		err = __rows.Scan(&project.Id, &project.OwnerId, &project.Name, &project.Description, &project.IsAgreedWithTerms, &project.ApiKeyHash, &project.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -1286,6 +1323,34 @@ func (obj *sqlite3Impl) All_Project_By_OwnerId(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) Get_Project_By_ApiKeyHash(ctx context.Context,
+	project_api_key_hash Project_ApiKeyHash_Field) (
+	project *Project, err error) {
+
+	var __cond_0 = &__sqlbundle_Condition{Left: "projects.api_key_hash", Equal: true, Right: "?", Null: true}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("SELECT projects.id, projects.owner_id, projects.name, projects.description, projects.is_agreed_with_terms, projects.api_key_hash, projects.created_at FROM projects WHERE "), __cond_0}}
+
+	var __values []interface{}
+	__values = append(__values)
+
+	if !project_api_key_hash.isnull() {
+		__cond_0.Null = false
+		__values = append(__values, project_api_key_hash.value())
+	}
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	project = &Project{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&project.Id, &project.OwnerId, &project.Name, &project.Description, &project.IsAgreedWithTerms, &project.ApiKeyHash, &project.CreatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return project, nil
+
+}
+
 func (obj *sqlite3Impl) Update_User_By_Id(ctx context.Context,
 	user_id User_Id_Field,
 	update User_Update_Fields) (
@@ -1458,6 +1523,11 @@ func (obj *sqlite3Impl) Update_Project_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("is_agreed_with_terms = ?"))
 	}
 
+	if update.ApiKeyHash._set {
+		__values = append(__values, update.ApiKeyHash.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("api_key_hash = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -1476,12 +1546,12 @@ func (obj *sqlite3Impl) Update_Project_By_Id(ctx context.Context,
 		return nil, obj.makeErr(err)
 	}
 
-	var __embed_stmt_get = __sqlbundle_Literal("SELECT projects.id, projects.owner_id, projects.name, projects.description, projects.is_agreed_with_terms, projects.created_at FROM projects WHERE projects.id = ?")
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT projects.id, projects.owner_id, projects.name, projects.description, projects.is_agreed_with_terms, projects.api_key_hash, projects.created_at FROM projects WHERE projects.id = ?")
 
 	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
 	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
 
-	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&project.Id, &project.OwnerId, &project.Name, &project.Description, &project.IsAgreedWithTerms, &project.CreatedAt)
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&project.Id, &project.OwnerId, &project.Name, &project.Description, &project.IsAgreedWithTerms, &project.ApiKeyHash, &project.CreatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -1609,13 +1679,13 @@ func (obj *sqlite3Impl) getLastProject(ctx context.Context,
 	pk int64) (
 	project *Project, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.owner_id, projects.name, projects.description, projects.is_agreed_with_terms, projects.created_at FROM projects WHERE _rowid_ = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.owner_id, projects.name, projects.description, projects.is_agreed_with_terms, projects.api_key_hash, projects.created_at FROM projects WHERE _rowid_ = ?")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, pk)
 
 	project = &Project{}
-	err = obj.driver.QueryRow(__stmt, pk).Scan(&project.Id, &project.OwnerId, &project.Name, &project.Description, &project.IsAgreedWithTerms, &project.CreatedAt)
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&project.Id, &project.OwnerId, &project.Name, &project.Description, &project.IsAgreedWithTerms, &project.ApiKeyHash, &project.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -1835,6 +1905,16 @@ func (rx *Rx) Get_Company_By_UserId(ctx context.Context,
 	return tx.Get_Company_By_UserId(ctx, company_user_id)
 }
 
+func (rx *Rx) Get_Project_By_ApiKeyHash(ctx context.Context,
+	project_api_key_hash Project_ApiKeyHash_Field) (
+	project *Project, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Get_Project_By_ApiKeyHash(ctx, project_api_key_hash)
+}
+
 func (rx *Rx) Get_Project_By_Id(ctx context.Context,
 	project_id Project_Id_Field) (
 	project *Project, err error) {
@@ -1954,6 +2034,10 @@ type Methods interface {
 		company_user_id Company_UserId_Field) (
 		company *Company, err error)
 
+	Get_Project_By_ApiKeyHash(ctx context.Context,
+		project_api_key_hash Project_ApiKeyHash_Field) (
+		project *Project, err error)
+
 	Get_Project_By_Id(ctx context.Context,
 		project_id Project_Id_Field) (
 		project *Project, err error)
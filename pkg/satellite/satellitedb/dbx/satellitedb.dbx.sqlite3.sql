@@ -28,6 +28,8 @@ CREATE TABLE projects (
 	name TEXT NOT NULL,
 	description TEXT NOT NULL,
 	is_agreed_with_terms INTEGER NOT NULL,
+	api_key_hash BLOB,
 	created_at TIMESTAMP NOT NULL,
-	PRIMARY KEY ( id )
+	PRIMARY KEY ( id ),
+	UNIQUE ( api_key_hash )
 );
@@ -69,7 +69,8 @@ func (projects *projects) Insert(ctx context.Context, project *satellite.Project
 		dbx.Project_Description(project.Description),
 		dbx.Project_IsAgreedWithTerms(project.IsAgreedWithTerms),
 		dbx.Project_Create_Fields{
-			OwnerId: ownerID,
+			OwnerId:    ownerID,
+			ApiKeyHash: dbx.Project_ApiKeyHash(project.APIKeyHash),
 		})
 
 	if err != nil {
@@ -94,6 +95,7 @@ func (projects *projects) Update(ctx context.Context, project *satellite.Project
 			Name:              dbx.Project_Name(project.Name),
 			Description:       dbx.Project_Description(project.Description),
 			IsAgreedWithTerms: dbx.Project_IsAgreedWithTerms(project.IsAgreedWithTerms),
+			ApiKeyHash:        dbx.Project_ApiKeyHash(project.APIKeyHash),
 		})
 
 	return err
@@ -115,6 +117,7 @@ func projectFromDBX(project *dbx.Project) (*satellite.Project, error) {
 		Name:              project.Name,
 		Description:       project.Description,
 		IsAgreedWithTerms: project.IsAgreedWithTerms,
+		APIKeyHash:        project.ApiKeyHash,
 		CreatedAt:         project.CreatedAt,
 	}
 
@@ -134,6 +134,7 @@ func TestProjectsRepository(t *testing.T) {
 			Name:              newName,
 			Description:       newDescription,
 			IsAgreedWithTerms: true,
+			APIKeyHash:        []byte("hash"),
 		}
 
 		err = projects.Update(ctx, newProject)
@@ -151,6 +152,7 @@ func TestProjectsRepository(t *testing.T) {
 		assert.Equal(t, newProject.Name, newName)
 		assert.Equal(t, newProject.Description, newDescription)
 		assert.Equal(t, newProject.IsAgreedWithTerms, true)
+		assert.Equal(t, newProject.APIKeyHash, []byte("hash"))
 	})
 
 	t.Run("Delete project success", func(t *testing.T) {
@@ -13,6 +13,8 @@ import (
 
 	"github.com/graphql-go/graphql"
 
+	"storj.io/storj/pkg/accounting"
+	"storj.io/storj/pkg/bwagreement"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/satellite/satellitedb"
 	"storj.io/storj/pkg/satellite/satelliteweb/satelliteql"
@@ -44,9 +46,18 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) error {
 	err = db.CreateTables()
 	sugar.Error(err)
 
+	// usage is only available when the satellite runs accounting and bandwidth agreements
+	var usage satellite.UsageDB
+	accountingDB := accounting.LoadFromContext(ctx)
+	agreements := bwagreement.LoadFromContext(ctx)
+	if accountingDB != nil && agreements != nil {
+		usage = &usageDB{buckets: accounting.NewUsage(accountingDB, agreements), agreements: agreements}
+	}
+
 	service, err := satellite.NewService(
 		&satelliteauth.Hmac{Secret: []byte("my-suppa-secret-key")},
 		db,
+		usage,
 	)
 
 	if err != nil {
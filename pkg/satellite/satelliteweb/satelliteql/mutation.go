@@ -5,6 +5,7 @@ package satelliteql
 
 import (
 	"github.com/graphql-go/graphql"
+	"github.com/skyrings/skyring-common/tools/uuid"
 
 	"storj.io/storj/pkg/satellite"
 )
@@ -13,7 +14,8 @@ const (
 	// Mutation is graphql request that modifies data
 	Mutation = "mutation"
 
-	registerMutation = "register"
+	registerMutation            = "register"
+	updateProjectLimitsMutation = "updateProjectLimits"
 )
 
 // rootMutation creates mutation for graphql populated by AccountsClient
@@ -60,6 +62,45 @@ func rootMutation(service *satellite.Service, types Types) *graphql.Object {
 					return user, nil
 				},
 			},
+			updateProjectLimitsMutation: &graphql.Field{
+				Type: types.ProjectLimitsType(),
+				Args: graphql.FieldConfigArgument{
+					fieldProjectID: &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+					fieldStorageLimit: &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.Float),
+					},
+					fieldEgressLimit: &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.Float),
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, _ := p.Args[fieldProjectID].(string)
+					storageLimit, _ := p.Args[fieldStorageLimit].(float64)
+					egressLimit, _ := p.Args[fieldEgressLimit].(float64)
+
+					projectID, err := uuid.Parse(id)
+					if err != nil {
+						return nil, err
+					}
+
+					limits, err := service.UpdateProjectLimits(
+						p.Context,
+						*projectID,
+						satellite.ProjectLimits{
+							StorageBytes: int64(storageLimit),
+							EgressBytes:  int64(egressLimit),
+						},
+					)
+
+					if err != nil {
+						return nil, err
+					}
+
+					return limits, nil
+				},
+			},
 		},
 	})
 }
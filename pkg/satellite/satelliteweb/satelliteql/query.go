@@ -4,6 +4,8 @@
 package satelliteql
 
 import (
+	"time"
+
 	"github.com/graphql-go/graphql"
 	"github.com/skyrings/skyring-common/tools/uuid"
 
@@ -14,8 +16,9 @@ const (
 	// Query is immutable graphql request
 	Query = "query"
 
-	userQuery  = "user"
-	tokenQuery = "token"
+	userQuery         = "user"
+	tokenQuery        = "token"
+	projectUsageQuery = "projectUsage"
 )
 
 // rootQuery creates query for graphql populated by AccountsClient
@@ -46,6 +49,46 @@ func rootQuery(service *satellite.Service, types Types) *graphql.Object {
 					return user, nil
 				},
 			},
+			projectUsageQuery: &graphql.Field{
+				Type: types.ProjectUsageType(),
+				Args: graphql.FieldConfigArgument{
+					fieldProjectID: &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+					fieldFrom: &graphql.ArgumentConfig{
+						Type: graphql.DateTime,
+					},
+					fieldTo: &graphql.ArgumentConfig{
+						Type: graphql.DateTime,
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, _ := p.Args[fieldProjectID].(string)
+
+					projectID, err := uuid.Parse(id)
+					if err != nil {
+						return nil, err
+					}
+
+					// egress defaults to the current month
+					now := time.Now().UTC()
+					from, ok := p.Args[fieldFrom].(time.Time)
+					if !ok {
+						from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+					}
+					to, ok := p.Args[fieldTo].(time.Time)
+					if !ok {
+						to = now
+					}
+
+					usage, err := service.GetProjectUsage(p.Context, *projectID, from, to)
+					if err != nil {
+						return nil, err
+					}
+
+					return usage, nil
+				},
+			},
 			tokenQuery: &graphql.Field{
 				Type: graphql.String,
 				Args: graphql.FieldConfigArgument{
@@ -14,6 +14,9 @@ type Types interface {
 	RootMutation() *graphql.Object
 
 	UserType() *graphql.Object
+	BucketUsageType() *graphql.Object
+	ProjectUsageType() *graphql.Object
+	ProjectLimitsType() *graphql.Object
 }
 
 // TypeCreator handles graphql type creation and error checking
@@ -21,7 +24,10 @@ type TypeCreator struct {
 	query    *graphql.Object
 	mutation *graphql.Object
 
-	user *graphql.Object
+	user          *graphql.Object
+	bucketUsage   *graphql.Object
+	projectUsage  *graphql.Object
+	projectLimits *graphql.Object
 }
 
 // RootQuery returns instance of query *graphql.Object
@@ -41,6 +47,21 @@ func (c *TypeCreator) Create(service *satellite.Service) error {
 		return err
 	}
 
+	c.bucketUsage = graphqlBucketUsage()
+	if err := c.bucketUsage.Error(); err != nil {
+		return err
+	}
+
+	c.projectLimits = graphqlProjectLimits()
+	if err := c.projectLimits.Error(); err != nil {
+		return err
+	}
+
+	c.projectUsage = graphqlProjectUsage(c)
+	if err := c.projectUsage.Error(); err != nil {
+		return err
+	}
+
 	c.query = rootQuery(service, c)
 	if err := c.query.Error(); err != nil {
 		return err
@@ -58,3 +79,18 @@ func (c *TypeCreator) Create(service *satellite.Service) error {
 func (c *TypeCreator) UserType() *graphql.Object {
 	return c.user
 }
+
+// BucketUsageType returns instance of bucketUsage *graphql.Object
+func (c *TypeCreator) BucketUsageType() *graphql.Object {
+	return c.bucketUsage
+}
+
+// ProjectUsageType returns instance of projectUsage *graphql.Object
+func (c *TypeCreator) ProjectUsageType() *graphql.Object {
+	return c.projectUsage
+}
+
+// ProjectLimitsType returns instance of projectLimits *graphql.Object
+func (c *TypeCreator) ProjectLimitsType() *graphql.Object {
+	return c.projectLimits
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satelliteql
+
+import (
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	bucketUsageType   = "bucketUsage"
+	projectUsageType  = "projectUsage"
+	projectLimitsType = "projectLimits"
+
+	fieldProjectID    = "projectId"
+	fieldFrom         = "from"
+	fieldTo           = "to"
+	fieldBucket       = "bucket"
+	fieldBuckets      = "buckets"
+	fieldStoredBytes  = "storedBytes"
+	fieldSegments     = "segments"
+	fieldObjects      = "objects"
+	fieldEgressBytes  = "egressBytes"
+	fieldLimits       = "limits"
+	fieldStorageLimit = "storageLimit"
+	fieldEgressLimit  = "egressLimit"
+)
+
+// graphqlBucketUsage creates instance of bucketUsage *graphql.Object,
+// bytes are Float as they don't fit into the 32 bit graphql Int
+func graphqlBucketUsage() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: bucketUsageType,
+		Fields: graphql.Fields{
+			fieldBucket: &graphql.Field{
+				Type: graphql.String,
+			},
+			fieldStoredBytes: &graphql.Field{
+				Type: graphql.Float,
+			},
+			fieldSegments: &graphql.Field{
+				Type: graphql.Int,
+			},
+			fieldObjects: &graphql.Field{
+				Type: graphql.Int,
+			},
+			fieldEgressBytes: &graphql.Field{
+				Type: graphql.Float,
+			},
+		},
+	})
+}
+
+// graphqlProjectUsage creates instance of projectUsage *graphql.Object
+func graphqlProjectUsage(types Types) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: projectUsageType,
+		Fields: graphql.Fields{
+			fieldProjectID: &graphql.Field{
+				Type: graphql.String,
+			},
+			fieldFrom: &graphql.Field{
+				Type: graphql.DateTime,
+			},
+			fieldTo: &graphql.Field{
+				Type: graphql.DateTime,
+			},
+			fieldStoredBytes: &graphql.Field{
+				Type: graphql.Float,
+			},
+			fieldSegments: &graphql.Field{
+				Type: graphql.Int,
+			},
+			fieldObjects: &graphql.Field{
+				Type: graphql.Int,
+			},
+			fieldEgressBytes: &graphql.Field{
+				Type: graphql.Float,
+			},
+			fieldBuckets: &graphql.Field{
+				Type: graphql.NewList(types.BucketUsageType()),
+			},
+			fieldLimits: &graphql.Field{
+				Type: types.ProjectLimitsType(),
+			},
+		},
+	})
+}
+
+// graphqlProjectLimits creates instance of projectLimits *graphql.Object
+func graphqlProjectLimits() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: projectLimitsType,
+		Fields: graphql.Fields{
+			fieldStorageLimit: &graphql.Field{
+				Type: graphql.Float,
+			},
+			fieldEgressLimit: &graphql.Field{
+				Type: graphql.Float,
+			},
+		},
+	})
+}
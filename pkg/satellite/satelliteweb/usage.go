@@ -0,0 +1,69 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satelliteweb
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"storj.io/storj/pkg/accounting"
+	dbmanager "storj.io/storj/pkg/bwagreement/database-manager"
+	"storj.io/storj/pkg/satellite"
+)
+
+// usageDB combines the bucket totals kept by accounting with the egress of the settled agreements
+type usageDB struct {
+	buckets    *accounting.Usage
+	agreements *dbmanager.DBManager
+}
+
+// GetBucketUsage returns the usage of the buckets of the project with the api key hash ordered by name
+func (db *usageDB) GetBucketUsage(ctx context.Context, apiKeyHash []byte, from, to time.Time) ([]satellite.BucketUsage, error) {
+	totals, err := db.buckets.Buckets(ctx, apiKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	egress, err := db.agreements.GetBucketEgress(ctx, apiKeyHash, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var buckets []satellite.BucketUsage
+	for _, total := range totals {
+		buckets = append(buckets, satellite.BucketUsage{
+			Bucket:      total.BucketName,
+			StoredBytes: total.StoredBytes,
+			Segments:    total.Segments,
+			Objects:     total.Objects,
+			EgressBytes: egress[total.BucketName],
+		})
+		delete(egress, total.BucketName)
+	}
+
+	// deleted buckets still count for what was downloaded from them
+	for bucket, bytes := range egress {
+		buckets = append(buckets, satellite.BucketUsage{Bucket: bucket, EgressBytes: bytes})
+	}
+	sort.Slice(buckets, func(i, k int) bool {
+		return buckets[i].Bucket < buckets[k].Bucket
+	})
+
+	return buckets, nil
+}
+
+// GetLimits returns the limits of the project with the api key hash
+func (db *usageDB) GetLimits(ctx context.Context, apiKeyHash []byte) (satellite.ProjectLimits, error) {
+	limits, err := db.buckets.GetLimits(ctx, apiKeyHash)
+	if err != nil {
+		return satellite.ProjectLimits{}, err
+	}
+	return satellite.ProjectLimits{StorageBytes: limits.StorageBytes, EgressBytes: limits.EgressBytes}, nil
+}
+
+// SetLimits replaces the limits of the project with the api key hash
+func (db *usageDB) SetLimits(ctx context.Context, apiKeyHash []byte, limits satellite.ProjectLimits) error {
+	return db.buckets.SetLimits(ctx, apiKeyHash, accounting.Limits{StorageBytes: limits.StorageBytes, EgressBytes: limits.EgressBytes})
+}
@@ -21,10 +21,12 @@ type Service struct {
 	Signer
 
 	store DB
+	usage UsageDB
 }
 
-// NewService returns new instance of Service
-func NewService(signer Signer, store DB) (*Service, error) {
+// NewService returns new instance of Service, usage can be nil when the
+// usage accounting isn't available
+func NewService(signer Signer, store DB, usage UsageDB) (*Service, error) {
 	if signer == nil {
 		return nil, errs.New("signer can't be nil")
 	}
@@ -33,7 +35,7 @@ func NewService(signer Signer, store DB) (*Service, error) {
 		return nil, errs.New("store can't be nil")
 	}
 
-	return &Service{Signer: signer, store: store}, nil
+	return &Service{Signer: signer, store: store, usage: usage}, nil
 }
 
 // Register gets password hash value and creates new user
@@ -74,6 +76,103 @@ func (s *Service) Login(ctx context.Context, email, password string) (string, er
 
 // GetUser returns user by id
 func (s *Service) GetUser(ctx context.Context, id uuid.UUID) (*User, error) {
+	_, err := s.authorizeContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.Users().Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// GetProjectUsage returns the usage of the buckets of the project owned by the user,
+// egress is counted between from and to
+func (s *Service) GetProjectUsage(ctx context.Context, projectID uuid.UUID, from, to time.Time) (*ProjectUsage, error) {
+	project, err := s.getOwnedProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &ProjectUsage{ProjectID: projectID, From: from, To: to}
+
+	// a project without an api key has no buckets yet
+	if len(project.APIKeyHash) == 0 {
+		return usage, nil
+	}
+
+	usage.Buckets, err = s.usage.GetBucketUsage(ctx, project.APIKeyHash, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	usage.Limits, err = s.usage.GetLimits(ctx, project.APIKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bucket := range usage.Buckets {
+		usage.StoredBytes += bucket.StoredBytes
+		usage.Segments += bucket.Segments
+		usage.Objects += bucket.Objects
+		usage.EgressBytes += bucket.EgressBytes
+	}
+
+	return usage, nil
+}
+
+// UpdateProjectLimits replaces the storage and egress limits of the project owned by the user
+func (s *Service) UpdateProjectLimits(ctx context.Context, projectID uuid.UUID, limits ProjectLimits) (*ProjectLimits, error) {
+	if limits.StorageBytes < 0 || limits.EgressBytes < 0 {
+		return nil, errs.New("limits can't be negative")
+	}
+
+	project, err := s.getOwnedProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the limits apply to what is stored and downloaded with the api key of the project
+	if len(project.APIKeyHash) == 0 {
+		return nil, errs.New("project %s has no api key", projectID.String())
+	}
+
+	err = s.usage.SetLimits(ctx, project.APIKeyHash, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	return &limits, nil
+}
+
+// getOwnedProject returns the project if the user of the request in ctx owns it and usage accounting is available
+func (s *Service) getOwnedProject(ctx context.Context, projectID uuid.UUID) (*Project, error) {
+	claims, err := s.authorizeContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.usage == nil {
+		return nil, errs.New("usage accounting is not available")
+	}
+
+	project, err := s.store.Projects().Get(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.OwnerID == nil || *project.OwnerID != claims.ID {
+		return nil, errs.New("user %s is not the owner of project %s", claims.ID.String(), projectID.String())
+	}
+
+	return project, nil
+}
+
+// authorizeContext authenticates and authorizes the token of the request in ctx
+func (s *Service) authorizeContext(ctx context.Context) (*satelliteauth.Claims, error) {
 	token, ok := auth.GetAPIKey(ctx)
 	if !ok {
 		return nil, errs.New("no api key was provided")
@@ -89,12 +188,7 @@ func (s *Service) GetUser(ctx context.Context, id uuid.UUID) (*User, error) {
 		return nil, err
 	}
 
-	user, err := s.store.Users().Get(ctx, id)
-	if err != nil {
-		return nil, err
-	}
-
-	return user, nil
+	return claims, nil
 }
 
 func (s *Service) createToken(claims *satelliteauth.Claims) (string, error) {
@@ -0,0 +1,58 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellite
+
+import (
+	"context"
+	"time"
+
+	"github.com/skyrings/skyring-common/tools/uuid"
+)
+
+// UsageDB exposes the usage accounting of the buckets of projects
+type UsageDB interface {
+	// GetBucketUsage returns what the buckets of the project with the api key hash store
+	// and how much was downloaded from them between from and to
+	GetBucketUsage(ctx context.Context, apiKeyHash []byte, from, to time.Time) ([]BucketUsage, error)
+	// GetLimits returns the limits of the project with the api key hash
+	GetLimits(ctx context.Context, apiKeyHash []byte) (ProjectLimits, error)
+	// SetLimits replaces the limits of the project with the api key hash
+	SetLimits(ctx context.Context, apiKeyHash []byte, limits ProjectLimits) error
+}
+
+// BucketUsage describes what a bucket stores and how much was downloaded from it
+type BucketUsage struct {
+	Bucket string `json:"bucket"`
+
+	StoredBytes int64 `json:"storedBytes"`
+	Segments    int64 `json:"segments"`
+	Objects     int64 `json:"objects"`
+	// Bytes of the settled GET agreements of the bucket during the requested period.
+	EgressBytes int64 `json:"egressBytes"`
+}
+
+// ProjectUsage describes the usage of all buckets of a project
+type ProjectUsage struct {
+	ProjectID uuid.UUID `json:"projectId"`
+
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	StoredBytes int64 `json:"storedBytes"`
+	Segments    int64 `json:"segments"`
+	Objects     int64 `json:"objects"`
+	EgressBytes int64 `json:"egressBytes"`
+
+	Buckets []BucketUsage `json:"buckets"`
+
+	Limits ProjectLimits `json:"limits"`
+}
+
+// ProjectLimits are the caps of a project, zero means unlimited
+type ProjectLimits struct {
+	// StorageBytes is the maximum number of bytes the buckets of the project store together.
+	StorageBytes int64 `json:"storageLimit"`
+	// EgressBytes is the maximum number of bytes downloaded from the project during a calendar month.
+	EgressBytes int64 `json:"egressLimit"`
+}
@@ -24,13 +24,23 @@ import (
 
 var mon = monkit.Package()
 
-// Client defines an interface for storing erasure coded data to piece store nodes
+// Client defines an interface for storing erasure coded data to piece store nodes.
+// Every node gets its own order limit, limits[i] is for nodes[i].
+// Put returns the roots of the Merkle trees of the erasure shares of every piece,
+// roots[i] is nil if piece i couldn't be encoded completely.
 type Client interface {
 	Put(ctx context.Context, nodes []*pb.Node, rs eestream.RedundancyStrategy,
-		pieceID psclient.PieceID, data io.Reader, expiration time.Time, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (successfulNodes []*pb.Node, err error)
+		pieceID psclient.PieceID, data io.Reader, expiration time.Time, limits []*pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (successfulNodes []*pb.Node, roots [][]byte, err error)
 	Get(ctx context.Context, nodes []*pb.Node, es eestream.ErasureScheme,
-		pieceID psclient.PieceID, size int64, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (ranger.Ranger, error)
+		pieceID psclient.PieceID, size int64, limits []*pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (ranger.Ranger, error)
 	Delete(ctx context.Context, nodes []*pb.Node, pieceID psclient.PieceID, authorization *pb.SignedMessage) error
+	DeleteBatch(ctx context.Context, pieces []PieceNodes, authorization *pb.SignedMessage) error
+}
+
+// PieceNodes is a piece id with the nodes storing the erasure shares of the piece
+type PieceNodes struct {
+	PieceID psclient.PieceID
+	Nodes   []*pb.Node
 }
 
 type psClientFunc func(context.Context, transport.Client, *pb.Node, int) (psclient.Client, error)
@@ -57,20 +67,29 @@ func (ec *ecClient) newPSClient(ctx context.Context, n *pb.Node) (psclient.Clien
 }
 
 func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.RedundancyStrategy,
-	pieceID psclient.PieceID, data io.Reader, expiration time.Time, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (successfulNodes []*pb.Node, err error) {
+	pieceID psclient.PieceID, data io.Reader, expiration time.Time, limits []*pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (successfulNodes []*pb.Node, roots [][]byte, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	if len(nodes) != rs.TotalCount() {
-		return nil, Error.New("number of nodes (%d) do not match total count (%d) of erasure scheme", len(nodes), rs.TotalCount())
+		return nil, nil, Error.New("number of nodes (%d) do not match total count (%d) of erasure scheme", len(nodes), rs.TotalCount())
+	}
+	if len(limits) != len(nodes) {
+		return nil, nil, Error.New("number of order limits (%d) do not match number of nodes (%d)", len(limits), len(nodes))
 	}
 	if !unique(nodes) {
-		return nil, Error.New("duplicated nodes are not allowed")
+		return nil, nil, Error.New("duplicated nodes are not allowed")
 	}
 
 	padded := eestream.PadReader(ioutil.NopCloser(data), rs.StripeSize())
-	readers, err := eestream.EncodeReader(ctx, padded, rs, ec.memoryLimit)
+	encoded, err := eestream.EncodeReader(ctx, padded, rs, ec.memoryLimit)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// the shares of every piece are hashed while the piece is uploaded, so they can be audited one node at a time
+	readers := make([]*hashReader, len(encoded))
+	for i := range encoded {
+		readers[i] = newHashReader(encoded[i], rs.ErasureShareSize())
 	}
 
 	type info struct {
@@ -101,7 +120,7 @@ func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.Redun
 				infos <- info{i: i, err: err}
 				return
 			}
-			err = ps.Put(ctx, derivedPieceID, readers[i], expiration, pba, authorization)
+			err = ps.Put(ctx, derivedPieceID, readers[i], expiration, limits[i], authorization)
 			// normally the bellow call should be deferred, but doing so fails
 			// randomly the unit tests
 			utils.LogClose(ps)
@@ -138,20 +157,28 @@ func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.Redun
 	}()
 
 	if successfulCount < rs.RepairThreshold() {
-		return nil, Error.New("successful puts (%d) less than repair threshold (%d)", successfulCount, rs.RepairThreshold())
+		return nil, nil, Error.New("successful puts (%d) less than repair threshold (%d)", successfulCount, rs.RepairThreshold())
 	}
 
-	return successfulNodes, nil
+	roots = make([][]byte, len(readers))
+	for i, reader := range readers {
+		roots[i] = reader.root()
+	}
+
+	return successfulNodes, roots, nil
 }
 
 func (ec *ecClient) Get(ctx context.Context, nodes []*pb.Node, es eestream.ErasureScheme,
-	pieceID psclient.PieceID, size int64, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (rr ranger.Ranger, err error) {
+	pieceID psclient.PieceID, size int64, limits []*pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (rr ranger.Ranger, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	validNodeCount := validCount(nodes)
 	if validNodeCount < es.RequiredCount() {
 		return nil, Error.New("number of nodes (%v) do not match minimum required count (%v) of erasure scheme", len(nodes), es.RequiredCount())
 	}
+	if len(limits) != len(nodes) {
+		return nil, Error.New("number of order limits (%d) do not match number of nodes (%d)", len(limits), len(nodes))
+	}
 
 	paddedSize := calcPadded(size, es.StripeSize())
 	pieceSize := paddedSize / int64(es.RequiredCount())
@@ -183,7 +210,7 @@ func (ec *ecClient) Get(ctx context.Context, nodes []*pb.Node, es eestream.Erasu
 				node:              n,
 				id:                derivedPieceID,
 				size:              pieceSize,
-				pba:               pba,
+				pba:               limits[i],
 				authorization:     authorization,
 			}
 
@@ -252,6 +279,109 @@ func (ec *ecClient) Delete(ctx context.Context, nodes []*pb.Node, pieceID psclie
 	return nil
 }
 
+// DeleteBatch deletes the pieces with one request per node. It fails only
+// for the pieces that couldn't be deleted from any of their nodes.
+func (ec *ecClient) DeleteBatch(ctx context.Context, pieces []PieceNodes, authorization *pb.SignedMessage) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	type nodePieces struct {
+		node      *pb.Node
+		ids       []psclient.PieceID
+		indexes   []int // index of the piece in pieces for each derived id
+		positions []int // position of the node in the nodes of the piece for each derived id
+	}
+
+	// group the derived piece ids by node
+	byNode := map[string]*nodePieces{}
+	nodeCount := make([]int, len(pieces))
+	for i, piece := range pieces {
+		for position, n := range piece.Nodes {
+			if n == nil {
+				continue
+			}
+			derivedPieceID, err := piece.PieceID.Derive([]byte(n.GetId()))
+			if err != nil {
+				return err
+			}
+
+			group, ok := byNode[n.GetId()]
+			if !ok {
+				group = &nodePieces{node: n}
+				byNode[n.GetId()] = group
+			}
+			group.ids = append(group.ids, derivedPieceID)
+			group.indexes = append(group.indexes, i)
+			group.positions = append(group.positions, position)
+			nodeCount[i]++
+		}
+	}
+
+	type failure struct {
+		index    int
+		position int
+		err      error
+	}
+	results := make(chan []failure, len(byNode))
+
+	for _, group := range byNode {
+		go func(group *nodePieces) {
+			failAll := func(err error) {
+				failures := make([]failure, len(group.indexes))
+				for i, index := range group.indexes {
+					failures[i] = failure{index: index, position: group.positions[i], err: err}
+				}
+				results <- failures
+			}
+
+			ps, err := ec.newPSClient(ctx, group.node)
+			if err != nil {
+				zap.S().Errorf("Failed dialing for deleting %d pieces from node %s: %v",
+					len(group.ids), group.node.GetId(), err)
+				failAll(err)
+				return
+			}
+			failed, err := ps.DeleteBatch(ctx, group.ids, authorization)
+			utils.LogClose(ps)
+			if err != nil {
+				zap.S().Errorf("Failed deleting %d pieces from node %s: %v",
+					len(group.ids), group.node.GetId(), err)
+				failAll(err)
+				return
+			}
+
+			var failures []failure
+			for i, id := range group.ids {
+				if err, ok := failed[id]; ok {
+					zap.S().Errorf("Failed deleting piece %s -> %s from node %s: %v",
+						pieces[group.indexes[i]].PieceID, id, group.node.GetId(), err)
+					failures = append(failures, failure{index: group.indexes[i], position: group.positions[i], err: err})
+				}
+			}
+			results <- failures
+		}(group)
+	}
+
+	// report the error of the last node of a piece, so the error doesn't depend on the order of the replies
+	failedCount := make([]int, len(pieces))
+	lastErr := make([]failure, len(pieces))
+	for range byNode {
+		for _, failure := range <-results {
+			failedCount[failure.index]++
+			if lastErr[failure.index].err == nil || lastErr[failure.index].position < failure.position {
+				lastErr[failure.index] = failure
+			}
+		}
+	}
+
+	var allerrs []error
+	for i := range pieces {
+		if nodeCount[i] > 0 && failedCount[i] == nodeCount[i] {
+			allerrs = append(allerrs, lastErr[i].err)
+		}
+	}
+	return utils.CombineErrors(allerrs...)
+}
+
 func collectErrors(errs <-chan error, size int) []error {
 	var result []error
 	for i := 0; i < size; i++ {
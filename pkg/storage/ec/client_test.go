@@ -11,7 +11,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"testing"
 	"time"
 
@@ -20,6 +19,7 @@ import (
 	"github.com/vivint/infectious"
 
 	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/merkle"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/piecestore/psclient"
 	"storj.io/storj/pkg/provider"
@@ -121,8 +121,14 @@ TestLoop:
 			errs[n] = tt.errs[i]
 		}
 
+		limits := make([]*pb.PayerBandwidthAllocation, len(tt.nodes))
+		for i := range limits {
+			limits[i] = &pb.PayerBandwidthAllocation{Signature: []byte{byte(i)}}
+		}
+
 		clients := make(map[*pb.Node]psclient.Client, len(tt.nodes))
-		for _, n := range tt.nodes {
+		hashers := make([]*merkle.ShareHasher, len(tt.nodes))
+		for j, n := range tt.nodes {
 			if n == nil || tt.badInput {
 				continue
 			}
@@ -130,12 +136,14 @@ TestLoop:
 			if !assert.NoError(t, err, errTag) {
 				continue TestLoop
 			}
+			hasher := merkle.NewShareHasher(es.ErasureShareSize())
+			hashers[j] = hasher
 			ps := NewMockPSClient(ctrl)
 			gomock.InOrder(
-				ps.EXPECT().Put(gomock.Any(), derivedID, gomock.Any(), ttl, gomock.Any(), gomock.Any()).Return(errs[n]).
+				ps.EXPECT().Put(gomock.Any(), derivedID, gomock.Any(), ttl, limits[j], gomock.Any()).Return(errs[n]).
 					Do(func(ctx context.Context, id psclient.PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) {
 						// simulate that the mocked piece store client is reading the data
-						_, err := io.Copy(ioutil.Discard, data)
+						_, err := io.Copy(hasher, data)
 						assert.NoError(t, err, errTag)
 					}),
 				ps.EXPECT().Close().Return(nil),
@@ -149,7 +157,7 @@ TestLoop:
 		r := io.LimitReader(rand.Reader, int64(size))
 		ec := ecClient{newPSClientFunc: mockNewPSClient(clients), memoryLimit: tt.mbm}
 
-		successfulNodes, err := ec.Put(ctx, tt.nodes, rs, id, r, ttl, nil, nil)
+		successfulNodes, roots, err := ec.Put(ctx, tt.nodes, rs, id, r, ttl, limits, nil)
 
 		if tt.errString != "" {
 			assert.EqualError(t, err, tt.errString, errTag)
@@ -163,6 +171,12 @@ TestLoop:
 					assert.Equal(t, tt.nodes[i], successfulNodes[i], errTag)
 				}
 			}
+			assert.Equal(t, len(tt.nodes), len(roots), errTag)
+			for i := range tt.nodes {
+				if hashers[i] != nil {
+					assert.Equal(t, hashers[i].Root(), roots[i], errTag)
+				}
+			}
 		}
 	}
 }
@@ -226,20 +240,25 @@ TestLoop:
 			errs[n] = tt.errs[i]
 		}
 
+		limits := make([]*pb.PayerBandwidthAllocation, len(tt.nodes))
+		for i := range limits {
+			limits[i] = &pb.PayerBandwidthAllocation{Signature: []byte{byte(i)}}
+		}
+
 		clients := make(map[*pb.Node]psclient.Client, len(tt.nodes))
-		for _, n := range tt.nodes {
+		for j, n := range tt.nodes {
 			if errs[n] == ErrOpFailed {
 				derivedID, err := id.Derive([]byte(n.GetId()))
 				if !assert.NoError(t, err, errTag) {
 					continue TestLoop
 				}
 				ps := NewMockPSClient(ctrl)
-				ps.EXPECT().Get(gomock.Any(), derivedID, int64(size/k), gomock.Any(), gomock.Any()).Return(ranger.ByteRanger(nil), errs[n])
+				ps.EXPECT().Get(gomock.Any(), derivedID, int64(size/k), limits[j], gomock.Any()).Return(ranger.ByteRanger(nil), errs[n])
 				clients[n] = ps
 			}
 		}
 		ec := ecClient{newPSClientFunc: mockNewPSClient(clients), memoryLimit: tt.mbm}
-		rr, err := ec.Get(ctx, tt.nodes, es, id, int64(size), nil, nil)
+		rr, err := ec.Get(ctx, tt.nodes, es, id, int64(size), limits, nil)
 		if err == nil {
 			_, err := rr.Range(ctx, 0, 0)
 			assert.NoError(t, err, errTag)
@@ -312,6 +331,72 @@ TestLoop:
 	}
 }
 
+func TestDeleteBatch(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+TestLoop:
+	for i, tt := range []struct {
+		nodeErrs  []error // error of the request to node0 and node1
+		failed    []int   // index of the pieces node1 fails to delete
+		errString string
+	}{
+		{[]error{nil, nil}, nil, ""},
+		{[]error{ErrDialFailed, nil}, nil, ""},
+		{[]error{ErrOpFailed, nil}, []int{0, 1}, opFailed + "\n" + opFailed},
+		{[]error{ErrDialFailed, nil}, []int{1}, opFailed},
+		{[]error{ErrDialFailed, ErrDialFailed}, nil, dialFailed + "\n" + dialFailed},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		ids := []psclient.PieceID{psclient.NewPieceID(), psclient.NewPieceID()}
+		nodes := []*pb.Node{node0, node1}
+
+		clients := make(map[*pb.Node]psclient.Client, len(nodes))
+		for n, node := range nodes {
+			if tt.nodeErrs[n] == ErrDialFailed {
+				continue
+			}
+
+			var derivedIDs []psclient.PieceID
+			for _, id := range ids {
+				derivedID, err := id.Derive([]byte(node.GetId()))
+				if !assert.NoError(t, err, errTag) {
+					continue TestLoop
+				}
+				derivedIDs = append(derivedIDs, derivedID)
+			}
+
+			failed := map[psclient.PieceID]error{}
+			if n == 1 {
+				for _, index := range tt.failed {
+					failed[derivedIDs[index]] = ErrOpFailed
+				}
+			}
+
+			ps := NewMockPSClient(ctrl)
+			gomock.InOrder(
+				ps.EXPECT().DeleteBatch(gomock.Any(), derivedIDs, gomock.Any()).Return(failed, tt.nodeErrs[n]),
+				ps.EXPECT().Close().Return(nil),
+			)
+			clients[node] = ps
+		}
+
+		ec := ecClient{newPSClientFunc: mockNewPSClient(clients)}
+		err := ec.DeleteBatch(ctx, []PieceNodes{
+			{PieceID: ids[0], Nodes: nodes},
+			{PieceID: ids[1], Nodes: []*pb.Node{nil, node0, node1}},
+		}, nil)
+
+		if tt.errString != "" {
+			assert.EqualError(t, err, tt.errString, errTag)
+		} else {
+			assert.NoError(t, err, errTag)
+		}
+	}
+}
+
 func TestUnique(t *testing.T) {
 	for i, tt := range []struct {
 		nodes  []*pb.Node
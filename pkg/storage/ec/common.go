@@ -4,8 +4,40 @@
 package ecclient
 
 import (
+	"io"
+
 	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/merkle"
 )
 
 // Error is the errs class of standard Ranger errors
 var Error = errs.Class("ecclient error")
+
+// hashReader hashes the erasure shares of a piece while it is read
+type hashReader struct {
+	reader io.Reader
+	hasher *merkle.ShareHasher
+	eof    bool
+}
+
+func newHashReader(reader io.Reader, shareSize int) *hashReader {
+	return &hashReader{reader: reader, hasher: merkle.NewShareHasher(shareSize)}
+}
+
+func (r *hashReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	_, _ = r.hasher.Write(p[:n])
+	if err == io.EOF {
+		r.eof = true
+	}
+	return n, err
+}
+
+// root returns the root of the tree of the shares, or nil if the piece wasn't read completely
+func (r *hashReader) root() []byte {
+	if !r.eof {
+		return nil
+	}
+	return r.hasher.Root()
+}
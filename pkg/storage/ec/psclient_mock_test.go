@@ -17,6 +17,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 
+	bloomfilter "storj.io/storj/pkg/bloomfilter"
 	pb "storj.io/storj/pkg/pb"
 	client "storj.io/storj/pkg/piecestore/psclient"
 	ranger "storj.io/storj/pkg/ranger"
@@ -69,6 +70,19 @@ func (mr *MockPSClientMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPSClient)(nil).Delete), arg0, arg1, arg2)
 }
 
+// DeleteBatch mocks base method
+func (m *MockPSClient) DeleteBatch(arg0 context.Context, arg1 []client.PieceID, arg2 *pb.SignedMessage) (map[client.PieceID]error, error) {
+	ret := m.ctrl.Call(m, "DeleteBatch", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[client.PieceID]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBatch indicates an expected call of DeleteBatch
+func (mr *MockPSClientMockRecorder) DeleteBatch(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatch", reflect.TypeOf((*MockPSClient)(nil).DeleteBatch), arg0, arg1, arg2)
+}
+
 // Get mocks base method
 func (m *MockPSClient) Get(arg0 context.Context, arg1 client.PieceID, arg2 int64, arg3 *pb.PayerBandwidthAllocation, arg4 *pb.SignedMessage) (ranger.Ranger, error) {
 	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2, arg3, arg4)
@@ -95,6 +109,20 @@ func (mr *MockPSClientMockRecorder) Meta(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Meta", reflect.TypeOf((*MockPSClient)(nil).Meta), arg0, arg1)
 }
 
+// Prove mocks base method
+func (m *MockPSClient) Prove(arg0 context.Context, arg1 client.PieceID, arg2 int, arg3 int64, arg4 *pb.PayerBandwidthAllocation, arg5 *pb.SignedMessage) ([]byte, [][]byte, error) {
+	ret := m.ctrl.Call(m, "Prove", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Prove indicates an expected call of Prove
+func (mr *MockPSClientMockRecorder) Prove(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prove", reflect.TypeOf((*MockPSClient)(nil).Prove), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // Put mocks base method
 func (m *MockPSClient) Put(arg0 context.Context, arg1 client.PieceID, arg2 io.Reader, arg3 time.Time, arg4 *pb.PayerBandwidthAllocation, arg5 *pb.SignedMessage) error {
 	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2, arg3, arg4, arg5)
@@ -107,6 +135,32 @@ func (mr *MockPSClientMockRecorder) Put(arg0, arg1, arg2, arg3, arg4, arg5 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockPSClient)(nil).Put), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// RestoreTrash mocks base method
+func (m *MockPSClient) RestoreTrash(arg0 context.Context, arg1 *pb.SignedMessage) (int64, error) {
+	ret := m.ctrl.Call(m, "RestoreTrash", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTrash indicates an expected call of RestoreTrash
+func (mr *MockPSClientMockRecorder) RestoreTrash(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTrash", reflect.TypeOf((*MockPSClient)(nil).RestoreTrash), arg0, arg1)
+}
+
+// Retain mocks base method
+func (m *MockPSClient) Retain(arg0 context.Context, arg1 *bloomfilter.Filter, arg2 time.Time) (int64, error) {
+	ret := m.ctrl.Call(m, "Retain", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Retain indicates an expected call of Retain
+func (mr *MockPSClientMockRecorder) Retain(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retain", reflect.TypeOf((*MockPSClient)(nil).Retain), arg0, arg1, arg2)
+}
+
 // Stats mocks base method
 func (m *MockPSClient) Stats(arg0 context.Context) (*pb.StatSummary, error) {
 	ret := m.ctrl.Call(m, "Stats", arg0)
@@ -5,7 +5,19 @@ package segments
 
 import (
 	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/storj"
 )
 
 // Error is the errs class of standard segment errors
 var Error = errs.Class("segment error")
+
+// segmentBucket returns the bucket of the segment path, which consists of the segment,
+// the bucket and the encrypted path of the object
+func segmentBucket(path storj.Path) string {
+	components := storj.SplitPath(path)
+	if len(components) < 2 {
+		return ""
+	}
+	return components[1]
+}
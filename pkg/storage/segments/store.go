@@ -15,6 +15,7 @@ import (
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 	"storj.io/storj/pkg/dht"
 	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/merkle"
 	"storj.io/storj/pkg/node"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
@@ -50,8 +51,9 @@ type Store interface {
 	Meta(ctx context.Context, path storj.Path) (meta Meta, err error)
 	Get(ctx context.Context, path storj.Path) (rr ranger.Ranger, meta Meta, err error)
 	Repair(ctx context.Context, path storj.Path, lostPieces []int32) (err error)
-	Put(ctx context.Context, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error)
+	Put(ctx context.Context, bucket string, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error)
 	Delete(ctx context.Context, path storj.Path) (err error)
+	DeleteBatch(ctx context.Context, paths []storj.Path) (err error)
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
 }
 
@@ -72,7 +74,7 @@ func NewSegmentStore(oc overlay.Client, ec ecclient.Client, pdb pdbclient.Client
 func (s *segmentStore) Meta(ctx context.Context, path storj.Path) (meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	pr, _, err := s.pdb.Get(ctx, path)
+	pr, _, _, err := s.pdb.Get(ctx, path)
 	if err != nil {
 		return Meta{}, Error.Wrap(err)
 	}
@@ -80,8 +82,8 @@ func (s *segmentStore) Meta(ctx context.Context, path storj.Path) (meta Meta, er
 	return convertMeta(pr), nil
 }
 
-// Put uploads a segment to an erasure code client
-func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error) {
+// Put uploads a segment of an object in the bucket to an erasure code client
+func (s *segmentStore) Put(ctx context.Context, bucket string, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	exp, err := ptypes.TimestampProto(expiration)
@@ -121,9 +123,13 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 		sizedReader := SizeReader(peekReader)
 
 		authorization := s.pdb.SignedMessage()
-		pba := s.pdb.PayerBandwidthAllocation()
+		// the size of the pieces isn't known before uploading, so the satellite decides the limit
+		limits, err := s.pdb.OrderLimits(ctx, pb.PayerBandwidthAllocation_PUT, bucket, pieceID, nodes, 0)
+		if err != nil {
+			return Meta{}, Error.Wrap(err)
+		}
 		// puts file to ecclient
-		successfulNodes, err := s.ec.Put(ctx, nodes, s.rs, pieceID, sizedReader, expiration, pba, authorization)
+		successfulNodes, roots, err := s.ec.Put(ctx, nodes, s.rs, pieceID, sizedReader, expiration, limits, authorization)
 		if err != nil {
 			return Meta{}, Error.Wrap(err)
 		}
@@ -134,7 +140,7 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 		}
 		path = p
 
-		pointer, err = s.makeRemotePointer(successfulNodes, pieceID, sizedReader.Size(), exp, metadata)
+		pointer, err = s.makeRemotePointer(successfulNodes, roots, pieceID, sizedReader.Size(), exp, metadata)
 		if err != nil {
 			return Meta{}, err
 		}
@@ -154,8 +160,8 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 	return m, nil
 }
 
-// makeRemotePointer creates a pointer of type remote
-func (s *segmentStore) makeRemotePointer(nodes []*pb.Node, pieceID psclient.PieceID, readerSize int64, exp *timestamp.Timestamp, metadata []byte) (pointer *pb.Pointer, err error) {
+// makeRemotePointer creates a pointer of type remote, roots are the Merkle roots of the erasure shares of every piece
+func (s *segmentStore) makeRemotePointer(nodes []*pb.Node, roots [][]byte, pieceID psclient.PieceID, readerSize int64, exp *timestamp.Timestamp, metadata []byte) (pointer *pb.Pointer, err error) {
 	var remotePieces []*pb.RemotePiece
 	for i := range nodes {
 		if nodes[i] == nil {
@@ -180,6 +186,8 @@ func (s *segmentStore) makeRemotePointer(nodes []*pb.Node, pieceID psclient.Piec
 			},
 			PieceId:      string(pieceID),
 			RemotePieces: remotePieces,
+			MerkleRoot:   merkle.DataRoot(roots),
+			PieceRoots:   roots,
 		},
 		Size:           readerSize,
 		ExpirationDate: exp,
@@ -192,7 +200,7 @@ func (s *segmentStore) makeRemotePointer(nodes []*pb.Node, pieceID psclient.Piec
 func (s *segmentStore) Get(ctx context.Context, path storj.Path) (rr ranger.Ranger, meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	pr, nodes, err := s.pdb.Get(ctx, path)
+	pr, nodes, limits, err := s.pdb.Get(ctx, path)
 	if err != nil {
 		return nil, Meta{}, Error.Wrap(err)
 	}
@@ -200,6 +208,8 @@ func (s *segmentStore) Get(ctx context.Context, path storj.Path) (rr ranger.Rang
 	if pr.GetType() == pb.Pointer_REMOTE {
 		seg := pr.GetRemote()
 		pid := psclient.PieceID(seg.GetPieceId())
+		// the pointer comes without order limits once the project reached its egress limit
+		missingLimits := len(limits) == 0 && len(seg.GetRemotePieces()) > 0
 
 		// fall back if nodes are not available
 		if nodes == nil {
@@ -207,6 +217,15 @@ func (s *segmentStore) Get(ctx context.Context, path storj.Path) (rr ranger.Rang
 			if err != nil {
 				return nil, Meta{}, Error.Wrap(err)
 			}
+			limits = indexByPieceNum(seg, limits)
+		}
+
+		// requesting the order limits explicitly reports why they are missing
+		if missingLimits {
+			limits, err = s.pdb.OrderLimits(ctx, pb.PayerBandwidthAllocation_GET, segmentBucket(path), pid, nodes, 0)
+			if err != nil {
+				return nil, Meta{}, Error.Wrap(err)
+			}
 		}
 
 		es, err := makeErasureScheme(pr.GetRemote().GetRedundancy())
@@ -223,14 +242,14 @@ func (s *segmentStore) Get(ctx context.Context, path storj.Path) (rr ranger.Rang
 				needed--
 				if needed <= 0 {
 					nodes = nodes[:i+1]
+					limits = limits[:i+1]
 					break
 				}
 			}
 		}
 
 		authorization := s.pdb.SignedMessage()
-		pba := s.pdb.PayerBandwidthAllocation()
-		rr, err = s.ec.Get(ctx, nodes, es, pid, pr.GetSize(), pba, authorization)
+		rr, err = s.ec.Get(ctx, nodes, es, pid, pr.GetSize(), limits, authorization)
 		if err != nil {
 			return nil, Meta{}, Error.Wrap(err)
 		}
@@ -254,7 +273,7 @@ func makeErasureScheme(rs *pb.RedundancyScheme) (eestream.ErasureScheme, error)
 func (s *segmentStore) Delete(ctx context.Context, path storj.Path) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	pr, nodes, err := s.pdb.Get(ctx, path)
+	pr, nodes, _, err := s.pdb.Get(ctx, path)
 	if err != nil {
 		return Error.Wrap(err)
 	}
@@ -283,12 +302,57 @@ func (s *segmentStore) Delete(ctx context.Context, path storj.Path) (err error)
 	return s.pdb.Delete(ctx, path)
 }
 
+// DeleteBatch tells piece stores to delete the segments with one request per node
+// and deletes the pointers from pointerdb in the order of paths
+func (s *segmentStore) DeleteBatch(ctx context.Context, paths []storj.Path) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var pieces []ecclient.PieceNodes
+	for _, path := range paths {
+		pr, nodes, _, err := s.pdb.Get(ctx, path)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if pr.GetType() != pb.Pointer_REMOTE {
+			continue
+		}
+
+		seg := pr.GetRemote()
+
+		// fall back if nodes are not available
+		if nodes == nil {
+			nodes, err = s.lookupNodes(ctx, seg)
+			if err != nil {
+				return Error.Wrap(err)
+			}
+		}
+
+		pieces = append(pieces, ecclient.PieceNodes{PieceID: psclient.PieceID(seg.PieceId), Nodes: nodes})
+	}
+
+	if len(pieces) > 0 {
+		// ecclient sends one delete request per node
+		err = s.ec.DeleteBatch(ctx, pieces, s.pdb.SignedMessage())
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	// deletes pointers from pointerdb
+	for _, path := range paths {
+		if err := s.pdb.Delete(ctx, path); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	return nil
+}
+
 // Repair retrieves an at-risk segment and repairs and stores lost pieces on new nodes
 func (s *segmentStore) Repair(ctx context.Context, path storj.Path, lostPieces []int32) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	//Read the segment's pointer's info from the PointerDB
-	pr, originalNodes, err := s.pdb.Get(ctx, path)
+	pr, originalNodes, _, err := s.pdb.Get(ctx, path)
 	if err != nil {
 		return Error.Wrap(err)
 	}
@@ -374,10 +438,15 @@ func (s *segmentStore) Repair(ctx context.Context, path storj.Path, lostPieces [
 	}
 
 	signedMessage := s.pdb.SignedMessage()
-	pba := s.pdb.PayerBandwidthAllocation()
+
+	// repair traffic is accounted separately from the downloads and uploads of the uplinks
+	getLimits, err := s.pdb.OrderLimits(ctx, pb.PayerBandwidthAllocation_GET_REPAIR, "", pid, healthyNodes, 0)
+	if err != nil {
+		return Error.Wrap(err)
+	}
 
 	// download the segment using the nodes just with healthy nodes
-	rr, err := s.ec.Get(ctx, healthyNodes, es, pid, pr.GetSize(), pba, signedMessage)
+	rr, err := s.ec.Get(ctx, healthyNodes, es, pid, pr.GetSize(), getLimits, signedMessage)
 	if err != nil {
 		return Error.Wrap(err)
 	}
@@ -392,11 +461,23 @@ func (s *segmentStore) Repair(ctx context.Context, path storj.Path, lostPieces [
 	// puts file to ecclient
 	exp := pr.GetExpirationDate()
 
-	successfulNodes, err := s.ec.Put(ctx, repairNodesList, s.rs, pid, r, time.Unix(exp.GetSeconds(), 0), pba, signedMessage)
+	putLimits, err := s.pdb.OrderLimits(ctx, pb.PayerBandwidthAllocation_PUT_REPAIR, "", pid, repairNodesList, 0)
 	if err != nil {
 		return Error.Wrap(err)
 	}
 
+	successfulNodes, roots, err := s.ec.Put(ctx, repairNodesList, s.rs, pid, r, time.Unix(exp.GetSeconds(), 0), putLimits, signedMessage)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	// the segment is encoded the same way again, the roots of pieces which weren't uploaded completely are kept
+	for i, root := range seg.GetPieceRoots() {
+		if i < len(roots) && roots[i] == nil {
+			roots[i] = root
+		}
+	}
+
 	// merge the successful nodes list into the healthy nodes list
 	for i, v := range healthyNodes {
 		if v == nil {
@@ -406,7 +487,7 @@ func (s *segmentStore) Repair(ctx context.Context, path storj.Path, lostPieces [
 	}
 
 	metadata := pr.GetMetadata()
-	pointer, err := s.makeRemotePointer(healthyNodes, pid, rr.Size(), exp, metadata)
+	pointer, err := s.makeRemotePointer(healthyNodes, roots, pid, rr.Size(), exp, metadata)
 	if err != nil {
 		return err
 	}
@@ -436,6 +517,17 @@ func (s *segmentStore) lookupNodes(ctx context.Context, seg *pb.RemoteSegment) (
 	return nodes, nil
 }
 
+// indexByPieceNum orders the order limits of the remote pieces by piece number like lookupNodes orders the nodes
+func indexByPieceNum(seg *pb.RemoteSegment, limits []*pb.PayerBandwidthAllocation) []*pb.PayerBandwidthAllocation {
+	indexed := make([]*pb.PayerBandwidthAllocation, seg.GetRedundancy().GetTotal())
+	for i, p := range seg.GetRemotePieces() {
+		if i < len(limits) {
+			indexed[p.PieceNum] = limits[i]
+		}
+	}
+	return indexed
+}
+
 // List retrieves paths to segments and their metadata stored in the pointerdb
 func (s *segmentStore) List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error) {
 	defer mon.Task()(&ctx)(&err)
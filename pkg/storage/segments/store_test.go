@@ -16,9 +16,11 @@ import (
 	mock_eestream "storj.io/storj/pkg/eestream/mocks"
 	mock_overlay "storj.io/storj/pkg/overlay/mocks"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
 	pdb "storj.io/storj/pkg/pointerdb/pdbclient"
 	mock_pointerdb "storj.io/storj/pkg/pointerdb/pdbclient/mocks"
 	"storj.io/storj/pkg/ranger"
+	ecclient "storj.io/storj/pkg/storage/ec"
 	mock_ecclient "storj.io/storj/pkg/storage/ec/mocks"
 	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/pkg/storj"
@@ -71,7 +73,7 @@ func TestSegmentStoreMeta(t *testing.T) {
 		calls := []*gomock.Call{
 			mockPDB.EXPECT().Get(
 				gomock.Any(), gomock.Any(),
-			).Return(tt.returnPointer, nil, nil),
+			).Return(tt.returnPointer, nil, nil, nil),
 		}
 		gomock.InOrder(calls...)
 
@@ -114,7 +116,7 @@ func TestSegmentStorePutRemote(t *testing.T) {
 				{Id: "im-a-node"},
 			}, nil),
 			mockPDB.EXPECT().SignedMessage(),
-			mockPDB.EXPECT().PayerBandwidthAllocation(),
+			mockPDB.EXPECT().OrderLimits(gomock.Any(), pb.PayerBandwidthAllocation_PUT, "bucket", gomock.Any(), gomock.Any(), int64(0)),
 			mockEC.EXPECT().Put(
 				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
 			),
@@ -130,7 +132,7 @@ func TestSegmentStorePutRemote(t *testing.T) {
 		}
 		gomock.InOrder(calls...)
 
-		_, err := ss.Put(ctx, strings.NewReader(tt.readerContent), tt.expiration, func() (storj.Path, []byte, error) {
+		_, err := ss.Put(ctx, "bucket", strings.NewReader(tt.readerContent), tt.expiration, func() (storj.Path, []byte, error) {
 			return tt.pathInput, tt.mdInput, nil
 		})
 		assert.NoError(t, err, tt.name)
@@ -172,7 +174,7 @@ func TestSegmentStorePutInline(t *testing.T) {
 		}
 		gomock.InOrder(calls...)
 
-		_, err := ss.Put(ctx, strings.NewReader(tt.readerContent), tt.expiration, func() (storj.Path, []byte, error) {
+		_, err := ss.Put(ctx, "bucket", strings.NewReader(tt.readerContent), tt.expiration, func() (storj.Path, []byte, error) {
 			return tt.pathInput, tt.mdInput, nil
 		})
 		assert.NoError(t, err, tt.name)
@@ -218,7 +220,7 @@ func TestSegmentStoreGetInline(t *testing.T) {
 				ExpirationDate: someTime,
 				Size:           tt.size,
 				Metadata:       tt.metadata,
-			}, nil, nil),
+			}, nil, nil, nil),
 		}
 		gomock.InOrder(calls...)
 
@@ -281,17 +283,18 @@ func TestSegmentStoreRepairRemote(t *testing.T) {
 				ExpirationDate: someTime,
 				Size:           tt.size,
 				Metadata:       tt.metadata,
-			}, nil, nil),
+			}, nil, nil, nil),
 			mockOC.EXPECT().BulkLookup(gomock.Any(), gomock.Any()),
 			mockOC.EXPECT().Choose(gomock.Any(), gomock.Any()).Return(tt.newNodes, nil),
 			mockPDB.EXPECT().SignedMessage(),
-			mockPDB.EXPECT().PayerBandwidthAllocation(),
+			mockPDB.EXPECT().OrderLimits(gomock.Any(), pb.PayerBandwidthAllocation_GET_REPAIR, "", gomock.Any(), gomock.Any(), int64(0)),
 			mockEC.EXPECT().Get(
 				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
 			).Return(ranger.ByteRanger([]byte(tt.data)), nil),
+			mockPDB.EXPECT().OrderLimits(gomock.Any(), pb.PayerBandwidthAllocation_PUT_REPAIR, "", gomock.Any(), gomock.Any(), int64(0)),
 			mockEC.EXPECT().Put(
 				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
-			).Return(tt.newNodes, nil),
+			).Return(tt.newNodes, nil, nil),
 			mockES.EXPECT().RequiredCount().Return(1),
 			mockES.EXPECT().TotalCount().Return(1),
 			mockES.EXPECT().ErasureShareSize().Return(1),
@@ -354,10 +357,9 @@ func TestSegmentStoreGetRemote(t *testing.T) {
 				ExpirationDate: someTime,
 				Size:           tt.size,
 				Metadata:       tt.metadata,
-			}, nil, nil),
+			}, nil, nil, nil),
 			mockOC.EXPECT().BulkLookup(gomock.Any(), gomock.Any()),
 			mockPDB.EXPECT().SignedMessage(),
-			mockPDB.EXPECT().PayerBandwidthAllocation(),
 			mockEC.EXPECT().Get(
 				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
 			),
@@ -408,7 +410,7 @@ func TestSegmentStoreDeleteInline(t *testing.T) {
 				ExpirationDate: someTime,
 				Size:           tt.size,
 				Metadata:       tt.metadata,
-			}, nil, nil),
+			}, nil, nil, nil),
 			mockPDB.EXPECT().Delete(
 				gomock.Any(), gomock.Any(),
 			),
@@ -468,7 +470,7 @@ func TestSegmentStoreDeleteRemote(t *testing.T) {
 				ExpirationDate: someTime,
 				Size:           tt.size,
 				Metadata:       tt.metadata,
-			}, nil, nil),
+			}, nil, nil, nil),
 			mockOC.EXPECT().BulkLookup(gomock.Any(), gomock.Any()),
 			mockPDB.EXPECT().SignedMessage(),
 			mockEC.EXPECT().Delete(
@@ -485,6 +487,48 @@ func TestSegmentStoreDeleteRemote(t *testing.T) {
 	}
 }
 
+func TestSegmentStoreDeleteBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOC := mock_overlay.NewMockClient(ctrl)
+	mockEC := mock_ecclient.NewMockClient(ctrl)
+	mockPDB := mock_pointerdb.NewMockClient(ctrl)
+	mockES := mock_eestream.NewMockErasureScheme(ctrl)
+	rs := eestream.RedundancyStrategy{
+		ErasureScheme: mockES,
+	}
+
+	ss := segmentStore{mockOC, mockEC, mockPDB, rs, 10}
+
+	remote := &pb.Pointer{
+		Type: pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{
+			PieceId:      "here's my piece id",
+			RemotePieces: []*pb.RemotePiece{},
+		},
+	}
+	inline := &pb.Pointer{
+		Type:          pb.Pointer_INLINE,
+		InlineSegment: []byte("inline"),
+	}
+
+	gomock.InOrder(
+		mockPDB.EXPECT().Get(gomock.Any(), "s0/path").Return(remote, nil, nil, nil),
+		mockOC.EXPECT().BulkLookup(gomock.Any(), gomock.Any()),
+		mockPDB.EXPECT().Get(gomock.Any(), "l/path").Return(inline, nil, nil, nil),
+		mockPDB.EXPECT().SignedMessage(),
+		mockEC.EXPECT().DeleteBatch(gomock.Any(), []ecclient.PieceNodes{
+			{PieceID: psclient.PieceID("here's my piece id"), Nodes: []*pb.Node{}},
+		}, gomock.Any()),
+		mockPDB.EXPECT().Delete(gomock.Any(), "s0/path"),
+		mockPDB.EXPECT().Delete(gomock.Any(), "l/path"),
+	)
+
+	err := ss.DeleteBatch(ctx, []storj.Path{"s0/path", "l/path"})
+	assert.NoError(t, err)
+}
+
 func TestSegmentStoreList(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
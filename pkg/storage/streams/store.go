@@ -194,7 +194,7 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 			transformedReader = bytes.NewReader(cipherData)
 		}
 
-		putMeta, err = s.segments.Put(ctx, transformedReader, expiration, func() (storj.Path, []byte, error) {
+		putMeta, err = s.segments.Put(ctx, storj.SplitPath(path)[0], transformedReader, expiration, func() (storj.Path, []byte, error) {
 			encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
 			if err != nil {
 				return "", nil, err
@@ -427,19 +427,14 @@ func (s *streamStore) Delete(ctx context.Context, path storj.Path, pathCipher st
 		return err
 	}
 
+	// delete the segments together so every node is contacted only once
+	var paths []storj.Path
 	for i := 0; i < int(stream.NumberOfSegments-1); i++ {
-		encPath, err = EncryptAfterBucket(path, pathCipher, s.rootKey)
-		if err != nil {
-			return err
-		}
-		currentPath := getSegmentPath(encPath, int64(i))
-		err := s.segments.Delete(ctx, currentPath)
-		if err != nil {
-			return err
-		}
+		paths = append(paths, getSegmentPath(encPath, int64(i)))
 	}
+	paths = append(paths, storj.JoinPaths("l", encPath))
 
-	return s.segments.Delete(ctx, storj.JoinPaths("l", encPath))
+	return s.segments.DeleteBatch(ctx, paths)
 }
 
 // ListItem is a single item in a listing
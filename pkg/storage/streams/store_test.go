@@ -144,9 +144,9 @@ func TestStreamStorePut(t *testing.T) {
 		errTag := fmt.Sprintf("Test case #%d", i)
 
 		mockSegmentStore.EXPECT().
-			Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Put(gomock.Any(), "bucket", gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(test.segmentMeta, test.segmentError).
-			Do(func(ctx context.Context, data io.Reader, expiration time.Time, info func() (storj.Path, []byte, error)) {
+			Do(func(ctx context.Context, bucket string, data io.Reader, expiration time.Time, info func() (storj.Path, []byte, error)) {
 				for {
 					buf := make([]byte, 4)
 					_, err := data.Read(buf)
@@ -160,7 +160,7 @@ func TestStreamStorePut(t *testing.T) {
 			Meta(gomock.Any(), gomock.Any()).
 			Return(test.segmentMeta, test.segmentError)
 		mockSegmentStore.EXPECT().
-			Delete(gomock.Any(), gomock.Any()).
+			DeleteBatch(gomock.Any(), gomock.Any()).
 			Return(test.segmentError)
 
 		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, 0)
@@ -309,7 +309,7 @@ func TestStreamStoreDelete(t *testing.T) {
 			Meta(gomock.Any(), gomock.Any()).
 			Return(test.segmentMeta, test.segmentError)
 		mockSegmentStore.EXPECT().
-			Delete(gomock.Any(), gomock.Any()).
+			DeleteBatch(gomock.Any(), gomock.Any()).
 			Return(test.segmentError)
 
 		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, 0)
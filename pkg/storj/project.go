@@ -0,0 +1,15 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storj
+
+import (
+	"github.com/zeebo/errs"
+)
+
+var (
+	// ErrStorageLimit is an error class for uploading to a project which stores as much as it may
+	ErrStorageLimit = errs.Class("project storage limit reached")
+	// ErrEgressLimit is an error class for downloading from a project which used its egress of the month
+	ErrEgressLimit = errs.Class("project egress limit reached")
+)
@@ -0,0 +1,20 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uplinkdb
+
+import (
+	"github.com/zeebo/errs"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+)
+
+var (
+	// Error is a standard error class for this package.
+	Error = errs.Class("uplinkdb error")
+	// ErrNotRegistered is returned when a public key isn't registered for the API key
+	ErrNotRegistered = errs.Class("uplink key not registered")
+	// ErrRevoked is returned when a public key has been revoked
+	ErrRevoked = errs.Class("uplink key revoked")
+
+	mon = monkit.Package()
+)
@@ -0,0 +1,56 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uplinkdb
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/kvstore"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/utils"
+)
+
+// CtxKeyUplinkDB is used as uplinkdb key
+type CtxKeyUplinkDB int
+
+const (
+	// BoltKeysBucket is the bucket used for uplink keys in BoltDB or PostgreSQL
+	BoltKeysBucket                = "uplinkkeys"
+	ctxKey         CtxKeyUplinkDB = iota
+)
+
+// Config is a configuration struct that is everything you need to start an
+// uplink key registry responsibility
+type Config struct {
+	DatabaseURL string `help:"the database connection string to use" default:"bolt://$CONFDIR/uplinkdb.db"`
+}
+
+// Run implements the provider.Responsibility interface
+func (c Config) Run(ctx context.Context, server *provider.Provider) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db, err := kvstore.Open(c.DatabaseURL, BoltKeysBucket)
+	if err != nil {
+		return err
+	}
+	defer func() { err = utils.CombineErrors(err, db.Close()) }()
+
+	keys := New(db)
+	pb.RegisterUplinkKeysServer(server.GRPC(), NewServer(keys, zap.L()))
+
+	// add the registry to the context
+	ctx = context.WithValue(ctx, ctxKey, keys)
+	return server.Run(ctx)
+}
+
+// LoadFromContext gives access to the uplink key registry from the context, or returns nil
+func LoadFromContext(ctx context.Context) *DB {
+	if v, ok := ctx.Value(ctxKey).(*DB); ok {
+		return v
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uplinkdb
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mr-tron/base58/base58"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/storage"
+)
+
+// DB keeps the public keys uplinks registered for their API keys
+type DB struct {
+	mu sync.Mutex
+	db storage.KeyValueStore
+}
+
+// New creates a key registry stored in db
+func New(db storage.KeyValueStore) *DB {
+	return &DB{db: db}
+}
+
+// PublicKeyBytes returns the PKIX encoding of the public key, which is how uplink keys are registered
+func PublicKeyBytes(key crypto.PublicKey) ([]byte, error) {
+	return x509.MarshalPKIXPublicKey(key)
+}
+
+// KeyID returns the id of the PKIX encoded public key
+func KeyID(publicKey []byte) string {
+	hash := sha256.Sum256(publicKey)
+	return base58.Encode(hash[:])
+}
+
+// HashAPIKey returns the hash the API key is stored as
+func HashAPIKey(apiKey []byte) []byte {
+	hash := sha256.Sum256(apiKey)
+	return hash[:]
+}
+
+func keyKey(keyID string) storage.Key {
+	return storage.Key("keys/" + keyID)
+}
+
+// Register registers the PKIX encoded public key for the API key, registering a key again
+// returns the existing registration; revoked keys and keys of other API keys can't be registered
+func (db *DB) Register(apiKey, publicKey []byte) (*pb.UplinkKey, error) {
+	if _, err := x509.ParsePKIXPublicKey(publicKey); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	keyID := KeyID(publicKey)
+	key, err := db.get(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if key != nil {
+		if !bytes.Equal(key.GetApiKeyHash(), HashAPIKey(apiKey)) {
+			return nil, Error.New("key %s is registered for another API key", keyID)
+		}
+		if key.GetRevokedUnixSec() != 0 {
+			return nil, ErrRevoked.New("%s", keyID)
+		}
+		return key, nil
+	}
+
+	key = &pb.UplinkKey{
+		KeyId:          keyID,
+		PublicKey:      publicKey,
+		ApiKeyHash:     HashAPIKey(apiKey),
+		CreatedUnixSec: time.Now().Unix(),
+	}
+	return key, db.put(key)
+}
+
+// Revoke revokes the key registered for the API key, allocations issued to the key before are still valid
+func (db *DB) Revoke(apiKey []byte, keyID string) (*pb.UplinkKey, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	key, err := db.get(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || !bytes.Equal(key.GetApiKeyHash(), HashAPIKey(apiKey)) {
+		return nil, ErrNotRegistered.New("%s", keyID)
+	}
+	if key.GetRevokedUnixSec() != 0 {
+		return key, nil
+	}
+
+	key.RevokedUnixSec = time.Now().Unix()
+	return key, db.put(key)
+}
+
+// Get returns the registered key or nil when the key isn't registered
+func (db *DB) Get(keyID string) (*pb.UplinkKey, error) {
+	return db.get(keyID)
+}
+
+// List returns the keys registered for the API key, including the revoked ones
+func (db *DB) List(apiKey []byte) (keys []*pb.UplinkKey, err error) {
+	apiKeyHash := HashAPIKey(apiKey)
+	err = db.db.Iterate(storage.IterateOptions{Prefix: storage.Key("keys/"), Recurse: true},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				key := &pb.UplinkKey{}
+				if err := proto.Unmarshal(item.Value, key); err != nil {
+					return err
+				}
+				if bytes.Equal(key.GetApiKeyHash(), apiKeyHash) {
+					keys = append(keys, key)
+				}
+			}
+			return nil
+		})
+	return keys, Error.Wrap(err)
+}
+
+// Verify checks that the PKIX encoded public key is registered for the API key and isn't revoked
+func (db *DB) Verify(apiKey, publicKey []byte) error {
+	keyID := KeyID(publicKey)
+	key, err := db.get(keyID)
+	if err != nil {
+		return err
+	}
+	if key == nil || !bytes.Equal(key.GetApiKeyHash(), HashAPIKey(apiKey)) {
+		return ErrNotRegistered.New("%s", keyID)
+	}
+	if key.GetRevokedUnixSec() != 0 {
+		return ErrRevoked.New("%s", keyID)
+	}
+	return nil
+}
+
+// VerifyAt checks that the PKIX encoded public key wasn't revoked at the time,
+// keys that aren't registered are vouched for by whoever signed them into an allocation
+func (db *DB) VerifyAt(publicKey []byte, at time.Time) error {
+	keyID := KeyID(publicKey)
+	key, err := db.get(keyID)
+	if err != nil {
+		return err
+	}
+	if revoked := key.GetRevokedUnixSec(); revoked != 0 && revoked <= at.Unix() {
+		return ErrRevoked.New("%s", keyID)
+	}
+	return nil
+}
+
+func (db *DB) get(keyID string) (*pb.UplinkKey, error) {
+	value, err := db.db.Get(keyKey(keyID))
+	if storage.ErrKeyNotFound.Has(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	key := &pb.UplinkKey{}
+	if err := proto.Unmarshal(value, key); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return key, nil
+}
+
+func (db *DB) put(key *pb.UplinkKey) error {
+	value, err := proto.Marshal(key)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return Error.Wrap(db.db.Put(keyKey(key.GetKeyId()), value))
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uplinkdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/storage/teststore"
+)
+
+func newPublicKey(t *testing.T) []byte {
+	ca, err := provider.NewTestCA(context.Background())
+	require.NoError(t, err)
+	identity, err := ca.NewIdentity()
+	require.NoError(t, err)
+	publicKey, err := PublicKeyBytes(identity.Leaf.PublicKey)
+	require.NoError(t, err)
+	return publicKey
+}
+
+func TestRegisterRevoke(t *testing.T) {
+	db := New(teststore.New())
+	apiKey, otherAPIKey := []byte("apikey"), []byte("other")
+	oldKey, newKey := newPublicKey(t), newPublicKey(t)
+
+	assert.True(t, ErrNotRegistered.Has(db.Verify(apiKey, oldKey)))
+	_, err := db.Register(apiKey, []byte("not a key"))
+	assert.Error(t, err)
+
+	registered, err := db.Register(apiKey, oldKey)
+	require.NoError(t, err)
+	assert.Equal(t, KeyID(oldKey), registered.GetKeyId())
+	assert.NoError(t, db.Verify(apiKey, oldKey))
+	assert.True(t, ErrNotRegistered.Has(db.Verify(otherAPIKey, oldKey)))
+
+	// registering again returns the same key, other API keys can't take it over
+	again, err := db.Register(apiKey, oldKey)
+	require.NoError(t, err)
+	assert.Equal(t, registered.GetCreatedUnixSec(), again.GetCreatedUnixSec())
+	_, err = db.Register(otherAPIKey, oldKey)
+	assert.Error(t, err)
+	_, err = db.Revoke(otherAPIKey, KeyID(oldKey))
+	assert.True(t, ErrNotRegistered.Has(err))
+
+	// rotate the key
+	_, err = db.Register(apiKey, newKey)
+	require.NoError(t, err)
+	revoked, err := db.Revoke(apiKey, KeyID(oldKey))
+	require.NoError(t, err)
+	assert.NotZero(t, revoked.GetRevokedUnixSec())
+
+	assert.True(t, ErrRevoked.Has(db.Verify(apiKey, oldKey)))
+	assert.NoError(t, db.Verify(apiKey, newKey))
+	_, err = db.Register(apiKey, oldKey)
+	assert.True(t, ErrRevoked.Has(err))
+
+	keys, err := db.List(apiKey)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+	keys, err = db.List(otherAPIKey)
+	require.NoError(t, err)
+	assert.Len(t, keys, 0)
+
+	// allocations issued before the revocation stay valid
+	assert.NoError(t, db.VerifyAt(oldKey, time.Unix(revoked.GetRevokedUnixSec()-1, 0)))
+	assert.True(t, ErrRevoked.Has(db.VerifyAt(oldKey, time.Unix(revoked.GetRevokedUnixSec(), 0))))
+	assert.NoError(t, db.VerifyAt(newPublicKey(t), time.Now()))
+}
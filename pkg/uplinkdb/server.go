@@ -0,0 +1,111 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uplinkdb
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/pb"
+	pointerdbAuth "storj.io/storj/pkg/pointerdb/auth"
+	"storj.io/storj/pkg/provider"
+)
+
+// Server implements the uplink key registration RPC service
+type Server struct {
+	keys   *DB
+	logger *zap.Logger
+}
+
+// NewServer creates instance of Server
+func NewServer(keys *DB, logger *zap.Logger) *Server {
+	return &Server{keys: keys, logger: logger}
+}
+
+// Register registers the public key of the calling uplink for the API key
+func (s *Server) Register(ctx context.Context, req *pb.RegisterUplinkKeyRequest) (resp *pb.RegisterUplinkKeyResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	apiKey, err := s.validateAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := PeerPublicKey(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, err.Error())
+	}
+
+	key, err := s.keys.Register(apiKey, publicKey)
+	if err != nil {
+		s.logger.Error("err registering uplink key", zap.Error(err))
+		if ErrRevoked.Has(err) {
+			return nil, status.Errorf(codes.PermissionDenied, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &pb.RegisterUplinkKeyResponse{Key: key}, nil
+}
+
+// Revoke revokes a public key registered for the API key
+func (s *Server) Revoke(ctx context.Context, req *pb.RevokeUplinkKeyRequest) (resp *pb.RevokeUplinkKeyResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	apiKey, err := s.validateAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.keys.Revoke(apiKey, req.GetKeyId())
+	if err != nil {
+		if ErrNotRegistered.Has(err) {
+			return nil, status.Errorf(codes.NotFound, err.Error())
+		}
+		s.logger.Error("err revoking uplink key", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &pb.RevokeUplinkKeyResponse{Key: key}, nil
+}
+
+// List returns the public keys registered for the API key
+func (s *Server) List(ctx context.Context, req *pb.ListUplinkKeysRequest) (resp *pb.ListUplinkKeysResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	apiKey, err := s.validateAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.keys.List(apiKey)
+	if err != nil {
+		s.logger.Error("err listing uplink keys", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &pb.ListUplinkKeysResponse{Keys: keys}, nil
+}
+
+func (s *Server) validateAuth(ctx context.Context) ([]byte, error) {
+	APIKey, ok := auth.GetAPIKey(ctx)
+	if !ok || !pointerdbAuth.ValidateAPIKey(string(APIKey)) {
+		s.logger.Error("unauthorized request: ", zap.Error(status.Errorf(codes.Unauthenticated, "Invalid API credential")))
+		return nil, status.Errorf(codes.Unauthenticated, "Invalid API credential")
+	}
+	return APIKey, nil
+}
+
+// PeerPublicKey returns the PKIX encoded public key of the peer in ctx
+func PeerPublicKey(ctx context.Context) ([]byte, error) {
+	pi, err := provider.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return PublicKeyBytes(pi.Leaf.PublicKey)
+}
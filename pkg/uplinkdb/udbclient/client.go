@@ -0,0 +1,91 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package udbclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+
+	"storj.io/storj/pkg/auth/grpcauth"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/transport"
+)
+
+var (
+	mon = monkit.Package()
+)
+
+// UplinkDB creates a grpcClient
+type UplinkDB struct {
+	client pb.UplinkKeysClient
+}
+
+// Client services offerred for the interface
+type Client interface {
+	// Register registers the public key of the client identity
+	Register(ctx context.Context) (*pb.UplinkKey, error)
+	Revoke(ctx context.Context, keyID string) (*pb.UplinkKey, error)
+	List(ctx context.Context) ([]*pb.UplinkKey, error)
+}
+
+// New Used as a public function
+func New(client pb.UplinkKeysClient) *UplinkDB {
+	return &UplinkDB{client: client}
+}
+
+// NewClient initializes a new uplink key registry client
+func NewClient(identity *provider.FullIdentity, address string, APIKey string) (*UplinkDB, error) {
+	apiKeyInjector := grpcauth.NewAPIKeyInjector(APIKey)
+	tc := transport.NewClient(identity)
+	conn, err := tc.DialAddress(
+		context.Background(),
+		address,
+		grpc.WithUnaryInterceptor(apiKeyInjector),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UplinkDB{client: pb.NewUplinkKeysClient(conn)}, nil
+}
+
+// a compiler trick to make sure *UplinkDB implements Client
+var _ Client = (*UplinkDB)(nil)
+
+// Register registers the public key of the client identity for the API key,
+// keys are rotated by registering a new identity and revoking the old key
+func (udb *UplinkDB) Register(ctx context.Context) (key *pb.UplinkKey, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	res, err := udb.client.Register(ctx, &pb.RegisterUplinkKeyRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetKey(), nil
+}
+
+// Revoke revokes the key registered for the API key
+func (udb *UplinkDB) Revoke(ctx context.Context, keyID string) (key *pb.UplinkKey, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	res, err := udb.client.Revoke(ctx, &pb.RevokeUplinkKeyRequest{KeyId: keyID})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetKey(), nil
+}
+
+// List returns the keys registered for the API key
+func (udb *UplinkDB) List(ctx context.Context) (keys []*pb.UplinkKey, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	res, err := udb.client.List(ctx, &pb.ListUplinkKeysRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetKeys(), nil
+}
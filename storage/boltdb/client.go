@@ -151,6 +151,24 @@ func (client *Client) Delete(key storage.Key) error {
 	})
 }
 
+// CompareAndSwap atomically replaces the value of key with newValue if its current value is oldValue
+func (client *Client) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+
+	return client.update(func(bucket *bolt.Bucket) error {
+		data := bucket.Get([]byte(key))
+		if (oldValue == nil) != (len(data) == 0) || !bytes.Equal(data, oldValue) {
+			return storage.ErrValueChanged.New(key.String())
+		}
+		if newValue == nil {
+			return bucket.Delete(key)
+		}
+		return bucket.Put(key, newValue)
+	})
+}
+
 // List returns either a list of keys for which boltdb has values or an error.
 func (client *Client) List(first storage.Key, limit int) (storage.Keys, error) {
 	return storage.ListKeys(client, first, limit)
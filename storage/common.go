@@ -6,6 +6,7 @@ package storage
 import (
 	"bytes"
 	"errors"
+	"time"
 
 	"github.com/zeebo/errs"
 )
@@ -19,9 +20,15 @@ var ErrKeyNotFound = errs.Class("key not found")
 // ErrEmptyKey is returned when an empty key is used in Put
 var ErrEmptyKey = errs.Class("empty key")
 
+// ErrValueChanged is returned when the current value of the key does not match the oldValue in CompareAndSwap
+var ErrValueChanged = errs.Class("value changed")
+
 // ErrEmptyQueue is returned when attempting to Dequeue from an empty queue
 var ErrEmptyQueue = errors.New("empty queue")
 
+// ErrLeaseExpired is returned when acknowledging or releasing a queue element whose lease timed out
+var ErrLeaseExpired = errors.New("lease expired")
+
 // ErrLimitExceeded is returned when request limit is exceeded
 var ErrLimitExceeded = errors.New("limit exceeded")
 
@@ -60,6 +67,10 @@ type KeyValueStore interface {
 	GetAll(Keys) (Values, error)
 	// Delete deletes key and the value
 	Delete(Key) error
+	// CompareAndSwap replaces the value of key with newValue if its current value is oldValue,
+	// returning ErrValueChanged otherwise. A nil oldValue stands for a missing key and
+	// a nil newValue deletes the key
+	CompareAndSwap(key Key, oldValue, newValue Value) error
 	// List lists all keys starting from start and upto limit items
 	List(start Key, limit int) (Keys, error)
 	// ReverseList lists all keys in revers order
@@ -70,18 +81,40 @@ type KeyValueStore interface {
 	Close() error
 }
 
-//Queue is an interface describing queue stores like redis
+// Queue is an interface describing queue stores like redis. Elements are dequeued by ascending
+// priority and in FIFO order among elements of the same priority, every release by Nack adds one
+// to the priority of an element. Dequeued elements are leased:
+// they are hidden from the other consumers until they are acknowledged or released, or until the
+// lease times out and the element is dequeued again.
 type Queue interface {
-	//Enqueue add a FIFO element
-	Enqueue(Value) error
-	//Dequeue removes a FIFO element, returning ErrEmptyQueue if empty
-	Dequeue() (Value, error)
-	//Peekqueue returns 'limit' elements from the queue
+	//Enqueue adds an element with the priority unless an element with the key is queued or leased.
+	//A queued element with the key gets the value and the lower of both priorities instead.
+	Enqueue(key Key, value Value, priority int64) error
+	//Dequeue leases the first element for timeout, returning ErrEmptyQueue if empty
+	Dequeue(timeout time.Duration) (Lease, error)
+	//Ack removes the leased element from the queue, returning ErrLeaseExpired if the lease is gone
+	Ack(lease LeaseID) error
+	//Nack releases the leased element to its place in the queue after delay, returning ErrLeaseExpired if the lease
+	//is gone. The element stays hidden like a leased one until then, the release is counted as a failure.
+	Nack(lease LeaseID, delay time.Duration) error
+	//Peekqueue returns 'limit' elements from the queue which aren't leased in the order they are dequeued
 	Peekqueue(limit int) ([]Value, error)
 	//Close closes the store
 	Close() error
 }
 
+// LeaseID identifies a single lease of a queue element, an element which is dequeued again
+// after its lease timed out gets a new id
+type LeaseID string
+
+// Lease is a queue element leased by Dequeue
+type Lease struct {
+	ID    LeaseID
+	Value Value
+	// Failures is how often the element was released by Nack since it was enqueued
+	Failures int64
+}
+
 // IterateOptions contains options for iterator
 type IterateOptions struct {
 	// Prefix ensure
@@ -10,7 +10,9 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	"storj.io/storj/pkg/utils"
 	"storj.io/storj/storage"
@@ -39,15 +41,17 @@ func NewDir(path string) (*Dir, error) {
 		os.MkdirAll(dir.blobdir(), dirPermission),
 		os.MkdirAll(dir.tempdir(), dirPermission),
 		os.MkdirAll(dir.trashdir(), dirPermission),
+		os.MkdirAll(dir.quarantinedir(), dirPermission),
 	)
 }
 
 // Path returns the directory path
 func (dir *Dir) Path() string { return dir.path }
 
-func (dir *Dir) blobdir() string  { return filepath.Join(dir.path) }
-func (dir *Dir) tempdir() string  { return filepath.Join(dir.path, "tmp") }
-func (dir *Dir) trashdir() string { return filepath.Join(dir.path, "trash") }
+func (dir *Dir) blobdir() string       { return filepath.Join(dir.path) }
+func (dir *Dir) tempdir() string       { return filepath.Join(dir.path, "tmp") }
+func (dir *Dir) trashdir() string      { return filepath.Join(dir.path, "trash") }
+func (dir *Dir) quarantinedir() string { return filepath.Join(dir.path, "quarantine") }
 
 // CreateTemporaryFile creates a preallocated temporary file in the temp directory
 // prealloc preallocates file to make writing faster
@@ -213,6 +217,86 @@ func removeAllContent(path string) error {
 	}
 }
 
+// SpaceUsed walks the blob folder and returns the total size of the blob contents
+func (dir *Dir) SpaceUsed() (total int64, err error) {
+	err = filepath.Walk(dir.blobdir(), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			// pending, deleted and quarantined blobs don't count
+			if dir.isSpecialDir(path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Size() > headerSize {
+			total += info.Size() - headerSize
+		}
+		return nil
+	})
+	return total, err
+}
+
+// BlobInfo describes a committed blob
+type BlobInfo struct {
+	Ref      storage.BlobRef
+	Size     int64 // size of the blob content
+	Modified time.Time
+}
+
+// Walk calls fn for every committed blob in the blob folder
+func (dir *Dir) Walk(fn func(BlobInfo) error) error {
+	return filepath.Walk(dir.blobdir(), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if dir.isSpecialDir(path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		ref, ok := dir.pathToRef(path)
+		if !ok {
+			return nil
+		}
+
+		size := info.Size() - headerSize
+		if size < 0 {
+			size = 0
+		}
+		return fn(BlobInfo{Ref: ref, Size: size, Modified: info.ModTime()})
+	})
+}
+
+// Quarantine moves the blob out of the blob folder for later inspection
+func (dir *Dir) Quarantine(ref storage.BlobRef) error {
+	path := dir.refToPath(ref)
+	return os.Rename(path, filepath.Join(dir.quarantinedir(), hex.EncodeToString(ref[:])))
+}
+
+// isSpecialDir returns whether path is a folder that doesn't contain committed blobs
+func (dir *Dir) isSpecialDir(path string) bool {
+	return path == dir.tempdir() || path == dir.trashdir() || path == dir.quarantinedir()
+}
+
+// pathToRef converts a filepath in the blob folder back to the blob reference
+func (dir *Dir) pathToRef(path string) (ref storage.BlobRef, ok bool) {
+	rel, err := filepath.Rel(dir.blobdir(), path)
+	if err != nil {
+		return ref, false
+	}
+
+	data, err := hex.DecodeString(strings.Replace(filepath.ToSlash(rel), "/", "", 1))
+	if err != nil || len(data) != len(ref) {
+		return ref, false
+	}
+	copy(ref[:], data)
+	return ref, true
+}
+
 // DiskInfo contains statistics about this dir
 type DiskInfo struct {
 	ID             string
@@ -84,6 +84,29 @@ func (store *Store) GarbageCollect(ctx context.Context) error {
 	return nil
 }
 
+// SpaceUsed returns the total size of the stored blobs
+func (store *Store) SpaceUsed(ctx context.Context) (int64, error) {
+	total, err := store.dir.SpaceUsed()
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+	return total, nil
+}
+
+// Walk calls fn for every stored blob
+func (store *Store) Walk(ctx context.Context, fn func(BlobInfo) error) error {
+	return store.dir.Walk(fn)
+}
+
+// Quarantine moves the blob with the specified hash out of the store
+func (store *Store) Quarantine(ctx context.Context, hash storage.BlobRef) error {
+	err := store.dir.Quarantine(hash)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return nil
+}
+
 // Store stores r to disk, optionally takes a size argument, -1 is unknown size
 func (store *Store) Store(ctx context.Context, r io.Reader, size int64) (storage.BlobRef, error) {
 	file, err := store.dir.CreateTemporaryFile(size)
@@ -96,6 +96,15 @@ func TestStoreLoad(t *testing.T) {
 		}
 	}
 
+	// only the contents of the stored blobs are counted
+	used, err := store.SpaceUsed(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used != int64(len(refs)*blobSize) {
+		t.Fatalf("expected %d bytes used got %d", len(refs)*blobSize, used)
+	}
+
 	// try reading all the blobs
 	for ref := range refs {
 		reader, err := store.Load(ctx, ref)
@@ -130,6 +139,14 @@ func TestStoreLoad(t *testing.T) {
 		}
 	}
 
+	used, err = store.SpaceUsed(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used != 0 {
+		t.Fatalf("expected no space used got %d", used)
+	}
+
 	// try reading all the blobs
 	for ref := range refs {
 		_, err := store.Load(ctx, ref)
@@ -223,3 +240,55 @@ func BenchmarkStoreDelete(b *testing.B) {
 	}
 	b.StopTimer()
 }
+
+func TestWalkQuarantine(t *testing.T) {
+	ctx := context.Background()
+
+	_, store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	refs := map[storage.BlobRef]int64{}
+	for _, content := range []string{"a", "bb", "ccc"} {
+		ref, err := store.Store(ctx, bytes.NewReader([]byte(content)), -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		refs[ref] = int64(len(content))
+	}
+
+	walk := func() map[storage.BlobRef]int64 {
+		walked := map[storage.BlobRef]int64{}
+		err := store.Walk(ctx, func(info filestore.BlobInfo) error {
+			walked[info.Ref] = info.Size
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return walked
+	}
+
+	walked := walk()
+	if len(walked) != len(refs) {
+		t.Fatalf("expected %d blobs got %d", len(refs), len(walked))
+	}
+	for ref, size := range refs {
+		if walked[ref] != size {
+			t.Fatalf("expected size %d for %x got %d", size, ref, walked[ref])
+		}
+	}
+
+	for ref := range refs {
+		if err := store.Quarantine(ctx, ref); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.Load(ctx, ref); !os.IsNotExist(err) {
+			t.Fatalf("expected quarantined blob to be gone, got %v", err)
+		}
+		break
+	}
+
+	if walked := walk(); len(walked) != len(refs)-1 {
+		t.Fatalf("expected %d blobs got %d", len(refs)-1, len(walked))
+	}
+}
@@ -136,7 +136,7 @@ func newAlternateOrderedPostgresIterator(altClient *AlternateClient, opts storag
 	opi1 := &orderedPostgresIterator{
 		client:    altClient.Client,
 		opts:      &opts,
-		bucket:    storage.Key(defaultBucket),
+		bucket:    altClient.bucket,
 		delimiter: byte('/'),
 		batchSize: batchSize,
 		curIndex:  0,
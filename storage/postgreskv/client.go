@@ -24,10 +24,17 @@ const (
 type Client struct {
 	URL    string
 	pgConn *sql.DB
+	bucket storage.Key // bucket of the keys used without a bucket
 }
 
 // New instantiates a new postgreskv client given db URL
 func New(dbURL string) (*Client, error) {
+	return NewBucket(dbURL, defaultBucket)
+}
+
+// NewBucket instantiates a new postgreskv client given db URL, which keeps its keys
+// in the bucket apart from the keys of other clients of the database
+func NewBucket(dbURL, bucket string) (*Client, error) {
 	pgConn, err := sql.Open("postgres", dbURL)
 	if err != nil {
 		return nil, err
@@ -39,12 +46,13 @@ func New(dbURL string) (*Client, error) {
 	return &Client{
 		URL:    dbURL,
 		pgConn: pgConn,
+		bucket: storage.Key(bucket),
 	}, nil
 }
 
 // Put sets the value for the provided key.
 func (client *Client) Put(key storage.Key, value storage.Value) error {
-	return client.PutPath(storage.Key(defaultBucket), key, value)
+	return client.PutPath(client.bucket, key, value)
 }
 
 // PutPath sets the value for the provided key (in the given bucket).
@@ -63,7 +71,7 @@ func (client *Client) PutPath(bucket, key storage.Key, value storage.Value) erro
 
 // Get looks up the provided key and returns its value (or an error).
 func (client *Client) Get(key storage.Key) (storage.Value, error) {
-	return client.GetPath(storage.Key(defaultBucket), key)
+	return client.GetPath(client.bucket, key)
 }
 
 // GetPath looks up the provided key (in the given bucket) and returns its value (or an error).
@@ -87,7 +95,7 @@ func (client *Client) GetPath(bucket, key storage.Key) (storage.Value, error) {
 
 // Delete deletes the given key and its associated value.
 func (client *Client) Delete(key storage.Key) error {
-	return client.DeletePath(storage.Key(defaultBucket), key)
+	return client.DeletePath(client.bucket, key)
 }
 
 // DeletePath deletes the given key (in the given bucket) and its associated value.
@@ -111,6 +119,60 @@ func (client *Client) DeletePath(bucket, key storage.Key) error {
 	return nil
 }
 
+// CompareAndSwap replaces the value of key with newValue if its current value is oldValue.
+func (client *Client) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	return client.CompareAndSwapPath(client.bucket, key, oldValue, newValue)
+}
+
+// CompareAndSwapPath replaces the value of key (in the given bucket) with newValue if its current value is oldValue.
+func (client *Client) CompareAndSwapPath(bucket, key storage.Key, oldValue, newValue storage.Value) error {
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+
+	var result sql.Result
+	var err error
+	switch {
+	case oldValue == nil && newValue == nil:
+		var exists bool
+		q := "SELECT EXISTS (SELECT 1 FROM pathdata WHERE bucket = $1::BYTEA AND fullpath = $2::BYTEA)"
+		if err := client.pgConn.QueryRow(q, []byte(bucket), []byte(key)).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return storage.ErrValueChanged.New(key.String())
+		}
+		return nil
+	case oldValue == nil:
+		q := `
+			INSERT INTO pathdata (bucket, fullpath, metadata)
+				VALUES ($1::BYTEA, $2::BYTEA, $3::BYTEA)
+				ON CONFLICT (bucket, fullpath) DO NOTHING
+		`
+		result, err = client.pgConn.Exec(q, []byte(bucket), []byte(key), []byte(newValue))
+	case newValue == nil:
+		q := "DELETE FROM pathdata WHERE bucket = $1::BYTEA AND fullpath = $2::BYTEA AND metadata = $3::BYTEA"
+		result, err = client.pgConn.Exec(q, []byte(bucket), []byte(key), []byte(oldValue))
+	default:
+		q := `
+			UPDATE pathdata SET metadata = $4::BYTEA
+				WHERE bucket = $1::BYTEA AND fullpath = $2::BYTEA AND metadata = $3::BYTEA
+		`
+		result, err = client.pgConn.Exec(q, []byte(bucket), []byte(key), []byte(oldValue), []byte(newValue))
+	}
+	if err != nil {
+		return err
+	}
+	numRows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if numRows == 0 {
+		return storage.ErrValueChanged.New(key.String())
+	}
+	return nil
+}
+
 // List returns either a list of known keys, in order, or an error.
 func (client *Client) List(first storage.Key, limit int) (storage.Keys, error) {
 	return storage.ListKeys(client, first, limit)
@@ -130,7 +192,7 @@ func (client *Client) Close() error {
 // GetAll finds all values for the provided keys (up to storage.LookupLimit).
 // If more keys are provided than the maximum, an error will be returned.
 func (client *Client) GetAll(keys storage.Keys) (storage.Values, error) {
-	return client.GetAllPath(storage.Key(defaultBucket), keys)
+	return client.GetAllPath(client.bucket, keys)
 }
 
 // GetAllPath finds all values for the provided keys (up to storage.LookupLimit)
@@ -275,7 +337,7 @@ func newOrderedPostgresIterator(pgClient *Client, opts storage.IterateOptions, b
 	opi := &orderedPostgresIterator{
 		client:    pgClient,
 		opts:      &opts,
-		bucket:    storage.Key(defaultBucket),
+		bucket:    pgClient.bucket,
 		delimiter: byte('/'),
 		batchSize: batchSize,
 		curIndex:  0,
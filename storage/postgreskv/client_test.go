@@ -53,6 +53,40 @@ func TestSuite(t *testing.T) {
 	testsuite.RunTests(t, storelogger.New(zap, store))
 }
 
+func TestBuckets(t *testing.T) {
+	if *testPostgres == "" {
+		t.Skipf("postgres flag missing, example:\n-postgres-test-db=%s", defaultPostgresConn)
+	}
+
+	a, err := NewBucket(*testPostgres, "bucket-a")
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	defer func() { _ = a.Close() }()
+	b, err := NewBucket(*testPostgres, "bucket-b")
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	defer func() { _ = b.Close() }()
+
+	if err := a.Put(storage.Key("key"), storage.Value("value")); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = a.Delete(storage.Key("key")) }()
+
+	// the keys of other buckets aren't seen
+	if _, err := b.Get(storage.Key("key")); !storage.ErrKeyNotFound.Has(err) {
+		t.Fatalf("expected the key not to be found got %v", err)
+	}
+	keys, err := b.List(nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 0 {
+		t.Fatalf("expected no keys got %v", keys)
+	}
+}
+
 func BenchmarkSuite(b *testing.B) {
 	store, cleanup := newTestPostgres(b)
 	defer cleanup()
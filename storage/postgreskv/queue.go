@@ -0,0 +1,138 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package postgreskv
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"storj.io/storj/pkg/utils"
+	"storj.io/storj/storage"
+)
+
+// Queue is a postgres backed storage.Queue
+type Queue Client
+
+// NewQueue instantiates a new postgres queue given db URL
+func NewQueue(dbURL string) (*Queue, error) {
+	client, err := New(dbURL)
+	return (*Queue)(client), err
+}
+
+// Close closes the postgres connection
+func (queue *Queue) Close() error {
+	return queue.pgConn.Close()
+}
+
+// Enqueue adds an element unless an element with the key is queued or leased
+func (queue *Queue) Enqueue(key storage.Key, value storage.Value, priority int64) error {
+	q := `
+		INSERT INTO queue (key, value, priority) VALUES ($1::BYTEA, $2::BYTEA, $3)
+		ON CONFLICT (key) DO UPDATE
+		   SET value = EXCLUDED.value,
+		       priority = LEAST(queue.priority, EXCLUDED.priority)
+		 WHERE queue.lease IS NULL
+	`
+	_, err := queue.pgConn.Exec(q, []byte(key), []byte(value), priority)
+	return err
+}
+
+// Dequeue leases the first element which isn't leased or whose lease timed out,
+// every failure lowers the priority of an element
+func (queue *Queue) Dequeue(timeout time.Duration) (storage.Lease, error) {
+	q := `
+		UPDATE queue
+		   SET lease = nextval('queue_lease_seq'),
+		       leased_until = now() + $1::BIGINT * INTERVAL '1 microsecond'
+		 WHERE id = (
+		    SELECT id FROM queue
+		     WHERE leased_until IS NULL OR leased_until <= now()
+		     ORDER BY priority + failures, id
+		     LIMIT 1
+		       FOR UPDATE SKIP LOCKED
+		 )
+		RETURNING lease, value, failures
+	`
+	var lease, failures int64
+	var value []byte
+	err := queue.pgConn.QueryRow(q, int64(timeout/time.Microsecond)).Scan(&lease, &value, &failures)
+	if err == sql.ErrNoRows {
+		return storage.Lease{}, storage.ErrEmptyQueue
+	}
+	if err != nil {
+		return storage.Lease{}, err
+	}
+	return storage.Lease{ID: storage.LeaseID(strconv.FormatInt(lease, 10)), Value: storage.Value(value), Failures: failures}, nil
+}
+
+// Ack removes the leased element
+func (queue *Queue) Ack(id storage.LeaseID) error {
+	return queue.release("DELETE FROM queue WHERE lease = $1", id)
+}
+
+// Nack releases the leased element to its place in the queue after delay, which is kept by its id.
+// Until then the element is leased by a lease nobody holds.
+func (queue *Queue) Nack(id storage.LeaseID, delay time.Duration) error {
+	if delay <= 0 {
+		return queue.release("UPDATE queue SET lease = NULL, leased_until = NULL, failures = failures + 1 WHERE lease = $1", id)
+	}
+	q := `
+		UPDATE queue
+		   SET lease = nextval('queue_lease_seq'),
+		       leased_until = now() + $2::BIGINT * INTERVAL '1 microsecond',
+		       failures = failures + 1
+		 WHERE lease = $1
+	`
+	return queue.release(q, id, int64(delay/time.Microsecond))
+}
+
+// release ends the lease with the statement q, which gets the lease as $1 and args after it
+func (queue *Queue) release(q string, id storage.LeaseID, args ...interface{}) error {
+	lease, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil {
+		return storage.ErrLeaseExpired
+	}
+	result, err := queue.pgConn.Exec(q, append([]interface{}{lease}, args...)...)
+	if err != nil {
+		return err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		// the element was leased again after the lease timed out
+		return storage.ErrLeaseExpired
+	}
+	return nil
+}
+
+// Peekqueue returns upto 'limit' elements which aren't leased
+func (queue *Queue) Peekqueue(limit int) (values []storage.Value, err error) {
+	if limit < 0 || limit > storage.LookupLimit {
+		limit = storage.LookupLimit
+	}
+	q := `
+		SELECT value FROM queue
+		 WHERE leased_until IS NULL OR leased_until <= now()
+		 ORDER BY priority + failures, id
+		 LIMIT $1
+	`
+	rows, err := queue.pgConn.Query(q, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	values = make([]storage.Value, 0)
+	for rows.Next() {
+		var value []byte
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, storage.Value(value))
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,28 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package postgreskv
+
+import (
+	"testing"
+
+	"storj.io/storj/storage/testsuite"
+)
+
+func TestQueue(t *testing.T) {
+	if *testPostgres == "" {
+		t.Skipf("postgres flag missing, example:\n-postgres-test-db=%s", defaultPostgresConn)
+	}
+
+	queue, err := NewQueue(*testPostgres)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	defer func() {
+		if err := queue.Close(); err != nil {
+			t.Fatalf("failed to close db: %v", err)
+		}
+	}()
+
+	testsuite.RunQueueTests(t, queue)
+}
@@ -0,0 +1,2 @@
+DROP SEQUENCE queue_lease_seq;
+DROP TABLE queue;
@@ -0,0 +1,13 @@
+-- the elements of storage.Queue in FIFO order of their ids, an element is hidden from
+-- Dequeue while it is leased
+CREATE TABLE queue (
+    id BIGSERIAL
+        PRIMARY KEY,
+    value BYTEA
+        NOT NULL,
+    lease BIGINT
+        UNIQUE,
+    leased_until TIMESTAMP WITH TIME ZONE
+);
+
+CREATE SEQUENCE queue_lease_seq;
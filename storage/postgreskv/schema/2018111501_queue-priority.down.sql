@@ -0,0 +1,4 @@
+DROP INDEX queue_priority_id_index;
+ALTER TABLE queue
+    DROP COLUMN priority,
+    DROP COLUMN key;
@@ -0,0 +1,10 @@
+-- elements are dequeued by priority, an element with a key which is queued or leased
+-- already isn't added again
+ALTER TABLE queue
+    ADD COLUMN key BYTEA
+        UNIQUE,
+    ADD COLUMN priority BIGINT
+        NOT NULL
+        DEFAULT 0;
+
+CREATE INDEX queue_priority_id_index ON queue (priority, id);
@@ -0,0 +1,2 @@
+ALTER TABLE queue
+    DROP COLUMN failures;
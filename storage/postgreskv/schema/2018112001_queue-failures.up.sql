@@ -0,0 +1,6 @@
+-- failures counts how often an element was released by Nack, a released element can be
+-- leased until a delay passed
+ALTER TABLE queue
+    ADD COLUMN failures BIGINT
+        NOT NULL
+        DEFAULT 0;
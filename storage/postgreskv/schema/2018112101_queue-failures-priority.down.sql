@@ -0,0 +1,3 @@
+DROP INDEX queue_priority_failures_id_index;
+
+CREATE INDEX queue_priority_id_index ON queue (priority, id);
@@ -0,0 +1,4 @@
+-- every failure lowers the priority of an element
+DROP INDEX queue_priority_id_index;
+
+CREATE INDEX queue_priority_failures_id_index ON queue ((priority + failures), id);
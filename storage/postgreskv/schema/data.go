@@ -2,6 +2,14 @@
 // sources:
 // 2018092201_initial-tables.down.sql
 // 2018092201_initial-tables.up.sql
+// 2018111401_queue.down.sql
+// 2018111401_queue.up.sql
+// 2018111501_queue-priority.down.sql
+// 2018111501_queue-priority.up.sql
+// 2018112001_queue-failures.down.sql
+// 2018112001_queue-failures.up.sql
+// 2018112101_queue-failures-priority.down.sql
+// 2018112101_queue-failures-priority.up.sql
 package schema
 
 import (
@@ -107,6 +115,166 @@ func _2018092201_initialTablesUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __2018111401_queueDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x31\x00\xce\xff\x44\x52\x4f\x50\x20\x53\x45\x51\x55\x45\x4e\x43\x45\x20\x71\x75\x65\x75\x65\x5f\x6c\x65\x61\x73\x65\x5f\x73\x65\x71\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x71\x75\x65\x75\x65\x3b\x0a\x03\x00\x67\x90\xb3\x28\x31\x00\x00\x00")
+
+func _2018111401_queueDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__2018111401_queueDownSql,
+		"2018111401_queue.down.sql",
+	)
+}
+
+func _2018111401_queueDownSql() (*asset, error) {
+	bytes, err := _2018111401_queueDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "2018111401_queue.down.sql", size: 49, mode: os.FileMode(420), modTime: time.Unix(1542153600, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __2018111401_queueUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4c\x8e\xc1\x6e\xb3\x30\x10\x84\xef\x7e\x8a\x39\xfe\xbf\x14\xfa\x02\x39\x99\x74\xd3\x5a\x05\x13\xc0\xa8\xa2\x17\x84\xe4\x4d\xb1\xe4\x80\x82\xa1\x7d\xfd\x0a\xa7\x89\x2a\xed\x65\x67\xbf\x9d\x99\x24\xc1\x32\x30\xd8\xf3\x85\xc7\x25\x60\x3a\x23\x2c\xd3\xdc\x7f\xf2\x53\xb9\xf2\xca\x70\x23\x8e\xea\x58\x60\x9a\x2d\xcf\xdb\x79\x19\xd8\xcd\x70\x36\xec\xd0\x8f\xf7\x47\xb8\x80\xc1\x59\xcb\x23\xce\xf3\x74\x11\x49\x82\x67\xbe\x46\x83\xef\xc1\x79\x86\x8b\x88\xe7\x3e\xb0\x15\x87\x8a\xa4\x21\x18\x99\x66\x84\x1b\xf5\x4f\x00\x80\xb3\x48\xd5\x4b\x4d\x95\x92\x59\x14\xb6\x39\x55\x2a\x97\x55\x8b\x37\x6a\x77\x51\xfc\xea\xfd\xca\x48\x5b\x43\xf2\x01\xe9\xc2\x40\x37\x59\x76\x23\x62\xce\x66\xa5\xb4\x79\x20\x8d\x56\x65\x43\x7f\x00\xdb\xad\xe3\xe2\x3c\x8c\xca\xa9\x36\x32\x3f\xe1\x5d\x99\xd7\xb8\xe2\xa3\xd0\x24\xfe\xef\xc5\xbd\x6b\x4d\x65\x43\xfa\xf0\x5b\xb7\xf3\xdc\x07\xee\x02\x5f\xf7\xe2\x67\x00\x37\x8c\x60\x8d\x42\x01\x00\x00")
+
+func _2018111401_queueUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__2018111401_queueUpSql,
+		"2018111401_queue.up.sql",
+	)
+}
+
+func _2018111401_queueUpSql() (*asset, error) {
+	bytes, err := _2018111401_queueUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "2018111401_queue.up.sql", size: 322, mode: os.FileMode(420), modTime: time.Unix(1542153600, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __2018111501_queuePriorityDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x65\x00\x9a\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x71\x75\x65\x75\x65\x5f\x70\x72\x69\x6f\x72\x69\x74\x79\x5f\x69\x64\x5f\x69\x6e\x64\x65\x78\x3b\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x71\x75\x65\x75\x65\x0a\x20\x20\x20\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x70\x72\x69\x6f\x72\x69\x74\x79\x2c\x0a\x20\x20\x20\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x6b\x65\x79\x3b\x0a\x03\x00\xd6\x8e\x55\x07\x65\x00\x00\x00")
+
+func _2018111501_queuePriorityDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__2018111501_queuePriorityDownSql,
+		"2018111501_queue-priority.down.sql",
+	)
+}
+
+func _2018111501_queuePriorityDownSql() (*asset, error) {
+	bytes, err := _2018111501_queuePriorityDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "2018111501_queue-priority.down.sql", size: 101, mode: os.FileMode(420), modTime: time.Unix(1542240000, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __2018111501_queuePriorityUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\x8e\x41\x4b\xc4\x30\x10\x85\xef\xf9\x15\xef\xa6\x42\x0b\xde\xf7\x94\x6e\xa3\x14\x62\x8a\x4b\x02\x7a\x2a\xd1\x19\xec\x60\xcd\x6a\xda\x65\xed\xbf\x17\xb7\xb6\x07\x61\x4e\xf3\x7d\xef\xcd\x94\x25\x78\xe0\x0f\x4e\xd3\x88\x98\x19\xc4\x5f\x27\x3e\x31\xe1\x65\xc6\x67\x96\x63\x96\x69\x2e\x10\xd3\x6a\xe1\x2c\x53\x8f\x88\x77\x9e\x71\xee\xe5\xb5\x87\x8c\xf8\x8b\x1c\x33\x06\x8e\x23\x93\x2a\x4b\xc4\x21\x73\xa4\x19\x32\xa6\xab\x09\x91\x88\x09\xf1\x2d\x4a\x52\xda\x7a\x73\x80\xd7\x95\x35\x4b\x52\x01\x80\xae\x6b\xec\x5b\x1b\x1e\xdc\xa5\xbb\x7a\xf6\x46\x5f\xc0\xef\x04\xd7\x3c\x06\x53\xfc\x17\xd7\x07\x51\x35\xf7\x8d\xf3\x9b\xee\x5a\x0f\x17\xac\xdd\x16\xb5\xb9\xd3\xc1\x7a\xdc\xee\x94\xda\x1f\x8c\xf6\x06\x8d\xab\xcd\xd3\x72\xbf\x5b\x7b\x3a\xa1\x4e\x12\xf1\x37\x5a\xb7\x20\x5c\xaf\xac\x80\xd0\xcd\x4e\xfd\x0c\x00\x2b\xda\x8a\x39\x30\x01\x00\x00")
+
+func _2018111501_queuePriorityUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__2018111501_queuePriorityUpSql,
+		"2018111501_queue-priority.up.sql",
+	)
+}
+
+func _2018111501_queuePriorityUpSql() (*asset, error) {
+	bytes, err := _2018111501_queuePriorityUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "2018111501_queue-priority.up.sql", size: 304, mode: os.FileMode(420), modTime: time.Unix(1542240000, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __2018112001_queueFailuresDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x2c\x00\xd3\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x71\x75\x65\x75\x65\x0a\x20\x20\x20\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x66\x61\x69\x6c\x75\x72\x65\x73\x3b\x0a\x03\x00\xcf\x69\x30\x69\x2c\x00\x00\x00")
+
+func _2018112001_queueFailuresDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__2018112001_queueFailuresDownSql,
+		"2018112001_queue-failures.down.sql",
+	)
+}
+
+func _2018112001_queueFailuresDownSql() (*asset, error) {
+	bytes, err := _2018112001_queueFailuresDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "2018112001_queue-failures.down.sql", size: 44, mode: os.FileMode(420), modTime: time.Unix(1542672000, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __2018112001_queueFailuresUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x44\x8e\x41\xaa\xc2\x30\x14\x45\xe7\x59\xc5\x5d\xc0\x0f\xfc\xb9\xa3\xd4\x56\x29\xc4\x14\x24\x5d\xc0\x6b\xfb\x8a\xc5\x98\x68\x93\x50\xba\x7b\x51\xd4\xc2\x1d\x9d\x73\x07\x47\x4a\x8c\x34\xb9\x3c\x73\x44\x1f\xb2\x4f\x11\x97\xb0\x20\x8c\x89\x3d\xc8\x83\x1d\xdf\xd8\x27\x2c\x14\x31\xb3\x63\x8a\x3c\xa0\x5b\x61\xa8\xbf\xfe\x81\x36\xf6\x3d\xf6\xe4\xd1\xb1\x90\x12\x1f\x91\x7d\x9a\x1c\x08\x03\x3b\x5a\x71\xa7\x18\x79\x10\x4a\xdb\xea\x0c\xab\x0a\x5d\xe1\x91\x39\xb3\x00\x00\x55\x96\xd8\x37\xba\x3d\x99\x2d\xaa\xa8\x8f\xb5\xb1\x6f\xfd\x9a\x69\x2c\x4c\xab\xf5\x0f\x94\xd5\x41\xb5\xda\xe2\x7f\x27\x9e\x03\x00\xe7\xdc\x0c\x91\xcc\x00\x00\x00")
+
+func _2018112001_queueFailuresUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__2018112001_queueFailuresUpSql,
+		"2018112001_queue-failures.up.sql",
+	)
+}
+
+func _2018112001_queueFailuresUpSql() (*asset, error) {
+	bytes, err := _2018112001_queueFailuresUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "2018112001_queue-failures.up.sql", size: 204, mode: os.FileMode(420), modTime: time.Unix(1542672000, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __2018112101_queueFailuresPriorityDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6c\x00\x93\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x71\x75\x65\x75\x65\x5f\x70\x72\x69\x6f\x72\x69\x74\x79\x5f\x66\x61\x69\x6c\x75\x72\x65\x73\x5f\x69\x64\x5f\x69\x6e\x64\x65\x78\x3b\x0a\x0a\x43\x52\x45\x41\x54\x45\x20\x49\x4e\x44\x45\x58\x20\x71\x75\x65\x75\x65\x5f\x70\x72\x69\x6f\x72\x69\x74\x79\x5f\x69\x64\x5f\x69\x6e\x64\x65\x78\x20\x4f\x4e\x20\x71\x75\x65\x75\x65\x20\x28\x70\x72\x69\x6f\x72\x69\x74\x79\x2c\x20\x69\x64\x29\x3b\x0a\x03\x00\x00\x92\xcf\x82\x6c\x00\x00\x00")
+
+func _2018112101_queueFailuresPriorityDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__2018112101_queueFailuresPriorityDownSql,
+		"2018112101_queue-failures-priority.down.sql",
+	)
+}
+
+func _2018112101_queueFailuresPriorityDownSql() (*asset, error) {
+	bytes, err := _2018112101_queueFailuresPriorityDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "2018112101_queue-failures-priority.down.sql", size: 108, mode: os.FileMode(420), modTime: time.Unix(1542758400, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __2018112101_queueFailuresPriorityUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xce\xbd\x0e\x82\x30\x1c\x45\xf1\xbd\x4f\x71\x47\x88\xf2\x04\x4c\x46\x18\x5c\xc0\x10\x07\xb7\x86\xa4\x97\xf8\x4f\x6a\xab\xfd\x50\x79\x7b\x07\xad\x93\xfb\x2f\x39\xa7\x69\xc0\x07\xc3\x8a\x65\x16\x9b\x03\x61\xfd\x93\x21\x22\x5d\x88\x5b\x10\x1f\x24\xad\xf0\x0b\x66\x07\x5a\x5e\xe9\x92\xea\xa6\xf1\x88\xc3\xd0\xf5\x67\xdc\x33\x33\x75\x71\x5a\x8c\x16\x67\xf8\x6a\x95\xda\x4f\xfd\xee\xd4\xff\x67\xdf\x54\xfc\x79\x8c\xc3\xc7\xa0\xaa\x8a\xc2\xa6\x2c\xc5\x7a\x0b\x31\x75\xab\xde\x03\x00\x4c\xfc\x60\x68\xac\x00\x00\x00")
+
+func _2018112101_queueFailuresPriorityUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__2018112101_queueFailuresPriorityUpSql,
+		"2018112101_queue-failures-priority.up.sql",
+	)
+}
+
+func _2018112101_queueFailuresPriorityUpSql() (*asset, error) {
+	bytes, err := _2018112101_queueFailuresPriorityUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "2018112101_queue-failures-priority.up.sql", size: 172, mode: os.FileMode(420), modTime: time.Unix(1542758400, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -159,8 +327,16 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"2018092201_initial-tables.down.sql": _2018092201_initialTablesDownSql,
-	"2018092201_initial-tables.up.sql":   _2018092201_initialTablesUpSql,
+	"2018092201_initial-tables.down.sql":          _2018092201_initialTablesDownSql,
+	"2018092201_initial-tables.up.sql":            _2018092201_initialTablesUpSql,
+	"2018111401_queue.down.sql":                   _2018111401_queueDownSql,
+	"2018111401_queue.up.sql":                     _2018111401_queueUpSql,
+	"2018111501_queue-priority.down.sql":          _2018111501_queuePriorityDownSql,
+	"2018111501_queue-priority.up.sql":            _2018111501_queuePriorityUpSql,
+	"2018112001_queue-failures.down.sql":          _2018112001_queueFailuresDownSql,
+	"2018112001_queue-failures.up.sql":            _2018112001_queueFailuresUpSql,
+	"2018112101_queue-failures-priority.down.sql": _2018112101_queueFailuresPriorityDownSql,
+	"2018112101_queue-failures-priority.up.sql":   _2018112101_queueFailuresPriorityUpSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -204,8 +380,16 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"2018092201_initial-tables.down.sql": &bintree{_2018092201_initialTablesDownSql, map[string]*bintree{}},
-	"2018092201_initial-tables.up.sql":   &bintree{_2018092201_initialTablesUpSql, map[string]*bintree{}},
+	"2018092201_initial-tables.down.sql":          &bintree{_2018092201_initialTablesDownSql, map[string]*bintree{}},
+	"2018092201_initial-tables.up.sql":            &bintree{_2018092201_initialTablesUpSql, map[string]*bintree{}},
+	"2018111401_queue.down.sql":                   &bintree{_2018111401_queueDownSql, map[string]*bintree{}},
+	"2018111401_queue.up.sql":                     &bintree{_2018111401_queueUpSql, map[string]*bintree{}},
+	"2018111501_queue-priority.down.sql":          &bintree{_2018111501_queuePriorityDownSql, map[string]*bintree{}},
+	"2018111501_queue-priority.up.sql":            &bintree{_2018111501_queuePriorityUpSql, map[string]*bintree{}},
+	"2018112001_queue-failures.down.sql":          &bintree{_2018112001_queueFailuresDownSql, map[string]*bintree{}},
+	"2018112001_queue-failures.up.sql":            &bintree{_2018112001_queueFailuresUpSql, map[string]*bintree{}},
+	"2018112101_queue-failures-priority.down.sql": &bintree{_2018112101_queueFailuresPriorityDownSql, map[string]*bintree{}},
+	"2018112101_queue-failures-priority.up.sql":   &bintree{_2018112101_queueFailuresPriorityUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
@@ -4,24 +4,186 @@
 package redis
 
 import (
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+
+	"storj.io/storj/pkg/utils"
 	"storj.io/storj/storage"
 )
 
 // Queue is the aliased entrypoint into Redis
 type Queue Client
 
-const queueKey = "queue"
+// the queued element ids are a sorted set by priority plus failures, the ids have the same length so
+// that elements of the same score are sorted in FIFO order. The elements are kept in hashes by id
+// until they are acknowledged. A leased element is moved from the queued ids to the leases,
+// a sorted set of the lease ids by deadline. An element released after a delay is leased
+// until then under a lease id nobody holds.
+const (
+	queueKey           = "queue:queued"
+	queueItemsKey      = "queue:items"
+	queueLeasesKey     = "queue:leases"
+	queueLeasedKey     = "queue:leased"
+	queueNextKey       = "queue:next"
+	queuePrioritiesKey = "queue:priorities"
+	queueKeysKey       = "queue:keys"
+	queueItemKeysKey   = "queue:itemkeys"
+	queueFailuresKey   = "queue:failures"
+)
+
+// legacyQueueKey is the list of the former FIFO queue, its elements are moved to the queue on first use
+const legacyQueueKey = "queue"
+
+var queueKeys = []string{queueKey, queueItemsKey, queueLeasesKey, queueLeasedKey, queueNextKey,
+	queuePrioritiesKey, queueKeysKey, queueItemKeysKey, queueFailuresKey}
+
+// scoreFunc returns the score of the element id in the queued ids, its failures lower its priority
+const scoreFunc = `
+	local function score(id)
+		return tonumber(redis.call('HGET', KEYS[6], id)) + tonumber(redis.call('HGET', KEYS[9], id) or '0')
+	end
+`
+
+// enqueueFunc adds the element value with key and priority
+const enqueueFunc = scoreFunc + `
+	local function enqueue(key, value, priority)
+		local id = redis.call('HGET', KEYS[7], key)
+		if id then
+			-- a leased element stays as it is
+			if redis.call('ZSCORE', KEYS[1], id) then
+				local queued = math.min(tonumber(redis.call('HGET', KEYS[6], id)), tonumber(priority))
+				redis.call('HSET', KEYS[2], id, value)
+				redis.call('HSET', KEYS[6], id, queued)
+				redis.call('ZADD', KEYS[1], score(id), id)
+			end
+			return id
+		end
+
+		id = string.format('%020d', redis.call('INCR', KEYS[5]))
+		redis.call('HSET', KEYS[2], id, value)
+		redis.call('HSET', KEYS[6], id, priority)
+		redis.call('HSET', KEYS[7], key, id)
+		redis.call('HSET', KEYS[8], id, key)
+		redis.call('ZADD', KEYS[1], priority, id)
+		return id
+	end
+`
+
+// the scripts run atomically, they are passed queueKeys as KEYS
+var (
+	// enqueueScript adds the element ARGV[2] with key ARGV[1] and priority ARGV[3]
+	enqueueScript = redis.NewScript(enqueueFunc + `
+		return enqueue(ARGV[1], ARGV[2], ARGV[3])
+	`)
+
+	// migrateScript moves the elements of the former FIFO queue, passed as KEYS[10], to the queue in
+	// FIFO order. They are keyed by their value and get priority 0 as their urgency isn't known.
+	migrateScript = redis.NewScript(enqueueFunc + `
+		local count = 0
+		while true do
+			-- a key which doesn't hold a list isn't the former queue
+			local value = redis.pcall('RPOP', KEYS[10])
+			if type(value) ~= 'string' then
+				return count
+			end
+			enqueue(value, value, 0)
+			count = count + 1
+		end
+	`)
+
+	// dequeueScript puts the elements of the expired leases back in the queue and leases
+	// the first element until ARGV[2], ARGV[1] is the current time in milliseconds
+	dequeueScript = redis.NewScript(scoreFunc + `
+		local expired = redis.call('ZRANGEBYSCORE', KEYS[3], '-inf', ARGV[1])
+		for _, lease in ipairs(expired) do
+			local id = redis.call('HGET', KEYS[4], lease)
+			redis.call('HDEL', KEYS[4], lease)
+			redis.call('ZREM', KEYS[3], lease)
+			if id then
+				redis.call('ZADD', KEYS[1], score(id), id)
+			end
+		end
+
+		local first = redis.call('ZRANGE', KEYS[1], 0, 0)
+		if #first == 0 then
+			return false
+		end
+		local id = first[1]
+		redis.call('ZREM', KEYS[1], id)
+		local lease = redis.call('INCR', KEYS[5])
+		redis.call('ZADD', KEYS[3], ARGV[2], lease)
+		redis.call('HSET', KEYS[4], lease, id)
+		return {tostring(lease), redis.call('HGET', KEYS[2], id), redis.call('HGET', KEYS[9], id) or '0'}
+	`)
+
+	ackScript = redis.NewScript(`
+		local id = redis.call('HGET', KEYS[4], ARGV[1])
+		if not id then
+			return 0
+		end
+		redis.call('HDEL', KEYS[4], ARGV[1])
+		redis.call('ZREM', KEYS[3], ARGV[1])
+		redis.call('HDEL', KEYS[2], id)
+		redis.call('HDEL', KEYS[6], id)
+		redis.call('HDEL', KEYS[9], id)
+		local key = redis.call('HGET', KEYS[8], id)
+		if key then
+			redis.call('HDEL', KEYS[7], key)
+		end
+		redis.call('HDEL', KEYS[8], id)
+		return 1
+	`)
+
+	// nackScript releases the lease ARGV[1], the element is leased again until ARGV[2] if ARGV[3] is set
+	nackScript = redis.NewScript(scoreFunc + `
+		local id = redis.call('HGET', KEYS[4], ARGV[1])
+		if not id then
+			return 0
+		end
+		redis.call('HDEL', KEYS[4], ARGV[1])
+		redis.call('ZREM', KEYS[3], ARGV[1])
+		redis.call('HINCRBY', KEYS[9], id, 1)
+		if ARGV[3] == '1' then
+			local lease = redis.call('INCR', KEYS[5])
+			redis.call('ZADD', KEYS[3], ARGV[2], lease)
+			redis.call('HSET', KEYS[4], lease, id)
+		else
+			redis.call('ZADD', KEYS[1], score(id), id)
+		end
+		return 1
+	`)
+
+	peekScript = redis.NewScript(`
+		local ids = redis.call('ZRANGE', KEYS[1], 0, ARGV[1])
+		if #ids == 0 then
+			return {}
+		end
+		return redis.call('HMGET', KEYS[2], unpack(ids))
+	`)
+)
 
 // NewQueue returns a configured Client instance, verifying a successful connection to redis
 func NewQueue(address, password string, db int) (*Queue, error) {
-	queue, err := NewClient(address, password, db)
-	return (*Queue)(queue), err
+	return newQueue(NewClient(address, password, db))
 }
 
 // NewQueueFrom returns a configured Client instance from a redis address, verifying a successful connection to redis
 func NewQueueFrom(address string) (*Queue, error) {
-	queue, err := NewClientFrom(address)
-	return (*Queue)(queue), err
+	return newQueue(NewClientFrom(address))
+}
+
+// newQueue moves the elements of the former FIFO queue to the queue of the connected client
+func newQueue(client *Client, err error) (*Queue, error) {
+	if err != nil {
+		return nil, err
+	}
+	queue := (*Queue)(client)
+	if err := migrateScript.Run(client.db, append(queueKeys[:len(queueKeys):len(queueKeys)], legacyQueueKey)).Err(); err != nil {
+		return nil, utils.CombineErrors(Error.New("migrate error: %v", err), queue.Close())
+	}
+	return queue, nil
 }
 
 // Close closes a redis client
@@ -29,34 +191,83 @@ func (client *Queue) Close() error {
 	return client.db.Close()
 }
 
-//Enqueue add a FIFO element, for the storage.Queue interface
-func (client *Queue) Enqueue(value storage.Value) error {
-	err := client.db.LPush(queueKey, []byte(value)).Err()
+// Enqueue adds an element unless an element with the key is queued or leased, for the storage.Queue interface
+func (client *Queue) Enqueue(key storage.Key, value storage.Value, priority int64) error {
+	err := enqueueScript.Run(client.db, queueKeys, []byte(key), []byte(value), priority).Err()
 	if err != nil {
 		return Error.New("enqueue error: %v", err)
 	}
 	return nil
 }
 
-//Dequeue removes a FIFO element, for the storage.Queue interface
-func (client *Queue) Dequeue() (storage.Value, error) {
-	out, err := client.db.RPop(queueKey).Bytes()
+// Dequeue leases a FIFO element for timeout, for the storage.Queue interface
+func (client *Queue) Dequeue(timeout time.Duration) (storage.Lease, error) {
+	now := time.Now()
+	result, err := dequeueScript.Run(client.db, queueKeys, milliseconds(now), milliseconds(now.Add(timeout))).Result()
+	if err == redis.Nil {
+		return storage.Lease{}, storage.ErrEmptyQueue
+	}
+	if err != nil {
+		return storage.Lease{}, Error.New("dequeue error: %v", err)
+	}
+
+	fields, ok := result.([]interface{})
+	if !ok || len(fields) != 3 {
+		return storage.Lease{}, Error.New("dequeue error: unexpected result %v", result)
+	}
+	id, _ := fields[0].(string)
+	value, _ := fields[1].(string)
+	failures, _ := fields[2].(string)
+	lease := storage.Lease{ID: storage.LeaseID(id), Value: storage.Value(value)}
+	lease.Failures, err = strconv.ParseInt(failures, 10, 64)
+	if err != nil {
+		return storage.Lease{}, Error.New("dequeue error: %v", err)
+	}
+	return lease, nil
+}
+
+// Ack removes a leased element, for the storage.Queue interface
+func (client *Queue) Ack(id storage.LeaseID) error {
+	return client.release(ackScript, id)
+}
+
+// Nack puts a leased element back at its place in the queue after delay, for the storage.Queue interface
+func (client *Queue) Nack(id storage.LeaseID, delay time.Duration) error {
+	delayed := 0
+	if delay > 0 {
+		delayed = 1
+	}
+	return client.release(nackScript, id, milliseconds(time.Now().Add(delay)), delayed)
+}
+
+// release ends the lease with the script
+func (client *Queue) release(script *redis.Script, id storage.LeaseID, args ...interface{}) error {
+	released, err := script.Run(client.db, queueKeys, append([]interface{}{string(id)}, args...)...).Int64()
 	if err != nil {
-		return nil, Error.New("dequeue error: %v", err)
+		return Error.New("release error: %v", err)
+	}
+	if released == 0 {
+		return storage.ErrLeaseExpired
 	}
-	return storage.Value(out), nil
+	return nil
 }
 
-// Peekqueue returns upto 1000 entries in the queue without removing
+// Peekqueue returns upto 1000 entries in the queue in priority order without removing
 func (client *Queue) Peekqueue(limit int) ([]storage.Value, error) {
-	cmd := client.db.LRange(queueKey, 0, int64(limit))
-	items, err := cmd.Result()
+	items, err := peekScript.Run(client.db, queueKeys, limit).Result()
 	if err != nil {
 		return nil, err
 	}
+	values, _ := items.([]interface{})
 	result := make([]storage.Value, 0)
-	for _, v := range items {
-		result = append(result, storage.Value([]byte(v)))
+	for _, v := range values {
+		s, _ := v.(string)
+		result = append(result, storage.Value([]byte(s)))
 	}
-	return result, err
+	return result, nil
+}
+
+// milliseconds returns the unix time of t in milliseconds, lua numbers can't hold nanoseconds
+func milliseconds(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
 }
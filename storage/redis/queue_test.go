@@ -5,7 +5,11 @@ package redis
 
 import (
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/storage"
 	"storj.io/storj/storage/redis/redisserver"
 	"storj.io/storj/storage/testsuite"
 )
@@ -24,3 +28,50 @@ func TestQueue(t *testing.T) {
 
 	testsuite.RunQueueTests(t, client)
 }
+
+func TestQueueMigration(t *testing.T) {
+	addr, cleanup, err := redisserver.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	client, err := NewClient(addr, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range []string{"first", "second", "first"} {
+		if err := client.db.LPush(legacyQueueKey, value).Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the elements of the former FIFO queue are dequeued first in their order
+	queue, err := NewQueue(addr, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, queue.Enqueue(storage.Key("third"), storage.Value("third"), 1))
+	for _, expected := range []string{"first", "second", "third"} {
+		lease, err := queue.Dequeue(time.Minute)
+		if assert.NoError(t, err) {
+			assert.Equal(t, storage.Value(expected), lease.Value)
+			assert.NoError(t, queue.Ack(lease.ID))
+		}
+	}
+	_, err = queue.Dequeue(time.Minute)
+	assert.Equal(t, storage.ErrEmptyQueue, err)
+
+	exists, err := client.db.Exists(legacyQueueKey).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), exists)
+
+	// the queue is kept when it is used again
+	assert.NoError(t, queue.Enqueue(storage.Key("fourth"), storage.Value("fourth"), 0))
+	again, err := NewQueue(addr, "", 0)
+	if assert.NoError(t, err) {
+		list, err := again.Peekqueue(10)
+		assert.NoError(t, err)
+		assert.Equal(t, []storage.Value{storage.Value("fourth")}, list)
+	}
+}
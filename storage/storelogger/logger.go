@@ -51,6 +51,12 @@ func (store *Logger) Delete(key storage.Key) error {
 	return store.store.Delete(key)
 }
 
+// CompareAndSwap replaces the value of key with newValue if its current value is oldValue
+func (store *Logger) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	store.log.Debug("CompareAndSwap", zap.String("key", string(key)), zap.Binary("oldValue", []byte(oldValue)), zap.Binary("newValue", []byte(newValue)))
+	return store.store.CompareAndSwap(key, oldValue, newValue)
+}
+
 // List lists all keys starting from first and upto limit items
 func (store *Logger) List(first storage.Key, limit int) (storage.Keys, error) {
 	keys, err := store.store.List(first, limit)
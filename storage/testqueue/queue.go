@@ -4,44 +4,165 @@
 package testqueue
 
 import (
-	"container/list"
+	"sort"
+	"strconv"
 	"sync"
+	"time"
 
 	"storj.io/storj/storage"
 )
 
-//Queue is a threadsafe FIFO queue implementing storage.Queue
+//Queue is a threadsafe priority queue implementing storage.Queue
 type Queue struct {
 	mu sync.Mutex
-	s  *list.List
+	// items are the queued elements in the order they are dequeued
+	items []*item
+	// keys holds the queued and leased elements by key
+	keys map[string]*item
+	// leases holds the dequeued elements until they are acknowledged or released
+	leases    map[storage.LeaseID]*lease
+	nextLease int64
+	nextSeq   int64
+}
+
+// item is a queued element, seq keeps FIFO order among elements of the same priority
+type item struct {
+	key      string
+	value    storage.Value
+	priority int64
+	seq      int64
+	failures int64
+}
+
+// lease is a dequeued or delayed element with the time its lease runs out
+type lease struct {
+	item     *item
+	deadline time.Time
 }
 
 //New returns a queue suitable for testing
 func New() *Queue {
-	return &Queue{s: list.New(), mu: sync.Mutex{}}
+	return &Queue{
+		keys:   make(map[string]*item),
+		leases: make(map[storage.LeaseID]*lease),
+	}
+}
+
+// before returns whether a is dequeued before b, every failure lowers the priority of an element
+func (a *item) before(b *item) bool {
+	if a.priority+a.failures != b.priority+b.failures {
+		return a.priority+a.failures < b.priority+b.failures
+	}
+	return a.seq < b.seq
+}
+
+// insert puts the element at its place in the queue
+func (q *Queue) insert(it *item) {
+	i := sort.Search(len(q.items), func(i int) bool { return it.before(q.items[i]) })
+	q.items = append(q.items, nil)
+	copy(q.items[i+1:], q.items[i:])
+	q.items[i] = it
+}
+
+// remove takes the element out of the queue and returns whether it was queued
+func (q *Queue) remove(it *item) bool {
+	for i := range q.items {
+		if q.items[i] == it {
+			q.items = append(q.items[:i], q.items[i+1:]...)
+			return true
+		}
+	}
+	return false
 }
 
-//Enqueue add a FIFO element
-func (q *Queue) Enqueue(value storage.Value) error {
+//Enqueue adds an element unless an element with the key is queued or leased
+func (q *Queue) Enqueue(key storage.Key, value storage.Value, priority int64) error {
 	q.mu.Lock()
 	defer q.mu.Unlock()
-	q.s.PushBack(value)
+	if it, ok := q.keys[string(key)]; ok {
+		// a leased element isn't in the queue and stays as it is
+		if q.remove(it) {
+			it.value = value
+			if priority < it.priority {
+				it.priority = priority
+			}
+			q.insert(it)
+		}
+		return nil
+	}
+	q.nextSeq++
+	it := &item{key: string(key), value: value, priority: priority, seq: q.nextSeq}
+	q.keys[it.key] = it
+	q.insert(it)
 	return nil
 }
 
-//Dequeue removes a FIFO element
-func (q *Queue) Dequeue() (storage.Value, error) {
+//Dequeue leases the first element for timeout
+func (q *Queue) Dequeue(timeout time.Duration) (storage.Lease, error) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
-	for q.s.Len() > 0 {
-		e := q.s.Front() // First element
-		q.s.Remove(e)    // Dequeue
-		return e.Value.(storage.Value), nil
+	q.reclaim()
+	if len(q.items) == 0 {
+		return storage.Lease{}, storage.ErrEmptyQueue
 	}
-	return nil, storage.ErrEmptyQueue
+	it := q.items[0]
+	q.items = q.items[1:]
+	id := q.lease(it, timeout)
+	return storage.Lease{ID: id, Value: it.value, Failures: it.failures}, nil
+}
+
+// lease hides the element for timeout under a new lease id
+func (q *Queue) lease(it *item, timeout time.Duration) storage.LeaseID {
+	q.nextLease++
+	id := storage.LeaseID(strconv.FormatInt(q.nextLease, 10))
+	q.leases[id] = &lease{item: it, deadline: time.Now().Add(timeout)}
+	return id
+}
+
+// reclaim puts the elements whose leases timed out back in the queue
+func (q *Queue) reclaim() {
+	now := time.Now()
+	for id, lease := range q.leases {
+		if !lease.deadline.After(now) {
+			q.insert(lease.item)
+			delete(q.leases, id)
+		}
+	}
+}
+
+//Ack removes the leased element
+func (q *Queue) Ack(id storage.LeaseID) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	lease, ok := q.leases[id]
+	if !ok {
+		return storage.ErrLeaseExpired
+	}
+	delete(q.leases, id)
+	delete(q.keys, lease.item.key)
+	return nil
+}
+
+//Nack releases the leased element to its place in the queue after delay
+func (q *Queue) Nack(id storage.LeaseID, delay time.Duration) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	lease, ok := q.leases[id]
+	if !ok {
+		return storage.ErrLeaseExpired
+	}
+	delete(q.leases, id)
+	lease.item.failures++
+	if delay > 0 {
+		// the element is hidden under a lease nobody holds until it is reclaimed
+		q.lease(lease.item, delay)
+		return nil
+	}
+	q.insert(lease.item)
+	return nil
 }
 
-//Peekqueue gets upto 'limit' entries from the list queue
+//Peekqueue gets upto 'limit' entries from the queue in priority order
 func (q *Queue) Peekqueue(limit int) ([]storage.Value, error) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -49,8 +170,8 @@ func (q *Queue) Peekqueue(limit int) ([]storage.Value, error) {
 		limit = storage.LookupLimit
 	}
 	result := make([]storage.Value, 0)
-	for e := q.s.Front(); e != nil; e = e.Next() {
-		result = append(result, e.Value.(storage.Value))
+	for _, it := range q.items {
+		result = append(result, it.value)
 		limit--
 		if limit <= 0 {
 			break
@@ -25,6 +25,7 @@ type Client struct {
 		GetAll      int
 		ReverseList int
 		Delete      int
+		CAS         int
 		Close       int
 		Iterate     int
 	}
@@ -150,6 +151,42 @@ func (store *Client) Delete(key storage.Key) error {
 	return nil
 }
 
+// CompareAndSwap replaces the value of key with newValue if its current value is oldValue
+func (store *Client) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	store.version++
+	store.CallCount.CAS++
+
+	if store.forcedError() {
+		return errInternal
+	}
+
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+
+	keyIndex, found := store.indexOf(key)
+	if found != (oldValue != nil) || (found && !bytes.Equal(store.Items[keyIndex].Value, oldValue)) {
+		return storage.ErrValueChanged.New(key.String())
+	}
+
+	switch {
+	case newValue == nil && found:
+		copy(store.Items[keyIndex:], store.Items[keyIndex+1:])
+		store.Items = store.Items[:len(store.Items)-1]
+	case newValue == nil:
+	case found:
+		store.Items[keyIndex].Value = storage.CloneValue(newValue)
+	default:
+		store.Items = append(store.Items, storage.ListItem{})
+		copy(store.Items[keyIndex+1:], store.Items[keyIndex:])
+		store.Items[keyIndex] = storage.ListItem{
+			Key:   storage.CloneKey(key),
+			Value: storage.CloneValue(newValue),
+		}
+	}
+	return nil
+}
+
 // List lists all keys starting from start and upto limit items
 func (store *Client) List(first storage.Key, limit int) (storage.Keys, error) {
 	store.CallCount.List++
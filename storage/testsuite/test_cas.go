@@ -0,0 +1,65 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testsuite
+
+import (
+	"bytes"
+	"testing"
+
+	"storj.io/storj/storage"
+)
+
+func testCompareAndSwap(t *testing.T, store storage.KeyValueStore) {
+	key := storage.Key("cas/key")
+	defer func() { _ = store.Delete(key) }()
+
+	expect := func(value storage.Value) {
+		t.Helper()
+		current, err := store.Get(key)
+		if value == nil {
+			if !storage.ErrKeyNotFound.Has(err) {
+				t.Fatalf("expected %q to be missing: got %v, %v", key, current, err)
+			}
+			return
+		}
+		if err != nil || !bytes.Equal(current, value) {
+			t.Fatalf("invalid value for %q = %v: got %v, %v", key, value, current, err)
+		}
+	}
+
+	if err := store.CompareAndSwap(key, storage.Value("a"), storage.Value("b")); !storage.ErrValueChanged.Has(err) {
+		t.Fatalf("swapping a missing key should fail: %v", err)
+	}
+	expect(nil)
+
+	if err := store.CompareAndSwap(key, nil, storage.Value("a")); err != nil {
+		t.Fatalf("failed to create %q: %v", key, err)
+	}
+	expect(storage.Value("a"))
+
+	if err := store.CompareAndSwap(key, nil, storage.Value("b")); !storage.ErrValueChanged.Has(err) {
+		t.Fatalf("creating an existing key should fail: %v", err)
+	}
+	if err := store.CompareAndSwap(key, storage.Value("b"), storage.Value("c")); !storage.ErrValueChanged.Has(err) {
+		t.Fatalf("swapping a changed value should fail: %v", err)
+	}
+	expect(storage.Value("a"))
+
+	if err := store.CompareAndSwap(key, storage.Value("a"), storage.Value("b")); err != nil {
+		t.Fatalf("failed to swap %q: %v", key, err)
+	}
+	expect(storage.Value("b"))
+
+	if err := store.CompareAndSwap(key, storage.Value("a"), nil); !storage.ErrValueChanged.Has(err) {
+		t.Fatalf("deleting a changed value should fail: %v", err)
+	}
+	if err := store.CompareAndSwap(key, storage.Value("b"), nil); err != nil {
+		t.Fatalf("failed to delete %q: %v", key, err)
+	}
+	expect(nil)
+
+	if err := store.CompareAndSwap(key, nil, nil); err != nil {
+		t.Fatalf("a missing key should stay missing: %v", err)
+	}
+}
@@ -5,36 +5,241 @@ package testsuite
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"storj.io/storj/storage"
 )
 
+// leaseTimeout is long enough for a lease not to time out during a test
+const leaseTimeout = time.Minute
+
 // RunQueueTests runs common storage.Queue tests
 func RunQueueTests(t *testing.T, q storage.Queue) {
 	t.Run("basic", func(t *testing.T) { testBasic(t, q) })
+	t.Run("lease", func(t *testing.T) { testLease(t, q) })
+	t.Run("nack", func(t *testing.T) { testNack(t, q) })
+	t.Run("delay", func(t *testing.T) { testDelay(t, q) })
+	t.Run("timeout", func(t *testing.T) { testTimeout(t, q) })
+	t.Run("priority", func(t *testing.T) { testPriority(t, q) })
+	t.Run("duplicates", func(t *testing.T) { testDuplicates(t, q) })
+}
+
+// dequeueAck dequeues an element and acknowledges it
+func dequeueAck(t *testing.T, q storage.Queue) storage.Value {
+	lease, err := q.Dequeue(leaseTimeout)
+	require.NoError(t, err)
+	assert.NoError(t, q.Ack(lease.ID))
+	return lease.Value
 }
 
 func testBasic(t *testing.T, q storage.Queue) {
-	err := q.Enqueue(storage.Value("hello world"))
+	err := q.Enqueue(storage.Key("hello world"), storage.Value("hello world"), 0)
 	assert.NoError(t, err)
-	err = q.Enqueue(storage.Value("Привіт, світе"))
+	err = q.Enqueue(storage.Key("Привіт, світе"), storage.Value("Привіт, світе"), 0)
 	assert.NoError(t, err)
-	err = q.Enqueue(storage.Value([]byte{0, 0, 0, 0, 255, 255, 255, 255}))
+	err = q.Enqueue(storage.Key("binary"), storage.Value([]byte{0, 0, 0, 0, 255, 255, 255, 255}), 0)
 	assert.NoError(t, err)
 	list, err := q.Peekqueue(100)
 	assert.NotNil(t, list)
 	assert.NoError(t, err)
-	out, err := q.Dequeue()
-	assert.NoError(t, err)
-	assert.Equal(t, out, storage.Value("hello world"))
-	out, err = q.Dequeue()
-	assert.NoError(t, err)
-	assert.Equal(t, out, storage.Value("Привіт, світе"))
-	out, err = q.Dequeue()
-	assert.NoError(t, err)
-	assert.Equal(t, out, storage.Value([]byte{0, 0, 0, 0, 255, 255, 255, 255}))
-	out, err = q.Dequeue()
-	assert.Nil(t, out)
-	assert.Error(t, err)
+	assert.Equal(t, dequeueAck(t, q), storage.Value("hello world"))
+	assert.Equal(t, dequeueAck(t, q), storage.Value("Привіт, світе"))
+	assert.Equal(t, dequeueAck(t, q), storage.Value([]byte{0, 0, 0, 0, 255, 255, 255, 255}))
+	out, err := q.Dequeue(leaseTimeout)
+	assert.Nil(t, out.Value)
+	assert.Equal(t, storage.ErrEmptyQueue, err)
+}
+
+func testLease(t *testing.T, q storage.Queue) {
+	require.NoError(t, q.Enqueue(storage.Key("first"), storage.Value("first"), 0))
+	require.NoError(t, q.Enqueue(storage.Key("second"), storage.Value("second"), 0))
+
+	// a leased element is hidden from other consumers
+	first, err := q.Dequeue(leaseTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, storage.Value("first"), first.Value)
+	list, err := q.Peekqueue(100)
+	require.NoError(t, err)
+	assert.Equal(t, []storage.Value{storage.Value("second")}, list)
+
+	second, err := q.Dequeue(leaseTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, storage.Value("second"), second.Value)
+	assert.NotEqual(t, first.ID, second.ID)
+
+	_, err = q.Dequeue(leaseTimeout)
+	assert.Equal(t, storage.ErrEmptyQueue, err)
+
+	// an acknowledged element is removed
+	assert.NoError(t, q.Ack(first.ID))
+	assert.NoError(t, q.Ack(second.ID))
+	assert.Equal(t, storage.ErrLeaseExpired, q.Ack(first.ID))
+	assert.Equal(t, storage.ErrLeaseExpired, q.Nack(second.ID, 0))
+
+	_, err = q.Dequeue(leaseTimeout)
+	assert.Equal(t, storage.ErrEmptyQueue, err)
+}
+
+func testNack(t *testing.T, q storage.Queue) {
+	require.NoError(t, q.Enqueue(storage.Key("first"), storage.Value("first"), 0))
+	require.NoError(t, q.Enqueue(storage.Key("second"), storage.Value("second"), 5))
+
+	// a released element is dequeued again, its failures are counted
+	first, err := q.Dequeue(leaseTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), first.Failures)
+	require.NoError(t, q.Nack(first.ID, 0))
+	assert.Equal(t, storage.ErrLeaseExpired, q.Ack(first.ID))
+
+	again, err := q.Dequeue(leaseTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, storage.Value("first"), again.Value)
+	assert.Equal(t, int64(1), again.Failures)
+	require.NoError(t, q.Nack(again.ID, 0))
+
+	again, err = q.Dequeue(leaseTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), again.Failures)
+	require.NoError(t, q.Ack(again.ID))
+	assert.Equal(t, storage.Value("second"), dequeueAck(t, q))
+
+	// an element enqueued again after it was acknowledged starts without failures
+	require.NoError(t, q.Enqueue(storage.Key("first"), storage.Value("first"), 0))
+	first, err = q.Dequeue(leaseTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), first.Failures)
+	require.NoError(t, q.Ack(first.ID))
+
+	_, err = q.Dequeue(leaseTimeout)
+	assert.Equal(t, storage.ErrEmptyQueue, err)
+}
+
+func testDelay(t *testing.T, q storage.Queue) {
+	require.NoError(t, q.Enqueue(storage.Key("failing"), storage.Value("failing"), 0))
+	require.NoError(t, q.Enqueue(storage.Key("next"), storage.Value("next"), 0))
+
+	// an element released with a delay is hidden until the delay passed
+	failing, err := q.Dequeue(leaseTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, storage.Value("failing"), failing.Value)
+	require.NoError(t, q.Nack(failing.ID, 100*time.Millisecond))
+	assert.Equal(t, storage.ErrLeaseExpired, q.Ack(failing.ID))
+
+	// and isn't added again meanwhile
+	require.NoError(t, q.Enqueue(storage.Key("failing"), storage.Value("again"), -1))
+	assert.Equal(t, storage.Value("next"), dequeueAck(t, q))
+	_, err = q.Dequeue(leaseTimeout)
+	assert.Equal(t, storage.ErrEmptyQueue, err)
+
+	time.Sleep(200 * time.Millisecond)
+	failing, err = q.Dequeue(leaseTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, storage.Value("failing"), failing.Value)
+	assert.Equal(t, int64(1), failing.Failures)
+	require.NoError(t, q.Ack(failing.ID))
+
+	_, err = q.Dequeue(leaseTimeout)
+	assert.Equal(t, storage.ErrEmptyQueue, err)
+}
+
+func testTimeout(t *testing.T, q storage.Queue) {
+	require.NoError(t, q.Enqueue(storage.Key("crashed"), storage.Value("crashed"), 0))
+	require.NoError(t, q.Enqueue(storage.Key("next"), storage.Value("next"), 0))
+
+	// an element whose consumer didn't acknowledge it in time is dequeued again first
+	crashed, err := q.Dequeue(50 * time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(200 * time.Millisecond)
+
+	again, err := q.Dequeue(leaseTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, storage.Value("crashed"), again.Value)
+	assert.NotEqual(t, crashed.ID, again.ID)
+
+	// the expired lease can't remove the element from its new consumer
+	assert.Equal(t, storage.ErrLeaseExpired, q.Ack(crashed.ID))
+	assert.NoError(t, q.Ack(again.ID))
+
+	assert.Equal(t, storage.Value("next"), dequeueAck(t, q))
+	_, err = q.Dequeue(leaseTimeout)
+	assert.Equal(t, storage.ErrEmptyQueue, err)
+}
+
+func testPriority(t *testing.T, q storage.Queue) {
+	for _, item := range []struct {
+		key      string
+		priority int64
+	}{
+		{"third", 2}, {"first", -1}, {"fourth", 2}, {"second", 0},
+	} {
+		require.NoError(t, q.Enqueue(storage.Key(item.key), storage.Value(item.key), item.priority))
+	}
+
+	// elements are peeked and dequeued by priority, then in FIFO order
+	list, err := q.Peekqueue(100)
+	require.NoError(t, err)
+	assert.Equal(t, []storage.Value{
+		storage.Value("first"), storage.Value("second"), storage.Value("third"), storage.Value("fourth"),
+	}, list)
+
+	// every failure lowers the priority of a released element by one
+	first, err := q.Dequeue(leaseTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, storage.Value("first"), first.Value)
+	require.NoError(t, q.Nack(first.ID, 0))
+	list, err = q.Peekqueue(100)
+	require.NoError(t, err)
+	assert.Equal(t, []storage.Value{
+		storage.Value("first"), storage.Value("second"), storage.Value("third"), storage.Value("fourth"),
+	}, list)
+
+	first, err = q.Dequeue(leaseTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, storage.Value("first"), first.Value)
+	require.NoError(t, q.Nack(first.ID, 0))
+	list, err = q.Peekqueue(100)
+	require.NoError(t, err)
+	assert.Equal(t, []storage.Value{
+		storage.Value("second"), storage.Value("first"), storage.Value("third"), storage.Value("fourth"),
+	}, list)
+
+	// and isn't undone by enqueueing the element again
+	require.NoError(t, q.Enqueue(storage.Key("first"), storage.Value("first"), -1))
+	assert.Equal(t, storage.Value("second"), dequeueAck(t, q))
+	assert.Equal(t, storage.Value("first"), dequeueAck(t, q))
+	assert.Equal(t, storage.Value("third"), dequeueAck(t, q))
+	assert.Equal(t, storage.Value("fourth"), dequeueAck(t, q))
+	_, err = q.Dequeue(leaseTimeout)
+	assert.Equal(t, storage.ErrEmptyQueue, err)
+}
+
+func testDuplicates(t *testing.T, q storage.Queue) {
+	require.NoError(t, q.Enqueue(storage.Key("a"), storage.Value("a1"), 5))
+	require.NoError(t, q.Enqueue(storage.Key("b"), storage.Value("b"), 3))
+
+	// a queued element isn't added again, it gets the new value and the more urgent priority
+	require.NoError(t, q.Enqueue(storage.Key("a"), storage.Value("a2"), 1))
+	require.NoError(t, q.Enqueue(storage.Key("a"), storage.Value("a3"), 4))
+	list, err := q.Peekqueue(100)
+	require.NoError(t, err)
+	assert.Equal(t, []storage.Value{storage.Value("a3"), storage.Value("b")}, list)
+
+	// a leased element isn't added again either
+	a, err := q.Dequeue(leaseTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, storage.Value("a3"), a.Value)
+	require.NoError(t, q.Enqueue(storage.Key("a"), storage.Value("a4"), 0))
+	list, err = q.Peekqueue(100)
+	require.NoError(t, err)
+	assert.Equal(t, []storage.Value{storage.Value("b")}, list)
+
+	// an acknowledged element can be added again
+	require.NoError(t, q.Ack(a.ID))
+	require.NoError(t, q.Enqueue(storage.Key("a"), storage.Value("a5"), 4))
+	assert.Equal(t, storage.Value("b"), dequeueAck(t, q))
+	assert.Equal(t, storage.Value("a5"), dequeueAck(t, q))
+	_, err = q.Dequeue(leaseTimeout)
+	assert.Equal(t, storage.ErrEmptyQueue, err)
 }